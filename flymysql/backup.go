@@ -0,0 +1,180 @@
+package flymysql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// BackupExtension is the extension of the gzipped logical backups.
+const BackupExtension = ".sql.gz"
+
+// backupTimeout is how long dumps and restores may take, in seconds.
+const backupTimeout = 3600
+
+// Backups are staged on the volume, which has room for them, only while they
+// are copied off or loaded.
+const stagingFile = volumePath + "/.backup" + BackupExtension
+
+const (
+	// readChunkSize is how much of a staged backup is read per exec call.
+	readChunkSize = 1 << 20
+	// writeChunkSize is how much of a backup is written per exec call. It
+	// is passed, base64 encoded, as an argument, which Linux caps at 128KiB.
+	writeChunkSize = 64 << 10
+)
+
+var backupNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Backup is a logical backup stored in a bucket.
+type Backup struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewBackupName returns the name of a backup made at t.
+func NewBackupName(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// ValidateBackupName reports an error when name can't name a backup.
+func ValidateBackupName(name string) error {
+	if !backupNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid backup name %q", name)
+	}
+	return nil
+}
+
+// BackupKey returns the key the backup name of app is stored at.
+func BackupKey(app, name string) string {
+	return path.Join("mysql", app, name+BackupExtension)
+}
+
+// BackupPrefix returns the prefix of the keys of the backups of app.
+func BackupPrefix(app string) string {
+	return path.Join("mysql", app) + "/"
+}
+
+// BackupObject is an object listed under BackupPrefix.
+type BackupObject struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// BackupsFromObjects returns the backups among objects, newest first.
+func BackupsFromObjects(objects []BackupObject) []Backup {
+	var backups []Backup
+	for _, obj := range objects {
+		name, ok := strings.CutSuffix(path.Base(obj.Key), BackupExtension)
+		if !ok || ValidateBackupName(name) != nil {
+			continue
+		}
+		backups = append(backups, Backup{
+			Name:      name,
+			Size:      obj.Size,
+			CreatedAt: obj.LastModified,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups
+}
+
+// CreateBackup dumps every database of m and writes the gzipped dump to w.
+// The dump is consistent for InnoDB tables.
+func (c *Client) CreateBackup(ctx context.Context, m *fly.Machine, w io.Writer) error {
+	defer c.removeStaging(ctx, m)
+
+	// sh may not support pipefail, so a failed dump is flagged in a file.
+	_, err := c.Run(ctx, m, fmt.Sprintf(
+		`rm -f %[1]s.failed; { MYSQL_PWD="$MYSQL_ROOT_PASSWORD" %[2]s -uroot --single-transaction --all-databases --routines --events --triggers || touch %[1]s.failed; } | gzip > %[1]s && test ! -e %[1]s.failed`,
+		stagingFile, dumpBinary(Flavor(m)),
+	), backupTimeout)
+	if err != nil {
+		return err
+	}
+
+	return c.readFile(ctx, m, stagingFile, w)
+}
+
+// RestoreBackup loads the gzipped dump read from r into m.
+func (c *Client) RestoreBackup(ctx context.Context, m *fly.Machine, r io.Reader) error {
+	defer c.removeStaging(ctx, m)
+
+	if err := c.writeFile(ctx, m, stagingFile, r); err != nil {
+		return err
+	}
+
+	_, err := c.Run(ctx, m, fmt.Sprintf(
+		`gunzip -c %s | MYSQL_PWD="$MYSQL_ROOT_PASSWORD" %s -uroot`,
+		stagingFile, clientBinary(Flavor(m)),
+	), backupTimeout)
+	return err
+}
+
+func (c *Client) removeStaging(ctx context.Context, m *fly.Machine) {
+	_, _ = c.Run(context.WithoutCancel(ctx), m, fmt.Sprintf("rm -f %[1]s %[1]s.failed", stagingFile), 30)
+}
+
+// readFile copies file on m to w, a chunk per exec call.
+func (c *Client) readFile(ctx context.Context, m *fly.Machine, file string, w io.Writer) error {
+	for chunk := 0; ; chunk++ {
+		out, err := c.Run(ctx, m, fmt.Sprintf(
+			`dd if=%s bs=%d skip=%d count=1 2>/dev/null | base64 -w0`,
+			file, readChunkSize, chunk,
+		), 60)
+		if err != nil {
+			return err
+		}
+
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(out))
+		if err != nil {
+			return fmt.Errorf("failed to read %s on machine %s: %w", file, m.ID, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		if len(data) < readChunkSize {
+			return nil
+		}
+	}
+}
+
+// writeFile copies r to file on m, a chunk per exec call.
+func (c *Client) writeFile(ctx context.Context, m *fly.Machine, file string, r io.Reader) error {
+	if _, err := c.Run(ctx, m, fmt.Sprintf("rm -f %s && touch %s", file, file), 30); err != nil {
+		return err
+	}
+
+	buf := make([]byte, writeChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if _, err := c.Run(ctx, m, fmt.Sprintf(
+				"echo %s | base64 -d >> %s",
+				base64.StdEncoding.EncodeToString(buf[:n]), file,
+			), 60); err != nil {
+				return err
+			}
+		}
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package flymysql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+var (
+	ddRegexp    = regexp.MustCompile(`^dd if=(\S+) bs=(\d+) skip=(\d+) count=1 `)
+	appendRegex = regexp.MustCompile(`^echo (\S+) \| base64 -d >> (\S+)$`)
+)
+
+// fakeMachine runs the file operations of backups on an in-memory file
+// system, and records the other scripts.
+type fakeMachine struct {
+	files   map[string][]byte
+	dump    []byte
+	scripts []string
+}
+
+func (f *fakeMachine) Exec(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error) {
+	script := strings.TrimSuffix(strings.TrimPrefix(in.Cmd, "sh -c '"), "'")
+
+	if m := ddRegexp.FindStringSubmatch(script); m != nil {
+		var bs, skip int
+		fmt.Sscan(m[2], &bs)
+		fmt.Sscan(m[3], &skip)
+		data := f.files[m[1]]
+		start := min(bs*skip, len(data))
+		end := min(start+bs, len(data))
+		return &fly.MachineExecResponse{StdOut: base64.StdEncoding.EncodeToString(data[start:end])}, nil
+	}
+	if m := appendRegex.FindStringSubmatch(script); m != nil {
+		data, err := base64.StdEncoding.DecodeString(m[1])
+		if err != nil {
+			return nil, err
+		}
+		f.files[m[2]] = append(f.files[m[2]], data...)
+		return &fly.MachineExecResponse{}, nil
+	}
+
+	f.scripts = append(f.scripts, script)
+	switch {
+	case strings.Contains(script, "| gzip > "+stagingFile):
+		f.files[stagingFile] = f.dump
+	case strings.HasPrefix(script, "rm -f "+stagingFile):
+		delete(f.files, stagingFile)
+	}
+	return &fly.MachineExecResponse{}, nil
+}
+
+func randomBytes(n int) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(1)).Read(data)
+	return data
+}
+
+func TestCreateBackup(t *testing.T) {
+	for _, size := range []int{0, 10, readChunkSize, 2*readChunkSize + 5} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			fake := &fakeMachine{files: map[string][]byte{}, dump: randomBytes(size)}
+
+			var buf bytes.Buffer
+			require.NoError(t, NewClient(fake).CreateBackup(context.Background(), machine("a", RolePrimary), &buf))
+
+			assert.True(t, bytes.Equal(fake.dump, buf.Bytes()))
+			assert.NotContains(t, fake.files, stagingFile, "the staged dump is removed")
+		})
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	fake := &fakeMachine{files: map[string][]byte{}}
+	dump := randomBytes(2*writeChunkSize + 5)
+
+	var restored []byte
+	client := NewClient(executorFunc(func(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error) {
+		if strings.Contains(in.Cmd, "gunzip -c "+stagingFile) {
+			restored = append([]byte(nil), fake.files[stagingFile]...)
+		}
+		return fake.Exec(ctx, machineID, in)
+	}))
+	require.NoError(t, client.RestoreBackup(context.Background(), machine("a", RolePrimary), bytes.NewReader(dump)))
+
+	assert.Equal(t, dump, restored)
+	assert.NotContains(t, fake.files, stagingFile, "the staged dump is removed")
+}
+
+type executorFunc func(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error)
+
+func (f executorFunc) Exec(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error) {
+	return f(ctx, machineID, in)
+}
+
+func TestBackupsFromObjects(t *testing.T) {
+	backups := BackupsFromObjects([]BackupObject{
+		{Key: BackupKey("db", "old"), Size: 10, LastModified: time.Unix(1000, 0)},
+		{Key: "mysql/db/notes.txt", Size: 1, LastModified: time.Unix(3000, 0)},
+		{Key: BackupKey("db", "new"), Size: 20, LastModified: time.Unix(2000, 0)},
+	})
+	assert.Equal(t, []Backup{
+		{Name: "new", Size: 20, CreatedAt: time.Unix(2000, 0)},
+		{Name: "old", Size: 10, CreatedAt: time.Unix(1000, 0)},
+	}, backups)
+
+	assert.Equal(t, "mysql/db/nightly.sql.gz", BackupKey("db", "nightly"))
+	assert.Equal(t, "mysql/db/", BackupPrefix("db"))
+	assert.Error(t, ValidateBackupName("../etc/passwd"))
+	assert.NoError(t, ValidateBackupName("nightly"))
+}
@@ -0,0 +1,130 @@
+package flymysql
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// replicationPasswordPlaceholder is replaced, on the machine, by the
+// replication password in the statements ran, so that it never leaves the
+// machine's secrets.
+const replicationPasswordPlaceholder = "@@REPLICATION_PASSWORD@@"
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z0-9_]{1,64}$`)
+
+// ValidateIdentifier reports an error when name can't be used unquoted as the
+// name of a database or user.
+func ValidateIdentifier(name string) error {
+	if !identifierRegexp.MatchString(name) {
+		return fmt.Errorf("invalid name %q, use up to 64 letters, digits and underscores", name)
+	}
+	return nil
+}
+
+// Identifier returns name, such as the name of an app, turned into a valid
+// database or user name.
+func Identifier(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
+
+// Executor runs commands on machines, as *flaps.Client does.
+type Executor interface {
+	Exec(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error)
+}
+
+// Client runs statements and commands on the machines of a MySQL app, as
+// root, through the machines API.
+type Client struct {
+	flaps Executor
+}
+
+func NewClient(flapsClient Executor) *Client {
+	return &Client{flaps: flapsClient}
+}
+
+// Query runs statements on m and returns the rows of the output of the last
+// one, as tab separated columns.
+func (c *Client) Query(ctx context.Context, m *fly.Machine, statements ...string) ([][]string, error) {
+	sql := strings.Join(statements, ";\n") + ";"
+	script := fmt.Sprintf(
+		`echo %s | base64 -d | sed "s/%s/$MARIADB_REPLICATION_PASSWORD/g" | MYSQL_PWD="$MYSQL_ROOT_PASSWORD" %s -uroot --batch --skip-column-names`,
+		base64.StdEncoding.EncodeToString([]byte(sql)), replicationPasswordPlaceholder, clientBinary(Flavor(m)),
+	)
+
+	out, err := c.Run(ctx, m, script, 60)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if line != "" {
+			rows = append(rows, strings.Split(line, "\t"))
+		}
+	}
+	return rows, nil
+}
+
+// Run runs the shell script on m and returns its output. script can't hold
+// single quotes.
+func (c *Client) Run(ctx context.Context, m *fly.Machine, script string, timeout int) (string, error) {
+	if strings.Contains(script, "'") {
+		return "", fmt.Errorf("script holds a single quote")
+	}
+
+	out, err := c.flaps.Exec(ctx, m.ID, &fly.MachineExecRequest{
+		Cmd:     fmt.Sprintf("sh -c '%s'", script),
+		Timeout: timeout,
+	})
+	if err != nil {
+		return "", err
+	}
+	if out.ExitCode != 0 {
+		return "", fmt.Errorf("failed on machine %s: %s", m.ID, strings.TrimSpace(out.StdErr))
+	}
+	return out.StdOut, nil
+}
+
+// DatabaseExists reports whether database exists on m.
+func (c *Client) DatabaseExists(ctx context.Context, m *fly.Machine, database string) (bool, error) {
+	rows, err := c.Query(ctx, m, fmt.Sprintf("SHOW DATABASES LIKE %q", database))
+	return len(rows) > 0, err
+}
+
+// UserExists reports whether user exists on m.
+func (c *Client) UserExists(ctx context.Context, m *fly.Machine, user string) (bool, error) {
+	rows, err := c.Query(ctx, m, fmt.Sprintf("SELECT User FROM mysql.user WHERE User = %q", user))
+	return len(rows) > 0, err
+}
+
+// CreateDatabase creates database on m, unless it exists.
+func (c *Client) CreateDatabase(ctx context.Context, m *fly.Machine, database string) error {
+	_, err := c.Query(ctx, m, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS `%s`", database))
+	return err
+}
+
+// DropDatabase drops database on m.
+func (c *Client) DropDatabase(ctx context.Context, m *fly.Machine, database string) error {
+	_, err := c.Query(ctx, m, fmt.Sprintf("DROP DATABASE IF EXISTS `%s`", database))
+	return err
+}
+
+// CreateUser creates user on m, granted every privilege on database.
+func (c *Client) CreateUser(ctx context.Context, m *fly.Machine, user, password, database string) error {
+	_, err := c.Query(ctx, m,
+		fmt.Sprintf("CREATE USER %q@'%%' IDENTIFIED BY %q", user, password),
+		fmt.Sprintf("GRANT ALL PRIVILEGES ON `%s`.* TO %q@'%%'", database, user),
+	)
+	return err
+}
+
+// DropUser drops user on m.
+func (c *Client) DropUser(ctx context.Context, m *fly.Machine, user string) error {
+	_, err := c.Query(ctx, m, fmt.Sprintf("DROP USER IF EXISTS %q@'%%'", user))
+	return err
+}
@@ -0,0 +1,221 @@
+package flymysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/watch"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+type Launcher struct {
+	client *fly.Client
+}
+
+type CreateClusterInput struct {
+	AppName            string
+	Organization       *fly.Organization
+	Region             string
+	Flavor             string
+	ImageRef           string
+	InitialClusterSize int
+	VolumeSize         *int
+	VMSize             *fly.VMSize
+	SnapshotID         *string
+}
+
+func NewLauncher(client *fly.Client) *Launcher {
+	return &Launcher{
+		client: client,
+	}
+}
+
+// LaunchMachinesMySQL launches a primary and, for MariaDB, replicas of it.
+func (l *Launcher) LaunchMachinesMySQL(ctx context.Context, config *CreateClusterInput, detach bool) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+	)
+
+	if config.InitialClusterSize > 1 && !SupportsReplicas(config.Flavor) {
+		return fmt.Errorf("replicas require the %s flavor, use an initial cluster size of 1 with %s", FlavorMariaDB, config.Flavor)
+	}
+	if config.ImageRef == "" {
+		config.ImageRef = fmt.Sprintf("%s:%s", config.Flavor, DefaultVersions[config.Flavor])
+	}
+
+	app, err := l.createApp(ctx, config)
+	if err != nil {
+		return err
+	}
+	config.AppName = app.Name
+
+	addr, err := l.client.AllocateIPAddress(ctx, config.AppName, "private_v6", config.Region, config.Organization, "")
+	if err != nil {
+		return err
+	}
+
+	secrets, err := l.setSecrets(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppCompact: app,
+		AppName:    app.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		nodes       []*fly.Machine
+		primaryHost string
+	)
+	for i := 0; i < config.InitialClusterSize; i++ {
+		machineConf := machineConfig(config, i+1, primaryHost)
+
+		// replicas copy the primary rather than the snapshot
+		snapshot := config.SnapshotID
+		verb := "Provisioning"
+		if snapshot != nil && i == 0 {
+			verb = "Restoring"
+		} else {
+			snapshot = nil
+		}
+
+		fmt.Fprintf(io.Out, "%s %d of %d machines with image %s\n", verb, i+1, config.InitialClusterSize, machineConf.Image)
+
+		vol, err := flapsClient.CreateVolume(ctx, fly.CreateVolumeRequest{
+			Name:                volumeName,
+			Region:              config.Region,
+			SizeGb:              config.VolumeSize,
+			Encrypted:           fly.Pointer(true),
+			RequireUniqueZone:   fly.Pointer(true),
+			SnapshotID:          snapshot,
+			ComputeRequirements: machineConf.Guest,
+			ComputeImage:        machineConf.Image,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create volume: %w", err)
+		}
+
+		machineConf.Mounts = append(machineConf.Mounts, fly.MachineMount{
+			Volume: vol.ID,
+			Path:   volumePath,
+		})
+
+		machine, err := flapsClient.Launch(ctx, fly.LaunchMachineInput{
+			Region: config.Region,
+			Config: machineConf,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(io.Out, "Waiting for machine to start...\n")
+
+		waitTimeout := time.Minute * 5
+		if snapshot != nil {
+			waitTimeout = time.Hour
+		}
+		if err := mach.WaitForStartOrStop(ctx, machine, "start", waitTimeout); err != nil {
+			return err
+		}
+		nodes = append(nodes, machine)
+
+		if i == 0 {
+			primaryHost = Hostname(config.AppName, machine)
+		}
+
+		fmt.Fprintf(io.Out, "Machine %s is %s\n", machine.ID, machine.State)
+	}
+
+	if !detach {
+		fmt.Fprintln(io.Out, colorize.Green("==> "+"Monitoring health checks"))
+
+		if err := watch.MachinesChecks(ctx, nodes); err != nil {
+			return err
+		}
+		fmt.Fprintln(io.Out)
+	}
+
+	fmt.Fprintf(io.Out, "%s cluster %s created\n", config.Flavor, config.AppName)
+	fmt.Fprintf(io.Out, "  Username:    root\n")
+	fmt.Fprintf(io.Out, "  Password:    %s\n", secrets["MYSQL_ROOT_PASSWORD"])
+	fmt.Fprintf(io.Out, "  Hostname:    %s.flycast\n", config.AppName)
+	fmt.Fprintf(io.Out, "  Flycast:     %s\n", addr.Address)
+	fmt.Fprintf(io.Out, "  Port:        %d\n", Port)
+	fmt.Fprintf(io.Out, "  Connection string: %s\n", ConnectionString(config.AppName, "root", secrets["MYSQL_ROOT_PASSWORD"], ""))
+	fmt.Fprintln(io.Out, colorize.Italic("Save your credentials in a secure place -- you won't be able to see them again!"))
+
+	fmt.Fprintln(io.Out)
+	fmt.Fprintln(io.Out, colorize.Bold("Connect to "+config.Flavor))
+	fmt.Fprintf(io.Out, "Any app within the %s organization can connect using the above connection string,\n", config.Organization.Name)
+	fmt.Fprintf(io.Out, "or get its own database and user with 'fly mysql attach %s --app <app>'\n", config.AppName)
+
+	return nil
+}
+
+func (l *Launcher) createApp(ctx context.Context, config *CreateClusterInput) (*fly.AppCompact, error) {
+	fmt.Fprintln(iostreams.FromContext(ctx).Out, "Creating app...")
+	app, err := l.client.CreateApp(ctx, fly.CreateAppInput{
+		OrganizationID:  config.Organization.ID,
+		Name:            config.AppName,
+		PreferredRegion: &config.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: app.Name})
+	if err != nil {
+		return nil, err
+	} else if err := f.WaitForApp(ctx, app.Name); err != nil {
+		return nil, err
+	}
+
+	return &fly.AppCompact{
+		ID:       app.ID,
+		Name:     app.Name,
+		Status:   app.Status,
+		Deployed: app.Deployed,
+		Hostname: app.Hostname,
+		AppURL:   app.AppURL,
+		Organization: &fly.OrganizationBasic{
+			ID:   app.Organization.ID,
+			Slug: app.Organization.Slug,
+		},
+	}, nil
+}
+
+func (l *Launcher) setSecrets(ctx context.Context, config *CreateClusterInput) (map[string]string, error) {
+	out := iostreams.FromContext(ctx).Out
+
+	fmt.Fprintf(out, "Setting secrets on app %s...\n", config.AppName)
+
+	secrets := map[string]string{}
+	for _, name := range []string{"MYSQL_ROOT_PASSWORD", "MARIADB_REPLICATION_PASSWORD"} {
+		if name == "MARIADB_REPLICATION_PASSWORD" && !SupportsReplicas(config.Flavor) {
+			continue
+		}
+
+		password, err := helpers.RandString(15)
+		if err != nil {
+			return nil, err
+		}
+		secrets[name] = password
+	}
+
+	if _, err := l.client.SetSecrets(ctx, config.AppName, secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,240 @@
+// Package flymysql provisions and operates MySQL and MariaDB on machines,
+// using the official images. The primary and its replicas are told apart by
+// the role in their metadata; only the primary exposes the MySQL service, so
+// that flycast always routes clients to it.
+package flymysql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/buildinfo"
+)
+
+const (
+	FlavorMySQL   = "mysql"
+	FlavorMariaDB = "mariadb"
+
+	RolePrimary = "primary"
+	RoleReplica = "replica"
+
+	// MetadataKeyManaged marks the machines of MySQL apps.
+	MetadataKeyManaged = "fly-managed-mysql"
+	// MetadataKeyFlavor holds the flavor of the machine, see Flavors.
+	MetadataKeyFlavor = "fly-mysql-flavor"
+	// MetadataKeyRole holds the role of the machine, primary or replica.
+	MetadataKeyRole = "role"
+
+	Port = 3306
+
+	// ReplicationUser is the user replicas replicate from the primary as.
+	ReplicationUser = "replicator"
+
+	volumeName = "mysql_data"
+	volumePath = "/data"
+	dataDir    = volumePath + "/mysql"
+)
+
+// Flavors lists the databases which can be provisioned.
+var Flavors = []string{FlavorMySQL, FlavorMariaDB}
+
+// DefaultVersions are the image tags provisioned by default.
+var DefaultVersions = map[string]string{
+	FlavorMySQL:   "8.4",
+	FlavorMariaDB: "11.4",
+}
+
+// ValidateFlavor reports an error when flavor isn't one of Flavors.
+func ValidateFlavor(flavor string) error {
+	if !slices.Contains(Flavors, flavor) {
+		return fmt.Errorf("invalid flavor %q, must be one of %s", flavor, strings.Join(Flavors, ", "))
+	}
+	return nil
+}
+
+// SupportsReplicas reports whether replicas can be provisioned for flavor.
+// Only the MariaDB image configures replication on its own.
+func SupportsReplicas(flavor string) bool {
+	return flavor == FlavorMariaDB
+}
+
+// IsManaged reports whether m is a machine of a MySQL app.
+func IsManaged(m *fly.Machine) bool {
+	return m != nil && m.Config != nil && m.Config.Metadata[MetadataKeyManaged] == "true"
+}
+
+// Flavor returns the flavor of m.
+func Flavor(m *fly.Machine) string {
+	if m.Config == nil {
+		return ""
+	}
+	return m.Config.Metadata[MetadataKeyFlavor]
+}
+
+// Role returns the role of m, primary or replica.
+func Role(m *fly.Machine) string {
+	if m.Config == nil {
+		return ""
+	}
+	return m.Config.Metadata[MetadataKeyRole]
+}
+
+// Primary returns the primary of machines, along with the others.
+func Primary(machines []*fly.Machine) (*fly.Machine, []*fly.Machine, error) {
+	var (
+		primary  *fly.Machine
+		replicas []*fly.Machine
+	)
+	for _, m := range machines {
+		if !IsManaged(m) {
+			continue
+		}
+		if Role(m) == RolePrimary {
+			if primary != nil {
+				return nil, nil, fmt.Errorf("both machines %s and %s are primaries", primary.ID, m.ID)
+			}
+			primary = m
+		} else {
+			replicas = append(replicas, m)
+		}
+	}
+
+	if primary == nil {
+		return nil, nil, fmt.Errorf("no primary found")
+	}
+	return primary, replicas, nil
+}
+
+// Hostname returns the private hostname of machine m of app.
+func Hostname(app string, m *fly.Machine) string {
+	return fmt.Sprintf("%s.vm.%s.internal", m.ID, app)
+}
+
+// clientBinary returns the name of the command line client of flavor;
+// recent MariaDB images don't ship the mysql names anymore.
+func clientBinary(flavor string) string {
+	if flavor == FlavorMariaDB {
+		return "mariadb"
+	}
+	return "mysql"
+}
+
+func dumpBinary(flavor string) string {
+	if flavor == FlavorMariaDB {
+		return "mariadb-dump"
+	}
+	return "mysqldump"
+}
+
+func serverBinary(flavor string) string {
+	if flavor == FlavorMariaDB {
+		return "mariadbd"
+	}
+	return "mysqld"
+}
+
+// machineConfig returns the configuration of the machine serverID of a new
+// cluster. Replicas replicate from primaryHost.
+func machineConfig(input *CreateClusterInput, serverID int, primaryHost string) *fly.MachineConfig {
+	conf := &fly.MachineConfig{
+		Image: input.ImageRef,
+		Env: map[string]string{
+			"PRIMARY_REGION": input.Region,
+		},
+		Init: fly.MachineInit{
+			Cmd: []string{
+				serverBinary(input.Flavor),
+				"--datadir=" + dataDir,
+				"--server-id=" + strconv.Itoa(serverID),
+			},
+		},
+		Guest: &fly.MachineGuest{
+			CPUKind:  input.VMSize.CPUClass,
+			CPUs:     int(input.VMSize.CPUCores),
+			MemoryMB: input.VMSize.MemoryMB,
+		},
+		Checks: map[string]fly.MachineCheck{
+			"mysql": {
+				Port:     fly.Pointer(Port),
+				Type:     fly.Pointer("tcp"),
+				Interval: &fly.Duration{Duration: 15 * time.Second},
+				Timeout:  &fly.Duration{Duration: 10 * time.Second},
+			},
+		},
+		Metadata: map[string]string{
+			fly.MachineConfigMetadataKeyFlyctlVersion:      buildinfo.Version().String(),
+			fly.MachineConfigMetadataKeyFlyPlatformVersion: fly.MachineFlyPlatformVersion2,
+			MetadataKeyManaged:                             "true",
+			MetadataKeyFlavor:                              input.Flavor,
+		},
+		Restart: &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyAlways,
+		},
+	}
+
+	if SupportsReplicas(input.Flavor) {
+		// binary logs on every member let any replica be promoted
+		conf.Init.Cmd = append(conf.Init.Cmd, "--log-bin", "--log-basename="+input.AppName, "--log-slave-updates")
+		conf.Env["MARIADB_REPLICATION_USER"] = ReplicationUser
+	}
+
+	if primaryHost == "" {
+		SetRole(conf, RolePrimary)
+	} else {
+		conf.Env["MARIADB_MASTER_HOST"] = primaryHost
+		SetRole(conf, RoleReplica)
+	}
+
+	return conf
+}
+
+// SetRole updates conf for a machine taking role: only the primary serves
+// clients, and replicas are read only.
+func SetRole(conf *fly.MachineConfig, role string) {
+	conf.Metadata[MetadataKeyRole] = role
+
+	conf.Init.Cmd = slices.DeleteFunc(conf.Init.Cmd, func(arg string) bool {
+		return arg == "--read-only"
+	})
+
+	if role == RoleReplica {
+		conf.Services = nil
+		conf.Init.Cmd = append(conf.Init.Cmd, "--read-only")
+		return
+	}
+
+	delete(conf.Env, "MARIADB_MASTER_HOST")
+	conf.Services = []fly.MachineService{
+		{
+			Protocol:     "tcp",
+			InternalPort: Port,
+			Ports: []fly.MachinePort{
+				{Port: fly.Pointer(Port)},
+			},
+			Concurrency: &fly.MachineServiceConcurrency{
+				Type:      "connections",
+				HardLimit: 1000,
+				SoftLimit: 1000,
+			},
+		},
+	}
+}
+
+// ConnectionString returns the URL apps connect to database of app as user.
+func ConnectionString(app, user, password, database string) string {
+	return fmt.Sprintf("mysql://%s:%s@%s.flycast:%d/%s", user, password, app, Port, database)
+}
+
+// ConsoleCommand returns the command that opens the client console of m as
+// root, connected to database when it isn't empty.
+func ConsoleCommand(m *fly.Machine, database string) string {
+	args := clientBinary(Flavor(m)) + " -uroot"
+	if database != "" {
+		args += " " + database
+	}
+	return fmt.Sprintf(`sh -c 'MYSQL_PWD="$MYSQL_ROOT_PASSWORD" exec %s'`, args)
+}
@@ -0,0 +1,82 @@
+package flymysql
+
+import (
+	"context"
+	"fmt"
+
+	fly "github.com/superfly/fly-go"
+)
+
+// Fence makes the primary m read only, and returns its binary log position
+// for replicas to catch up to.
+func (c *Client) Fence(ctx context.Context, m *fly.Machine) (string, error) {
+	rows, err := c.Query(ctx, m, "SET GLOBAL read_only = 1", "SELECT @@GLOBAL.gtid_binlog_pos")
+	if err != nil {
+		return "", err
+	}
+	if len(rows) == 0 {
+		return "", nil
+	}
+	return rows[0][0], nil
+}
+
+// WaitForPosition waits for the replica m to apply the transactions up to the
+// binary log position pos of the primary.
+func (c *Client) WaitForPosition(ctx context.Context, m *fly.Machine, pos string, timeoutSeconds int) error {
+	if pos == "" {
+		return nil
+	}
+
+	rows, err := c.Query(ctx, m, fmt.Sprintf("SELECT MASTER_GTID_WAIT(%q, %d)", pos, timeoutSeconds))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 || rows[0][0] != "0" {
+		return fmt.Errorf("machine %s didn't catch up with the primary within %d seconds", m.ID, timeoutSeconds)
+	}
+	return nil
+}
+
+// Promote stops replication on the replica m and makes it writable.
+func (c *Client) Promote(ctx context.Context, m *fly.Machine) error {
+	_, err := c.Query(ctx, m, "STOP REPLICA", "RESET REPLICA ALL", "SET GLOBAL read_only = 0")
+	return err
+}
+
+// ReplicateFrom makes m a read only replica of the primary at primaryHost,
+// resuming from the transactions m already applied.
+func (c *Client) ReplicateFrom(ctx context.Context, m *fly.Machine, primaryHost string) error {
+	_, err := c.Query(ctx, m,
+		"SET GLOBAL read_only = 1",
+		"STOP REPLICA",
+		fmt.Sprintf(
+			"CHANGE MASTER TO MASTER_HOST = %q, MASTER_PORT = %d, MASTER_USER = %q, MASTER_PASSWORD = %q, MASTER_USE_GTID = current_pos",
+			primaryHost, Port, ReplicationUser, replicationPasswordPlaceholder,
+		),
+		"START REPLICA",
+	)
+	return err
+}
+
+// ReplicationLag returns how many seconds the replica m is behind its
+// primary, or -1 when it isn't replicating.
+func (c *Client) ReplicationLag(ctx context.Context, m *fly.Machine) (int, error) {
+	rows, err := c.Query(ctx, m, "SELECT IFNULL((SELECT VARIABLE_VALUE FROM information_schema.GLOBAL_STATUS WHERE VARIABLE_NAME = \"SLAVE_RUNNING\"), \"OFF\")")
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || rows[0][0] != "ON" {
+		return -1, nil
+	}
+
+	out, err := c.Run(ctx, m, fmt.Sprintf(`MYSQL_PWD="$MYSQL_ROOT_PASSWORD" %s -uroot -e "SHOW REPLICA STATUS\G" | sed -n "s/.*Seconds_Behind_Master: //p"`, clientBinary(Flavor(m))), 30)
+	if err != nil {
+		return 0, err
+	}
+
+	var lag int
+	if _, err := fmt.Sscan(out, &lag); err != nil {
+		return -1, nil
+	}
+	return lag, nil
+}
@@ -555,6 +555,113 @@ type AppDataSecretsSecret struct {
 // GetName returns AppDataSecretsSecret.Name, and is useful for accessing the field via an interface.
 func (v *AppDataSecretsSecret) GetName() string { return v.Name }
 
+// AppReleasesUnprocessedApp includes the requested fields of the GraphQL type App.
+type AppReleasesUnprocessedApp struct {
+	// Individual releases for this application, without any config processing
+	ReleasesUnprocessed AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection `json:"releasesUnprocessed"`
+}
+
+// GetReleasesUnprocessed returns AppReleasesUnprocessedApp.ReleasesUnprocessed, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedApp) GetReleasesUnprocessed() AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection {
+	return v.ReleasesUnprocessed
+}
+
+// AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection includes the requested fields of the GraphQL type ReleaseUnprocessedConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for ReleaseUnprocessed.
+type AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection struct {
+	// A list of nodes.
+	Nodes []AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed `json:"nodes"`
+	// Information to aid in pagination.
+	PageInfo AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo `json:"pageInfo"`
+}
+
+// GetNodes returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection) GetNodes() []AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed {
+	return v.Nodes
+}
+
+// GetPageInfo returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnection) GetPageInfo() AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo {
+	return v.PageInfo
+}
+
+// AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed includes the requested fields of the GraphQL type ReleaseUnprocessed.
+type AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed struct {
+	// Unique ID
+	Id string `json:"id"`
+	// The version of the release
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Docker image URI
+	ImageRef         string      `json:"imageRef"`
+	ConfigDefinition interface{} `json:"configDefinition"`
+}
+
+// GetId returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed.Id, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) GetId() string {
+	return v.Id
+}
+
+// GetVersion returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed.Version, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) GetVersion() int {
+	return v.Version
+}
+
+// GetCreatedAt returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed.CreatedAt, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) GetCreatedAt() time.Time {
+	return v.CreatedAt
+}
+
+// GetImageRef returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed.ImageRef, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) GetImageRef() string {
+	return v.ImageRef
+}
+
+// GetConfigDefinition returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed.ConfigDefinition, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) GetConfigDefinition() interface{} {
+	return v.ConfigDefinition
+}
+
+// AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo struct {
+	// When paginating forwards, are there more items?
+	HasNextPage bool `json:"hasNextPage"`
+	// When paginating forwards, the cursor to continue.
+	EndCursor string `json:"endCursor"`
+}
+
+// GetHasNextPage returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// AppReleasesUnprocessedResponse is returned by AppReleasesUnprocessed on success.
+type AppReleasesUnprocessedResponse struct {
+	// Find an app by name
+	App AppReleasesUnprocessedApp `json:"app"`
+}
+
+// GetApp returns AppReleasesUnprocessedResponse.App, and is useful for accessing the field via an interface.
+func (v *AppReleasesUnprocessedResponse) GetApp() AppReleasesUnprocessedApp { return v.App }
+
+type BillingStatus string
+
+const (
+	BillingStatusCurrent        BillingStatus = "CURRENT"
+	BillingStatusPastDue        BillingStatus = "PAST_DUE"
+	BillingStatusSourceRequired BillingStatus = "SOURCE_REQUIRED"
+)
+
 type BuildFinalImageInput struct {
 	// Sha256 id of docker image
 	Id string `json:"id"`
@@ -1409,6 +1516,88 @@ type ExtensionProviderDataExcludedRegionsRegion struct {
 // GetCode returns ExtensionProviderDataExcludedRegionsRegion.Code, and is useful for accessing the field via an interface.
 func (v *ExtensionProviderDataExcludedRegionsRegion) GetCode() string { return v.Code }
 
+// ExtensionStatusData includes the GraphQL fields of AddOn requested by the fragment ExtensionStatusData.
+type ExtensionStatusData struct {
+	// The service name according to the provider
+	Name string `json:"name"`
+	// Status of the add-on
+	Status string `json:"status"`
+	// Optional error message when `status` is `error`
+	ErrorMessage string `json:"errorMessage"`
+	// Region where the primary instance is deployed
+	PrimaryRegion string `json:"primaryRegion"`
+	// The add-on plan
+	AddOnPlan ExtensionStatusDataAddOnPlan `json:"addOnPlan"`
+	// The add-on provider
+	AddOnProvider ExtensionStatusDataAddOnProvider `json:"addOnProvider"`
+	// An app associated with this add-on
+	App ExtensionStatusDataApp `json:"app"`
+	// Organization that owns this service
+	Organization ExtensionStatusDataOrganization `json:"organization"`
+}
+
+// GetName returns ExtensionStatusData.Name, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetName() string { return v.Name }
+
+// GetStatus returns ExtensionStatusData.Status, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetStatus() string { return v.Status }
+
+// GetErrorMessage returns ExtensionStatusData.ErrorMessage, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetErrorMessage() string { return v.ErrorMessage }
+
+// GetPrimaryRegion returns ExtensionStatusData.PrimaryRegion, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetPrimaryRegion() string { return v.PrimaryRegion }
+
+// GetAddOnPlan returns ExtensionStatusData.AddOnPlan, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetAddOnPlan() ExtensionStatusDataAddOnPlan { return v.AddOnPlan }
+
+// GetAddOnProvider returns ExtensionStatusData.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetAddOnProvider() ExtensionStatusDataAddOnProvider {
+	return v.AddOnProvider
+}
+
+// GetApp returns ExtensionStatusData.App, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetApp() ExtensionStatusDataApp { return v.App }
+
+// GetOrganization returns ExtensionStatusData.Organization, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusData) GetOrganization() ExtensionStatusDataOrganization {
+	return v.Organization
+}
+
+// ExtensionStatusDataAddOnPlan includes the requested fields of the GraphQL type AddOnPlan.
+type ExtensionStatusDataAddOnPlan struct {
+	DisplayName string `json:"displayName"`
+}
+
+// GetDisplayName returns ExtensionStatusDataAddOnPlan.DisplayName, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusDataAddOnPlan) GetDisplayName() string { return v.DisplayName }
+
+// ExtensionStatusDataAddOnProvider includes the requested fields of the GraphQL type AddOnProvider.
+type ExtensionStatusDataAddOnProvider struct {
+	DisplayName string `json:"displayName"`
+}
+
+// GetDisplayName returns ExtensionStatusDataAddOnProvider.DisplayName, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusDataAddOnProvider) GetDisplayName() string { return v.DisplayName }
+
+// ExtensionStatusDataApp includes the requested fields of the GraphQL type App.
+type ExtensionStatusDataApp struct {
+	// The unique application name
+	Name string `json:"name"`
+}
+
+// GetName returns ExtensionStatusDataApp.Name, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusDataApp) GetName() string { return v.Name }
+
+// ExtensionStatusDataOrganization includes the requested fields of the GraphQL type Organization.
+type ExtensionStatusDataOrganization struct {
+	// Unique organization slug
+	Slug string `json:"slug"`
+}
+
+// GetSlug returns ExtensionStatusDataOrganization.Slug, and is useful for accessing the field via an interface.
+func (v *ExtensionStatusDataOrganization) GetSlug() string { return v.Slug }
+
 // Autogenerated input type of FinishBuild
 type FinishBuildInput struct {
 	// The name of the app being built
@@ -1541,6 +1730,8 @@ type GetAddOnAddOn struct {
 	AddOnData `json:"-"`
 	// Public URL for this service
 	PublicUrl string `json:"publicUrl"`
+	// Environment variables for the add-on
+	Environment interface{} `json:"environment"`
 	// Private flycast IP address of the add-on
 	PrivateIp string `json:"privateIp"`
 	// Password for the add-on
@@ -1568,6 +1759,9 @@ type GetAddOnAddOn struct {
 // GetPublicUrl returns GetAddOnAddOn.PublicUrl, and is useful for accessing the field via an interface.
 func (v *GetAddOnAddOn) GetPublicUrl() string { return v.PublicUrl }
 
+// GetEnvironment returns GetAddOnAddOn.Environment, and is useful for accessing the field via an interface.
+func (v *GetAddOnAddOn) GetEnvironment() interface{} { return v.Environment }
+
 // GetPrivateIp returns GetAddOnAddOn.PrivateIp, and is useful for accessing the field via an interface.
 func (v *GetAddOnAddOn) GetPrivateIp() string { return v.PrivateIp }
 
@@ -1641,6 +1835,8 @@ func (v *GetAddOnAddOn) UnmarshalJSON(b []byte) error {
 type __premarshalGetAddOnAddOn struct {
 	PublicUrl string `json:"publicUrl"`
 
+	Environment interface{} `json:"environment"`
+
 	PrivateIp string `json:"privateIp"`
 
 	Password string `json:"password"`
@@ -1684,6 +1880,7 @@ func (v *GetAddOnAddOn) __premarshalJSON() (*__premarshalGetAddOnAddOn, error) {
 	var retval __premarshalGetAddOnAddOn
 
 	retval.PublicUrl = v.PublicUrl
+	retval.Environment = v.Environment
 	retval.PrivateIp = v.PrivateIp
 	retval.Password = v.Password
 	retval.Status = v.Status
@@ -2178,6 +2375,159 @@ type GetAddOnResponse struct {
 // GetAddOn returns GetAddOnResponse.AddOn, and is useful for accessing the field via an interface.
 func (v *GetAddOnResponse) GetAddOn() GetAddOnAddOn { return v.AddOn }
 
+// GetAppAddOnsApp includes the requested fields of the GraphQL type App.
+type GetAppAddOnsApp struct {
+	AddOns GetAppAddOnsAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetAddOns returns GetAppAddOnsApp.AddOns, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsApp) GetAddOns() GetAppAddOnsAppAddOnsAddOnConnection { return v.AddOns }
+
+// GetAppAddOnsAppAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type GetAppAddOnsAppAddOnsAddOnConnection struct {
+	// A list of nodes.
+	Nodes []GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+	// Information to aid in pagination.
+	PageInfo GetAppAddOnsAppAddOnsAddOnConnectionPageInfo `json:"pageInfo"`
+}
+
+// GetNodes returns GetAppAddOnsAppAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnection) GetNodes() []GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// GetPageInfo returns GetAppAddOnsAppAddOnsAddOnConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnection) GetPageInfo() GetAppAddOnsAppAddOnsAddOnConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn struct {
+	AddOnData `json:"-"`
+}
+
+// GetId returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.Id, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetId() string { return v.AddOnData.Id }
+
+// GetName returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.Name, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetName() string { return v.AddOnData.Name }
+
+// GetPrimaryRegion returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.PrimaryRegion, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetPrimaryRegion() string {
+	return v.AddOnData.PrimaryRegion
+}
+
+// GetStatus returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.Status, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetStatus() string {
+	return v.AddOnData.Status
+}
+
+// GetErrorMessage returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.ErrorMessage, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetErrorMessage() string {
+	return v.AddOnData.ErrorMessage
+}
+
+// GetMetadata returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.Metadata, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetMetadata() interface{} {
+	return v.AddOnData.Metadata
+}
+
+// GetOptions returns GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn.Options, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) GetOptions() interface{} {
+	return v.AddOnData.Options
+}
+
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.AddOnData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn struct {
+	Id string `json:"id"`
+
+	Name string `json:"name"`
+
+	PrimaryRegion string `json:"primaryRegion"`
+
+	Status string `json:"status"`
+
+	ErrorMessage string `json:"errorMessage"`
+
+	Metadata interface{} `json:"metadata"`
+
+	Options interface{} `json:"options"`
+}
+
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn) __premarshalJSON() (*__premarshalGetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn, error) {
+	var retval __premarshalGetAppAddOnsAppAddOnsAddOnConnectionNodesAddOn
+
+	retval.Id = v.AddOnData.Id
+	retval.Name = v.AddOnData.Name
+	retval.PrimaryRegion = v.AddOnData.PrimaryRegion
+	retval.Status = v.AddOnData.Status
+	retval.ErrorMessage = v.AddOnData.ErrorMessage
+	retval.Metadata = v.AddOnData.Metadata
+	retval.Options = v.AddOnData.Options
+	return &retval, nil
+}
+
+// GetAppAddOnsAppAddOnsAddOnConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type GetAppAddOnsAppAddOnsAddOnConnectionPageInfo struct {
+	// When paginating forwards, are there more items?
+	HasNextPage bool `json:"hasNextPage"`
+	// When paginating forwards, the cursor to continue.
+	EndCursor string `json:"endCursor"`
+}
+
+// GetHasNextPage returns GetAppAddOnsAppAddOnsAddOnConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
+// GetEndCursor returns GetAppAddOnsAppAddOnsAddOnConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsAppAddOnsAddOnConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetAppAddOnsResponse is returned by GetAppAddOns on success.
+type GetAppAddOnsResponse struct {
+	// Find an app by name
+	App GetAppAddOnsApp `json:"app"`
+}
+
+// GetApp returns GetAppAddOnsResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppAddOnsResponse) GetApp() GetAppAddOnsApp { return v.App }
+
 // GetAppApp includes the requested fields of the GraphQL type App.
 type GetAppApp struct {
 	AppData `json:"-"`
@@ -2260,6 +2610,73 @@ func (v *GetAppApp) __premarshalJSON() (*__premarshalGetAppApp, error) {
 	return &retval, nil
 }
 
+// GetAppGrafanaOrgApp includes the requested fields of the GraphQL type App.
+type GetAppGrafanaOrgApp struct {
+	// Organization that owns this app
+	Organization GetAppGrafanaOrgAppOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetAppGrafanaOrgApp.Organization, and is useful for accessing the field via an interface.
+func (v *GetAppGrafanaOrgApp) GetOrganization() GetAppGrafanaOrgAppOrganization {
+	return v.Organization
+}
+
+// GetAppGrafanaOrgAppOrganization includes the requested fields of the GraphQL type Organization.
+type GetAppGrafanaOrgAppOrganization struct {
+	InternalNumericId int64 `json:"internalNumericId"`
+}
+
+// GetInternalNumericId returns GetAppGrafanaOrgAppOrganization.InternalNumericId, and is useful for accessing the field via an interface.
+func (v *GetAppGrafanaOrgAppOrganization) GetInternalNumericId() int64 { return v.InternalNumericId }
+
+// GetAppGrafanaOrgResponse is returned by GetAppGrafanaOrg on success.
+type GetAppGrafanaOrgResponse struct {
+	// Find an app by name
+	App GetAppGrafanaOrgApp `json:"app"`
+}
+
+// GetApp returns GetAppGrafanaOrgResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppGrafanaOrgResponse) GetApp() GetAppGrafanaOrgApp { return v.App }
+
+// GetAppOrganizationBillingApp includes the requested fields of the GraphQL type App.
+type GetAppOrganizationBillingApp struct {
+	// Organization that owns this app
+	Organization GetAppOrganizationBillingAppOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetAppOrganizationBillingApp.Organization, and is useful for accessing the field via an interface.
+func (v *GetAppOrganizationBillingApp) GetOrganization() GetAppOrganizationBillingAppOrganization {
+	return v.Organization
+}
+
+// GetAppOrganizationBillingAppOrganization includes the requested fields of the GraphQL type Organization.
+type GetAppOrganizationBillingAppOrganization struct {
+	// Unique organization slug
+	Slug          string            `json:"slug"`
+	BillingStatus BillingStatus     `json:"billingStatus"`
+	Trust         OrganizationTrust `json:"trust"`
+}
+
+// GetSlug returns GetAppOrganizationBillingAppOrganization.Slug, and is useful for accessing the field via an interface.
+func (v *GetAppOrganizationBillingAppOrganization) GetSlug() string { return v.Slug }
+
+// GetBillingStatus returns GetAppOrganizationBillingAppOrganization.BillingStatus, and is useful for accessing the field via an interface.
+func (v *GetAppOrganizationBillingAppOrganization) GetBillingStatus() BillingStatus {
+	return v.BillingStatus
+}
+
+// GetTrust returns GetAppOrganizationBillingAppOrganization.Trust, and is useful for accessing the field via an interface.
+func (v *GetAppOrganizationBillingAppOrganization) GetTrust() OrganizationTrust { return v.Trust }
+
+// GetAppOrganizationBillingResponse is returned by GetAppOrganizationBilling on success.
+type GetAppOrganizationBillingResponse struct {
+	// Find an app by name
+	App GetAppOrganizationBillingApp `json:"app"`
+}
+
+// GetApp returns GetAppOrganizationBillingResponse.App, and is useful for accessing the field via an interface.
+func (v *GetAppOrganizationBillingResponse) GetApp() GetAppOrganizationBillingApp { return v.App }
+
 // GetAppResponse is returned by GetApp on success.
 type GetAppResponse struct {
 	// Find an app by name
@@ -2614,41 +3031,349 @@ func (v *GetExtensionSsoLinkResponse) GetOrganization() GetExtensionSsoLinkOrgan
 	return v.Organization
 }
 
-// GetNearestRegionNearestRegion includes the requested fields of the GraphQL type Region.
-type GetNearestRegionNearestRegion struct {
-	// The IATA airport code for this region
-	Code string `json:"code"`
-	// The name of this region
-	Name             string `json:"name"`
-	GatewayAvailable bool   `json:"gatewayAvailable"`
+// GetIncidentScopeAppApp includes the requested fields of the GraphQL type App.
+type GetIncidentScopeAppApp struct {
+	IncidentScopeApp `json:"-"`
 }
 
-// GetCode returns GetNearestRegionNearestRegion.Code, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetCode() string { return v.Code }
-
-// GetName returns GetNearestRegionNearestRegion.Name, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetName() string { return v.Name }
-
-// GetGatewayAvailable returns GetNearestRegionNearestRegion.GatewayAvailable, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionNearestRegion) GetGatewayAvailable() bool { return v.GatewayAvailable }
+// GetRole returns GetIncidentScopeAppApp.Role, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppApp) GetRole() IncidentScopeAppRole { return v.IncidentScopeApp.Role }
 
-// GetNearestRegionResponse is returned by GetNearestRegion on success.
-type GetNearestRegionResponse struct {
-	NearestRegion GetNearestRegionNearestRegion `json:"nearestRegion"`
+// GetRegions returns GetIncidentScopeAppApp.Regions, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppApp) GetRegions() []IncidentScopeAppRegionsRegion {
+	return v.IncidentScopeApp.Regions
 }
 
-// GetNearestRegion returns GetNearestRegionResponse.NearestRegion, and is useful for accessing the field via an interface.
-func (v *GetNearestRegionResponse) GetNearestRegion() GetNearestRegionNearestRegion {
-	return v.NearestRegion
+// GetAddOns returns GetIncidentScopeAppApp.AddOns, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppApp) GetAddOns() IncidentScopeAppAddOnsAddOnConnection {
+	return v.IncidentScopeApp.AddOns
 }
 
-// GetOrganizationOrganization includes the requested fields of the GraphQL type Organization.
-type GetOrganizationOrganization struct {
-	OrganizationData `json:"-"`
-}
+func (v *GetIncidentScopeAppApp) UnmarshalJSON(b []byte) error {
 
-// GetId returns GetOrganizationOrganization.Id, and is useful for accessing the field via an interface.
-func (v *GetOrganizationOrganization) GetId() string { return v.OrganizationData.Id }
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetIncidentScopeAppApp
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetIncidentScopeAppApp = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.IncidentScopeApp)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetIncidentScopeAppApp struct {
+	Role json.RawMessage `json:"role"`
+
+	Regions []IncidentScopeAppRegionsRegion `json:"regions"`
+
+	AddOns IncidentScopeAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+func (v *GetIncidentScopeAppApp) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetIncidentScopeAppApp) __premarshalJSON() (*__premarshalGetIncidentScopeAppApp, error) {
+	var retval __premarshalGetIncidentScopeAppApp
+
+	{
+
+		dst := &retval.Role
+		src := v.IncidentScopeApp.Role
+		var err error
+		*dst, err = __marshalIncidentScopeAppRole(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetIncidentScopeAppApp.IncidentScopeApp.Role: %w", err)
+		}
+	}
+	retval.Regions = v.IncidentScopeApp.Regions
+	retval.AddOns = v.IncidentScopeApp.AddOns
+	return &retval, nil
+}
+
+// GetIncidentScopeAppResponse is returned by GetIncidentScopeApp on success.
+type GetIncidentScopeAppResponse struct {
+	// Find an app by name
+	App GetIncidentScopeAppApp `json:"app"`
+}
+
+// GetApp returns GetIncidentScopeAppResponse.App, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppResponse) GetApp() GetIncidentScopeAppApp { return v.App }
+
+// GetIncidentScopeAppsAppsAppConnection includes the requested fields of the GraphQL type AppConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for App.
+type GetIncidentScopeAppsAppsAppConnection struct {
+	// A list of nodes.
+	Nodes []GetIncidentScopeAppsAppsAppConnectionNodesApp `json:"nodes"`
+}
+
+// GetNodes returns GetIncidentScopeAppsAppsAppConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppsAppsAppConnection) GetNodes() []GetIncidentScopeAppsAppsAppConnectionNodesApp {
+	return v.Nodes
+}
+
+// GetIncidentScopeAppsAppsAppConnectionNodesApp includes the requested fields of the GraphQL type App.
+type GetIncidentScopeAppsAppsAppConnectionNodesApp struct {
+	IncidentScopeApp `json:"-"`
+}
+
+// GetRole returns GetIncidentScopeAppsAppsAppConnectionNodesApp.Role, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) GetRole() IncidentScopeAppRole {
+	return v.IncidentScopeApp.Role
+}
+
+// GetRegions returns GetIncidentScopeAppsAppsAppConnectionNodesApp.Regions, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) GetRegions() []IncidentScopeAppRegionsRegion {
+	return v.IncidentScopeApp.Regions
+}
+
+// GetAddOns returns GetIncidentScopeAppsAppsAppConnectionNodesApp.AddOns, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) GetAddOns() IncidentScopeAppAddOnsAddOnConnection {
+	return v.IncidentScopeApp.AddOns
+}
+
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetIncidentScopeAppsAppsAppConnectionNodesApp
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetIncidentScopeAppsAppsAppConnectionNodesApp = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.IncidentScopeApp)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetIncidentScopeAppsAppsAppConnectionNodesApp struct {
+	Role json.RawMessage `json:"role"`
+
+	Regions []IncidentScopeAppRegionsRegion `json:"regions"`
+
+	AddOns IncidentScopeAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetIncidentScopeAppsAppsAppConnectionNodesApp) __premarshalJSON() (*__premarshalGetIncidentScopeAppsAppsAppConnectionNodesApp, error) {
+	var retval __premarshalGetIncidentScopeAppsAppsAppConnectionNodesApp
+
+	{
+
+		dst := &retval.Role
+		src := v.IncidentScopeApp.Role
+		var err error
+		*dst, err = __marshalIncidentScopeAppRole(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetIncidentScopeAppsAppsAppConnectionNodesApp.IncidentScopeApp.Role: %w", err)
+		}
+	}
+	retval.Regions = v.IncidentScopeApp.Regions
+	retval.AddOns = v.IncidentScopeApp.AddOns
+	return &retval, nil
+}
+
+// GetIncidentScopeAppsResponse is returned by GetIncidentScopeApps on success.
+type GetIncidentScopeAppsResponse struct {
+	// List apps
+	Apps GetIncidentScopeAppsAppsAppConnection `json:"apps"`
+}
+
+// GetApps returns GetIncidentScopeAppsResponse.Apps, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeAppsResponse) GetApps() GetIncidentScopeAppsAppsAppConnection { return v.Apps }
+
+// GetIncidentScopeOrgAppsOrganization includes the requested fields of the GraphQL type Organization.
+type GetIncidentScopeOrgAppsOrganization struct {
+	Apps GetIncidentScopeOrgAppsOrganizationAppsAppConnection `json:"apps"`
+}
+
+// GetApps returns GetIncidentScopeOrgAppsOrganization.Apps, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsOrganization) GetApps() GetIncidentScopeOrgAppsOrganizationAppsAppConnection {
+	return v.Apps
+}
+
+// GetIncidentScopeOrgAppsOrganizationAppsAppConnection includes the requested fields of the GraphQL type AppConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for App.
+type GetIncidentScopeOrgAppsOrganizationAppsAppConnection struct {
+	// A list of nodes.
+	Nodes []GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp `json:"nodes"`
+}
+
+// GetNodes returns GetIncidentScopeOrgAppsOrganizationAppsAppConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnection) GetNodes() []GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp {
+	return v.Nodes
+}
+
+// GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp includes the requested fields of the GraphQL type App.
+type GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp struct {
+	IncidentScopeApp `json:"-"`
+}
+
+// GetRole returns GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp.Role, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) GetRole() IncidentScopeAppRole {
+	return v.IncidentScopeApp.Role
+}
+
+// GetRegions returns GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp.Regions, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) GetRegions() []IncidentScopeAppRegionsRegion {
+	return v.IncidentScopeApp.Regions
+}
+
+// GetAddOns returns GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp.AddOns, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) GetAddOns() IncidentScopeAppAddOnsAddOnConnection {
+	return v.IncidentScopeApp.AddOns
+}
+
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.IncidentScopeApp)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp struct {
+	Role json.RawMessage `json:"role"`
+
+	Regions []IncidentScopeAppRegionsRegion `json:"regions"`
+
+	AddOns IncidentScopeAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp) __premarshalJSON() (*__premarshalGetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp, error) {
+	var retval __premarshalGetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp
+
+	{
+
+		dst := &retval.Role
+		src := v.IncidentScopeApp.Role
+		var err error
+		*dst, err = __marshalIncidentScopeAppRole(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal GetIncidentScopeOrgAppsOrganizationAppsAppConnectionNodesApp.IncidentScopeApp.Role: %w", err)
+		}
+	}
+	retval.Regions = v.IncidentScopeApp.Regions
+	retval.AddOns = v.IncidentScopeApp.AddOns
+	return &retval, nil
+}
+
+// GetIncidentScopeOrgAppsResponse is returned by GetIncidentScopeOrgApps on success.
+type GetIncidentScopeOrgAppsResponse struct {
+	// Find an organization by ID
+	Organization GetIncidentScopeOrgAppsOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetIncidentScopeOrgAppsResponse.Organization, and is useful for accessing the field via an interface.
+func (v *GetIncidentScopeOrgAppsResponse) GetOrganization() GetIncidentScopeOrgAppsOrganization {
+	return v.Organization
+}
+
+// GetNearestRegionNearestRegion includes the requested fields of the GraphQL type Region.
+type GetNearestRegionNearestRegion struct {
+	// The IATA airport code for this region
+	Code string `json:"code"`
+	// The name of this region
+	Name             string `json:"name"`
+	GatewayAvailable bool   `json:"gatewayAvailable"`
+}
+
+// GetCode returns GetNearestRegionNearestRegion.Code, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetCode() string { return v.Code }
+
+// GetName returns GetNearestRegionNearestRegion.Name, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetName() string { return v.Name }
+
+// GetGatewayAvailable returns GetNearestRegionNearestRegion.GatewayAvailable, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionNearestRegion) GetGatewayAvailable() bool { return v.GatewayAvailable }
+
+// GetNearestRegionResponse is returned by GetNearestRegion on success.
+type GetNearestRegionResponse struct {
+	NearestRegion GetNearestRegionNearestRegion `json:"nearestRegion"`
+}
+
+// GetNearestRegion returns GetNearestRegionResponse.NearestRegion, and is useful for accessing the field via an interface.
+func (v *GetNearestRegionResponse) GetNearestRegion() GetNearestRegionNearestRegion {
+	return v.NearestRegion
+}
+
+// GetOrganizationOrganization includes the requested fields of the GraphQL type Organization.
+type GetOrganizationOrganization struct {
+	OrganizationData `json:"-"`
+}
+
+// GetId returns GetOrganizationOrganization.Id, and is useful for accessing the field via an interface.
+func (v *GetOrganizationOrganization) GetId() string { return v.OrganizationData.Id }
 
 // GetSlug returns GetOrganizationOrganization.Slug, and is useful for accessing the field via an interface.
 func (v *GetOrganizationOrganization) GetSlug() string { return v.OrganizationData.Slug }
@@ -2675,70 +3400,412 @@ func (v *GetOrganizationOrganization) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
-	var firstPass struct {
-		*GetOrganizationOrganization
-		graphql.NoUnmarshalJSON
+	var firstPass struct {
+		*GetOrganizationOrganization
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.GetOrganizationOrganization = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.OrganizationData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalGetOrganizationOrganization struct {
+	Id string `json:"id"`
+
+	Slug string `json:"slug"`
+
+	RawSlug string `json:"rawSlug"`
+
+	PaidPlan bool `json:"paidPlan"`
+
+	AddOnSsoLink string `json:"addOnSsoLink"`
+
+	ProvisionsBetaExtensions bool `json:"provisionsBetaExtensions"`
+}
+
+func (v *GetOrganizationOrganization) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *GetOrganizationOrganization) __premarshalJSON() (*__premarshalGetOrganizationOrganization, error) {
+	var retval __premarshalGetOrganizationOrganization
+
+	retval.Id = v.OrganizationData.Id
+	retval.Slug = v.OrganizationData.Slug
+	retval.RawSlug = v.OrganizationData.RawSlug
+	retval.PaidPlan = v.OrganizationData.PaidPlan
+	retval.AddOnSsoLink = v.OrganizationData.AddOnSsoLink
+	retval.ProvisionsBetaExtensions = v.OrganizationData.ProvisionsBetaExtensions
+	return &retval, nil
+}
+
+// GetOrganizationResponse is returned by GetOrganization on success.
+type GetOrganizationResponse struct {
+	// Find an organization by ID
+	Organization GetOrganizationOrganization `json:"organization"`
+}
+
+// GetOrganization returns GetOrganizationResponse.Organization, and is useful for accessing the field via an interface.
+func (v *GetOrganizationResponse) GetOrganization() GetOrganizationOrganization {
+	return v.Organization
+}
+
+// GetPlatformVMSizesPlatformFlyPlatform includes the requested fields of the GraphQL type FlyPlatform.
+type GetPlatformVMSizesPlatformFlyPlatform struct {
+	// Available VM sizes
+	VmSizes []GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize `json:"vmSizes"`
+}
+
+// GetVmSizes returns GetPlatformVMSizesPlatformFlyPlatform.VmSizes, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatform) GetVmSizes() []GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize {
+	return v.VmSizes
+}
+
+// GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize includes the requested fields of the GraphQL type VMSize.
+type GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize struct {
+	Name        string  `json:"name"`
+	CpuCores    float64 `json:"cpuCores"`
+	MemoryMb    int     `json:"memoryMb"`
+	MaxMemoryMb int     `json:"maxMemoryMb"`
+	PriceMonth  float64 `json:"priceMonth"`
+	PriceSecond float64 `json:"priceSecond"`
+}
+
+// GetName returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.Name, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetName() string { return v.Name }
+
+// GetCpuCores returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.CpuCores, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetCpuCores() float64 { return v.CpuCores }
+
+// GetMemoryMb returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.MemoryMb, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetMemoryMb() int { return v.MemoryMb }
+
+// GetMaxMemoryMb returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.MaxMemoryMb, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetMaxMemoryMb() int {
+	return v.MaxMemoryMb
+}
+
+// GetPriceMonth returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.PriceMonth, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetPriceMonth() float64 {
+	return v.PriceMonth
+}
+
+// GetPriceSecond returns GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize.PriceSecond, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesPlatformFlyPlatformVmSizesVMSize) GetPriceSecond() float64 {
+	return v.PriceSecond
+}
+
+// GetPlatformVMSizesResponse is returned by GetPlatformVMSizes on success.
+type GetPlatformVMSizesResponse struct {
+	// fly.io platform information
+	Platform GetPlatformVMSizesPlatformFlyPlatform `json:"platform"`
+}
+
+// GetPlatform returns GetPlatformVMSizesResponse.Platform, and is useful for accessing the field via an interface.
+func (v *GetPlatformVMSizesResponse) GetPlatform() GetPlatformVMSizesPlatformFlyPlatform {
+	return v.Platform
+}
+
+// IncidentScopeApp includes the GraphQL fields of App requested by the fragment IncidentScopeApp.
+type IncidentScopeApp struct {
+	Role    IncidentScopeAppRole                  `json:"-"`
+	Regions []IncidentScopeAppRegionsRegion       `json:"regions"`
+	AddOns  IncidentScopeAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetRole returns IncidentScopeApp.Role, and is useful for accessing the field via an interface.
+func (v *IncidentScopeApp) GetRole() IncidentScopeAppRole { return v.Role }
+
+// GetRegions returns IncidentScopeApp.Regions, and is useful for accessing the field via an interface.
+func (v *IncidentScopeApp) GetRegions() []IncidentScopeAppRegionsRegion { return v.Regions }
+
+// GetAddOns returns IncidentScopeApp.AddOns, and is useful for accessing the field via an interface.
+func (v *IncidentScopeApp) GetAddOns() IncidentScopeAppAddOnsAddOnConnection { return v.AddOns }
+
+func (v *IncidentScopeApp) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*IncidentScopeApp
+		Role json.RawMessage `json:"role"`
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.IncidentScopeApp = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	{
+		dst := &v.Role
+		src := firstPass.Role
+		if len(src) != 0 && string(src) != "null" {
+			err = __unmarshalIncidentScopeAppRole(
+				src, dst)
+			if err != nil {
+				return fmt.Errorf(
+					"unable to unmarshal IncidentScopeApp.Role: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+type __premarshalIncidentScopeApp struct {
+	Role json.RawMessage `json:"role"`
+
+	Regions []IncidentScopeAppRegionsRegion `json:"regions"`
+
+	AddOns IncidentScopeAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+func (v *IncidentScopeApp) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *IncidentScopeApp) __premarshalJSON() (*__premarshalIncidentScopeApp, error) {
+	var retval __premarshalIncidentScopeApp
+
+	{
+
+		dst := &retval.Role
+		src := v.Role
+		var err error
+		*dst, err = __marshalIncidentScopeAppRole(
+			&src)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"unable to marshal IncidentScopeApp.Role: %w", err)
+		}
+	}
+	retval.Regions = v.Regions
+	retval.AddOns = v.AddOns
+	return &retval, nil
+}
+
+// IncidentScopeAppAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type IncidentScopeAppAddOnsAddOnConnection struct {
+	// A list of nodes.
+	Nodes []IncidentScopeAppAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+}
+
+// GetNodes returns IncidentScopeAppAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppAddOnsAddOnConnection) GetNodes() []IncidentScopeAppAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// IncidentScopeAppAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type IncidentScopeAppAddOnsAddOnConnectionNodesAddOn struct {
+	// The add-on provider
+	AddOnProvider IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider `json:"addOnProvider"`
+}
+
+// GetAddOnProvider returns IncidentScopeAppAddOnsAddOnConnectionNodesAddOn.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppAddOnsAddOnConnectionNodesAddOn) GetAddOnProvider() IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider {
+	return v.AddOnProvider
+}
+
+// IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider includes the requested fields of the GraphQL type AddOnProvider.
+type IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider struct {
+	Name string `json:"name"`
+}
+
+// GetName returns IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider.Name, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppAddOnsAddOnConnectionNodesAddOnAddOnProvider) GetName() string {
+	return v.Name
+}
+
+// IncidentScopeAppRegionsRegion includes the requested fields of the GraphQL type Region.
+type IncidentScopeAppRegionsRegion struct {
+	// The IATA airport code for this region
+	Code string `json:"code"`
+}
+
+// GetCode returns IncidentScopeAppRegionsRegion.Code, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRegionsRegion) GetCode() string { return v.Code }
+
+// IncidentScopeAppRole includes the requested fields of the GraphQL interface AppRole.
+//
+// IncidentScopeAppRole is implemented by the following types:
+// IncidentScopeAppRoleEmptyAppRole
+// IncidentScopeAppRoleFlyctlMachineHostAppRole
+// IncidentScopeAppRolePostgresClusterAppRole
+// IncidentScopeAppRoleRemoteDockerBuilderAppRole
+type IncidentScopeAppRole interface {
+	implementsGraphQLInterfaceIncidentScopeAppRole()
+	// GetTypename returns the receiver's concrete GraphQL type-name (see interface doc for possible values).
+	GetTypename() string
+	// GetName returns the interface-field "name" from its implementation.
+	// The GraphQL interface field's documentation follows.
+	//
+	// The name of this role
+	GetName() string
+}
+
+func (v *IncidentScopeAppRoleEmptyAppRole) implementsGraphQLInterfaceIncidentScopeAppRole() {}
+func (v *IncidentScopeAppRoleFlyctlMachineHostAppRole) implementsGraphQLInterfaceIncidentScopeAppRole() {
+}
+func (v *IncidentScopeAppRolePostgresClusterAppRole) implementsGraphQLInterfaceIncidentScopeAppRole() {
+}
+func (v *IncidentScopeAppRoleRemoteDockerBuilderAppRole) implementsGraphQLInterfaceIncidentScopeAppRole() {
+}
+
+func __unmarshalIncidentScopeAppRole(b []byte, v *IncidentScopeAppRole) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var tn struct {
+		TypeName string `json:"__typename"`
 	}
-	firstPass.GetOrganizationOrganization = v
-
-	err := json.Unmarshal(b, &firstPass)
+	err := json.Unmarshal(b, &tn)
 	if err != nil {
 		return err
 	}
 
-	err = json.Unmarshal(
-		b, &v.OrganizationData)
-	if err != nil {
-		return err
+	switch tn.TypeName {
+	case "EmptyAppRole":
+		*v = new(IncidentScopeAppRoleEmptyAppRole)
+		return json.Unmarshal(b, *v)
+	case "FlyctlMachineHostAppRole":
+		*v = new(IncidentScopeAppRoleFlyctlMachineHostAppRole)
+		return json.Unmarshal(b, *v)
+	case "PostgresClusterAppRole":
+		*v = new(IncidentScopeAppRolePostgresClusterAppRole)
+		return json.Unmarshal(b, *v)
+	case "RemoteDockerBuilderAppRole":
+		*v = new(IncidentScopeAppRoleRemoteDockerBuilderAppRole)
+		return json.Unmarshal(b, *v)
+	case "":
+		return fmt.Errorf(
+			"response was missing AppRole.__typename")
+	default:
+		return fmt.Errorf(
+			`unexpected concrete type for IncidentScopeAppRole: "%v"`, tn.TypeName)
 	}
-	return nil
 }
 
-type __premarshalGetOrganizationOrganization struct {
-	Id string `json:"id"`
+func __marshalIncidentScopeAppRole(v *IncidentScopeAppRole) ([]byte, error) {
 
-	Slug string `json:"slug"`
+	var typename string
+	switch v := (*v).(type) {
+	case *IncidentScopeAppRoleEmptyAppRole:
+		typename = "EmptyAppRole"
 
-	RawSlug string `json:"rawSlug"`
+		result := struct {
+			TypeName string `json:"__typename"`
+			*IncidentScopeAppRoleEmptyAppRole
+		}{typename, v}
+		return json.Marshal(result)
+	case *IncidentScopeAppRoleFlyctlMachineHostAppRole:
+		typename = "FlyctlMachineHostAppRole"
 
-	PaidPlan bool `json:"paidPlan"`
+		result := struct {
+			TypeName string `json:"__typename"`
+			*IncidentScopeAppRoleFlyctlMachineHostAppRole
+		}{typename, v}
+		return json.Marshal(result)
+	case *IncidentScopeAppRolePostgresClusterAppRole:
+		typename = "PostgresClusterAppRole"
 
-	AddOnSsoLink string `json:"addOnSsoLink"`
+		result := struct {
+			TypeName string `json:"__typename"`
+			*IncidentScopeAppRolePostgresClusterAppRole
+		}{typename, v}
+		return json.Marshal(result)
+	case *IncidentScopeAppRoleRemoteDockerBuilderAppRole:
+		typename = "RemoteDockerBuilderAppRole"
 
-	ProvisionsBetaExtensions bool `json:"provisionsBetaExtensions"`
+		result := struct {
+			TypeName string `json:"__typename"`
+			*IncidentScopeAppRoleRemoteDockerBuilderAppRole
+		}{typename, v}
+		return json.Marshal(result)
+	case nil:
+		return []byte("null"), nil
+	default:
+		return nil, fmt.Errorf(
+			`unexpected concrete type for IncidentScopeAppRole: "%T"`, v)
+	}
 }
 
-func (v *GetOrganizationOrganization) MarshalJSON() ([]byte, error) {
-	premarshaled, err := v.__premarshalJSON()
-	if err != nil {
-		return nil, err
-	}
-	return json.Marshal(premarshaled)
+// IncidentScopeAppRoleEmptyAppRole includes the requested fields of the GraphQL type EmptyAppRole.
+type IncidentScopeAppRoleEmptyAppRole struct {
+	Typename string `json:"__typename"`
+	// The name of this role
+	Name string `json:"name"`
 }
 
-func (v *GetOrganizationOrganization) __premarshalJSON() (*__premarshalGetOrganizationOrganization, error) {
-	var retval __premarshalGetOrganizationOrganization
+// GetTypename returns IncidentScopeAppRoleEmptyAppRole.Typename, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleEmptyAppRole) GetTypename() string { return v.Typename }
 
-	retval.Id = v.OrganizationData.Id
-	retval.Slug = v.OrganizationData.Slug
-	retval.RawSlug = v.OrganizationData.RawSlug
-	retval.PaidPlan = v.OrganizationData.PaidPlan
-	retval.AddOnSsoLink = v.OrganizationData.AddOnSsoLink
-	retval.ProvisionsBetaExtensions = v.OrganizationData.ProvisionsBetaExtensions
-	return &retval, nil
+// GetName returns IncidentScopeAppRoleEmptyAppRole.Name, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleEmptyAppRole) GetName() string { return v.Name }
+
+// IncidentScopeAppRoleFlyctlMachineHostAppRole includes the requested fields of the GraphQL type FlyctlMachineHostAppRole.
+type IncidentScopeAppRoleFlyctlMachineHostAppRole struct {
+	Typename string `json:"__typename"`
+	// The name of this role
+	Name string `json:"name"`
 }
 
-// GetOrganizationResponse is returned by GetOrganization on success.
-type GetOrganizationResponse struct {
-	// Find an organization by ID
-	Organization GetOrganizationOrganization `json:"organization"`
+// GetTypename returns IncidentScopeAppRoleFlyctlMachineHostAppRole.Typename, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleFlyctlMachineHostAppRole) GetTypename() string { return v.Typename }
+
+// GetName returns IncidentScopeAppRoleFlyctlMachineHostAppRole.Name, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleFlyctlMachineHostAppRole) GetName() string { return v.Name }
+
+// IncidentScopeAppRolePostgresClusterAppRole includes the requested fields of the GraphQL type PostgresClusterAppRole.
+type IncidentScopeAppRolePostgresClusterAppRole struct {
+	Typename string `json:"__typename"`
+	// The name of this role
+	Name string `json:"name"`
 }
 
-// GetOrganization returns GetOrganizationResponse.Organization, and is useful for accessing the field via an interface.
-func (v *GetOrganizationResponse) GetOrganization() GetOrganizationOrganization {
-	return v.Organization
+// GetTypename returns IncidentScopeAppRolePostgresClusterAppRole.Typename, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRolePostgresClusterAppRole) GetTypename() string { return v.Typename }
+
+// GetName returns IncidentScopeAppRolePostgresClusterAppRole.Name, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRolePostgresClusterAppRole) GetName() string { return v.Name }
+
+// IncidentScopeAppRoleRemoteDockerBuilderAppRole includes the requested fields of the GraphQL type RemoteDockerBuilderAppRole.
+type IncidentScopeAppRoleRemoteDockerBuilderAppRole struct {
+	Typename string `json:"__typename"`
+	// The name of this role
+	Name string `json:"name"`
 }
 
+// GetTypename returns IncidentScopeAppRoleRemoteDockerBuilderAppRole.Typename, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleRemoteDockerBuilderAppRole) GetTypename() string { return v.Typename }
+
+// GetName returns IncidentScopeAppRoleRemoteDockerBuilderAppRole.Name, and is useful for accessing the field via an interface.
+func (v *IncidentScopeAppRoleRemoteDockerBuilderAppRole) GetName() string { return v.Name }
+
 // ListAddOnPlansAddOnPlansAddOnPlanConnection includes the requested fields of the GraphQL type AddOnPlanConnection.
 // The GraphQL type's documentation follows.
 //
@@ -2892,9 +3959,298 @@ type ListAddOnsResponse struct {
 	AddOns ListAddOnsAddOnsAddOnConnection `json:"addOns"`
 }
 
-// GetAddOns returns ListAddOnsResponse.AddOns, and is useful for accessing the field via an interface.
-func (v *ListAddOnsResponse) GetAddOns() ListAddOnsAddOnsAddOnConnection { return v.AddOns }
-
+// GetAddOns returns ListAddOnsResponse.AddOns, and is useful for accessing the field via an interface.
+func (v *ListAddOnsResponse) GetAddOns() ListAddOnsAddOnsAddOnConnection { return v.AddOns }
+
+// ListAppExtensionsStatusApp includes the requested fields of the GraphQL type App.
+type ListAppExtensionsStatusApp struct {
+	AddOns ListAppExtensionsStatusAppAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetAddOns returns ListAppExtensionsStatusApp.AddOns, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusApp) GetAddOns() ListAppExtensionsStatusAppAddOnsAddOnConnection {
+	return v.AddOns
+}
+
+// ListAppExtensionsStatusAppAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type ListAppExtensionsStatusAppAddOnsAddOnConnection struct {
+	// A list of nodes.
+	Nodes []ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+}
+
+// GetNodes returns ListAppExtensionsStatusAppAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnection) GetNodes() []ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn struct {
+	ExtensionStatusData `json:"-"`
+}
+
+// GetName returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.Name, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetName() string {
+	return v.ExtensionStatusData.Name
+}
+
+// GetStatus returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.Status, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetStatus() string {
+	return v.ExtensionStatusData.Status
+}
+
+// GetErrorMessage returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.ErrorMessage, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetErrorMessage() string {
+	return v.ExtensionStatusData.ErrorMessage
+}
+
+// GetPrimaryRegion returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.PrimaryRegion, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetPrimaryRegion() string {
+	return v.ExtensionStatusData.PrimaryRegion
+}
+
+// GetAddOnPlan returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.AddOnPlan, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetAddOnPlan() ExtensionStatusDataAddOnPlan {
+	return v.ExtensionStatusData.AddOnPlan
+}
+
+// GetAddOnProvider returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetAddOnProvider() ExtensionStatusDataAddOnProvider {
+	return v.ExtensionStatusData.AddOnProvider
+}
+
+// GetApp returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.App, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetApp() ExtensionStatusDataApp {
+	return v.ExtensionStatusData.App
+}
+
+// GetOrganization returns ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn.Organization, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) GetOrganization() ExtensionStatusDataOrganization {
+	return v.ExtensionStatusData.Organization
+}
+
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.ExtensionStatusData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn struct {
+	Name string `json:"name"`
+
+	Status string `json:"status"`
+
+	ErrorMessage string `json:"errorMessage"`
+
+	PrimaryRegion string `json:"primaryRegion"`
+
+	AddOnPlan ExtensionStatusDataAddOnPlan `json:"addOnPlan"`
+
+	AddOnProvider ExtensionStatusDataAddOnProvider `json:"addOnProvider"`
+
+	App ExtensionStatusDataApp `json:"app"`
+
+	Organization ExtensionStatusDataOrganization `json:"organization"`
+}
+
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn) __premarshalJSON() (*__premarshalListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn, error) {
+	var retval __premarshalListAppExtensionsStatusAppAddOnsAddOnConnectionNodesAddOn
+
+	retval.Name = v.ExtensionStatusData.Name
+	retval.Status = v.ExtensionStatusData.Status
+	retval.ErrorMessage = v.ExtensionStatusData.ErrorMessage
+	retval.PrimaryRegion = v.ExtensionStatusData.PrimaryRegion
+	retval.AddOnPlan = v.ExtensionStatusData.AddOnPlan
+	retval.AddOnProvider = v.ExtensionStatusData.AddOnProvider
+	retval.App = v.ExtensionStatusData.App
+	retval.Organization = v.ExtensionStatusData.Organization
+	return &retval, nil
+}
+
+// ListAppExtensionsStatusResponse is returned by ListAppExtensionsStatus on success.
+type ListAppExtensionsStatusResponse struct {
+	// Find an app by name
+	App ListAppExtensionsStatusApp `json:"app"`
+}
+
+// GetApp returns ListAppExtensionsStatusResponse.App, and is useful for accessing the field via an interface.
+func (v *ListAppExtensionsStatusResponse) GetApp() ListAppExtensionsStatusApp { return v.App }
+
+// ListOrgExtensionsStatusOrganization includes the requested fields of the GraphQL type Organization.
+type ListOrgExtensionsStatusOrganization struct {
+	// List third party integrations associated with an organization
+	AddOns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetAddOns returns ListOrgExtensionsStatusOrganization.AddOns, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganization) GetAddOns() ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection {
+	return v.AddOns
+}
+
+// ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection struct {
+	// A list of nodes.
+	Nodes []ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+}
+
+// GetNodes returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnection) GetNodes() []ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn struct {
+	ExtensionStatusData `json:"-"`
+}
+
+// GetName returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.Name, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetName() string {
+	return v.ExtensionStatusData.Name
+}
+
+// GetStatus returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.Status, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetStatus() string {
+	return v.ExtensionStatusData.Status
+}
+
+// GetErrorMessage returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.ErrorMessage, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetErrorMessage() string {
+	return v.ExtensionStatusData.ErrorMessage
+}
+
+// GetPrimaryRegion returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.PrimaryRegion, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetPrimaryRegion() string {
+	return v.ExtensionStatusData.PrimaryRegion
+}
+
+// GetAddOnPlan returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.AddOnPlan, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetAddOnPlan() ExtensionStatusDataAddOnPlan {
+	return v.ExtensionStatusData.AddOnPlan
+}
+
+// GetAddOnProvider returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetAddOnProvider() ExtensionStatusDataAddOnProvider {
+	return v.ExtensionStatusData.AddOnProvider
+}
+
+// GetApp returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.App, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetApp() ExtensionStatusDataApp {
+	return v.ExtensionStatusData.App
+}
+
+// GetOrganization returns ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn.Organization, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) GetOrganization() ExtensionStatusDataOrganization {
+	return v.ExtensionStatusData.Organization
+}
+
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	var firstPass struct {
+		*ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn
+		graphql.NoUnmarshalJSON
+	}
+	firstPass.ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn = v
+
+	err := json.Unmarshal(b, &firstPass)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(
+		b, &v.ExtensionStatusData)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+type __premarshalListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn struct {
+	Name string `json:"name"`
+
+	Status string `json:"status"`
+
+	ErrorMessage string `json:"errorMessage"`
+
+	PrimaryRegion string `json:"primaryRegion"`
+
+	AddOnPlan ExtensionStatusDataAddOnPlan `json:"addOnPlan"`
+
+	AddOnProvider ExtensionStatusDataAddOnProvider `json:"addOnProvider"`
+
+	App ExtensionStatusDataApp `json:"app"`
+
+	Organization ExtensionStatusDataOrganization `json:"organization"`
+}
+
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) MarshalJSON() ([]byte, error) {
+	premarshaled, err := v.__premarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(premarshaled)
+}
+
+func (v *ListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn) __premarshalJSON() (*__premarshalListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn, error) {
+	var retval __premarshalListOrgExtensionsStatusOrganizationAddOnsAddOnConnectionNodesAddOn
+
+	retval.Name = v.ExtensionStatusData.Name
+	retval.Status = v.ExtensionStatusData.Status
+	retval.ErrorMessage = v.ExtensionStatusData.ErrorMessage
+	retval.PrimaryRegion = v.ExtensionStatusData.PrimaryRegion
+	retval.AddOnPlan = v.ExtensionStatusData.AddOnPlan
+	retval.AddOnProvider = v.ExtensionStatusData.AddOnProvider
+	retval.App = v.ExtensionStatusData.App
+	retval.Organization = v.ExtensionStatusData.Organization
+	return &retval, nil
+}
+
+// ListOrgExtensionsStatusResponse is returned by ListOrgExtensionsStatus on success.
+type ListOrgExtensionsStatusResponse struct {
+	// Find an organization by ID
+	Organization ListOrgExtensionsStatusOrganization `json:"organization"`
+}
+
+// GetOrganization returns ListOrgExtensionsStatusResponse.Organization, and is useful for accessing the field via an interface.
+func (v *ListOrgExtensionsStatusResponse) GetOrganization() ListOrgExtensionsStatusOrganization {
+	return v.Organization
+}
+
 // LogOutLogOutLogOutPayload includes the requested fields of the GraphQL type LogOutPayload.
 // The GraphQL type's documentation follows.
 //
@@ -2985,6 +4341,137 @@ type MachinesUpdateReleaseUpdateReleaseUpdateReleasePayloadRelease struct {
 // GetId returns MachinesUpdateReleaseUpdateReleaseUpdateReleasePayloadRelease.Id, and is useful for accessing the field via an interface.
 func (v *MachinesUpdateReleaseUpdateReleaseUpdateReleasePayloadRelease) GetId() string { return v.Id }
 
+// OrgAppsForPruneOrganization includes the requested fields of the GraphQL type Organization.
+type OrgAppsForPruneOrganization struct {
+	Apps OrgAppsForPruneOrganizationAppsAppConnection `json:"apps"`
+}
+
+// GetApps returns OrgAppsForPruneOrganization.Apps, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganization) GetApps() OrgAppsForPruneOrganizationAppsAppConnection {
+	return v.Apps
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnection includes the requested fields of the GraphQL type AppConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for App.
+type OrgAppsForPruneOrganizationAppsAppConnection struct {
+	// Information to aid in pagination.
+	PageInfo OrgAppsForPruneOrganizationAppsAppConnectionPageInfo `json:"pageInfo"`
+	// A list of nodes.
+	Nodes []OrgAppsForPruneOrganizationAppsAppConnectionNodesApp `json:"nodes"`
+}
+
+// GetPageInfo returns OrgAppsForPruneOrganizationAppsAppConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnection) GetPageInfo() OrgAppsForPruneOrganizationAppsAppConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetNodes returns OrgAppsForPruneOrganizationAppsAppConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnection) GetNodes() []OrgAppsForPruneOrganizationAppsAppConnectionNodesApp {
+	return v.Nodes
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnectionNodesApp includes the requested fields of the GraphQL type App.
+type OrgAppsForPruneOrganizationAppsAppConnectionNodesApp struct {
+	// The unique application name
+	Name string `json:"name"`
+	// Application status
+	Status string `json:"status"`
+	// The latest release of this application
+	CurrentRelease *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease           `json:"currentRelease"`
+	Machines       OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection `json:"machines"`
+}
+
+// GetName returns OrgAppsForPruneOrganizationAppsAppConnectionNodesApp.Name, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesApp) GetName() string { return v.Name }
+
+// GetStatus returns OrgAppsForPruneOrganizationAppsAppConnectionNodesApp.Status, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesApp) GetStatus() string { return v.Status }
+
+// GetCurrentRelease returns OrgAppsForPruneOrganizationAppsAppConnectionNodesApp.CurrentRelease, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesApp) GetCurrentRelease() *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease {
+	return v.CurrentRelease
+}
+
+// GetMachines returns OrgAppsForPruneOrganizationAppsAppConnectionNodesApp.Machines, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesApp) GetMachines() OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection {
+	return v.Machines
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease includes the requested fields of the GraphQL type Release.
+type OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease struct {
+	// The status of the release
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GetStatus returns OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease.Status, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease) GetStatus() string {
+	return v.Status
+}
+
+// GetCreatedAt returns OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease.CreatedAt, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease) GetCreatedAt() time.Time {
+	return v.CreatedAt
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection includes the requested fields of the GraphQL type MachineConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for Machine.
+type OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection struct {
+	// A list of nodes.
+	Nodes []OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine `json:"nodes"`
+}
+
+// GetNodes returns OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnection) GetNodes() []OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine {
+	return v.Nodes
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine includes the requested fields of the GraphQL type Machine.
+type OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine struct {
+	State string `json:"state"`
+}
+
+// GetState returns OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine.State, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine) GetState() string {
+	return v.State
+}
+
+// OrgAppsForPruneOrganizationAppsAppConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type OrgAppsForPruneOrganizationAppsAppConnectionPageInfo struct {
+	// When paginating forwards, are there more items?
+	HasNextPage bool `json:"hasNextPage"`
+	// When paginating forwards, the cursor to continue.
+	EndCursor string `json:"endCursor"`
+}
+
+// GetHasNextPage returns OrgAppsForPruneOrganizationAppsAppConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns OrgAppsForPruneOrganizationAppsAppConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneOrganizationAppsAppConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// OrgAppsForPruneResponse is returned by OrgAppsForPrune on success.
+type OrgAppsForPruneResponse struct {
+	// Find an organization by ID
+	Organization OrgAppsForPruneOrganization `json:"organization"`
+}
+
+// GetOrganization returns OrgAppsForPruneResponse.Organization, and is useful for accessing the field via an interface.
+func (v *OrgAppsForPruneResponse) GetOrganization() OrgAppsForPruneOrganization {
+	return v.Organization
+}
+
 // OrganizationData includes the GraphQL fields of Organization requested by the fragment OrganizationData.
 type OrganizationData struct {
 	Id string `json:"id"`
@@ -3017,6 +4504,305 @@ func (v *OrganizationData) GetAddOnSsoLink() string { return v.AddOnSsoLink }
 // GetProvisionsBetaExtensions returns OrganizationData.ProvisionsBetaExtensions, and is useful for accessing the field via an interface.
 func (v *OrganizationData) GetProvisionsBetaExtensions() bool { return v.ProvisionsBetaExtensions }
 
+type OrganizationTrust string
+
+const (
+	// Organization cannot use our services
+	OrganizationTrustBanned OrganizationTrust = "BANNED"
+	// Organization proved that it's safe to use our services
+	OrganizationTrustHigh OrganizationTrust = "HIGH"
+	// Organization has to prove that is not fraud over time but can use our services
+	OrganizationTrustLow OrganizationTrust = "LOW"
+	// Organization has limited access to our service
+	OrganizationTrustRestricted OrganizationTrust = "RESTRICTED"
+	// We haven't set a trust level yet
+	OrganizationTrustUnknown OrganizationTrust = "UNKNOWN"
+)
+
+// OrganizationUsageAddOnsOrganization includes the requested fields of the GraphQL type Organization.
+type OrganizationUsageAddOnsOrganization struct {
+	// List third party integrations associated with an organization
+	AddOns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection `json:"addOns"`
+}
+
+// GetAddOns returns OrganizationUsageAddOnsOrganization.AddOns, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganization) GetAddOns() OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection {
+	return v.AddOns
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection includes the requested fields of the GraphQL type AddOnConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for AddOn.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection struct {
+	// Information to aid in pagination.
+	PageInfo OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo `json:"pageInfo"`
+	// A list of nodes.
+	Nodes []OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn `json:"nodes"`
+}
+
+// GetPageInfo returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection) GetPageInfo() OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetNodes returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnection) GetNodes() []OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn {
+	return v.Nodes
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn includes the requested fields of the GraphQL type AddOn.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn struct {
+	// The service name according to the provider
+	Name string `json:"name"`
+	// The add-on provider
+	AddOnProvider OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider `json:"addOnProvider"`
+	// The add-on plan
+	AddOnPlan OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan `json:"addOnPlan"`
+	// An app associated with this add-on
+	App OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp `json:"app"`
+}
+
+// GetName returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn.Name, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn) GetName() string {
+	return v.Name
+}
+
+// GetAddOnProvider returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn.AddOnProvider, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn) GetAddOnProvider() OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider {
+	return v.AddOnProvider
+}
+
+// GetAddOnPlan returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn.AddOnPlan, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn) GetAddOnPlan() OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan {
+	return v.AddOnPlan
+}
+
+// GetApp returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn.App, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn) GetApp() OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp {
+	return v.App
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan includes the requested fields of the GraphQL type AddOnPlan.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan struct {
+	DisplayName   string `json:"displayName"`
+	PricePerMonth int    `json:"pricePerMonth"`
+}
+
+// GetDisplayName returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan.DisplayName, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan) GetDisplayName() string {
+	return v.DisplayName
+}
+
+// GetPricePerMonth returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan.PricePerMonth, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnPlan) GetPricePerMonth() int {
+	return v.PricePerMonth
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider includes the requested fields of the GraphQL type AddOnProvider.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider struct {
+	Name string `json:"name"`
+}
+
+// GetName returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider.Name, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnAddOnProvider) GetName() string {
+	return v.Name
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp includes the requested fields of the GraphQL type App.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp struct {
+	// The unique application name
+	Name string `json:"name"`
+}
+
+// GetName returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp.Name, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOnApp) GetName() string {
+	return v.Name
+}
+
+// OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo struct {
+	// When paginating forwards, are there more items?
+	HasNextPage bool `json:"hasNextPage"`
+	// When paginating forwards, the cursor to continue.
+	EndCursor string `json:"endCursor"`
+}
+
+// GetHasNextPage returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// OrganizationUsageAddOnsResponse is returned by OrganizationUsageAddOns on success.
+type OrganizationUsageAddOnsResponse struct {
+	// Find an organization by ID
+	Organization OrganizationUsageAddOnsOrganization `json:"organization"`
+}
+
+// GetOrganization returns OrganizationUsageAddOnsResponse.Organization, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAddOnsResponse) GetOrganization() OrganizationUsageAddOnsOrganization {
+	return v.Organization
+}
+
+// OrganizationUsageAppsOrganization includes the requested fields of the GraphQL type Organization.
+type OrganizationUsageAppsOrganization struct {
+	Apps OrganizationUsageAppsOrganizationAppsAppConnection `json:"apps"`
+}
+
+// GetApps returns OrganizationUsageAppsOrganization.Apps, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganization) GetApps() OrganizationUsageAppsOrganizationAppsAppConnection {
+	return v.Apps
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnection includes the requested fields of the GraphQL type AppConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for App.
+type OrganizationUsageAppsOrganizationAppsAppConnection struct {
+	// Information to aid in pagination.
+	PageInfo OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo `json:"pageInfo"`
+	// A list of nodes.
+	Nodes []OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp `json:"nodes"`
+}
+
+// GetPageInfo returns OrganizationUsageAppsOrganizationAppsAppConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnection) GetPageInfo() OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo {
+	return v.PageInfo
+}
+
+// GetNodes returns OrganizationUsageAppsOrganizationAppsAppConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnection) GetNodes() []OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp {
+	return v.Nodes
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp includes the requested fields of the GraphQL type App.
+type OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp struct {
+	// The unique application name
+	Name  string                                                            `json:"name"`
+	Usage []OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage `json:"usage"`
+	// Volumes associated with app
+	Volumes OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection `json:"volumes"`
+}
+
+// GetName returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp.Name, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp) GetName() string { return v.Name }
+
+// GetUsage returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp.Usage, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp) GetUsage() []OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage {
+	return v.Usage
+}
+
+// GetVolumes returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp.Volumes, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp) GetVolumes() OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection {
+	return v.Volumes
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage includes the requested fields of the GraphQL type AppUsage.
+// The GraphQL type's documentation follows.
+//
+// Application usage data
+type OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage struct {
+	// The start of the timespan for this usage sample
+	Ts time.Time `json:"ts"`
+	// The timespan interval for this usage sample
+	Interval string `json:"interval"`
+	// Total requests for this time period
+	RequestsCount int `json:"requestsCount"`
+	// Total app execution time (in seconds) for this time period
+	TotalAppExecS int `json:"totalAppExecS"`
+	// Total GB transferred out in this time period
+	TotalDataOutGB float64 `json:"totalDataOutGB"`
+}
+
+// GetTs returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage.Ts, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage) GetTs() time.Time {
+	return v.Ts
+}
+
+// GetInterval returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage.Interval, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage) GetInterval() string {
+	return v.Interval
+}
+
+// GetRequestsCount returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage.RequestsCount, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage) GetRequestsCount() int {
+	return v.RequestsCount
+}
+
+// GetTotalAppExecS returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage.TotalAppExecS, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage) GetTotalAppExecS() int {
+	return v.TotalAppExecS
+}
+
+// GetTotalDataOutGB returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage.TotalDataOutGB, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppUsage) GetTotalDataOutGB() float64 {
+	return v.TotalDataOutGB
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection includes the requested fields of the GraphQL type VolumeConnection.
+// The GraphQL type's documentation follows.
+//
+// The connection type for Volume.
+type OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection struct {
+	// A list of nodes.
+	Nodes []OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume `json:"nodes"`
+}
+
+// GetNodes returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnection) GetNodes() []OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume {
+	return v.Nodes
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume includes the requested fields of the GraphQL type Volume.
+type OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume struct {
+	SizeGb int `json:"sizeGb"`
+}
+
+// GetSizeGb returns OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume.SizeGb, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionNodesAppVolumesVolumeConnectionNodesVolume) GetSizeGb() int {
+	return v.SizeGb
+}
+
+// OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Information about pagination in a connection.
+type OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo struct {
+	// When paginating forwards, are there more items?
+	HasNextPage bool `json:"hasNextPage"`
+	// When paginating forwards, the cursor to continue.
+	EndCursor string `json:"endCursor"`
+}
+
+// GetHasNextPage returns OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsOrganizationAppsAppConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// OrganizationUsageAppsResponse is returned by OrganizationUsageApps on success.
+type OrganizationUsageAppsResponse struct {
+	// Find an organization by ID
+	Organization OrganizationUsageAppsOrganization `json:"organization"`
+}
+
+// GetOrganization returns OrganizationUsageAppsResponse.Organization, and is useful for accessing the field via an interface.
+func (v *OrganizationUsageAppsResponse) GetOrganization() OrganizationUsageAppsOrganization {
+	return v.Organization
+}
+
 type PlatformVersionEnum string
 
 const (
@@ -3123,6 +4909,40 @@ func (v *ResolverFinishBuildResponse) GetFinishBuild() ResolverFinishBuildFinish
 	return v.FinishBuild
 }
 
+// RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload includes the requested fields of the GraphQL type ResetAddOnPasswordPayload.
+// The GraphQL type's documentation follows.
+//
+// Autogenerated return type of ResetAddOnPassword.
+type RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload struct {
+	AddOn RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn `json:"addOn"`
+}
+
+// GetAddOn returns RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload.AddOn, and is useful for accessing the field via an interface.
+func (v *RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload) GetAddOn() RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn {
+	return v.AddOn
+}
+
+// RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn includes the requested fields of the GraphQL type AddOn.
+type RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn struct {
+	// Environment variables for the add-on
+	Environment interface{} `json:"environment"`
+}
+
+// GetEnvironment returns RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn.Environment, and is useful for accessing the field via an interface.
+func (v *RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayloadAddOn) GetEnvironment() interface{} {
+	return v.Environment
+}
+
+// RotateAddOnCredentialsResponse is returned by RotateAddOnCredentials on success.
+type RotateAddOnCredentialsResponse struct {
+	ResetAddOnPassword RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload `json:"resetAddOnPassword"`
+}
+
+// GetResetAddOnPassword returns RotateAddOnCredentialsResponse.ResetAddOnPassword, and is useful for accessing the field via an interface.
+func (v *RotateAddOnCredentialsResponse) GetResetAddOnPassword() RotateAddOnCredentialsResetAddOnPasswordResetAddOnPasswordPayload {
+	return v.ResetAddOnPassword
+}
+
 type RuntimeType string
 
 const (
@@ -3411,6 +5231,22 @@ type __AllAppsInput struct {
 // GetOrgSlug returns __AllAppsInput.OrgSlug, and is useful for accessing the field via an interface.
 func (v *__AllAppsInput) GetOrgSlug() string { return v.OrgSlug }
 
+// __AppReleasesUnprocessedInput is used internally by genqlient
+type __AppReleasesUnprocessedInput struct {
+	AppName string `json:"appName"`
+	First   int    `json:"first"`
+	After   string `json:"after"`
+}
+
+// GetAppName returns __AppReleasesUnprocessedInput.AppName, and is useful for accessing the field via an interface.
+func (v *__AppReleasesUnprocessedInput) GetAppName() string { return v.AppName }
+
+// GetFirst returns __AppReleasesUnprocessedInput.First, and is useful for accessing the field via an interface.
+func (v *__AppReleasesUnprocessedInput) GetFirst() int { return v.First }
+
+// GetAfter returns __AppReleasesUnprocessedInput.After, and is useful for accessing the field via an interface.
+func (v *__AppReleasesUnprocessedInput) GetAfter() string { return v.After }
+
 // __CreateAddOnInput is used internally by genqlient
 type __CreateAddOnInput struct {
 	Input CreateAddOnInput `json:"input"`
@@ -3507,6 +5343,26 @@ type __GetAddOnProviderInput struct {
 // GetName returns __GetAddOnProviderInput.Name, and is useful for accessing the field via an interface.
 func (v *__GetAddOnProviderInput) GetName() string { return v.Name }
 
+// __GetAppAddOnsInput is used internally by genqlient
+type __GetAppAddOnsInput struct {
+	Name  string `json:"name"`
+	After string `json:"after"`
+}
+
+// GetName returns __GetAppAddOnsInput.Name, and is useful for accessing the field via an interface.
+func (v *__GetAppAddOnsInput) GetName() string { return v.Name }
+
+// GetAfter returns __GetAppAddOnsInput.After, and is useful for accessing the field via an interface.
+func (v *__GetAppAddOnsInput) GetAfter() string { return v.After }
+
+// __GetAppGrafanaOrgInput is used internally by genqlient
+type __GetAppGrafanaOrgInput struct {
+	AppName string `json:"appName"`
+}
+
+// GetAppName returns __GetAppGrafanaOrgInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetAppGrafanaOrgInput) GetAppName() string { return v.AppName }
+
 // __GetAppInput is used internally by genqlient
 type __GetAppInput struct {
 	Name string `json:"name"`
@@ -3515,6 +5371,14 @@ type __GetAppInput struct {
 // GetName returns __GetAppInput.Name, and is useful for accessing the field via an interface.
 func (v *__GetAppInput) GetName() string { return v.Name }
 
+// __GetAppOrganizationBillingInput is used internally by genqlient
+type __GetAppOrganizationBillingInput struct {
+	AppName string `json:"appName"`
+}
+
+// GetAppName returns __GetAppOrganizationBillingInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetAppOrganizationBillingInput) GetAppName() string { return v.AppName }
+
 // __GetAppWithAddonsInput is used internally by genqlient
 type __GetAppWithAddonsInput struct {
 	Name      string    `json:"name"`
@@ -3551,6 +5415,22 @@ func (v *__GetExtensionSsoLinkInput) GetOrgSlug() string { return v.OrgSlug }
 // GetProvider returns __GetExtensionSsoLinkInput.Provider, and is useful for accessing the field via an interface.
 func (v *__GetExtensionSsoLinkInput) GetProvider() string { return v.Provider }
 
+// __GetIncidentScopeAppInput is used internally by genqlient
+type __GetIncidentScopeAppInput struct {
+	AppName string `json:"appName"`
+}
+
+// GetAppName returns __GetIncidentScopeAppInput.AppName, and is useful for accessing the field via an interface.
+func (v *__GetIncidentScopeAppInput) GetAppName() string { return v.AppName }
+
+// __GetIncidentScopeOrgAppsInput is used internally by genqlient
+type __GetIncidentScopeOrgAppsInput struct {
+	Slug string `json:"slug"`
+}
+
+// GetSlug returns __GetIncidentScopeOrgAppsInput.Slug, and is useful for accessing the field via an interface.
+func (v *__GetIncidentScopeOrgAppsInput) GetSlug() string { return v.Slug }
+
 // __GetOrganizationInput is used internally by genqlient
 type __GetOrganizationInput struct {
 	Slug string `json:"slug"`
@@ -3575,6 +5455,22 @@ type __ListAddOnsInput struct {
 // GetAddOnType returns __ListAddOnsInput.AddOnType, and is useful for accessing the field via an interface.
 func (v *__ListAddOnsInput) GetAddOnType() AddOnType { return v.AddOnType }
 
+// __ListAppExtensionsStatusInput is used internally by genqlient
+type __ListAppExtensionsStatusInput struct {
+	AppName string `json:"appName"`
+}
+
+// GetAppName returns __ListAppExtensionsStatusInput.AppName, and is useful for accessing the field via an interface.
+func (v *__ListAppExtensionsStatusInput) GetAppName() string { return v.AppName }
+
+// __ListOrgExtensionsStatusInput is used internally by genqlient
+type __ListOrgExtensionsStatusInput struct {
+	OrgSlug string `json:"orgSlug"`
+}
+
+// GetOrgSlug returns __ListOrgExtensionsStatusInput.OrgSlug, and is useful for accessing the field via an interface.
+func (v *__ListOrgExtensionsStatusInput) GetOrgSlug() string { return v.OrgSlug }
+
 // __MachinesCreateReleaseInput is used internally by genqlient
 type __MachinesCreateReleaseInput struct {
 	Input CreateReleaseInput `json:"input"`
@@ -3591,6 +5487,50 @@ type __MachinesUpdateReleaseInput struct {
 // GetInput returns __MachinesUpdateReleaseInput.Input, and is useful for accessing the field via an interface.
 func (v *__MachinesUpdateReleaseInput) GetInput() UpdateReleaseInput { return v.Input }
 
+// __OrgAppsForPruneInput is used internally by genqlient
+type __OrgAppsForPruneInput struct {
+	Slug  string `json:"slug"`
+	After string `json:"after,omitempty"`
+}
+
+// GetSlug returns __OrgAppsForPruneInput.Slug, and is useful for accessing the field via an interface.
+func (v *__OrgAppsForPruneInput) GetSlug() string { return v.Slug }
+
+// GetAfter returns __OrgAppsForPruneInput.After, and is useful for accessing the field via an interface.
+func (v *__OrgAppsForPruneInput) GetAfter() string { return v.After }
+
+// __OrganizationUsageAddOnsInput is used internally by genqlient
+type __OrganizationUsageAddOnsInput struct {
+	Slug  string `json:"slug"`
+	First int    `json:"first"`
+	After string `json:"after"`
+}
+
+// GetSlug returns __OrganizationUsageAddOnsInput.Slug, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAddOnsInput) GetSlug() string { return v.Slug }
+
+// GetFirst returns __OrganizationUsageAddOnsInput.First, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAddOnsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __OrganizationUsageAddOnsInput.After, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAddOnsInput) GetAfter() string { return v.After }
+
+// __OrganizationUsageAppsInput is used internally by genqlient
+type __OrganizationUsageAppsInput struct {
+	Slug  string `json:"slug"`
+	First int    `json:"first"`
+	After string `json:"after"`
+}
+
+// GetSlug returns __OrganizationUsageAppsInput.Slug, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAppsInput) GetSlug() string { return v.Slug }
+
+// GetFirst returns __OrganizationUsageAppsInput.First, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAppsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __OrganizationUsageAppsInput.After, and is useful for accessing the field via an interface.
+func (v *__OrganizationUsageAppsInput) GetAfter() string { return v.After }
+
 // __ResetAddOnPasswordInput is used internally by genqlient
 type __ResetAddOnPasswordInput struct {
 	Name string `json:"name"`
@@ -3615,6 +5555,14 @@ type __ResolverFinishBuildInput struct {
 // GetInput returns __ResolverFinishBuildInput.Input, and is useful for accessing the field via an interface.
 func (v *__ResolverFinishBuildInput) GetInput() FinishBuildInput { return v.Input }
 
+// __RotateAddOnCredentialsInput is used internally by genqlient
+type __RotateAddOnCredentialsInput struct {
+	Name string `json:"name"`
+}
+
+// GetName returns __RotateAddOnCredentialsInput.Name, and is useful for accessing the field via an interface.
+func (v *__RotateAddOnCredentialsInput) GetName() string { return v.Name }
+
 // __SetNomadVMCountInput is used internally by genqlient
 type __SetNomadVMCountInput struct {
 	Input SetVMCountInput `json:"input"`
@@ -3787,6 +5735,57 @@ func AllApps(
 	return &data_, err_
 }
 
+// The query or mutation executed by AppReleasesUnprocessed.
+const AppReleasesUnprocessed_Operation = `
+query AppReleasesUnprocessed ($appName: String!, $first: Int!, $after: String) {
+	app(name: $appName) {
+		releasesUnprocessed(first: $first, after: $after) {
+			nodes {
+				id
+				version
+				createdAt
+				imageRef
+				configDefinition
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+func AppReleasesUnprocessed(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+	first int,
+	after string,
+) (*AppReleasesUnprocessedResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "AppReleasesUnprocessed",
+		Query:  AppReleasesUnprocessed_Operation,
+		Variables: &__AppReleasesUnprocessedInput{
+			AppName: appName,
+			First:   first,
+			After:   after,
+		},
+	}
+	var err_ error
+
+	var data_ AppReleasesUnprocessedResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by CreateAddOn.
 const CreateAddOn_Operation = `
 mutation CreateAddOn ($input: CreateAddOnInput!) {
@@ -4132,6 +6131,7 @@ query GetAddOn ($name: String) {
 	addOn(name: $name) {
 		... AddOnData
 		publicUrl
+		environment
 		privateIp
 		password
 		status
@@ -4346,6 +6346,136 @@ func GetApp(
 	return &data_, err_
 }
 
+// The query or mutation executed by GetAppAddOns.
+const GetAppAddOns_Operation = `
+query GetAppAddOns ($name: String!, $after: String) {
+	app(name: $name) {
+		addOns(after: $after) {
+			nodes {
+				... AddOnData
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+fragment AddOnData on AddOn {
+	id
+	name
+	primaryRegion
+	status
+	errorMessage
+	metadata
+	options
+}
+`
+
+func GetAppAddOns(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	name string,
+	after string,
+) (*GetAppAddOnsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppAddOns",
+		Query:  GetAppAddOns_Operation,
+		Variables: &__GetAppAddOnsInput{
+			Name:  name,
+			After: after,
+		},
+	}
+	var err_ error
+
+	var data_ GetAppAddOnsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by GetAppGrafanaOrg.
+const GetAppGrafanaOrg_Operation = `
+query GetAppGrafanaOrg ($appName: String!) {
+	app(name: $appName) {
+		organization {
+			internalNumericId
+		}
+	}
+}
+`
+
+func GetAppGrafanaOrg(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+) (*GetAppGrafanaOrgResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppGrafanaOrg",
+		Query:  GetAppGrafanaOrg_Operation,
+		Variables: &__GetAppGrafanaOrgInput{
+			AppName: appName,
+		},
+	}
+	var err_ error
+
+	var data_ GetAppGrafanaOrgResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by GetAppOrganizationBilling.
+const GetAppOrganizationBilling_Operation = `
+query GetAppOrganizationBilling ($appName: String!) {
+	app(name: $appName) {
+		organization {
+			slug
+			billingStatus
+			trust
+		}
+	}
+}
+`
+
+func GetAppOrganizationBilling(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+) (*GetAppOrganizationBillingResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetAppOrganizationBilling",
+		Query:  GetAppOrganizationBilling_Operation,
+		Variables: &__GetAppOrganizationBillingInput{
+			AppName: appName,
+		},
+	}
+	var err_ error
+
+	var data_ GetAppOrganizationBillingResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by GetAppWithAddons.
 const GetAppWithAddons_Operation = `
 query GetAppWithAddons ($name: String!, $addOnType: AddOnType!) {
@@ -4475,33 +6605,188 @@ func GetAppsByRole(
 
 	return &data_, err_
 }
-
-// The query or mutation executed by GetExtensionSsoLink.
-const GetExtensionSsoLink_Operation = `
-query GetExtensionSsoLink ($orgSlug: String!, $provider: String!) {
-	organization(slug: $orgSlug) {
-		extensionSsoLink(provider: $provider)
+
+// The query or mutation executed by GetExtensionSsoLink.
+const GetExtensionSsoLink_Operation = `
+query GetExtensionSsoLink ($orgSlug: String!, $provider: String!) {
+	organization(slug: $orgSlug) {
+		extensionSsoLink(provider: $provider)
+	}
+}
+`
+
+func GetExtensionSsoLink(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	orgSlug string,
+	provider string,
+) (*GetExtensionSsoLinkResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetExtensionSsoLink",
+		Query:  GetExtensionSsoLink_Operation,
+		Variables: &__GetExtensionSsoLinkInput{
+			OrgSlug:  orgSlug,
+			Provider: provider,
+		},
+	}
+	var err_ error
+
+	var data_ GetExtensionSsoLinkResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by GetIncidentScopeApp.
+const GetIncidentScopeApp_Operation = `
+query GetIncidentScopeApp ($appName: String!) {
+	app(name: $appName) {
+		... IncidentScopeApp
+	}
+}
+fragment IncidentScopeApp on App {
+	role {
+		__typename
+		name
+	}
+	regions {
+		code
+	}
+	addOns {
+		nodes {
+			addOnProvider {
+				name
+			}
+		}
+	}
+}
+`
+
+func GetIncidentScopeApp(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+) (*GetIncidentScopeAppResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetIncidentScopeApp",
+		Query:  GetIncidentScopeApp_Operation,
+		Variables: &__GetIncidentScopeAppInput{
+			AppName: appName,
+		},
+	}
+	var err_ error
+
+	var data_ GetIncidentScopeAppResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by GetIncidentScopeApps.
+const GetIncidentScopeApps_Operation = `
+query GetIncidentScopeApps {
+	apps {
+		nodes {
+			... IncidentScopeApp
+		}
+	}
+}
+fragment IncidentScopeApp on App {
+	role {
+		__typename
+		name
+	}
+	regions {
+		code
+	}
+	addOns {
+		nodes {
+			addOnProvider {
+				name
+			}
+		}
+	}
+}
+`
+
+func GetIncidentScopeApps(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (*GetIncidentScopeAppsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetIncidentScopeApps",
+		Query:  GetIncidentScopeApps_Operation,
+	}
+	var err_ error
+
+	var data_ GetIncidentScopeAppsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by GetIncidentScopeOrgApps.
+const GetIncidentScopeOrgApps_Operation = `
+query GetIncidentScopeOrgApps ($slug: String!) {
+	organization(slug: $slug) {
+		apps {
+			nodes {
+				... IncidentScopeApp
+			}
+		}
+	}
+}
+fragment IncidentScopeApp on App {
+	role {
+		__typename
+		name
+	}
+	regions {
+		code
+	}
+	addOns {
+		nodes {
+			addOnProvider {
+				name
+			}
+		}
 	}
 }
 `
 
-func GetExtensionSsoLink(
+func GetIncidentScopeOrgApps(
 	ctx_ context.Context,
 	client_ graphql.Client,
-	orgSlug string,
-	provider string,
-) (*GetExtensionSsoLinkResponse, error) {
+	slug string,
+) (*GetIncidentScopeOrgAppsResponse, error) {
 	req_ := &graphql.Request{
-		OpName: "GetExtensionSsoLink",
-		Query:  GetExtensionSsoLink_Operation,
-		Variables: &__GetExtensionSsoLinkInput{
-			OrgSlug:  orgSlug,
-			Provider: provider,
+		OpName: "GetIncidentScopeOrgApps",
+		Query:  GetIncidentScopeOrgApps_Operation,
+		Variables: &__GetIncidentScopeOrgAppsInput{
+			Slug: slug,
 		},
 	}
 	var err_ error
 
-	var data_ GetExtensionSsoLinkResponse
+	var data_ GetIncidentScopeOrgAppsResponse
 	resp_ := &graphql.Response{Data: &data_}
 
 	err_ = client_.MakeRequest(
@@ -4589,6 +6874,44 @@ func GetOrganization(
 	return &data_, err_
 }
 
+// The query or mutation executed by GetPlatformVMSizes.
+const GetPlatformVMSizes_Operation = `
+query GetPlatformVMSizes {
+	platform {
+		vmSizes {
+			name
+			cpuCores
+			memoryMb
+			maxMemoryMb
+			priceMonth
+			priceSecond
+		}
+	}
+}
+`
+
+func GetPlatformVMSizes(
+	ctx_ context.Context,
+	client_ graphql.Client,
+) (*GetPlatformVMSizesResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "GetPlatformVMSizes",
+		Query:  GetPlatformVMSizes_Operation,
+	}
+	var err_ error
+
+	var data_ GetPlatformVMSizesResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by ListAddOnPlans.
 const ListAddOnPlans_Operation = `
 query ListAddOnPlans ($addOnType: AddOnType!) {
@@ -4680,6 +7003,120 @@ func ListAddOns(
 	return &data_, err_
 }
 
+// The query or mutation executed by ListAppExtensionsStatus.
+const ListAppExtensionsStatus_Operation = `
+query ListAppExtensionsStatus ($appName: String!) {
+	app(name: $appName) {
+		addOns {
+			nodes {
+				... ExtensionStatusData
+			}
+		}
+	}
+}
+fragment ExtensionStatusData on AddOn {
+	name
+	status
+	errorMessage
+	primaryRegion
+	addOnPlan {
+		displayName
+	}
+	addOnProvider {
+		displayName
+	}
+	app {
+		name
+	}
+	organization {
+		slug
+	}
+}
+`
+
+func ListAppExtensionsStatus(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	appName string,
+) (*ListAppExtensionsStatusResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "ListAppExtensionsStatus",
+		Query:  ListAppExtensionsStatus_Operation,
+		Variables: &__ListAppExtensionsStatusInput{
+			AppName: appName,
+		},
+	}
+	var err_ error
+
+	var data_ ListAppExtensionsStatusResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by ListOrgExtensionsStatus.
+const ListOrgExtensionsStatus_Operation = `
+query ListOrgExtensionsStatus ($orgSlug: String!) {
+	organization(slug: $orgSlug) {
+		addOns {
+			nodes {
+				... ExtensionStatusData
+			}
+		}
+	}
+}
+fragment ExtensionStatusData on AddOn {
+	name
+	status
+	errorMessage
+	primaryRegion
+	addOnPlan {
+		displayName
+	}
+	addOnProvider {
+		displayName
+	}
+	app {
+		name
+	}
+	organization {
+		slug
+	}
+}
+`
+
+func ListOrgExtensionsStatus(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	orgSlug string,
+) (*ListOrgExtensionsStatusResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "ListOrgExtensionsStatus",
+		Query:  ListOrgExtensionsStatus_Operation,
+		Variables: &__ListOrgExtensionsStatusInput{
+			OrgSlug: orgSlug,
+		},
+	}
+	var err_ error
+
+	var data_ ListOrgExtensionsStatusResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by LogOut.
 const LogOut_Operation = `
 mutation LogOut {
@@ -4786,6 +7223,177 @@ func MachinesUpdateRelease(
 	return &data_, err_
 }
 
+// The query or mutation executed by OrgAppsForPrune.
+const OrgAppsForPrune_Operation = `
+query OrgAppsForPrune ($slug: String!, $after: String) {
+	organization(slug: $slug) {
+		apps(first: 100, after: $after) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				name
+				status
+				currentRelease {
+					status
+					createdAt
+				}
+				machines(first: 100) {
+					nodes {
+						state
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func OrgAppsForPrune(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	slug string,
+	after string,
+) (*OrgAppsForPruneResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "OrgAppsForPrune",
+		Query:  OrgAppsForPrune_Operation,
+		Variables: &__OrgAppsForPruneInput{
+			Slug:  slug,
+			After: after,
+		},
+	}
+	var err_ error
+
+	var data_ OrgAppsForPruneResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by OrganizationUsageAddOns.
+const OrganizationUsageAddOns_Operation = `
+query OrganizationUsageAddOns ($slug: String!, $first: Int!, $after: String) {
+	organization(slug: $slug) {
+		addOns(first: $first, after: $after) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				name
+				addOnProvider {
+					name
+				}
+				addOnPlan {
+					displayName
+					pricePerMonth
+				}
+				app {
+					name
+				}
+			}
+		}
+	}
+}
+`
+
+func OrganizationUsageAddOns(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	slug string,
+	first int,
+	after string,
+) (*OrganizationUsageAddOnsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "OrganizationUsageAddOns",
+		Query:  OrganizationUsageAddOns_Operation,
+		Variables: &__OrganizationUsageAddOnsInput{
+			Slug:  slug,
+			First: first,
+			After: after,
+		},
+	}
+	var err_ error
+
+	var data_ OrganizationUsageAddOnsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
+// The query or mutation executed by OrganizationUsageApps.
+const OrganizationUsageApps_Operation = `
+query OrganizationUsageApps ($slug: String!, $first: Int!, $after: String) {
+	organization(slug: $slug) {
+		apps(first: $first, after: $after) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				name
+				usage {
+					ts
+					interval
+					requestsCount
+					totalAppExecS
+					totalDataOutGB
+				}
+				volumes {
+					nodes {
+						sizeGb
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+func OrganizationUsageApps(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	slug string,
+	first int,
+	after string,
+) (*OrganizationUsageAppsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "OrganizationUsageApps",
+		Query:  OrganizationUsageApps_Operation,
+		Variables: &__OrganizationUsageAppsInput{
+			Slug:  slug,
+			First: first,
+			After: after,
+		},
+	}
+	var err_ error
+
+	var data_ OrganizationUsageAppsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by ResetAddOnPassword.
 const ResetAddOnPassword_Operation = `
 mutation ResetAddOnPassword ($name: String!) {
@@ -4896,6 +7504,43 @@ func ResolverFinishBuild(
 	return &data_, err_
 }
 
+// The query or mutation executed by RotateAddOnCredentials.
+const RotateAddOnCredentials_Operation = `
+mutation RotateAddOnCredentials ($name: String!) {
+	resetAddOnPassword(input: {name:$name}) {
+		addOn {
+			environment
+		}
+	}
+}
+`
+
+func RotateAddOnCredentials(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	name string,
+) (*RotateAddOnCredentialsResponse, error) {
+	req_ := &graphql.Request{
+		OpName: "RotateAddOnCredentials",
+		Query:  RotateAddOnCredentials_Operation,
+		Variables: &__RotateAddOnCredentialsInput{
+			Name: name,
+		},
+	}
+	var err_ error
+
+	var data_ RotateAddOnCredentialsResponse
+	resp_ := &graphql.Response{Data: &data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return &data_, err_
+}
+
 // The query or mutation executed by SetNomadVMCount.
 const SetNomadVMCount_Operation = `
 mutation SetNomadVMCount ($input: SetVMCountInput!) {
@@ -16,6 +16,10 @@ import (
 const (
 	// DefaultConfigFileName denotes the default application configuration file name.
 	DefaultConfigFileName = "fly.toml"
+
+	// MachineConfigMetadataKeyRegionFloor marks machines that count towards the
+	// min_machines of their region and must never be autostopped.
+	MachineConfigMetadataKeyRegionFloor = "fly_region_floor"
 )
 
 type RestartPolicy string
@@ -49,6 +53,14 @@ type Config struct {
 	Deploy       *Deploy           `toml:"deploy,omitempty" json:"deploy,omitempty"`
 	Env          map[string]string `toml:"env,omitempty" json:"env,omitempty"`
 
+	// CLI holds the default flags of flyctl commands for the app, keyed by
+	// command such as "deploy" or "scale count", for example
+	// "--strategy bluegreen --wait-timeout 10m". Flags given explicitly, and
+	// the defaults of fly settings defaults, take precedence.
+	CLI map[string]string `toml:"cli,omitempty" json:"cli,omitempty"`
+
+	SLOs []SLO `toml:"slo,omitempty" json:"slo,omitempty"`
+
 	// Fields that are process group aware must come after Processes
 	Processes        map[string]string         `toml:"processes,omitempty" json:"processes,omitempty"`
 	Mounts           []Mount                   `toml:"mounts,omitempty" json:"mounts,omitempty"`
@@ -60,6 +72,8 @@ type Config struct {
 
 	Restart []Restart `toml:"restart,omitempty" json:"restart,omitempty"`
 
+	RegionScaling []RegionScaling `toml:"region_scaling,omitempty" json:"region_scaling,omitempty"`
+
 	Compute []*Compute `toml:"vm,omitempty" json:"vm,omitempty"`
 
 	// Others, less important.
@@ -85,11 +99,24 @@ type Metrics struct {
 }
 
 type Deploy struct {
-	ReleaseCommand        string        `toml:"release_command,omitempty" json:"release_command,omitempty"`
-	ReleaseCommandTimeout *fly.Duration `toml:"release_command_timeout,omitempty" json:"release_command_timeout,omitempty"`
-	Strategy              string        `toml:"strategy,omitempty" json:"strategy,omitempty"`
-	MaxUnavailable        *float64      `toml:"max_unavailable,omitempty" json:"max_unavailable,omitempty"`
-	WaitTimeout           *fly.Duration `toml:"wait_timeout,omitempty" json:"wait_timeout,omitempty"`
+	ReleaseCommand        string               `toml:"release_command,omitempty" json:"release_command,omitempty"`
+	ReleaseCommandTimeout *fly.Duration        `toml:"release_command_timeout,omitempty" json:"release_command_timeout,omitempty"`
+	Strategy              string               `toml:"strategy,omitempty" json:"strategy,omitempty"`
+	MaxUnavailable        *float64             `toml:"max_unavailable,omitempty" json:"max_unavailable,omitempty"`
+	WaitTimeout           *fly.Duration        `toml:"wait_timeout,omitempty" json:"wait_timeout,omitempty"`
+	SmokeTest             string               `toml:"smoke_test,omitempty" json:"smoke_test,omitempty"`
+	SmokeTestTimeout      *fly.Duration        `toml:"smoke_test_timeout,omitempty" json:"smoke_test_timeout,omitempty"`
+	Notifications         *DeployNotifications `toml:"notifications,omitempty" json:"notifications,omitempty"`
+}
+
+// DeployNotifications are the webhooks told about the start and outcome of
+// deployments. Slack and Discord webhook URLs get messages in their format,
+// any other URL gets a JSON payload. A URL can be read from an environment
+// variable of flyctl with env:VARIABLE to keep it out of fly.toml.
+type DeployNotifications struct {
+	URLs []string `toml:"urls,omitempty" json:"urls,omitempty"`
+	// Events are the events to notify about, all of them by default.
+	Events []string `toml:"events,omitempty" json:"events,omitempty"`
 }
 
 type File struct {
@@ -148,6 +175,11 @@ type Build struct {
 	Dockerfile        string            `toml:"dockerfile,omitempty" json:"dockerfile,omitempty"`
 	Ignorefile        string            `toml:"ignorefile,omitempty" json:"ignorefile,omitempty"`
 	DockerBuildTarget string            `toml:"build-target,omitempty" json:"build-target,omitempty"`
+	BakeFile          string            `toml:"bake_file,omitempty" json:"bake_file,omitempty"`
+	BakeTarget        string            `toml:"bake_target,omitempty" json:"bake_target,omitempty"`
+	// Processes are the builds of the process groups which don't run the
+	// image of the app, keyed by group name.
+	Processes map[string]*Build `toml:"processes,omitempty" json:"processes,omitempty"`
 }
 
 type Experimental struct {
@@ -166,12 +198,48 @@ type Compute struct {
 	*fly.MachineGuest `toml:",inline" json:",inline"`
 	Processes         []string `json:"processes,omitempty" toml:"processes,omitempty"`
 }
+
+// SLO is a service level objective of the app: Target percent of the events
+// measured by Metric must be good over Window, 30 days when unset.
+type SLO struct {
+	Metric string        `toml:"metric" json:"metric"`
+	Target float64       `toml:"target" json:"target"`
+	Window *fly.Duration `toml:"window,omitempty" json:"window,omitempty"`
+}
+
 type Restart struct {
 	Policy     RestartPolicy `toml:"policy,omitempty" json:"policy,omitempty"`
 	MaxRetries int           `toml:"retries,omitempty" json:"retries,omitempty"`
 	Processes  []string      `json:"processes,omitempty" toml:"processes,omitempty"`
 }
 
+// RegionScaling bounds the number of machines of a process group in a region.
+// MaxMachines caps how many machines `fly scale count` places in the region,
+// which is also the most fly-proxy can autostart there. The first MinMachines
+// machines of the region have autostop disabled so they are always running.
+type RegionScaling struct {
+	Region      string   `toml:"region,omitempty" json:"region,omitempty"`
+	MinMachines *int     `toml:"min_machines,omitempty" json:"min_machines,omitempty"`
+	MaxMachines *int     `toml:"max_machines,omitempty" json:"max_machines,omitempty"`
+	Processes   []string `toml:"processes,omitempty" json:"processes,omitempty"`
+}
+
+// RegionScalingForGroup returns the region scaling bounds that apply to the
+// given process group, keyed by region.
+func (c *Config) RegionScalingForGroup(groupName string) map[string]RegionScaling {
+	if groupName == "" {
+		groupName = c.DefaultProcessName()
+	}
+
+	bounds := make(map[string]RegionScaling)
+	for _, rs := range c.RegionScaling {
+		if c.flattenGroupsMatch(groupName, rs.Processes) {
+			bounds[rs.Region] = rs
+		}
+	}
+	return bounds
+}
+
 func (c *Config) ConfigFilePath() string {
 	return c.configFilePath
 }
@@ -240,6 +308,29 @@ func (c *Config) DockerBuildTarget() string {
 	return c.Build.DockerBuildTarget
 }
 
+// NixpacksBuilder is the builder of the build section building images with
+// Nixpacks rather than a buildpacks builder image.
+const NixpacksBuilder = "nixpacks"
+
+// UsesNixpacks reports whether the app builds its image with Nixpacks.
+func (c *Config) UsesNixpacks() bool {
+	return c != nil && c.Build != nil && c.Build.Builder == NixpacksBuilder
+}
+
+func (c *Config) BakeFile() string {
+	if c == nil || c.Build == nil {
+		return ""
+	}
+	return c.Build.BakeFile
+}
+
+func (c *Config) BakeTarget() string {
+	if c == nil || c.Build == nil {
+		return ""
+	}
+	return c.Build.BakeTarget
+}
+
 func (c *Config) InternalPort() int {
 	if c.HTTPService != nil {
 		return c.HTTPService.InternalPort
@@ -261,15 +352,23 @@ func (cfg *Config) BuildStrategies() []string {
 	if cfg.Build.Image != "" {
 		strategies = append(strategies, fmt.Sprintf("the \"%s\" docker image", cfg.Build.Image))
 	}
-	if cfg.Build.Builder != "" || len(cfg.Build.Buildpacks) > 0 {
+	if cfg.UsesNixpacks() {
+		strategies = append(strategies, "nixpacks")
+	} else if cfg.Build.Builder != "" || len(cfg.Build.Buildpacks) > 0 {
 		strategies = append(strategies, "a buildpack")
 	}
+	// A bake target builds a dockerfile, which the dockerfile settings
+	// override.
 	if cfg.Build.Dockerfile != "" || cfg.Build.DockerBuildTarget != "" {
 		if cfg.Build.Dockerfile != "" {
 			strategies = append(strategies, fmt.Sprintf("the \"%s\" dockerfile", cfg.Build.Dockerfile))
 		} else {
 			strategies = append(strategies, "a dockerfile")
 		}
+	} else if cfg.Build.BakeTarget != "" {
+		strategies = append(strategies, fmt.Sprintf("the \"%s\" bake target", cfg.Build.BakeTarget))
+	} else if cfg.Build.BakeFile != "" {
+		strategies = append(strategies, "the default bake target")
 	}
 	if cfg.Build.Builtin != "" {
 		strategies = append(strategies, fmt.Sprintf("the \"%s\" builtin image", cfg.Build.Builtin))
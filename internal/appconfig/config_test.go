@@ -276,3 +276,18 @@ func TestURL(t *testing.T) {
 	}}
 	assert.Nil(t, cfg.URL())
 }
+
+func TestNixpacksBuildStrategy(t *testing.T) {
+	cfg := Config{
+		Build: &Build{
+			Builder: NixpacksBuilder,
+		},
+	}
+
+	assert.True(t, cfg.UsesNixpacks())
+	assert.Equal(t, []string{"nixpacks"}, cfg.BuildStrategies())
+
+	cfg.Build.Builder = "heroku/buildpacks:20"
+	assert.False(t, cfg.UsesNixpacks())
+	assert.Equal(t, []string{"a buildpack"}, cfg.BuildStrategies())
+}
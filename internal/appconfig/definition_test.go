@@ -167,6 +167,8 @@ func TestToDefinition(t *testing.T) {
 			"dockerfile":   "Dockerfile",
 			"ignorefile":   ".gitignore",
 			"build-target": "target",
+			"bake_file":    "docker-bake.hcl",
+			"bake_target":  "web",
 			"buildpacks":   []any{"packme", "well"},
 			"settings": map[string]any{
 				"foo":   "bar",
@@ -176,6 +178,11 @@ func TestToDefinition(t *testing.T) {
 				"param1": "value1",
 				"param2": "value2",
 			},
+			"processes": map[string]any{
+				"task": map[string]any{
+					"dockerfile": "Dockerfile.task",
+				},
+			},
 		},
 
 		"restart": []any{
@@ -186,6 +193,15 @@ func TestToDefinition(t *testing.T) {
 			},
 		},
 
+		"region_scaling": []any{
+			map[string]any{
+				"region":       "fra",
+				"min_machines": int64(1),
+				"max_machines": int64(5),
+				"processes":    []any{"web"},
+			},
+		},
+
 		"http_service": map[string]any{
 			"internal_port":        int64(8080),
 			"force_https":          true,
@@ -242,10 +258,25 @@ func TestToDefinition(t *testing.T) {
 			"release_command": "release command",
 			"strategy":        "rolling-eyes",
 			"max_unavailable": 0.2,
+			"notifications": map[string]any{
+				"urls":   []any{"https://hooks.slack.com/services/T0/B0/X", "env:DEPLOY_WEBHOOK_URL"},
+				"events": []any{"success", "failure"},
+			},
 		},
 		"env": map[string]any{
 			"FOO": "BAR",
 		},
+		"cli": map[string]any{
+			"deploy":      "--strategy bluegreen --wait-timeout 10m",
+			"scale count": "--region sea",
+		},
+		"slo": []any{
+			map[string]any{
+				"metric": "http_5xx_ratio",
+				"target": float64(99.9),
+				"window": "720h0m0s",
+			},
+		},
 		"metrics": []any{
 			map[string]any{
 				"port": int64(9999),
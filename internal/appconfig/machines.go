@@ -143,6 +143,12 @@ func (c *Config) updateMachineConfig(src *fly.MachineConfig) (*fly.MachineConfig
 			return *s.toMachineService()
 		})
 	}
+	// Machines keeping a region's min_machines must stay up
+	if mConfig.Metadata[MachineConfigMetadataKeyRegionFloor] == "true" {
+		for i := range mConfig.Services {
+			mConfig.Services[i].Autostop = fly.Pointer(false)
+		}
+	}
 
 	// Checks
 	mConfig.Checks = nil
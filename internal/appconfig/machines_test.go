@@ -538,3 +538,17 @@ func TestToMachineConfig_hostdedicationid(t *testing.T) {
 		})
 	}
 }
+
+func TestToMachineConfig_regionFloor(t *testing.T) {
+	cfg, err := LoadConfig("./testdata/tomachine-services.toml")
+	require.NoError(t, err)
+
+	src := &fly.MachineConfig{
+		Metadata: map[string]string{MachineConfigMetadataKeyRegionFloor: "true"},
+	}
+	got, err := cfg.ToMachineConfig("", src)
+	require.NoError(t, err)
+	for _, s := range got.Services {
+		assert.Equal(t, fly.Pointer(false), s.Autostop)
+	}
+}
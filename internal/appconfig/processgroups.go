@@ -169,6 +169,14 @@ func (c *Config) Flatten(groupName string) (*Config, error) {
 		dst.Restart[i].Processes = []string{groupName}
 	}
 
+	// [[region_scaling]]
+	dst.RegionScaling = lo.Filter(dst.RegionScaling, func(x RegionScaling, _ int) bool {
+		return matchesGroups(x.Processes)
+	})
+	for i := range dst.RegionScaling {
+		dst.RegionScaling[i].Processes = []string{groupName}
+	}
+
 	// [[vm]]
 	compute := dst.ComputeForGroup(groupName)
 
@@ -226,3 +234,27 @@ func (c *Config) InitCmd(groupName string) ([]string, error) {
 	}
 	return cmd, nil
 }
+
+// ProcessGroupBuilds returns the sorted names of the process groups with a
+// build of their own.
+func (c *Config) ProcessGroupBuilds() []string {
+	if c == nil || c.Build == nil {
+		return nil
+	}
+	groups := lo.Keys(c.Build.Processes)
+	slices.Sort(groups)
+	return groups
+}
+
+// ForProcessGroupBuild returns a copy of the config whose build section is
+// the build of the process group.
+func (c *Config) ForProcessGroupBuild(group string) *Config {
+	dst := helpers.Clone(c)
+	dst.configFilePath = c.configFilePath
+	dst.defaultGroupName = c.defaultGroupName
+	dst.Build = helpers.Clone(c.Build.Processes[group])
+	if dst.Build != nil {
+		dst.Build.Processes = nil
+	}
+	return dst
+}
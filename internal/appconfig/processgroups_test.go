@@ -73,3 +73,38 @@ func TestProcessNames(t *testing.T) {
 		})
 	}
 }
+
+func TestForProcessGroupBuild(t *testing.T) {
+	cfg := &Config{
+		AppName: "my-app",
+		Processes: map[string]string{
+			"web":    "run web",
+			"worker": "run worker",
+		},
+		Build: &Build{
+			Dockerfile: "Dockerfile",
+			Processes: map[string]*Build{
+				"worker": {Dockerfile: "worker/Dockerfile", DockerBuildTarget: "worker"},
+			},
+		},
+		configFilePath: "/src/fly.toml",
+	}
+
+	assert.Equal(t, []string{"worker"}, cfg.ProcessGroupBuilds())
+
+	worker := cfg.ForProcessGroupBuild("worker")
+	assert.Equal(t, "my-app", worker.AppName)
+	assert.Equal(t, "/src/fly.toml", worker.ConfigFilePath())
+	assert.Equal(t, "worker/Dockerfile", worker.Dockerfile())
+	assert.Equal(t, "worker", worker.DockerBuildTarget())
+	assert.Empty(t, worker.ProcessGroupBuilds())
+	assert.Equal(t, "Dockerfile", cfg.Dockerfile())
+
+	_, err := cfg.validateProcessGroupBuilds()
+	require.NoError(t, err)
+
+	cfg.Build.Processes["cron"] = &Build{Dockerfile: "cron/Dockerfile"}
+	info, err := cfg.validateProcessGroupBuilds()
+	assert.ErrorIs(t, err, ValidationError)
+	assert.Contains(t, info, "build.processes.cron builds the image of an undefined process group")
+}
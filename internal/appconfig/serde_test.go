@@ -366,6 +366,15 @@ func TestLoadTOMLAppConfigReferenceFormat(t *testing.T) {
 			},
 		},
 
+		RegionScaling: []RegionScaling{
+			{
+				Region:      "fra",
+				MinMachines: fly.Pointer(1),
+				MaxMachines: fly.Pointer(5),
+				Processes:   []string{"web"},
+			},
+		},
+
 		Build: &Build{
 			Builder:           "dockerfile",
 			Image:             "foo/fighter",
@@ -373,6 +382,8 @@ func TestLoadTOMLAppConfigReferenceFormat(t *testing.T) {
 			Dockerfile:        "Dockerfile",
 			Ignorefile:        ".gitignore",
 			DockerBuildTarget: "target",
+			BakeFile:          "docker-bake.hcl",
+			BakeTarget:        "web",
 			Buildpacks:        []string{"packme", "well"},
 			Settings: map[string]any{
 				"foo":   "bar",
@@ -383,18 +394,37 @@ func TestLoadTOMLAppConfigReferenceFormat(t *testing.T) {
 				"param1": "value1",
 				"param2": "value2",
 			},
+
+			Processes: map[string]*Build{
+				"task": {Dockerfile: "Dockerfile.task"},
+			},
 		},
 
 		Deploy: &Deploy{
 			ReleaseCommand: "release command",
 			Strategy:       "rolling-eyes",
 			MaxUnavailable: fly.Pointer(0.2),
+			Notifications: &DeployNotifications{
+				URLs:   []string{"https://hooks.slack.com/services/T0/B0/X", "env:DEPLOY_WEBHOOK_URL"},
+				Events: []string{"success", "failure"},
+			},
 		},
 
 		Env: map[string]string{
 			"FOO": "BAR",
 		},
 
+		CLI: map[string]string{
+			"deploy":      "--strategy bluegreen --wait-timeout 10m",
+			"scale count": "--region sea",
+		},
+
+		SLOs: []SLO{{
+			Metric: "http_5xx_ratio",
+			Target: 99.9,
+			Window: fly.MustParseDuration("720h"),
+		}},
+
 		Metrics: []*Metrics{
 			{
 				MachineMetrics: &fly.MachineMetrics{
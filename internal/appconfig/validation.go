@@ -10,15 +10,16 @@ import (
 
 	"github.com/docker/go-units"
 	"github.com/google/shlex"
-	"github.com/logrusorgru/aurora"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/sentry"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 var (
 	ValidationError          = errors.New("invalid app configuration")
 	MachinesDeployStrategies = []string{"canary", "rolling", "immediate", "bluegreen"}
+	DeployNotificationEvents = []string{"start", "success", "failure"}
 )
 
 func (cfg *Config) Validate(ctx context.Context) (err error, extra_info string) {
@@ -28,14 +29,18 @@ func (cfg *Config) Validate(ctx context.Context) (err error, extra_info string)
 
 	validators := []func() (string, error){
 		cfg.validateBuildStrategies,
+		cfg.validateProcessGroupBuilds,
 		cfg.validateDeploySection,
 		cfg.validateChecksSection,
 		cfg.validateServicesSection,
 		cfg.validateProcessesSection,
 		cfg.validateMachineConversion,
 		cfg.validateConsoleCommand,
+		cfg.validateCLISection,
+		cfg.validateSLOs,
 		cfg.validateMounts,
 		cfg.validateRestartPolicy,
+		cfg.validateRegionScaling,
 	}
 
 	extra_info = fmt.Sprintf("Validating %s\n", cfg.ConfigFilePath())
@@ -53,11 +58,11 @@ func (cfg *Config) Validate(ctx context.Context) (err error, extra_info string)
 	}
 
 	if err != nil {
-		extra_info += fmt.Sprintf("\n   %s%s\n", aurora.Red("✘"), err)
+		extra_info += fmt.Sprintf("\n   %s%s\n", iostreams.Aurora().Red("✘"), err)
 		return errors.New("App configuration is not valid"), extra_info
 	}
 
-	extra_info += fmt.Sprintf("%s Configuration is valid\n", aurora.Green("✓"))
+	extra_info += fmt.Sprintf("%s Configuration is valid\n", iostreams.Aurora().Green("✓"))
 	return nil, extra_info
 }
 
@@ -83,13 +88,28 @@ func (cfg *Config) validateBuildStrategies() (extraInfo string, err error) {
 	buildStrats := cfg.BuildStrategies()
 	if len(buildStrats) > 1 {
 		// TODO: validate that most users are not affected by this and/or fixing this, then make it fail validation
-		msg := fmt.Sprintf("%s more than one build configuration found: [%s]", aurora.Yellow("WARN"), strings.Join(buildStrats, ", "))
+		msg := fmt.Sprintf("%s more than one build configuration found: [%s]", iostreams.Aurora().Yellow("WARN"), strings.Join(buildStrats, ", "))
 		extraInfo += msg + "\n"
 		sentry.CaptureException(errors.New(msg))
 	}
 	return
 }
 
+func (cfg *Config) validateProcessGroupBuilds() (extraInfo string, err error) {
+	groups := cfg.ProcessNames()
+	for _, group := range cfg.ProcessGroupBuilds() {
+		if !slices.Contains(groups, group) {
+			extraInfo += fmt.Sprintf("build.processes.%s builds the image of an undefined process group\n", group)
+			err = ValidationError
+		}
+		if len(cfg.Build.Processes[group].Processes) > 0 {
+			extraInfo += fmt.Sprintf("build.processes.%s can't have builds of its own\n", group)
+			err = ValidationError
+		}
+	}
+	return
+}
+
 func (cfg *Config) validateDeploySection() (extraInfo string, err error) {
 	if cfg.Deploy == nil {
 		return
@@ -115,6 +135,24 @@ func (cfg *Config) validateDeploySection() (extraInfo string, err error) {
 		}
 	}
 
+	if n := cfg.Deploy.Notifications; n != nil {
+		for _, u := range n.URLs {
+			if !strings.HasPrefix(u, "env:") && !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
+				extraInfo += fmt.Sprintf("deploy.notifications.urls: '%s' must be an http(s) URL or env:VARIABLE\n", u)
+				err = ValidationError
+			}
+		}
+		for _, e := range n.Events {
+			if !slices.Contains(DeployNotificationEvents, e) {
+				extraInfo += fmt.Sprintf(
+					"deploy.notifications.events: unsupported event '%s'; supported events are: %s\n", e,
+					strings.Join(DeployNotificationEvents, ", "),
+				)
+				err = ValidationError
+			}
+		}
+	}
+
 	return
 }
 
@@ -178,6 +216,9 @@ func (cfg *Config) validateServicesSection() (extraInfo string, err error) {
 			//err = ValidationError
 		}
 
+		// The proxy copes with odd limits, so they only warrant a warning
+		extraInfo += validateServiceConcurrency(service.Concurrency)
+
 		for _, check := range service.TCPChecks {
 			extraInfo += validateServiceCheckDurations(check.Interval, check.Timeout, check.GracePeriod, "TCP")
 		}
@@ -189,6 +230,22 @@ func (cfg *Config) validateServicesSection() (extraInfo string, err error) {
 	return extraInfo, err
 }
 
+func validateServiceConcurrency(c *fly.MachineServiceConcurrency) (extraInfo string) {
+	if c == nil {
+		return ""
+	}
+	if c.Type != "" && c.Type != "connections" && c.Type != "requests" {
+		extraInfo += fmt.Sprintf("Service concurrency type '%s' is invalid, must be 'connections' or 'requests'\n", c.Type)
+	}
+	if c.SoftLimit < 0 || c.HardLimit < 0 {
+		extraInfo += "Service concurrency limits can't be negative\n"
+	}
+	if c.SoftLimit > 0 && c.HardLimit > 0 && c.SoftLimit > c.HardLimit {
+		extraInfo += fmt.Sprintf("Service concurrency soft_limit %d is above its hard_limit %d\n", c.SoftLimit, c.HardLimit)
+	}
+	return extraInfo
+}
+
 func validateServiceCheckDurations(interval, timeout, gracePeriod *fly.Duration, proto string) (extraInfo string) {
 	extraInfo += validateSingleServiceCheckDuration(interval, false, proto, "an interval")
 	extraInfo += validateSingleServiceCheckDuration(timeout, false, proto, "a timeout")
@@ -203,17 +260,17 @@ func validateSingleServiceCheckDuration(d *fly.Duration, zeroOK bool, proto, des
 	case zeroOK && d.Duration != 0 && d.Duration < time.Second:
 		extraInfo += fmt.Sprintf(
 			"%s Service %s check has %s that is non-zero and less than 1 second (%v); this will be raised to 1 second\n",
-			aurora.Yellow("WARN"), proto, description, d.Duration,
+			iostreams.Aurora().Yellow("WARN"), proto, description, d.Duration,
 		)
 	case !zeroOK && d.Duration < time.Second:
 		extraInfo += fmt.Sprintf(
 			"%s Service %s check has %s less than 1 second (%v); this will be raised to 1 second\n",
-			aurora.Yellow("WARN"), proto, description, d.Duration,
+			iostreams.Aurora().Yellow("WARN"), proto, description, d.Duration,
 		)
 	case d.Duration > time.Minute:
 		extraInfo += fmt.Sprintf(
 			"%s Service %s check has %s greater than 1 minute (%v); this will be lowered to 1 minute\n",
-			aurora.Yellow("WARN"), proto, description, d.Duration,
+			iostreams.Aurora().Yellow("WARN"), proto, description, d.Duration,
 		)
 	}
 	return
@@ -256,6 +313,47 @@ func (cfg *Config) validateConsoleCommand() (extraInfo string, err error) {
 	return
 }
 
+func (cfg *Config) validateCLISection() (extraInfo string, err error) {
+	for command, flags := range cfg.CLI {
+		args, vErr := shlex.Split(flags)
+		if vErr != nil {
+			extraInfo += fmt.Sprintf("Can't shell split the default flags of '%s' in [cli]: '%s'\n", command, flags)
+			err = ValidationError
+			continue
+		}
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			extraInfo += fmt.Sprintf("The defaults of '%s' in [cli] must be flags, such as '--strategy bluegreen', not '%s'\n", command, flags)
+			err = ValidationError
+		}
+	}
+	return
+}
+
+func (cfg *Config) validateSLOs() (extraInfo string, err error) {
+	seen := map[string]bool{}
+	for _, slo := range cfg.SLOs {
+		if slo.Metric == "" {
+			extraInfo += "Each [[slo]] must have a metric\n"
+			err = ValidationError
+			continue
+		}
+		if seen[slo.Metric] {
+			extraInfo += fmt.Sprintf("The %s metric has more than one [[slo]]\n", slo.Metric)
+			err = ValidationError
+		}
+		seen[slo.Metric] = true
+		if slo.Target <= 0 || slo.Target >= 100 {
+			extraInfo += fmt.Sprintf("The target of the %s [[slo]] must be a percentage between 0 and 100, such as 99.9, not %g\n", slo.Metric, slo.Target)
+			err = ValidationError
+		}
+		if slo.Window != nil && slo.Window.Duration < 24*time.Hour {
+			extraInfo += fmt.Sprintf("The window of the %s [[slo]] must be at least 24h, not %s\n", slo.Metric, slo.Window)
+			err = ValidationError
+		}
+	}
+	return
+}
+
 func (cfg *Config) validateMounts() (extraInfo string, err error) {
 	if cfg.configFilePath == "--flatten--" && len(cfg.Mounts) > 1 {
 		extraInfo += fmt.Sprintf("group '%s' has more than one [[mounts]] section defined\n", cfg.defaultGroupName)
@@ -355,3 +453,53 @@ func (cfg *Config) validateRestartPolicy() (extraInfo string, err error) {
 
 	return
 }
+
+func (cfg *Config) validateRegionScaling() (extraInfo string, err error) {
+	validGroupNames := cfg.ProcessNames()
+	seen := make(map[string]bool)
+
+	for _, rs := range cfg.RegionScaling {
+		if rs.Region == "" {
+			extraInfo += "Region scaling entries must specify a region\n"
+			err = ValidationError
+			continue
+		}
+
+		for _, processName := range rs.Processes {
+			if !slices.Contains(validGroupNames, processName) {
+				extraInfo += fmt.Sprintf("Region scaling for '%s' specifies '%s' as one of its processes, but no processes are defined with that name\n",
+					rs.Region, processName,
+				)
+				err = ValidationError
+			}
+		}
+
+		groups := rs.Processes
+		if len(groups) == 0 {
+			groups = validGroupNames
+		}
+		for _, group := range groups {
+			key := group + "/" + rs.Region
+			if seen[key] {
+				extraInfo += fmt.Sprintf("Region scaling for process group '%s' in region '%s' is defined more than once\n", group, rs.Region)
+				err = ValidationError
+			}
+			seen[key] = true
+		}
+
+		switch {
+		case rs.MinMachines != nil && *rs.MinMachines < 0:
+			extraInfo += fmt.Sprintf("Region scaling for '%s' has a negative min_machines\n", rs.Region)
+			err = ValidationError
+		case rs.MaxMachines != nil && *rs.MaxMachines < 0:
+			extraInfo += fmt.Sprintf("Region scaling for '%s' has a negative max_machines\n", rs.Region)
+			err = ValidationError
+		case rs.MinMachines != nil && rs.MaxMachines != nil && *rs.MinMachines > *rs.MaxMachines:
+			extraInfo += fmt.Sprintf("Region scaling for '%s' has min_machines (%d) greater than max_machines (%d)\n",
+				rs.Region, *rs.MinMachines, *rs.MaxMachines)
+			err = ValidationError
+		}
+	}
+
+	return
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/cmdutil/preparers"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/logger"
@@ -71,3 +72,64 @@ func TestConfig_ValidateServices(t *testing.T) {
 	err, x = cfg.ValidateGroups(ctx, []string{"success"})
 	require.NoErrorf(t, err, x)
 }
+
+func TestConfig_ValidateConcurrency(t *testing.T) {
+	cfg, err := LoadConfig("./testdata/validate-concurrency.toml")
+	require.NoError(t, err)
+	require.NoError(t, cfg.SetMachinesPlatform())
+
+	ctx := _getValidationContext(t)
+	err, x := cfg.Validate(ctx)
+	require.NoError(t, err, "concurrency issues are warnings")
+	require.Contains(t, x, "Service concurrency type 'sessions' is invalid")
+	require.Contains(t, x, "soft_limit 50 is above its hard_limit 25")
+	require.NotContains(t, x, "soft_limit 20")
+}
+
+func TestConfig_ValidateDeployNotifications(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Deploy = &Deploy{Notifications: &DeployNotifications{
+		URLs:   []string{"https://example.com/hook", "env:HOOK_URL", "example.com/hook"},
+		Events: []string{"success", "rollback"},
+	}}
+
+	x, err := cfg.validateDeploySection()
+	require.ErrorIs(t, err, ValidationError)
+	require.Contains(t, x, "'example.com/hook' must be an http(s) URL or env:VARIABLE")
+	require.Contains(t, x, "unsupported event 'rollback'")
+	require.NotContains(t, x, "HOOK_URL")
+}
+
+func TestConfig_ValidateCLISection(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CLI = map[string]string{
+		"deploy":      "--strategy bluegreen --wait-timeout 10m",
+		"scale count": "count 2",
+		"logs":        `--region "sea`,
+	}
+
+	x, err := cfg.validateCLISection()
+	require.ErrorIs(t, err, ValidationError)
+	require.Contains(t, x, "The defaults of 'scale count' in [cli] must be flags")
+	require.Contains(t, x, "Can't shell split the default flags of 'logs' in [cli]")
+	require.NotContains(t, x, "'deploy'")
+}
+
+func TestConfig_ValidateSLOs(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SLOs = []SLO{
+		{Metric: "http_5xx_ratio", Target: 99.9},
+		{Metric: "http_5xx_ratio", Target: 100},
+		{Metric: "latency", Target: 99, Window: fly.MustParseDuration("1h")},
+	}
+
+	x, err := cfg.validateSLOs()
+	require.ErrorIs(t, err, ValidationError)
+	require.Contains(t, x, "The http_5xx_ratio metric has more than one [[slo]]")
+	require.Contains(t, x, "must be a percentage between 0 and 100, such as 99.9, not 100")
+	require.Contains(t, x, "The window of the latency [[slo]] must be at least 24h")
+
+	cfg.SLOs = cfg.SLOs[:1]
+	_, err = cfg.validateSLOs()
+	require.NoError(t, err)
+}
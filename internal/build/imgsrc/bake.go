@@ -0,0 +1,226 @@
+package imgsrc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cli/safeexec"
+	"github.com/superfly/flyctl/helpers"
+)
+
+// bakeFileNames are the bake files looked up in the working directory when
+// none is given, in the order docker buildx bake reads them.
+var bakeFileNames = []string{"docker-bake.json", "docker-bake.hcl"}
+
+// ResolveBakeFile returns the bake file of the working directory cwd, or an
+// empty path when there's none.
+func ResolveBakeFile(cwd string) string {
+	for _, name := range bakeFileNames {
+		if path := filepath.Join(cwd, name); helpers.FileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// BakeTarget is a target of a docker buildx bake file, with its paths made
+// absolute.
+type BakeTarget struct {
+	Name       string
+	Context    string
+	Dockerfile string
+	Target     string
+	Args       map[string]string
+	Labels     map[string]string
+	NoCache    bool
+	CacheFrom  []string
+	CacheTo    []string
+}
+
+// bakePrint is the part of the output of docker buildx bake --print used
+// here.
+type bakePrint struct {
+	Target map[string]struct {
+		Context    string            `json:"context"`
+		Dockerfile string            `json:"dockerfile"`
+		Target     string            `json:"target"`
+		Args       map[string]string `json:"args"`
+		Labels     map[string]string `json:"labels"`
+		NoCache    bool              `json:"no-cache"`
+		CacheFrom  []bakeCacheEntry  `json:"cache-from"`
+		CacheTo    []bakeCacheEntry  `json:"cache-to"`
+	} `json:"target"`
+}
+
+// bakeCacheEntry is a cache option, which buildx prints as a string or, in
+// later versions, as an object of its attributes.
+type bakeCacheEntry string
+
+func (e *bakeCacheEntry) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*e = bakeCacheEntry(s)
+		return nil
+	}
+
+	var attrs map[string]string
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		return err
+	}
+	fields := make([]string, 0, len(attrs))
+	if t, ok := attrs["type"]; ok {
+		fields = append(fields, "type="+t)
+		delete(attrs, "type")
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = append(fields, k+"="+attrs[k])
+	}
+	*e = bakeCacheEntry(strings.Join(fields, ","))
+	return nil
+}
+
+// LoadBakeTarget resolves the target name of the bake file path, or the
+// default target when name is empty, with docker buildx bake --print, so that
+// the whole bake syntax is supported. A group resolves to its target when it
+// has a single one.
+func LoadBakeTarget(ctx context.Context, path, name string) (*BakeTarget, error) {
+	docker, err := safeexec.LookPath("docker")
+	if err != nil {
+		return nil, errors.New("building from a bake target needs docker with the buildx plugin installed")
+	}
+
+	args := []string{"buildx", "bake", "--file", filepath.Base(path), "--print"}
+	if name != "" {
+		args = append(args, name)
+	}
+	cmd := exec.CommandContext(ctx, docker, args...)
+	cmd.Dir = filepath.Dir(path)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed resolving bake target of %s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseBakePrint(stdout.Bytes(), path, name)
+}
+
+// parseBakePrint returns the single target printed by docker buildx bake
+// --print for name, run next to the bake file path.
+func parseBakePrint(data []byte, path, name string) (*BakeTarget, error) {
+	var printed bakePrint
+	if err := json.Unmarshal(data, &printed); err != nil {
+		return nil, fmt.Errorf("failed parsing bake targets of %s: %w", path, err)
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	switch len(printed.Target) {
+	case 0:
+		return nil, fmt.Errorf("bake file %s has no target %s", path, name)
+	case 1:
+	default:
+		names := make([]string, 0, len(printed.Target))
+		for n := range printed.Target {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("bake target %s is a group of %s, pick one of them to build the image of the app", name, strings.Join(names, ", "))
+	}
+
+	t := &BakeTarget{
+		Context:    ".",
+		Dockerfile: "Dockerfile",
+		Args:       map[string]string{},
+		Labels:     map[string]string{},
+	}
+	for n, def := range printed.Target {
+		t.Name = n
+		if def.Context != "" {
+			t.Context = def.Context
+		}
+		if def.Dockerfile != "" {
+			t.Dockerfile = def.Dockerfile
+		}
+		t.Target = def.Target
+		t.NoCache = def.NoCache
+		for k, v := range def.Args {
+			t.Args[k] = v
+		}
+		for k, v := range def.Labels {
+			t.Labels[k] = v
+		}
+		for _, c := range def.CacheFrom {
+			t.CacheFrom = append(t.CacheFrom, string(c))
+		}
+		for _, c := range def.CacheTo {
+			t.CacheTo = append(t.CacheTo, string(c))
+		}
+	}
+
+	// Like buildx, the context is relative to where bake ran, next to the
+	// bake file, and the Dockerfile to the context.
+	if !filepath.IsAbs(t.Context) {
+		t.Context = filepath.Join(filepath.Dir(path), t.Context)
+	}
+	if !filepath.IsAbs(t.Dockerfile) {
+		t.Dockerfile = filepath.Join(t.Context, t.Dockerfile)
+	}
+	var err error
+	if t.Context, err = filepath.Abs(t.Context); err != nil {
+		return nil, err
+	}
+	if t.Dockerfile, err = filepath.Abs(t.Dockerfile); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func overlayMap(base, over map[string]string) map[string]string {
+	if len(over) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(over))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range over {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Apply sets the options of the image build of the target where opts
+// doesn't set them already, so that fly.toml and flags take precedence.
+func (t *BakeTarget) Apply(opts *ImageOptions) {
+	opts.WorkingDir = t.Context
+	if opts.DockerfilePath == "" {
+		opts.DockerfilePath = t.Dockerfile
+	}
+	if opts.Target == "" {
+		opts.Target = t.Target
+	}
+	opts.NoCache = opts.NoCache || t.NoCache
+	if len(opts.CacheFrom) == 0 {
+		opts.CacheFrom = t.CacheFrom
+	}
+	if len(opts.CacheTo) == 0 {
+		opts.CacheTo = t.CacheTo
+	}
+	opts.BuildArgs = overlayMap(t.Args, opts.BuildArgs)
+	opts.Label = overlayMap(t.Labels, opts.Label)
+}
@@ -0,0 +1,143 @@
+package imgsrc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webBakePrint is the output of docker buildx bake --print web, with the
+// cache options in the object form of later buildx versions.
+const webBakePrint = `{
+  "group": {
+    "default": {"targets": ["web"]}
+  },
+  "target": {
+    "web": {
+      "context": "app",
+      "dockerfile": "web.Dockerfile",
+      "args": {"NODE_VERSION": "20", "MODE": "web"},
+      "labels": {"org.opencontainers.image.version": "v1.2.3"},
+      "target": "runtime",
+      "no-cache": true,
+      "cache-from": [{"type": "registry", "ref": "registry.fly.io/my-app:cache"}],
+      "cache-to": ["type=inline"]
+    }
+  }
+}`
+
+func TestParseBakePrint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker-bake.hcl")
+
+	target, err := parseBakePrint([]byte(webBakePrint), path, "web")
+	require.NoError(t, err)
+	assert.Equal(t, &BakeTarget{
+		Name:       "web",
+		Context:    filepath.Join(dir, "app"),
+		Dockerfile: filepath.Join(dir, "app", "web.Dockerfile"),
+		Target:     "runtime",
+		Args:       map[string]string{"NODE_VERSION": "20", "MODE": "web"},
+		Labels:     map[string]string{"org.opencontainers.image.version": "v1.2.3"},
+		NoCache:    true,
+		CacheFrom:  []string{"type=registry,ref=registry.fly.io/my-app:cache"},
+		CacheTo:    []string{"type=inline"},
+	}, target)
+
+	// The default group resolves to its single target.
+	target, err = parseBakePrint([]byte(webBakePrint), path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "web", target.Name)
+
+	target, err = parseBakePrint([]byte(`{"target": {"worker": {}}}`), path, "worker")
+	require.NoError(t, err)
+	assert.Equal(t, dir, target.Context)
+	assert.Equal(t, filepath.Join(dir, "Dockerfile"), target.Dockerfile)
+
+	_, err = parseBakePrint([]byte(`{"target": {"web": {}, "worker": {}}}`), path, "")
+	assert.EqualError(t, err, "bake target default is a group of web, worker, pick one of them to build the image of the app")
+
+	_, err = parseBakePrint([]byte(`{}`), path, "missing")
+	assert.ErrorContains(t, err, "has no target missing")
+
+	_, err = parseBakePrint([]byte(`ERROR: failed`), path, "web")
+	assert.ErrorContains(t, err, "failed parsing bake targets")
+}
+
+// fakeDocker puts a docker script printing output first in PATH, which
+// records its working directory and arguments in the file it returns.
+func fakeDocker(t *testing.T, output string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker is a shell script")
+	}
+
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "output.json"), []byte(output), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker"), []byte(`#!/bin/sh
+echo "$(pwd)" "$@" > `+calls+`
+cat `+filepath.Join(dir, "output.json")+`
+`), 0o755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return calls
+}
+
+func TestLoadBakeTarget(t *testing.T) {
+	calls := fakeDocker(t, webBakePrint)
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	path := filepath.Join(dir, "docker-bake.hcl")
+
+	target, err := LoadBakeTarget(context.Background(), path, "web")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "app", "web.Dockerfile"), target.Dockerfile)
+
+	call, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, dir+" buildx bake --file docker-bake.hcl --print web\n", string(call))
+
+	_, err = LoadBakeTarget(context.Background(), path, "")
+	require.NoError(t, err)
+	call, err = os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, dir+" buildx bake --file docker-bake.hcl --print\n", string(call))
+}
+
+func TestBakeTargetApply(t *testing.T) {
+	target := &BakeTarget{
+		Context:    "/src/app",
+		Dockerfile: "/src/app/Dockerfile",
+		Target:     "runtime",
+		Args:       map[string]string{"A": "bake", "B": "bake"},
+		Labels:     map[string]string{"l": "bake"},
+		CacheFrom:  []string{"registry.fly.io/my-app:cache"},
+	}
+
+	opts := ImageOptions{
+		WorkingDir: "/src",
+		Target:     "debug",
+		BuildArgs:  map[string]string{"B": "cli"},
+	}
+	target.Apply(&opts)
+
+	assert.Equal(t, "/src/app", opts.WorkingDir)
+	assert.Equal(t, "/src/app/Dockerfile", opts.DockerfilePath)
+	assert.Equal(t, "debug", opts.Target)
+	assert.Equal(t, map[string]string{"A": "bake", "B": "cli"}, opts.BuildArgs)
+	assert.Equal(t, map[string]string{"l": "bake"}, opts.Label)
+	assert.Equal(t, []string{"registry.fly.io/my-app:cache"}, opts.CacheFrom)
+}
+
+func TestResolveBakeFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, "", ResolveBakeFile(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docker-bake.hcl"), nil, 0o644))
+	assert.Equal(t, filepath.Join(dir, "docker-bake.hcl"), ResolveBakeFile(dir))
+}
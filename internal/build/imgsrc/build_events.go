@@ -0,0 +1,146 @@
+package imgsrc
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/buildkit/client"
+)
+
+// buildEvent is a progress event of a build or push written in JSON progress
+// mode, one per line, for CI wrappers to consume.
+type buildEvent struct {
+	// Type is stage, log, push or image.
+	Type  string    `json:"type"`
+	Time  time.Time `json:"time"`
+	Stage string    `json:"stage,omitempty"`
+	// Status is started, finished, cached or failed for stages, and
+	// pushing or pushed for layers.
+	Status  string `json:"status,omitempty"`
+	Layer   string `json:"layer,omitempty"`
+	Percent int    `json:"percent,omitempty"`
+	ImageID string `json:"image_id,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// buildEventWriter translates the progress of BuildKit and of the Docker
+// daemon into build events.
+type buildEventWriter struct {
+	enc *json.Encoder
+	now func() time.Time
+
+	// vertexes tracks the BuildKit vertexes which started and finished.
+	vertexes map[string]*vertexProgress
+	// stage is the current step of a classic build.
+	stage string
+}
+
+type vertexProgress struct {
+	name      string
+	started   bool
+	completed bool
+}
+
+func newBuildEventWriter(out io.Writer) *buildEventWriter {
+	return &buildEventWriter{
+		enc:      json.NewEncoder(out),
+		now:      time.Now,
+		vertexes: map[string]*vertexProgress{},
+	}
+}
+
+func (w *buildEventWriter) emit(e buildEvent) {
+	e.Time = w.now().UTC()
+	_ = w.enc.Encode(e)
+}
+
+// solveStatus emits the stage and log events of a BuildKit status update.
+func (w *buildEventWriter) solveStatus(s *client.SolveStatus) {
+	for _, v := range s.Vertexes {
+		p, ok := w.vertexes[v.Digest.String()]
+		if !ok {
+			p = &vertexProgress{}
+			w.vertexes[v.Digest.String()] = p
+		}
+		p.name = v.Name
+
+		if v.Started != nil && !p.started {
+			p.started = true
+			w.emit(buildEvent{Type: "stage", Stage: v.Name, Status: "started"})
+		}
+		if v.Completed != nil && !p.completed {
+			p.completed = true
+			e := buildEvent{Type: "stage", Stage: v.Name, Status: "finished"}
+			switch {
+			case v.Error != "":
+				e.Status = "failed"
+				e.Message = v.Error
+			case v.Cached:
+				e.Status = "cached"
+			}
+			w.emit(e)
+		}
+	}
+
+	for _, l := range s.Logs {
+		var stage string
+		if p, ok := w.vertexes[l.Vertex.String()]; ok {
+			stage = p.name
+		}
+		if msg := strings.TrimRight(string(l.Data), "\n"); msg != "" {
+			w.emit(buildEvent{Type: "log", Stage: stage, Message: msg})
+		}
+	}
+}
+
+var classicStepPattern = regexp.MustCompile(`^Step \d+/\d+ : `)
+
+// jsonMessage emits the events of a message of the Docker daemon's build or
+// push stream.
+func (w *buildEventWriter) jsonMessage(msg jsonmessage.JSONMessage) {
+	switch {
+	case msg.Aux != nil:
+		var aux struct {
+			ID     string
+			Digest string
+		}
+		if err := json.Unmarshal(*msg.Aux, &aux); err == nil && (aux.ID != "" || aux.Digest != "") {
+			w.emit(buildEvent{Type: "image", ImageID: aux.ID, Digest: aux.Digest})
+		}
+	case msg.Stream != "":
+		line := strings.TrimRight(msg.Stream, "\n")
+		if classicStepPattern.MatchString(line) {
+			w.finishStage("finished")
+			w.stage = classicStepPattern.ReplaceAllString(line, "")
+			w.emit(buildEvent{Type: "stage", Stage: w.stage, Status: "started"})
+		} else if line != "" {
+			w.emit(buildEvent{Type: "log", Stage: w.stage, Message: line})
+		}
+	case msg.ID != "":
+		e := buildEvent{Type: "push", Layer: msg.ID, Status: "pushing", Message: msg.Status}
+		switch {
+		case msg.Status == "Pushed" || msg.Status == "Layer already exists":
+			e.Status = "pushed"
+			e.Percent = 100
+		case msg.Progress != nil && msg.Progress.Total > 0:
+			e.Percent = int(msg.Progress.Current * 100 / msg.Progress.Total)
+		}
+		w.emit(e)
+	case msg.Status != "":
+		w.emit(buildEvent{Type: "log", Stage: w.stage, Message: msg.Status})
+	}
+}
+
+// finishStage emits the end of the current step of a classic build.
+func (w *buildEventWriter) finishStage(status string) {
+	if w.stage == "" {
+		return
+	}
+	w.emit(buildEvent{Type: "stage", Stage: w.stage, Status: status})
+	w.stage = ""
+}
@@ -0,0 +1,75 @@
+package imgsrc
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeBuildEvents(t *testing.T, out string) []buildEvent {
+	var events []buildEvent
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e buildEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		e.Time = time.Time{}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestBuildEventsSolveStatus(t *testing.T) {
+	var out bytes.Buffer
+	w := newBuildEventWriter(&out)
+
+	now := time.Now()
+	w.solveStatus(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:a", Name: "[1/2] FROM alpine", Started: &now},
+			{Digest: "sha256:b", Name: "[2/2] RUN make", Started: &now},
+		},
+		Logs: []*client.VertexLog{{Vertex: "sha256:b", Data: []byte("cc main.c\n")}},
+	})
+	w.solveStatus(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: "sha256:a", Name: "[1/2] FROM alpine", Started: &now, Completed: &now, Cached: true},
+			{Digest: "sha256:b", Name: "[2/2] RUN make", Started: &now, Completed: &now, Error: "exit code 2"},
+		},
+	})
+
+	assert.Equal(t, []buildEvent{
+		{Type: "stage", Stage: "[1/2] FROM alpine", Status: "started"},
+		{Type: "stage", Stage: "[2/2] RUN make", Status: "started"},
+		{Type: "log", Stage: "[2/2] RUN make", Message: "cc main.c"},
+		{Type: "stage", Stage: "[1/2] FROM alpine", Status: "cached"},
+		{Type: "stage", Stage: "[2/2] RUN make", Status: "failed", Message: "exit code 2"},
+	}, decodeBuildEvents(t, out.String()))
+}
+
+func TestBuildEventsPush(t *testing.T) {
+	var out bytes.Buffer
+	w := newBuildEventWriter(&out)
+
+	aux := json.RawMessage(`{"Tag":"latest","Digest":"sha256:abc","Size":1234}`)
+	for _, msg := range []jsonmessage.JSONMessage{
+		{Status: "The push refers to repository [registry.fly.io/app]"},
+		{ID: "5f70bf18a086", Status: "Pushing", Progress: &jsonmessage.JSONProgress{Current: 512, Total: 2048}},
+		{ID: "5f70bf18a086", Status: "Pushed"},
+		{Aux: &aux},
+	} {
+		w.jsonMessage(msg)
+	}
+
+	assert.Equal(t, []buildEvent{
+		{Type: "log", Message: "The push refers to repository [registry.fly.io/app]"},
+		{Type: "push", Layer: "5f70bf18a086", Status: "pushing", Percent: 25, Message: "Pushing"},
+		{Type: "push", Layer: "5f70bf18a086", Status: "pushed", Percent: 100, Message: "Pushed"},
+		{Type: "image", Digest: "sha256:abc"},
+	}, decodeBuildEvents(t, out.String()))
+}
@@ -0,0 +1,62 @@
+package imgsrc
+
+import "github.com/superfly/flyctl/gql"
+
+// BuildInfo describes how an image was built, from what the build tracker
+// recorded, for scripts consuming the result of builds.
+type BuildInfo struct {
+	// ID is the ID of the build on the platform, empty when it couldn't be
+	// recorded there.
+	ID string `json:"id,omitempty"`
+	// Strategy is how the image was built, such as dockerfile or buildpacks.
+	Strategy        string       `json:"strategy"`
+	Builder         string       `json:"builder,omitempty"`
+	BuilderApp      string       `json:"builder_app,omitempty"`
+	BuilderMachine  string       `json:"builder_machine,omitempty"`
+	BuildkitEnabled bool         `json:"buildkit_enabled"`
+	DockerVersion   string       `json:"docker_version,omitempty"`
+	Platform        string       `json:"platform,omitempty"`
+	Timings         BuildTimings `json:"timings"`
+}
+
+// BuildTimings are the durations of the steps of a build in milliseconds.
+// Steps the build skipped are left out.
+type BuildTimings struct {
+	BuildAndPushMs int64 `json:"build_and_push_ms,omitempty"`
+	BuilderInitMs  int64 `json:"builder_init_ms,omitempty"`
+	BuildMs        int64 `json:"build_ms,omitempty"`
+	ContextBuildMs int64 `json:"context_build_ms,omitempty"`
+	ImageBuildMs   int64 `json:"image_build_ms,omitempty"`
+	PushMs         int64 `json:"push_ms,omitempty"`
+}
+
+// info returns what the build recorded about its successful strategy.
+func (b *build) info(strategy string) *BuildInfo {
+	info := &BuildInfo{
+		ID:       b.BuildId,
+		Strategy: strategy,
+		Timings:  buildTimings(b.Timings),
+	}
+	if meta := b.BuilderMeta; meta != nil {
+		info.Builder = meta.BuilderType
+		info.BuilderApp = meta.RemoteAppName
+		info.BuilderMachine = meta.RemoteMachineId
+		info.BuildkitEnabled = meta.BuildkitEnabled
+		info.DockerVersion = meta.DockerVersion
+		info.Platform = meta.Platform
+	}
+	return info
+}
+
+func buildTimings(t *gql.BuildTimingsInput) BuildTimings {
+	// The tracker marks the steps which didn't run with -1.
+	ms := func(v int64) int64 { return max(v, 0) }
+	return BuildTimings{
+		BuildAndPushMs: ms(t.BuildAndPushMs),
+		BuilderInitMs:  ms(t.BuilderInitMs),
+		BuildMs:        ms(t.BuildMs),
+		ContextBuildMs: ms(t.ContextBuildMs),
+		ImageBuildMs:   ms(t.ImageBuildMs),
+		PushMs:         ms(t.PushMs),
+	}
+}
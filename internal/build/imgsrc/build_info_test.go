@@ -0,0 +1,28 @@
+package imgsrc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildInfo(t *testing.T) {
+	b := newBuild("build-1", false)
+	b.SetBuilderMetaPart1(true, "fly-builder-abc", "m-1")
+	b.SetBuilderMetaPart2(true, "25.0.3", "linux/amd64")
+	b.Timings.BuildAndPushMs = 1500
+	b.Timings.PushMs = 0
+
+	assert.Equal(t, &BuildInfo{
+		ID:              "build-1",
+		Strategy:        "dockerfile",
+		Builder:         "remote",
+		BuilderApp:      "fly-builder-abc",
+		BuilderMachine:  "m-1",
+		BuildkitEnabled: true,
+		DockerVersion:   "25.0.3",
+		Platform:        "linux/amd64",
+		// Steps marked as not run are left out.
+		Timings: BuildTimings{BuildAndPushMs: 1500},
+	}, b.info("dockerfile"))
+}
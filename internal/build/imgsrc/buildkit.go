@@ -10,6 +10,7 @@ import (
 	dockerclient "github.com/docker/docker/client"
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth"
+	"github.com/superfly/flyctl/terminal"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -21,6 +22,17 @@ func buildkitEnabled(docker *dockerclient.Client) (buildkitEnabled bool, err err
 		return false, err
 	}
 
+	// Podman advertises BuildKit on some versions but has no BuildKit session
+	// endpoint, so it only takes classic builds.
+	podman, err := isPodman(context.Background(), docker)
+	if err != nil {
+		return false, err
+	}
+	if podman {
+		terminal.Debug("docker daemon is podman, using classic builds")
+		return false, nil
+	}
+
 	buildkitEnabled = ping.BuilderVersion == types.BuilderBuildKit
 	if buildkitEnv := os.Getenv("DOCKER_BUILDKIT"); buildkitEnv != "" {
 		buildkitEnabled, err = strconv.ParseBool(buildkitEnv)
@@ -49,6 +49,16 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 		return nil, note, nil
 	}
 
+	if opts.Output != nil {
+		build.BuildFinish()
+		return nil, "", errors.New("--output isn't supported by buildpacks builds")
+	}
+
+	if opts.SourceDateEpoch != nil {
+		build.BuildFinish()
+		return nil, "", errors.New("--reproducible isn't supported by buildpacks builds")
+	}
+
 	builder := opts.Builder
 	buildpacks := opts.Buildpacks
 
@@ -154,11 +164,12 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 
 	cmdfmt.PrintDone(streams.ErrOut, "Building image done")
 
+	var digest string
 	if opts.Publish {
 		build.PushStart()
 		cmdfmt.PrintBegin(streams.ErrOut, "Pushing image to fly")
 
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if digest, err = pushToFly(ctx, docker, streams, opts.Tag); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
@@ -177,9 +188,10 @@ func (*buildpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	}
 
 	di := DeploymentImage{
-		ID:   img.ID,
-		Tag:  opts.Tag,
-		Size: img.Size,
+		ID:     img.ID,
+		Tag:    opts.Tag,
+		Digest: digest,
+		Size:   img.Size,
 	}
 
 	span.SetAttributes(di.ToSpanAttributes()...)
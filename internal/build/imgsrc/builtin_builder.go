@@ -122,11 +122,12 @@ func (*builtinBuilder) Run(ctx context.Context, dockerFactory *dockerClientFacto
 	build.BuildFinish()
 	cmdfmt.PrintDone(streams.ErrOut, "Building image done")
 
+	var digest string
 	if opts.Publish {
 		build.PushStart()
 		cmdfmt.PrintBegin(streams.ErrOut, "Pushing image to fly")
 
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if digest, err = pushToFly(ctx, docker, streams, opts.Tag); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
@@ -139,11 +140,11 @@ func (*builtinBuilder) Run(ctx context.Context, dockerFactory *dockerClientFacto
 	if err != nil {
 		return nil, "", errors.Wrap(err, "count not find built image")
 	}
-	fmt.Println(img)
 
 	return &DeploymentImage{
-		ID:   img.ID,
-		Tag:  opts.Tag,
-		Size: img.Size,
+		ID:     img.ID,
+		Tag:    opts.Tag,
+		Digest: digest,
+		Size:   img.Size,
 	}, "", nil
 }
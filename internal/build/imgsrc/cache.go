@@ -0,0 +1,71 @@
+package imgsrc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+)
+
+// parseCacheOptions parses --cache-from and --cache-to values the way docker
+// buildx does: comma separated key=value pairs with a type, such as
+// type=registry,ref=registry.fly.io/my-app:cache,mode=max. A value without
+// any key is the reference of a registry cache.
+func parseCacheOptions(specs []string) ([]client.CacheOptionsEntry, error) {
+	var entries []client.CacheOptionsEntry
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		if !strings.Contains(spec, "=") {
+			entries = append(entries, client.CacheOptionsEntry{
+				Type:  "registry",
+				Attrs: map[string]string{"ref": spec},
+			})
+			continue
+		}
+
+		fields, err := csv.NewReader(strings.NewReader(spec)).Read()
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache option %q: %w", spec, err)
+		}
+
+		entry := client.CacheOptionsEntry{Attrs: map[string]string{}}
+		for _, field := range fields {
+			k, v, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid cache option %q: %q isn't a key=value pair", spec, field)
+			}
+			k = strings.ToLower(strings.TrimSpace(k))
+			if k == "type" {
+				entry.Type = v
+			} else {
+				entry.Attrs[k] = v
+			}
+		}
+
+		switch {
+		case entry.Type == "":
+			return nil, fmt.Errorf("invalid cache option %q: missing type", spec)
+		case entry.Type == "registry" && entry.Attrs["ref"] == "":
+			return nil, fmt.Errorf("invalid cache option %q: registry caches need a ref", spec)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// registryCacheRefs returns the references of the registry caches in
+// entries, which classic builds can pull as cache sources.
+func registryCacheRefs(entries []client.CacheOptionsEntry) []string {
+	var refs []string
+	for _, e := range entries {
+		if e.Type == "registry" {
+			refs = append(refs, e.Attrs["ref"])
+		}
+	}
+	return refs
+}
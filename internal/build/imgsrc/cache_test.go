@@ -0,0 +1,53 @@
+package imgsrc
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCacheOptions(t *testing.T) {
+	entries, err := parseCacheOptions([]string{
+		"registry.fly.io/my-app:cache",
+		"type=registry,ref=registry.fly.io/my-app:cache,mode=max",
+		"type=gha",
+		"",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []client.CacheOptionsEntry{
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache"}},
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache", "mode": "max"}},
+		{Type: "gha", Attrs: map[string]string{}},
+	}, entries)
+
+	assert.Equal(t, []string{"registry.fly.io/my-app:cache", "registry.fly.io/my-app:cache"}, registryCacheRefs(entries))
+
+	for _, spec := range []string{
+		"ref=registry.fly.io/my-app:cache",
+		"type=registry,mode=max",
+		"type=registry,registry.fly.io/my-app:cache",
+	} {
+		_, err := parseCacheOptions([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSolveOptFromImageOptionsCache(t *testing.T) {
+	opts, err := solveOptFromImageOptions(ImageOptions{
+		Tag:       "registry.fly.io/my-app:deployment-1",
+		CacheFrom: []string{"registry.fly.io/my-app:cache"},
+		CacheTo:   []string{"type=registry,ref=registry.fly.io/my-app:cache,mode=max"},
+	}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []client.CacheOptionsEntry{
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache"}},
+	}, opts.CacheImports)
+	assert.Equal(t, []client.CacheOptionsEntry{
+		{Type: "registry", Attrs: map[string]string{"ref": "registry.fly.io/my-app:cache", "mode": "max"}},
+	}, opts.CacheExports)
+
+	_, err = solveOptFromImageOptions(ImageOptions{CacheTo: []string{"mode=max"}}, "Dockerfile", nil)
+	assert.Error(t, err)
+}
@@ -52,7 +52,7 @@ type dockerClientFactory struct {
 	appName   string
 }
 
-func newDockerClientFactory(daemonType DockerDaemonType, apiClient *fly.Client, appName string, streams *iostreams.IOStreams, connectOverWireguard bool) *dockerClientFactory {
+func newDockerClientFactory(daemonType DockerDaemonType, apiClient *fly.Client, appName string, streams *iostreams.IOStreams, connectOverWireguard bool, localHost string) *dockerClientFactory {
 	remoteFactory := func() *dockerClientFactory {
 		terminal.Debug("trying remote docker daemon")
 		return &dockerClientFactory{
@@ -68,7 +68,7 @@ func newDockerClientFactory(daemonType DockerDaemonType, apiClient *fly.Client,
 
 	localFactory := func() *dockerClientFactory {
 		terminal.Debug("trying local docker daemon")
-		c, err := NewLocalDockerClient()
+		c, err := NewLocalDockerClient(localHost)
 		if c != nil && err == nil {
 			return &dockerClientFactory{
 				mode: DockerDaemonTypeLocal,
@@ -178,11 +178,24 @@ func (t DockerDaemonType) PrefersLocal() bool {
 	return (t & DockerDaemonTypePrefersLocal) != 0
 }
 
-func NewLocalDockerClient() (*dockerclient.Client, error) {
-	c, err := dockerclient.NewClientWithOpts(
+// NewLocalDockerClient returns a client of the local Docker daemon at host,
+// or at the one the environment and docker context point to when host is
+// empty.
+func NewLocalDockerClient(host string) (*dockerclient.Client, error) {
+	host, err := localDockerHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []dockerclient.Opt{
 		dockerclient.FromEnv,
 		dockerclient.WithAPIVersionNegotiation(),
-	)
+	}
+	if host != "" {
+		opts = append(opts, dockerclient.WithHost(host))
+	}
+
+	c, err := dockerclient.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +270,10 @@ func newRemoteDockerClient(ctx context.Context, apiClient *fly.Client, appName s
 			return nil, err
 		}
 
-		if res.StatusCode == http.StatusNotFound {
+		if res.StatusCode == http.StatusNotFound && remoteBuilderFromContext(ctx).App != "" {
+			logClearLinesAbove(streams, 1)
+			return nil, fmt.Errorf("builder %s doesn't support deploys without WireGuard; retry with --wg or use another builder", app.Name)
+		} else if res.StatusCode == http.StatusNotFound {
 			logClearLinesAbove(streams, 1)
 			fmt.Fprintln(streams.Out, streams.ColorScheme().Yellow("🔧 automatically deleting and recreating builder"))
 
@@ -700,7 +716,7 @@ func ResolveDockerfile(cwd string) string {
 
 func EagerlyEnsureRemoteBuilder(ctx context.Context, apiClient *fly.Client, orgSlug string) {
 	// skip if local docker is available
-	if _, err := NewLocalDockerClient(); err == nil {
+	if _, err := NewLocalDockerClient(""); err == nil {
 		return
 	}
 
@@ -710,8 +726,7 @@ func EagerlyEnsureRemoteBuilder(ctx context.Context, apiClient *fly.Client, orgS
 		return
 	}
 
-	region := os.Getenv("FLY_REMOTE_BUILDER_REGION")
-	_, app, err := apiClient.EnsureRemoteBuilder(ctx, org.ID, "", region)
+	_, app, err := EnsureOrgRemoteBuilder(ctx, apiClient, org.ID)
 	if err != nil {
 		terminal.Debugf("error ensuring remote builder for organization: %s", err)
 		return
@@ -725,8 +740,28 @@ func remoteBuilderMachine(ctx context.Context, apiClient *fly.Client, appName st
 		return nil, nil, nil
 	}
 
-	region := os.Getenv("FLY_REMOTE_BUILDER_REGION")
-	return apiClient.EnsureRemoteBuilder(ctx, "", appName, region)
+	var (
+		machine *fly.GqlMachine
+		app     *fly.App
+		err     error
+		rb      = remoteBuilderFromContext(ctx)
+	)
+	if rb.App != "" {
+		machine, app, err = pinnedBuilderMachine(ctx, apiClient, rb.App)
+	} else {
+		region := os.Getenv("FLY_REMOTE_BUILDER_REGION")
+		machine, app, err = apiClient.EnsureRemoteBuilder(ctx, "", appName, region)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if rb.Size != "" {
+		if err := resizeBuilder(ctx, app.Name, machine.ID, rb.Size); err != nil {
+			return nil, nil, err
+		}
+	}
+	return machine, app, nil
 }
 
 func (d *dockerClientFactory) IsRemote() bool {
@@ -0,0 +1,163 @@
+package imgsrc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/superfly/flyctl/terminal"
+)
+
+// localDockerHost returns the address of the local Docker daemon, picked in
+// the order the docker CLI picks it: the explicit host, then DOCKER_HOST,
+// then the endpoint of the current docker context, then the unix socket in
+// Podman's CONTAINER_HOST. Without any, it falls back to the first socket
+// found of the default daemon and its common alternatives (Docker Desktop,
+// Colima, Rancher Desktop, rootless Docker, Podman). An empty host leaves the
+// choice to the Docker client.
+func localDockerHost(explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return host, nil
+	}
+
+	host, err := dockerContextHost(dockerConfigDir(), os.Getenv("DOCKER_CONTEXT"))
+	if err != nil {
+		return "", err
+	}
+	if host != "" {
+		return host, nil
+	}
+
+	// podman-remote also takes ssh:// hosts, which the Docker client can't
+	// dial by itself.
+	if host := os.Getenv("CONTAINER_HOST"); strings.HasPrefix(host, "unix://") {
+		return host, nil
+	}
+
+	home, _ := os.UserHomeDir()
+	for _, candidate := range dockerHostCandidates(runtime.GOOS, home, os.Getenv("XDG_RUNTIME_DIR")) {
+		if socketExists(candidate) {
+			terminal.Debugf("using docker daemon at %s\n", candidate)
+			return candidate, nil
+		}
+	}
+	return "", nil
+}
+
+func dockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".docker")
+}
+
+// dockerContextHost returns the docker endpoint of the docker context name,
+// or of the current context of the docker CLI when name is empty. It returns
+// an empty host for the default context, which follows DOCKER_HOST.
+func dockerContextHost(configDir, name string) (string, error) {
+	if name == "" {
+		data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return "", nil
+		case err != nil:
+			return "", err
+		}
+
+		var cfg struct {
+			CurrentContext string `json:"currentContext"`
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return "", fmt.Errorf("failed parsing %s: %w", filepath.Join(configDir, "config.json"), err)
+		}
+		name = cfg.CurrentContext
+	}
+
+	if name == "" || name == "default" {
+		return "", nil
+	}
+
+	// The docker CLI stores contexts under the digest of their name.
+	digest := sha256.Sum256([]byte(name))
+	path := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]), "meta.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed reading docker context %s: %w", name, err)
+	}
+
+	var meta struct {
+		Endpoints map[string]struct {
+			Host string `json:"Host"`
+		} `json:"Endpoints"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return "", fmt.Errorf("failed parsing docker context %s: %w", name, err)
+	}
+	return meta.Endpoints["docker"].Host, nil
+}
+
+// dockerHostCandidates returns the usual addresses of local Docker daemons on
+// goos, most common first, followed by those of Podman's Docker compatible
+// API.
+func dockerHostCandidates(goos, home, runtimeDir string) []string {
+	if goos == "windows" {
+		return []string{
+			"npipe:////./pipe/docker_engine",
+			"npipe:////./pipe/dockerDesktopLinuxEngine",
+			"npipe:////./pipe/docker_engine_linux",
+			"npipe:////./pipe/podman-machine-default",
+		}
+	}
+
+	candidates := []string{"unix:///var/run/docker.sock"}
+	if home != "" {
+		candidates = append(candidates,
+			"unix://"+filepath.Join(home, ".docker", "run", "docker.sock"),
+			"unix://"+filepath.Join(home, ".colima", "default", "docker.sock"),
+			"unix://"+filepath.Join(home, ".colima", "docker.sock"),
+			"unix://"+filepath.Join(home, ".rd", "docker.sock"),
+		)
+	}
+	if runtimeDir != "" {
+		candidates = append(candidates, "unix://"+filepath.Join(runtimeDir, "docker.sock"))
+	}
+
+	// Rootless Podman, then rootful Podman, then podman machine on macOS.
+	if runtimeDir != "" {
+		candidates = append(candidates, "unix://"+filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	candidates = append(candidates, "unix:///run/podman/podman.sock")
+	if home != "" {
+		candidates = append(candidates,
+			"unix://"+filepath.Join(home, ".local", "share", "containers", "podman", "machine", "podman.sock"),
+			"unix://"+filepath.Join(home, ".local", "share", "containers", "podman", "machine", "qemu", "podman.sock"),
+		)
+	}
+	return candidates
+}
+
+// socketExists reports whether the unix socket or Windows named pipe host
+// points to exists.
+func socketExists(host string) bool {
+	switch {
+	case strings.HasPrefix(host, "unix://"):
+		_, err := os.Stat(strings.TrimPrefix(host, "unix://"))
+		return err == nil
+	case strings.HasPrefix(host, "npipe://"):
+		// npipe:////./pipe/name is \\.\pipe\name
+		_, err := os.Stat(filepath.FromSlash(strings.TrimPrefix(host, "npipe://")))
+		return err == nil
+	default:
+		return false
+	}
+}
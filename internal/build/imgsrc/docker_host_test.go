@@ -0,0 +1,91 @@
+package imgsrc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDockerContext(t *testing.T, configDir, name, host string) {
+	digest := sha256.Sum256([]byte(name))
+	dir := filepath.Join(configDir, "contexts", "meta", hex.EncodeToString(digest[:]))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	meta := `{"Name":"` + name + `","Endpoints":{"docker":{"Host":"` + host + `","SkipTLSVerify":false}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0o644))
+}
+
+func TestDockerContextHost(t *testing.T) {
+	configDir := t.TempDir()
+
+	// No config.json: the default context
+	host, err := dockerContextHost(configDir, "")
+	require.NoError(t, err)
+	assert.Empty(t, host)
+
+	writeDockerContext(t, configDir, "colima", "unix:///Users/me/.colima/default/docker.sock")
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.json"), []byte(`{"currentContext":"colima"}`), 0o644))
+
+	host, err = dockerContextHost(configDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, "unix:///Users/me/.colima/default/docker.sock", host)
+
+	// DOCKER_CONTEXT wins over the current context
+	writeDockerContext(t, configDir, "remote", "tcp://10.0.0.1:2375")
+	host, err = dockerContextHost(configDir, "remote")
+	require.NoError(t, err)
+	assert.Equal(t, "tcp://10.0.0.1:2375", host)
+
+	host, err = dockerContextHost(configDir, "default")
+	require.NoError(t, err)
+	assert.Empty(t, host)
+
+	_, err = dockerContextHost(configDir, "missing")
+	assert.ErrorContains(t, err, "docker context missing")
+}
+
+func TestDockerHostCandidates(t *testing.T) {
+	assert.Equal(t, "npipe:////./pipe/docker_engine", dockerHostCandidates("windows", `C:\Users\me`, "")[0])
+
+	candidates := dockerHostCandidates("darwin", "/Users/me", "")
+	assert.Equal(t, "unix:///var/run/docker.sock", candidates[0])
+	assert.Contains(t, candidates, "unix:///Users/me/.colima/default/docker.sock")
+	assert.Contains(t, candidates, "unix:///Users/me/.rd/docker.sock")
+
+	assert.Contains(t, candidates, "unix:///Users/me/.local/share/containers/podman/machine/podman.sock")
+
+	candidates = dockerHostCandidates("linux", "", "/run/user/1000")
+	assert.Equal(t, []string{
+		"unix:///var/run/docker.sock",
+		"unix:///run/user/1000/docker.sock",
+		"unix:///run/user/1000/podman/podman.sock",
+		"unix:///run/podman/podman.sock",
+	}, candidates)
+}
+
+func TestLocalDockerHost(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "tcp://127.0.0.1:2375")
+
+	host, err := localDockerHost("unix:///tmp/docker.sock")
+	require.NoError(t, err)
+	assert.Equal(t, "unix:///tmp/docker.sock", host)
+
+	host, err = localDockerHost("")
+	require.NoError(t, err)
+	assert.Equal(t, "tcp://127.0.0.1:2375", host)
+}
+
+func TestLocalDockerHostPodman(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("DOCKER_CONTEXT", "")
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	t.Setenv("CONTAINER_HOST", "unix:///run/user/1000/podman/podman.sock")
+
+	host, err := localDockerHost("")
+	require.NoError(t, err)
+	assert.Equal(t, "unix:///run/user/1000/podman/podman.sock", host)
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -240,6 +241,30 @@ func (*dockerfileBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 		return nil, "", fmt.Errorf("error parsing build args: %w", err)
 	}
 
+	// The moby exporter drops attestations unless Docker Engine keeps images
+	// in containerd, so a build that can't keep the SBOM doesn't start.
+	if buildkitEnabled && opts.SBOM && !usesContainerdStore(serverInfo) {
+		build.ImageBuildFinish()
+		build.BuildFinish()
+		return nil, "", errors.New("--sbom needs a Docker daemon using the containerd image store to keep the SBOM attestation")
+	}
+
+	// Docker Engine's BuildKit only has the oci and docker exporters with the
+	// containerd image store. Without it, docker-archive outputs are saved
+	// from the image store after the build, like docker save, while oci
+	// outputs can't be written.
+	var saveTo *ExportOutput
+	if buildkitEnabled && opts.Output != nil && !usesContainerdStore(serverInfo) {
+		switch opts.Output.Type {
+		case "oci":
+			build.ImageBuildFinish()
+			build.BuildFinish()
+			return nil, "", errors.New("oci outputs need a Docker daemon using the containerd image store, use a docker-archive or tar output instead")
+		case "docker-archive":
+			saveTo, opts.Output = opts.Output, nil
+		}
+	}
+
 	build.SetBuilderMetaPart2(buildkitEnabled, serverInfo.ServerVersion, fmt.Sprintf("%s/%s/%s", serverInfo.OSType, serverInfo.Architecture, serverInfo.OSVersion))
 	if buildkitEnabled {
 		imageID, err = runBuildKitBuild(ctx, docker, opts, dockerfile, buildArgs)
@@ -269,10 +294,27 @@ func (*dockerfileBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	build.BuildFinish()
 	cmdfmt.PrintDone(streams.ErrOut, "Building image done")
 
+	if saveTo != nil {
+		tb := render.NewTextBlock(ctx, "Saving image")
+		if err := saveImage(ctx, docker, opts.Tag, saveTo.Path); err != nil {
+			return nil, "", err
+		}
+		tb.Done(fmt.Sprintf("Saved image to %s", saveTo.Path))
+		opts.Output = saveTo
+	}
+
+	// The exported image isn't in Docker's image store to push or inspect.
+	if opts.Output != nil {
+		di := DeploymentImage{ID: imageID, Tag: opts.Tag}
+		span.SetAttributes(di.ToSpanAttributes()...)
+		return &di, "", nil
+	}
+
+	var digest string
 	if opts.Publish {
 		build.PushStart()
 		tb := render.NewTextBlock(ctx, "Pushing image to fly")
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if digest, err = pushToFly(ctx, docker, streams, opts.Tag); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
@@ -287,9 +329,10 @@ func (*dockerfileBuilder) Run(ctx context.Context, dockerFactory *dockerClientFa
 	}
 
 	di := DeploymentImage{
-		ID:   img.ID,
-		Tag:  opts.Tag,
-		Size: img.Size,
+		ID:     img.ID,
+		Tag:    opts.Tag,
+		Digest: digest,
+		Size:   img.Size,
 	}
 
 	if opts.UseOverlaybd && dockerFactory.IsRemote() {
@@ -391,6 +434,18 @@ func normalizeBuildArgsForDocker(buildArgs map[string]string) (map[string]*strin
 	return out, nil
 }
 
+// usesContainerdStore reports whether the Docker Engine described by info
+// keeps its images in containerd, which keeps their attestations and
+// multi-platform indexes.
+func usesContainerdStore(info types.Info) bool {
+	for _, kv := range info.DriverStatus {
+		if len(kv) == 2 && kv[0] == "driver-type" && kv[1] == "io.containerd.snapshotter.v1" {
+			return true
+		}
+	}
+	return false
+}
+
 func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *dockerclient.Client, r io.ReadCloser, opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) (imageID string, err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "build_image",
 		trace.WithAttributes(opts.ToSpanAttributes()...),
@@ -398,6 +453,27 @@ func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *
 	)
 	defer span.End()
 
+	if opts.SBOM {
+		return "", errors.New("--sbom needs a Docker daemon with BuildKit enabled")
+	}
+	if len(opts.SSH) > 0 {
+		return "", errors.New("--ssh needs a Docker daemon with BuildKit enabled")
+	}
+	if opts.Output != nil {
+		return "", errors.New("--output needs a Docker daemon with BuildKit enabled")
+	}
+	if opts.SourceDateEpoch != nil {
+		return "", errors.New("--reproducible needs a Docker daemon with BuildKit enabled")
+	}
+
+	cacheFrom, err := parseCacheOptions(opts.CacheFrom)
+	if err != nil {
+		return "", err
+	}
+	if len(opts.CacheTo) > 0 {
+		terminal.Warnf("--cache-to needs BuildKit, ignoring it for this build\n")
+	}
+
 	options := types.ImageBuildOptions{
 		Tags:        []string{opts.Tag},
 		BuildArgs:   buildArgs,
@@ -407,6 +483,7 @@ func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *
 		Target:      opts.Target,
 		NoCache:     opts.NoCache,
 		Labels:      opts.Label,
+		CacheFrom:   registryCacheRefs(cacheFrom),
 	}
 
 	resp, err := docker.ImageBuild(ctx, r, options)
@@ -423,14 +500,23 @@ func runClassicBuild(ctx context.Context, streams *iostreams.IOStreams, docker *
 		imageID = aux.ID
 	}
 
-	if err := jsonmessage.DisplayJSONMessagesStream(resp.Body, streams.ErrOut, streams.StderrFd(), streams.IsStderrTTY(), idCallback); err != nil {
+	if err := displayJSONMessages(streams, resp.Body, idCallback); err != nil {
 		return "", errors.Wrap(err, "error rendering build status stream")
 	}
 
 	return imageID, nil
 }
 
-func solveOptFromImageOptions(opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) client.SolveOpt {
+func solveOptFromImageOptions(opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) (client.SolveOpt, error) {
+	cacheImports, err := parseCacheOptions(opts.CacheFrom)
+	if err != nil {
+		return client.SolveOpt{}, err
+	}
+	cacheExports, err := parseCacheOptions(opts.CacheTo)
+	if err != nil {
+		return client.SolveOpt{}, err
+	}
+
 	attrs := map[string]string{
 		"filename": filepath.Base(dockerfilePath),
 		"target":   opts.Target,
@@ -443,6 +529,14 @@ func solveOptFromImageOptions(opts ImageOptions, dockerfilePath string, buildArg
 		attrs["no-cache"] = ""
 	}
 
+	if opts.SBOM {
+		// Scanned by BuildKit's default SBOM generator, which writes SPDX.
+		// Docker Engine keeps the attestation with the image, and pushes it
+		// along, only with the containerd image store, which Run
+		// checks for.
+		attrs["attest:sbom"] = ""
+	}
+
 	for k, v := range opts.Label {
 		attrs["label:"+k] = v
 	}
@@ -454,8 +548,29 @@ func solveOptFromImageOptions(opts ImageOptions, dockerfilePath string, buildArg
 		attrs["build-arg:"+k] = *v
 	}
 
+	exports := []client.ExportEntry{
+		{Type: "moby", Attrs: map[string]string{"name": opts.Tag}},
+	}
+	if opts.Output != nil {
+		exports = []client.ExportEntry{opts.Output.exportEntry(opts.Tag)}
+	}
+
+	frontend := "dockerfile.v0"
+	if opts.SourceDateEpoch != nil {
+		// The frontend clamps the timestamps of the image config and history,
+		// the image exporters those of the files of new layers.
+		attrs["build-arg:SOURCE_DATE_EPOCH"] = strconv.FormatInt(*opts.SourceDateEpoch, 10)
+		frontend = "gateway.v0"
+		attrs["source"] = ReproducibleFrontend
+		for _, export := range exports {
+			if export.Type != client.ExporterTar {
+				export.Attrs[string(exptypes.OptKeyRewriteTimestamp)] = "true"
+			}
+		}
+	}
+
 	return client.SolveOpt{
-		Frontend:      "dockerfile.v0",
+		Frontend:      frontend,
 		FrontendAttrs: attrs,
 		LocalDirs: map[string]string{
 			"dockerfile": filepath.Dir(dockerfilePath),
@@ -465,10 +580,14 @@ func solveOptFromImageOptions(opts ImageOptions, dockerfilePath string, buildArg
 		// "moby" exporter works best for flyctl, since we want to keep images in
 		// Docker Engine's image store. The others are exporting images to somewhere else.
 		// https://github.com/moby/moby/blob/v20.10.24/builder/builder-next/worker/worker.go#L221
-		Exports: []client.ExportEntry{
-			{Type: "moby", Attrs: map[string]string{"name": opts.Tag}},
-		},
-	}
+		// --output exports to a file instead, which the oci and docker
+		// exporters only support with the containerd image store.
+		Exports: exports,
+		// Registry caches are pushed and pulled with the credentials of the
+		// auth provider, so they work on ephemeral CI runners too.
+		CacheImports: cacheImports,
+		CacheExports: cacheExports,
+	}, nil
 }
 
 func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) (string, error) {
@@ -487,13 +606,36 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 		return "", err
 	}
 
+	// Everything that can fail is checked before the goroutines start: only
+	// bc.Solve closes statusCh, which the display goroutine waits on.
+	options, err := buildKitSolveOpt(ctx, opts, dockerfilePath, buildArgs)
+	if err != nil {
+		return "", err
+	}
+
 	// Build the image.
+	streams := iostreams.FromContext(ctx)
+	mode := progressuiMode(streams)
+	var events *buildEventWriter
+	if streams.ProgressMode() == iostreams.ProgressJSON {
+		events = newBuildEventWriter(streams.ErrOut)
+	}
 	statusCh := make(chan *client.SolveStatus)
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
-		var err error
+		if events != nil {
+			for s := range statusCh {
+				events.solveStatus(s)
+			}
+			return nil
+		}
 
-		display, err := progressui.NewDisplay(os.Stderr, "auto")
+		// The animated display needs the terminal itself.
+		var out io.Writer = streams.ErrOut
+		if mode == progressui.AutoMode {
+			out = os.Stderr
+		}
+		display, err := progressui.NewDisplay(out, mode)
 		if err != nil {
 			return err
 		}
@@ -505,19 +647,7 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 	})
 	var res *client.SolveResponse
 	eg.Go(func() error {
-		options := solveOptFromImageOptions(opts, dockerfilePath, buildArgs)
-		secrets := make(map[string][]byte)
-		for k, v := range opts.BuildSecrets {
-			secrets[k] = []byte(v)
-		}
-		options.Session = append(
-			options.Session,
-			// To pull images from local Docker Engine with Fly's access token,
-			// we need to pass the provider. Remote builders don't need that.
-			newBuildkitAuthProvider(config.Tokens(ctx).Docker()),
-			secretsprovider.FromMap(secrets),
-		)
-
+		var err error
 		res, err = bc.Solve(ctx, nil, options, statusCh)
 		if err != nil {
 			return err
@@ -529,10 +659,59 @@ func runBuildKitBuild(ctx context.Context, docker *dockerclient.Client, opts Ima
 	if err != nil {
 		return "", err
 	}
-	return res.ExporterResponse[exptypes.ExporterImageDigestKey], nil
+
+	digest := res.ExporterResponse[exptypes.ExporterImageDigestKey]
+	if events != nil {
+		events.emit(buildEvent{Type: "image", Digest: digest})
+	}
+	return digest, nil
+}
+
+// buildKitSolveOpt returns the options of the solve building opts, with the
+// session attachables it needs.
+func buildKitSolveOpt(ctx context.Context, opts ImageOptions, dockerfilePath string, buildArgs map[string]*string) (client.SolveOpt, error) {
+	options, err := solveOptFromImageOptions(opts, dockerfilePath, buildArgs)
+	if err != nil {
+		return client.SolveOpt{}, err
+	}
+	secrets := make(map[string][]byte)
+	for k, v := range opts.BuildSecrets {
+		secrets[k] = []byte(v)
+	}
+	options.Session = append(
+		options.Session,
+		// To pull images from local Docker Engine with Fly's access token,
+		// we need to pass the provider. Remote builders don't need that.
+		newBuildkitAuthProvider(config.Tokens(ctx).Docker()),
+		secretsprovider.FromMap(secrets),
+	)
+
+	ssh, err := sshAgentProvider(opts.SSH)
+	if err != nil {
+		return client.SolveOpt{}, err
+	}
+	if ssh != nil {
+		options.Session = append(options.Session, ssh)
+	}
+	return options, nil
 }
 
-func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostreams.IOStreams, tag string) (err error) {
+// pushResultDigest returns the digest of the manifest the registry reports
+// in the push result message msg, or an empty string for other messages.
+func pushResultDigest(msg jsonmessage.JSONMessage) string {
+	if msg.Aux == nil {
+		return ""
+	}
+	var result types.PushResult
+	if json.Unmarshal(*msg.Aux, &result) != nil {
+		return ""
+	}
+	return result.Digest
+}
+
+// pushToFly pushes the image tagged tag to the Fly registry, and returns the
+// digest of its manifest there.
+func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostreams.IOStreams, tag string) (digest string, err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "push_image_to_registry", trace.WithAttributes(attribute.String("tag", tag)))
 	defer span.End()
 
@@ -551,22 +730,26 @@ func pushToFly(ctx context.Context, docker *dockerclient.Client, streams *iostre
 	metrics.Status(ctx, "image_push", err == nil)
 
 	if err != nil {
-		return errors.Wrap(err, "error pushing image to registry")
+		return "", errors.Wrap(err, "error pushing image to registry")
 	}
 	defer pushResp.Close() // skipcq: GO-S2307
 	sendImgPushMetrics()
 
-	err = jsonmessage.DisplayJSONMessagesStream(pushResp, streams.ErrOut, streams.StderrFd(), streams.IsStderrTTY(), nil)
+	err = displayJSONMessages(streams, pushResp, func(msg jsonmessage.JSONMessage) {
+		if d := pushResultDigest(msg); d != "" {
+			digest = d
+		}
+	})
 	if err != nil {
 		var msgerr *jsonmessage.JSONError
 
 		if errors.As(err, &msgerr) {
 			if msgerr.Message == "denied: requested access to the resource is denied" {
-				return &RegistryUnauthorizedError{Tag: tag}
+				return "", &RegistryUnauthorizedError{Tag: tag}
 			}
 		}
-		return errors.Wrap(err, "error rendering push status stream")
+		return "", errors.Wrap(err, "error rendering push status stream")
 	}
 
-	return nil
+	return digest, nil
 }
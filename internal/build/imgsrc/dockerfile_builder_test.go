@@ -0,0 +1,97 @@
+package imgsrc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestSolveOptFromImageOptionsSBOM(t *testing.T) {
+	opts, err := solveOptFromImageOptions(ImageOptions{}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, opts.FrontendAttrs, "attest:sbom")
+
+	opts, err = solveOptFromImageOptions(ImageOptions{SBOM: true}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.Contains(t, opts.FrontendAttrs, "attest:sbom")
+}
+
+func TestSolveOptFromImageOptionsOutput(t *testing.T) {
+	opts, err := solveOptFromImageOptions(ImageOptions{Tag: "registry.fly.io/my-app:deployment-1"}, "Dockerfile", nil)
+	require.NoError(t, err)
+	require.Len(t, opts.Exports, 1)
+	assert.Equal(t, "moby", opts.Exports[0].Type)
+
+	opts, err = solveOptFromImageOptions(ImageOptions{
+		Tag:    "registry.fly.io/my-app:deployment-1",
+		Output: &ExportOutput{Type: "oci", Path: "out"},
+	}, "Dockerfile", nil)
+	require.NoError(t, err)
+	require.Len(t, opts.Exports, 1)
+	assert.Equal(t, client.ExporterOCI, opts.Exports[0].Type)
+	assert.Equal(t, "out", opts.Exports[0].OutputDir)
+}
+
+func TestSolveOptFromImageOptionsReproducible(t *testing.T) {
+	opts, err := solveOptFromImageOptions(ImageOptions{Tag: "registry.fly.io/my-app:deployment-1"}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "dockerfile.v0", opts.Frontend)
+	assert.NotContains(t, opts.FrontendAttrs, "build-arg:SOURCE_DATE_EPOCH")
+
+	epoch := int64(1700000000)
+	opts, err = solveOptFromImageOptions(ImageOptions{
+		Tag:             "registry.fly.io/my-app:deployment-1",
+		SourceDateEpoch: &epoch,
+	}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "gateway.v0", opts.Frontend)
+	assert.Equal(t, ReproducibleFrontend, opts.FrontendAttrs["source"])
+	assert.Equal(t, "1700000000", opts.FrontendAttrs["build-arg:SOURCE_DATE_EPOCH"])
+	assert.Equal(t, "true", opts.Exports[0].Attrs["rewrite-timestamp"])
+
+	opts, err = solveOptFromImageOptions(ImageOptions{
+		Tag:             "registry.fly.io/my-app:deployment-1",
+		Output:          &ExportOutput{Type: "tar", Path: "fs.tar"},
+		SourceDateEpoch: &epoch,
+	}, "Dockerfile", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, opts.Exports[0].Attrs, "rewrite-timestamp")
+}
+
+func TestUsesContainerdStore(t *testing.T) {
+	assert.False(t, usesContainerdStore(types.Info{DriverStatus: [][2]string{{"Backing Filesystem", "extfs"}}}))
+	assert.True(t, usesContainerdStore(types.Info{DriverStatus: [][2]string{{"driver-type", "io.containerd.snapshotter.v1"}}}))
+}
+
+func TestRunBuildKitBuildInvalidOptions(t *testing.T) {
+	docker, err := dockerclient.NewClientWithOpts(dockerclient.WithHost("tcp://127.0.0.1:1"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ctx = iostreams.NewContext(ctx, iostreams.System())
+
+	// Invalid options used to be reported by the solve goroutine, leaving
+	// the progress display waiting on a channel nothing would close.
+	_, err = runBuildKitBuild(ctx, docker, ImageOptions{CacheTo: []string{"mode=max"}}, "Dockerfile", nil)
+	assert.ErrorContains(t, err, "mode=max")
+	assert.NoError(t, ctx.Err())
+}
+
+func TestPushResultDigest(t *testing.T) {
+	aux := json.RawMessage(`{"Tag":"deployment-1","Digest":"sha256:d1","Size":1234}`)
+	assert.Equal(t, "sha256:d1", pushResultDigest(jsonmessage.JSONMessage{Aux: &aux}))
+
+	other := json.RawMessage(`{"ID":"sha256:abc"}`)
+	assert.Equal(t, "", pushResultDigest(jsonmessage.JSONMessage{Aux: &other}))
+	assert.Equal(t, "", pushResultDigest(jsonmessage.JSONMessage{Status: "Pushed"}))
+}
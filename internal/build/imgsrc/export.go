@@ -0,0 +1,100 @@
+package imgsrc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/moby/buildkit/client"
+)
+
+// ExportOutput is where a build exports its image instead of Docker's image
+// store, given as TYPE:PATH.
+type ExportOutput struct {
+	// Type is oci, for an OCI image layout, docker-archive, for a tarball
+	// `docker load` takes, or tar, for the filesystem of the image.
+	Type string
+	// Path is the file written to. For oci, a path not ending with .tar is
+	// the directory of the layout.
+	Path string
+}
+
+// ParseExportOutput parses an output such as oci:./out or
+// docker-archive:app.tar.
+func ParseExportOutput(s string) (*ExportOutput, error) {
+	typ, path, ok := strings.Cut(s, ":")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("invalid output %q, expected TYPE:PATH such as oci:./out", s)
+	}
+	switch typ {
+	case "oci", "docker-archive", "tar":
+		return &ExportOutput{Type: typ, Path: path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output type %q, expected oci, docker-archive or tar", typ)
+	}
+}
+
+func (o *ExportOutput) String() string {
+	return o.Type + ":" + o.Path
+}
+
+// isDir returns whether the output is a directory rather than a file.
+func (o *ExportOutput) isDir() bool {
+	return o.Type == "oci" && filepath.Ext(o.Path) != ".tar"
+}
+
+// exportEntry returns the BuildKit export of the image tagged tag to the
+// output.
+func (o *ExportOutput) exportEntry(tag string) client.ExportEntry {
+	var entry client.ExportEntry
+	switch o.Type {
+	case "oci":
+		entry = client.ExportEntry{Type: client.ExporterOCI, Attrs: map[string]string{"name": tag}}
+	case "docker-archive":
+		entry = client.ExportEntry{Type: client.ExporterDocker, Attrs: map[string]string{"name": tag}}
+	default:
+		entry = client.ExportEntry{Type: client.ExporterTar, Attrs: map[string]string{}}
+	}
+
+	if o.isDir() {
+		entry.Attrs["tar"] = "false"
+		entry.OutputDir = o.Path
+		return entry
+	}
+
+	path := o.Path
+	entry.Output = func(map[string]string) (io.WriteCloser, error) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		return os.Create(path)
+	}
+	return entry
+}
+
+// saveImage writes the image tagged tag of the image store of docker to
+// path, as a tarball docker load takes.
+func saveImage(ctx context.Context, docker dockerclient.APIClient, tag, path string) error {
+	r, err := docker.ImageSave(ctx, []string{tag})
+	if err != nil {
+		return fmt.Errorf("failed saving image %s: %w", tag, err)
+	}
+	defer r.Close() // skipcq: GO-S2307
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed saving image %s to %s: %w", tag, path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,81 @@
+package imgsrc
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/moby/buildkit/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExportOutput(t *testing.T) {
+	out, err := ParseExportOutput("oci:./out")
+	require.NoError(t, err)
+	assert.Equal(t, &ExportOutput{Type: "oci", Path: "./out"}, out)
+	assert.Equal(t, "oci:./out", out.String())
+
+	out, err = ParseExportOutput("docker-archive:C:/images/app.tar")
+	require.NoError(t, err)
+	assert.Equal(t, "C:/images/app.tar", out.Path)
+
+	for _, s := range []string{"./out", "oci:", "registry:registry.fly.io/app"} {
+		_, err := ParseExportOutput(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestExportEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	entry := (&ExportOutput{Type: "oci", Path: dir}).exportEntry("app:latest")
+	assert.Equal(t, client.ExporterOCI, entry.Type)
+	assert.Equal(t, dir, entry.OutputDir)
+	assert.Equal(t, "false", entry.Attrs["tar"])
+	assert.Nil(t, entry.Output)
+
+	path := filepath.Join(dir, "images", "app.tar")
+	entry = (&ExportOutput{Type: "docker-archive", Path: path}).exportEntry("app:latest")
+	assert.Equal(t, client.ExporterDocker, entry.Type)
+	assert.Equal(t, "app:latest", entry.Attrs["name"])
+	assert.Empty(t, entry.OutputDir)
+
+	w, err := entry.Output(nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+
+	entry = (&ExportOutput{Type: "tar", Path: path}).exportEntry("app:latest")
+	assert.Equal(t, client.ExporterTar, entry.Type)
+	assert.NotContains(t, entry.Attrs, "name")
+}
+
+// savingDocker is a Docker client whose ImageSave returns a tarball of the
+// tags it's given.
+type savingDocker struct {
+	dockerclient.APIClient
+	saved []string
+}
+
+func (d *savingDocker) ImageSave(_ context.Context, tags []string) (io.ReadCloser, error) {
+	d.saved = tags
+	return io.NopCloser(strings.NewReader("tarball of " + strings.Join(tags, ","))), nil
+}
+
+func TestSaveImage(t *testing.T) {
+	docker := &savingDocker{}
+	path := filepath.Join(t.TempDir(), "images", "app.tar")
+
+	require.NoError(t, saveImage(context.Background(), docker, "registry.fly.io/app:deployment-1", path))
+	assert.Equal(t, []string{"registry.fly.io/app:deployment-1"}, docker.saved)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "tarball of registry.fly.io/app:deployment-1", string(data))
+}
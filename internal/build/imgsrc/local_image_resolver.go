@@ -83,6 +83,7 @@ func (*localImageResolver) Run(ctx context.Context, dockerFactory *dockerClientF
 
 	span.SetAttributes(attribute.String("image.id", img.ID))
 
+	var digest string
 	if opts.Publish {
 		build.PushStart()
 		err = docker.ImageTag(ctx, img.ID, opts.Tag)
@@ -96,7 +97,7 @@ func (*localImageResolver) Run(ctx context.Context, dockerFactory *dockerClientF
 
 		cmdfmt.PrintBegin(streams.ErrOut, "Pushing image to fly")
 
-		if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+		if digest, err = pushToFly(ctx, docker, streams, opts.Tag); err != nil {
 			build.PushFinish()
 			return nil, "", err
 		}
@@ -105,9 +106,10 @@ func (*localImageResolver) Run(ctx context.Context, dockerFactory *dockerClientF
 	}
 
 	di := &DeploymentImage{
-		ID:   img.ID,
-		Tag:  opts.Tag,
-		Size: img.Size,
+		ID:     img.ID,
+		Tag:    opts.Tag,
+		Digest: digest,
+		Size:   img.Size,
 	}
 
 	span.SetAttributes(di.ToSpanAttributes()...)
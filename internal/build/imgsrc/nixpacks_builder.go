@@ -9,12 +9,14 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/flyctl"
+	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/proxy"
 	"github.com/superfly/flyctl/terminal"
@@ -106,6 +108,16 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 		return nil, note, nil
 	}
 
+	if opts.Output != nil {
+		build.BuildFinish()
+		return nil, "", errors.New("--output isn't supported by nixpacks builds")
+	}
+
+	if opts.SourceDateEpoch != nil {
+		build.BuildFinish()
+		return nil, "", errors.New("--reproducible isn't supported by nixpacks builds")
+	}
+
 	if err := ensureNixpacksBinary(ctx, streams); err != nil {
 		build.BuildFinish()
 		return nil, "", errors.Wrap(err, "could not install nixpacks")
@@ -199,12 +211,9 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 	confDir := flyctl.ConfigDir()
 	nixpacksPath := filepath.Join(confDir, "bin", "nixpacks")
 
-	nixpacksArgs := []string{"build", "--name", opts.Tag, opts.WorkingDir}
-	for _, kv := range os.Environ() {
-		if strings.HasPrefix(kv, "NIXPACKS_") {
-			nixpacksArgs = append(nixpacksArgs, "--env", kv)
-		}
-	}
+	nixpacksArgs := nixpacksBuildArgs(opts, os.Environ())
+
+	tb := render.NewTextBlock(ctx, "Building image with Nixpacks")
 
 	terminal.Debugf("calling nixpacks at %s with args: %v and docker host: %s", nixpacksPath, nixpacksArgs, dockerHost)
 
@@ -219,11 +228,13 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 		build.BuildFinish()
 		return nil, "", err
 	}
+	tb.Done("Nixpacks build done")
 	build.ImageBuildFinish()
 	build.BuildFinish()
 
 	build.PushStart()
-	if err := pushToFly(ctx, docker, streams, opts.Tag); err != nil {
+	digest, err := pushToFly(ctx, docker, streams, opts.Tag)
+	if err != nil {
 		build.PushFinish()
 		return nil, "", err
 	}
@@ -238,8 +249,42 @@ func (*nixpacksBuilder) Run(ctx context.Context, dockerFactory *dockerClientFact
 	}
 
 	return &DeploymentImage{
-		ID:   img.ID,
-		Tag:  opts.Tag,
-		Size: img.Size,
+		ID:     img.ID,
+		Tag:    opts.Tag,
+		Digest: digest,
+		Size:   img.Size,
 	}, "", nil
 }
+
+// nixpacksBuildArgs returns the arguments of nixpacks building the image of
+// opts. Build args become build environment variables, as do the NIXPACKS_
+// variables of environ configuring Nixpacks.
+func nixpacksBuildArgs(opts ImageOptions, environ []string) []string {
+	args := []string{"build", "--name", opts.Tag}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+
+	for _, k := range sortedKeys(opts.BuildArgs) {
+		args = append(args, "--env", k+"="+opts.BuildArgs[k])
+	}
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, "NIXPACKS_") {
+			args = append(args, "--env", kv)
+		}
+	}
+	for _, k := range sortedKeys(opts.Label) {
+		args = append(args, "--label", k+"="+opts.Label[k])
+	}
+
+	return append(args, opts.WorkingDir)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
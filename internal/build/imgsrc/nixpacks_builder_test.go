@@ -0,0 +1,27 @@
+package imgsrc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNixpacksBuildArgs(t *testing.T) {
+	args := nixpacksBuildArgs(ImageOptions{
+		Tag:        "registry.fly.io/my-app:deployment-1",
+		WorkingDir: "/src",
+		NoCache:    true,
+		BuildArgs:  map[string]string{"B": "2", "A": "1"},
+		Label:      map[string]string{"team": "web"},
+	}, []string{"HOME=/root", "NIXPACKS_NODE_VERSION=20"})
+
+	assert.Equal(t, []string{
+		"build", "--name", "registry.fly.io/my-app:deployment-1",
+		"--no-cache",
+		"--env", "A=1",
+		"--env", "B=2",
+		"--env", "NIXPACKS_NODE_VERSION=20",
+		"--label", "team=web",
+		"/src",
+	}, args)
+}
@@ -0,0 +1,28 @@
+package imgsrc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+)
+
+// isPodman reports whether the daemon behind docker is Podman serving its
+// Docker compatible API.
+func isPodman(ctx context.Context, docker *dockerclient.Client) (bool, error) {
+	version, err := docker.ServerVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return isPodmanVersion(version), nil
+}
+
+func isPodmanVersion(version types.Version) bool {
+	for _, c := range version.Components {
+		if strings.HasPrefix(strings.ToLower(c.Name), "podman") {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(version.Platform.Name), "podman")
+}
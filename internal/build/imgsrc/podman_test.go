@@ -0,0 +1,21 @@
+package imgsrc
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPodmanVersion(t *testing.T) {
+	assert.True(t, isPodmanVersion(types.Version{
+		Components: []types.ComponentVersion{{Name: "Podman Engine", Version: "4.9.3"}},
+	}))
+	assert.True(t, isPodmanVersion(types.Version{
+		Platform: struct{ Name string }{Name: "linux/amd64/fedora-39 (Podman)"},
+	}))
+	assert.False(t, isPodmanVersion(types.Version{
+		Platform:   struct{ Name string }{Name: "Docker Engine - Community"},
+		Components: []types.ComponentVersion{{Name: "Engine", Version: "26.1.0"}},
+	}))
+}
@@ -0,0 +1,70 @@
+package imgsrc
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/buildkit/util/progress/progressui"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// progressuiMode maps the progress mode of streams onto a BuildKit display
+// mode.
+func progressuiMode(streams *iostreams.IOStreams) progressui.DisplayMode {
+	switch streams.ProgressMode() {
+	case iostreams.ProgressPlain:
+		return progressui.PlainMode
+	case iostreams.ProgressJSON:
+		return progressui.RawJSONMode
+	case iostreams.ProgressQuiet:
+		return progressui.QuietMode
+	default:
+		return progressui.AutoMode
+	}
+}
+
+// displayJSONMessages renders the JSON message stream the Docker daemon
+// returns for builds and pushes according to the progress mode of streams.
+// In JSON mode they're translated into build events written to stderr, one
+// per line.
+func displayJSONMessages(streams *iostreams.IOStreams, in io.Reader, auxCallback func(jsonmessage.JSONMessage)) error {
+	switch streams.ProgressMode() {
+	case iostreams.ProgressJSON:
+		return passJSONMessages(streams.ErrOut, in, auxCallback)
+	case iostreams.ProgressQuiet:
+		return jsonmessage.DisplayJSONMessagesStream(in, io.Discard, 0, false, auxCallback)
+	case iostreams.ProgressPlain:
+		return jsonmessage.DisplayJSONMessagesStream(in, streams.ErrOut, streams.StderrFd(), false, auxCallback)
+	default:
+		return jsonmessage.DisplayJSONMessagesStream(in, streams.ErrOut, streams.StderrFd(), streams.IsStderrTTY(), auxCallback)
+	}
+}
+
+func passJSONMessages(out io.Writer, in io.Reader, auxCallback func(jsonmessage.JSONMessage)) error {
+	var (
+		dec    = json.NewDecoder(in)
+		events = newBuildEventWriter(out)
+	)
+
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); errors.Is(err, io.EOF) {
+			events.finishStage("finished")
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if msg.Error != nil {
+			events.finishStage("failed")
+			return msg.Error
+		}
+
+		events.jsonMessage(msg)
+		if msg.Aux != nil && auxCallback != nil {
+			auxCallback(msg)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package imgsrc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const testMessages = `{"stream":"Step 1/2 : FROM alpine\n"}
+{"aux":{"ID":"sha256:abc"}}
+{"stream":"Successfully built abc\n"}
+`
+
+func TestDisplayJSONMessagesJSON(t *testing.T) {
+	streams, _, _, errOut := iostreams.Test()
+	require.NoError(t, streams.SetProgress(iostreams.ProgressJSON))
+
+	var aux int
+	err := displayJSONMessages(streams, strings.NewReader(testMessages), func(jsonmessage.JSONMessage) { aux++ })
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, aux)
+	assert.Equal(t, 4, strings.Count(errOut.String(), "\n"))
+	assert.Contains(t, errOut.String(), `"type":"stage","time":`)
+	assert.Contains(t, errOut.String(), `"stage":"FROM alpine","status":"started"`)
+	assert.Contains(t, errOut.String(), `"type":"image"`)
+	assert.Contains(t, errOut.String(), `"stage":"FROM alpine","status":"finished"`)
+}
+
+func TestDisplayJSONMessagesQuiet(t *testing.T) {
+	streams, _, _, errOut := iostreams.Test()
+	streams.SetQuiet(true)
+
+	err := displayJSONMessages(streams, strings.NewReader(testMessages+`{"errorDetail":{"message":"boom"}}`), nil)
+	assert.EqualError(t, err, "boom")
+	assert.Empty(t, errOut.String())
+}
+
+func TestProgressuiMode(t *testing.T) {
+	streams, _, _, _ := iostreams.Test()
+	streams.SetStderrTTY(true)
+	assert.EqualValues(t, "auto", progressuiMode(streams))
+
+	require.NoError(t, streams.SetProgress(iostreams.ProgressJSON))
+	assert.EqualValues(t, "rawjson", progressuiMode(streams))
+}
@@ -0,0 +1,200 @@
+package imgsrc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	dockerclient "github.com/docker/docker/client"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// NewRemoteBuilderClient connects to the remote builder app builderApp,
+// starting it if it's stopped, and returns a client of its Docker daemon.
+func NewRemoteBuilderClient(ctx context.Context, apiClient *fly.Client, builderApp string, streams *iostreams.IOStreams, connectOverWireguard bool) (*dockerclient.Client, error) {
+	return newRemoteDockerClient(ctx, apiClient, builderApp, streams, newBuild("", true), nil, connectOverWireguard)
+}
+
+// EnsureOrgRemoteBuilder returns the remote builder app of the organization
+// orgID and its machine, creating them when the organization has none.
+func EnsureOrgRemoteBuilder(ctx context.Context, apiClient *fly.Client, orgID string) (*fly.GqlMachine, *fly.App, error) {
+	region := os.Getenv("FLY_REMOTE_BUILDER_REGION")
+	return apiClient.EnsureRemoteBuilder(ctx, orgID, "", region)
+}
+
+// RemoteBuilder selects the remote builder of builds, instead of the one
+// flyctl provisions for the organization of the app.
+type RemoteBuilder struct {
+	// App is the builder app to build on.
+	App string
+	// Size is the machine size to resize the builder to, such as
+	// performance-4x, for builds which run out of memory. The builder keeps
+	// it, so it applies to every later build on the builder too.
+	Size string
+}
+
+type remoteBuilderKey struct{}
+
+// WithRemoteBuilder returns a copy of ctx whose builds run on the remote
+// builder rb.
+func WithRemoteBuilder(ctx context.Context, rb RemoteBuilder) context.Context {
+	return context.WithValue(ctx, remoteBuilderKey{}, rb)
+}
+
+func remoteBuilderFromContext(ctx context.Context) RemoteBuilder {
+	rb, _ := ctx.Value(remoteBuilderKey{}).(RemoteBuilder)
+	return rb
+}
+
+// ValidateRemoteBuilderSize returns an error when size isn't a machine size.
+func ValidateRemoteBuilderSize(size string) error {
+	return new(fly.MachineGuest).SetSize(size)
+}
+
+// builderImageRepository is the repository of the image remote builders
+// run, whatever registry it's pulled from.
+const builderImageRepository = "flyio/rchab"
+
+// isBuilderImage reports whether image is an image of remote builders.
+func isBuilderImage(image string) bool {
+	repository, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository == builderImageRepository || strings.HasSuffix(repository, "/"+builderImageRepository)
+}
+
+// builderMachines returns the builder app name and its machines, or an error
+// when it isn't a remote builder app, so that builds aren't sent to a
+// machine of an app which doesn't run a builder.
+func builderMachines(ctx context.Context, apiClient *fly.Client, name string) (*fly.App, []*fly.Machine, error) {
+	app, err := apiClient.GetApp(ctx, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed retrieving builder %s: %w", name, err)
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: name})
+	if err != nil {
+		return nil, nil, err
+	}
+	machines, err := flapsClient.List(ctx, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed listing machines of builder %s: %w", name, err)
+	}
+	if len(machines) == 0 {
+		return nil, nil, fmt.Errorf("builder %s has no machine", name)
+	}
+	for _, m := range machines {
+		if m.Config == nil || !isBuilderImage(m.Config.Image) {
+			return nil, nil, fmt.Errorf("app %s isn't a remote builder: machine %s doesn't run the %s image", name, m.ID, builderImageRepository)
+		}
+	}
+	return app, machines, nil
+}
+
+// VerifyBuilderApp returns an error when the app name isn't a remote
+// builder app of the organization orgSlug.
+func VerifyBuilderApp(ctx context.Context, apiClient *fly.Client, name, orgSlug string) error {
+	app, _, err := builderMachines(ctx, apiClient, name)
+	if err != nil {
+		return err
+	}
+	if app.Organization.Slug != orgSlug {
+		return fmt.Errorf("builder %s belongs to %s, not to %s", name, app.Organization.Slug, orgSlug)
+	}
+	return nil
+}
+
+// pinnedBuilderMachine returns the builder app name and its machine, starting
+// it if it's stopped.
+func pinnedBuilderMachine(ctx context.Context, apiClient *fly.Client, name string) (*fly.GqlMachine, *fly.App, error) {
+	app, machines, err := builderMachines(ctx, apiClient, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: name})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	machine := machines[0]
+	for _, m := range machines {
+		if m.State == fly.MachineStateStarted {
+			machine = m
+			break
+		}
+	}
+	if machine.State != fly.MachineStateStarted {
+		terminal.Debugf("starting machine %s of builder %s", machine.ID, name)
+		if _, err := flapsClient.Start(ctx, machine.ID, ""); err != nil {
+			return nil, nil, fmt.Errorf("failed starting builder %s: %w", name, err)
+		}
+	}
+
+	gqlMachine := &fly.GqlMachine{
+		ID:     machine.ID,
+		Name:   machine.Name,
+		State:  machine.State,
+		Region: machine.Region,
+	}
+	gqlMachine.IPs.Nodes = []*fly.MachineIP{{Kind: "privatenet", IP: machine.PrivateIP}}
+	return gqlMachine, app, nil
+}
+
+// resizeBuilder updates the machine of the builder app to size, unless it's
+// already of that size, holding its lease so that it isn't updated by
+// another build at the same time. The builder keeps its new size for later
+// builds.
+func resizeBuilder(ctx context.Context, app, machineID, size string) error {
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: app})
+	if err != nil {
+		return err
+	}
+
+	lease, err := flapsClient.AcquireLease(ctx, machineID, fly.IntPointer(120))
+	if err != nil {
+		return fmt.Errorf("failed to obtain lease of builder %s: %w", app, err)
+	}
+	defer func() {
+		if releaseErr := flapsClient.ReleaseLease(ctx, machineID, lease.Data.Nonce); releaseErr != nil {
+			terminal.Debugf("failed releasing lease of builder %s: %v", app, releaseErr)
+		}
+	}()
+
+	machine, err := flapsClient.Get(ctx, machineID)
+	if err != nil {
+		return fmt.Errorf("failed retrieving machine of builder %s: %w", app, err)
+	}
+
+	config := machine.Config
+	if config.Guest == nil {
+		config.Guest = &fly.MachineGuest{}
+	}
+	if config.Guest.ToSize() == size {
+		return nil
+	}
+	if err := config.Guest.SetSize(size); err != nil {
+		return err
+	}
+
+	terminal.Infof("Resizing builder %s to %s\n", app, size)
+	updated, err := flapsClient.Update(ctx, fly.LaunchMachineInput{
+		ID:     machine.ID,
+		Region: machine.Region,
+		Config: config,
+	}, lease.Data.Nonce)
+	if err != nil {
+		return fmt.Errorf("failed resizing builder %s to %s: %w", app, size, err)
+	}
+	if err := flapsClient.Wait(ctx, updated, fly.MachineStateStarted, 2*time.Minute); err != nil {
+		return fmt.Errorf("builder %s didn't start after resizing it to %s: %w", app, size, err)
+	}
+	return nil
+}
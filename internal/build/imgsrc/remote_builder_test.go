@@ -0,0 +1,32 @@
+package imgsrc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteBuilderContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, RemoteBuilder{}, remoteBuilderFromContext(ctx))
+
+	rb := RemoteBuilder{App: "fly-builder-x", Size: "performance-4x"}
+	assert.Equal(t, rb, remoteBuilderFromContext(WithRemoteBuilder(ctx, rb)))
+}
+
+func TestValidateRemoteBuilderSize(t *testing.T) {
+	assert.NoError(t, ValidateRemoteBuilderSize("performance-4x"))
+	assert.ErrorContains(t, ValidateRemoteBuilderSize("performance-3x"), "invalid machine size")
+	assert.Error(t, ValidateRemoteBuilderSize("huge"))
+}
+
+func TestIsBuilderImage(t *testing.T) {
+	assert.True(t, isBuilderImage("flyio/rchab:sha-9346699"))
+	assert.True(t, isBuilderImage("docker-hub-mirror.fly.io/flyio/rchab:sha-9346699"))
+	assert.True(t, isBuilderImage("registry-1.docker.io/flyio/rchab@sha256:0123abcd"))
+	assert.True(t, isBuilderImage("localhost:5000/flyio/rchab"))
+	assert.False(t, isBuilderImage("registry.fly.io/my-app:deployment-01H"))
+	assert.False(t, isBuilderImage("flyio/rchab-fork:latest"))
+	assert.False(t, isBuilderImage(""))
+}
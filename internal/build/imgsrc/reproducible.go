@@ -0,0 +1,37 @@
+package imgsrc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ReproducibleFrontend is the Dockerfile frontend reproducible builds run
+// with, instead of the one the BuildKit of the builder ships, so the same
+// source builds the same way on any builder. It's pinned by digest, since
+// tags can be moved.
+const ReproducibleFrontend = "docker.io/docker/dockerfile:1.7.1@sha256:a57df69d0ea827fb7266491f2813635de6f17269be881f696fbfdf2d83dda33e"
+
+// SourceDateEpoch returns the time, in seconds since the Unix epoch,
+// reproducible builds of the source at dir clamp timestamps to: the one of
+// $SOURCE_DATE_EPOCH, or else the commit time of HEAD of its git repository.
+func SourceDateEpoch(ctx context.Context, dir string) (int64, error) {
+	if v := os.Getenv("SOURCE_DATE_EPOCH"); v != "" {
+		epoch, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || epoch < 0 {
+			return 0, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q, expected seconds since the Unix epoch", v)
+		}
+		return epoch, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "log", "-1", "--format=%ct")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("reproducible builds need SOURCE_DATE_EPOCH set or a git repository with a commit: %w", err)
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+}
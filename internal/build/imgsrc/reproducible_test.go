@@ -0,0 +1,57 @@
+package imgsrc
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReproducibleFrontendIsPinned(t *testing.T) {
+	_, digest, ok := strings.Cut(ReproducibleFrontend, "@sha256:")
+	assert.True(t, ok, "%s isn't pinned by digest", ReproducibleFrontend)
+	assert.Regexp(t, "^[0-9a-f]{64}$", digest)
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	epoch, err := SourceDateEpoch(context.Background(), t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000), epoch)
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	_, err = SourceDateEpoch(context.Background(), t.TempDir())
+	assert.ErrorContains(t, err, "invalid SOURCE_DATE_EPOCH")
+}
+
+func TestSourceDateEpochFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+
+	dir := t.TempDir()
+	_, err := SourceDateEpoch(context.Background(), dir)
+	assert.Error(t, err)
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(cmd.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_COMMITTER_DATE=1600000000 +0000",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "initial")
+
+	epoch, err := SourceDateEpoch(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1600000000), epoch)
+}
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -44,6 +45,10 @@ type ImageOptions struct {
 	Tag                  string
 	Target               string
 	NoCache              bool
+	CacheFrom            []string
+	CacheTo              []string
+	SBOM                 bool
+	SSH                  []string
 	BuiltIn              string
 	BuiltInSettings      map[string]interface{}
 	Builder              string
@@ -52,6 +57,13 @@ type ImageOptions struct {
 	BuildpacksDockerHost string
 	BuildpacksVolumes    []string
 	UseOverlaybd         bool
+	// Output is where the image is exported to instead of Docker's image
+	// store, for builds which aren't deployed.
+	Output *ExportOutput
+	// SourceDateEpoch, when set, makes the build reproducible: timestamps,
+	// including those of the files of layers, are clamped to it, and the
+	// Dockerfile frontend is pinned to ReproducibleFrontend.
+	SourceDateEpoch *int64
 }
 
 func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
@@ -65,6 +77,10 @@ func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
 		attribute.Bool("imageoptions.publish", io.Publish),
 		attribute.String("imageoptions.tag", io.Tag),
 		attribute.Bool("imageoptions.nocache", io.NoCache),
+		attribute.StringSlice("imageoptions.cache_from", io.CacheFrom),
+		attribute.StringSlice("imageoptions.cache_to", io.CacheTo),
+		attribute.Bool("imageoptions.sbom", io.SBOM),
+		attribute.StringSlice("imageoptions.ssh", io.SSH),
 		attribute.String("imageoptions.builtin", io.BuiltIn),
 		attribute.String("imageoptions.builder", io.BuiltIn),
 		attribute.String("imageoptions.buildpacks_docker_host", io.BuildpacksDockerHost),
@@ -82,7 +98,13 @@ func (io ImageOptions) ToSpanAttributes() []attribute.KeyValue {
 		attrs = append(attrs, attribute.String("imageoptions.extra_build_args", string(b)))
 	}
 
-	b, err = json.Marshal(io.BuildSecrets)
+	// Only the names of the secrets, which may be read from files.
+	secretNames := make([]string, 0, len(io.BuildSecrets))
+	for name := range io.BuildSecrets {
+		secretNames = append(secretNames, name)
+	}
+	sort.Strings(secretNames)
+	b, err = json.Marshal(secretNames)
 	if err == nil {
 		attrs = append(attrs, attribute.String("imageoptions.build_secrets", string(b)))
 	}
@@ -121,10 +143,15 @@ func (ro RefOptions) ToSpanAttributes() []attribute.KeyValue {
 }
 
 type DeploymentImage struct {
-	ID     string
-	Tag    string
+	ID  string
+	Tag string
+	// Digest is the digest of the manifest of the image in the registry,
+	// when it was pushed.
+	Digest string
 	Size   int64
 	Labels map[string]string
+	// Build describes how the image was built, when it was.
+	Build *BuildInfo
 }
 
 func (di DeploymentImage) ToSpanAttributes() []attribute.KeyValue {
@@ -186,6 +213,7 @@ func (r *Resolver) ResolveReference(ctx context.Context, streams *iostreams.IOSt
 		}
 		if img != nil {
 			bld.BuildAndPushFinish()
+			img.Build = bld.info(s.Name())
 			bld.FinishImageStrategy(s, false /* success */, nil, note)
 			r.finishBuild(ctx, bld, false /* completed */, "", img)
 			return img, nil
@@ -257,6 +285,7 @@ func (r *Resolver) BuildImage(ctx context.Context, streams *iostreams.IOStreams,
 		}
 		if img != nil {
 			bld.BuildAndPushFinish()
+			img.Build = bld.info(s.Name())
 			bld.FinishStrategy(s, false /* success */, nil, note)
 			r.finishBuild(ctx, bld, false /* completed */, "", img)
 			return img, nil
@@ -616,6 +645,17 @@ func heartbeat(ctx context.Context, client *dockerclient.Client, req *http.Reque
 	return &httpError{StatusCode: resp.StatusCode, Body: string(b)}
 }
 
+// EnsureRemoteBuilder connects to the remote builder, creating it when the
+// app has none, so that concurrent builds don't race to create it. It does
+// nothing when builds use a local Docker daemon.
+func (r *Resolver) EnsureRemoteBuilder(ctx context.Context) error {
+	if !r.dockerFactory.remote {
+		return nil
+	}
+	_, err := r.dockerFactory.buildFn(ctx, nil)
+	return err
+}
+
 // For remote builders send a periodic heartbeat during build to ensure machine stays alive
 // This is a noop for local builders
 func (r *Resolver) StartHeartbeat(ctx context.Context) (*StopSignal, error) {
@@ -750,9 +790,9 @@ func (s *StopSignal) Stop() {
 	})
 }
 
-func NewResolver(daemonType DockerDaemonType, apiClient *fly.Client, appName string, iostreams *iostreams.IOStreams, connectOverWireguard bool) *Resolver {
+func NewResolver(daemonType DockerDaemonType, apiClient *fly.Client, appName string, iostreams *iostreams.IOStreams, connectOverWireguard bool, localDockerHost string) *Resolver {
 	return &Resolver{
-		dockerFactory: newDockerClientFactory(daemonType, apiClient, appName, iostreams, connectOverWireguard),
+		dockerFactory: newDockerClientFactory(daemonType, apiClient, appName, iostreams, connectOverWireguard, localDockerHost),
 		apiClient:     apiClient,
 	}
 }
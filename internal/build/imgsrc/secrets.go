@@ -0,0 +1,81 @@
+package imgsrc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseBuildSecrets returns the values of the build secrets of specs. A spec
+// is either a NAME=VALUE literal, or in Docker's id=NAME,src=PATH and
+// id=NAME,env=VARIABLE forms to read the secret from a file or an environment
+// variable, so that large certificates and tokens don't have to appear on the
+// command line. Secrets are read here, before any build starts.
+// https://docs.docker.com/engine/reference/commandline/buildx_build/#secret
+func ParseBuildSecrets(specs []string) (map[string]string, error) {
+	secrets := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		name, value, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("'%s': must be in the format NAME=VALUE, id=NAME,src=PATH or id=NAME,env=VARIABLE", spec)
+		}
+		if name == "id" && (strings.Contains(value, ",src=") || strings.Contains(value, ",source=") || strings.Contains(value, ",env=")) {
+			var err error
+			if name, value, err = readBuildSecret(spec); err != nil {
+				return nil, err
+			}
+		}
+		secrets[name] = value
+	}
+	return secrets, nil
+}
+
+// readBuildSecret reads the secret of spec, in Docker's form.
+func readBuildSecret(spec string) (name, value string, err error) {
+	fields, err := csv.NewReader(strings.NewReader(spec)).Read()
+	if err != nil {
+		return "", "", fmt.Errorf("invalid build secret %q: %w", spec, err)
+	}
+
+	var src, env string
+	for _, field := range fields {
+		k, v, ok := strings.Cut(field, "=")
+		if !ok {
+			return "", "", fmt.Errorf("invalid build secret %q: %q isn't a key=value pair", spec, field)
+		}
+		switch strings.ToLower(k) {
+		case "id":
+			name = v
+		case "src", "source":
+			src = v
+		case "env":
+			env = v
+		case "type":
+			if v != "file" && v != "env" {
+				return "", "", fmt.Errorf("invalid build secret %q: unsupported type %q", spec, v)
+			}
+		default:
+			return "", "", fmt.Errorf("invalid build secret %q: unknown key %q", spec, k)
+		}
+	}
+
+	switch {
+	case name == "":
+		return "", "", fmt.Errorf("invalid build secret %q: missing id", spec)
+	case src != "" && env != "":
+		return "", "", fmt.Errorf("invalid build secret %q: set either src or env", spec)
+	case src != "":
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return "", "", fmt.Errorf("failed reading build secret %s: %w", name, err)
+		}
+		return name, string(data), nil
+	default:
+		data, ok := os.LookupEnv(env)
+		if !ok {
+			return "", "", fmt.Errorf("build secret %s reads the environment variable %s, which isn't set", name, env)
+		}
+		return name, data, nil
+	}
+}
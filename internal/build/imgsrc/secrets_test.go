@@ -0,0 +1,56 @@
+package imgsrc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBuildSecrets(t *testing.T) {
+	dir := t.TempDir()
+	cert := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(cert, []byte("-----BEGIN CERTIFICATE-----\n"), 0o600))
+	t.Setenv("NPM_TOKEN", "npm_123")
+
+	secrets, err := ParseBuildSecrets([]string{
+		"literal=value",
+		// Literal values are never read from elsewhere.
+		"at=@not/a/file",
+		"prefixed=env:NOT_A_VARIABLE",
+		"equals=a=b",
+		"id=cert,src=" + cert,
+		"id=key,source=" + cert + ",type=file",
+		"id=npm,env=NPM_TOKEN",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"literal":  "value",
+		"at":       "@not/a/file",
+		"prefixed": "env:NOT_A_VARIABLE",
+		"equals":   "a=b",
+		"cert":     "-----BEGIN CERTIFICATE-----\n",
+		"key":      "-----BEGIN CERTIFICATE-----\n",
+		"npm":      "npm_123",
+	}, secrets)
+
+	// A secret named id is still a literal.
+	secrets, err = ParseBuildSecrets([]string{"id=abc"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"id": "abc"}, secrets)
+
+	for spec, msg := range map[string]string{
+		"novalue":                             "must be in the format NAME=VALUE",
+		"id=cert,src=" + dir + "/missing":     "failed reading build secret cert",
+		"id=npm,env=FLYCTL_TEST_UNSET_VAR":    "FLYCTL_TEST_UNSET_VAR, which isn't set",
+		"id=,env=NPM_TOKEN":                   "missing id",
+		"id=x,src=" + cert + ",env=NPM_TOKEN": "set either src or env",
+		"id=x,env=NPM_TOKEN,type=ssh":         "unsupported type",
+		"id=x,env=NPM_TOKEN,mode=0400":        "unknown key",
+	} {
+		_, err := ParseBuildSecrets([]string{spec})
+		assert.ErrorContains(t, err, msg, spec)
+	}
+}
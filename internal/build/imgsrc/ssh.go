@@ -0,0 +1,64 @@
+package imgsrc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+)
+
+// parseSSHSpecs parses --ssh values the way docker buildx does:
+// default|<id>[=<socket>|<key>[,<key>]]. An id without paths forwards the
+// agent of SSH_AUTH_SOCK.
+func parseSSHSpecs(specs []string) ([]sshprovider.AgentConfig, error) {
+	var configs []sshprovider.AgentConfig
+	seen := map[string]bool{}
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		id, paths, _ := strings.Cut(spec, "=")
+		switch {
+		case id == "":
+			return nil, fmt.Errorf("invalid ssh option %q: missing id", spec)
+		case seen[id]:
+			return nil, fmt.Errorf("invalid ssh option %q: id %s is forwarded twice", spec, id)
+		}
+		seen[id] = true
+
+		config := sshprovider.AgentConfig{ID: id}
+		if paths != "" {
+			config.Paths = strings.Split(paths, ",")
+		} else if os.Getenv("SSH_AUTH_SOCK") == "" {
+			return nil, fmt.Errorf("ssh option %q forwards the agent of SSH_AUTH_SOCK, which isn't set, start an agent or give the path of a socket or key", spec)
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// ValidateSSH reports an error when the --ssh values specs can't be
+// forwarded, such as when a key doesn't exist, before a build is prepared.
+func ValidateSSH(specs []string) error {
+	_, err := sshAgentProvider(specs)
+	return err
+}
+
+// sshAgentProvider returns the session attachable forwarding the SSH agents
+// and keys of specs to RUN --mount=type=ssh steps, or nil without specs.
+func sshAgentProvider(specs []string) (session.Attachable, error) {
+	configs, err := parseSSHSpecs(specs)
+	if err != nil || len(configs) == 0 {
+		return nil, err
+	}
+
+	provider, err := sshprovider.NewSSHAgentProvider(configs)
+	if err != nil {
+		return nil, fmt.Errorf("failed forwarding ssh agent: %w", err)
+	}
+	return provider, nil
+}
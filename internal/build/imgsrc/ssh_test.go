@@ -0,0 +1,56 @@
+package imgsrc
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSSHSpecs(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "/run/agent.sock")
+
+	configs, err := parseSSHSpecs([]string{
+		"default",
+		"github=/run/agent.sock",
+		"deploy=/keys/id_ed25519,/keys/id_rsa",
+		" ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []sshprovider.AgentConfig{
+		{ID: "default"},
+		{ID: "github", Paths: []string{"/run/agent.sock"}},
+		{ID: "deploy", Paths: []string{"/keys/id_ed25519", "/keys/id_rsa"}},
+	}, configs)
+
+	_, err = parseSSHSpecs([]string{"=/run/agent.sock"})
+	assert.ErrorContains(t, err, "missing id")
+
+	_, err = parseSSHSpecs([]string{"default", "default=/run/agent.sock"})
+	assert.ErrorContains(t, err, "forwarded twice")
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	_, err = parseSSHSpecs([]string{"default"})
+	assert.ErrorContains(t, err, "SSH_AUTH_SOCK, which isn't set")
+
+	_, err = parseSSHSpecs([]string{"github=/run/agent.sock"})
+	assert.NoError(t, err)
+}
+
+func TestValidateSSH(t *testing.T) {
+	assert.NoError(t, ValidateSSH(nil))
+
+	missing := filepath.Join(t.TempDir(), "id_ed25519")
+	assert.Error(t, ValidateSSH([]string{"deploy=" + missing}))
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	assert.ErrorContains(t, ValidateSSH([]string{"default"}), "SSH_AUTH_SOCK")
+}
+
+func TestSSHAgentProviderWithoutSpecs(t *testing.T) {
+	provider, err := sshAgentProvider(nil)
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+}
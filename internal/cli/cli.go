@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"html/template"
 	"os"
+	"path/filepath"
 	"runtime/debug"
 	"slices"
 	"strings"
@@ -18,6 +19,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/env"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flag/flagnames"
@@ -35,6 +38,7 @@ import (
 
 	term2 "github.com/superfly/flyctl/terminal"
 
+	"github.com/superfly/flyctl/internal/command/alias"
 	"github.com/superfly/flyctl/internal/command/root"
 )
 
@@ -63,14 +67,22 @@ func Run(ctx context.Context, io *iostreams.IOStreams, args ...string) int {
 	defer httptracing.Finish()
 
 	cmd := root.New()
+	markValidationErrors(cmd)
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return flyerr.ValidationError{Err: err}
+	})
 	cmd.SetOut(io.Out)
 	cmd.SetErr(io.ErrOut)
 
+	args = expandAlias(cmd, args)
+
 	// Special case for the launch command, support `flyctl launch args -- [subargs]`
 	// Where the arguments after `--` are passed to the scanner/dockerfile generator.
+	// Likewise `flyctl redis connect --eval script -- [keys , args]` passes them to
+	// the script.
 	// This isn't supported natively by cobra, so we have to manually split the args
 	// See: https://github.com/spf13/cobra/issues/739
-	if len(args) > 0 && args[0] == "launch" {
+	if len(args) > 0 && (args[0] == "launch" || len(args) > 1 && args[0] == "redis" && args[1] == "connect") {
 		index := slices.Index(args, "--")
 		if index >= 0 {
 			ctx = flag.WithExtraArgs(ctx, args[index+1:])
@@ -93,6 +105,8 @@ func Run(ctx context.Context, io *iostreams.IOStreams, args ...string) int {
 
 	cmd, err = cmd.ExecuteContextC(ctx)
 
+	var exitErr flyerr.ExitCodeError
+
 	if cmd != nil {
 		metrics.RecordCommandFinish(cmd, err != nil)
 	}
@@ -102,17 +116,16 @@ func Run(ctx context.Context, io *iostreams.IOStreams, args ...string) int {
 
 	switch {
 	case err == nil:
-		return 0
+		return flyerr.ExitOK
 	case errors.Is(err, context.Canceled), errors.Is(err, terminal.InterruptErr):
-		return 127
-	case errors.Is(err, context.DeadlineExceeded):
-		printError(io, cs, cmd, err)
-		return 126
+		return flyerr.ExitCanceled
 	case isUnchangedError(err):
 		// This means the deployment was a noop, which is noteworthy but not something we should
 		// fail CI on. Print a warning and exit 0. Remove this once we're fully on Machines!
 		printError(io, cs, cmd, err)
-		return 0
+		return flyerr.ExitOK
+	case errors.As(err, &exitErr) && exitErr.Err == nil:
+		return exitErr.Code
 	default:
 		printError(io, cs, cmd, err)
 
@@ -122,7 +135,24 @@ func Run(ctx context.Context, io *iostreams.IOStreams, args ...string) int {
 			fmt.Println()
 		}
 
-		return 1
+		return flyerr.ExitCode(err)
+	}
+}
+
+// markValidationErrors makes the errors of invalid arguments and flags of cmd
+// and its subcommands validation errors, so they exit with the exit code of
+// those.
+func markValidationErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			if err := args(cmd, a); err != nil {
+				return flyerr.ValidationError{Err: err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		markValidationErrors(sub)
 	}
 }
 
@@ -270,3 +300,24 @@ Additional help topics:{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
 
 Use "{{.CommandPath}} [command] --help" for more information about a command.{{end}}
 `
+
+// expandAlias replaces a leading user-defined alias in args with its
+// expansion. Aliases that can't be read or parsed are ignored.
+func expandAlias(root *cobra.Command, args []string) []string {
+	dir, err := helpers.GetConfigDirectory()
+	if err != nil {
+		return args
+	}
+
+	aliases, err := config.ReadAliases(filepath.Join(dir, config.FileName))
+	if err != nil || len(aliases) == 0 {
+		return args
+	}
+
+	expanded, err := alias.Expand(root, args, aliases)
+	if err != nil {
+		term2.Debugf("failed expanding alias: %s", err)
+		return args
+	}
+	return expanded
+}
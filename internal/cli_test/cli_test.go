@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/superfly/flyctl/iostreams"
 
 	"github.com/superfly/flyctl/internal/cli"
+	"github.com/superfly/flyctl/internal/command/schema"
 )
 
 func TestVersion(t *testing.T) {
@@ -25,6 +27,17 @@ func TestVersion(t *testing.T) {
 	assert.Empty(t, stderr)
 }
 
+func TestJSONSchemaCommands(t *testing.T) {
+	root := cli.NewRootCommand()
+
+	for _, command := range schema.Commands() {
+		cmd, _, err := root.Find(strings.Fields(command))
+		require.NoError(t, err, command)
+		assert.Equal(t, "fly "+command, cmd.CommandPath())
+		assert.NotNil(t, cmd.LocalFlags().Lookup("json"), command)
+	}
+}
+
 func capture(ctx context.Context, t *testing.T, args ...string) (stdout, stderr string, code int) {
 	t.Helper()
 
@@ -13,6 +13,8 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag/flagctx"
 	"github.com/superfly/flyctl/internal/flyutil"
+	"github.com/superfly/flyctl/internal/httpretry"
+	"github.com/superfly/flyctl/internal/httptracing"
 	"github.com/superfly/flyctl/internal/instrument"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/state"
@@ -48,7 +50,7 @@ func InitClient(ctx context.Context) (context.Context, error) {
 	fly.SetBaseURL(cfg.APIBaseURL)
 	fly.SetErrorLog(cfg.LogGQLErrors)
 	fly.SetInstrumenter(instrument.ApiAdapter)
-	fly.SetTransport(otelhttp.NewTransport(http.DefaultTransport))
+	fly.SetTransport(otelhttp.NewTransport(httpretry.NewFromContext(ctx, httptracing.NewTransport(http.DefaultTransport))))
 
 	c := flyutil.NewClientFromOptions(ctx, fly.ClientOptions{Tokens: cfg.Tokens})
 	logger.Debug("client initialized.")
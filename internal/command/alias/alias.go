@@ -0,0 +1,202 @@
+// Package alias implements the alias command chain.
+package alias
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() *cobra.Command {
+	const (
+		short = "Manage command aliases"
+		long  = `Aliases are shortcuts for commands and their flags, kept in the flyctl
+config file:
+
+  fly alias set deployprod 'deploy -a myapp-prod --strategy bluegreen'
+  fly deployprod --detach
+
+Arguments following an alias are appended to its expansion. Aliases can't
+shadow built-in commands.
+`
+	)
+
+	cmd := command.New("alias", short, long, nil)
+	cmd.AddCommand(
+		newSet(),
+		newList(),
+		newDelete(),
+	)
+	return cmd
+}
+
+func newSet() *cobra.Command {
+	const (
+		short = "Set an alias to expand to a command"
+		long  = short + "\n"
+	)
+
+	cmd := command.New("set <name> <expansion>", short, long, runSet)
+	cmd.Args = cobra.ExactArgs(2)
+	return cmd
+}
+
+func newList() *cobra.Command {
+	const (
+		short = "List aliases"
+		long  = short + "\n"
+	)
+
+	cmd := command.New("list", short, long, runList)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd, flag.JSONOutput())
+	return cmd
+}
+
+func newDelete() *cobra.Command {
+	const (
+		short = "Delete an alias"
+		long  = short + "\n"
+	)
+
+	cmd := command.New("delete <name>", short, long, runDelete)
+	cmd.Aliases = []string{"rm", "remove"}
+	cmd.Args = cobra.ExactArgs(1)
+	return cmd
+}
+
+func runSet(ctx context.Context) error {
+	var (
+		out       = iostreams.FromContext(ctx).Out
+		name      = flag.FirstArg(ctx)
+		expansion = strings.TrimSpace(flag.Args(ctx)[1])
+	)
+
+	if err := validate(command.FromContext(ctx).Root(), name, expansion); err != nil {
+		return err
+	}
+
+	path := state.ConfigFile(ctx)
+	if err := config.SetAlias(path, name, expansion); err != nil {
+		return fmt.Errorf("failed persisting alias in %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "fly %s now runs fly %s\n", name, expansion)
+	return nil
+}
+
+// validate checks name doesn't shadow a command of root, and that expansion
+// starts with one.
+func validate(root *cobra.Command, name, expansion string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid alias name %q", name)
+	}
+	if IsCommand(root, name) {
+		return fmt.Errorf("%s is a fly command and can't be aliased", name)
+	}
+
+	args, err := shlex.Split(expansion)
+	if err != nil {
+		return fmt.Errorf("invalid expansion: %w", err)
+	}
+	if len(args) == 0 || !IsCommand(root, args[0]) {
+		return fmt.Errorf("the expansion must start with a fly command, such as deploy")
+	}
+	return nil
+}
+
+func runList(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	aliases, err := config.ReadAliases(state.ConfigFile(ctx))
+	if err != nil {
+		return err
+	}
+	if aliases == nil {
+		aliases = map[string]string{}
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, aliases)
+	}
+
+	if len(aliases) == 0 {
+		fmt.Fprintln(out, "No aliases set, add one with fly alias set")
+		return nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name, aliases[name]})
+	}
+	return render.Table(out, "", rows, "Alias", "Expansion")
+}
+
+func runDelete(ctx context.Context) error {
+	var (
+		out  = iostreams.FromContext(ctx).Out
+		name = flag.FirstArg(ctx)
+		path = state.ConfigFile(ctx)
+	)
+
+	aliases, err := config.ReadAliases(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := aliases[name]; !ok {
+		return fmt.Errorf("no alias named %s", name)
+	}
+
+	if err := config.SetAlias(path, name, ""); err != nil {
+		return fmt.Errorf("failed persisting aliases in %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "Deleted alias %s\n", name)
+	return nil
+}
+
+// IsCommand reports whether name is a subcommand of root, or an alias of one.
+func IsCommand(root *cobra.Command, name string) bool {
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help" || name == "completion"
+}
+
+// Expand returns args with a leading alias replaced by its expansion.
+func Expand(root *cobra.Command, args []string, aliases map[string]string) ([]string, error) {
+	if len(args) == 0 || IsCommand(root, args[0]) {
+		return args, nil
+	}
+
+	expansion, ok := aliases[args[0]]
+	if !ok {
+		return args, nil
+	}
+
+	expanded, err := shlex.Split(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expansion of alias %s: %w", args[0], err)
+	}
+	return append(expanded, args[1:]...), nil
+}
@@ -0,0 +1,52 @@
+package alias
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRoot() *cobra.Command {
+	root := &cobra.Command{Use: "fly"}
+	root.AddCommand(
+		&cobra.Command{Use: "deploy"},
+		&cobra.Command{Use: "status", Aliases: []string{"st"}},
+	)
+	return root
+}
+
+func TestExpand(t *testing.T) {
+	aliases := map[string]string{
+		"deployprod": "deploy -a myapp-prod --strategy 'blue green'",
+		"status":     "deploy",
+	}
+
+	args, err := Expand(newRoot(), []string{"deployprod", "--detach"}, aliases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy", "-a", "myapp-prod", "--strategy", "blue green", "--detach"}, args)
+
+	args, err = Expand(newRoot(), []string{"status", "-a", "x"}, aliases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"status", "-a", "x"}, args)
+
+	args, err = Expand(newRoot(), []string{"unknown"}, aliases)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unknown"}, args)
+
+	args, err = Expand(newRoot(), nil, aliases)
+	require.NoError(t, err)
+	assert.Empty(t, args)
+}
+
+func TestValidate(t *testing.T) {
+	root := newRoot()
+
+	assert.NoError(t, validate(root, "deployprod", "deploy -a myapp-prod"))
+	assert.Error(t, validate(root, "deploy", "deploy -a myapp-prod"))
+	assert.Error(t, validate(root, "st", "deploy"))
+	assert.Error(t, validate(root, "-x", "deploy"))
+	assert.Error(t, validate(root, "dp", "launch"))
+	assert.Error(t, validate(root, "dp", ""))
+}
@@ -36,11 +36,13 @@ The LIST command will list all currently registered applications.
 		newDestroy(),
 		newRestart(),
 		newMove(),
+		newClone(),
 		newResume(),
 		newSuspend(),
 		NewOpen(),
 		NewReleases(),
 		newErrors(),
+		newCrashes(),
 	)
 
 	return apps
@@ -0,0 +1,445 @@
+package apps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// snapshotTimeout is how long volume snapshots may take to be created.
+const snapshotTimeout = 15 * time.Minute
+
+func newClone() *cobra.Command {
+	const (
+		long = `The APPS CLONE command will copy an application into a new one,
+in the organization and regions of your choice: its machines, its volumes
+from fresh snapshots, its secrets and its IP addresses. Secret values can't be
+read back from the platform, so they are prompted for unless --copy-values
+reads them from a running machine of the source app. Dedicated IPv4 addresses
+are paid for, so they are only allocated once confirmed. The new app is
+destroyed when the clone fails midway.
+`
+		short = "Copy an app into a new app"
+		usage = "clone <SOURCE APP> [NEW APP]"
+	)
+
+	cmd := command.New(usage, short, long, runClone,
+		command.RequireSession,
+	)
+
+	cmd.Args = cobra.RangeArgs(1, 2)
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.Yes(),
+		flag.StringSlice{
+			Name:        "regions",
+			Description: "Regions to spread the machines of the new app over, defaults to the regions of the source app",
+		},
+		flag.Bool{
+			Name:        "copy-values",
+			Description: "Copy the values of secrets from a running machine of the source app instead of prompting for them",
+		},
+		flag.Bool{
+			Name:        "empty-volumes",
+			Description: "Create empty volumes instead of forking those of the source app",
+		},
+		flag.Bool{
+			Name:        "skip-ips",
+			Description: "Don't allocate the IP addresses the source app has",
+		},
+	)
+
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteApps)
+
+	return cmd
+}
+
+func runClone(ctx context.Context) (err error) {
+	var (
+		client   = fly.ClientFromContext(ctx)
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+		args     = flag.Args(ctx)
+		regions  = flag.GetStringSlice(ctx, "regions")
+	)
+
+	source, err := client.GetAppCompact(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed fetching app: %w", err)
+	}
+
+	sourceCtx, err := BuildContext(ctx, source)
+	if err != nil {
+		return err
+	}
+
+	machines, err := mach.ListActive(sourceCtx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving machines: %w", err)
+	}
+
+	var name string
+	if len(args) > 1 {
+		name = args[1]
+	} else if name, err = prompt.SelectAppName(ctx); err != nil {
+		return err
+	}
+
+	org, err := prompt.Org(ctx)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := cloneSecrets(sourceCtx, source, machines)
+	if err != nil {
+		return err
+	}
+
+	var ips []fly.IPAddress
+	if !flag.GetBool(ctx, "skip-ips") {
+		if ips, err = ipsToClone(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	app, err := client.CreateApp(ctx, fly.CreateAppInput{
+		OrganizationID: org.ID,
+		Name:           name,
+		Machines:       true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating app %s: %w", name, err)
+	}
+	fmt.Fprintf(io.Out, "Created app %s in organization %s\n", colorize.Bold(app.Name), app.Organization.Slug)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		fmt.Fprintf(io.ErrOut, "Destroying app %s, as the clone failed\n", app.Name)
+		if destroyErr := client.DeleteApp(context.WithoutCancel(ctx), app.Name); destroyErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed destroying app %s, destroy it with 'fly apps destroy %s': %w", app.Name, app.Name, destroyErr))
+		}
+	}()
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: app.Name})
+	if err != nil {
+		return err
+	}
+	targetCtx := flaps.NewContext(ctx, flapsClient)
+
+	if len(secrets) > 0 {
+		if _, err := client.SetSecrets(ctx, app.Name, secrets); err != nil {
+			return fmt.Errorf("failed setting secrets: %w", err)
+		}
+		fmt.Fprintf(io.Out, "Set %d secrets\n", len(secrets))
+	}
+
+	// Standbys refer to the machines they watch, so those are cloned first.
+	machines = slices.Clone(machines)
+	slices.SortStableFunc(machines, func(a, b *fly.Machine) int {
+		return len(a.Config.Standbys) - len(b.Config.Standbys)
+	})
+
+	clones := map[string]string{}
+	for i, m := range machines {
+		region := m.Region
+		if len(regions) > 0 {
+			region = regions[i%len(regions)]
+		}
+
+		clone, err := cloneMachine(sourceCtx, targetCtx, m, region, clones)
+		if err != nil {
+			return fmt.Errorf("failed cloning machine %s: %w", m.ID, err)
+		}
+		clones[m.ID] = clone.ID
+		fmt.Fprintf(io.Out, "Cloned machine %s into %s in %s\n", m.ID, colorize.Bold(clone.ID), region)
+	}
+
+	for _, ip := range ips {
+		allocated, err := allocateIPLike(ctx, ip, app.Name, org)
+		if err != nil {
+			return fmt.Errorf("failed allocating %s address: %w", ip.Type, err)
+		}
+		fmt.Fprintf(io.Out, "Allocated %s address %s\n", ip.Type, allocated)
+	}
+
+	fmt.Fprintf(io.Out, "App %s is a clone of %s\n", colorize.Bold(app.Name), source.Name)
+	return nil
+}
+
+// cloneSecrets returns the secrets of source, either read from one of its
+// running machines or prompted for.
+func cloneSecrets(ctx context.Context, source *fly.AppCompact, machines []*fly.Machine) (map[string]string, error) {
+	client := fly.ClientFromContext(ctx)
+
+	secrets, err := client.GetAppSecrets(ctx, source.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving secrets: %w", err)
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]string, len(secrets))
+
+	if flag.GetBool(ctx, "copy-values") {
+		i := slices.IndexFunc(machines, func(m *fly.Machine) bool { return m.State == fly.MachineStateStarted })
+		if i < 0 {
+			return nil, fmt.Errorf("app %s has no running machine to read secrets from", source.Name)
+		}
+
+		flapsClient := flaps.FromContext(ctx)
+		for _, secret := range secrets {
+			out, err := flapsClient.Exec(ctx, machines[i].ID, &fly.MachineExecRequest{
+				Cmd:     "printenv " + secret.Name,
+				Timeout: 10,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed reading secret %s: %w", secret.Name, err)
+			}
+			if out.ExitCode != 0 {
+				return nil, fmt.Errorf("secret %s isn't set on machine %s, it may need a deploy", secret.Name, machines[i].ID)
+			}
+			values[secret.Name] = strings.TrimSuffix(out.StdOut, "\n")
+		}
+		return values, nil
+	}
+
+	for _, secret := range secrets {
+		var value string
+		err := prompt.Password(ctx, &value, fmt.Sprintf("Value of secret %s:", secret.Name), true)
+		switch {
+		case prompt.IsNonInteractive(err):
+			return nil, prompt.NonInteractiveError("copy-values flag must be specified when not running interactively")
+		case err != nil:
+			return nil, err
+		}
+		values[secret.Name] = value
+	}
+	return values, nil
+}
+
+// cloneMachine launches a copy of m in region, with copies of its volumes.
+// clones maps the machines already cloned to their copies.
+func cloneMachine(sourceCtx, targetCtx context.Context, m *fly.Machine, region string, clones map[string]string) (*fly.Machine, error) {
+	var (
+		sourceFlaps = flaps.FromContext(sourceCtx)
+		targetFlaps = flaps.FromContext(targetCtx)
+		config      = cloneConfig(m.Config, clones)
+	)
+
+	for i, mnt := range config.Mounts {
+		vol, err := sourceFlaps.GetVolume(sourceCtx, mnt.Volume)
+		if err != nil {
+			return nil, err
+		}
+
+		input := fly.CreateVolumeRequest{
+			Name:                vol.Name,
+			Region:              region,
+			SizeGb:              &vol.SizeGb,
+			Encrypted:           &vol.Encrypted,
+			ComputeRequirements: config.Guest,
+			ComputeImage:        config.Image,
+		}
+
+		if !flag.GetBool(sourceCtx, "empty-volumes") {
+			snapshot, err := snapshotVolume(sourceCtx, vol.ID)
+			if err != nil {
+				return nil, err
+			}
+			input.SnapshotID = &snapshot.ID
+		}
+
+		clone, err := targetFlaps.CreateVolume(targetCtx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating volume: %w", err)
+		}
+		config.Mounts[i].Volume = clone.ID
+	}
+
+	return targetFlaps.Launch(targetCtx, fly.LaunchMachineInput{
+		Name:       m.Name,
+		Region:     region,
+		Config:     config,
+		SkipLaunch: m.State != fly.MachineStateStarted || len(config.Standbys) > 0,
+	})
+}
+
+// cloneConfig returns a copy of config for a clone, without release metadata
+// and with standbys of the machines clones maps to their copies.
+func cloneConfig(config *fly.MachineConfig, clones map[string]string) *fly.MachineConfig {
+	config = mach.CloneConfig(config)
+
+	delete(config.Metadata, fly.MachineConfigMetadataKeyFlyReleaseId)
+	delete(config.Metadata, fly.MachineConfigMetadataKeyFlyReleaseVersion)
+
+	var standbys []string
+	for _, standby := range config.Standbys {
+		if clone, ok := clones[standby]; ok {
+			standbys = append(standbys, clone)
+		}
+	}
+	config.Standbys = standbys
+
+	return config
+}
+
+// snapshotVolume takes a snapshot of the volume volID and waits for it to be
+// usable.
+func snapshotVolume(ctx context.Context, volID string) (*fly.VolumeSnapshot, error) {
+	flapsClient := flaps.FromContext(ctx)
+
+	// The snapshot ID isn't returned, so it's the one missing from the
+	// snapshots listed beforehand.
+	existing, err := flapsClient.GetVolumeSnapshots(ctx, volID)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		known[s.ID] = true
+	}
+
+	if err := flapsClient.CreateVolumeSnapshot(ctx, volID); err != nil {
+		return nil, fmt.Errorf("failed snapshotting volume %s: %w", volID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	for {
+		snapshots, err := flapsClient.GetVolumeSnapshots(ctx, volID)
+		if err != nil {
+			return nil, err
+		}
+		if s := newSnapshot(snapshots, known); s != nil && s.Status == "created" {
+			return s, nil
+		}
+
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("snapshot of volume %s wasn't created within %s", volID, snapshotTimeout)
+			}
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// newSnapshot returns the earliest of snapshots that isn't known.
+func newSnapshot(snapshots []fly.VolumeSnapshot, known map[string]bool) *fly.VolumeSnapshot {
+	var found *fly.VolumeSnapshot
+	for i, s := range snapshots {
+		if known[s.ID] {
+			continue
+		}
+		if found == nil || s.CreatedAt.Before(found.CreatedAt) {
+			found = &snapshots[i]
+		}
+	}
+	return found
+}
+
+// ipsToClone returns the IP addresses of source to allocate to its clone.
+// Dedicated IPv4 addresses are paid for, so they are replaced by a shared one
+// unless confirmed.
+func ipsToClone(ctx context.Context, source *fly.AppCompact) ([]fly.IPAddress, error) {
+	ips, err := fly.ClientFromContext(ctx).GetIPAddresses(ctx, source.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving IP addresses: %w", err)
+	}
+
+	dedicated := countDedicatedV4(ips)
+	if dedicated == 0 || flag.GetYes(ctx) {
+		return ips, nil
+	}
+
+	msg := fmt.Sprintf(`App %s has %d dedicated IPv4 addresses, which cost $2/mo each.
+Allocate as many to the clone? Otherwise a shared IPv4 address is allocated.`, source.Name, dedicated)
+	confirmed, err := prompt.Confirm(ctx, msg)
+	switch {
+	case prompt.IsNonInteractive(err):
+		return nil, prompt.NonInteractiveError("yes or skip-ips flag must be specified when not running interactively")
+	case err != nil:
+		return nil, err
+	}
+	return withoutDedicatedV4(ips, confirmed), nil
+}
+
+func countDedicatedV4(ips []fly.IPAddress) (n int) {
+	for _, ip := range ips {
+		if ip.Type == "v4" {
+			n++
+		}
+	}
+	return n
+}
+
+// withoutDedicatedV4 returns ips, where dedicated IPv4 addresses are replaced
+// by a shared one unless keep is set.
+func withoutDedicatedV4(ips []fly.IPAddress, keep bool) []fly.IPAddress {
+	if keep || countDedicatedV4(ips) == 0 {
+		return ips
+	}
+
+	var (
+		kept   []fly.IPAddress
+		shared bool
+	)
+	for _, ip := range ips {
+		if ip.Type == "v4" {
+			continue
+		}
+		shared = shared || ip.Type == "shared_v4"
+		kept = append(kept, ip)
+	}
+	if !shared {
+		kept = append(kept, fly.IPAddress{Type: "shared_v4"})
+	}
+	return kept
+}
+
+// allocateIPLike allocates to appName an IP address of the type of ip.
+func allocateIPLike(ctx context.Context, ip fly.IPAddress, appName string, org *fly.Organization) (string, error) {
+	client := fly.ClientFromContext(ctx)
+
+	switch ip.Type {
+	case "shared_v4":
+		addr, err := client.AllocateSharedIPAddress(ctx, appName)
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	case "private_v6":
+		addr, err := client.AllocateIPAddress(ctx, appName, ip.Type, "", org, "")
+		if err != nil {
+			return "", err
+		}
+		return addr.Address, nil
+	default:
+		addr, err := client.AllocateIPAddress(ctx, appName, ip.Type, ip.Region, nil, "")
+		if err != nil {
+			return "", err
+		}
+		return addr.Address, nil
+	}
+}
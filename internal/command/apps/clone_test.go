@@ -0,0 +1,61 @@
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestCloneConfig(t *testing.T) {
+	config := &fly.MachineConfig{
+		Image: "app:latest",
+		Metadata: map[string]string{
+			fly.MachineConfigMetadataKeyFlyReleaseId:      "rel",
+			fly.MachineConfigMetadataKeyFlyReleaseVersion: "3",
+			fly.MachineConfigMetadataKeyFlyProcessGroup:   "app",
+		},
+		Standbys: []string{"m1", "m2"},
+	}
+
+	clone := cloneConfig(config, map[string]string{"m1": "c1"})
+
+	assert.Equal(t, map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: "app"}, clone.Metadata)
+	assert.Equal(t, []string{"c1"}, clone.Standbys, "standbys of machines not cloned are dropped")
+	assert.Equal(t, []string{"m1", "m2"}, config.Standbys, "the source config is left untouched")
+	assert.Len(t, config.Metadata, 3)
+}
+
+func TestNewSnapshot(t *testing.T) {
+	now := time.Now()
+	snapshots := []fly.VolumeSnapshot{
+		{ID: "old", CreatedAt: now.Add(-time.Hour)},
+		{ID: "other", CreatedAt: now.Add(time.Second)},
+		{ID: "ours", CreatedAt: now},
+	}
+
+	assert.Nil(t, newSnapshot(snapshots[:1], map[string]bool{"old": true}))
+
+	s := newSnapshot(snapshots, map[string]bool{"old": true})
+	if assert.NotNil(t, s) {
+		assert.Equal(t, "ours", s.ID, "the earliest unknown snapshot, even when another one was taken since")
+	}
+}
+
+func TestWithoutDedicatedV4(t *testing.T) {
+	types := func(ips []fly.IPAddress) (types []string) {
+		for _, ip := range ips {
+			types = append(types, ip.Type)
+		}
+		return types
+	}
+
+	ips := []fly.IPAddress{{Type: "v4"}, {Type: "v6"}, {Type: "v4"}}
+	assert.Equal(t, 2, countDedicatedV4(ips))
+	assert.Equal(t, []string{"v4", "v6", "v4"}, types(withoutDedicatedV4(ips, true)))
+	assert.Equal(t, []string{"v6", "shared_v4"}, types(withoutDedicatedV4(ips, false)))
+
+	ips = []fly.IPAddress{{Type: "shared_v4"}, {Type: "v4"}}
+	assert.Equal(t, []string{"shared_v4"}, types(withoutDedicatedV4(ips, false)), "a single shared address")
+}
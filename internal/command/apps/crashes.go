@@ -0,0 +1,276 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newCrashes() (cmd *cobra.Command) {
+	const (
+		short = "Summarize recent crashes of an app's machines"
+		long  = short + `: OOM kills, non-zero exits and failing
+health checks, grouped by process group and region, so crash patterns show
+without scrolling through logs. Exits the machine was asked to do, such as
+stops during deploys, aren't counted.
+
+Only the latest events of each machine are kept, so machines which
+restarted often may have older crashes missing: their counts are marked
+with a +, and listed as incomplete with --json.
+`
+		usage = "crashes"
+	)
+
+	cmd = command.New(usage, short, long, runCrashes, command.RequireSession, command.RequireAppName)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.Duration{
+			Name:        "since",
+			Description: "How far back to look for crashes",
+			Default:     24 * time.Hour,
+		},
+	)
+	return cmd
+}
+
+func runCrashes(ctx context.Context) error {
+	var (
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+		since   = flag.GetDuration(ctx, "since")
+	)
+
+	if since <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err != nil {
+		return err
+	}
+
+	machines, err := flapsClient.List(ctx, "include_deleted=true")
+	if err != nil {
+		return fmt.Errorf("failed to list machines: %w", err)
+	}
+
+	summaries := summarizeCrashes(machines, time.Now().Add(-since))
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, summaries)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Fprintf(out, "No crashes in the last %s\n", since)
+		return nil
+	}
+
+	var incomplete bool
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		count := strconv.Itoa
+		if len(s.Incomplete) > 0 {
+			incomplete = true
+			count = func(n int) string { return strconv.Itoa(n) + "+" }
+		}
+		rows = append(rows, []string{
+			s.ProcessGroup,
+			s.Region,
+			count(s.OOMKills),
+			count(s.Crashes),
+			formatExitCodes(s.ExitCodes),
+			strconv.Itoa(s.FailingChecks),
+			strconv.Itoa(len(s.Machines)),
+			s.Last.Local().Format(time.DateTime),
+		})
+	}
+	if err := render.Table(out, "", rows, "Process Group", "Region", "OOM Kills", "Crashes", "Exit Codes", "Failing Checks", "Machines", "Last"); err != nil {
+		return err
+	}
+
+	if incomplete {
+		fmt.Fprintf(out, "Counts marked + may miss older crashes: only the latest events of each machine are kept.\n")
+	}
+	return nil
+}
+
+// crashSummary counts the crashes of the machines of a process group in a
+// region.
+type crashSummary struct {
+	ProcessGroup string `json:"process_group"`
+	Region       string `json:"region"`
+	OOMKills     int    `json:"oom_kills"`
+	// Crashes are exits with a non-zero code which weren't OOM kills.
+	Crashes   int         `json:"crashes"`
+	ExitCodes map[int]int `json:"exit_codes,omitempty"`
+	// FailingChecks are the health checks currently critical.
+	FailingChecks int      `json:"failing_checks"`
+	Machines      []string `json:"machines"`
+	// Incomplete are the machines whose kept events don't go back to the
+	// start of the window, so that the counts are lower bounds.
+	Incomplete []string  `json:"incomplete,omitempty"`
+	Last       time.Time `json:"last"`
+}
+
+func (s *crashSummary) total() int {
+	return s.OOMKills + s.Crashes + s.FailingChecks
+}
+
+func (s *crashSummary) record(machineID string, at time.Time) {
+	if !slices.Contains(s.Machines, machineID) {
+		s.Machines = append(s.Machines, machineID)
+	}
+	if at.After(s.Last) {
+		s.Last = at
+	}
+}
+
+// summarizeCrashes groups the OOM kills, non-zero exits and failing checks
+// of machines since from by process group and region, the groups with the
+// most first.
+//
+// The Machines API only keeps the latest events of each machine: when the
+// oldest one kept is after from and isn't the machine's launch, earlier
+// crashes may be missing and the machine is listed as incomplete in its
+// group.
+func summarizeCrashes(machines []*fly.Machine, from time.Time) []*crashSummary {
+	groups := map[string]*crashSummary{}
+	key := func(m *fly.Machine) string {
+		return m.ProcessGroup() + "/" + m.Region
+	}
+	summary := func(m *fly.Machine) *crashSummary {
+		s, ok := groups[key(m)]
+		if !ok {
+			s = &crashSummary{ProcessGroup: m.ProcessGroup(), Region: m.Region}
+			groups[key(m)] = s
+		}
+		return s
+	}
+
+	for _, m := range machines {
+		for _, e := range m.Events {
+			if e == nil || e.Type != "exit" || e.Request == nil || e.Time().Before(from) {
+				continue
+			}
+			exit := e.Request.ExitEvent
+			if e.Request.MonitorEvent != nil && e.Request.MonitorEvent.ExitEvent != nil {
+				exit = e.Request.MonitorEvent.ExitEvent
+			}
+			if exit == nil || exit.RequestedStop {
+				continue
+			}
+
+			switch {
+			case exit.OOMKilled:
+				s := summary(m)
+				s.OOMKills++
+				s.record(m.ID, e.Time())
+			case exit.ExitCode != 0:
+				s := summary(m)
+				s.Crashes++
+				if s.ExitCodes == nil {
+					s.ExitCodes = map[int]int{}
+				}
+				s.ExitCodes[exit.ExitCode]++
+				s.record(m.ID, e.Time())
+			}
+		}
+
+		// Deleted machines' checks no longer matter.
+		if m.State == fly.MachineStateDestroyed {
+			continue
+		}
+		for _, check := range m.Checks {
+			if check == nil || check.Status != fly.Critical {
+				continue
+			}
+			// Checks without a timestamp are failing as of now.
+			at := time.Now()
+			if check.UpdatedAt != nil {
+				if check.UpdatedAt.Before(from) {
+					continue
+				}
+				at = *check.UpdatedAt
+			}
+			s := summary(m)
+			s.FailingChecks++
+			s.record(m.ID, at)
+		}
+	}
+
+	// Only the groups with crashes are reported, incomplete or not.
+	for _, m := range machines {
+		if s, ok := groups[key(m)]; ok && eventsTruncated(m, from) {
+			s.Incomplete = append(s.Incomplete, m.ID)
+		}
+	}
+
+	summaries := make([]*crashSummary, 0, len(groups))
+	for _, s := range groups {
+		sort.Strings(s.Machines)
+		sort.Strings(s.Incomplete)
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.total() != b.total() {
+			return a.total() > b.total()
+		}
+		if a.ProcessGroup != b.ProcessGroup {
+			return a.ProcessGroup < b.ProcessGroup
+		}
+		return a.Region < b.Region
+	})
+	return summaries
+}
+
+// eventsTruncated reports whether the kept events of m may not go back to
+// from.
+func eventsTruncated(m *fly.Machine, from time.Time) bool {
+	var oldest *fly.MachineEvent
+	for _, e := range m.Events {
+		if e != nil && (oldest == nil || e.Timestamp < oldest.Timestamp) {
+			oldest = e
+		}
+	}
+	return oldest != nil && oldest.Type != "launch" && oldest.Time().After(from)
+}
+
+// formatExitCodes formats exit codes and how many times each occurred, such
+// as 1 (x3), 137.
+func formatExitCodes(codes map[int]int) string {
+	keys := make([]int, 0, len(codes))
+	for code := range codes {
+		keys = append(keys, code)
+	}
+	sort.Ints(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, code := range keys {
+		if n := codes[code]; n > 1 {
+			parts = append(parts, fmt.Sprintf("%d (x%d)", code, n))
+		} else {
+			parts = append(parts, strconv.Itoa(code))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
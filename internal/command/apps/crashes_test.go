@@ -0,0 +1,88 @@
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestSummarizeCrashes(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := now.Add(-24 * time.Hour)
+
+	event := func(typ string, at time.Time, exit *fly.MachineExitEvent) *fly.MachineEvent {
+		e := &fly.MachineEvent{Type: typ, Timestamp: at.UnixMilli()}
+		if exit != nil {
+			e.Request = &fly.MachineRequest{ExitEvent: exit}
+		}
+		return e
+	}
+	machine := func(id, group, region string, events ...*fly.MachineEvent) *fly.Machine {
+		return &fly.Machine{
+			ID:     id,
+			Region: region,
+			State:  fly.MachineStateStarted,
+			Config: &fly.MachineConfig{Metadata: map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: group}},
+			Events: events,
+		}
+	}
+
+	failingAt := now.Add(-time.Hour)
+	web1 := machine("web1", "app", "ams",
+		event("launch", now.Add(-48*time.Hour), nil),
+		event("exit", now.Add(-30*time.Hour), &fly.MachineExitEvent{ExitCode: 1}),
+		event("exit", now.Add(-3*time.Hour), &fly.MachineExitEvent{ExitCode: 137, OOMKilled: true}),
+		event("exit", now.Add(-2*time.Hour), &fly.MachineExitEvent{ExitCode: 1}),
+		event("exit", now.Add(-90*time.Minute), &fly.MachineExitEvent{ExitCode: 0, RequestedStop: true}),
+	)
+	web1.Checks = []*fly.MachineCheckStatus{{Name: "http", Status: fly.Critical, UpdatedAt: &failingAt}}
+
+	// web2 has no launch event kept, and its oldest event is in the window.
+	web2 := machine("web2", "app", "ams",
+		event("start", now.Add(-5*time.Hour), nil),
+		event("exit", now.Add(-4*time.Hour), &fly.MachineExitEvent{ExitCode: 1}),
+	)
+
+	// worker1 was destroyed after crashing: its checks don't count anymore.
+	worker1 := machine("worker1", "worker", "fra",
+		event("launch", now.Add(-10*time.Hour), nil),
+		event("exit", now.Add(-6*time.Hour), &fly.MachineExitEvent{ExitCode: 2}),
+	)
+	worker1.State = fly.MachineStateDestroyed
+	worker1.Checks = []*fly.MachineCheckStatus{{Name: "tcp", Status: fly.Critical, UpdatedAt: &failingAt}}
+
+	// Truncated events don't make a group without crashes show up.
+	quiet := machine("quiet", "worker", "ord", event("start", now.Add(-time.Hour), nil))
+
+	summaries := summarizeCrashes([]*fly.Machine{worker1, web1, web2, quiet}, from)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, &crashSummary{
+		ProcessGroup:  "app",
+		Region:        "ams",
+		OOMKills:      1,
+		Crashes:       2,
+		ExitCodes:     map[int]int{1: 2},
+		FailingChecks: 1,
+		Machines:      []string{"web1", "web2"},
+		Incomplete:    []string{"web2"},
+		Last:          failingAt,
+	}, summaries[0])
+
+	assert.Equal(t, &crashSummary{
+		ProcessGroup: "worker",
+		Region:       "fra",
+		Crashes:      1,
+		ExitCodes:    map[int]int{2: 1},
+		Machines:     []string{"worker1"},
+		Last:         now.Add(-6 * time.Hour).Local(),
+	}, summaries[1])
+}
+
+func TestFormatExitCodes(t *testing.T) {
+	assert.Equal(t, "1 (x3), 137", formatExitCodes(map[int]int{137: 1, 1: 3}))
+	assert.Equal(t, "", formatExitCodes(nil))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 )
 
@@ -23,6 +24,9 @@ func newList() *cobra.Command {
 registered and available to this user. The list will include applications
 from all the organizations the user is a member of. Each application will
 be shown with its name, owner and when it was last deployed.
+
+The apps of several organizations are fetched concurrently, page by page,
+and shown as their pages arrive.
 `
 		short = "List applications"
 	)
@@ -31,8 +35,10 @@ be shown with its name, owner and when it was last deployed.
 		command.RequireSession,
 	)
 
-	flag.Add(cmd, flag.JSONOutput())
-	flag.Add(cmd, flag.Org())
+	flag.Add(cmd,
+		flag.JSONOutput(),
+		flag.Org(),
+	)
 
 	cmd.Aliases = []string{"ls"}
 	return cmd
@@ -46,26 +52,73 @@ func runList(ctx context.Context) (err error) {
 		return fmt.Errorf("error getting organization: %w", err)
 	}
 
-	var apps []fly.App
+	var (
+		out      = iostreams.FromContext(ctx).Out
+		verbose  = flag.GetBool(ctx, "verbose")
+		table    = render.NewTableStream(out, "Name", "Owner", "Status", "Latest Deploy")
+		streamed bool
+		shown    bool
+	)
+
+	onPage := func(apps []fly.App) {
+		if !cfg.JSONOutput {
+			table.Append(appRows(apps, verbose))
+			shown = shown || len(apps) > 0
+		}
+	}
+
+	key := "apps list"
 	if org != nil {
-		apps, err = client.GetAppsForOrganization(ctx, org.ID)
-	} else {
-		apps, err = client.GetApps(ctx, nil)
+		key += ":" + org.Slug
 	}
+	apps, err := readcache.Get(ctx, key, func(ctx context.Context) ([]fly.App, error) {
+		var orgIDs []string
+		if org != nil {
+			orgIDs = append(orgIDs, org.ID)
+		} else {
+			orgs, err := client.GetOrganizations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range orgs {
+				orgIDs = append(orgIDs, o.ID)
+			}
+		}
+
+		apps, err := fetchApps(ctx, client, orgIDs, onPage)
+		if err != nil && shown {
+			// The cached apps would repeat the rows already shown
+			return nil, readcache.Partial(err)
+		}
+		streamed = err == nil
+		return apps, err
+	})
 
 	if err != nil {
 		return
 	}
 
-	out := iostreams.FromContext(ctx).Out
 	if cfg.JSONOutput {
+		if apps == nil {
+			apps = []fly.App{}
+		}
 		_ = render.JSON(out, apps)
 
 		return
 	}
 
-	verbose := flag.GetBool(ctx, "verbose")
+	if !streamed {
+		// the result came from the cache, so nothing was shown yet
+		table.Append(appRows(apps, verbose))
+	}
+	if !table.Close() {
+		_ = render.Table(out, "", nil, "Name", "Owner", "Status", "Latest Deploy")
+	}
 
+	return
+}
+
+func appRows(apps []fly.App, verbose bool) [][]string {
 	rows := make([][]string, 0, len(apps))
 	for _, app := range apps {
 		latestDeploy := ""
@@ -84,10 +137,7 @@ func runList(ctx context.Context) (err error) {
 			latestDeploy,
 		})
 	}
-
-	_ = render.Table(out, "", rows, "Name", "Owner", "Status", "Latest Deploy")
-
-	return
+	return rows
 }
 
 func getOrg(ctx context.Context) (*fly.Organization, error) {
@@ -99,5 +149,7 @@ func getOrg(ctx context.Context) (*fly.Organization, error) {
 		return nil, nil
 	}
 
-	return client.GetOrganizationBySlug(ctx, orgName)
+	return readcache.Get(ctx, "org:"+orgName, func(ctx context.Context) (*fly.Organization, error) {
+		return client.GetOrganizationBySlug(ctx, orgName)
+	})
 }
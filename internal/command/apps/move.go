@@ -19,7 +19,9 @@ import (
 func newMove() *cobra.Command {
 	const (
 		long = `The APPS MOVE command will move an application to another
-organization the current user belongs to.
+organization the current user belongs to. A preflight report lists the
+machines, volumes, IP addresses, certificates and extensions of the app, and
+whether they transfer to the new organization.
 `
 		short = "Move an app to another organization"
 		usage = "move <APPNAME>"
@@ -34,6 +36,14 @@ organization the current user belongs to.
 	flag.Add(move,
 		flag.Yes(),
 		flag.Org(),
+		flag.String{
+			Name:        "to-org",
+			Description: "The organization to move the app to",
+		},
+		flag.Bool{
+			Name:        "dry-run",
+			Description: "Print the preflight report without moving the app",
+		},
 		flag.Bool{
 			Name:        "skip-health-checks",
 			Description: "Update machines without waiting for health checks",
@@ -62,7 +72,7 @@ func RunMove(ctx context.Context) error {
 	}
 
 	logger.Infof("app %q is currently in organization %q", app.Name, app.Organization.Slug)
-	org, err := prompt.Org(ctx)
+	org, err := targetOrg(ctx)
 	if err != nil {
 		return err
 	}
@@ -72,6 +82,28 @@ func RunMove(ctx context.Context) error {
 		return nil
 	}
 
+	ctx, err = BuildContext(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	machines, err := mach.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving machines: %w", err)
+	}
+
+	report := movePreflight(ctx, app, machines)
+	if err := report.render(io.Out, colorize); err != nil {
+		return err
+	}
+
+	switch {
+	case report.blocked():
+		return fmt.Errorf("app %s can't be moved to %s, see the preflight report", app.Name, org.Slug)
+	case flag.GetBool(ctx, "dry-run"):
+		return nil
+	}
+
 	if !flag.GetYes(ctx) {
 		const msg = `Moving an app between organizations requires a complete shutdown and restart. This will result in some app downtime.
 If the app relies on other services within the current organization, it may not come back up in a healthy manner.
@@ -93,6 +125,20 @@ Please confirm whether you wish to restart this app now.`
 	return runMoveAppOnMachines(ctx, app, org)
 }
 
+// targetOrg returns the organization given by --to-org, or prompts for it.
+func targetOrg(ctx context.Context) (*fly.Organization, error) {
+	slug := flag.GetString(ctx, "to-org")
+	if slug == "" {
+		return prompt.Org(ctx)
+	}
+
+	org, err := fly.ClientFromContext(ctx).GetOrganizationBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching organization %s: %w", slug, err)
+	}
+	return org, nil
+}
+
 func runMoveAppOnMachines(ctx context.Context, app *fly.AppCompact, targetOrg *fly.Organization) error {
 	var (
 		client           = fly.ClientFromContext(ctx)
@@ -100,11 +146,6 @@ func runMoveAppOnMachines(ctx context.Context, app *fly.AppCompact, targetOrg *f
 		skipHealthChecks = flag.GetBool(ctx, "skip-health-checks")
 	)
 
-	ctx, err := BuildContext(ctx, app)
-	if err != nil {
-		return err
-	}
-
 	machines, releaseLeaseFunc, err := mach.AcquireAllLeases(ctx)
 	defer releaseLeaseFunc()
 	if err != nil {
@@ -122,7 +163,9 @@ func runMoveAppOnMachines(ctx context.Context, app *fly.AppCompact, targetOrg *f
 			Config:           machine.Config,
 			SkipHealthChecks: skipHealthChecks,
 		}
-		mach.Update(ctx, machine, input)
+		if err := mach.Update(ctx, machine, input); err != nil {
+			return fmt.Errorf("app moved, but machine %s failed to restart: %w", machine.ID, err)
+		}
 	}
 	fmt.Fprintf(io.Out, "successfully moved %s to %s\n", app.Name, targetOrg.Name)
 
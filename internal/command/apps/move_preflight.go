@@ -0,0 +1,181 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// moveCheck is the outcome of checking how one resource of an app fares when
+// the app moves to another organization.
+type moveCheck struct {
+	Resource string
+	Name     string
+	Note     string
+	// Transfers reports whether the resource comes along with the app.
+	Transfers bool
+	// Blocking reports whether the resource prevents the move.
+	Blocking bool
+	// Unchecked reports whether the resource couldn't be retrieved.
+	Unchecked bool
+}
+
+type moveReport []moveCheck
+
+func (r moveReport) blocked() bool {
+	for _, c := range r {
+		if c.Blocking {
+			return true
+		}
+	}
+	return false
+}
+
+func (r moveReport) render(w io.Writer, colorize *iostreams.ColorScheme) error {
+	rows := make([][]string, 0, len(r))
+	for _, c := range r {
+		result := colorize.Green("transfers")
+		switch {
+		case c.Blocking:
+			result = colorize.Red("blocks the move")
+		case c.Unchecked:
+			result = colorize.Yellow("unchecked")
+		case !c.Transfers:
+			result = colorize.Yellow("stays behind")
+		}
+		rows = append(rows, []string{c.Resource, c.Name, result, c.Note})
+	}
+	return render.Table(w, "Preflight", rows, "Resource", "Name", "Result", "Note")
+}
+
+// moveResources are the resources of an app the preflight checks. A resource
+// that couldn't be retrieved has its error in errs, keyed by its kind.
+type moveResources struct {
+	machines []*fly.Machine
+	volumes  []fly.Volume
+	ips      []fly.IPAddress
+	certs    []fly.AppCertificateCompact
+	addOns   []gql.AddOnData
+	errs     map[string]error
+}
+
+// movePreflight checks the machines, volumes, IPs, certificates and extensions
+// of app for what won't make it to another organization. It is best effort:
+// resources that can't be retrieved are reported as unchecked.
+func movePreflight(ctx context.Context, app *fly.AppCompact, machines []*fly.Machine) moveReport {
+	var (
+		client      = fly.ClientFromContext(ctx)
+		flapsClient = flaps.FromContext(ctx)
+		res         = moveResources{machines: machines, errs: map[string]error{}}
+		err         error
+	)
+
+	if res.volumes, err = flapsClient.GetVolumes(ctx); err != nil {
+		res.errs["volume"] = err
+	}
+	if res.ips, err = client.GetIPAddresses(ctx, app.Name); err != nil {
+		res.errs["ip"] = err
+	}
+	if res.certs, err = client.GetAppCertificates(ctx, app.Name); err != nil {
+		res.errs["certificate"] = err
+	}
+	if res.addOns, err = appAddOns(ctx, app.Name); err != nil {
+		res.errs["extension"] = err
+	}
+
+	return buildMoveReport(app, res)
+}
+
+func buildMoveReport(app *fly.AppCompact, res moveResources) moveReport {
+	var report moveReport
+
+	for _, m := range res.machines {
+		check := moveCheck{Resource: "machine", Name: m.ID, Transfers: true, Note: "restarts in the new organization"}
+		if m.Config != nil && m.Config.Guest != nil && m.Config.Guest.HostDedicationID != "" {
+			check.Blocking = true
+			check.Note = fmt.Sprintf("runs on dedicated host %s of %s", m.Config.Guest.HostDedicationID, app.Organization.Slug)
+		}
+		report = append(report, check)
+	}
+
+	for _, v := range res.volumes {
+		check := moveCheck{Resource: "volume", Name: fmt.Sprintf("%s (%s)", v.ID, v.Name), Transfers: true, Note: "keeps its data and snapshots"}
+		if v.HostDedicationID != "" {
+			check.Blocking = true
+			check.Note = fmt.Sprintf("lives on dedicated host %s of %s", v.HostDedicationID, app.Organization.Slug)
+		}
+		report = append(report, check)
+	}
+
+	for _, ip := range res.ips {
+		check := moveCheck{Resource: "ip", Name: ip.Address, Transfers: true}
+		switch ip.Type {
+		case "private_v6":
+			check.Transfers = false
+			check.Note = "Flycast addresses belong to the network of the organization, allocate a new one with 'fly ips allocate-v6 --private'"
+		case "shared_v4":
+			check.Note = "shared address"
+		}
+		report = append(report, check)
+	}
+
+	for _, cert := range res.certs {
+		check := moveCheck{Resource: "certificate", Name: cert.Hostname, Transfers: true}
+		if cert.ClientStatus != "Ready" {
+			check.Note = fmt.Sprintf("%s, finish its setup with 'fly certs check %s'", cert.ClientStatus, cert.Hostname)
+		}
+		report = append(report, check)
+	}
+
+	for _, addOn := range res.addOns {
+		report = append(report, moveCheck{
+			Resource: "extension",
+			Name:     addOn.Name,
+			Note:     fmt.Sprintf("belongs to %s and stays billed there; private ones become unreachable", app.Organization.Slug),
+		})
+	}
+
+	for _, resource := range []string{"volume", "ip", "certificate", "extension"} {
+		if err := res.errs[resource]; err != nil {
+			report = append(report, moveCheck{
+				Resource:  resource,
+				Name:      "-",
+				Transfers: true,
+				Unchecked: true,
+				Note:      fmt.Sprintf("couldn't be checked: %v", err),
+			})
+		}
+	}
+
+	return report
+}
+
+// appAddOns returns the extensions of the app appName, of every type.
+func appAddOns(ctx context.Context, appName string) ([]gql.AddOnData, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	var (
+		addOns []gql.AddOnData
+		after  string
+	)
+	for {
+		resp, err := gql.GetAppAddOns(ctx, client, appName, after)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range resp.App.AddOns.Nodes {
+			addOns = append(addOns, node.AddOnData)
+		}
+		pageInfo := resp.App.AddOns.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			return addOns, nil
+		}
+		after = pageInfo.EndCursor
+	}
+}
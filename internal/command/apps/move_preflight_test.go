@@ -0,0 +1,88 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestBuildMoveReport(t *testing.T) {
+	app := &fly.AppCompact{Name: "app", Organization: &fly.OrganizationBasic{Slug: "personal"}}
+
+	report := buildMoveReport(app, moveResources{
+		machines: []*fly.Machine{
+			{ID: "m1", Config: &fly.MachineConfig{Guest: &fly.MachineGuest{GPUs: 1}}},
+			{ID: "m2", Config: &fly.MachineConfig{Guest: &fly.MachineGuest{HostDedicationID: "host"}}},
+		},
+		volumes: []fly.Volume{{ID: "vol_1", Name: "data"}},
+		ips: []fly.IPAddress{
+			{Address: "1.2.3.4", Type: "v4"},
+			{Address: "fdaa::1", Type: "private_v6"},
+		},
+		addOns: []gql.AddOnData{{Name: "bucket"}},
+		errs:   map[string]error{"certificate": errors.New("boom")},
+	})
+
+	byName := map[string]moveCheck{}
+	for _, c := range report {
+		byName[c.Resource+"/"+c.Name] = c
+	}
+
+	assert.False(t, byName["machine/m1"].Blocking, "GPUs don't block the move")
+	assert.True(t, byName["machine/m2"].Blocking, "dedicated hosts block the move")
+	assert.True(t, byName["volume/vol_1 (data)"].Transfers)
+	assert.True(t, byName["ip/1.2.3.4"].Transfers)
+	assert.False(t, byName["ip/fdaa::1"].Transfers)
+	assert.False(t, byName["extension/bucket"].Transfers)
+
+	unchecked := byName["certificate/-"]
+	assert.True(t, unchecked.Unchecked, "retrieval failures are reported")
+	assert.False(t, unchecked.Blocking, "retrieval failures don't block the move")
+	assert.Contains(t, unchecked.Note, "boom")
+
+	assert.True(t, report.blocked())
+}
+
+func TestAppAddOns(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req struct {
+			Variables struct {
+				Name  string `json:"name"`
+				After string `json:"after"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "app", req.Variables.Name)
+
+		if req.Variables.After == "" {
+			fmt.Fprint(w, `{"data":{"app":{"addOns":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[{"name":"redis"},{"name":"bucket"}]}}}}`)
+			return
+		}
+		assert.Equal(t, "c1", req.Variables.After)
+		fmt.Fprint(w, `{"data":{"app":{"addOns":{"pageInfo":{"hasNextPage":false,"endCursor":"c2"},"nodes":[{"name":"sentry"}]}}}}`)
+	}))
+	defer srv.Close()
+
+	ctx := fly.NewContextWithClient(context.Background(), fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL}))
+
+	addOns, err := appAddOns(ctx, "app")
+	require.NoError(t, err)
+
+	var names []string
+	for _, addOn := range addOns {
+		names = append(names, addOn.Name)
+	}
+	assert.Equal(t, []string{"redis", "bucket", "sentry"}, names)
+	assert.Equal(t, 2, calls, "every type of extension is fetched at once")
+}
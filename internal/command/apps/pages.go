@@ -0,0 +1,97 @@
+package apps
+
+import (
+	"context"
+	"sync"
+
+	fly "github.com/superfly/fly-go"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// appsPageSize is the number of apps fetched per request.
+	appsPageSize = 200
+
+	// maxConcurrentOrgs bounds how many organizations are paged through
+	// at the same time.
+	maxConcurrentOrgs = 4
+)
+
+const appsPageQuery = `
+	query($org: ID, $after: String, $first: Int) {
+		apps(first: $first, after: $after, organizationId: $org) {
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+			nodes {
+				id
+				name
+				deployed
+				hostname
+				platformVersion
+				organization {
+					slug
+					name
+				}
+				currentRelease {
+					createdAt
+					status
+				}
+				status
+			}
+		}
+	}
+`
+
+// fetchApps pages through the apps of the given organizations, several
+// organizations at a time, and returns all of them. onPage, when set, is
+// called with each page as soon as it arrives; calls are never concurrent.
+func fetchApps(ctx context.Context, client *fly.Client, orgIDs []string, onPage func([]fly.App)) ([]fly.App, error) {
+	var (
+		mu   sync.Mutex
+		apps []fly.App
+	)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(maxConcurrentOrgs)
+
+	for _, orgID := range orgIDs {
+		orgID := orgID
+
+		eg.Go(func() error {
+			var cursor string
+			for {
+				req := client.NewRequest(appsPageQuery)
+				req.Var("org", orgID)
+				req.Var("first", appsPageSize)
+				if cursor != "" {
+					req.Var("after", cursor)
+				}
+
+				data, err := client.RunWithContext(ctx, req)
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				apps = append(apps, data.Apps.Nodes...)
+				if onPage != nil {
+					onPage(data.Apps.Nodes)
+				}
+				mu.Unlock()
+
+				if !data.Apps.PageInfo.HasNextPage {
+					return nil
+				}
+				cursor = data.Apps.PageInfo.EndCursor
+			}
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return apps, nil
+}
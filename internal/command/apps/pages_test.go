@@ -0,0 +1,54 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFetchApps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Org   string `json:"org"`
+				After string `json:"after"`
+				First int    `json:"first"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, appsPageSize, req.Variables.First)
+
+		// org a has two pages of apps, org b one
+		page := 1
+		if req.Variables.After != "" {
+			page = 2
+		}
+		last := req.Variables.Org == "b" || page == 2
+
+		fmt.Fprintf(w, `{"data":{"apps":{"pageInfo":{"hasNextPage":%t,"endCursor":"c%d"},"nodes":[{"name":"%s-%d"}]}}}`,
+			!last, page, req.Variables.Org, page)
+	}))
+	defer srv.Close()
+
+	client := fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL})
+
+	var pages int
+	apps, err := fetchApps(context.Background(), client, []string{"a", "b"}, func([]fly.App) { pages++ })
+	require.NoError(t, err)
+
+	var names []string
+	for _, app := range apps {
+		names = append(names, app.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"a-1", "a-2", "b-1"}, names)
+	assert.Equal(t, 3, pages)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -43,6 +44,8 @@ including type, when, success/fail and which user triggered the release.
 		},
 	)
 
+	cmd.AddCommand(newReleasesConfig())
+
 	return
 }
 
@@ -53,7 +56,9 @@ func runReleases(ctx context.Context) error {
 		out     = iostreams.FromContext(ctx).Out
 	)
 
-	releases, err := client.GetAppReleasesMachines(ctx, appName, "", 25)
+	releases, err := readcache.Get(ctx, "releases:"+appName, func(ctx context.Context) ([]fly.Release, error) {
+		return client.GetAppReleasesMachines(ctx, appName, "", 25)
+	})
 	if err != nil {
 		return fmt.Errorf("failed retrieving app releases %s: %w", appName, err)
 	}
@@ -0,0 +1,200 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/releaseconfig"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newReleasesConfig() *cobra.Command {
+	const (
+		long = `Show the machine configs a release of the application ran with.
+
+The configs of each process group are rendered from the fly.toml and image
+the platform keeps with the release, so they're available wherever the
+release was deployed from. They don't include what was changed on the
+machines after the deploy, such as by fly scale: the configs of the machines
+still running the release are shown too.
+`
+		short = "Show the machine configs of a release"
+		usage = "config <version>"
+	)
+
+	cmd := command.New(usage, short, long, runReleasesConfig,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+func runReleasesConfig(ctx context.Context) error {
+	var (
+		appName = appconfig.NameFromContext(ctx)
+		io      = iostreams.FromContext(ctx)
+		client  = fly.ClientFromContext(ctx)
+	)
+
+	version, err := parseReleaseVersion(flag.FirstArg(ctx))
+	if err != nil {
+		return err
+	}
+
+	release, err := findRelease(ctx, client.GenqClient, appName, version)
+	if err != nil {
+		return err
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err != nil {
+		return err
+	}
+	machines, err := flapsClient.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed listing machines of %s: %w", appName, err)
+	}
+	release.Machines = releaseconfig.RunningMachines(version, machines)
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, release)
+	}
+
+	rows := make([][]string, 0, len(release.Groups))
+	for _, g := range release.Groups {
+		rows = append(rows, []string{g.ProcessGroup, g.Config.Image, g.Config.Guest.ToSize()})
+	}
+	title := fmt.Sprintf("Release v%d (%s)", release.Version, release.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	if err := render.Table(io.Out, title, rows, "Process Group", "Image", "Size"); err != nil {
+		return err
+	}
+
+	if len(release.Machines) == 0 {
+		fmt.Fprintf(io.Out, "No machine runs v%d anymore.\n", version)
+	} else {
+		rows = make([][]string, 0, len(release.Machines))
+		for _, m := range release.Machines {
+			rows = append(rows, []string{m.ID, m.ProcessGroup, m.Region, m.Config.Guest.ToSize()})
+		}
+		if err := render.Table(io.Out, "Machines still running it", rows, "ID", "Process Group", "Region", "Size"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(io.Out, "Run with --json to see the full machine configs.\n")
+	return nil
+}
+
+// releasesPageSize is how many releases are fetched at a time looking for
+// one.
+const releasesPageSize = 50
+
+// findRelease returns the release version of appName, with the machine
+// configs rendered from its fly.toml.
+func findRelease(ctx context.Context, client graphql.Client, appName string, version int) (*releaseconfig.Release, error) {
+	_ = `# @genqlient
+	query AppReleasesUnprocessed($appName: String!, $first: Int!, $after: String) {
+		app(name: $appName) {
+			releasesUnprocessed(first: $first, after: $after) {
+				nodes {
+					id
+					version
+					createdAt
+					imageRef
+					configDefinition
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}
+	`
+
+	var after string
+	for {
+		resp, err := gql.AppReleasesUnprocessed(ctx, client, appName, releasesPageSize, after)
+		if err != nil {
+			return nil, fmt.Errorf("failed retrieving releases of %s: %w", appName, err)
+		}
+
+		releases := resp.App.ReleasesUnprocessed
+		for _, r := range releases.Nodes {
+			switch {
+			case r.Version > version:
+				continue
+			case r.Version < version:
+				// Releases come from the latest.
+				return nil, fmt.Errorf("app %s has no release v%d", appName, version)
+			}
+			return renderRelease(appName, r)
+		}
+
+		if !releases.PageInfo.HasNextPage {
+			return nil, fmt.Errorf("app %s has no release v%d", appName, version)
+		}
+		after = releases.PageInfo.EndCursor
+	}
+}
+
+func renderRelease(appName string, r gql.AppReleasesUnprocessedAppReleasesUnprocessedReleaseUnprocessedConnectionNodesReleaseUnprocessed) (*releaseconfig.Release, error) {
+	definition, ok := r.ConfigDefinition.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("release v%d of %s has no stored fly.toml", r.Version, appName)
+	}
+
+	cfg, err := appconfig.FromDefinition(fly.DefinitionPtr(definition))
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing the fly.toml of release v%d: %w", r.Version, err)
+	}
+	if err := cfg.SetMachinesPlatform(); err != nil {
+		return nil, err
+	}
+	cfg.AppName = appName
+
+	groups, err := releaseconfig.Render(cfg, r.Version, r.ImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed rendering the machine configs of release v%d: %w", r.Version, err)
+	}
+
+	return &releaseconfig.Release{
+		App:       appName,
+		Version:   r.Version,
+		ReleaseID: r.Id,
+		CreatedAt: r.CreatedAt,
+		Image:     r.ImageRef,
+		Groups:    groups,
+	}, nil
+}
+
+func parseReleaseVersion(s string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "v"))
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid release version %q, use the number shown by fly releases such as v41", s)
+	}
+	return version, nil
+}
@@ -0,0 +1,69 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFindRelease(t *testing.T) {
+	const release = `{"id": "rel_41", "version": 41, "createdAt": "2024-06-01T10:00:00Z", "imageRef": "registry.fly.io/app:deployment-41",
+		"configDefinition": {"app": "app", "processes": {"web": "bin/web"}}}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				AppName string `json:"appName"`
+				After   string `json:"after"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "app", req.Variables.AppName)
+
+		if req.Variables.After == "" {
+			fmt.Fprint(w, `{"data":{"app":{"releasesUnprocessed":{"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[{"version":43},{"version":42}]}}}}`)
+			return
+		}
+		assert.Equal(t, "c1", req.Variables.After)
+		fmt.Fprintf(w, `{"data":{"app":{"releasesUnprocessed":{"pageInfo":{"hasNextPage":false,"endCursor":"c2"},"nodes":[%s,{"version":40}]}}}}`, release)
+	}))
+	defer srv.Close()
+
+	client := fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL}).GenqClient
+
+	found, err := findRelease(context.Background(), client, "app", 41)
+	require.NoError(t, err)
+	assert.Equal(t, 41, found.Version)
+	assert.Equal(t, "rel_41", found.ReleaseID)
+	assert.Equal(t, "registry.fly.io/app:deployment-41", found.Image)
+	require.Len(t, found.Groups, 1)
+	assert.Equal(t, "web", found.Groups[0].ProcessGroup)
+	assert.Equal(t, "registry.fly.io/app:deployment-41", found.Groups[0].Config.Image)
+
+	_, err = findRelease(context.Background(), client, "app", 39)
+	assert.EqualError(t, err, "app app has no release v39")
+
+	// v42 has no stored fly.toml.
+	_, err = findRelease(context.Background(), client, "app", 42)
+	assert.EqualError(t, err, "release v42 of app has no stored fly.toml")
+}
+
+func TestParseReleaseVersion(t *testing.T) {
+	for s, want := range map[string]int{"41": 41, "v41": 41, "V7": 7} {
+		version, err := parseReleaseVersion(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, version, s)
+	}
+
+	for _, s := range []string{"", "v", "0", "-1", "latest"} {
+		_, err := parseReleaseVersion(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
@@ -18,7 +19,11 @@ import (
 
 func newRestart() *cobra.Command {
 	const (
-		long  = `The APPS RESTART command will perform a rolling restart against all running VMs`
+		long = `The APPS RESTART command will perform a rolling restart against all running VMs.
+
+Machines restart --max-unavailable at a time, and each batch has to pass its
+health checks before the next one restarts, so the app keeps its capacity.
+Use --process-group to only restart the machines of a process group.`
 		short = "Restart an application"
 		usage = "restart [APPNAME]"
 	)
@@ -41,6 +46,12 @@ func newRestart() *cobra.Command {
 			Description: "Restarts app without waiting for health checks",
 			Default:     false,
 		},
+		flag.ProcessGroup("Only restart the machines of this process group"),
+		flag.Int{
+			Name:        "max-unavailable",
+			Description: "Number of machines restarted at a time",
+			Default:     1,
+		},
 	)
 
 	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteApps)
@@ -97,17 +108,20 @@ func runMachinesRestart(ctx context.Context, app *fly.AppCompact) error {
 		return err
 	}
 
+	if group := flag.GetProcessGroup(ctx); group != "" {
+		machines = lo.Filter(machines, func(m *fly.Machine, _ int) bool {
+			return m.ProcessGroup() == group
+		})
+		if len(machines) == 0 {
+			return fmt.Errorf("no machines in process group %s", group)
+		}
+	}
+
 	machines, releaseFunc, err := machine.AcquireLeases(ctx, machines)
 	defer releaseFunc()
 	if err != nil {
 		return err
 	}
 
-	for _, m := range machines {
-		if err := machine.Restart(ctx, m, input, m.LeaseNonce); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return machine.RestartBatches(ctx, machines, input, flag.GetInt(ctx, "max-unavailable"))
 }
@@ -0,0 +1,16 @@
+// Package build implements the build command.
+package build
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command/image"
+)
+
+// New returns fly build, an alias of fly image push.
+func New() *cobra.Command {
+	cmd := image.NewPush()
+	cmd.Use = "build"
+	cmd.Short += ", alias of fly image push"
+	return cmd
+}
@@ -0,0 +1,156 @@
+// Package builders implements the builders command chain.
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() *cobra.Command {
+	const (
+		long = `Commands for managing the remote builders of organizations. Each
+organization builds images on its own remote builder app, which keeps the
+build cache between deploys.
+`
+		short = "Manage remote builders"
+	)
+
+	cmd := command.New("builders", short, long, nil)
+
+	cmd.AddCommand(
+		newList(),
+		newStatus(),
+		newDestroy(),
+		newWarm(),
+		newUse(),
+		newCache(),
+	)
+
+	return cmd
+}
+
+// builder is the remote builder app of an organization.
+type builder struct {
+	Org      *fly.Organization
+	App      string
+	Image    string
+	Machines []*fly.Machine
+}
+
+// orgBuilder returns the remote builder of the organization of the app, when
+// there's one and no --org, otherwise of --org or the one selected, with an
+// empty App when the organization has none.
+func orgBuilder(ctx context.Context) (*builder, error) {
+	if appName := appconfig.NameFromContext(ctx); appName != "" && flag.GetOrg(ctx) == "" {
+		app, err := fly.ClientFromContext(ctx).GetAppCompact(ctx, appName)
+		if err != nil {
+			return nil, fmt.Errorf("failed retrieving app %s: %w", appName, err)
+		}
+		return builderOf(ctx, &fly.Organization{ID: app.Organization.ID, Slug: app.Organization.Slug})
+	}
+
+	org, err := prompt.Org(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return builderOf(ctx, org)
+}
+
+func builderOf(ctx context.Context, org *fly.Organization) (*builder, error) {
+	client := fly.ClientFromContext(ctx)
+
+	details, err := client.GetDetailedOrganizationBySlug(ctx, org.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving organization %s: %w", org.Slug, err)
+	}
+
+	b := &builder{
+		Org:   org,
+		Image: details.RemoteBuilderImage,
+	}
+	if details.RemoteBuilderApp != nil {
+		b.App = details.RemoteBuilderApp.Name
+	}
+	if b.App == "" {
+		return b, nil
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: b.App})
+	if err != nil {
+		return nil, err
+	}
+	if b.Machines, err = flapsClient.List(ctx, ""); err != nil {
+		return nil, fmt.Errorf("failed listing machines of builder %s: %w", b.App, err)
+	}
+	return b, nil
+}
+
+// dockerClient connects to the builder, starting it if it's stopped.
+func (b *builder) dockerClient(ctx context.Context) (*dockerclient.Client, error) {
+	if b.App == "" {
+		return nil, fmt.Errorf("organization %s has no remote builder", b.Org.Slug)
+	}
+
+	io := iostreams.FromContext(ctx)
+	docker, err := imgsrc.NewRemoteBuilderClient(ctx, fly.ClientFromContext(ctx), b.App, io, flag.GetWireguard(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed connecting to builder %s: %w", b.App, err)
+	}
+	return docker, nil
+}
+
+// state returns the state of the builder, from the state of its machine.
+func (b *builder) state() string {
+	switch {
+	case b.App == "":
+		return "none"
+	case len(b.Machines) == 0:
+		return "no machine"
+	default:
+		return b.Machines[0].State
+	}
+}
+
+func (b *builder) region() string {
+	if len(b.Machines) == 0 {
+		return ""
+	}
+	return b.Machines[0].Region
+}
+
+func (b *builder) started() bool {
+	for _, m := range b.Machines {
+		if m.State == fly.MachineStateStarted {
+			return true
+		}
+	}
+	return false
+}
+
+// describePin describes the builder builds are pinned to.
+func describePin(pin config.RemoteBuilder) string {
+	switch {
+	case pin.App == "" && pin.Size == "":
+		return ""
+	case pin.App == "":
+		return "the provisioned builder (" + pin.Size + ")"
+	case pin.Size == "":
+		return pin.App
+	default:
+		return pin.App + " (" + pin.Size + ")"
+	}
+}
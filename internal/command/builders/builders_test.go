@@ -0,0 +1,53 @@
+package builders
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/image"
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func TestSummarizeDiskUsage(t *testing.T) {
+	du := types.DiskUsage{
+		Images: []*image.Summary{{Size: 100}, {Size: 50}},
+		BuildCache: []*types.BuildCache{
+			{Size: 10, InUse: true},
+			{Size: 20},
+			{Size: 40, Shared: true},
+		},
+	}
+	assert.Equal(t, diskUsage{
+		ImagesBytes:       150,
+		BuildCacheBytes:   30,
+		BuildCacheRecords: 2,
+		ReclaimableBytes:  20,
+	}, summarizeDiskUsage(du))
+}
+
+func TestBuilderState(t *testing.T) {
+	assert.Equal(t, "none", (&builder{}).state())
+	assert.Equal(t, "no machine", (&builder{App: "fly-builder-x"}).state())
+
+	b := &builder{App: "fly-builder-x", Machines: []*fly.Machine{{State: "stopped", Region: "iad"}}}
+	assert.Equal(t, "stopped", b.state())
+	assert.Equal(t, "iad", b.region())
+	assert.False(t, b.started())
+
+	b.Machines[0].State = fly.MachineStateStarted
+	assert.True(t, b.started())
+}
+
+func TestDescribePin(t *testing.T) {
+	assert.Equal(t, "", describePin(config.RemoteBuilder{}))
+	assert.Equal(t, "fly-builder-x", describePin(config.RemoteBuilder{App: "fly-builder-x"}))
+	assert.Equal(t, "fly-builder-x (performance-4x)", describePin(config.RemoteBuilder{App: "fly-builder-x", Size: "performance-4x"}))
+	assert.Equal(t, "the provisioned builder (performance-4x)", describePin(config.RemoteBuilder{Size: "performance-4x"}))
+}
+
+func TestSizeWarning(t *testing.T) {
+	assert.Contains(t, sizeWarning(config.RemoteBuilder{Size: "performance-4x"}), "every build of the organization, including those of others")
+	assert.Contains(t, sizeWarning(config.RemoteBuilder{App: "fly-builder-x", Size: "performance-4x"}), "builder fly-builder-x to performance-4x")
+}
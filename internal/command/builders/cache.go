@@ -0,0 +1,230 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newCache() *cobra.Command {
+	const (
+		long = `Commands for inspecting and pruning the BuildKit cache of the remote
+builder of an organization, the one of the app when run in an app's
+directory or given --app.
+`
+		short = "Manage the build cache of the remote builder"
+	)
+
+	cmd := command.New("cache", short, long, nil)
+
+	commonFlags := flag.Set{
+		flag.App(),
+		flag.AppConfig(),
+		flag.Org(),
+		flag.Wireguard(),
+	}
+
+	show := command.New("show", "Show the build cache usage of the remote builder",
+		`Show the build cache of the remote builder, by type of cache record, with
+how much of it isn't in use and could be pruned. Starts the builder when it's
+stopped.`,
+		runCacheShow, command.RequireSession, command.LoadAppNameIfPresent)
+	flag.Add(show, commonFlags, flag.JSONOutput())
+
+	prune := command.New("prune", "Prune the build cache of the remote builder",
+		`Remove the build cache records of the remote builder that aren't in use,
+or only those unused for --older-than, such as 7d or 12h. --keep-storage
+keeps the most recently used records up to a size, such as 10GB.`,
+		runCachePrune, command.RequireSession, command.LoadAppNameIfPresent)
+	flag.Add(prune, commonFlags,
+		flag.String{
+			Name:        "older-than",
+			Description: "Only prune records unused for this long, such as 7d, 36h or 90m",
+		},
+		flag.String{
+			Name:        "keep-storage",
+			Description: "Size of the build cache to keep, such as 10GB",
+		},
+	)
+
+	cmd.AddCommand(show, prune)
+	return cmd
+}
+
+// cacheUsage is the usage of the build cache records of a type.
+type cacheUsage struct {
+	Type             string     `json:"type"`
+	Records          int        `json:"records"`
+	Bytes            int64      `json:"bytes"`
+	ReclaimableBytes int64      `json:"reclaimable_bytes"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty"`
+}
+
+// summarizeCache sums up the records by type, largest first.
+func summarizeCache(records []*types.BuildCache) []cacheUsage {
+	byType := map[string]*cacheUsage{}
+	for _, r := range records {
+		// Shared records are also counted in the images.
+		if r.Shared {
+			continue
+		}
+		u, ok := byType[r.Type]
+		if !ok {
+			u = &cacheUsage{Type: r.Type}
+			byType[r.Type] = u
+		}
+		u.Records++
+		u.Bytes += r.Size
+		if !r.InUse {
+			u.ReclaimableBytes += r.Size
+		}
+		if r.LastUsedAt != nil && (u.LastUsedAt == nil || r.LastUsedAt.After(*u.LastUsedAt)) {
+			u.LastUsedAt = r.LastUsedAt
+		}
+	}
+
+	usages := make([]cacheUsage, 0, len(byType))
+	for _, u := range byType {
+		usages = append(usages, *u)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Bytes != usages[j].Bytes {
+			return usages[i].Bytes > usages[j].Bytes
+		}
+		return usages[i].Type < usages[j].Type
+	})
+	return usages
+}
+
+func runCacheShow(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	b, err := orgBuilder(ctx)
+	if err != nil {
+		return err
+	}
+	docker, err := b.dockerClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer docker.Close() // skipcq: GO-S2307
+	builder := b.App
+
+	du, err := docker.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.BuildCacheObject},
+	})
+	if err != nil {
+		return fmt.Errorf("failed retrieving build cache of builder %s: %w", builder, err)
+	}
+
+	usages := summarizeCache(du.BuildCache)
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, usages)
+	}
+
+	var total cacheUsage
+	rows := make([][]string, 0, len(usages)+1)
+	for _, u := range usages {
+		lastUsed := ""
+		if u.LastUsedAt != nil {
+			lastUsed = humanize.Time(*u.LastUsedAt)
+		}
+		rows = append(rows, []string{u.Type, strconv.Itoa(u.Records), humanize.Bytes(uint64(u.Bytes)), humanize.Bytes(uint64(u.ReclaimableBytes)), lastUsed})
+		total.Records += u.Records
+		total.Bytes += u.Bytes
+		total.ReclaimableBytes += u.ReclaimableBytes
+	}
+	rows = append(rows, []string{"Total", strconv.Itoa(total.Records), humanize.Bytes(uint64(total.Bytes)), humanize.Bytes(uint64(total.ReclaimableBytes)), ""})
+
+	title := fmt.Sprintf("Build cache of %s", builder)
+	return render.Table(io.Out, title, rows, "Type", "Records", "Size", "Reclaimable", "Last Used")
+}
+
+func runCachePrune(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	opts, err := pruneOptions(flag.GetString(ctx, "older-than"), flag.GetString(ctx, "keep-storage"))
+	if err != nil {
+		return err
+	}
+
+	b, err := orgBuilder(ctx)
+	if err != nil {
+		return err
+	}
+	docker, err := b.dockerClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer docker.Close() // skipcq: GO-S2307
+	builder := b.App
+
+	report, err := docker.BuildCachePrune(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed pruning build cache of builder %s: %w", builder, err)
+	}
+
+	fmt.Fprintf(io.Out, "Pruned %d build cache records of %s, reclaiming %s\n", len(report.CachesDeleted), builder, humanize.Bytes(report.SpaceReclaimed))
+	return nil
+}
+
+// pruneOptions returns the options pruning the records unused for olderThan,
+// keeping keepStorage of the cache. Both are optional.
+func pruneOptions(olderThan, keepStorage string) (types.BuildCachePruneOptions, error) {
+	opts := types.BuildCachePruneOptions{
+		// Not only the dangling records, which rarely add up to much.
+		All:     true,
+		Filters: filters.NewArgs(),
+	}
+
+	if olderThan != "" {
+		age, err := parseAge(olderThan)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters.Add("until", age.String())
+	}
+
+	if keepStorage != "" {
+		size, err := humanize.ParseBytes(keepStorage)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --keep-storage %q: %w", keepStorage, err)
+		}
+		opts.KeepStorage = int64(size)
+	}
+	return opts, nil
+}
+
+// parseAge parses a duration which may also be given in days or weeks, such
+// as 7d or 2w.
+func parseAge(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected such as 7d, 36h or 90m", s)
+	}
+	return d, nil
+}
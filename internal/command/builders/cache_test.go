@@ -0,0 +1,59 @@
+package builders
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAge(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"7d":  7 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+		"90m": 90 * time.Minute,
+	} {
+		got, err := parseAge(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	for _, s := range []string{"", "d", "-1d", "0h", "soon"} {
+		_, err := parseAge(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestPruneOptions(t *testing.T) {
+	opts, err := pruneOptions("", "")
+	require.NoError(t, err)
+	assert.True(t, opts.All)
+	assert.Equal(t, 0, opts.Filters.Len())
+
+	opts, err = pruneOptions("7d", "10GB")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"168h0m0s"}, opts.Filters.Get("until"))
+	assert.Equal(t, int64(10_000_000_000), opts.KeepStorage)
+
+	_, err = pruneOptions("", "lots")
+	assert.Error(t, err)
+}
+
+func TestSummarizeCache(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	usages := summarizeCache([]*types.BuildCache{
+		{Type: "regular", Size: 100, LastUsedAt: &older},
+		{Type: "regular", Size: 50, InUse: true, LastUsedAt: &newer},
+		{Type: "exec.cachemount", Size: 500},
+		{Type: "regular", Size: 1000, Shared: true},
+	})
+	assert.Equal(t, []cacheUsage{
+		{Type: "exec.cachemount", Records: 1, Bytes: 500, ReclaimableBytes: 500},
+		{Type: "regular", Records: 2, Bytes: 150, ReclaimableBytes: 100, LastUsedAt: &newer},
+	}, usages)
+}
@@ -0,0 +1,73 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newDestroy() *cobra.Command {
+	const (
+		long = `Destroy the remote builder app of an organization, wiping its build
+cache. A new builder, with an empty cache, is created on the next remote build.
+`
+		short = "Destroy the remote builder of an organization"
+	)
+
+	cmd := command.New("destroy", short, long, runDestroy,
+		command.RequireSession,
+	)
+	cmd.Aliases = []string{"rm"}
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.Yes(),
+	)
+	return cmd
+}
+
+func runDestroy(ctx context.Context) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+		client   = fly.ClientFromContext(ctx)
+	)
+
+	b, err := orgBuilder(ctx)
+	if err != nil {
+		return err
+	}
+	if b.App == "" {
+		fmt.Fprintf(io.Out, "Organization %s has no remote builder\n", b.Org.Slug)
+		return nil
+	}
+
+	if !flag.GetYes(ctx) {
+		fmt.Fprintln(io.ErrOut, colorize.Yellow("Destroying the builder wipes its build cache, so the next builds start from scratch."))
+
+		switch confirmed, err := prompt.Confirmf(ctx, "Destroy builder %s of %s?", b.App, b.Org.Slug); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	if err := client.DeleteApp(ctx, b.App); err != nil {
+		return fmt.Errorf("failed destroying builder %s: %w", b.App, err)
+	}
+
+	fmt.Fprintf(io.Out, "Destroyed builder %s\n", b.App)
+	return nil
+}
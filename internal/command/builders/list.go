@@ -0,0 +1,98 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newList() *cobra.Command {
+	const (
+		long = `List the remote builder app of each of your organizations, or of the
+organization of --org, with the state and region of its machine, and the
+builder builds are pinned to with "fly builders use".
+`
+		short = "List the remote builders of your organizations"
+	)
+
+	cmd := command.New("list", short, long, runList,
+		command.RequireSession,
+	)
+	cmd.Aliases = []string{"ls"}
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+type builderRow struct {
+	Org    string `json:"org"`
+	App    string `json:"app"`
+	State  string `json:"state"`
+	Region string `json:"region"`
+	Image  string `json:"image"`
+	Pinned string `json:"pinned,omitempty"`
+}
+
+func runList(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+	)
+
+	var orgs []fly.Organization
+	if slug := flag.GetOrg(ctx); slug != "" {
+		org, err := client.GetOrganizationBySlug(ctx, slug)
+		if err != nil {
+			return fmt.Errorf("failed retrieving organization %s: %w", slug, err)
+		}
+		orgs = append(orgs, *org)
+	} else {
+		var err error
+		if orgs, err = client.GetOrganizations(ctx); err != nil {
+			return fmt.Errorf("failed retrieving organizations: %w", err)
+		}
+	}
+
+	pins, err := config.ReadRemoteBuilders(state.ConfigFile(ctx))
+	if err != nil {
+		return err
+	}
+
+	rows := make([]builderRow, 0, len(orgs))
+	for i := range orgs {
+		b, err := builderOf(ctx, &orgs[i])
+		if err != nil {
+			return err
+		}
+		rows = append(rows, builderRow{
+			Org:    b.Org.Slug,
+			App:    b.App,
+			State:  b.state(),
+			Region: b.region(),
+			Image:  b.Image,
+			Pinned: describePin(pins[b.Org.Slug]),
+		})
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, rows)
+	}
+
+	table := make([][]string, 0, len(rows))
+	for _, r := range rows {
+		table = append(table, []string{r.Org, r.App, r.State, r.Region, r.Image, r.Pinned})
+	}
+	return render.Table(io.Out, "", table, "Org", "Builder", "State", "Region", "Image", "Pinned To")
+}
@@ -0,0 +1,117 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newStatus() *cobra.Command {
+	const (
+		long = `Show the remote builder of an organization: its app, image, machine
+and, when it's running, the disk used by images and the build cache. Use
+'fly builders warm' to start it first.
+`
+		short = "Show the remote builder of an organization"
+	)
+
+	cmd := command.New("status", short, long, runStatus,
+		command.RequireSession,
+	)
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.Wireguard(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+// diskUsage is the disk used on a remote builder.
+type diskUsage struct {
+	ImagesBytes       int64 `json:"images_bytes"`
+	BuildCacheBytes   int64 `json:"build_cache_bytes"`
+	BuildCacheRecords int   `json:"build_cache_records"`
+	// ReclaimableBytes is the size of the build cache not in use.
+	ReclaimableBytes int64 `json:"reclaimable_bytes"`
+}
+
+func summarizeDiskUsage(du types.DiskUsage) diskUsage {
+	var s diskUsage
+	for _, img := range du.Images {
+		s.ImagesBytes += img.Size
+	}
+	for _, u := range summarizeCache(du.BuildCache) {
+		s.BuildCacheRecords += u.Records
+		s.BuildCacheBytes += u.Bytes
+		s.ReclaimableBytes += u.ReclaimableBytes
+	}
+	return s
+}
+
+func runStatus(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	b, err := orgBuilder(ctx)
+	if err != nil {
+		return err
+	}
+	if b.App == "" {
+		fmt.Fprintf(io.Out, "Organization %s has no remote builder yet, one is created on its first remote build\n", b.Org.Slug)
+		return nil
+	}
+
+	var usage *diskUsage
+	if b.started() {
+		docker, err := b.dockerClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer docker.Close() // skipcq: GO-S2307
+
+		du, err := docker.DiskUsage(ctx, types.DiskUsageOptions{
+			Types: []types.DiskUsageObject{types.ImageObject, types.BuildCacheObject},
+		})
+		if err != nil {
+			return fmt.Errorf("failed retrieving disk usage of builder %s: %w", b.App, err)
+		}
+		s := summarizeDiskUsage(du)
+		usage = &s
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, struct {
+			Org    string     `json:"org"`
+			App    string     `json:"app"`
+			Image  string     `json:"image"`
+			State  string     `json:"state"`
+			Region string     `json:"region"`
+			Disk   *diskUsage `json:"disk,omitempty"`
+		}{b.Org.Slug, b.App, b.Image, b.state(), b.region(), usage})
+	}
+
+	obj := [][]string{{b.Org.Slug, b.App, b.Image, b.state(), b.region()}}
+	if err := render.VerticalTable(io.Out, "Builder", obj, "Org", "App", "Image", "State", "Region"); err != nil {
+		return err
+	}
+
+	if usage == nil {
+		fmt.Fprintln(io.Out, "The builder isn't running, start it with 'fly builders warm' to see its disk usage")
+		return nil
+	}
+	obj = [][]string{{
+		humanize.Bytes(uint64(usage.ImagesBytes)),
+		fmt.Sprintf("%s in %d records", humanize.Bytes(uint64(usage.BuildCacheBytes)), usage.BuildCacheRecords),
+		humanize.Bytes(uint64(usage.ReclaimableBytes)),
+	}}
+	return render.VerticalTable(io.Out, "Disk Usage", obj, "Images", "Build Cache", "Reclaimable")
+}
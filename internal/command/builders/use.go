@@ -0,0 +1,128 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newUse() *cobra.Command {
+	const (
+		long = `Pin the remote builds of the apps of an organization to the builder app
+BUILDER, such as one running in a region close to you, instead of the one
+flyctl provisions. BUILDER must be a remote builder app of the organization.
+With --size, the builder is resized to that machine size before builds, so
+heavy builds stop running out of memory. The builder keeps that size: without
+BUILDER, that's the builder every build of the organization shares. The
+--builder-app and --builder-size flags of deploy override the pin.
+
+With --unset, builds go back to the builder flyctl provisions.
+`
+		short = "Pin remote builds of an organization to a builder"
+		usage = "use [BUILDER]"
+	)
+
+	cmd := command.New(usage, short, long, runUse,
+		command.RequireSession,
+	)
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.String{
+			Name:        "size",
+			Description: "Machine size of the builder, such as performance-4x",
+		},
+		flag.Bool{
+			Name:        "unset",
+			Description: "Unpin the builds of the organization",
+		},
+		flag.Yes(),
+	)
+	return cmd
+}
+
+func runUse(ctx context.Context) error {
+	var (
+		io         = iostreams.FromContext(ctx)
+		client     = fly.ClientFromContext(ctx)
+		configPath = state.ConfigFile(ctx)
+		pin        = config.RemoteBuilder{
+			App:  flag.FirstArg(ctx),
+			Size: flag.GetString(ctx, "size"),
+		}
+	)
+
+	org, err := prompt.Org(ctx)
+	if err != nil {
+		return err
+	}
+
+	if flag.GetBool(ctx, "unset") {
+		if pin != (config.RemoteBuilder{}) {
+			return fmt.Errorf("--unset can't be combined with a builder or --size")
+		}
+		if err := config.SetRemoteBuilder(configPath, org.Slug, pin); err != nil {
+			return fmt.Errorf("failed unpinning builder of %s: %w", org.Slug, err)
+		}
+		fmt.Fprintf(io.Out, "Builds of %s run on the builder flyctl provisions\n", org.Slug)
+		return nil
+	}
+
+	if pin == (config.RemoteBuilder{}) {
+		return fmt.Errorf("specify a builder app, --size or --unset")
+	}
+
+	if pin.Size != "" {
+		if err := imgsrc.ValidateRemoteBuilderSize(pin.Size); err != nil {
+			return err
+		}
+	}
+
+	if pin.App != "" {
+		if err := imgsrc.VerifyBuilderApp(ctx, client, pin.App, org.Slug); err != nil {
+			return err
+		}
+	}
+
+	if pin.Size != "" {
+		fmt.Fprintln(io.ErrOut, sizeWarning(pin))
+		if pin.App == "" && !flag.GetYes(ctx) {
+			switch confirmed, err := prompt.Confirm(ctx, "Resize the shared builder of the organization on your builds?"); {
+			case err == nil:
+				if !confirmed {
+					return nil
+				}
+			case prompt.IsNonInteractive(err):
+				return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+			default:
+				return err
+			}
+		}
+	}
+
+	if err := config.SetRemoteBuilder(configPath, org.Slug, pin); err != nil {
+		return fmt.Errorf("failed pinning builder of %s: %w", org.Slug, err)
+	}
+
+	fmt.Fprintf(io.Out, "Builds of %s now run on %s\n", org.Slug, describePin(pin))
+	return nil
+}
+
+// sizeWarning warns that the builder of pin keeps its size.
+func sizeWarning(pin config.RemoteBuilder) string {
+	if pin.App == "" {
+		return fmt.Sprintf("Warning: builds resize the builder of the organization to %s, and it keeps that size for every build of the organization, including those of others", pin.Size)
+	}
+	return fmt.Sprintf("Warning: builds resize builder %s to %s, and it keeps that size for every build which runs on it", pin.App, pin.Size)
+}
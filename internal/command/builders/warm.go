@@ -0,0 +1,61 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newWarm() *cobra.Command {
+	const (
+		long = `Start the remote builder of an organization, creating it when the
+organization has none, and wait for its Docker daemon to be ready, so that
+the next deploys don't wait for it.
+`
+		short = "Start the remote builder of an organization ahead of builds"
+	)
+
+	cmd := command.New("warm", short, long, runWarm,
+		command.RequireSession,
+	)
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.Wireguard(),
+	)
+	return cmd
+}
+
+func runWarm(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+	)
+
+	org, err := prompt.Org(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, app, err := imgsrc.EnsureOrgRemoteBuilder(ctx, client, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed ensuring remote builder of %s: %w", org.Slug, err)
+	}
+
+	docker, err := imgsrc.NewRemoteBuilderClient(ctx, client, app.Name, io, flag.GetWireguard(ctx))
+	if err != nil {
+		return fmt.Errorf("failed starting builder %s: %w", app.Name, err)
+	}
+	defer docker.Close() // skipcq: GO-S2307
+
+	fmt.Fprintf(io.Out, "Builder %s of %s is ready\n", app.Name, org.Slug)
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 
@@ -139,7 +140,9 @@ func runCertificatesList(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
 	apiClient := fly.ClientFromContext(ctx)
 
-	certs, err := apiClient.GetAppCertificates(ctx, appName)
+	certs, err := readcache.Get(ctx, "certs list:"+appName, func(ctx context.Context) ([]fly.AppCertificateCompact, error) {
+		return apiClient.GetAppCertificates(ctx, appName)
+	})
 	if err != nil {
 		return err
 	}
@@ -13,10 +13,15 @@ func New() *cobra.Command {
 	flag.Add(cmd, commonFlags)
 
 	// fly checks list
-	listCmd := command.New("list", "List health checks", "", runAppCheckList, command.RequireSession, command.RequireAppName)
+	listCmd := command.New("list", "List health checks",
+		`List the health checks of the machines of an app, or with --org of every
+app of an organization. --failing leaves out the checks that pass.`,
+		runAppCheckList, command.RequireSession, command.LoadAppNameIfPresent)
 	listCmd.Aliases = []string{"ls"}
 	flag.Add(listCmd, commonFlags,
+		flag.Org(),
 		flag.String{Name: "check-name", Description: "Filter checks by name"},
+		flag.Bool{Name: "failing", Description: "Only list the checks which aren't passing"},
 	)
 	flag.Add(listCmd, flag.JSONOutput())
 	cmd.AddCommand(listCmd)
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
@@ -17,10 +20,21 @@ import (
 	"github.com/superfly/flyctl/iostreams"
 )
 
+// listConcurrency is how many apps have their checks listed at once.
+const listConcurrency = 8
+
 func runAppCheckList(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
 	out := iostreams.FromContext(ctx).Out
 	nameFilter := flag.GetString(ctx, "check-name")
+	failing := flag.GetBool(ctx, "failing")
+
+	if org := flag.GetOrg(ctx); org != "" {
+		return runOrgCheckList(ctx, org)
+	}
+	if appName == "" {
+		return command.ErrRequireAppName
+	}
 
 	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 		AppName: appName,
@@ -40,9 +54,12 @@ func runAppCheckList(ctx context.Context) error {
 	if config.FromContext(ctx).JSONOutput {
 		checks := map[string][]fly.MachineCheckStatus{}
 		for _, machine := range machines {
-			checks[machine.ID] = make([]fly.MachineCheckStatus, len(machine.Checks))
-			for i, check := range machine.Checks {
-				checks[machine.ID][i] = *check
+			checks[machine.ID] = make([]fly.MachineCheckStatus, 0, len(machine.Checks))
+			for _, check := range machine.Checks {
+				if failing && check.Status == fly.Passing {
+					continue
+				}
+				checks[machine.ID] = append(checks[machine.ID], *check)
 			}
 		}
 		return render.JSON(out, checks)
@@ -60,6 +77,9 @@ func runAppCheckList(ctx context.Context) error {
 			if nameFilter != "" && nameFilter != check.Name {
 				continue
 			}
+			if failing && check.Status == fly.Passing {
+				continue
+			}
 			table.Append([]string{check.Name, string(check.Status), machine.ID, format.RelativeTime(*check.UpdatedAt), check.Output})
 		}
 	}
@@ -67,3 +87,128 @@ func runAppCheckList(ctx context.Context) error {
 
 	return nil
 }
+
+// checkRow is the status of a check of a machine of an app.
+type checkRow struct {
+	App       string                `json:"app"`
+	Machine   string                `json:"machine"`
+	Region    string                `json:"region"`
+	Name      string                `json:"name"`
+	Status    fly.ConsulCheckStatus `json:"status"`
+	Output    string                `json:"output"`
+	UpdatedAt *time.Time            `json:"updated_at,omitempty"`
+}
+
+// runOrgCheckList lists the checks of the machines of every app of orgSlug.
+func runOrgCheckList(ctx context.Context, orgSlug string) error {
+	var (
+		io         = iostreams.FromContext(ctx)
+		client     = fly.ClientFromContext(ctx)
+		nameFilter = flag.GetString(ctx, "check-name")
+		failing    = flag.GetBool(ctx, "failing")
+	)
+
+	org, err := client.GetOrganizationBySlug(ctx, orgSlug)
+	if err != nil {
+		return fmt.Errorf("failed retrieving organization %s: %w", orgSlug, err)
+	}
+
+	apps, err := client.GetAppsForOrganization(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed retrieving apps: %w", err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sem  = make(chan struct{}, listConcurrency)
+		rows []checkRow
+	)
+	for _, app := range apps {
+		app := app
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: app.Name})
+			if err == nil {
+				var machines []*fly.Machine
+				if machines, err = flapsClient.ListActive(ctx); err == nil {
+					appRows := checkRows(app.Name, machines, nameFilter, failing)
+					mu.Lock()
+					rows = append(rows, appRows...)
+					mu.Unlock()
+					return
+				}
+			}
+			fmt.Fprintf(io.ErrOut, "Failed listing checks of %s: %v\n", app.Name, err)
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.App != b.App {
+			return a.App < b.App
+		}
+		if a.Machine != b.Machine {
+			return a.Machine < b.Machine
+		}
+		return a.Name < b.Name
+	})
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, rows)
+	}
+
+	if len(rows) == 0 {
+		if failing {
+			fmt.Fprintf(io.Out, "No failing health checks in %s\n", orgSlug)
+		} else {
+			fmt.Fprintf(io.Out, "No health checks in %s\n", orgSlug)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(io.Out, "Health Checks for %s\n", orgSlug)
+	table := helpers.MakeSimpleTable(io.Out, []string{"App", "Name", "Status", "Machine", "Region", "Last Updated", "Output"})
+	table.SetRowLine(true)
+	for _, r := range rows {
+		updated := ""
+		if r.UpdatedAt != nil {
+			updated = format.RelativeTime(*r.UpdatedAt)
+		}
+		table.Append([]string{r.App, r.Name, string(r.Status), r.Machine, r.Region, updated, r.Output})
+	}
+	table.Render()
+
+	return nil
+}
+
+// checkRows returns the checks of machines named nameFilter, when given, and
+// only those not passing when failing is set.
+func checkRows(appName string, machines []*fly.Machine, nameFilter string, failing bool) []checkRow {
+	var rows []checkRow
+	for _, m := range machines {
+		for _, check := range m.Checks {
+			if nameFilter != "" && nameFilter != check.Name {
+				continue
+			}
+			if failing && check.Status == fly.Passing {
+				continue
+			}
+			rows = append(rows, checkRow{
+				App:       appName,
+				Machine:   m.ID,
+				Region:    m.Region,
+				Name:      check.Name,
+				Status:    check.Status,
+				Output:    check.Output,
+				UpdatedAt: check.UpdatedAt,
+			})
+		}
+	}
+	return rows
+}
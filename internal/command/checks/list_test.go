@@ -0,0 +1,42 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestCheckRows(t *testing.T) {
+	machines := []*fly.Machine{
+		{
+			ID:     "m1",
+			Region: "ord",
+			Checks: []*fly.MachineCheckStatus{
+				{Name: "http", Status: fly.Passing},
+				{Name: "tcp", Status: fly.Critical, Output: "connection refused"},
+			},
+		},
+		{
+			ID:     "m2",
+			Region: "ams",
+			Checks: []*fly.MachineCheckStatus{
+				{Name: "http", Status: fly.Warning},
+			},
+		},
+	}
+
+	rows := checkRows("web", machines, "", false)
+	assert.Len(t, rows, 3)
+
+	rows = checkRows("web", machines, "", true)
+	assert.Equal(t, []checkRow{
+		{App: "web", Machine: "m1", Region: "ord", Name: "tcp", Status: fly.Critical, Output: "connection refused"},
+		{App: "web", Machine: "m2", Region: "ams", Name: "http", Status: fly.Warning},
+	}, rows)
+
+	rows = checkRows("web", machines, "http", true)
+	assert.Equal(t, []checkRow{
+		{App: "web", Machine: "m2", Region: "ams", Name: "http", Status: fly.Warning},
+	}, rows)
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/env"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/httptracing"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/metrics"
 	"github.com/superfly/flyctl/internal/state"
@@ -52,6 +54,7 @@ func New(usage, short, long string, fn Runner, p ...preparers.Preparer) *cobra.C
 // I don't like this, but it's shippable until someone else fixes it
 var commonPreparers = []preparers.Preparer{
 	preparers.ApplyAliases,
+	applyLogFlags,
 	determineHostname,
 	determineWorkingDir,
 	preparers.DetermineConfigDir,
@@ -59,6 +62,7 @@ var commonPreparers = []preparers.Preparer{
 	ensureConfigDirPerms,
 	loadCache,
 	preparers.LoadConfig,
+	applyOutputFlags,
 	startQueryingForNewRelease,
 	promptAndAutoUpdate,
 	startMetrics,
@@ -110,6 +114,11 @@ func newRunE(fn Runner, preparers ...preparers.Preparer) func(*cobra.Command, []
 			return
 		}
 
+		// apply the default flags the user configured for the command and app
+		if ctx, err = applyAppDefaults(ctx); err != nil {
+			return
+		}
+
 		// start task manager using the prepared context
 		task.FromContext(ctx).Start(ctx)
 
@@ -133,6 +142,9 @@ func newRunE(fn Runner, preparers ...preparers.Preparer) func(*cobra.Command, []
 
 		// run the command
 		if err = fn(ctx); err == nil {
+			// suggest what to do next
+			showHint(ctx)
+
 			// and finally, run the finalizer
 			finalize(ctx)
 		}
@@ -166,6 +178,86 @@ func finalize(ctx context.Context) {
 	}
 }
 
+// applyLogFlags replaces the logger of ctx according to --log-level, and
+// makes it also write debug logs and HTTP traces to --log-file.
+func applyLogFlags(ctx context.Context) (context.Context, error) {
+	var (
+		fs    = flag.FromContext(ctx)
+		io    = iostreams.FromContext(ctx)
+		level string
+		path  string
+	)
+
+	if f := fs.Lookup(flagnames.LogLevel); f != nil && f.Changed {
+		level = f.Value.String()
+	} else if quiet, _ := fs.GetBool(flagnames.Quiet); quiet {
+		level = "warn"
+	}
+	if f := fs.Lookup(flagnames.LogFile); f != nil {
+		path = f.Value.String()
+	}
+
+	if level == "" && path == "" {
+		return ctx, nil
+	}
+
+	l := logger.FromEnv(io.ErrOut)
+	if level != "" {
+		lvl, err := logger.ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		l = logger.New(io.ErrOut, lvl, io.IsStderrTTY())
+
+		// some commands read the level straight from the environment
+		_ = os.Setenv("LOG_LEVEL", level)
+	}
+	l = l.AndLogToFile()
+
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening log file: %w", err)
+		}
+
+		fmt.Fprintf(f, "# %s, flyctl %s, %s\n\n", FromContext(ctx).CommandPath(), buildinfo.Version(), time.Now().Format(time.RFC3339))
+
+		l = l.AndLogTo(f)
+		httptracing.SetTraceOutput(f)
+
+		task.FromContext(ctx).RunFinalizer(func(context.Context) {
+			httptracing.SetTraceOutput(nil)
+			_ = f.Close()
+		})
+	}
+
+	return logger.NewContext(ctx, l), nil
+}
+
+// applyOutputFlags applies --quiet, --no-color and --progress, or their
+// environment counterparts, to the IOStreams of ctx. JSON output implies JSON
+// progress unless another progress mode is asked for.
+func applyOutputFlags(ctx context.Context) (context.Context, error) {
+	var (
+		cfg = config.FromContext(ctx)
+		io  = iostreams.FromContext(ctx)
+	)
+
+	progress := cfg.Progress
+	if progress == "" && cfg.JSONOutput {
+		progress = iostreams.ProgressJSON
+	}
+	if err := io.SetProgress(progress); err != nil {
+		return nil, err
+	}
+	io.SetQuiet(cfg.Quiet)
+	if cfg.NoColor {
+		io.SetColorEnabled(false)
+	}
+
+	return ctx, nil
+}
+
 func determineHostname(ctx context.Context) (context.Context, error) {
 	h, err := os.Hostname()
 	if err != nil {
@@ -264,7 +356,7 @@ func startQueryingForNewRelease(ctx context.Context) (context.Context, error) {
 	logger := logger.FromContext(ctx)
 
 	cache := cache.FromContext(ctx)
-	if !update.Check() || time.Since(cache.LastCheckedAt()) < time.Hour {
+	if !update.Check() || config.FromContext(ctx).Offline || time.Since(cache.LastCheckedAt()) < time.Hour {
 		logger.Debug("skipped querying for new release")
 
 		return ctx, nil
@@ -336,7 +428,7 @@ func shouldIgnore(ctx context.Context, cmds [][]string) bool {
 		// The shape of the ignoredCmd slice is something like ["version", "upgrade"],
 		// but we're walking up the tree from the end, so we have to iterate that in reverse
 		for i := len(ignoredCmd) - 1; i >= 0; i-- {
-			if !currentCmd.HasParent() || currentCmd.Use != ignoredCmd[i] {
+			if !currentCmd.HasParent() || currentCmd.Name() != ignoredCmd[i] {
 				match = false
 				break
 			}
@@ -356,6 +448,8 @@ func promptAndAutoUpdate(ctx context.Context) (context.Context, error) {
 	cfg := config.FromContext(ctx)
 	if shouldIgnore(ctx, [][]string{
 		{"version", "upgrade"},
+		{"version", "use"},
+		{"version", "rollback"},
 		{"settings", "autoupdate"},
 	}) {
 		return ctx, nil
@@ -390,6 +484,11 @@ func promptAndAutoUpdate(ctx context.Context) (context.Context, error) {
 		return ctx, err
 	}
 
+	if pin, err := update.ParsePin(cfg.UpdatePin); cfg.UpdatePin != "" && (err != nil || !pin.Matches(latest)) {
+		// the user pinned flyctl to versions the latest release isn't one of
+		return ctx, nil
+	}
+
 	if !latest.Newer(current) {
 		if versionInvalidMsg != "" && !silent {
 			// Continuing from versionInvalidMsg above
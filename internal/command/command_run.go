@@ -36,41 +36,14 @@ func DetermineImage(ctx context.Context, appName string, imageOrPath string) (im
 	)
 
 	daemonType := imgsrc.NewDockerDaemonType(!flag.GetBool(ctx, "build-remote-only"), !flag.GetBool(ctx, "build-local-only"), env.IsCI(), flag.GetBool(ctx, "build-nixpacks"))
-	resolver := imgsrc.NewResolver(daemonType, client, appName, io, flag.GetWireguard(ctx))
+	resolver := imgsrc.NewResolver(daemonType, client, appName, io, flag.GetWireguard(ctx), flag.GetDockerHost(ctx))
 
 	// build if relative or absolute path
 	if strings.HasPrefix(imageOrPath, ".") || strings.HasPrefix(imageOrPath, "/") {
-		opts := imgsrc.ImageOptions{
-			AppName:              appName,
-			WorkingDir:           path.Join(state.WorkingDirectory(ctx)),
-			Publish:              !flag.GetBuildOnly(ctx),
-			ImageLabel:           flag.GetString(ctx, "image-label"),
-			Target:               flag.GetString(ctx, "build-target"),
-			NoCache:              flag.GetBool(ctx, "no-build-cache"),
-			BuildpacksDockerHost: flag.GetString(ctx, flag.BuildpacksDockerHost),
-			BuildpacksVolumes:    flag.GetStringSlice(ctx, flag.BuildpacksVolume),
-		}
-
-		dockerfilePath := cfg.Dockerfile()
-
-		// dockerfile passed through flags takes precedence over the one set in config
-		if flag.GetString(ctx, "dockerfile") != "" {
-			dockerfilePath = flag.GetString(ctx, "dockerfile")
-		}
-
-		if dockerfilePath != "" {
-			dockerfilePath, err := filepath.Abs(dockerfilePath)
-			if err != nil {
-				return nil, err
-			}
-			opts.DockerfilePath = dockerfilePath
-		}
-
-		extraArgs, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "build-arg"))
+		opts, err := buildImageOptions(ctx, appName, cfg)
 		if err != nil {
-			return nil, errors.Wrap(err, "invalid build-arg")
+			return nil, err
 		}
-		opts.BuildArgs = extraArgs
 
 		img, err = resolver.BuildImage(ctx, io, opts)
 		if err != nil {
@@ -104,6 +77,86 @@ func DetermineImage(ctx context.Context, appName string, imageOrPath string) (im
 	return img, nil
 }
 
+// buildImageOptions returns the options of the build of the image of a
+// machine, which take the [build] section of the app config and the same
+// build flags as deploy, so that one-off machines match deployed images.
+func buildImageOptions(ctx context.Context, appName string, cfg *appconfig.Config) (opts imgsrc.ImageOptions, err error) {
+	opts = imgsrc.ImageOptions{
+		AppName:              appName,
+		WorkingDir:           path.Join(state.WorkingDirectory(ctx)),
+		Publish:              !flag.GetBuildOnly(ctx),
+		ImageLabel:           flag.GetString(ctx, "image-label"),
+		Target:               flag.GetString(ctx, "build-target"),
+		NoCache:              flag.GetBool(ctx, "no-build-cache"),
+		CacheFrom:            flag.GetStringArray(ctx, "cache-from"),
+		CacheTo:              flag.GetStringArray(ctx, "cache-to"),
+		SBOM:                 flag.GetBool(ctx, "sbom"),
+		SSH:                  flag.GetStringArray(ctx, "ssh"),
+		BuildpacksDockerHost: flag.GetString(ctx, flag.BuildpacksDockerHost),
+		BuildpacksVolumes:    flag.GetStringSlice(ctx, flag.BuildpacksVolume),
+	}
+	if err := imgsrc.ValidateSSH(opts.SSH); err != nil {
+		return opts, err
+	}
+	if opts.Target == "" {
+		opts.Target = cfg.DockerBuildTarget()
+	}
+
+	// dockerfile and ignorefile passed through flags take precedence over the
+	// ones set in config
+	dockerfilePath := cfg.Dockerfile()
+	if flag.GetString(ctx, "dockerfile") != "" {
+		dockerfilePath = flag.GetString(ctx, "dockerfile")
+	}
+	if dockerfilePath != "" {
+		if opts.DockerfilePath, err = filepath.Abs(dockerfilePath); err != nil {
+			return opts, err
+		}
+	}
+
+	ignorefilePath := flag.GetString(ctx, "ignorefile")
+	if ignorefilePath == "" && cfg.Ignorefile() != "" {
+		ignorefilePath = filepath.Join(filepath.Dir(cfg.ConfigFilePath()), cfg.Ignorefile())
+	}
+	if ignorefilePath != "" {
+		if opts.IgnorefilePath, err = filepath.Abs(ignorefilePath); err != nil {
+			return opts, err
+		}
+	}
+
+	// build args from the command line override those of the config
+	opts.BuildArgs = map[string]string{}
+	if cfg != nil && cfg.Build != nil {
+		for k, v := range cfg.Build.Args {
+			opts.BuildArgs[k] = v
+		}
+	}
+	extraArgs, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "build-arg"))
+	if err != nil {
+		return opts, errors.Wrap(err, "invalid build-arg")
+	}
+	for k, v := range extraArgs {
+		opts.BuildArgs[k] = v
+	}
+
+	if opts.BuildSecrets, err = imgsrc.ParseBuildSecrets(flag.GetStringArray(ctx, "build-secret")); err != nil {
+		return opts, errors.Wrap(err, "invalid build-secret")
+	}
+	if opts.Label, err = cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "label")); err != nil {
+		return opts, errors.Wrap(err, "invalid label")
+	}
+
+	if flag.GetBool(ctx, "reproducible") {
+		epoch, err := imgsrc.SourceDateEpoch(ctx, opts.WorkingDir)
+		if err != nil {
+			return opts, err
+		}
+		opts.SourceDateEpoch = &epoch
+	}
+
+	return opts, nil
+}
+
 func DetermineServices(ctx context.Context, services []fly.MachineService) ([]fly.MachineService, error) {
 	svcKey := func(internalPort int, protocol string) string {
 		return fmt.Sprintf("%d/%s", internalPort, protocol)
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func TestBuildImageOptions(t *testing.T) {
+	wd := t.TempDir()
+
+	cfg := appconfig.NewConfig()
+	cfg.SetConfigFilePath(filepath.Join(wd, "fly.toml"))
+	cfg.Build = &appconfig.Build{
+		DockerBuildTarget: "release",
+		Dockerfile:        filepath.Join(wd, "Dockerfile.config"),
+		Ignorefile:        ".dockerignore.config",
+		Args:              map[string]string{"NODE_ENV": "production", "RUBY_VERSION": "3.3"},
+	}
+
+	cases := []struct {
+		name  string
+		args  []string
+		cfg   *appconfig.Config
+		check func(t *testing.T, opts imgsrc.ImageOptions)
+		err   string
+	}{
+		{
+			name: "config",
+			cfg:  cfg,
+			check: func(t *testing.T, opts imgsrc.ImageOptions) {
+				assert.Equal(t, "release", opts.Target)
+				assert.Equal(t, filepath.Join(wd, "Dockerfile.config"), opts.DockerfilePath)
+				assert.Equal(t, filepath.Join(wd, ".dockerignore.config"), opts.IgnorefilePath)
+				assert.Equal(t, map[string]string{"NODE_ENV": "production", "RUBY_VERSION": "3.3"}, opts.BuildArgs)
+			},
+		},
+		{
+			name: "flags override config",
+			args: []string{
+				"--build-target", "debug",
+				"--dockerfile", filepath.Join(wd, "Dockerfile.flag"),
+				"--ignorefile", filepath.Join(wd, ".dockerignore.flag"),
+				"--build-arg", "NODE_ENV=test",
+			},
+			cfg: cfg,
+			check: func(t *testing.T, opts imgsrc.ImageOptions) {
+				assert.Equal(t, "debug", opts.Target)
+				assert.Equal(t, filepath.Join(wd, "Dockerfile.flag"), opts.DockerfilePath)
+				assert.Equal(t, filepath.Join(wd, ".dockerignore.flag"), opts.IgnorefilePath)
+				assert.Equal(t, map[string]string{"NODE_ENV": "test", "RUBY_VERSION": "3.3"}, opts.BuildArgs)
+			},
+		},
+		{
+			name: "flags without config",
+			args: []string{
+				"--build-target", "debug",
+				"--build-arg", "NODE_ENV=test",
+				"--build-secret", "TOKEN=s3cr3t",
+				"--label", "team=payments",
+				"--cache-from", "registry.fly.io/my-app:cache",
+			},
+			check: func(t *testing.T, opts imgsrc.ImageOptions) {
+				assert.Equal(t, "debug", opts.Target)
+				assert.Empty(t, opts.DockerfilePath)
+				assert.Empty(t, opts.IgnorefilePath)
+				assert.Equal(t, map[string]string{"NODE_ENV": "test"}, opts.BuildArgs)
+				assert.Equal(t, map[string]string{"TOKEN": "s3cr3t"}, opts.BuildSecrets)
+				assert.Equal(t, map[string]string{"team": "payments"}, opts.Label)
+				assert.Equal(t, []string{"registry.fly.io/my-app:cache"}, opts.CacheFrom)
+			},
+		},
+		{
+			name: "no flags nor config",
+			check: func(t *testing.T, opts imgsrc.ImageOptions) {
+				assert.Empty(t, opts.Target)
+				assert.Empty(t, opts.DockerfilePath)
+				assert.Empty(t, opts.IgnorefilePath)
+				assert.Empty(t, opts.BuildArgs)
+				assert.Nil(t, opts.SourceDateEpoch)
+			},
+		},
+		{
+			name: "invalid build arg",
+			args: []string{"--build-arg", "NODE_ENV"},
+			cfg:  cfg,
+			err:  "invalid build-arg",
+		},
+		{
+			name: "invalid label",
+			args: []string{"--label", "team"},
+			err:  "invalid label",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("run", pflag.ContinueOnError)
+			fs.String("build-target", "", "")
+			fs.String("dockerfile", "", "")
+			fs.String("ignorefile", "", "")
+			fs.StringArray("build-arg", nil, "")
+			fs.StringArray("build-secret", nil, "")
+			fs.StringArray("label", nil, "")
+			fs.StringArray("cache-from", nil, "")
+			require.NoError(t, fs.Parse(tc.args))
+
+			ctx := flag.NewContext(context.Background(), fs)
+			ctx = state.WithWorkingDirectory(ctx, wd)
+
+			opts, err := buildImageOptions(ctx, "my-app", tc.cfg)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "my-app", opts.AppName)
+			assert.Equal(t, wd, opts.WorkingDir)
+			tc.check(t, opts)
+		})
+	}
+}
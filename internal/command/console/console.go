@@ -19,6 +19,7 @@ import (
 	"github.com/superfly/flyctl/internal/command/ssh"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/prompt"
@@ -45,8 +46,9 @@ func New() *cobra.Command {
 		flag.Region(),
 		flag.Wireguard(),
 		flag.String{
-			Name:        "machine",
-			Description: "Run the console in the existing machine with the specified ID",
+			Name:         "machine",
+			Description:  "Run the console in the existing machine with the specified ID",
+			CompletionFn: completion.CompleteMachines,
 		},
 		flag.Bool{
 			Name:        "select",
@@ -89,6 +91,7 @@ func New() *cobra.Command {
 			Description: "Only perform builds locally using the local docker daemon",
 			Hidden:      true,
 		},
+		flag.DockerHost(),
 		flag.Bool{
 			Name:        "build-nixpacks",
 			Description: "Build your image with nixpacks",
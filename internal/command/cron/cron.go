@@ -0,0 +1,606 @@
+// Package cron implements the cron command chain, which runs commands of an
+// app on a schedule.
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/apps"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/cron"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/format"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/logs"
+)
+
+func New() *cobra.Command {
+	const (
+		short = "Run commands of an app on a schedule"
+		long  = short + `. Each job is a stopped machine running the image of
+the app, which a supervisor machine starts on the schedule of the job. Deploys
+move jobs to the image and environment of the new release.
+`
+	)
+
+	cmd := command.New("cron", short, long, nil)
+
+	cmd.AddCommand(
+		newCreate(),
+		newList(),
+		newRuns(),
+		newLogs(),
+		newUpdate(),
+		newDelete(),
+	)
+
+	return cmd
+}
+
+// appContext returns ctx set up to operate on the machines of the app.
+func appContext(ctx context.Context) (context.Context, *fly.AppCompact, error) {
+	var (
+		client  = fly.ClientFromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed retrieving app %s: %w", appName, err)
+	}
+
+	ctx, err = apps.BuildContext(ctx, app)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, app, nil
+}
+
+func parseCommand(command string) ([]string, error) {
+	args, err := shlex.Split(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command: %w", err)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("the command of the job can't be empty")
+	}
+	return args, nil
+}
+
+func newCreate() *cobra.Command {
+	const (
+		short = "Create a job running a command on a schedule"
+		long  = short + `. The schedule is a cron expression, such as
+'*/5 * * * *' for every five minutes, or a macro such as @daily, in UTC.
+`
+		usage = "create"
+	)
+
+	cmd := command.New(usage, short, long, runCreate,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Region(),
+		flag.String{
+			Name:        "schedule",
+			Description: "The cron expression of the schedule",
+		},
+		flag.String{
+			Name:        "command",
+			Description: "The command to run",
+		},
+		flag.String{
+			Name:        "group",
+			Description: "The process group of the job",
+			Default:     "cron",
+		},
+		flag.String{
+			Name:        "name",
+			Description: "The name of the job, defaults to the process group",
+		},
+		flag.String{
+			Name:        "image",
+			Description: "The image to run, defaults to the image of the app",
+		},
+	)
+
+	return cmd
+}
+
+func runCreate(ctx context.Context) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+		group    = flag.GetString(ctx, "group")
+		name     = flag.GetString(ctx, "name")
+	)
+
+	if name == "" {
+		name = group
+	}
+	if err := validateJobName(name); err != nil {
+		return err
+	}
+
+	schedule, err := parseSchedule(flag.GetString(ctx, "schedule"))
+	if err != nil {
+		return err
+	}
+
+	command, err := parseCommand(flag.GetString(ctx, "command"))
+	if err != nil {
+		return err
+	}
+
+	ctx, app, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := findJob(ctx, name); err == nil {
+		return fmt.Errorf("job %s already exists, pick another name with --name", name)
+	}
+
+	base, region, err := appImage(ctx)
+	if image := flag.GetString(ctx, "image"); image != "" {
+		if base == nil {
+			base = &fly.MachineConfig{}
+		}
+		base.Image = image
+	} else if err != nil {
+		return err
+	}
+
+	if r := flag.GetRegion(ctx); r != "" {
+		region = r
+	}
+
+	m, err := flaps.FromContext(ctx).Launch(ctx, fly.LaunchMachineInput{
+		Name:       "cron-" + name,
+		Region:     region,
+		Config:     jobConfig(base, name, group, schedule, command),
+		SkipLaunch: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating the machine of job %s: %w", name, err)
+	}
+
+	if err := syncSupervisor(ctx, app, m.Region); err != nil {
+		return fmt.Errorf("failed updating the cron supervisor: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Job %s runs %s on machine %s on schedule %s\n",
+		colorize.Bold(name), strings.Join(command, " "), m.ID, colorize.Bold(schedule))
+	return nil
+}
+
+func newList() *cobra.Command {
+	const (
+		short = "List the jobs of an app"
+		long  = short + "\n"
+
+		usage = "list"
+	)
+
+	cmd := command.New(usage, short, long, runList,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	cmd.Aliases = []string{"ls"}
+
+	return cmd
+}
+
+type job struct {
+	Name     string   `json:"name"`
+	Schedule string   `json:"schedule"`
+	Command  []string `json:"command"`
+	Group    string   `json:"group"`
+	Machine  string   `json:"machine"`
+	Image    string   `json:"image"`
+	LastRun  *run     `json:"last_run,omitempty"`
+}
+
+func runList(ctx context.Context) error {
+	var (
+		io  = iostreams.FromContext(ctx)
+		cfg = config.FromContext(ctx)
+	)
+
+	ctx, _, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	machines, supervisor, err := listJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Events are only returned for machines fetched one by one.
+	flapsClient := flaps.FromContext(ctx)
+	jobs := make([]job, len(machines))
+	var eg errgroup.Group
+	for i, m := range machines {
+		i, m := i, m
+		eg.Go(func() error {
+			full, err := flapsClient.Get(ctx, m.ID)
+			if err != nil {
+				return err
+			}
+			jobs[i] = job{
+				Name:     cron.JobName(full),
+				Schedule: full.Config.Metadata[cron.MetadataKeySchedule],
+				Command:  full.Config.Init.Cmd,
+				Group:    full.ProcessGroup(),
+				Machine:  full.ID,
+				Image:    full.FullImageRef(),
+			}
+			if r := runs(full.Events); len(r) > 0 {
+				jobs[i].LastRun = &r[0]
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("failed retrieving jobs: %w", err)
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, jobs)
+	}
+
+	if len(jobs) == 0 {
+		fmt.Fprintln(io.Out, "No jobs found, create one with 'fly cron create'")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(jobs))
+	for _, j := range jobs {
+		lastRun, exit := "", ""
+		if j.LastRun != nil {
+			lastRun = format.RelativeTime(j.LastRun.StartedAt)
+			exit = formatExit(*j.LastRun)
+		}
+		rows = append(rows, []string{j.Name, j.Schedule, strings.Join(j.Command, " "), j.Machine, lastRun, exit})
+	}
+
+	if err := render.Table(io.Out, "", rows, "Name", "Schedule", "Command", "Machine", "Last Run", "Result"); err != nil {
+		return err
+	}
+
+	if supervisor == nil || supervisor.State != fly.MachineStateStarted {
+		fmt.Fprintf(io.ErrOut, "%s the cron supervisor isn't running, jobs won't start on schedule\n", io.ColorScheme().WarningIcon())
+	}
+	return nil
+}
+
+func formatExit(r run) string {
+	switch {
+	case r.ExitCode == nil:
+		return "running"
+	case r.OOM:
+		return "out of memory"
+	case *r.ExitCode == 0:
+		return "succeeded"
+	default:
+		return fmt.Sprintf("failed (exit code %d)", *r.ExitCode)
+	}
+}
+
+func newRuns() *cobra.Command {
+	const (
+		short = "List the recent runs of a job"
+		long  = short + "\n"
+
+		usage = "runs <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runRuns,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+func runRuns(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		cfg  = config.FromContext(ctx)
+		name = flag.FirstArg(ctx)
+	)
+
+	ctx, _, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	m, err := findJob(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	m, err = flaps.FromContext(ctx).Get(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	runs := runs(m.Events)
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, runs)
+	}
+
+	if len(runs) == 0 {
+		fmt.Fprintf(io.Out, "Job %s hasn't run yet\n", name)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(runs))
+	for _, r := range runs {
+		duration := ""
+		if r.ExitCode != nil {
+			duration = r.Duration.Round(100 * time.Millisecond).String()
+		}
+		rows = append(rows, []string{
+			r.StartedAt.Format("2006-01-02T15:04:05Z07:00"),
+			duration,
+			formatExit(r),
+		})
+	}
+
+	return render.Table(io.Out, "", rows, "Started", "Duration", "Result")
+}
+
+func newLogs() *cobra.Command {
+	const (
+		short = "Show the recent logs of a job"
+		long  = short + "\n"
+
+		usage = "logs <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runLogs,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+func runLogs(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		cfg    = config.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+		name   = flag.FirstArg(ctx)
+	)
+
+	jobCtx, app, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	m, err := findJob(jobCtx, name)
+	if err != nil {
+		return err
+	}
+
+	entries := make(chan logs.LogEntry)
+	var eg errgroup.Group
+	eg.Go(func() error {
+		defer close(entries)
+		return logs.Poll(ctx, entries, client, &logs.LogOptions{
+			AppName: app.Name,
+			VMID:    m.ID,
+			NoTail:  true,
+		})
+	})
+
+	for entry := range entries {
+		if cfg.JSONOutput {
+			err = render.JSON(io.Out, entry)
+		} else {
+			err = render.LogEntry(io.Out, entry, render.HideAllocID(), render.RemoveNewlines())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return eg.Wait()
+}
+
+func newUpdate() *cobra.Command {
+	const (
+		short = "Update the schedule, command or image of a job"
+		long  = short + `. The job moves to the current image of the app unless
+--image is given.
+`
+		usage = "update <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runUpdate,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.String{
+			Name:        "schedule",
+			Description: "The new cron expression of the schedule",
+		},
+		flag.String{
+			Name:        "command",
+			Description: "The new command to run",
+		},
+		flag.String{
+			Name:        "image",
+			Description: "The image to run, defaults to the current image of the app",
+		},
+	)
+
+	return cmd
+}
+
+func runUpdate(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		name = flag.FirstArg(ctx)
+	)
+
+	ctx, app, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	m, err := findJob(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	schedule := m.Config.Metadata[cron.MetadataKeySchedule]
+	if flag.IsSpecified(ctx, "schedule") {
+		if schedule, err = parseSchedule(flag.GetString(ctx, "schedule")); err != nil {
+			return err
+		}
+	}
+
+	command := m.Config.Init.Cmd
+	if flag.IsSpecified(ctx, "command") {
+		if command, err = parseCommand(flag.GetString(ctx, "command")); err != nil {
+			return err
+		}
+	}
+
+	base := mach.CloneConfig(m.Config)
+	if image := flag.GetString(ctx, "image"); image != "" {
+		base.Image = image
+	} else if current, _, err := appImage(ctx); err == nil {
+		base = current
+	} else {
+		return err
+	}
+
+	conf := jobConfig(base, name, m.ProcessGroup(), schedule, command)
+
+	machines, release, err := mach.AcquireLeases(ctx, []*fly.Machine{m})
+	defer release()
+	if err != nil {
+		return err
+	}
+
+	if _, err := flaps.FromContext(ctx).Update(ctx, fly.LaunchMachineInput{
+		ID:         m.ID,
+		Region:     m.Region,
+		Config:     conf,
+		SkipLaunch: true,
+	}, machines[0].LeaseNonce); err != nil {
+		return fmt.Errorf("failed updating the machine of job %s: %w", name, err)
+	}
+
+	if err := syncSupervisor(ctx, app, m.Region); err != nil {
+		return fmt.Errorf("failed updating the cron supervisor: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Job %s updated\n", name)
+	return nil
+}
+
+func newDelete() *cobra.Command {
+	const (
+		short = "Delete a job"
+		long  = short + "\n"
+
+		usage = "delete <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runDelete,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Aliases = []string{"rm"}
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+	)
+
+	return cmd
+}
+
+func runDelete(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		name = flag.FirstArg(ctx)
+	)
+
+	ctx, app, err := appContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	m, err := findJob(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := flaps.FromContext(ctx).Destroy(ctx, fly.RemoveMachineInput{ID: m.ID, Kill: true}, ""); err != nil {
+		return fmt.Errorf("failed destroying the machine of job %s: %w", name, err)
+	}
+
+	if err := syncSupervisor(ctx, app, m.Region); err != nil {
+		return fmt.Errorf("failed updating the cron supervisor: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Job %s deleted\n", name)
+	return nil
+}
@@ -0,0 +1,128 @@
+package cron
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/cron"
+)
+
+func TestParseSchedule(t *testing.T) {
+	cases := map[string]string{
+		"*/5 * * * *":         "*/5 * * * *",
+		"  0  3 * * 1-5 ":     "0 3 * * 1-5",
+		"0,30 9-17/2 1 1,6 *": "0,30 9-17/2 1 1,6 *",
+		"@daily":              "0 0 * * *",
+		"@Hourly":             "0 * * * *",
+	}
+	for in, want := range cases {
+		got, err := parseSchedule(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@reboot"} {
+		_, err := parseSchedule(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestRuns(t *testing.T) {
+	at := func(s int64) int64 { return s * 1000 }
+	exit := func(code int) *fly.MachineRequest {
+		return &fly.MachineRequest{ExitEvent: &fly.MachineExitEvent{ExitCode: code}}
+	}
+
+	got := runs([]*fly.MachineEvent{
+		{Type: "start", Timestamp: at(300)},
+		{Type: "exit", Timestamp: at(130), Request: exit(1)},
+		{Type: "launch", Timestamp: at(10)},
+		{Type: "start", Timestamp: at(100)},
+		{Type: "exit", Timestamp: at(30), Request: exit(0)},
+		{Type: "start", Timestamp: at(20)},
+	})
+
+	require.Len(t, got, 3)
+	assert.Equal(t, time.Unix(300, 0), got[0].StartedAt)
+	assert.Nil(t, got[0].ExitCode)
+	assert.Equal(t, 30*time.Second, got[1].Duration)
+	assert.Equal(t, 1, *got[1].ExitCode)
+	assert.Equal(t, 10*time.Second, got[2].Duration)
+	assert.Equal(t, 0, *got[2].ExitCode)
+}
+
+func TestCrontab(t *testing.T) {
+	job := &fly.Machine{
+		ID: "148e",
+		Config: &fly.MachineConfig{
+			Metadata: map[string]string{cron.MetadataKeyJob: "reports", cron.MetadataKeySchedule: "0 3 * * *"},
+		},
+	}
+
+	assert.Equal(t,
+		"# reports\n0 3 * * * wget -q -O /dev/null --post-data \"\" --header \"Authorization: $(cat /etc/fly-cron/token)\" http://_api.internal:4280/v1/apps/app/machines/148e/start\n",
+		crontab("app", []*fly.Machine{job}),
+	)
+}
+
+func TestJobConfig(t *testing.T) {
+	base := &fly.MachineConfig{
+		Image: "registry.fly.io/app:deployment-1",
+		Env:   map[string]string{"FLY_PROCESS_GROUP": "app"},
+		Guest: &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 512},
+		Services: []fly.MachineService{
+			{Protocol: "tcp", InternalPort: 8080},
+		},
+		Metadata: map[string]string{
+			fly.MachineConfigMetadataKeyFlyPlatformVersion: fly.MachineFlyPlatformVersion2,
+			fly.MachineConfigMetadataKeyFlyReleaseId:       "rel",
+			fly.MachineConfigMetadataKeyFlyReleaseVersion:  "7",
+		},
+	}
+
+	conf := jobConfig(base, "reports", "app", "0 3 * * *", []string{"bin/report"})
+
+	assert.Equal(t, base.Image, conf.Image)
+	assert.Equal(t, base.Env, conf.Env)
+	assert.Equal(t, []string{"bin/report"}, conf.Init.Cmd)
+	assert.Equal(t, fly.MachineRestartPolicyNo, conf.Restart.Policy)
+	assert.Empty(t, conf.Services, "jobs don't serve traffic")
+	assert.Equal(t, map[string]string{
+		cron.MetadataKeyJob:                           "reports",
+		cron.MetadataKeySchedule:                      "0 3 * * *",
+		fly.MachineConfigMetadataKeyFlyProcessGroup:   "app",
+		fly.MachineConfigMetadataKeyFlyReleaseId:      "rel",
+		fly.MachineConfigMetadataKeyFlyReleaseVersion: "7",
+	}, conf.Metadata, "jobs carry the release, not the platform version")
+}
+
+func TestSupervisorConfig(t *testing.T) {
+	jobs := []*fly.Machine{{
+		ID:     "148e",
+		Config: &fly.MachineConfig{Metadata: map[string]string{cron.MetadataKeyJob: "reports", cron.MetadataKeySchedule: "0 3 * * *"}},
+	}}
+
+	conf := supervisorConfig("app", jobs)
+
+	require.Len(t, conf.Files, 2)
+	crontabFile, err := base64.StdEncoding.DecodeString(*conf.Files[0].RawValue)
+	require.NoError(t, err)
+	assert.Equal(t, crontab("app", jobs), string(crontabFile))
+	assert.Equal(t, tokenSecret, *conf.Files[1].SecretName)
+	assert.True(t, isSupervisor(&fly.Machine{Config: conf}))
+	assert.Empty(t, cron.JobName(&fly.Machine{Config: conf}))
+}
+
+func TestValidateJobName(t *testing.T) {
+	for _, name := range []string{"reports", "db-vacuum", "a1"} {
+		assert.NoError(t, validateJobName(name), name)
+	}
+	for _, name := range []string{"", "Reports", "-x", "a_b", strings.Repeat("a", 64)} {
+		assert.Error(t, validateJobName(name), name)
+	}
+}
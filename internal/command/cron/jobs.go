@@ -0,0 +1,289 @@
+package cron
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/cron"
+	mach "github.com/superfly/flyctl/internal/machine"
+)
+
+const (
+	// metadataKeySupervisor marks the machine starting the jobs.
+	metadataKeySupervisor = "fly_cron_supervisor"
+
+	// tokenSecret holds the authorization header the supervisor starts jobs
+	// with, base64 encoded for it to be mounted as a file.
+	tokenSecret = "FLY_CRON_TOKEN"
+
+	supervisorImage = "alpine:3.20"
+	crontabPath     = "/etc/crontabs/root"
+	tokenPath       = "/etc/fly-cron/token"
+
+	// machinesAPI is the address of the machines API on the private network.
+	machinesAPI = "http://_api.internal:4280"
+)
+
+var jobNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+func validateJobName(name string) error {
+	if !jobNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid job name %q, use lowercase letters, digits and dashes", name)
+	}
+	return nil
+}
+
+func isSupervisor(m *fly.Machine) bool {
+	return m.Config != nil && m.Config.Metadata[metadataKeySupervisor] == "true"
+}
+
+// listJobs returns the machines running the jobs of the app, sorted by name,
+// and its supervisor, when there is one.
+func listJobs(ctx context.Context) (jobs []*fly.Machine, supervisor *fly.Machine, err error) {
+	machines, err := flaps.FromContext(ctx).List(ctx, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed retrieving machines: %w", err)
+	}
+
+	for _, m := range machines {
+		switch {
+		case m.State == fly.MachineStateDestroyed || m.State == fly.MachineStateDestroying:
+		case isSupervisor(m):
+			supervisor = m
+		case cron.JobName(m) != "":
+			jobs = append(jobs, m)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return cron.JobName(jobs[i]) < cron.JobName(jobs[j])
+	})
+	return jobs, supervisor, nil
+}
+
+// findJob returns the machine running the job name.
+func findJob(ctx context.Context, name string) (*fly.Machine, error) {
+	jobs, _, err := listJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range jobs {
+		if cron.JobName(m) == name {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("no job named %s, see 'fly cron list'", name)
+}
+
+// appImage returns the configuration of the most recently updated machine of
+// the app, for jobs to run the same image with the same environment, and its
+// region.
+func appImage(ctx context.Context) (*fly.MachineConfig, string, error) {
+	machines, _, err := flaps.FromContext(ctx).ListFlyAppsMachines(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed retrieving machines: %w", err)
+	}
+	if len(machines) == 0 {
+		return nil, "", fmt.Errorf("the app has no machines to take the image of jobs from, deploy it first or pass --image")
+	}
+
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].UpdatedAt > machines[j].UpdatedAt
+	})
+	conf := mach.CloneConfig(machines[0].Config)
+	conf.Image = machines[0].FullImageRef()
+	return conf, machines[0].Region, nil
+}
+
+// jobConfig returns the configuration of a machine running command on
+// schedule, based on the configuration base of a machine of the app.
+func jobConfig(base *fly.MachineConfig, name, group, schedule string, command []string) *fly.MachineConfig {
+	return &fly.MachineConfig{
+		Image: base.Image,
+		Env:   base.Env,
+		Guest: base.Guest,
+		Init: fly.MachineInit{
+			Cmd: command,
+		},
+		Restart: &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyNo,
+		},
+		// Jobs don't carry the platform version, for deploys not to manage
+		// them as machines of their process group. Deploys move them to the
+		// image of the release instead.
+		Metadata: map[string]string{
+			cron.MetadataKeyJob:                           name,
+			cron.MetadataKeySchedule:                      schedule,
+			fly.MachineConfigMetadataKeyFlyProcessGroup:   group,
+			fly.MachineConfigMetadataKeyFlyReleaseId:      base.Metadata[fly.MachineConfigMetadataKeyFlyReleaseId],
+			fly.MachineConfigMetadataKeyFlyReleaseVersion: base.Metadata[fly.MachineConfigMetadataKeyFlyReleaseVersion],
+		},
+	}
+}
+
+// crontab returns the crontab of the supervisor, starting each of jobs on
+// its schedule.
+func crontab(appName string, jobs []*fly.Machine) string {
+	var b strings.Builder
+	for _, m := range jobs {
+		fmt.Fprintf(&b, "# %s\n", cron.JobName(m))
+		fmt.Fprintf(&b,
+			`%s wget -q -O /dev/null --post-data "" --header "Authorization: $(cat %s)" %s/v1/apps/%s/machines/%s/start`+"\n",
+			m.Config.Metadata[cron.MetadataKeySchedule], tokenPath, machinesAPI, appName, m.ID,
+		)
+	}
+	return b.String()
+}
+
+func supervisorConfig(appName string, jobs []*fly.Machine) *fly.MachineConfig {
+	return &fly.MachineConfig{
+		Image: supervisorImage,
+		Init: fly.MachineInit{
+			Cmd: []string{"crond", "-f", "-l", "2"},
+		},
+		Files: []*fly.File{
+			{
+				GuestPath: crontabPath,
+				RawValue:  fly.Pointer(base64.StdEncoding.EncodeToString([]byte(crontab(appName, jobs)))),
+			},
+			{
+				GuestPath:  tokenPath,
+				SecretName: fly.Pointer(tokenSecret),
+			},
+		},
+		Guest: &fly.MachineGuest{
+			CPUKind:  "shared",
+			CPUs:     1,
+			MemoryMB: 256,
+		},
+		Restart: &fly.MachineRestart{
+			Policy: fly.MachineRestartPolicyAlways,
+		},
+		Metadata: map[string]string{
+			metadataKeySupervisor: "true",
+		},
+	}
+}
+
+// syncSupervisor makes the supervisor of the app start the jobs it has now:
+// it's created with the first job, updated as they change and destroyed with
+// the last one.
+func syncSupervisor(ctx context.Context, app *fly.AppCompact, region string) error {
+	flapsClient := flaps.FromContext(ctx)
+
+	jobs, supervisor, err := listJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(jobs) == 0 && supervisor == nil:
+		return nil
+	case len(jobs) == 0:
+		return flapsClient.Destroy(ctx, fly.RemoveMachineInput{ID: supervisor.ID, Kill: true}, "")
+	case supervisor == nil:
+		if err := ensureToken(ctx, app); err != nil {
+			return err
+		}
+		_, err := flapsClient.Launch(ctx, fly.LaunchMachineInput{
+			Name:   "cron-supervisor",
+			Region: region,
+			Config: supervisorConfig(app.Name, jobs),
+		})
+		return err
+	default:
+		machines, release, err := mach.AcquireLeases(ctx, []*fly.Machine{supervisor})
+		defer release()
+		if err != nil {
+			return err
+		}
+		return mach.Update(ctx, machines[0], &fly.LaunchMachineInput{
+			Region: supervisor.Region,
+			Config: supervisorConfig(app.Name, jobs),
+		})
+	}
+}
+
+// ensureToken sets the secret holding the token the supervisor starts jobs
+// with, unless it's set.
+func ensureToken(ctx context.Context, app *fly.AppCompact) error {
+	client := fly.ClientFromContext(ctx)
+
+	secrets, err := client.GetAppSecrets(ctx, app.Name)
+	if err != nil {
+		return err
+	}
+	for _, s := range secrets {
+		if s.Name == tokenSecret {
+			return nil
+		}
+	}
+
+	resp, err := gql.CreateLimitedAccessToken(ctx, client.GenqClient, "fly cron", app.Organization.ID, "deploy", &gql.LimitedAccessTokenOptions{
+		"app_id": app.ID,
+	}, "")
+	if err != nil {
+		return fmt.Errorf("failed creating token: %w", err)
+	}
+	token := resp.CreateLimitedAccessToken.LimitedAccessToken.TokenHeader
+
+	_, err = client.SetSecrets(ctx, app.Name, map[string]string{
+		tokenSecret: base64.StdEncoding.EncodeToString([]byte(token)),
+	})
+	return err
+}
+
+// run is a run of a job, from the events of its machine.
+type run struct {
+	StartedAt time.Time `json:"started_at"`
+	// Duration and ExitCode are unset while the job runs.
+	Duration time.Duration `json:"duration,omitempty"`
+	ExitCode *int          `json:"exit_code,omitempty"`
+	OOM      bool          `json:"oom,omitempty"`
+}
+
+// runs returns the runs of a job from the events of its machine, newest first.
+func runs(events []*fly.MachineEvent) []run {
+	events = append([]*fly.MachineEvent(nil), events...)
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Timestamp < events[j].Timestamp
+	})
+
+	var out []run
+	for _, e := range events {
+		switch e.Type {
+		case "start":
+			out = append(out, run{StartedAt: e.Time()})
+		case "exit":
+			if len(out) == 0 || out[len(out)-1].ExitCode != nil {
+				continue
+			}
+			r := &out[len(out)-1]
+			r.Duration = e.Time().Sub(r.StartedAt)
+			if e.Request != nil {
+				if code, err := e.Request.GetExitCode(); err == nil {
+					r.ExitCode = &code
+				}
+				if e.Request.ExitEvent != nil {
+					r.OOM = e.Request.ExitEvent.OOMKilled
+				}
+			}
+			if r.ExitCode == nil {
+				r.ExitCode = fly.Pointer(-1)
+			}
+		}
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
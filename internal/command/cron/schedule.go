@@ -0,0 +1,99 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// macros are the schedule shorthands, expanded since the supervisor's crond
+// doesn't know them all.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// fields are the bounds of the five fields of a schedule.
+var fields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseSchedule validates the cron expression schedule and returns it in the
+// five field form.
+func parseSchedule(schedule string) (string, error) {
+	schedule = strings.TrimSpace(schedule)
+	if expanded, ok := macros[strings.ToLower(schedule)]; ok {
+		return expanded, nil
+	}
+
+	parts := strings.Fields(schedule)
+	if len(parts) != len(fields) {
+		return "", fmt.Errorf("invalid schedule %q: expected 5 fields (minute hour day-of-month month day-of-week) or a macro such as @hourly", schedule)
+	}
+
+	for i, part := range parts {
+		if err := parseField(part, fields[i].min, fields[i].max); err != nil {
+			return "", fmt.Errorf("invalid schedule %q: %s field: %w", schedule, fields[i].name, err)
+		}
+	}
+
+	return strings.Join(parts, " "), nil
+}
+
+// parseField validates a comma separated list of *, values and ranges, each
+// with an optional step.
+func parseField(field string, min, max int) error {
+	for _, item := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		if rng == "*" {
+			continue
+		}
+
+		lo, hi, isRange := strings.Cut(rng, "-")
+		from, err := parseValue(lo, min, max)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		to, err := parseValue(hi, min, max)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			return fmt.Errorf("invalid range %q", rng)
+		}
+	}
+	return nil
+}
+
+func parseValue(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", n, min, max)
+	}
+	return n, nil
+}
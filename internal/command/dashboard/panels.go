@@ -0,0 +1,170 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/exp/maps"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// grafanaURL is the managed Grafana of Fly.io organizations.
+const grafanaURL = "https://fly-metrics.net"
+
+// appDashboard is the UID and slug of the managed Grafana dashboard of apps.
+const (
+	appDashboardUID  = "fly-app"
+	appDashboardSlug = "fly-app"
+)
+
+// grafanaPanel is a panel of a Grafana dashboard. Collapsed rows hold their
+// panels.
+type grafanaPanel struct {
+	ID     int            `json:"id"`
+	Title  string         `json:"title"`
+	Type   string         `json:"type"`
+	Panels []grafanaPanel `json:"panels"`
+}
+
+// dashboardPanels fetches the app dashboard and returns the IDs of its panels.
+func dashboardPanels(ctx context.Context) (map[string]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, grafanaURL+"/api/dashboards/uid/"+appDashboardUID, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fly.AuthorizationHeader(config.FromContext(ctx).Tokens.GraphQL()))
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching the app dashboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed fetching the app dashboard: unexpected response: %s", resp.Status)
+	}
+
+	var body struct {
+		Dashboard struct {
+			Panels []grafanaPanel `json:"panels"`
+		} `json:"dashboard"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed fetching the app dashboard: %w", err)
+	}
+	return panelIDs(body.Dashboard.Panels), nil
+}
+
+// panelIDs maps the names of panels, their titles in kebab case such as
+// "http-response-times", to their IDs. Rows aren't panels themselves.
+func panelIDs(panels []grafanaPanel) map[string]int {
+	ids := make(map[string]int)
+	for _, p := range panels {
+		if p.Type == "row" {
+			for name, id := range panelIDs(p.Panels) {
+				ids[name] = id
+			}
+			continue
+		}
+		if name := panelName(p.Title); name != "" {
+			if _, ok := ids[name]; !ok {
+				ids[name] = p.ID
+			}
+		}
+	}
+	return ids
+}
+
+// panelName turns a panel title into a name usable as a flag value.
+func panelName(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, "-")
+}
+
+// panelID returns the ID of the panel name of the app dashboard.
+func panelID(panels map[string]int, name string) (int, error) {
+	if id, ok := panels[name]; ok {
+		return id, nil
+	}
+	names := maps.Keys(panels)
+	sort.Strings(names)
+	return 0, fmt.Errorf("unknown panel %s, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// panelURL returns the URL of a panel of the app dashboard between from and
+// to. Rendered URLs return a PNG of the panel instead of the interactive one.
+func panelURL(orgID int64, appName string, id int, from, to time.Time, rendered bool, width, height int) string {
+	path := "/d-solo/" + appDashboardUID + "/" + appDashboardSlug
+	params := url.Values{
+		"orgId":   {strconv.FormatInt(orgID, 10)},
+		"var-app": {appName},
+		"panelId": {strconv.Itoa(id)},
+		"from":    {strconv.FormatInt(from.UnixMilli(), 10)},
+		"to":      {strconv.FormatInt(to.UnixMilli(), 10)},
+	}
+	if rendered {
+		path = "/render" + path
+		params.Set("width", strconv.Itoa(width))
+		params.Set("height", strconv.Itoa(height))
+		params.Set("tz", "UTC")
+	}
+	return grafanaURL + path + "?" + params.Encode()
+}
+
+// pngPath returns where to write the image of panel: path itself when a
+// single panel is rendered, otherwise path suffixed with the panel name.
+func pngPath(path, panel string, multiple bool) string {
+	if !multiple {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + panel + ext
+}
+
+// renderPanel downloads the image of a rendered panel URL to path.
+func renderPanel(ctx context.Context, renderURL, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, renderURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fly.AuthorizationHeader(config.FromContext(ctx).Tokens.GraphQL()))
+
+	resp, err := (&http.Client{Timeout: 2 * time.Minute}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed rendering panel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed rendering panel: unexpected response: %s", resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "image/png") {
+		return fmt.Errorf("failed rendering panel: unexpected content type %s", ct)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
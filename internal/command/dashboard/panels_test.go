@@ -0,0 +1,62 @@
+package dashboard
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanelIDs(t *testing.T) {
+	ids := panelIDs([]grafanaPanel{
+		{ID: 2, Title: "HTTP Response Times", Type: "timeseries"},
+		{ID: 3, Title: "Instances", Type: "row", Panels: []grafanaPanel{
+			{ID: 7, Title: "CPU Utilization (%)", Type: "timeseries"},
+			{ID: 9, Title: "Memory", Type: "timeseries"},
+		}},
+		{ID: 11, Title: "Memory", Type: "timeseries"},
+		{ID: 12, Title: "", Type: "text"},
+	})
+
+	assert.Equal(t, map[string]int{
+		"http-response-times": 2,
+		"cpu-utilization":     7,
+		"memory":              9,
+	}, ids)
+
+	id, err := panelID(ids, "memory")
+	require.NoError(t, err)
+	assert.Equal(t, 9, id)
+
+	_, err = panelID(ids, "disk")
+	assert.ErrorContains(t, err, "unknown panel disk, expected one of cpu-utilization, http-response-times, memory")
+}
+
+func TestPanelURL(t *testing.T) {
+	to := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	from := to.Add(-time.Hour)
+
+	u, err := url.Parse(panelURL(42, "my-app", 8, from, to, false, 1000, 500))
+	require.NoError(t, err)
+	assert.Equal(t, "/d-solo/fly-app/fly-app", u.Path)
+	assert.Equal(t, "42", u.Query().Get("orgId"))
+	assert.Equal(t, "my-app", u.Query().Get("var-app"))
+	assert.Equal(t, "8", u.Query().Get("panelId"))
+	assert.Equal(t, "1714561200000", u.Query().Get("from"))
+	assert.Equal(t, "1714564800000", u.Query().Get("to"))
+	assert.Empty(t, u.Query().Get("width"))
+
+	u, err = url.Parse(panelURL(42, "my-app", 10, from, to, true, 800, 400))
+	require.NoError(t, err)
+	assert.Equal(t, "/render/d-solo/fly-app/fly-app", u.Path)
+	assert.Equal(t, "800", u.Query().Get("width"))
+	assert.Equal(t, "400", u.Query().Get("height"))
+}
+
+func TestPNGPath(t *testing.T) {
+	assert.Equal(t, "out.png", pngPath("out.png", "cpu", false))
+	assert.Equal(t, "out-cpu.png", pngPath("out.png", "cpu", true))
+	assert.Equal(t, "shots/out-memory", pngPath("shots/out", "memory", true))
+}
@@ -2,10 +2,14 @@ package dashboard
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
@@ -35,7 +39,14 @@ func New() *cobra.Command {
 func newDashboardMetrics() *cobra.Command {
 	const (
 		short = "Open web browser on Fly Web UI for this app's metrics"
-		long  = `Open web browser on Fly Web UI for this application's metrics`
+		long  = `Open web browser on Fly Web UI for this application's metrics.
+
+With --panel, print links to panels of the app's managed Grafana dashboard over
+a time range instead, and with --png render them as images, for example to
+attach them to incident reports. Panels are named after their titles in kebab
+case, and an unknown name lists the panels of the dashboard:
+
+  fly dashboard metrics --panel <name> --panel <name> --since 6h --png incident.png`
 	)
 	cmd := command.New("metrics", short, long, runDashboardMetrics,
 		command.RequireSession,
@@ -44,6 +55,33 @@ func newDashboardMetrics() *cobra.Command {
 	flag.Add(cmd,
 		flag.App(),
 		flag.AppConfig(),
+		flag.StringSlice{
+			Name:        "panel",
+			Description: "Panels of the app dashboard to link to or render, by title in kebab case such as http-response-times",
+		},
+		flag.Duration{
+			Name:        "since",
+			Description: "The time range of the panels, ending now or at --until",
+			Default:     time.Hour,
+		},
+		flag.String{
+			Name:        "until",
+			Description: "The end of the time range of the panels, as an RFC 3339 timestamp",
+		},
+		flag.String{
+			Name:        "png",
+			Description: "Render the panels as PNG images to this path, suffixed with the panel name when rendering several",
+		},
+		flag.Int{
+			Name:        "width",
+			Description: "The width of rendered panels, in pixels",
+			Default:     1000,
+		},
+		flag.Int{
+			Name:        "height",
+			Description: "The height of rendered panels, in pixels",
+			Default:     500,
+		},
 	)
 	return cmd
 }
@@ -55,7 +93,67 @@ func runDashboard(ctx context.Context) error {
 
 func runDashboardMetrics(ctx context.Context) error {
 	appName := appconfig.NameFromContext(ctx)
-	return runDashboardOpen(ctx, "https://fly.io/apps/"+appName+"/metrics")
+
+	panels := flag.GetStringSlice(ctx, "panel")
+	if len(panels) == 0 {
+		if flag.GetString(ctx, "png") != "" {
+			return errors.New("--png requires at least one --panel")
+		}
+		return runDashboardOpen(ctx, "https://fly.io/apps/"+appName+"/metrics")
+	}
+
+	to := time.Now()
+	if until := flag.GetString(ctx, "until"); until != "" {
+		var err error
+		if to, err = time.Parse(time.RFC3339, until); err != nil {
+			return fmt.Errorf("invalid --until %s, expected an RFC 3339 timestamp", until)
+		}
+	}
+	from := to.Add(-flag.GetDuration(ctx, "since"))
+
+	_ = `# @genqlient
+	query GetAppGrafanaOrg($appName: String!) {
+		app(name: $appName) {
+			organization {
+				internalNumericId
+			}
+		}
+	}
+	`
+
+	resp, err := gql.GetAppGrafanaOrg(ctx, fly.ClientFromContext(ctx).GenqClient, appName)
+	if err != nil {
+		return err
+	}
+	orgID := resp.App.Organization.InternalNumericId
+
+	var (
+		io     = iostreams.FromContext(ctx)
+		png    = flag.GetString(ctx, "png")
+		width  = flag.GetInt(ctx, "width")
+		height = flag.GetInt(ctx, "height")
+	)
+	ids, err := dashboardPanels(ctx)
+	if err != nil {
+		return err
+	}
+	for _, panel := range panels {
+		id, err := panelID(ids, panel)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(io.Out, "%s: %s\n", panel, panelURL(orgID, appName, id, from, to, false, width, height))
+
+		if png == "" {
+			continue
+		}
+		path := pngPath(png, panel, len(panels) > 1)
+		if err := renderPanel(ctx, panelURL(orgID, appName, id, from, to, true, width, height), path); err != nil {
+			return fmt.Errorf("failed rendering the %s panel: %w", panel, err)
+		}
+		fmt.Fprintf(io.Out, "Wrote %s\n", path)
+	}
+	return nil
 }
 
 func runDashboardOpen(ctx context.Context, url string) error {
@@ -0,0 +1,131 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/spf13/pflag"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+// preparedFlags are the flags consumed while preparing the command, before the
+// app, and so its defaults, are known. They can't have defaults.
+var preparedFlags = map[string]bool{
+	flagnames.App:               true,
+	flagnames.AppConfigFilePath: true,
+	flagnames.AccessToken:       true,
+	flagnames.Debug:             true,
+	flagnames.LogLevel:          true,
+	flagnames.LogFile:           true,
+	flagnames.Quiet:             true,
+	flagnames.MaxRetries:        true,
+	flagnames.RequestTimeout:    true,
+}
+
+// applyAppDefaults sets the flags configured as defaults for the running
+// command and app, first those the user set via fly settings defaults, then
+// those of the [cli] section of the app's fly.toml. Flags given explicitly
+// take precedence over both. The config of ctx is updated from the flags
+// afterwards, so defaults such as --json or --verbose take effect.
+func applyAppDefaults(ctx context.Context) (context.Context, error) {
+	appName := appconfig.NameFromContext(ctx)
+	if appName == "" {
+		return ctx, nil
+	}
+
+	cmd := FromContext(ctx)
+	name := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	fs := flag.FromContext(ctx)
+
+	path := state.ConfigFile(ctx)
+	if defaults, err := config.ReadAppDefaults(path); err == nil {
+		if flags, ok := defaults[appName][name]; ok {
+			if err := setDefaultFlags(fs, flags); err != nil {
+				return ctx, fmt.Errorf("invalid default flags for fly %s of %s in %s: %w", name, appName, path, err)
+			}
+		}
+	}
+
+	// The [cli] section of a fly.toml only applies to the app it configures,
+	// not to another one selected with --app.
+	if cfg := appconfig.ConfigFromContext(ctx); cfg != nil && (cfg.AppName == "" || cfg.AppName == appName) {
+		if flags, ok := cfg.CLI[name]; ok {
+			if err := setDefaultFlags(fs, flags); err != nil {
+				return ctx, fmt.Errorf("invalid default flags for fly %s in the [cli] section of %s: %w", name, cfg.ConfigFilePath(), err)
+			}
+		}
+	}
+
+	config.FromContext(ctx).ApplyFlags(fs)
+	return applyOutputFlags(ctx)
+}
+
+// setDefaultFlags parses flags, such as "--strategy bluegreen -y", and sets
+// each one that wasn't already given on fs.
+func setDefaultFlags(fs *pflag.FlagSet, flags string) error {
+	args, err := shlex.Split(flags)
+	if err != nil {
+		return err
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *pflag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	for len(args) > 0 {
+		arg := args[0]
+		args = args[1:]
+
+		var (
+			f     *pflag.Flag
+			value string
+			found bool
+		)
+
+		switch {
+		case strings.HasPrefix(arg, "--") && len(arg) > 2:
+			name, v, hasValue := strings.Cut(arg[2:], "=")
+			f, value, found = fs.Lookup(name), v, hasValue
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			name, v, hasValue := strings.Cut(arg[1:], "=")
+			f, value, found = fs.ShorthandLookup(name), v, hasValue
+		default:
+			return fmt.Errorf("unexpected argument %q, only flags are allowed", arg)
+		}
+
+		if f == nil {
+			return fmt.Errorf("unknown flag %s", arg)
+		}
+		if preparedFlags[f.Name] {
+			return fmt.Errorf("flag %s can't have a default, give it on the command line or set its environment variable", arg)
+		}
+
+		if !found {
+			switch {
+			case f.Value.Type() == "bool":
+				value = "true"
+			case len(args) == 0:
+				return fmt.Errorf("flag %s needs a value", arg)
+			default:
+				value, args = args[0], args[1:]
+			}
+		}
+
+		if explicit[f.Name] {
+			continue
+		}
+		if err := fs.Set(f.Name, value); err != nil {
+			return fmt.Errorf("invalid value %q for flag %s: %w", value, arg, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDefaultsFlagSet() *pflag.FlagSet {
+	fs := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	fs.String("strategy", "", "")
+	fs.BoolP("yes", "y", false, "")
+	fs.Bool("ha", true, "")
+	fs.StringSliceP("env", "e", nil, "")
+	fs.String("config", "", "")
+	fs.BoolP("quiet", "q", false, "")
+	return fs
+}
+
+func TestSetDefaultFlags(t *testing.T) {
+	fs := newDefaultsFlagSet()
+
+	err := setDefaultFlags(fs, `--strategy bluegreen -y --ha=false -e A=1 --env "B=two words"`)
+	require.NoError(t, err)
+
+	strategy, _ := fs.GetString("strategy")
+	assert.Equal(t, "bluegreen", strategy)
+	yes, _ := fs.GetBool("yes")
+	assert.True(t, yes)
+	ha, _ := fs.GetBool("ha")
+	assert.False(t, ha)
+	env, _ := fs.GetStringSlice("env")
+	assert.Equal(t, []string{"A=1", "B=two words"}, env)
+	assert.True(t, fs.Changed("strategy"))
+}
+
+func TestSetDefaultFlagsKeepsExplicitFlags(t *testing.T) {
+	fs := newDefaultsFlagSet()
+	require.NoError(t, fs.Parse([]string{"--strategy", "immediate"}))
+
+	require.NoError(t, setDefaultFlags(fs, "--strategy bluegreen -y"))
+
+	strategy, _ := fs.GetString("strategy")
+	assert.Equal(t, "immediate", strategy)
+	yes, _ := fs.GetBool("yes")
+	assert.True(t, yes)
+}
+
+func TestSetDefaultFlagsErrors(t *testing.T) {
+	for _, flags := range []string{"--nope", "--strategy", "deploy", "--ha=maybe", `--strategy "unterminated`, "--config fly.staging.toml", "-q"} {
+		assert.Error(t, setDefaultFlags(newDefaultsFlagSet(), flags), flags)
+	}
+}
+
+func TestSetDefaultFlagsLayers(t *testing.T) {
+	fs := newDefaultsFlagSet()
+	require.NoError(t, fs.Parse([]string{"-y"}))
+
+	// The defaults of fly settings defaults are set before those of [cli].
+	require.NoError(t, setDefaultFlags(fs, "--strategy canary"))
+	require.NoError(t, setDefaultFlags(fs, "--strategy bluegreen --ha=false"))
+
+	strategy, _ := fs.GetString("strategy")
+	assert.Equal(t, "canary", strategy)
+	ha, _ := fs.GetBool("ha")
+	assert.False(t, ha)
+}
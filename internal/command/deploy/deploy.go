@@ -7,7 +7,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/logrusorgru/aurora"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
@@ -35,6 +35,7 @@ var CommonFlags = flag.Set{
 	flag.Now(),
 	flag.RemoteOnly(false),
 	flag.LocalOnly(),
+	flag.DockerHost(),
 	flag.Push(),
 	flag.Wireguard(),
 	flag.HttpFailover(),
@@ -46,9 +47,33 @@ var CommonFlags = flag.Set{
 	flag.BuildArg(),
 	flag.BuildSecret(),
 	flag.BuildTarget(),
+	flag.BakeTarget(),
+	flag.BakeFile(),
 	flag.NoCache(),
+	flag.CacheFrom(),
+	flag.CacheTo(),
+	flag.SBOM(),
+	flag.Reproducible(),
+	flag.SSH(),
 	flag.Nixpacks(),
 	flag.BuildOnly(),
+	flag.Bool{
+		Name:        "push-only",
+		Description: "Build and push the image to the registry without deploying it, and print its reference",
+	},
+	flag.Int{
+		Name:        "build-concurrency",
+		Description: "Number of images to build at once for apps with process groups which have builds of their own",
+		Default:     1,
+	},
+	flag.String{
+		Name:        "builder-app",
+		Description: "Build on this remote builder app instead of the one of the organization, overriding the one pinned with `fly builders use`",
+	},
+	flag.String{
+		Name:        "builder-size",
+		Description: "Resize the remote builder to this machine size before building, such as performance-4x, for builds which run out of memory. The builder keeps that size for later builds",
+	},
 	flag.BpDockerHost(),
 	flag.BpVolume(),
 	flag.Yes(),
@@ -58,6 +83,10 @@ var CommonFlags = flag.Set{
 		Shorthand:   "e",
 		Description: "Set of environment variables in the form of NAME=VALUE pairs. Can be specified multiple times.",
 	},
+	flag.StringArray{
+		Name:        "env-file",
+		Description: "Path to a dotenv file of environment variables for the machines of this deploy only, recorded in its release. --env overrides them. Can be specified multiple times.",
+	},
 	flag.String{
 		Name:        "wait-timeout",
 		Description: "Time duration to wait for individual machines to transition states and become healthy.",
@@ -92,6 +121,14 @@ var CommonFlags = flag.Set{
 		Description: "Perform smoke checks during deployment",
 		Default:     true,
 	},
+	flag.StringArray{
+		Name:        "notify-url",
+		Description: "Post the start and outcome of the deployment to this Slack, Discord or generic webhook URL, in addition to those of [deploy.notifications] in fly.toml. Can be specified multiple times.",
+	},
+	flag.String{
+		Name:        "smoke-test",
+		Description: "Test each updated machine before moving on: a URL requested from its private address, such as http://:8080/healthz, or a command run on it. Overrides smoke_test in fly.toml",
+	},
 	flag.Bool{
 		Name:        "dns-checks",
 		Description: "Perform DNS checks during deployment",
@@ -197,6 +234,14 @@ func New() (cmd *cobra.Command) {
 			Description: "Do not run the release command during deployment.",
 			Default:     false,
 		},
+		flag.Bool{
+			Name:        "plan",
+			Description: "Show the changes the deployment would make to machines, without building or deploying",
+		},
+		flag.Bool{
+			Name:        "with-cost",
+			Description: "Include the estimated monthly cost change in the deployment plan, requires --plan",
+		},
 	)
 
 	return
@@ -206,6 +251,10 @@ func run(ctx context.Context) error {
 	io := iostreams.FromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 
+	if flag.GetBool(ctx, "with-cost") && !flag.GetBool(ctx, "plan") {
+		return fmt.Errorf("--with-cost requires --plan")
+	}
+
 	hook := ctrlc.Hook(func() {
 		metrics.FlushMetrics(ctx)
 	})
@@ -259,6 +308,10 @@ func run(ctx context.Context) error {
 	span.SetAttributes(attribute.StringSlice("gpu.kinds", gpuKinds))
 	span.SetAttributes(attribute.StringSlice("cpu.kinds", cpuKinds))
 
+	if flag.GetBool(ctx, "plan") {
+		return runDeployPlan(ctx, appConfig)
+	}
+
 	return DeployWithConfig(ctx, appConfig, flag.GetYes(ctx))
 }
 
@@ -275,20 +328,40 @@ func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, forceYes
 
 	for env := range appConfig.Env {
 		if containsCommonSecretSubstring(env) {
-			warning := fmt.Sprintf("%s %s may be a potentially sensitive environment variable. Consider setting it as a secret, and removing it from the [env] section: https://fly.io/docs/reference/secrets/\n", aurora.Yellow("WARN"), env)
+			warning := fmt.Sprintf("%s %s may be a potentially sensitive environment variable. Consider setting it as a secret, and removing it from the [env] section: https://fly.io/docs/reference/secrets/\n", iostreams.Aurora().Yellow("WARN"), env)
 			fmt.Fprintln(io.ErrOut, warning)
 		}
 	}
 
+	pushOnly := flag.GetBool(ctx, "push-only")
+	if pushOnly && flag.GetBuildOnly(ctx) {
+		return fmt.Errorf("--push-only and --build-only are mutually exclusive")
+	}
+
+	notifier, err := newDeployNotifier(ctx, appConfig, appCompact)
+	if err != nil {
+		return err
+	}
+	if !flag.GetBuildOnly(ctx) && !pushOnly {
+		notifier.start(ctx)
+		defer func() {
+			notifier.finish(ctx, err)
+		}()
+	}
+
+	if ctx, err = withRemoteBuilder(ctx, appCompact); err != nil {
+		return err
+	}
+
 	httpFailover := flag.GetHTTPFailover(ctx)
 	usingWireguard := flag.GetWireguard(ctx)
 
 	// Fetch an image ref or build from source to get the final image reference to deploy
-	img, err := determineImage(ctx, appConfig, usingWireguard)
+	img, groupImages, err := determineImages(ctx, appConfig, usingWireguard)
 	if err != nil && usingWireguard && httpFailover {
 		span.SetAttributes(attribute.String("builder.failover_error", err.Error()))
 		span.AddEvent("using http failover")
-		img, err = determineImage(ctx, appConfig, false)
+		img, groupImages, err = determineImages(ctx, appConfig, false)
 	}
 
 	if err != nil {
@@ -298,9 +371,15 @@ func DeployWithConfig(ctx context.Context, appConfig *appconfig.Config, forceYes
 	if flag.GetBuildOnly(ctx) {
 		return nil
 	}
+	if pushOnly {
+		return PrintPushedImages(iostreams.FromContext(ctx).Out, config.FromContext(ctx).JSONOutput, img, groupImages)
+	}
+
+	tagSentryRelease(ctx, appName, appConfig, img)
+	notifier.setImage(img.Tag)
 
 	fmt.Fprintf(io.Out, "\nWatch your deployment at https://fly.io/apps/%s/monitoring\n\n", appName)
-	if err := deployToMachines(ctx, appConfig, appCompact, img); err != nil {
+	if err := deployToMachines(ctx, appConfig, appCompact, img, groupImages, notifier); err != nil {
 		return err
 	}
 
@@ -344,6 +423,8 @@ func deployToMachines(
 	cfg *appconfig.Config,
 	app *fly.AppCompact,
 	img *imgsrc.DeploymentImage,
+	groupImages map[string]*imgsrc.DeploymentImage,
+	notifier *deployNotifier,
 ) (err error) {
 	// It's important to push appConfig into context because MachineDeployment will fetch it from there
 	ctx = appconfig.WithConfig(ctx, cfg)
@@ -424,6 +505,7 @@ func deployToMachines(
 	md, err := NewMachineDeployment(ctx, MachineDeploymentArgs{
 		AppCompact:            app,
 		DeploymentImage:       img.Tag,
+		ProcessGroupImages:    lo.MapValues(groupImages, func(img *imgsrc.DeploymentImage, _ string) string { return img.Tag }),
 		Strategy:              flag.GetString(ctx, "strategy"),
 		EnvFromFlags:          flag.GetStringArray(ctx, "env"),
 		PrimaryRegionFlag:     cfg.PrimaryRegion,
@@ -448,11 +530,13 @@ func deployToMachines(
 		MaxConcurrent:         maxConcurrent,
 		VolumeInitialSize:     flag.GetInt(ctx, "volume-initial-size"),
 		ProcessGroups:         processGroups,
+		SmokeTest:             flag.GetString(ctx, "smoke-test"),
 	})
 	if err != nil {
 		sentry.CaptureExceptionWithAppInfo(ctx, err, "deploy", app)
 		return err
 	}
+	notifier.setRelease(md.Release())
 
 	err = md.DeployMachinesApp(ctx)
 	if err != nil {
@@ -477,6 +561,20 @@ func determineAppConfig(ctx context.Context) (cfg *appconfig.Config, err error)
 		}
 	}
 
+	// Variables of env files aren't written to fly.toml, so the next deploy
+	// without them drops them.
+	if files := flag.GetStringArray(ctx, "env-file"); len(files) > 0 {
+		fileEnv, err := readEnvFiles(files)
+		if err != nil {
+			tracing.RecordError(span, err, "read env files")
+			return nil, err
+		}
+		cfg.SetEnvVariables(fileEnv)
+		if !config.FromContext(ctx).JSONOutput {
+			fmt.Fprintf(io.Out, "Setting %d environment variables from %s for this deploy\n", len(fileEnv), strings.Join(files, ", "))
+		}
+	}
+
 	if env := flag.GetStringArray(ctx, "env"); len(env) > 0 {
 		parsedEnv, err := cmdutil.ParseKVStringsToMap(env)
 		if err != nil {
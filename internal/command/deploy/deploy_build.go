@@ -5,21 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/dustin/go-humanize"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/build/imgsrc"
 	"github.com/superfly/flyctl/internal/cmdutil"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/env"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/metrics"
+	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/internal/state"
 	"github.com/superfly/flyctl/internal/tracing"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/terminal"
 	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/sync/errgroup"
 )
 
 func multipleDockerfile(ctx context.Context, appConfig *appconfig.Config) error {
@@ -46,18 +50,182 @@ func multipleDockerfile(ctx context.Context, appConfig *appconfig.Config) error
 	return nil
 }
 
+// determineImages builds the image of the app and the images of the process
+// groups with builds of their own, up to --build-concurrency at once. The
+// images of the process groups are keyed by group name.
+func determineImages(ctx context.Context, appConfig *appconfig.Config, useWG bool) (img *imgsrc.DeploymentImage, groupImages map[string]*imgsrc.DeploymentImage, err error) {
+	groups := appConfig.ProcessGroupBuilds()
+	if len(groups) == 0 || flag.GetString(ctx, "image") != "" {
+		img, err = determineImage(ctx, appConfig, useWG)
+		return
+	}
+
+	concurrency := flag.GetInt(ctx, "build-concurrency")
+	if concurrency > 1 {
+		// Builds would otherwise race to create the remote builder.
+		if err = newImageResolver(ctx, appConfig, useWG).EnsureRemoteBuilder(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var mu sync.Mutex
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(concurrency, 1))
+	groupImages = make(map[string]*imgsrc.DeploymentImage, len(groups))
+
+	eg.Go(func() (err error) {
+		img, err = determineImage(buildOutputContext(ctx, concurrency, "app"), appConfig, useWG)
+		return
+	})
+	for _, group := range groups {
+		group := group
+		eg.Go(func() error {
+			groupImg, err := determineGroupImage(buildOutputContext(ctx, concurrency, group), appConfig.ForProcessGroupBuild(group), useWG, group, nil)
+			if err != nil {
+				return fmt.Errorf("failed to build the image of process group %s: %w", group, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			groupImages[group] = groupImg
+			return nil
+		})
+	}
+
+	if err = eg.Wait(); err != nil {
+		return nil, nil, err
+	}
+	return img, groupImages, nil
+}
+
+// withRemoteBuilder returns a copy of ctx whose builds run on the remote
+// builder of --builder-app and --builder-size, falling back to the one
+// `fly builders use` pinned for the organization of app.
+func withRemoteBuilder(ctx context.Context, app *fly.AppCompact) (context.Context, error) {
+	rb := imgsrc.RemoteBuilder{
+		App:  flag.GetString(ctx, "builder-app"),
+		Size: flag.GetString(ctx, "builder-size"),
+	}
+
+	if app.Organization != nil {
+		pins, err := config.ReadRemoteBuilders(state.ConfigFile(ctx))
+		if err != nil {
+			return nil, err
+		}
+		pin := pins[app.Organization.Slug]
+		if rb.App == "" {
+			rb.App = pin.App
+		}
+		if rb.Size == "" {
+			rb.Size = pin.Size
+		}
+	}
+
+	if rb.Size != "" {
+		if err := imgsrc.ValidateRemoteBuilderSize(rb.Size); err != nil {
+			return nil, fmt.Errorf("invalid builder size: %w", err)
+		}
+	}
+
+	// The builder keeps its size, so resizing the one the organization
+	// shares changes it for every build, not just this one.
+	if rb.App == "" && flag.GetString(ctx, "builder-size") != "" && !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("--builder-size resizes the remote builder every build of the organization shares to %s, and it keeps that size. Continue?", rb.Size)
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err == nil:
+			if !confirmed {
+				return nil, errors.New("not resizing the shared builder, use --builder-app to build on a builder of your own")
+			}
+		case prompt.IsNonInteractive(err):
+			return nil, prompt.NonInteractiveError("yes flag must be specified to resize the shared builder when not running interactively")
+		default:
+			return nil, err
+		}
+	}
+
+	return imgsrc.WithRemoteBuilder(ctx, rb), nil
+}
+
+// buildOutputContext returns ctx for the build of name. When builds run
+// concurrently, their output lines are prefixed with name so they can be told
+// apart.
+func buildOutputContext(ctx context.Context, concurrency int, name string) context.Context {
+	if concurrency <= 1 {
+		return ctx
+	}
+	return iostreams.NewContext(ctx, iostreams.FromContext(ctx).WithPrefix("["+name+"] "))
+}
+
+func newImageResolver(ctx context.Context, appConfig *appconfig.Config, useWG bool) *imgsrc.Resolver {
+	daemonType := imgsrc.NewDockerDaemonType(!flag.GetRemoteOnly(ctx), !flag.GetLocalOnly(ctx), env.IsCI(), flag.GetBool(ctx, "nixpacks") || appConfig.UsesNixpacks())
+	return imgsrc.NewResolver(daemonType, fly.ClientFromContext(ctx), appConfig.AppName, iostreams.FromContext(ctx), useWG, flag.GetDockerHost(ctx))
+}
+
+// ExportImage builds the image of the app the way deploy would and exports
+// it to output instead of pushing it.
+func ExportImage(ctx context.Context, appConfig *appconfig.Config, output *imgsrc.ExportOutput) (*imgsrc.DeploymentImage, error) {
+	ctx, err := withAppRemoteBuilder(ctx, appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	useWG := flag.GetWireguard(ctx)
+	img, err := determineGroupImage(ctx, appConfig, useWG, "", output)
+	if err != nil && useWG && flag.GetHTTPFailover(ctx) {
+		img, err = determineGroupImage(ctx, appConfig, false, "", output)
+	}
+	return img, err
+}
+
+// PushImages builds the images of the app the way deploy would, or takes
+// the one of --image, and pushes them to the registry without deploying.
+func PushImages(ctx context.Context, appConfig *appconfig.Config) (img *imgsrc.DeploymentImage, groupImages map[string]*imgsrc.DeploymentImage, err error) {
+	if ctx, err = withAppRemoteBuilder(ctx, appConfig); err != nil {
+		return nil, nil, err
+	}
+
+	useWG := flag.GetWireguard(ctx)
+	img, groupImages, err = determineImages(ctx, appConfig, useWG)
+	if err != nil && useWG && flag.GetHTTPFailover(ctx) {
+		img, groupImages, err = determineImages(ctx, appConfig, false)
+	}
+	return
+}
+
+// withAppRemoteBuilder is withRemoteBuilder for the app of appConfig.
+func withAppRemoteBuilder(ctx context.Context, appConfig *appconfig.Config) (context.Context, error) {
+	app, err := fly.ClientFromContext(ctx).GetAppCompact(ctx, appConfig.AppName)
+	if err != nil {
+		return nil, err
+	}
+	return withRemoteBuilder(ctx, app)
+}
+
 // determineImage picks the deployment strategy, builds the image and returns a
 // DeploymentImage struct
 func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool) (img *imgsrc.DeploymentImage, err error) {
+	return determineGroupImage(ctx, appConfig, useWG, "", nil)
+}
+
+// determineGroupImage is determineImage for the image of a process group with
+// a build of its own, or of the app when group is empty. The image is
+// exported to output instead of being pushed when it's set.
+func determineGroupImage(ctx context.Context, appConfig *appconfig.Config, useWG bool, group string, output *imgsrc.ExportOutput) (img *imgsrc.DeploymentImage, err error) {
 	ctx, span := tracing.GetTracer().Start(ctx, "determine_image")
 	defer span.End()
 
-	span.SetAttributes(attribute.Bool("builder.using_wireguard", useWG))
+	span.SetAttributes(
+		attribute.Bool("builder.using_wireguard", useWG),
+		attribute.String("process_group", group),
+	)
 
-	tb := render.NewTextBlock(ctx, "Building image")
-	daemonType := imgsrc.NewDockerDaemonType(!flag.GetRemoteOnly(ctx), !flag.GetLocalOnly(ctx), env.IsCI(), flag.GetBool(ctx, "nixpacks"))
+	title := "Building image"
+	if group != "" {
+		title = fmt.Sprintf("Building image of process group %s", group)
+	}
+	tb := render.NewTextBlock(ctx, title)
+	daemonType := imgsrc.NewDockerDaemonType(!flag.GetRemoteOnly(ctx), !flag.GetLocalOnly(ctx), env.IsCI(), flag.GetBool(ctx, "nixpacks") || appConfig.UsesNixpacks())
 
-	client := fly.ClientFromContext(ctx)
 	io := iostreams.FromContext(ctx)
 
 	span.SetAttributes(attribute.String("daemon_type", daemonType.String()))
@@ -67,7 +235,7 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 		terminal.Warnf("%s\n", err.Error())
 	}
 
-	resolver := imgsrc.NewResolver(daemonType, client, appConfig.AppName, io, useWG)
+	resolver := newImageResolver(ctx, appConfig, useWG)
 
 	var imageRef string
 	if imageRef, err = fetchImageRef(ctx, appConfig); err != nil {
@@ -75,6 +243,10 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 		return
 	}
 
+	if output != nil && imageRef != "" {
+		return nil, fmt.Errorf("--output exports images built from source, not pre-built image %s", imageRef)
+	}
+
 	// we're using a pre-built Docker image
 	if imageRef != "" {
 		opts := imgsrc.RefOptions{
@@ -82,7 +254,7 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 			WorkingDir: state.WorkingDirectory(ctx),
 			Publish:    !flag.GetBuildOnly(ctx),
 			ImageRef:   imageRef,
-			ImageLabel: flag.GetString(ctx, "image-label"),
+			ImageLabel: imageLabel(ctx, group),
 		}
 
 		span.SetAttributes(opts.ToSpanAttributes()...)
@@ -103,28 +275,56 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 
 	span.AddEvent("building from source")
 
+	builder := build.Builder
+	if appConfig.UsesNixpacks() {
+		builder = ""
+	}
+
 	// We're building from source
 	opts := imgsrc.ImageOptions{
 		AppName:              appConfig.AppName,
 		WorkingDir:           state.WorkingDirectory(ctx),
 		Publish:              flag.GetBool(ctx, "push") || !flag.GetBuildOnly(ctx),
-		ImageLabel:           flag.GetString(ctx, "image-label"),
+		ImageLabel:           imageLabel(ctx, group),
 		NoCache:              flag.GetBool(ctx, "no-cache"),
+		CacheFrom:            flag.GetStringArray(ctx, "cache-from"),
+		CacheTo:              flag.GetStringArray(ctx, "cache-to"),
+		SBOM:                 flag.GetBool(ctx, "sbom"),
+		SSH:                  flag.GetStringArray(ctx, "ssh"),
 		BuiltIn:              build.Builtin,
 		BuiltInSettings:      build.Settings,
-		Builder:              build.Builder,
+		Builder:              builder,
 		Buildpacks:           build.Buildpacks,
 		BuildpacksDockerHost: flag.GetString(ctx, flag.BuildpacksDockerHost),
 		BuildpacksVolumes:    flag.GetStringSlice(ctx, flag.BuildpacksVolume),
+		Output:               output,
+	}
+	if output != nil {
+		opts.Publish = false
+	}
+
+	if err = imgsrc.ValidateSSH(opts.SSH); err != nil {
+		tracing.RecordError(span, err, "invalid ssh option")
+		return
+	}
+
+	if flag.GetBool(ctx, "reproducible") {
+		var epoch int64
+		if epoch, err = imgsrc.SourceDateEpoch(ctx, opts.WorkingDir); err != nil {
+			tracing.RecordError(span, err, "failed to determine SOURCE_DATE_EPOCH")
+			return
+		}
+		opts.SourceDateEpoch = &epoch
 	}
 
 	if appConfig.Experimental != nil {
 		opts.UseOverlaybd = appConfig.Experimental.LazyLoadImages
 	}
 
-	// flyctl supports key=value form while Docker supports id=key,src=/path/to/secret form.
+	// flyctl supports key=value form besides Docker's id=key,src=/path/to/secret
+	// and id=key,env=VARIABLE forms.
 	// https://docs.docker.com/engine/reference/commandline/buildx_build/#secret
-	cliBuildSecrets, err := cmdutil.ParseKVStringsToMap(flag.GetStringArray(ctx, "build-secret"))
+	cliBuildSecrets, err := imgsrc.ParseBuildSecrets(flag.GetStringArray(ctx, "build-secret"))
 	if err != nil {
 		tracing.RecordError(span, err, "failed to generate cliBuildSecrets")
 		return
@@ -174,6 +374,11 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 		opts.Target = target
 	}
 
+	if err = applyBakeTarget(ctx, appConfig, &opts); err != nil {
+		tracing.RecordError(span, err, "failed to apply bake target")
+		return
+	}
+
 	span.SetAttributes(opts.ToSpanAttributes()...)
 
 	// finally, build the image
@@ -205,6 +410,16 @@ func determineImage(ctx context.Context, appConfig *appconfig.Config, useWG bool
 	return
 }
 
+// imageLabel returns the --image-label of the image of the process group,
+// suffixed with the group so that its tag doesn't clash with the app's.
+func imageLabel(ctx context.Context, group string) string {
+	label := flag.GetString(ctx, "image-label")
+	if label != "" && group != "" {
+		label += "-" + group
+	}
+	return label
+}
+
 // resolveDockerfilePath returns the absolute path to the Dockerfile
 // if one was specified in the app config or a command line argument
 func resolveDockerfilePath(ctx context.Context, appConfig *appconfig.Config) (path string, err error) {
@@ -241,6 +456,36 @@ func resolveIgnorefilePath(ctx context.Context, appConfig *appconfig.Config) (pa
 	return
 }
 
+// applyBakeTarget sets the build options from the docker buildx bake target
+// specified in the app config or a command line argument, if any. A bake
+// file given without a target builds its default target.
+func applyBakeTarget(ctx context.Context, appConfig *appconfig.Config, opts *imgsrc.ImageOptions) error {
+	name := appConfig.BakeTarget()
+	if name == "" {
+		name = flag.GetString(ctx, "bake-target")
+	}
+
+	var path string
+	if path = appConfig.BakeFile(); path != "" {
+		path = filepath.Join(filepath.Dir(appConfig.ConfigFilePath()), path)
+	} else if path = flag.GetString(ctx, "bake-file"); path == "" {
+		if name == "" {
+			return nil
+		}
+		path = imgsrc.ResolveBakeFile(state.WorkingDirectory(ctx))
+	}
+	if path == "" {
+		return fmt.Errorf("no docker-bake.json or docker-bake.hcl found for bake target %s, set its path with bake_file in fly.toml or --bake-file", name)
+	}
+
+	target, err := imgsrc.LoadBakeTarget(ctx, path, name)
+	if err != nil {
+		return err
+	}
+	target.Apply(opts)
+	return nil
+}
+
 func mergeBuildArgs(ctx context.Context, args map[string]string) (map[string]string, error) {
 	if args == nil {
 		args = make(map[string]string)
@@ -2,12 +2,20 @@ package deploy
 
 import (
 	"context"
+	"fmt"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 )
 
@@ -32,3 +40,87 @@ func TestMultipleDockerfile(t *testing.T) {
 	)
 	assert.Error(t, err)
 }
+
+func TestApplyBakeTarget(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake docker is a shell script")
+	}
+
+	// A docker printing the web target, which is also the default one.
+	bin := t.TempDir()
+	docker := `#!/bin/sh
+case "$6" in
+  ""|web) echo '{"target": {"web": {"dockerfile": "web.Dockerfile", "args": {"MODE": "web"}}}}' ;;
+  *) echo "ERROR: failed to find target $6" >&2; exit 1 ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "docker"), []byte(docker), 0o755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bake.hcl"), nil, 0o644))
+
+	cfg := &appconfig.Config{
+		Build: &appconfig.Build{
+			BakeFile:   "bake.hcl",
+			BakeTarget: "web",
+		},
+	}
+	cfg.SetConfigFilePath(filepath.Join(dir, "fly.toml"))
+
+	fs := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	fs.String("bake-target", "", "")
+	fs.String("bake-file", "", "")
+	ctx := flag.NewContext(context.Background(), fs)
+
+	opts := imgsrc.ImageOptions{BuildArgs: map[string]string{}}
+	require.NoError(t, applyBakeTarget(ctx, cfg, &opts))
+	assert.Equal(t, dir, opts.WorkingDir)
+	assert.Equal(t, filepath.Join(dir, "web.Dockerfile"), opts.DockerfilePath)
+	assert.Equal(t, map[string]string{"MODE": "web"}, opts.BuildArgs)
+
+	cfg.Build.BakeTarget = ""
+	opts = imgsrc.ImageOptions{BuildArgs: map[string]string{}}
+	require.NoError(t, applyBakeTarget(ctx, cfg, &opts))
+	assert.Equal(t, filepath.Join(dir, "web.Dockerfile"), opts.DockerfilePath)
+
+	cfg.Build.BakeTarget = "api"
+	assert.ErrorContains(t, applyBakeTarget(ctx, cfg, &opts), "failed to find target api")
+}
+
+func TestBuildOutputContext(t *testing.T) {
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+
+	assert.Same(t, ios, iostreams.FromContext(buildOutputContext(ctx, 1, "web")))
+
+	fmt.Fprintln(iostreams.FromContext(buildOutputContext(ctx, 2, "web")).Out, "Building image")
+	assert.Equal(t, "[web] Building image\n", out.String())
+}
+
+func TestWithRemoteBuilderSharedSize(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	fs := pflag.NewFlagSet("deploy", pflag.ContinueOnError)
+	fs.String("builder-app", "", "")
+	fs.String("builder-size", "", "")
+	fs.Bool("yes", false, "")
+	ctx := flag.NewContext(iostreams.NewContext(context.Background(), ios), fs)
+	app := &fly.AppCompact{Name: "my-app"}
+
+	require.NoError(t, fs.Set("builder-size", "performance-4x"))
+	_, err := withRemoteBuilder(ctx, app)
+	assert.True(t, prompt.IsNonInteractive(err), "resizing the shared builder needs confirming")
+
+	require.NoError(t, fs.Set("builder-app", "fly-builder-mine"))
+	_, err = withRemoteBuilder(ctx, app)
+	assert.NoError(t, err)
+
+	require.NoError(t, fs.Set("builder-app", ""))
+	require.NoError(t, fs.Set("yes", "true"))
+	_, err = withRemoteBuilder(ctx, app)
+	assert.NoError(t, err)
+
+	require.NoError(t, fs.Set("builder-size", "huge"))
+	_, err = withRemoteBuilder(ctx, app)
+	assert.ErrorContains(t, err, "invalid builder size")
+}
@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// readEnvFiles reads the dotenv files paths, later files overriding the
+// variables of earlier ones.
+func readEnvFiles(paths []string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading env file: %w", err)
+		}
+		vars, err := parseEnvFile(f)
+		f.Close() // skipcq: GO-S2307
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing env file %s: %w", path, err)
+		}
+		for k, v := range vars {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+// parseEnvFile parses a dotenv file: NAME=VALUE lines, optionally prefixed
+// with export, with values in single quotes taken as is, values in double
+// quotes supporting escapes and spanning lines, and comments starting with #.
+func parseEnvFile(r io.Reader) (map[string]string, error) {
+	env := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !envNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("line %d: expected NAME=VALUE", lineNo)
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, `'`):
+			end := strings.Index(value[1:], `'`)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single quote", lineNo)
+			}
+			value = value[1 : end+1]
+		case strings.HasPrefix(value, `"`):
+			quoted := value[1:]
+			for !closesDoubleQuote(quoted) {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("line %d: unterminated double quote", lineNo)
+				}
+				lineNo++
+				quoted += "\n" + scanner.Text()
+			}
+			value = unescapeDoubleQuoted(quoted[:closingDoubleQuote(quoted)])
+		default:
+			// Unquoted values end at a comment.
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+		env[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// closingDoubleQuote returns the index of the first unescaped double quote of
+// s, or -1.
+func closingDoubleQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func closesDoubleQuote(s string) bool {
+	return closingDoubleQuote(s) >= 0
+}
+
+func unescapeDoubleQuoted(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	env, err := parseEnvFile(strings.NewReader(`
+# feature flags
+FEATURE_X=on
+export FEATURE_Y = off # not yet
+SINGLE='it''s literal \n'
+DOUBLE="line1\nline2 \"quoted\""
+MULTI="first
+second"
+EMPTY=
+URL=https://example.com/#anchor
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"FEATURE_X": "on",
+		"FEATURE_Y": "off",
+		"SINGLE":    "it",
+		"DOUBLE":    "line1\nline2 \"quoted\"",
+		"MULTI":     "first\nsecond",
+		"EMPTY":     "",
+		"URL":       "https://example.com/#anchor",
+	}, env)
+
+	for _, bad := range []string{"NOVALUE", "1X=2", `X="open`, `X='open`} {
+		_, err := parseEnvFile(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestReadEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, ".env")
+	override := filepath.Join(dir, ".env.staging")
+	require.NoError(t, os.WriteFile(base, []byte("A=1\nB=1\n"), 0o644))
+	require.NoError(t, os.WriteFile(override, []byte("B=2\n"), 0o644))
+
+	env, err := readEnvFiles([]string{base, override})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "1", "B": "2"}, env)
+
+	_, err = readEnvFiles([]string{filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+}
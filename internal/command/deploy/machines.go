@@ -11,11 +11,11 @@ import (
 
 	"github.com/Khan/genqlient/graphql"
 	"github.com/google/shlex"
-	"github.com/logrusorgru/aurora"
 	"github.com/morikuni/aec"
 	"github.com/samber/lo"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/gql"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/buildinfo"
@@ -41,11 +41,14 @@ const (
 
 type MachineDeployment interface {
 	DeployMachinesApp(context.Context) error
+	// Release returns the ID and version of the release being deployed.
+	Release() (id string, version int)
 }
 
 type MachineDeploymentArgs struct {
 	AppCompact            *fly.AppCompact
 	DeploymentImage       string
+	ProcessGroupImages    map[string]string
 	Strategy              string
 	EnvFromFlags          []string
 	PrimaryRegionFlag     string
@@ -73,6 +76,7 @@ type MachineDeploymentArgs struct {
 	VolumeInitialSize     int
 	RestartPolicy         *fly.MachineRestartPolicy
 	RestartMaxRetries     int
+	SmokeTest             string
 }
 
 type machineDeployment struct {
@@ -84,6 +88,7 @@ type machineDeployment struct {
 	app                   *fly.AppCompact
 	appConfig             *appconfig.Config
 	img                   string
+	groupImages           map[string]string
 	machineSet            machine.MachineSet
 	releaseCommandMachine machine.MachineSet
 	volumes               map[string][]fly.Volume
@@ -113,6 +118,11 @@ type machineDeployment struct {
 	processGroups         map[string]bool
 	maxConcurrent         int
 	volumeInitialSize     int
+	smokeTest             *smokeTest
+	smokeTestTimeout      time.Duration
+	dialerOnce            sync.Once
+	dialer                agent.Dialer
+	dialerErr             error
 }
 
 func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (MachineDeployment, error) {
@@ -186,6 +196,21 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (Mach
 		leaseTimeout = DefaultLeaseTtl
 	}
 
+	smokeTestSpec, smokeTestTimeout := args.SmokeTest, DefaultSmokeTestTimeout
+	if appConfig.Deploy != nil {
+		if smokeTestSpec == "" {
+			smokeTestSpec = appConfig.Deploy.SmokeTest
+		}
+		if appConfig.Deploy.SmokeTestTimeout != nil {
+			smokeTestTimeout = appConfig.Deploy.SmokeTestTimeout.Duration
+		}
+	}
+	smokeTest, err := parseSmokeTest(smokeTestSpec)
+	if err != nil {
+		tracing.RecordError(span, err, "failed to parse smoke test")
+		return nil, err
+	}
+
 	leaseDelayBetween := (leaseTimeout - 1*time.Second) / 3
 	if waitTimeout != DefaultWaitTimeout || leaseTimeout != DefaultLeaseTtl {
 		terminal.Infof("Using wait timeout: %s lease timeout: %s delay between lease refreshes: %s\n", waitTimeout, leaseTimeout, leaseDelayBetween)
@@ -213,6 +238,7 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (Mach
 		app:                   args.AppCompact,
 		appConfig:             appConfig,
 		img:                   args.DeploymentImage,
+		groupImages:           args.ProcessGroupImages,
 		skipSmokeChecks:       args.SkipSmokeChecks,
 		skipHealthChecks:      args.SkipHealthChecks,
 		skipDNSChecks:         args.SkipDNSChecks,
@@ -234,6 +260,8 @@ func NewMachineDeployment(ctx context.Context, args MachineDeploymentArgs) (Mach
 		maxConcurrent:         maxConcurrent,
 		volumeInitialSize:     args.VolumeInitialSize,
 		processGroups:         args.ProcessGroups,
+		smokeTest:             smokeTest,
+		smokeTestTimeout:      smokeTestTimeout,
 	}
 	if err := md.setStrategy(); err != nil {
 		tracing.RecordError(span, err, "failed to set strategy")
@@ -304,7 +332,7 @@ func (md *machineDeployment) setMachinesForDeployment(ctx context.Context) error
 			return err
 		}
 		if len(activeMachines) > 0 {
-			fmt.Fprintf(md.io.ErrOut, "%s Your app doesn't have any Fly Launch machines, so we'll create one now. Learn more at \nhttps://fly.io/docs/apps/deploy/#machines-not-managed-by-fly-launch\n\n", aurora.Yellow("[WARNING]"))
+			fmt.Fprintf(md.io.ErrOut, "%s Your app doesn't have any Fly Launch machines, so we'll create one now. Learn more at \nhttps://fly.io/docs/apps/deploy/#machines-not-managed-by-fly-launch\n\n", iostreams.Aurora().Yellow("[WARNING]"))
 			md.isFirstDeploy = true
 		}
 	}
@@ -581,6 +609,10 @@ func (md *machineDeployment) createReleaseInBackend(ctx context.Context) error {
 	return nil
 }
 
+func (md *machineDeployment) Release() (string, int) {
+	return md.releaseId, md.releaseVersion
+}
+
 func (md *machineDeployment) updateReleaseInBackend(ctx context.Context, status string) error {
 	ctx, span := tracing.GetTracer().Start(ctx, "update_release_in_backend", trace.WithAttributes(
 		attribute.String("release_id", md.releaseId),
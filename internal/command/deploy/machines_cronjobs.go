@@ -0,0 +1,74 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/cron"
+	"github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/tracing"
+)
+
+// updateCronJobs moves the jobs 'fly cron' runs to the image, environment and
+// release just deployed. Jobs aren't machines of their process group, so they
+// are left out of the rest of the deployment. Running jobs are left alone, for
+// the update not to interrupt them.
+func (md *machineDeployment) updateCronJobs(ctx context.Context) error {
+	ctx, span := tracing.GetTracer().Start(ctx, "update_cron_jobs")
+	defer span.End()
+
+	machines, err := md.flapsClient.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed retrieving cron jobs: %w", err)
+	}
+
+	jobs := lo.Filter(machines, func(m *fly.Machine, _ int) bool {
+		return cron.JobName(m) != "" && m.State != fly.MachineStateDestroyed && m.State != fly.MachineStateDestroying
+	})
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	jobs, release, err := machine.AcquireLeases(ctx, jobs)
+	defer release()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range jobs {
+		name := cron.JobName(m)
+		if m.State == fly.MachineStateStarted {
+			fmt.Fprintf(md.io.ErrOut, "Cron job %s is running, move it to this release with 'fly cron update %s' once done\n", name, name)
+			continue
+		}
+
+		conf := md.cronJobConfig(m.Config)
+		if _, err := md.flapsClient.Update(ctx, fly.LaunchMachineInput{
+			ID:         m.ID,
+			Region:     m.Region,
+			Config:     conf,
+			SkipLaunch: true,
+		}, m.LeaseNonce); err != nil {
+			return fmt.Errorf("failed updating cron job %s: %w", name, err)
+		}
+		fmt.Fprintf(md.io.ErrOut, "Updated cron job %s to %s\n", md.colorize.Bold(name), conf.Image)
+	}
+	return nil
+}
+
+// cronJobConfig returns the configuration of a job for the release deployed.
+func (md *machineDeployment) cronJobConfig(conf *fly.MachineConfig) *fly.MachineConfig {
+	conf = machine.CloneConfig(conf)
+	conf.Image = md.imageFor(conf.ProcessGroup())
+	conf.Env = lo.Assign(conf.Env, md.appConfig.Env)
+	conf.Metadata = lo.Assign(conf.Metadata, map[string]string{
+		fly.MachineConfigMetadataKeyFlyReleaseId:      md.releaseId,
+		fly.MachineConfigMetadataKeyFlyReleaseVersion: strconv.Itoa(md.releaseVersion),
+		fly.MachineConfigMetadataKeyFlyctlVersion:     buildinfo.Version().String(),
+	})
+	return conf
+}
@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/buildinfo"
+)
+
+func Test_cronJobConfig(t *testing.T) {
+	md, err := stabMachineDeployment(&appconfig.Config{
+		AppName: "my-cool-app",
+		Env: map[string]string{
+			"OTHER": "new",
+		},
+	})
+	require.NoError(t, err)
+	md.img = "super/globe"
+	md.groupImages = map[string]string{"worker": "super/worker"}
+	md.releaseId = "new_release_id"
+	md.releaseVersion = 4
+
+	job := &fly.MachineConfig{
+		Image: "super/balloon",
+		Init:  fly.MachineInit{Cmd: []string{"bin/report"}},
+		Env: map[string]string{
+			"FLY_PROCESS_GROUP": "app",
+			"OTHER":             "old",
+		},
+		Restart: &fly.MachineRestart{Policy: fly.MachineRestartPolicyNo},
+		Metadata: map[string]string{
+			"fly_cron_job":        "reports",
+			"fly_cron_schedule":   "0 3 * * *",
+			"fly_process_group":   "app",
+			"fly_release_id":      "release_id",
+			"fly_release_version": "3",
+		},
+	}
+
+	conf := md.cronJobConfig(job)
+	assert.Equal(t, &fly.MachineConfig{
+		Image: "super/globe",
+		Init:  fly.MachineInit{Cmd: []string{"bin/report"}},
+		Env: map[string]string{
+			"FLY_PROCESS_GROUP": "app",
+			"OTHER":             "new",
+		},
+		Restart: &fly.MachineRestart{Policy: fly.MachineRestartPolicyNo},
+		Metadata: map[string]string{
+			"fly_cron_job":        "reports",
+			"fly_cron_schedule":   "0 3 * * *",
+			"fly_process_group":   "app",
+			"fly_release_id":      "new_release_id",
+			"fly_release_version": "4",
+			"fly_flyctl_version":  buildinfo.Version().String(),
+		},
+	}, conf)
+	assert.NotContains(t, conf.Metadata, fly.MachineConfigMetadataKeyFlyPlatformVersion, "jobs stay out of process groups")
+	assert.Equal(t, "release_id", job.Metadata["fly_release_id"], "the job's config is left untouched")
+
+	job.Metadata["fly_process_group"] = "worker"
+	assert.Equal(t, "super/worker", md.cronJobConfig(job).Image)
+}
@@ -306,7 +306,11 @@ func (md *machineDeployment) deployMachinesApp(ctx context.Context) error {
 		machineUpdateEntries = append(machineUpdateEntries, &machineUpdateEntry{leasableMachine: lm, launchInput: li})
 	}
 
-	return md.updateExistingMachines(ctx, machineUpdateEntries)
+	if err := md.updateExistingMachines(ctx, machineUpdateEntries); err != nil {
+		return err
+	}
+
+	return md.updateCronJobs(ctx)
 }
 
 type machineUpdateEntry struct {
@@ -406,6 +410,10 @@ func (md *machineDeployment) waitForMachine(ctx context.Context, e *machineUpdat
 			err = suggestChangeWaitTimeout(err, "wait-timeout")
 			return err
 		}
+
+		if err := md.runSmokeTest(ctx, lm); err != nil {
+			return err
+		}
 	}
 
 	md.warnAboutIncorrectListenAddress(ctx, lm)
@@ -839,6 +847,10 @@ func (md *machineDeployment) spawnMachineInGroup(ctx context.Context, groupName
 			return nil, err
 		}
 
+		if err := md.runSmokeTest(ctx, lm); err != nil {
+			return nil, err
+		}
+
 		statuslogger.LogfStatus(ctx,
 			statuslogger.StatusSuccess,
 			"Machine %s update finished: %s",
@@ -23,6 +23,15 @@ func (md *machineDeployment) launchInputForRestart(origMachineRaw *fly.Machine)
 	}
 }
 
+// imageFor returns the image the machines of the process group run: the
+// image of its own build if it has one, or the image of the app.
+func (md *machineDeployment) imageFor(processGroup string) string {
+	if img, ok := md.groupImages[processGroup]; ok {
+		return img
+	}
+	return md.img
+}
+
 func (md *machineDeployment) launchInputForLaunch(processGroup string, guest *fly.MachineGuest, standbyFor []string) (*fly.LaunchMachineInput, error) {
 	mConfig, err := md.appConfig.ToMachineConfig(processGroup, nil)
 	if err != nil {
@@ -34,10 +43,10 @@ func (md *machineDeployment) launchInputForLaunch(processGroup string, guest *fl
 		mConfig.Guest = guest
 	}
 
-	mConfig.Image = md.img
-	md.setMachineReleaseData(mConfig)
 	// Get the final process group and prevent empty string
 	processGroup = mConfig.ProcessGroup()
+	mConfig.Image = md.imageFor(processGroup)
+	md.setMachineReleaseData(mConfig)
 	region := md.appConfig.PrimaryRegion
 
 	if len(mConfig.Mounts) > 0 {
@@ -73,10 +82,10 @@ func (md *machineDeployment) launchInputForUpdate(origMachineRaw *fly.Machine) (
 	if err != nil {
 		return nil, err
 	}
-	mConfig.Image = md.img
-	md.setMachineReleaseData(mConfig)
 	// Get the final process group and prevent empty string
 	processGroup = mConfig.ProcessGroup()
+	mConfig.Image = md.imageFor(processGroup)
+	md.setMachineReleaseData(mConfig)
 
 	// Mounts needs special treatment:
 	//   * Volumes attached to existings machines can't be swapped by other volumes
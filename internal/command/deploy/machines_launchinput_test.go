@@ -469,3 +469,28 @@ func Test_launchInputForUpdate_Files(t *testing.T) {
 	assert.Equal(t, "/path/to/hello.txt", li.Config.Files[1].GuestPath)
 	assert.Equal(t, "Z29vZGJ5ZQo=", *li.Config.Files[1].RawValue)
 }
+
+func Test_launchInputFor_processGroupImages(t *testing.T) {
+	md, err := stabMachineDeployment(&appconfig.Config{
+		AppName:       "my-cool-app",
+		PrimaryRegion: "scl",
+		Processes: map[string]string{
+			"web":    "run web",
+			"worker": "run worker",
+		},
+	})
+	require.NoError(t, err)
+	md.groupImages = map[string]string{"worker": "super/worker"}
+
+	li, err := md.launchInputForLaunch("web", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "super/balloon", li.Config.Image)
+
+	li, err = md.launchInputForLaunch("worker", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "super/worker", li.Config.Image)
+
+	li, err = md.launchInputForUpdate(&fly.Machine{ID: "ab1234567890", Region: "scl", Config: li.Config})
+	require.NoError(t, err)
+	assert.Equal(t, "super/worker", li.Config.Image)
+}
@@ -0,0 +1,254 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/env"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/terminal"
+)
+
+const notifyTimeout = 10 * time.Second
+
+// deployNotification is the payload posted to generic webhooks.
+type deployNotification struct {
+	// Event is start, success or failure.
+	Event          string    `json:"event"`
+	Time           time.Time `json:"time"`
+	App            string    `json:"app"`
+	Org            string    `json:"org,omitempty"`
+	ReleaseID      string    `json:"release_id,omitempty"`
+	ReleaseVersion int       `json:"release_version,omitempty"`
+	Image          string    `json:"image,omitempty"`
+	Strategy       string    `json:"strategy,omitempty"`
+	Commit         string    `json:"commit,omitempty"`
+	Duration       float64   `json:"duration_seconds,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	MonitoringURL  string    `json:"monitoring_url"`
+}
+
+// message is the text of the notification for chat webhooks.
+func (n deployNotification) message() string {
+	var msg string
+	switch n.Event {
+	case "start":
+		msg = fmt.Sprintf("Deploying %s", n.App)
+	case "success":
+		msg = fmt.Sprintf("Deployed %s", n.App)
+		if n.ReleaseVersion > 0 {
+			msg += fmt.Sprintf(" v%d", n.ReleaseVersion)
+		}
+		msg += fmt.Sprintf(" in %s", (time.Duration(n.Duration) * time.Second).String())
+	default:
+		msg = fmt.Sprintf("Deploying %s", n.App)
+		if n.ReleaseVersion > 0 {
+			msg += fmt.Sprintf(" v%d", n.ReleaseVersion)
+		}
+		msg += fmt.Sprintf(" failed: %s", n.Error)
+	}
+	if n.Commit != "" {
+		msg += fmt.Sprintf(" (commit %s)", n.Commit)
+	}
+	return msg + "\n" + n.MonitoringURL
+}
+
+// deployNotifier posts the start and outcome of a deployment to the webhooks
+// of [deploy.notifications] and --notify-url. A nil notifier notifies no one.
+type deployNotifier struct {
+	urls    []string
+	events  []string
+	client  *http.Client
+	started time.Time
+	base    deployNotification
+}
+
+func newDeployNotifier(ctx context.Context, cfg *appconfig.Config, app *fly.AppCompact) (*deployNotifier, error) {
+	var urls, events []string
+	if cfg.Deploy != nil && cfg.Deploy.Notifications != nil {
+		urls = append(urls, cfg.Deploy.Notifications.URLs...)
+		events = cfg.Deploy.Notifications.Events
+	}
+	urls = append(urls, flag.GetStringArray(ctx, "notify-url")...)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	resolved, err := resolveNotifyURLs(urls)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		events = appconfig.DeployNotificationEvents
+	}
+
+	n := &deployNotifier{
+		urls:   resolved,
+		events: events,
+		client: &http.Client{Timeout: notifyTimeout},
+		base: deployNotification{
+			App:           app.Name,
+			Strategy:      flag.GetString(ctx, "strategy"),
+			Commit:        env.GitCommitSHA(),
+			MonitoringURL: fmt.Sprintf("https://fly.io/apps/%s/monitoring", app.Name),
+		},
+	}
+	if app.Organization != nil {
+		n.base.Org = app.Organization.Slug
+	}
+	if n.base.Strategy == "" && cfg.Deploy != nil {
+		n.base.Strategy = cfg.Deploy.Strategy
+	}
+	return n, nil
+}
+
+// resolveNotifyURLs reads the URLs given as env:VARIABLE from the
+// environment.
+func resolveNotifyURLs(urls []string) ([]string, error) {
+	resolved := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if variable, ok := strings.CutPrefix(u, "env:"); ok {
+			value, ok := os.LookupEnv(variable)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("deploy notification URL reads the environment variable %s, which isn't set", variable)
+			}
+			u = value
+		}
+		if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return nil, fmt.Errorf("deploy notification URL %q must be an http(s) URL", u)
+		}
+		resolved = append(resolved, u)
+	}
+	return resolved, nil
+}
+
+func (n *deployNotifier) setImage(image string) {
+	if n != nil {
+		n.base.Image = image
+	}
+}
+
+func (n *deployNotifier) setRelease(id string, version int) {
+	if n != nil {
+		n.base.ReleaseID = id
+		n.base.ReleaseVersion = version
+	}
+}
+
+func (n *deployNotifier) start(ctx context.Context) {
+	if n == nil {
+		return
+	}
+	n.started = time.Now()
+	n.notify(ctx, "start", nil)
+}
+
+func (n *deployNotifier) finish(ctx context.Context, err error) {
+	if n == nil {
+		return
+	}
+	if err != nil {
+		n.notify(ctx, "failure", err)
+	} else {
+		n.notify(ctx, "success", nil)
+	}
+}
+
+// notify posts event to every webhook. Failing to notify only warns, as it
+// must not fail the deployment.
+func (n *deployNotifier) notify(ctx context.Context, event string, deployErr error) {
+	if !slices.Contains(n.events, event) {
+		return
+	}
+
+	// Still report interrupted deployments.
+	ctx = context.WithoutCancel(ctx)
+
+	payload := n.base
+	payload.Event = event
+	payload.Time = time.Now().UTC()
+	if event != "start" && !n.started.IsZero() {
+		payload.Duration = time.Since(n.started).Round(time.Second).Seconds()
+	}
+	if deployErr != nil {
+		payload.Error = deployErr.Error()
+	}
+
+	for _, u := range n.urls {
+		if err := n.post(ctx, u, payload); err != nil {
+			terminal.Warnf("failed to send the %s deploy notification to %s: %v\n", event, redactURL(u), err)
+		}
+	}
+}
+
+func (n *deployNotifier) post(ctx context.Context, u string, payload deployNotification) error {
+	var body any = payload
+	switch notifyKind(u) {
+	case "slack":
+		body = map[string]string{"text": payload.message()}
+	case "discord":
+		body = map[string]string{"content": payload.message()}
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", buildinfo.UserAgent())
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("got status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+// notifyKind tells Slack and Discord webhooks, which take messages in their
+// own format, from generic ones.
+func notifyKind(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "webhook"
+	}
+	switch host := parsed.Hostname(); {
+	case host == "hooks.slack.com":
+		return "slack"
+	case (host == "discord.com" || host == "discordapp.com") && strings.HasPrefix(parsed.Path, "/api/webhooks/"):
+		return "discord"
+	default:
+		return "webhook"
+	}
+}
+
+// redactURL drops the path of a webhook URL, which usually holds its token.
+func redactURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return "webhook"
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
@@ -0,0 +1,89 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyKind(t *testing.T) {
+	assert.Equal(t, "slack", notifyKind("https://hooks.slack.com/services/T0/B0/X"))
+	assert.Equal(t, "discord", notifyKind("https://discord.com/api/webhooks/1/abc"))
+	assert.Equal(t, "webhook", notifyKind("https://discord.com/channels/1"))
+	assert.Equal(t, "webhook", notifyKind("https://ci.example.com/hook"))
+}
+
+func TestResolveNotifyURLs(t *testing.T) {
+	t.Setenv("DEPLOY_HOOK", "https://ci.example.com/hook")
+
+	urls, err := resolveNotifyURLs([]string{"env:DEPLOY_HOOK", "https://hooks.slack.com/services/X"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://ci.example.com/hook", "https://hooks.slack.com/services/X"}, urls)
+
+	_, err = resolveNotifyURLs([]string{"env:DEPLOY_HOOK_UNSET"})
+	assert.ErrorContains(t, err, "DEPLOY_HOOK_UNSET, which isn't set")
+
+	_, err = resolveNotifyURLs([]string{"ci.example.com/hook"})
+	assert.Error(t, err)
+}
+
+func TestDeployNotifier(t *testing.T) {
+	var received []deployNotification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n deployNotification
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		received = append(received, n)
+	}))
+	defer server.Close()
+
+	n := &deployNotifier{
+		urls:   []string{server.URL},
+		events: []string{"start", "failure"},
+		client: server.Client(),
+		base:   deployNotification{App: "app", MonitoringURL: "https://fly.io/apps/app/monitoring"},
+	}
+
+	ctx := context.Background()
+	n.start(ctx)
+	n.setImage("registry.fly.io/app:deployment-1")
+	n.setRelease("rel", 7)
+	n.finish(ctx, nil)
+	n.finish(ctx, errors.New("smoke checks failed"))
+
+	require.Len(t, received, 2)
+	assert.Equal(t, "start", received[0].Event)
+	assert.Zero(t, received[0].ReleaseVersion)
+	assert.Equal(t, "failure", received[1].Event)
+	assert.Equal(t, 7, received[1].ReleaseVersion)
+	assert.Equal(t, "registry.fly.io/app:deployment-1", received[1].Image)
+	assert.Equal(t, "smoke checks failed", received[1].Error)
+
+	// A nil notifier notifies no one.
+	var none *deployNotifier
+	none.start(ctx)
+	none.setRelease("rel", 1)
+	none.finish(ctx, nil)
+}
+
+func TestDeployNotificationMessage(t *testing.T) {
+	n := deployNotification{
+		Event:          "success",
+		App:            "app",
+		ReleaseVersion: 7,
+		Duration:       (90 * time.Second).Seconds(),
+		Commit:         "abc123",
+		MonitoringURL:  "https://fly.io/apps/app/monitoring",
+	}
+	assert.Equal(t, "Deployed app v7 in 1m30s (commit abc123)\nhttps://fly.io/apps/app/monitoring", n.message())
+
+	n.Event = "failure"
+	n.Error = "smoke checks failed"
+	assert.Equal(t, "Deploying app v7 failed: smoke checks failed (commit abc123)\nhttps://fly.io/apps/app/monitoring", n.message())
+}
@@ -0,0 +1,194 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/pricing"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// planGroup describes what a deploy does to the machines of a process group.
+type planGroup struct {
+	Group string
+	// Action is one of "update", "create" or "destroy"
+	Action         string
+	Machines       int
+	CurrentSizes   []string `json:",omitempty"`
+	Sizes          []string `json:",omitempty"`
+	CurrentMonthly *float64 `json:",omitempty"`
+	Monthly        *float64 `json:",omitempty"`
+
+	current []*fly.MachineGuest
+	next    []*fly.MachineGuest
+}
+
+// computeDeployPlan works out the machines each process group ends up with
+// after deploying appConfig, without building or deploying anything. New
+// groups get defaultGuest unless the app config sets their size.
+func computeDeployPlan(appConfig *appconfig.Config, machines []*fly.Machine, defaultGuest *fly.MachineGuest, updateOnly, ha bool) ([]*planGroup, error) {
+	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string { return m.ProcessGroup() })
+	processNames := appConfig.ProcessNames()
+
+	var plan []*planGroup
+
+	for _, name := range processNames {
+		groupMachines := machineGroups[name]
+		if len(groupMachines) == 0 {
+			if updateOnly {
+				continue
+			}
+
+			mConfig, err := appConfig.ToMachineConfig(name, nil)
+			if err != nil {
+				return nil, err
+			}
+			guest := lo.Ternary(mConfig.Guest != nil, mConfig.Guest, defaultGuest)
+
+			groupConfig, err := appConfig.Flatten(name)
+			if err != nil {
+				return nil, err
+			}
+			// Mirror the spare machines created for availability
+			count := 1
+			if ha && len(groupConfig.Mounts) == 0 {
+				count = 2
+			}
+
+			plan = append(plan, &planGroup{
+				Group:    name,
+				Action:   "create",
+				Machines: count,
+				next:     lo.Times(count, func(int) *fly.MachineGuest { return guest }),
+			})
+			continue
+		}
+
+		g := &planGroup{Group: name, Action: "update", Machines: len(groupMachines)}
+		for _, m := range groupMachines {
+			mConfig, err := appConfig.ToMachineConfig(name, m.Config)
+			if err != nil {
+				return nil, err
+			}
+			g.current = append(g.current, m.Config.Guest)
+			g.next = append(g.next, mConfig.Guest)
+		}
+		plan = append(plan, g)
+	}
+
+	removed := lo.Filter(lo.Keys(machineGroups), func(name string, _ int) bool {
+		return !slices.Contains(processNames, name)
+	})
+	slices.Sort(removed)
+	for _, name := range removed {
+		plan = append(plan, &planGroup{
+			Group:    name,
+			Action:   "destroy",
+			Machines: len(machineGroups[name]),
+			current:  lo.Map(machineGroups[name], func(m *fly.Machine, _ int) *fly.MachineGuest { return m.Config.Guest }),
+		})
+	}
+
+	for _, g := range plan {
+		g.CurrentSizes = pricing.FormatSizes(g.current)
+		g.Sizes = pricing.FormatSizes(g.next)
+	}
+
+	return plan, nil
+}
+
+// estimatePlanCost fills in the monthly cost of each group of plan before and
+// after the deploy.
+func estimatePlanCost(plan []*planGroup, prices *pricing.Prices) {
+	for _, g := range plan {
+		if price, ok := prices.MachinesMonthly(g.current); ok {
+			g.CurrentMonthly = &price
+		}
+		if price, ok := prices.MachinesMonthly(g.next); ok {
+			g.Monthly = &price
+		}
+	}
+}
+
+// runDeployPlan prints the changes deploying appConfig makes to the app's
+// machines, optionally with their estimated cost.
+func runDeployPlan(ctx context.Context, appConfig *appconfig.Config) error {
+	io := iostreams.FromContext(ctx)
+	flapsClient := flaps.FromContext(ctx)
+	withCost := flag.GetBool(ctx, "with-cost")
+
+	machines, _, err := flapsClient.ListFlyAppsMachines(ctx)
+	if err != nil {
+		return err
+	}
+
+	defaultGuest, err := flag.GetMachineGuest(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	plan, err := computeDeployPlan(appConfig, machines, defaultGuest, flag.GetBool(ctx, "update-only"), flag.GetBool(ctx, "ha"))
+	if err != nil {
+		return err
+	}
+
+	if withCost {
+		prices, err := pricing.Fetch(ctx)
+		if err != nil {
+			return err
+		}
+		estimatePlanCost(plan, prices)
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, plan)
+	}
+
+	var (
+		total float64
+		known = true
+	)
+	rows := lo.Map(plan, func(g *planGroup, _ int) []string {
+		row := []string{
+			g.Group,
+			g.Action,
+			fmt.Sprint(g.Machines),
+			strings.Join(g.CurrentSizes, ", "),
+			strings.Join(g.Sizes, ", "),
+		}
+		if !withCost {
+			return row
+		}
+
+		current, next := lo.FromPtr(g.CurrentMonthly), lo.FromPtr(g.Monthly)
+		if (g.current != nil && g.CurrentMonthly == nil) || (g.next != nil && g.Monthly == nil) {
+			known = false
+			return append(row, "unknown")
+		}
+		total += next - current
+		return append(row, pricing.FormatDelta(next-current))
+	})
+
+	cols := []string{"Group", "Action", "Machines", "Current", "New"}
+	if withCost {
+		cols = append(cols, "Monthly Change")
+	}
+
+	fmt.Fprintf(io.Out, "Deploying app %s would make the following changes to its machines:\n\n", appConfig.AppName)
+	if err := render.Table(io.Out, "", rows, cols...); err != nil {
+		return err
+	}
+	if withCost && known {
+		fmt.Fprintf(io.Out, "Estimated monthly cost change: %s\n", pricing.FormatDelta(total))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/pricing"
+)
+
+func Test_computeDeployPlan(t *testing.T) {
+	cfg := appconfig.NewConfig()
+	cfg.AppName = "my-cool-app"
+	cfg.Processes = map[string]string{"web": "run web", "worker": "run worker"}
+	cfg.Compute = []*appconfig.Compute{{
+		MachineGuest: &fly.MachineGuest{CPUKind: "shared", CPUs: 2, MemoryMB: 512},
+		Processes:    []string{"web"},
+	}}
+
+	guest := &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}
+	machine := func(group string) *fly.Machine {
+		return &fly.Machine{Config: &fly.MachineConfig{
+			Guest:    guest,
+			Metadata: map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: group},
+		}}
+	}
+	machines := []*fly.Machine{machine("web"), machine("web"), machine("old")}
+
+	plan, err := computeDeployPlan(cfg, machines, guest, false, true)
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+
+	assert.Equal(t, "web", plan[0].Group)
+	assert.Equal(t, "update", plan[0].Action)
+	assert.Equal(t, []string{"shared-cpu-1x/256MB"}, plan[0].CurrentSizes)
+	assert.Equal(t, []string{"shared-cpu-2x/512MB"}, plan[0].Sizes)
+
+	assert.Equal(t, "worker", plan[1].Group)
+	assert.Equal(t, "create", plan[1].Action)
+	assert.Equal(t, 2, plan[1].Machines)
+	assert.Equal(t, []string{"shared-cpu-1x/256MB"}, plan[1].Sizes)
+
+	assert.Equal(t, "old", plan[2].Group)
+	assert.Equal(t, "destroy", plan[2].Action)
+	assert.Empty(t, plan[2].Sizes)
+
+	estimatePlanCost(plan, pricing.New(map[string]float64{"shared-cpu-1x": 2, "shared-cpu-2x": 4}))
+	assert.Equal(t, 4.0, *plan[0].CurrentMonthly)
+	assert.Equal(t, 8.0, *plan[0].Monthly)
+	assert.Equal(t, 4.0, *plan[1].Monthly)
+	assert.Equal(t, 0.0, *plan[2].Monthly)
+
+	plan, err = computeDeployPlan(cfg, machines, guest, true, true)
+	require.NoError(t, err)
+	assert.Len(t, plan, 2)
+}
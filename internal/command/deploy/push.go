@@ -0,0 +1,73 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/render"
+)
+
+// pushedImage is the metadata of a pushed image printed for scripts.
+type pushedImage struct {
+	// ProcessGroup is set for the images of process groups with builds of
+	// their own.
+	ProcessGroup string `json:"process_group,omitempty"`
+	Tag          string `json:"tag"`
+	Digest       string `json:"digest,omitempty"`
+	// Ref pins the tag to the digest when the registry reported it, so that
+	// deploying it later deploys exactly the pushed image.
+	Ref  string `json:"ref"`
+	ID   string `json:"id,omitempty"`
+	Size int64  `json:"size,omitempty"`
+	// Build is how the image was built, when it was.
+	Build *imgsrc.BuildInfo `json:"build,omitempty"`
+}
+
+func newPushedImage(group string, img *imgsrc.DeploymentImage) pushedImage {
+	ref := img.Tag
+	if img.Digest != "" {
+		ref += "@" + img.Digest
+	}
+	return pushedImage{
+		ProcessGroup: group,
+		Tag:          img.Tag,
+		Digest:       img.Digest,
+		Ref:          ref,
+		ID:           img.ID,
+		Size:         img.Size,
+		Build:        img.Build,
+	}
+}
+
+func pushedImages(img *imgsrc.DeploymentImage, groupImages map[string]*imgsrc.DeploymentImage) []pushedImage {
+	images := []pushedImage{newPushedImage("", img)}
+
+	groups := make([]string, 0, len(groupImages))
+	for group := range groupImages {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		images = append(images, newPushedImage(group, groupImages[group]))
+	}
+	return images
+}
+
+// PrintPushedImages prints the registry references of pushed images to out,
+// pinned to their digests, one per line with the image of the app first, or
+// their metadata with jsonOutput.
+func PrintPushedImages(out io.Writer, jsonOutput bool, img *imgsrc.DeploymentImage, groupImages map[string]*imgsrc.DeploymentImage) error {
+	images := pushedImages(img, groupImages)
+
+	if jsonOutput {
+		return render.JSON(out, images)
+	}
+
+	for _, image := range images {
+		fmt.Fprintln(out, image.Ref)
+	}
+	return nil
+}
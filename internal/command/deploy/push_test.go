@@ -0,0 +1,75 @@
+package deploy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+)
+
+func TestPushedImages(t *testing.T) {
+	build := &imgsrc.BuildInfo{Strategy: "dockerfile", Builder: "remote"}
+	img := &imgsrc.DeploymentImage{ID: "sha256:app", Tag: "registry.fly.io/app:deployment-1", Digest: "sha256:d1", Size: 100, Build: build}
+	groupImages := map[string]*imgsrc.DeploymentImage{
+		"worker": {ID: "sha256:worker", Tag: "registry.fly.io/app:deployment-1-worker", Size: 30},
+		"cron":   {ID: "sha256:cron", Tag: "registry.fly.io/app:deployment-1-cron", Size: 20},
+	}
+
+	assert.Equal(t, []pushedImage{
+		{Tag: "registry.fly.io/app:deployment-1", Digest: "sha256:d1", Ref: "registry.fly.io/app:deployment-1@sha256:d1", ID: "sha256:app", Size: 100, Build: build},
+		{ProcessGroup: "cron", Tag: "registry.fly.io/app:deployment-1-cron", Ref: "registry.fly.io/app:deployment-1-cron", ID: "sha256:cron", Size: 20},
+		{ProcessGroup: "worker", Tag: "registry.fly.io/app:deployment-1-worker", Ref: "registry.fly.io/app:deployment-1-worker", ID: "sha256:worker", Size: 30},
+	}, pushedImages(img, groupImages))
+
+	assert.Equal(t, []pushedImage{{Tag: "registry.fly.io/app:deployment-1", Ref: "registry.fly.io/app:deployment-1"}},
+		pushedImages(&imgsrc.DeploymentImage{Tag: "registry.fly.io/app:deployment-1"}, nil))
+}
+
+func TestPrintPushedImages(t *testing.T) {
+	img := &imgsrc.DeploymentImage{
+		ID:     "sha256:app",
+		Tag:    "registry.fly.io/app:deployment-1",
+		Digest: "sha256:d1",
+		Size:   100,
+		Build: &imgsrc.BuildInfo{
+			Strategy: "dockerfile",
+			Builder:  "remote",
+			Timings:  imgsrc.BuildTimings{BuildAndPushMs: 1500, PushMs: 500},
+		},
+	}
+	groupImages := map[string]*imgsrc.DeploymentImage{
+		"worker": {ID: "sha256:worker", Tag: "registry.fly.io/app:deployment-1-worker", Size: 30},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, PrintPushedImages(&out, false, img, groupImages))
+	assert.Equal(t, "registry.fly.io/app:deployment-1@sha256:d1\nregistry.fly.io/app:deployment-1-worker\n", out.String())
+
+	out.Reset()
+	require.NoError(t, PrintPushedImages(&out, true, img, groupImages))
+	assert.JSONEq(t, `[
+		{
+			"tag": "registry.fly.io/app:deployment-1",
+			"digest": "sha256:d1",
+			"ref": "registry.fly.io/app:deployment-1@sha256:d1",
+			"id": "sha256:app",
+			"size": 100,
+			"build": {
+				"strategy": "dockerfile",
+				"builder": "remote",
+				"buildkit_enabled": false,
+				"timings": {"build_and_push_ms": 1500, "push_ms": 500}
+			}
+		},
+		{
+			"process_group": "worker",
+			"tag": "registry.fly.io/app:deployment-1-worker",
+			"ref": "registry.fly.io/app:deployment-1-worker",
+			"id": "sha256:worker",
+			"size": 30
+		}
+	]`, out.String())
+}
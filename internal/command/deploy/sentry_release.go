@@ -0,0 +1,50 @@
+package deploy
+
+import (
+	"context"
+	"strings"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/terminal"
+)
+
+const sentryReleaseEnv = "SENTRY_RELEASE"
+
+// tagSentryRelease sets SENTRY_RELEASE on apps with a Sentry extension, so
+// Sentry attributes errors to the deployment that raised them. A
+// SENTRY_RELEASE set in fly.toml is left alone.
+func tagSentryRelease(ctx context.Context, appName string, appConfig *appconfig.Config, img *imgsrc.DeploymentImage) {
+	if _, ok := appConfig.Env[sentryReleaseEnv]; ok {
+		return
+	}
+
+	client := fly.ClientFromContext(ctx).GenqClient
+	response, err := gql.GetAppWithAddons(ctx, client, appName, gql.AddOnTypeSentry)
+	if err != nil {
+		terminal.Debugf("skipping Sentry release tagging, failed to list extensions: %v\n", err)
+		return
+	}
+	if len(response.App.AddOns.Nodes) == 0 {
+		return
+	}
+
+	if release := sentryRelease(img); release != "" {
+		appConfig.SetEnvVariables(map[string]string{sentryReleaseEnv: release})
+	}
+}
+
+// sentryRelease names the release of img after its tag, such as
+// deployment-01HZ..., falling back to its ID.
+func sentryRelease(img *imgsrc.DeploymentImage) string {
+	ref := img.Tag
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i >= 0 && !strings.Contains(ref[i:], "/") {
+		return ref[i+1:]
+	}
+	return img.ID
+}
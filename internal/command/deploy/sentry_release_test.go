@@ -0,0 +1,14 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+)
+
+func TestSentryRelease(t *testing.T) {
+	assert.Equal(t, "deployment-01HZ", sentryRelease(&imgsrc.DeploymentImage{Tag: "registry.fly.io/app:deployment-01HZ"}))
+	assert.Equal(t, "v1", sentryRelease(&imgsrc.DeploymentImage{Tag: "localhost:5000/app:v1@sha256:abc"}))
+	assert.Equal(t, "sha256:abc", sentryRelease(&imgsrc.DeploymentImage{Tag: "localhost:5000/app", ID: "sha256:abc"}))
+}
@@ -0,0 +1,159 @@
+package deploy
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/statuslogger"
+)
+
+// DefaultSmokeTestTimeout bounds a smoke test without a timeout.
+const DefaultSmokeTestTimeout = 30 * time.Second
+
+// smokeTest is a test run against each machine a deploy updates before it
+// moves on. It either requests a URL from the machine's private address or
+// runs a command on it.
+type smokeTest struct {
+	URL     *url.URL
+	Command string
+}
+
+// parseSmokeTest parses the smoke test s: a http or https URL, whose host is
+// replaced by the machine's private address, or else a command.
+func parseSmokeTest(s string) (*smokeTest, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return &smokeTest{Command: s}, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smoke test URL %q: %w", s, err)
+	}
+	if u.Port() == "" {
+		return nil, fmt.Errorf("smoke test URL %q needs the port the machine listens on, such as http://:8080/healthz", s)
+	}
+	return &smokeTest{URL: u}, nil
+}
+
+func (st *smokeTest) String() string {
+	if st.URL != nil {
+		return st.URL.String()
+	}
+	return st.Command
+}
+
+// machineURL returns the URL of the test on the machine at privateIP.
+func (st *smokeTest) machineURL(privateIP string) *url.URL {
+	u := *st.URL
+	u.Host = net.JoinHostPort(privateIP, st.URL.Port())
+	return &u
+}
+
+// runSmokeTest runs the smoke test of the deployment against the machine lm.
+func (md *machineDeployment) runSmokeTest(ctx context.Context, lm machine.LeasableMachine) error {
+	if md.smokeTest == nil {
+		return nil
+	}
+
+	m := lm.Machine()
+	statuslogger.Logf(ctx, "Running smoke test on %s", md.colorize.Bold(lm.FormattedMachineId()))
+
+	ctx, cancel := context.WithTimeout(ctx, md.smokeTestTimeout)
+	defer cancel()
+
+	var err error
+	if md.smokeTest.URL != nil {
+		err = md.requestSmokeTest(ctx, m)
+	} else {
+		err = md.execSmokeTest(ctx, m)
+	}
+	if err != nil {
+		return fmt.Errorf("smoke test %q failed on machine %s: %w", md.smokeTest, m.ID, err)
+	}
+
+	statuslogger.Logf(ctx, "Smoke test passed on %s", md.colorize.Bold(lm.FormattedMachineId()))
+	return nil
+}
+
+func (md *machineDeployment) execSmokeTest(ctx context.Context, m *fly.Machine) error {
+	out, err := md.flapsClient.Exec(ctx, m.ID, &fly.MachineExecRequest{
+		Cmd:     md.smokeTest.Command,
+		Timeout: int(md.smokeTestTimeout.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+	if out.ExitCode != 0 {
+		return fmt.Errorf("exited with code %d: %s", out.ExitCode, strings.TrimSpace(out.StdErr+out.StdOut))
+	}
+	return nil
+}
+
+func (md *machineDeployment) requestSmokeTest(ctx context.Context, m *fly.Machine) error {
+	dialer, err := md.privateDialer(ctx)
+	if err != nil {
+		return err
+	}
+
+	host := md.smokeTest.URL.Hostname()
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+			// Machines are reached by address, so there's no name to verify
+			// certificates against unless the test gives one.
+			TLSClientConfig: &tls.Config{
+				ServerName:         host,
+				InsecureSkipVerify: host == "",
+			},
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, md.smokeTest.machineURL(m.PrivateIP).String(), nil)
+	if err != nil {
+		return err
+	}
+	// Keep the host the test was written for, if any.
+	if host != "" {
+		req.Host = host
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("got status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// privateDialer returns a dialer into the private network of the app,
+// connecting to it the first time.
+func (md *machineDeployment) privateDialer(ctx context.Context) (agent.Dialer, error) {
+	md.dialerOnce.Do(func() {
+		var client *agent.Client
+		if client, md.dialerErr = agent.Establish(ctx, md.apiClient); md.dialerErr != nil {
+			return
+		}
+		md.dialer, md.dialerErr = client.Dialer(ctx, md.app.Organization.Slug, "")
+	})
+	return md.dialer, md.dialerErr
+}
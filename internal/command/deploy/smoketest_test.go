@@ -0,0 +1,31 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSmokeTest(t *testing.T) {
+	st, err := parseSmokeTest("")
+	require.NoError(t, err)
+	assert.Nil(t, st)
+
+	st, err = parseSmokeTest("bin/smoke --fast")
+	require.NoError(t, err)
+	assert.Equal(t, "bin/smoke --fast", st.Command)
+	assert.Nil(t, st.URL)
+
+	st, err = parseSmokeTest("http://:8080/healthz?deep=1")
+	require.NoError(t, err)
+	assert.Equal(t, "http://[fdaa::3]:8080/healthz?deep=1", st.machineURL("fdaa::3").String())
+
+	st, err = parseSmokeTest("https://example.com:8443/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://[fdaa::3]:8443/", st.machineURL("fdaa::3").String())
+	assert.Equal(t, "example.com", st.URL.Hostname())
+
+	_, err = parseSmokeTest("http://localhost/healthz")
+	assert.ErrorContains(t, err, "needs the port")
+}
@@ -71,6 +71,9 @@ type blueGreen struct {
 	hangingBlueMachines []string
 	timestamp           string
 	maxConcurrent       int
+	// smokeTest tests a green machine before traffic moves to it, when the
+	// deployment has a smoke test.
+	smokeTest func(context.Context, machine.LeasableMachine) error
 
 	rollbackLog RollbackLog
 }
@@ -95,6 +98,9 @@ func BlueGreenStrategy(md *machineDeployment, blueMachines []*machineUpdateEntry
 		maxConcurrent:       md.maxConcurrent,
 		rollbackLog:         RollbackLog{canDeleteGreenMachines: true, disableRollback: false},
 	}
+	if md.smokeTest != nil {
+		bg.smokeTest = md.runSmokeTest
+	}
 
 	// Hook into Ctrl+C so that we can rollback the deployment when it's aborted.
 	ctrlc.ClearHandlers()
@@ -726,6 +732,19 @@ func (bg *blueGreen) Deploy(ctx context.Context) error {
 		return ErrAborted
 	}
 
+	if bg.smokeTest != nil {
+		fmt.Fprintf(bg.io.ErrOut, "\nRunning smoke tests on green machines\n")
+		for _, gm := range bg.greenMachines {
+			if gm.launchInput.SkipLaunch {
+				continue
+			}
+			if err := bg.smokeTest(ctx, gm.leasableMachine); err != nil {
+				tracing.RecordError(span, err, "failed smoke test")
+				return err
+			}
+		}
+	}
+
 	fmt.Fprintf(bg.io.ErrOut, "\nMarking green machines as ready\n")
 	if err := bg.MarkGreenMachinesAsReadyForTraffic(ctx); err != nil {
 		tracing.RecordError(span, err, "failed to mark as ready for traffic")
@@ -76,34 +76,10 @@ add the --force flag to send us best-effort diagnostics.`)
 		return err
 	}
 
-	fts := []struct {
-		name   string
-		fn     func(context.Context, *zip.Writer) error
-		expect bool
-	}{
-		{"fly.toml", fetchFlyToml, true},
-		{"config.yml", fetchConfigYaml, true},
-		{"Dockerfile", fetchDockerfile, false},
-		{"fly agent logs", fetchAgentLogs, false},
-		{"local diagnostics", fetchLocalDiag, true},
-	}
-
 	zbuf := &bytes.Buffer{}
 	zip := zip.NewWriter(zbuf)
 
-	for _, ft := range fts {
-		fmt.Printf("Collecting %s... ", ft.name)
-
-		if err = ft.fn(ctx, zip); err != nil {
-			if ft.expect {
-				fmt.Printf(color.Red(fmt.Sprintf("FAILED: %s\n", err)))
-			} else {
-				fmt.Printf("skipping\n")
-			}
-		} else {
-			fmt.Printf(color.Green("ok\n"))
-		}
-	}
+	collect(ctx, zip, color, fetchers)
 
 	zip.Close()
 
@@ -136,6 +112,36 @@ add the --force flag to send us best-effort diagnostics.`)
 	return nil
 }
 
+type fetcher struct {
+	name   string
+	fn     func(context.Context, *zip.Writer) error
+	expect bool
+}
+
+var fetchers = []fetcher{
+	{"fly.toml", fetchFlyToml, true},
+	{"config.yml", fetchConfigYaml, true},
+	{"Dockerfile", fetchDockerfile, false},
+	{"fly agent logs", fetchAgentLogs, false},
+	{"local diagnostics", fetchLocalDiag, true},
+}
+
+func collect(ctx context.Context, z *zip.Writer, color *iostreams.ColorScheme, fts []fetcher) {
+	for _, ft := range fts {
+		fmt.Printf("Collecting %s... ", ft.name)
+
+		if err := ft.fn(ctx, z); err != nil {
+			if ft.expect {
+				fmt.Printf(color.Red(fmt.Sprintf("FAILED: %s\n", err)))
+			} else {
+				fmt.Printf("skipping\n")
+			}
+		} else {
+			fmt.Printf(color.Green("ok\n"))
+		}
+	}
+}
+
 func cp(z *zip.Writer, name string, f io.Reader) error {
 	zf, err := z.Create(name)
 	if err != nil {
@@ -228,7 +234,7 @@ func fetchLocalDiag(ctx context.Context, z *zip.Writer) error {
 
 	diags["version"] = buildinfo.Info()
 
-	client, err := imgsrc.NewLocalDockerClient()
+	client, err := imgsrc.NewLocalDockerClient("")
 	if err == nil {
 		_, err = client.Ping(ctx)
 	}
@@ -0,0 +1,125 @@
+package diag
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/httptracing"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// NewExport initializes and returns a new export Command.
+func NewExport() (cmd *cobra.Command) {
+	const (
+		short = `Save a diagnostic bundle to attach to a support ticket`
+		long  = `Save a zip file with the same diagnostic information fly doctor diag
+sends, plus the logs of recent flyctl runs and any log files given with
+--include, to attach to a support ticket.
+
+To capture a complete trace of a failing command, run it with --log-file
+first:
+
+  fly deploy --log-level debug --log-file deploy.log
+  fly doctor export --include deploy.log
+
+Credentials in logs and traces are redacted, but review the bundle before
+sharing it.
+`
+	)
+
+	cmd = command.New("export", short, long, runExport,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.String{
+			Name:        "output",
+			Shorthand:   "o",
+			Description: "Path of the bundle to write. Defaults to fly-doctor-<timestamp>.zip",
+		},
+		flag.StringSlice{
+			Name:        "include",
+			Description: "Log files to add to the bundle, such as those written with --log-file",
+		},
+	)
+
+	return
+}
+
+func runExport(ctx context.Context) error {
+	var (
+		io    = iostreams.FromContext(ctx)
+		path  = flag.GetString(ctx, "output")
+		files = flag.GetStringSlice(ctx, "include")
+	)
+
+	if path == "" {
+		path = fmt.Sprintf("fly-doctor-%s.zip", time.Now().Format("20060102-150405"))
+	}
+
+	fts := append([]fetcher{}, fetchers...)
+	fts = append(fts, fetcher{"flyctl logs", fetchFlyctlLogs, false})
+	for _, file := range files {
+		file := file
+		fts = append(fts, fetcher{file, func(_ context.Context, z *zip.Writer) error {
+			return cpRedacted(z, "included/"+filepath.Base(file), file)
+		}, true})
+	}
+
+	zbuf := &bytes.Buffer{}
+	z := zip.NewWriter(zbuf)
+
+	collect(ctx, z, io.ColorScheme(), fts)
+
+	if err := z.Close(); err != nil {
+		return fmt.Errorf("failed writing bundle: %w", err)
+	}
+	if err := os.WriteFile(path, zbuf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed writing bundle: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "\nWrote the diagnostic bundle to %s\n", path)
+	return nil
+}
+
+func fetchFlyctlLogs(ctx context.Context, z *zip.Writer) error {
+	logs, err := filepath.Glob(filepath.Join(state.ConfigDirectory(ctx), "logs", "flyctl-*.log"))
+	if err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return os.ErrNotExist
+	}
+
+	for _, log := range logs {
+		if err := cpRedacted(z, "logs/"+filepath.Base(log), log); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cpRedacted copies the file at path into z as name, with credentials
+// redacted.
+func cpRedacted(z *zip.Writer, name, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	data = httptracing.Redact(data)
+	return cp(z, name, grepv(bytes.NewReader(data), []string{"_token:", "private"}))
+}
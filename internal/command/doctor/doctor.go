@@ -25,7 +25,14 @@ import (
 func New() (cmd *cobra.Command) {
 	const (
 		short = `The DOCTOR command allows you to debug your Fly environment`
-		long  = short + "\n"
+		long  = `The DOCTOR command allows you to debug your Fly environment.
+
+It checks your local setup, including Docker contexts, proxies, clock skew,
+IPv6, the flyctl agent and shell completions, then your connectivity to the
+platform and your app. With --fix, problems with a safe remedy are fixed.
+With --json, results are printed as a JSON object keyed by check, for CI
+preflight checks.
+`
 	)
 
 	cmd = command.New("doctor", short, long, run,
@@ -45,6 +52,10 @@ func New() (cmd *cobra.Command) {
 			Default:     false,
 			Description: "Print extra diagnostic information.",
 		},
+		flag.Bool{
+			Name:        "fix",
+			Description: "Apply safe fixes for the problems found, such as restarting an outdated agent",
+		},
 		flag.String{
 			Name:         "org",
 			Shorthand:    "o",
@@ -54,7 +65,7 @@ func New() (cmd *cobra.Command) {
 		},
 	)
 
-	cmd.AddCommand(diag.New())
+	cmd.AddCommand(diag.New(), diag.NewExport())
 
 	return
 }
@@ -102,6 +113,35 @@ func run(ctx context.Context) (err error) {
 
 	// ------------------------------------------------------------
 
+	fix := flag.GetBool(ctx, "fix")
+
+	for _, c := range envChecks {
+		lprint(nil, "%s... ", c.title)
+
+		err := c.run(ctx)
+		if err != nil && fix && c.fix != nil {
+			if fixErr := c.fix(ctx); fixErr != nil {
+				err = fmt.Errorf("%w (fixing it failed: %v)", err, fixErr)
+			} else if err = c.run(ctx); err == nil {
+				lprint(color.Green, "FIXED\n")
+				checks[c.key] = "fixed"
+				continue
+			}
+		}
+
+		switch {
+		case err == nil, !isWarning(err):
+			if !check(c.key, err) && c.fix != nil && !fix {
+				lprint(nil, "Run 'flyctl doctor --fix' to fix this.\n")
+			}
+		default:
+			lprint(color.Yellow, "WARNING\n(%s)\n", err)
+			checks[c.key] = "warning: " + err.Error()
+		}
+	}
+
+	// ------------------------------------------------------------
+
 	lprint(nil, "Testing authentication token... ")
 
 	err = runAuth(ctx)
@@ -254,7 +294,7 @@ func runLocalDocker(ctx context.Context) (err error) {
 	}()
 
 	var client *dockerclient.Client
-	if client, err = imgsrc.NewLocalDockerClient(); err == nil {
+	if client, err = imgsrc.NewLocalDockerClient(""); err == nil {
 		_, err = client.Ping(ctx)
 	}
 
@@ -0,0 +1,457 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/env"
+	"github.com/superfly/flyctl/internal/version"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// maxClockSkew is how far the local clock may drift from the API's before
+// tokens and TLS certificates start being rejected.
+const maxClockSkew = time.Minute
+
+// envCheck is a check of the local environment doctor runs before talking
+// to the platform.
+type envCheck struct {
+	// key is the name of the check in the JSON output.
+	key   string
+	title string
+	run   func(context.Context) error
+	// fix, when set, remediates a failed check. It must be safe to run
+	// unattended.
+	fix func(context.Context) error
+}
+
+// warning is returned by checks which found something that may cause
+// issues, but which doesn't stop flyctl from working.
+type warning struct {
+	err error
+}
+
+func (w warning) Error() string { return w.err.Error() }
+
+func (w warning) Unwrap() error { return w.err }
+
+func isWarning(err error) bool {
+	var w warning
+	return errors.As(err, &w)
+}
+
+var envChecks = []envCheck{
+	{key: "docker_context", title: "Checking Docker context", run: checkDockerContext, fix: fixDockerContext},
+	{key: "proxy", title: "Checking network and proxies", run: checkProxy},
+	{key: "clock", title: "Checking clock skew", run: checkClock},
+	{key: "ipv6", title: "Checking IPv6 connectivity", run: checkIPv6},
+	{key: "agent_version", title: "Checking flyctl agent version", run: checkAgentVersion, fix: fixAgentVersion},
+	{key: "completions", title: "Checking shell completions", run: checkCompletions, fix: fixCompletions},
+}
+
+// ------------------------------------------------------------
+
+func dockerConfigDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".docker")
+}
+
+func readDockerConfig() (map[string]any, error) {
+	data, err := os.ReadFile(filepath.Join(dockerConfigDir(), "config.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := map[string]any{}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed parsing Docker config: %w", err)
+	}
+	return cfg, nil
+}
+
+// dockerContextHost returns the Docker host of the named context, or an error
+// wrapping fs.ErrNotExist when there's no such context.
+func dockerContextHost(name string) (string, error) {
+	sum := sha256.Sum256([]byte(name))
+	path := filepath.Join(dockerConfigDir(), "contexts", "meta", hex.EncodeToString(sum[:]), "meta.json")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var meta struct {
+		Endpoints map[string]struct {
+			Host string
+		}
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return "", fmt.Errorf("failed parsing Docker context %s: %w", name, err)
+	}
+	return meta.Endpoints["docker"].Host, nil
+}
+
+// checkSocket returns an error when host is a unix socket which doesn't exist.
+func checkSocket(host, source string) error {
+	path, ok := strings.CutPrefix(host, "unix://")
+	if !ok {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s points at %s, which doesn't exist; is Docker running?", source, path)
+	}
+	return nil
+}
+
+func checkDockerContext(ctx context.Context) error {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return checkSocket(host, "DOCKER_HOST")
+	}
+
+	cfg, err := readDockerConfig()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	name, _ := cfg["currentContext"].(string)
+	if name == "" || name == "default" {
+		return nil
+	}
+
+	host, err := dockerContextHost(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("the current Docker context %s doesn't exist", name)
+	} else if err != nil {
+		return err
+	}
+
+	return checkSocket(host, "Docker context "+name)
+}
+
+// fixDockerContext switches Docker back to its default context when the
+// current one doesn't exist.
+func fixDockerContext(ctx context.Context) error {
+	cfg, err := readDockerConfig()
+	if err != nil {
+		return err
+	}
+
+	name, _ := cfg["currentContext"].(string)
+	if _, err := dockerContextHost(name); !errors.Is(err, os.ErrNotExist) {
+		return errors.New("only missing contexts can be fixed; start Docker or run docker context use default")
+	}
+
+	delete(cfg, "currentContext")
+
+	data, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dockerConfigDir(), "config.json"), data, 0o600)
+}
+
+// ------------------------------------------------------------
+
+func proxyURL(target *url.URL) string {
+	u, err := http.ProxyFromEnvironment(&http.Request{URL: target})
+	if err != nil || u == nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
+func headAPI(ctx context.Context) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, config.FromContext(ctx).APIBaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+func checkProxy(ctx context.Context) error {
+	target, err := url.Parse(config.FromContext(ctx).APIBaseURL)
+	if err != nil {
+		return err
+	}
+	proxy := proxyURL(target)
+
+	_, err = headAPI(ctx)
+
+	var unknownAuthority x509.UnknownAuthorityError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &unknownAuthority):
+		issuer := unknownAuthority.Cert.Issuer.String()
+		return fmt.Errorf("the TLS certificate of %s is issued by %s, which isn't trusted; a corporate proxy is likely intercepting traffic. Add its CA certificate to your system's trust store, or set SSL_CERT_FILE to it", target.Host, issuer)
+	case proxy != "":
+		return fmt.Errorf("can't reach %s through the proxy %s: %w", target.Host, proxy, err)
+	default:
+		return fmt.Errorf("can't reach %s: %w", target.Host, err)
+	}
+}
+
+// ------------------------------------------------------------
+
+func checkClock(ctx context.Context) error {
+	resp, err := headAPI(ctx)
+	if err != nil {
+		return warning{fmt.Errorf("can't compare clocks: %w", err)}
+	}
+
+	at, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return warning{errors.New("can't compare clocks: the API sent no date")}
+	}
+
+	if skew := time.Since(at); skew > maxClockSkew || skew < -maxClockSkew {
+		return fmt.Errorf("the local clock is off by %s; sync it with NTP, or tokens and certificates may be rejected", skew.Round(time.Second))
+	}
+	return nil
+}
+
+// ------------------------------------------------------------
+
+func checkIPv6(ctx context.Context) error {
+	target, err := url.Parse(config.FromContext(ctx).APIBaseURL)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIP(ctx, "ip6", target.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return nil // nothing to test against
+	}
+
+	port := target.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp6", net.JoinHostPort(addrs[0].String(), port))
+	if err != nil {
+		return warning{fmt.Errorf("IPv6 is unavailable, so flyctl falls back to IPv4. If connections are slow to start, your network may be advertising broken IPv6 routes: %w", err)}
+	}
+	conn.Close()
+	return nil
+}
+
+// ------------------------------------------------------------
+
+func checkAgentVersion(ctx context.Context) error {
+	ac, err := agent.DefaultClient(ctx)
+	if err != nil {
+		return nil // no agent running, which is fine
+	}
+
+	res, err := ac.Ping(ctx)
+	if err != nil {
+		return nil
+	}
+
+	v, err := version.Parse(res.Version)
+	if err != nil {
+		return fmt.Errorf("the running agent reports an invalid version %q", res.Version)
+	}
+	if !buildinfo.Version().Equal(v) {
+		return fmt.Errorf("the running agent is v%s, but flyctl is v%s; run fly agent restart", res.Version, buildinfo.Version())
+	}
+	return nil
+}
+
+func fixAgentVersion(ctx context.Context) error {
+	ac, err := agent.DefaultClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := ac.Kill(ctx); err != nil {
+		return fmt.Errorf("failed stopping agent: %w", err)
+	}
+
+	// wait for the agent to exit
+	time.Sleep(time.Second)
+
+	_, err = agent.StartDaemon(ctx)
+	return err
+}
+
+// ------------------------------------------------------------
+
+type completionScript struct {
+	path  string
+	shell string
+	name  string
+}
+
+// userOwned reports whether s is in the home directory of the user, rather
+// than installed system wide.
+func (s completionScript) userOwned(home string) bool {
+	if home == "" {
+		return false
+	}
+	rel, err := filepath.Rel(home, s.path)
+	return err == nil && filepath.IsLocal(rel)
+}
+
+// completionScripts returns the completion scripts installed for the shell
+// the user runs, at the usual locations.
+func completionScripts() []completionScript {
+	home, _ := os.UserHomeDir()
+	shell := filepath.Base(env.First("SHELL"))
+
+	var candidates []completionScript
+	for _, name := range []string{"fly", "flyctl"} {
+		switch shell {
+		case "bash":
+			for _, dir := range []string{
+				filepath.Join(home, ".local", "share", "bash-completion", "completions"),
+				"/etc/bash_completion.d",
+				"/usr/local/etc/bash_completion.d",
+				"/opt/homebrew/etc/bash_completion.d",
+			} {
+				candidates = append(candidates, completionScript{filepath.Join(dir, name), shell, name})
+			}
+		case "zsh":
+			for _, dir := range []string{
+				filepath.Join(home, ".zsh", "completions"),
+				filepath.Join(home, ".oh-my-zsh", "completions"),
+				"/usr/local/share/zsh/site-functions",
+				"/opt/homebrew/share/zsh/site-functions",
+			} {
+				candidates = append(candidates, completionScript{filepath.Join(dir, "_"+name), shell, name})
+			}
+		case "fish":
+			candidates = append(candidates, completionScript{filepath.Join(home, ".config", "fish", "completions", name+".fish"), shell, name})
+		}
+	}
+
+	var scripts []completionScript
+	for _, c := range candidates {
+		if _, err := os.Stat(c.path); err == nil {
+			scripts = append(scripts, c)
+		}
+	}
+	return scripts
+}
+
+// generateCompletion returns the completion script fly completion would
+// print for s.
+func generateCompletion(root *cobra.Command, s completionScript) ([]byte, error) {
+	use := root.Use
+	root.Use = s.name
+	defer func() { root.Use = use }()
+
+	var buf bytes.Buffer
+	var err error
+	switch s.shell {
+	case "bash":
+		err = root.GenBashCompletionV2(&buf, true)
+	case "zsh":
+		err = root.GenZshCompletion(&buf)
+	case "fish":
+		err = root.GenFishCompletion(&buf, true)
+	}
+	return buf.Bytes(), err
+}
+
+// staleCompletions returns the completion scripts which differ from those
+// this version of flyctl generates.
+func staleCompletions(ctx context.Context) (stale []completionScript) {
+	root := command.FromContext(ctx).Root()
+
+	for _, s := range completionScripts() {
+		current, err := os.ReadFile(s.path)
+		if err != nil {
+			continue
+		}
+		expected, err := generateCompletion(root, s)
+		if err != nil {
+			continue
+		}
+		if !bytes.Equal(current, expected) {
+			stale = append(stale, s)
+		}
+	}
+	return
+}
+
+func checkCompletions(ctx context.Context) error {
+	stale := staleCompletions(ctx)
+	if len(stale) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(stale))
+	for _, s := range stale {
+		paths = append(paths, s.path)
+	}
+	return warning{fmt.Errorf("the completion scripts at %s were generated by another version of flyctl and may not work; regenerate them with fly completion", strings.Join(paths, ", "))}
+}
+
+// fixCompletions regenerates the stale completion scripts in the user's home
+// directory. Those elsewhere were installed by a package manager, which
+// updates them along with flyctl.
+func fixCompletions(ctx context.Context) error {
+	root := command.FromContext(ctx).Root()
+	home, _ := os.UserHomeDir()
+
+	var managed []string
+	for _, s := range staleCompletions(ctx) {
+		if !s.userOwned(home) {
+			managed = append(managed, s.path)
+			continue
+		}
+
+		script, err := generateCompletion(root, s)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(s.path, script, 0o644); err != nil {
+			return fmt.Errorf("failed updating %s: %w", s.path, err)
+		}
+		terminal.Debugf("regenerated completions at %s\n", s.path)
+	}
+
+	if len(managed) > 0 {
+		return warning{fmt.Errorf("the completion scripts at %s weren't updated as they're installed system wide; update flyctl with the package manager which installed them", strings.Join(managed, ", "))}
+	}
+	return nil
+}
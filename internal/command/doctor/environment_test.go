@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDockerContext(t *testing.T, dir, name, host string) {
+	sum := sha256.Sum256([]byte(name))
+	metaDir := filepath.Join(dir, "contexts", "meta", hex.EncodeToString(sum[:]))
+	require.NoError(t, os.MkdirAll(metaDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(metaDir, "meta.json"),
+		[]byte(`{"Name":"`+name+`","Endpoints":{"docker":{"Host":"`+host+`"}}}`), 0o600))
+}
+
+func TestCheckDockerContext(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+	t.Setenv("DOCKER_HOST", "")
+	ctx := context.Background()
+
+	// no config at all
+	assert.NoError(t, checkDockerContext(ctx))
+
+	socket := filepath.Join(dir, "docker.sock")
+	require.NoError(t, os.WriteFile(socket, nil, 0o600))
+	writeDockerContext(t, dir, "colima", "unix://"+socket)
+	writeDockerContext(t, dir, "gone", "unix://"+filepath.Join(dir, "missing.sock"))
+
+	config := filepath.Join(dir, "config.json")
+
+	require.NoError(t, os.WriteFile(config, []byte(`{"currentContext":"colima"}`), 0o600))
+	assert.NoError(t, checkDockerContext(ctx))
+
+	require.NoError(t, os.WriteFile(config, []byte(`{"currentContext":"gone"}`), 0o600))
+	assert.ErrorContains(t, checkDockerContext(ctx), "missing.sock")
+	assert.Error(t, fixDockerContext(ctx), "existing contexts aren't changed")
+
+	require.NoError(t, os.WriteFile(config, []byte(`{"currentContext":"deleted","auths":{}}`), 0o600))
+	assert.ErrorContains(t, checkDockerContext(ctx), "doesn't exist")
+	require.NoError(t, fixDockerContext(ctx))
+	assert.NoError(t, checkDockerContext(ctx))
+
+	data, err := os.ReadFile(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{}}`, string(data))
+
+	t.Setenv("DOCKER_HOST", "unix://"+filepath.Join(dir, "nope.sock"))
+	assert.ErrorContains(t, checkDockerContext(ctx), "DOCKER_HOST")
+}
+
+func TestWarning(t *testing.T) {
+	assert.True(t, isWarning(warning{assert.AnError}))
+	assert.False(t, isWarning(assert.AnError))
+}
+
+func TestCompletionScriptUserOwned(t *testing.T) {
+	home := "/home/fly"
+
+	for path, expected := range map[string]bool{
+		"/home/fly/.zsh/completions/_fly":                          true,
+		"/home/fly/.config/fish/completions/fly.fish":              true,
+		"/etc/bash_completion.d/fly":                               false,
+		"/opt/homebrew/share/zsh/site-functions/_fly":              false,
+		"/home/flyer/.local/share/bash-completion/completions/fly": false,
+	} {
+		assert.Equal(t, expected, completionScript{path: path}.userOwned(home), path)
+	}
+
+	assert.False(t, completionScript{path: "/etc/bash_completion.d/fly"}.userOwned(""))
+}
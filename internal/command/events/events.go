@@ -0,0 +1,258 @@
+// Package events implements the events command.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() (cmd *cobra.Command) {
+	const (
+		short = "Show a timeline of what happened to an app"
+		long  = short + `: deployments, machine events, volumes and snapshots
+and secret changes, merged in a single chronological feed.
+
+  fly events --around 14:32 --window 10m
+`
+	)
+
+	cmd = command.New("events", short, long, run, command.RequireSession, command.RequireAppName)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.Duration{
+			Name:        "since",
+			Description: "How far back to show events",
+			Default:     24 * time.Hour,
+		},
+		flag.String{
+			Name:        "around",
+			Description: "Only show events around this time, such as 14:32 or an RFC 3339 timestamp",
+		},
+		flag.Duration{
+			Name:        "window",
+			Description: "How long before and after --around to show events",
+			Default:     15 * time.Minute,
+		},
+		flag.StringSlice{
+			Name:        "type",
+			Description: "Only show events of these types: deploy, machine, volume or secret",
+		},
+		flag.String{
+			Name:        "subject",
+			Description: "Only show events of this machine, volume, secret or release",
+		},
+	)
+	return
+}
+
+func run(ctx context.Context) error {
+	var (
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+		now     = time.Now()
+	)
+
+	f := filter{
+		From:    now.Add(-flag.GetDuration(ctx, "since")),
+		Kinds:   flag.GetStringSlice(ctx, "type"),
+		Subject: flag.GetString(ctx, "subject"),
+	}
+	if err := validateKinds(f.Kinds); err != nil {
+		return err
+	}
+	if around := flag.GetString(ctx, "around"); around != "" {
+		t, err := parseAround(around, now)
+		if err != nil {
+			return err
+		}
+		window := flag.GetDuration(ctx, "window")
+		if window <= 0 {
+			return errors.New("--window must be positive")
+		}
+		f.From, f.To = t.Add(-window), t.Add(window)
+	}
+
+	events, err := collect(ctx, appName)
+	if err != nil {
+		return err
+	}
+	events = timeline(events, f)
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, events)
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintln(out, "No events found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, []string{e.Time.Local().Format(time.DateTime), e.Kind, e.Subject, e.Action, e.Details})
+	}
+	return render.Table(out, "", rows, "Time", "Type", "Subject", "Event", "Details")
+}
+
+// collect fetches the events of every source concurrently.
+func collect(ctx context.Context, appName string) ([]event, error) {
+	client := fly.ClientFromContext(ctx)
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err != nil {
+		return nil, err
+	}
+
+	sources := []func(context.Context) ([]event, error){
+		func(ctx context.Context) ([]event, error) { return deployEvents(ctx, client, appName) },
+		func(ctx context.Context) ([]event, error) { return machineEvents(ctx, flapsClient) },
+		func(ctx context.Context) ([]event, error) { return volumeEvents(ctx, flapsClient) },
+		func(ctx context.Context) ([]event, error) { return secretEvents(ctx, client, appName) },
+	}
+
+	results := make([][]event, len(sources))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, source := range sources {
+		i, source := i, source
+		g.Go(func() (err error) {
+			results[i], err = source(gctx)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var events []event
+	for _, r := range results {
+		events = append(events, r...)
+	}
+	return events, nil
+}
+
+func deployEvents(ctx context.Context, client *fly.Client, appName string) ([]event, error) {
+	releases, err := client.GetAppReleasesMachines(ctx, appName, "", 50)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	events := make([]event, 0, len(releases))
+	for _, r := range releases {
+		details := r.Description
+		if r.User.Email != "" {
+			details = fmt.Sprintf("%s by %s", details, r.User.Email)
+		}
+		events = append(events, event{
+			Time:    r.CreatedAt,
+			Kind:    kindDeploy,
+			Subject: "v" + strconv.Itoa(r.Version),
+			Action:  r.Status,
+			Details: details,
+		})
+	}
+	return events, nil
+}
+
+func machineEvents(ctx context.Context, flapsClient *flaps.Client) ([]event, error) {
+	machines, err := flapsClient.List(ctx, "include_deleted=true")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+
+	var events []event
+	for _, m := range machines {
+		for _, e := range m.Events {
+			details := e.Source
+			if e.Request != nil {
+				if code, err := e.Request.GetExitCode(); err == nil {
+					details = fmt.Sprintf("%s, exit code %d", details, code)
+				}
+				if exit := e.Request.ExitEvent; exit != nil && exit.OOMKilled {
+					details += ", out of memory"
+				} else if monitor := e.Request.MonitorEvent; monitor != nil && monitor.ExitEvent != nil && monitor.ExitEvent.OOMKilled {
+					details += ", out of memory"
+				}
+			}
+			events = append(events, event{
+				Time:    e.Time(),
+				Kind:    kindMachine,
+				Subject: m.ID,
+				Action:  fmt.Sprintf("%s %s", e.Type, e.Status),
+				Details: details,
+			})
+		}
+	}
+	return events, nil
+}
+
+func volumeEvents(ctx context.Context, flapsClient *flaps.Client) ([]event, error) {
+	volumes, err := flapsClient.GetVolumes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	var events []event
+	for _, v := range volumes {
+		events = append(events, event{
+			Time:    v.CreatedAt,
+			Kind:    kindVolume,
+			Subject: v.ID,
+			Action:  "created",
+			Details: fmt.Sprintf("%s, %dGB in %s", v.Name, v.SizeGb, v.Region),
+		})
+
+		snapshots, err := flapsClient.GetVolumeSnapshots(ctx, v.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots of %s: %w", v.ID, err)
+		}
+		for _, s := range snapshots {
+			events = append(events, event{
+				Time:    s.CreatedAt,
+				Kind:    kindVolume,
+				Subject: v.ID,
+				Action:  "snapshot " + s.Status,
+				Details: s.ID,
+			})
+		}
+	}
+	return events, nil
+}
+
+func secretEvents(ctx context.Context, client *fly.Client, appName string) ([]event, error) {
+	secrets, err := client.GetAppSecrets(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	events := make([]event, 0, len(secrets))
+	for _, s := range secrets {
+		events = append(events, event{
+			Time:    s.CreatedAt,
+			Kind:    kindSecret,
+			Subject: s.Name,
+			Action:  "set",
+			Details: "digest " + s.Digest,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of events of the timeline.
+const (
+	kindDeploy  = "deploy"
+	kindMachine = "machine"
+	kindVolume  = "volume"
+	kindSecret  = "secret"
+)
+
+var kinds = []string{kindDeploy, kindMachine, kindVolume, kindSecret}
+
+// event is an entry of the timeline of an app.
+type event struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Subject string    `json:"subject"`
+	Action  string    `json:"action"`
+	Details string    `json:"details,omitempty"`
+}
+
+// filter selects the events of the timeline.
+type filter struct {
+	From, To time.Time
+	Kinds    []string
+	Subject  string
+}
+
+func (f filter) match(e event) bool {
+	if e.Time.Before(f.From) || (!f.To.IsZero() && e.Time.After(f.To)) {
+		return false
+	}
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, e.Kind) {
+		return false
+	}
+	return f.Subject == "" || strings.HasPrefix(e.Subject, f.Subject)
+}
+
+// timeline returns the events matching f, oldest first.
+func timeline(events []event, f filter) []event {
+	matched := make([]event, 0, len(events))
+	for _, e := range events {
+		if f.match(e) {
+			matched = append(matched, e)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Time.Before(matched[j].Time) })
+	return matched
+}
+
+// parseAround parses the time given to --around: a time of day such as 14:32,
+// the most recent one before now, or an RFC 3339 timestamp.
+func parseAround(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		clock, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		t := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, now.Location())
+		if t.After(now) {
+			t = t.AddDate(0, 0, -1)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %s, expected a time of day such as 14:32 or an RFC 3339 timestamp", s)
+}
+
+func validateKinds(given []string) error {
+	for _, k := range given {
+		if !slices.Contains(kinds, k) {
+			return fmt.Errorf("unknown event type %s, expected one of %s", k, strings.Join(kinds, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAround(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	at, err := parseAround("14:32", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 14, 32, 0, 0, time.UTC), at)
+
+	// Times of day later than now are yesterday's
+	at, err = parseAround("16:05:30", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 9, 16, 5, 30, 0, time.UTC), at)
+
+	at, err = parseAround("2024-03-01T10:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), at)
+
+	_, err = parseAround("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestTimeline(t *testing.T) {
+	base := time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)
+	events := []event{
+		{Time: base.Add(30 * time.Minute), Kind: kindMachine, Subject: "148e"},
+		{Time: base.Add(10 * time.Minute), Kind: kindDeploy, Subject: "v3"},
+		{Time: base.Add(-time.Hour), Kind: kindSecret, Subject: "DATABASE_URL"},
+		{Time: base.Add(20 * time.Minute), Kind: kindMachine, Subject: "9080"},
+	}
+
+	all := timeline(events, filter{From: base.Add(-2 * time.Hour)})
+	require.Len(t, all, 4)
+	assert.Equal(t, "DATABASE_URL", all[0].Subject)
+	assert.Equal(t, "148e", all[3].Subject)
+
+	window := timeline(events, filter{From: base, To: base.Add(25 * time.Minute)})
+	assert.Equal(t, []string{"v3", "9080"}, []string{window[0].Subject, window[1].Subject})
+
+	machines := timeline(events, filter{From: base, Kinds: []string{kindMachine}, Subject: "14"})
+	require.Len(t, machines, 1)
+	assert.Equal(t, "148e", machines[0].Subject)
+
+	assert.NoError(t, validateKinds([]string{"deploy", "secret"}))
+	assert.Error(t, validateKinds([]string{"logs"}))
+}
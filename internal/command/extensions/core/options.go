@@ -0,0 +1,22 @@
+package extensions_core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/superfly/flyctl/gql"
+)
+
+// ParseOptions builds provisioning options from name=value pairs, passed to
+// the provider as is.
+func ParseOptions(pairs []string) (gql.AddOnOptions, error) {
+	options := gql.AddOnOptions{}
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid option %s, expected name=value", pair)
+		}
+		options[name] = value
+	}
+	return options, nil
+}
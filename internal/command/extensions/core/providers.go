@@ -0,0 +1,64 @@
+package extensions_core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/logger"
+)
+
+// AddOnTypes are the values of the AddOnType enum of the API. The API has no
+// query listing providers, so the catalog is built by looking each of them up.
+var AddOnTypes = []gql.AddOnType{
+	gql.AddOnTypeEnveloop,
+	gql.AddOnTypeKubernetes,
+	gql.AddOnTypePlanetscale,
+	gql.AddOnTypeRedis,
+	gql.AddOnTypeSentry,
+	gql.AddOnTypeSupabase,
+	gql.AddOnTypeTigris,
+	gql.AddOnTypeUpstashKafka,
+	gql.AddOnTypeUpstashRedis,
+	gql.AddOnTypeUpstashVector,
+	gql.AddOnTypeWafris,
+}
+
+// ListProviders returns the providers of types that can be provisioned
+// from flyctl, sorted by name. Internal providers are left out, as are types
+// the API has no provider for, like the legacy redis type.
+func ListProviders(ctx context.Context, client graphql.Client, types []gql.AddOnType) ([]gql.ExtensionProviderData, error) {
+	var (
+		providers []gql.ExtensionProviderData
+		firstErr  error
+	)
+
+	for _, t := range types {
+		resp, err := gql.GetAddOnProvider(ctx, client, string(t))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if l := logger.MaybeFromContext(ctx); l != nil {
+				l.Debugf("no provider for add-on type %s: %v", t, err)
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if provider := resp.AddOnProvider.ExtensionProviderData; !provider.Internal {
+			providers = append(providers, provider)
+		}
+	}
+
+	// Report the API failing rather than an empty catalog
+	if len(providers) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(providers, func(i, j int) bool { return providers[i].Name < providers[j].Name })
+	return providers, nil
+}
@@ -0,0 +1,47 @@
+package extensions_core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestListProviders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Name string `json:"name"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch req.Variables.Name {
+		case "tigris", "sentry":
+			fmt.Fprintf(w, `{"data":{"addOnProvider":{"name":%q,"displayName":"Provider","selectName":true}}}`, req.Variables.Name)
+		case "kubernetes":
+			fmt.Fprint(w, `{"data":{"addOnProvider":{"name":"kubernetes","internal":true}}}`)
+		default:
+			fmt.Fprint(w, `{"data":{"addOnProvider":null},"errors":[{"message":"Could not find AddOnProvider"}]}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL})
+
+	providers, err := ListProviders(context.Background(), client.GenqClient, AddOnTypes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sentry", "tigris"}, lo.Map(providers, func(p gql.ExtensionProviderData, _ int) string { return p.Name }))
+	assert.True(t, providers[0].SelectName)
+
+	_, err = ListProviders(context.Background(), client.GenqClient, []gql.AddOnType{gql.AddOnTypeRedis})
+	assert.ErrorContains(t, err, "Could not find AddOnProvider")
+}
@@ -0,0 +1,50 @@
+package extensions_core
+
+import (
+	"context"
+	"fmt"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+// RotateCredentials has the provider issue new credentials for the extension
+// name, and returns the secrets whose values differ from previous. The
+// previous credentials stop working.
+func RotateCredentials(ctx context.Context, name string, previous map[string]interface{}) (map[string]string, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	_ = `# @genqlient
+	mutation RotateAddOnCredentials($name: String!) {
+		resetAddOnPassword(input: {name: $name}) {
+			addOn {
+				environment
+			}
+		}
+	}
+	`
+
+	response, err := gql.RotateAddOnCredentials(ctx, client, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate the credentials of %s: %w", name, err)
+	}
+
+	environment, _ := response.ResetAddOnPassword.AddOn.Environment.(map[string]interface{})
+	return changedSecrets(previous, environment), nil
+}
+
+// changedSecrets returns the string values of environment that differ from
+// previous.
+func changedSecrets(previous, environment map[string]interface{}) map[string]string {
+	changed := map[string]string{}
+	for key, value := range environment {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if old, _ := previous[key].(string); old != s {
+			changed[key] = s
+		}
+	}
+	return changed
+}
@@ -0,0 +1,132 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/command/orgs"
+	"github.com/superfly/flyctl/internal/command/secrets"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+)
+
+func newCreate() (cmd *cobra.Command) {
+	const (
+		short = "Provision an extension from any provider"
+		long  = short + `. Without --provider, the provider is picked from
+'fly extensions providers list'. Whether a resource name and a primary region
+are asked for, and the terms of service shown, depends on the provider.
+Provider-specific options are passed with --option, and are documented by
+each provider.`
+	)
+
+	cmd = command.New("create", short, long, runCreate, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Org(),
+		flag.Region(),
+		extensions_core.SharedFlags,
+		flag.String{
+			Name:        "provider",
+			Description: "The extension provider, as listed by 'fly extensions providers list'",
+		},
+		flag.String{
+			Name:        "name",
+			Shorthand:   "n",
+			Description: "The name of the provisioned resource",
+		},
+		flag.StringSlice{
+			Name:        "option",
+			Description: "A provider option, as name=value. Can be specified multiple times",
+		},
+	)
+	return cmd
+}
+
+func runCreate(ctx context.Context) (err error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	var provider gql.ExtensionProviderData
+	if providerName := flag.GetString(ctx, "provider"); providerName != "" {
+		// Fail early on unknown providers, before selecting an organization
+		resp, err := gql.GetAddOnProvider(ctx, client, providerName)
+		if err != nil {
+			return err
+		}
+		provider = resp.AddOnProvider.ExtensionProviderData
+	} else if provider, err = selectProvider(ctx); err != nil {
+		return err
+	}
+
+	if flag.GetString(ctx, "name") != "" && !provider.SelectName {
+		return fmt.Errorf("%s %ss aren't named, remove --name", provider.DisplayName, provider.ResourceName)
+	}
+	if flag.GetRegion(ctx) != "" && !provider.SelectRegion {
+		return fmt.Errorf("%s %ss have no primary region, remove --region", provider.DisplayName, provider.ResourceName)
+	}
+
+	options, err := extensions_core.ParseOptions(flag.GetStringSlice(ctx, "option"))
+	if err != nil {
+		return err
+	}
+
+	params := extensions_core.ExtensionParams{
+		Provider:       provider.Name,
+		Options:        options,
+		OverrideRegion: flag.GetRegion(ctx),
+	}
+
+	if appName := appconfig.NameFromContext(ctx); appName != "" {
+		params.AppName = appName
+	} else {
+		org, err := orgs.OrgFromFlagOrSelect(ctx)
+		if err != nil {
+			return err
+		}
+		params.Organization = org
+	}
+
+	extension, err := extensions_core.ProvisionExtension(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	if extension.SetsSecrets {
+		err = secrets.DeploySecrets(ctx, gql.ToAppCompact(*extension.App), false, false)
+	}
+
+	return err
+}
+
+// selectProvider prompts for a provider of the catalog.
+func selectProvider(ctx context.Context) (gql.ExtensionProviderData, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	providers, err := extensions_core.ListProviders(ctx, client, extensions_core.AddOnTypes)
+	if err != nil {
+		return gql.ExtensionProviderData{}, err
+	}
+
+	options := lo.Map(providers, func(p gql.ExtensionProviderData, _ int) string {
+		return fmt.Sprintf("%s (%s)", p.DisplayName, p.ResourceName)
+	})
+
+	var index int
+	switch err := prompt.Select(ctx, &index, "Select a provider:", "", options...); {
+	case prompt.IsNonInteractive(err):
+		return gql.ExtensionProviderData{}, prompt.NonInteractiveError("--provider must be specified when not running interactively")
+	case err != nil:
+		return gql.ExtensionProviderData{}, err
+	}
+	return providers[index], nil
+}
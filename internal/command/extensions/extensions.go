@@ -32,6 +32,10 @@ func New() (cmd *cobra.Command) {
 		kafka.New(),
 		vector.New(),
 		enveloop.New(),
+		newCreate(),
+		newProviders(),
+		newStatus(),
+		newRotateCredentials(),
 	)
 	return
 }
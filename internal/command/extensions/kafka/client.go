@@ -0,0 +1,92 @@
+package kafka
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+// clusterFlag selects the cluster of the topic and consumer group commands,
+// which default to the app's cluster.
+var clusterFlag = flag.String{
+	Name:        "cluster",
+	Description: "The name of the Kafka cluster, defaults to the cluster of the app",
+}
+
+// firstString returns the first non-empty string value of keys in environment.
+func firstString(environment map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s, _ := environment[key].(string); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// newClient returns a Kafka client for the cluster given by --cluster, or
+// the app's cluster, authenticated with the cluster's SCRAM credentials.
+func newClient(ctx context.Context) (*kafkago.Client, string, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	name := flag.GetString(ctx, "cluster")
+	if name == "" {
+		appName := appconfig.NameFromContext(ctx)
+		if appName == "" {
+			return nil, "", errors.New("run this command in a Fly app directory or pass the cluster name with --cluster")
+		}
+		resp, err := gql.GetAppWithAddons(ctx, client, appName, gql.AddOnTypeUpstashKafka)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(resp.App.AddOns.Nodes) == 0 {
+			return nil, "", fmt.Errorf("no Kafka cluster found for %s. Provision one with 'flyctl ext kafka create'", appName)
+		}
+		name = resp.App.AddOns.Nodes[0].Name
+	}
+
+	response, err := gql.GetAddOn(ctx, client, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	environment, _ := response.AddOn.Environment.(map[string]interface{})
+	broker := firstString(environment, "KAFKA_BOOTSTRAP_SERVERS", "KAFKA_BROKER")
+	if broker == "" {
+		if u, err := url.Parse(response.AddOn.PublicUrl); err == nil && u.Host != "" {
+			broker = u.Host
+		}
+	}
+	username := firstString(environment, "KAFKA_USERNAME", "UPSTASH_KAFKA_REST_USERNAME")
+	password := firstString(environment, "KAFKA_PASSWORD", "UPSTASH_KAFKA_REST_PASSWORD")
+	if broker == "" || username == "" || password == "" {
+		return nil, "", fmt.Errorf("the connection details of %s are not available yet", name)
+	}
+	if _, _, err := net.SplitHostPort(broker); err != nil {
+		broker = net.JoinHostPort(broker, "9092")
+	}
+
+	mechanism, err := scram.Mechanism(scram.SHA256, username, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &kafkago.Client{
+		Addr:    kafkago.TCP(broker),
+		Timeout: 30 * time.Second,
+		Transport: &kafkago.Transport{
+			SASL: mechanism,
+			TLS:  &tls.Config{MinVersion: tls.VersionTLS12},
+		},
+	}, name, nil
+}
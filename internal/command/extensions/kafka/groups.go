@@ -0,0 +1,226 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func consumerGroups() (cmd *cobra.Command) {
+	const (
+		short = "Inspect the consumer groups of an Upstash Kafka cluster"
+		long  = short + "\n"
+	)
+
+	cmd = command.New("consumer-groups", short, long, nil)
+	cmd.Aliases = []string{"groups"}
+	cmd.AddCommand(consumerGroupsList(), consumerGroupsLag())
+	return cmd
+}
+
+func consumerGroupsList() (cmd *cobra.Command) {
+	const (
+		long  = `List the consumer groups of an Upstash Kafka cluster`
+		short = long
+		usage = "list"
+	)
+
+	cmd = command.New(usage, short, long, runConsumerGroupsList, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		clusterFlag,
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+func runConsumerGroupsList(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	client, cluster, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListGroups(ctx, &kafkago.ListGroupsRequest{})
+	if err == nil {
+		err = resp.Error
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list the consumer groups of %s: %w", cluster, err)
+	}
+
+	groups := make([]string, 0, len(resp.Groups))
+	for _, g := range resp.Groups {
+		groups = append(groups, g.GroupID)
+	}
+	sort.Strings(groups)
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, groups)
+	}
+
+	rows := make([][]string, 0, len(groups))
+	for _, g := range groups {
+		rows = append(rows, []string{g})
+	}
+	return render.Table(out, "", rows, "Group")
+}
+
+func consumerGroupsLag() (cmd *cobra.Command) {
+	const (
+		long = `Show how far a consumer group is behind, per topic partition. The lag
+is the number of messages between the group's committed offset and the end of
+the partition.`
+		short = "Show the lag of a consumer group"
+		usage = "lag <group>"
+	)
+
+	cmd = command.New(usage, short, long, runConsumerGroupsLag, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		clusterFlag,
+		flag.String{
+			Name:        "topic",
+			Description: "Only show the lag of this topic",
+		},
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+// partitionLag is the position of a consumer group on a topic partition.
+type partitionLag struct {
+	Topic           string
+	Partition       int
+	CommittedOffset int64
+	LastOffset      int64
+	Lag             int64
+}
+
+func runConsumerGroupsLag(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+	group := flag.FirstArg(ctx)
+
+	client, cluster, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	var topics []string
+	if topic := flag.GetString(ctx, "topic"); topic != "" {
+		topics = []string{topic}
+	}
+	metadata, err := client.Metadata(ctx, &kafkago.MetadataRequest{Topics: topics})
+	if err != nil {
+		return fmt.Errorf("failed to read the topics of %s: %w", cluster, err)
+	}
+
+	partitions := map[string][]int{}
+	ends := map[string][]kafkago.OffsetRequest{}
+	for _, t := range metadata.Topics {
+		if t.Error != nil {
+			return fmt.Errorf("failed to read topic %s: %w", t.Name, t.Error)
+		}
+		if t.Internal {
+			continue
+		}
+		for _, p := range t.Partitions {
+			partitions[t.Name] = append(partitions[t.Name], p.ID)
+			ends[t.Name] = append(ends[t.Name], kafkago.LastOffsetOf(p.ID))
+		}
+	}
+
+	committed, err := client.OffsetFetch(ctx, &kafkago.OffsetFetchRequest{GroupID: group, Topics: partitions})
+	if err == nil {
+		err = committed.Error
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the offsets of consumer group %s: %w", group, err)
+	}
+
+	last, err := client.ListOffsets(ctx, &kafkago.ListOffsetsRequest{Topics: ends})
+	if err != nil {
+		return fmt.Errorf("failed to read the partition offsets of %s: %w", cluster, err)
+	}
+	lags := consumerLag(committed.Topics, last.Topics)
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, lags)
+	}
+
+	if len(lags) == 0 {
+		fmt.Fprintf(out, "Consumer group %s has no committed offsets in %s\n", group, cluster)
+		return nil
+	}
+
+	var total int64
+	rows := make([][]string, 0, len(lags))
+	for _, l := range lags {
+		total += l.Lag
+		rows = append(rows, []string{
+			l.Topic,
+			strconv.Itoa(l.Partition),
+			strconv.FormatInt(l.CommittedOffset, 10),
+			strconv.FormatInt(l.LastOffset, 10),
+			strconv.FormatInt(l.Lag, 10),
+		})
+	}
+	if err := render.Table(out, "", rows, "Topic", "Partition", "Committed Offset", "Last Offset", "Lag"); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Total lag: %d\n", total)
+	return nil
+}
+
+// consumerLag returns the lag of each partition a consumer group committed an
+// offset to, sorted by topic and partition.
+func consumerLag(committed map[string][]kafkago.OffsetFetchPartition, last map[string][]kafkago.PartitionOffsets) []partitionLag {
+	lastOffsets := map[string]map[int]int64{}
+	for topic, offsets := range last {
+		lastOffsets[topic] = map[int]int64{}
+		for _, o := range offsets {
+			lastOffsets[topic][o.Partition] = o.LastOffset
+		}
+	}
+
+	var lags []partitionLag
+	for topic, offsets := range committed {
+		for _, o := range offsets {
+			// Partitions the group never committed to aren't consumed by it
+			if o.Error != nil || o.CommittedOffset < 0 {
+				continue
+			}
+			end := lastOffsets[topic][o.Partition]
+			lags = append(lags, partitionLag{
+				Topic:           topic,
+				Partition:       o.Partition,
+				CommittedOffset: o.CommittedOffset,
+				LastOffset:      end,
+				Lag:             max(end-o.CommittedOffset, 0),
+			})
+		}
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].Topic != lags[j].Topic {
+			return lags[i].Topic < lags[j].Topic
+		}
+		return lags[i].Partition < lags[j].Partition
+	})
+	return lags
+}
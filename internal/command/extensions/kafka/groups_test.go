@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsumerLag(t *testing.T) {
+	committed := map[string][]kafkago.OffsetFetchPartition{
+		"orders": {
+			{Partition: 1, CommittedOffset: 90},
+			{Partition: 0, CommittedOffset: 100},
+			// Never committed to
+			{Partition: 2, CommittedOffset: -1},
+		},
+		"events": {
+			// Ahead of the last offset read, e.g. after the partition was truncated
+			{Partition: 0, CommittedOffset: 50},
+			{Partition: 1, CommittedOffset: 10, Error: errors.New("unknown partition")},
+		},
+	}
+	last := map[string][]kafkago.PartitionOffsets{
+		"orders": {{Partition: 0, LastOffset: 100}, {Partition: 1, LastOffset: 120}, {Partition: 2, LastOffset: 7}},
+		"events": {{Partition: 0, LastOffset: 40}},
+	}
+
+	assert.Equal(t, []partitionLag{
+		{Topic: "events", Partition: 0, CommittedOffset: 50, LastOffset: 40, Lag: 0},
+		{Topic: "orders", Partition: 0, CommittedOffset: 100, LastOffset: 100, Lag: 0},
+		{Topic: "orders", Partition: 1, CommittedOffset: 90, LastOffset: 120, Lag: 30},
+	}, consumerLag(committed, last))
+
+	assert.Empty(t, consumerLag(nil, last))
+}
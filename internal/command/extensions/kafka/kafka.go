@@ -13,7 +13,7 @@ func New() (cmd *cobra.Command) {
 	)
 
 	cmd = command.New("kafka", short, long, nil)
-	cmd.AddCommand(create(), update(), list(), dashboard(), destroy(), status())
+	cmd.AddCommand(create(), update(), list(), dashboard(), destroy(), status(), topics(), consumerGroups())
 
 	return cmd
 }
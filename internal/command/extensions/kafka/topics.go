@@ -0,0 +1,218 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func topics() (cmd *cobra.Command) {
+	const (
+		short = "Manage the topics of an Upstash Kafka cluster"
+		long  = short + "\n"
+	)
+
+	cmd = command.New("topics", short, long, nil)
+	cmd.AddCommand(topicsList(), topicsCreate(), topicsDelete())
+	return cmd
+}
+
+func topicsList() (cmd *cobra.Command) {
+	const (
+		long  = `List the topics of an Upstash Kafka cluster`
+		short = long
+		usage = "list"
+	)
+
+	cmd = command.New(usage, short, long, runTopicsList, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		clusterFlag,
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+func runTopicsList(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	client, cluster, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := client.Metadata(ctx, &kafkago.MetadataRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list the topics of %s: %w", cluster, err)
+	}
+
+	type topic struct {
+		Name              string
+		Partitions        int
+		ReplicationFactor int
+	}
+	var list []topic
+	for _, t := range metadata.Topics {
+		if t.Internal {
+			continue
+		}
+		replicas := 0
+		if len(t.Partitions) > 0 {
+			replicas = len(t.Partitions[0].Replicas)
+		}
+		list = append(list, topic{Name: t.Name, Partitions: len(t.Partitions), ReplicationFactor: replicas})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, list)
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, t := range list {
+		rows = append(rows, []string{t.Name, strconv.Itoa(t.Partitions), strconv.Itoa(t.ReplicationFactor)})
+	}
+	return render.Table(out, "", rows, "Name", "Partitions", "Replication Factor")
+}
+
+func topicsCreate() (cmd *cobra.Command) {
+	const (
+		long  = `Create a topic in an Upstash Kafka cluster`
+		short = long
+		usage = "create <topic>"
+	)
+
+	cmd = command.New(usage, short, long, runTopicsCreate, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		clusterFlag,
+		flag.Int{
+			Name:        "partitions",
+			Description: "The number of partitions of the topic",
+			Default:     1,
+		},
+		flag.String{
+			Name:        "retention",
+			Description: "How long messages are kept, such as 168h. Defaults to the cluster's retention",
+		},
+		flag.String{
+			Name:        "cleanup-policy",
+			Description: "How old messages are discarded, delete or compact",
+		},
+	)
+	return cmd
+}
+
+func runTopicsCreate(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+	name := flag.FirstArg(ctx)
+
+	config := kafkago.TopicConfig{
+		Topic:             name,
+		NumPartitions:     flag.GetInt(ctx, "partitions"),
+		ReplicationFactor: -1,
+	}
+
+	if retention := flag.GetString(ctx, "retention"); retention != "" {
+		d, err := time.ParseDuration(retention)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid retention %s, expected a duration such as 168h", retention)
+		}
+		config.ConfigEntries = append(config.ConfigEntries, kafkago.ConfigEntry{ConfigName: "retention.ms", ConfigValue: strconv.FormatInt(d.Milliseconds(), 10)})
+	}
+	switch policy := flag.GetString(ctx, "cleanup-policy"); policy {
+	case "":
+	case "delete", "compact":
+		config.ConfigEntries = append(config.ConfigEntries, kafkago.ConfigEntry{ConfigName: "cleanup.policy", ConfigValue: policy})
+	default:
+		return fmt.Errorf("invalid cleanup policy %s, expected delete or compact", policy)
+	}
+
+	client, cluster, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.CreateTopics(ctx, &kafkago.CreateTopicsRequest{Topics: []kafkago.TopicConfig{config}})
+	if err == nil {
+		err = resp.Errors[name]
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create topic %s in %s: %w", name, cluster, err)
+	}
+
+	fmt.Fprintf(out, "Created topic %s in %s\n", name, cluster)
+	return nil
+}
+
+func topicsDelete() (cmd *cobra.Command) {
+	const (
+		long  = `Permanently delete a topic and its messages from an Upstash Kafka cluster`
+		short = long
+		usage = "delete <topic>"
+	)
+
+	cmd = command.New(usage, short, long, runTopicsDelete, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Aliases = []string{"rm"}
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		clusterFlag,
+		extensions_core.SharedFlags,
+	)
+	return cmd
+}
+
+func runTopicsDelete(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	name := flag.FirstArg(ctx)
+
+	client, cluster, err := newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Deleting topic %s of %s and its messages is not reversible. Continue?", name, cluster); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	resp, err := client.DeleteTopics(ctx, &kafkago.DeleteTopicsRequest{Topics: []string{name}})
+	if err == nil {
+		err = resp.Errors[name]
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete topic %s from %s: %w", name, cluster, err)
+	}
+
+	fmt.Fprintf(io.Out, "Deleted topic %s from %s\n", name, cluster)
+	return nil
+}
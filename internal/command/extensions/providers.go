@@ -0,0 +1,83 @@
+package extensions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newProviders() (cmd *cobra.Command) {
+	const (
+		short = "Explore the extension providers available to provision"
+		long  = short + "\n"
+	)
+
+	cmd = command.New("providers", short, long, nil)
+	cmd.AddCommand(newProvidersList())
+	return cmd
+}
+
+func newProvidersList() (cmd *cobra.Command) {
+	const (
+		short = "List the extension providers and what provisioning asks for"
+		long  = short + `. The Prompts column lists what 'fly extensions create'
+asks for: a resource name, a primary region and agreeing to the provider's
+terms of service, unless already agreed to.`
+	)
+
+	cmd = command.New("list", short, long, runProvidersList, command.RequireSession)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd, flag.JSONOutput())
+	return cmd
+}
+
+func runProvidersList(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	providers, err := extensions_core.ListProviders(ctx, client, extensions_core.AddOnTypes)
+	if err != nil {
+		return err
+	}
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, providers)
+	}
+
+	rows := make([][]string, 0, len(providers))
+	for _, p := range providers {
+		beta := ""
+		if p.Beta {
+			beta = "yes"
+		}
+
+		rows = append(rows, []string{p.Name, p.DisplayName, p.ResourceName, strings.Join(providerPrompts(p), ", "), beta})
+	}
+
+	return render.Table(out, "", rows, "Name", "Provider", "Resource", "Prompts", "Beta")
+}
+
+// providerPrompts lists what provisioning from p asks for.
+func providerPrompts(p gql.ExtensionProviderData) []string {
+	var prompts []string
+	if p.SelectName {
+		prompts = append(prompts, "name")
+	}
+	if p.SelectRegion {
+		prompts = append(prompts, "region")
+	}
+	if p.TosAgreement != "" {
+		prompts = append(prompts, "terms")
+	}
+	return prompts
+}
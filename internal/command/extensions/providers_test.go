@@ -0,0 +1,22 @@
+package extensions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestProviderPrompts(t *testing.T) {
+	cases := []struct {
+		provider gql.ExtensionProviderData
+		want     []string
+	}{
+		{gql.ExtensionProviderData{}, nil},
+		{gql.ExtensionProviderData{SelectName: true, SelectRegion: true, TosAgreement: "Agree?"}, []string{"name", "region", "terms"}},
+		{gql.ExtensionProviderData{SelectRegion: true}, []string{"region"}},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, providerPrompts(tc.provider))
+	}
+}
@@ -0,0 +1,108 @@
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/command/secrets"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newRotateCredentials() (cmd *cobra.Command) {
+	const (
+		short = "Rotate the credentials of an extension"
+		long  = short + `. New credentials are issued by the provider and set as
+secrets of the attached app, whose machines are then restarted one at a time so
+they pick up the new credentials. The previous credentials stop working.`
+		usage = "rotate-credentials <name>"
+	)
+
+	cmd = command.New(usage, short, long, runRotateCredentials, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		extensions_core.SharedFlags,
+		flag.Detach(),
+		flag.Bool{
+			Name:        "stage",
+			Description: "Set the secrets but skip restarting the app's machines",
+		},
+	)
+	return cmd
+}
+
+func runRotateCredentials(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+		name   = flag.FirstArg(ctx)
+	)
+
+	response, err := gql.GetAddOn(ctx, client.GenqClient, name)
+	if err != nil {
+		return err
+	}
+	addOn := response.AddOn
+	appName := addOn.App.Name
+
+	if !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("The current credentials of %s will stop working. Continue?", name)
+		if appName != "" {
+			msg = fmt.Sprintf("The current credentials of %s will stop working, and the machines of %s will be restarted. Continue?", name, appName)
+		}
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	previous, _ := addOn.Environment.(map[string]interface{})
+	changed, err := extensions_core.RotateCredentials(ctx, name, previous)
+	if err != nil {
+		return err
+	}
+
+	if len(changed) == 0 {
+		fmt.Fprintf(io.Out, "Rotated the credentials of %s, no secrets changed\n", name)
+		return nil
+	}
+
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if appName == "" {
+		fmt.Fprintf(io.Out, "Rotated the credentials of %s. It isn't attached to an app, update these secrets of the apps using it:\n", name)
+		for _, key := range keys {
+			fmt.Fprintf(io.Out, "  %s\n", key)
+		}
+		return nil
+	}
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Rotated the credentials of %s, updating secrets of %s:\n", name, appName)
+	for _, key := range keys {
+		fmt.Fprintf(io.Out, "  %s\n", key)
+	}
+	return secrets.SetSecretsAndDeploy(ctx, app, changed, flag.GetBool(ctx, "stage"), flag.GetDetach(ctx))
+}
@@ -0,0 +1,83 @@
+package sentry_ext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/command/secrets"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func link() (cmd *cobra.Command) {
+	const (
+		short = "Link an app to a Sentry environment"
+		long  = short + `. Events of the app are reported under the environment,
+set as the SENTRY_ENVIRONMENT secret, so staging and production apps sharing a
+Sentry organization can be told apart.`
+	)
+
+	cmd = command.New("link", short, long, runLink, command.RequireSession, command.RequireAppName)
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		extensions_core.SharedFlags,
+		deployFlags,
+		flag.String{
+			Name:        "environment",
+			Shorthand:   "e",
+			Description: "The Sentry environment, such as production or staging",
+		},
+	)
+	cmd.Args = cobra.NoArgs
+	return cmd
+}
+
+func runLink(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		client  = fly.ClientFromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	environment := flag.GetString(ctx, "environment")
+	if environment == "" {
+		return errors.New("--environment is required")
+	}
+
+	extension, _, err := extensions_core.Discover(ctx, gql.AddOnTypeSentry)
+	if err != nil {
+		return err
+	}
+
+	response, err := gql.GetAddOn(ctx, client.GenqClient, extension.Name)
+	if err != nil {
+		return err
+	}
+	addOn := response.AddOn
+
+	options, _ := addOn.Options.(map[string]interface{})
+	if options == nil {
+		options = make(map[string]interface{})
+	}
+	options["environment"] = environment
+
+	if _, err := gql.UpdateAddOn(ctx, client.GenqClient, addOn.Id, addOn.AddOnPlan.Id, []string{}, options); err != nil {
+		return err
+	}
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "%s now reports to the %s Sentry environment\n", appName, environment)
+	return secrets.SetSecretsAndDeploy(ctx, app, map[string]string{"SENTRY_ENVIRONMENT": environment}, flag.GetBool(ctx, "stage"), flag.GetDetach(ctx))
+}
@@ -0,0 +1,86 @@
+package sentry_ext
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/command/secrets"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+var deployFlags = flag.Set{
+	flag.Detach(),
+	flag.Bool{
+		Name:        "stage",
+		Description: "Set the secrets but skip deploying them to the app's machines",
+	},
+}
+
+func rotateDSN() (cmd *cobra.Command) {
+	const (
+		short = "Replace the Sentry DSN of an app"
+		long  = short + `. A new DSN is issued and set as the SENTRY_DSN secret,
+and events sent with the previous one are rejected. Use it when the DSN leaked.`
+	)
+
+	cmd = command.New("rotate-dsn", short, long, runRotateDSN, command.RequireSession, command.RequireAppName)
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		extensions_core.SharedFlags,
+		deployFlags,
+	)
+	cmd.Args = cobra.NoArgs
+	return cmd
+}
+
+func runRotateDSN(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		client  = fly.ClientFromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	extension, _, err := extensions_core.Discover(ctx, gql.AddOnTypeSentry)
+	if err != nil {
+		return err
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Events sent with the current DSN of %s will be rejected. Continue?", appName); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	changed, err := extensions_core.RotateCredentials(ctx, extension.Name, nil)
+	if err != nil {
+		return err
+	}
+	dsn := changed["SENTRY_DSN"]
+	if dsn == "" {
+		return fmt.Errorf("no new DSN was issued for %s", extension.Name)
+	}
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Issued a new Sentry DSN for %s\n", appName)
+	return secrets.SetSecretsAndDeploy(ctx, app, map[string]string{"SENTRY_DSN": dsn}, flag.GetBool(ctx, "stage"), flag.GetDetach(ctx))
+}
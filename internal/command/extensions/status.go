@@ -0,0 +1,169 @@
+package extensions
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/orgs"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newStatus() (cmd *cobra.Command) {
+	const (
+		short = "Show the state of every extension of an app or organization"
+		long  = short + `. In an app directory, or with --app, the app's extensions
+are listed, otherwise those of the organization.`
+	)
+
+	cmd = command.New("status", short, long, runStatus, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Org(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+// extensionStatus is the state of a provisioned extension.
+type extensionStatus struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Plan     string `json:"plan"`
+	Region   string `json:"region"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	App      string `json:"app,omitempty"`
+	Org      string `json:"org"`
+}
+
+func runStatus(ctx context.Context) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+		client   = fly.ClientFromContext(ctx).GenqClient
+	)
+
+	_ = `# @genqlient
+	fragment ExtensionStatusData on AddOn {
+		name
+		status
+		errorMessage
+		primaryRegion
+		addOnPlan {
+			displayName
+		}
+		addOnProvider {
+			displayName
+		}
+		app {
+			name
+		}
+		organization {
+			slug
+		}
+	}
+
+	query ListAppExtensionsStatus($appName: String!) {
+		app(name: $appName) {
+			addOns {
+				nodes {
+					...ExtensionStatusData
+				}
+			}
+		}
+	}
+
+	query ListOrgExtensionsStatus($orgSlug: String!) {
+		organization(slug: $orgSlug) {
+			addOns {
+				nodes {
+					...ExtensionStatusData
+				}
+			}
+		}
+	}
+	`
+
+	appName := appconfig.NameFromContext(ctx)
+	var orgSlug string
+	if appName == "" {
+		org, err := orgs.OrgFromFlagOrSelect(ctx)
+		if err != nil {
+			return err
+		}
+		orgSlug = org.Slug
+	}
+
+	statuses, err := listExtensionStatuses(ctx, client, appName, orgSlug)
+	if err != nil {
+		return err
+	}
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(io.Out, statuses)
+	}
+
+	rows := make([][]string, 0, len(statuses))
+	for _, s := range statuses {
+		status := s.Status
+		switch status {
+		case "ready", "active":
+			status = colorize.Green(status)
+		case "error", "failed", "suspended":
+			status = colorize.Red(status)
+		default:
+			status = colorize.Yellow(status)
+		}
+		rows = append(rows, []string{s.Name, s.Provider, s.Plan, s.Region, status, s.App, s.Error})
+	}
+
+	return render.Table(io.Out, "", rows, "Name", "Provider", "Plan", "Region", "Status", "App", "Last Error")
+}
+
+// listExtensionStatuses returns the state of the extensions of the app, or of
+// the organization when appName is empty.
+func listExtensionStatuses(ctx context.Context, client graphql.Client, appName, orgSlug string) ([]extensionStatus, error) {
+	var nodes []gql.ExtensionStatusData
+	if appName != "" {
+		resp, err := gql.ListAppExtensionsStatus(ctx, client, appName)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range resp.App.AddOns.Nodes {
+			nodes = append(nodes, node.ExtensionStatusData)
+		}
+	} else {
+		resp, err := gql.ListOrgExtensionsStatus(ctx, client, orgSlug)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range resp.Organization.AddOns.Nodes {
+			nodes = append(nodes, node.ExtensionStatusData)
+		}
+	}
+
+	statuses := make([]extensionStatus, 0, len(nodes))
+	for _, node := range nodes {
+		statuses = append(statuses, extensionStatus{
+			Name:     node.Name,
+			Provider: node.AddOnProvider.DisplayName,
+			Plan:     node.AddOnPlan.DisplayName,
+			Region:   node.PrimaryRegion,
+			Status:   node.Status,
+			Error:    node.ErrorMessage,
+			App:      node.App.Name,
+			Org:      node.Organization.Slug,
+		})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,57 @@
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestListExtensionStatuses(t *testing.T) {
+	const nodes = `{"addOns": {"nodes": [
+		{"name": "cache", "status": "ready", "primaryRegion": "iad", "addOnPlan": {"displayName": "Pay as you go"},
+		 "addOnProvider": {"displayName": "Upstash Redis"}, "app": {"name": "web"}, "organization": {"slug": "acme"}},
+		{"name": "db", "status": "error", "errorMessage": "quota exceeded", "addOnProvider": {"displayName": "Supabase"},
+		 "app": null, "organization": {"slug": "acme"}}
+	]}}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			OperationName string            `json:"operationName"`
+			Variables     map[string]string `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		switch req.OperationName {
+		case "ListAppExtensionsStatus":
+			assert.Equal(t, "web", req.Variables["appName"])
+			fmt.Fprintf(w, `{"data": {"app": %s}}`, nodes)
+		case "ListOrgExtensionsStatus":
+			assert.Equal(t, "acme", req.Variables["orgSlug"])
+			fmt.Fprintf(w, `{"data": {"organization": %s}}`, nodes)
+		default:
+			t.Errorf("unexpected operation %s", req.OperationName)
+		}
+	}))
+	defer srv.Close()
+
+	client := fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL})
+	want := []extensionStatus{
+		{Name: "cache", Provider: "Upstash Redis", Plan: "Pay as you go", Region: "iad", Status: "ready", App: "web", Org: "acme"},
+		{Name: "db", Provider: "Supabase", Status: "error", Error: "quota exceeded", Org: "acme"},
+	}
+
+	statuses, err := listExtensionStatuses(context.Background(), client.GenqClient, "web", "")
+	require.NoError(t, err)
+	assert.Equal(t, want, statuses)
+
+	statuses, err = listExtensionStatuses(context.Background(), client.GenqClient, "", "acme")
+	require.NoError(t, err)
+	assert.Equal(t, want, statuses)
+}
@@ -0,0 +1,185 @@
+package tigris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+var corsMethods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete}
+
+func cors() *cobra.Command {
+	const (
+		short = "Manage the CORS rules of a Tigris storage bucket"
+		long  = short + `. Rules are set through the bucket's S3 API, with the
+credentials in AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when they are set,
+and fetched from Fly.io otherwise.`
+	)
+
+	cmd := command.New("cors", short, long, nil)
+	cmd.AddCommand(corsShow(), corsSet(), corsClear())
+
+	return cmd
+}
+
+func corsShow() *cobra.Command {
+	const (
+		short = "Show the CORS rules of a Tigris storage bucket"
+		long  = short + "\n"
+		usage = "show <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runCorsShow, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runCorsShow(ctx context.Context) error {
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	var rules []types.CORSRule
+	out, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: aws.String(bucket)})
+	switch {
+	case isErrorCode(err, "NoSuchCORSConfiguration"):
+	case err != nil:
+		return fmt.Errorf("failed to get the CORS rules of %s: %w", bucket, err)
+	default:
+		rules = out.CORSRules
+	}
+
+	rows := lo.Map(rules, func(r types.CORSRule, _ int) []string {
+		maxAge := ""
+		if r.MaxAgeSeconds != nil {
+			maxAge = fmt.Sprint(*r.MaxAgeSeconds)
+		}
+		return []string{strings.Join(r.AllowedOrigins, ", "), strings.Join(r.AllowedMethods, ", "), strings.Join(r.AllowedHeaders, ", "), maxAge}
+	})
+	return render.Table(iostreams.FromContext(ctx).Out, "", rows, "Origins", "Methods", "Headers", "Max Age")
+}
+
+func corsSet() *cobra.Command {
+	const (
+		short = "Allow browsers to access a Tigris storage bucket from other origins"
+		long  = short + `. The rule replaces the bucket's existing CORS rules.`
+		usage = "set <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runCorsSet, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.StringSlice{
+			Name:        "origin",
+			Description: "An origin allowed to access the bucket, such as https://example.com or *. Can be specified multiple times",
+		},
+		flag.StringSlice{
+			Name:        "method",
+			Description: "An HTTP method allowed from the origins, one of " + strings.Join(corsMethods, ", ") + ". Can be specified multiple times",
+			Default:     []string{http.MethodGet, http.MethodHead},
+		},
+		flag.StringSlice{
+			Name:        "header",
+			Description: "A request header allowed from the origins. Can be specified multiple times",
+		},
+		flag.Int{
+			Name:        "max-age",
+			Description: "How long browsers may cache the response to preflight requests, in seconds",
+			Default:     3600,
+		},
+	)
+
+	return cmd
+}
+
+func runCorsSet(ctx context.Context) error {
+	rule, err := newCORSRule(flag.GetStringSlice(ctx, "origin"), flag.GetStringSlice(ctx, "method"), flag.GetStringSlice(ctx, "header"), flag.GetInt(ctx, "max-age"))
+	if err != nil {
+		return err
+	}
+
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket:            aws.String(bucket),
+		CORSConfiguration: &types.CORSConfiguration{CORSRules: []types.CORSRule{rule}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set the CORS rules of %s: %w", bucket, err)
+	}
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "Set the CORS rules of %s\n", bucket)
+	return nil
+}
+
+// newCORSRule builds a CORS rule allowing the given methods and headers from
+// origins.
+func newCORSRule(origins, methods, headers []string, maxAge int) (types.CORSRule, error) {
+	if len(origins) == 0 {
+		return types.CORSRule{}, errors.New("specify at least one --origin")
+	}
+	if maxAge < 0 {
+		return types.CORSRule{}, errors.New("--max-age must be positive")
+	}
+
+	rule := types.CORSRule{
+		AllowedOrigins: origins,
+		AllowedHeaders: headers,
+		MaxAgeSeconds:  aws.Int32(int32(maxAge)),
+	}
+	for _, m := range methods {
+		m = strings.ToUpper(m)
+		if !slices.Contains(corsMethods, m) {
+			return types.CORSRule{}, fmt.Errorf("unsupported method %s, expected one of %s", m, strings.Join(corsMethods, ", "))
+		}
+		rule.AllowedMethods = append(rule.AllowedMethods, m)
+	}
+	return rule, nil
+}
+
+func corsClear() *cobra.Command {
+	const (
+		short = "Remove the CORS rules of a Tigris storage bucket"
+		long  = short + "\n"
+		usage = "clear <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runCorsClear, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runCorsClear(ctx context.Context) error {
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.DeleteBucketCors(ctx, &s3.DeleteBucketCorsInput{Bucket: aws.String(bucket)}); err != nil {
+		return fmt.Errorf("failed to remove the CORS rules of %s: %w", bucket, err)
+	}
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "Removed the CORS rules of %s\n", bucket)
+	return nil
+}
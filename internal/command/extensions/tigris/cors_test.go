@@ -0,0 +1,22 @@
+package tigris
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCORSRule(t *testing.T) {
+	rule, err := newCORSRule([]string{"https://example.com"}, []string{"get", "PUT"}, []string{"Content-Type"}, 600)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com"}, rule.AllowedOrigins)
+	assert.Equal(t, []string{"GET", "PUT"}, rule.AllowedMethods)
+	assert.Equal(t, []string{"Content-Type"}, rule.AllowedHeaders)
+	assert.Equal(t, int32(600), *rule.MaxAgeSeconds)
+
+	_, err = newCORSRule(nil, []string{"GET"}, nil, 600)
+	assert.ErrorContains(t, err, "specify at least one --origin")
+	_, err = newCORSRule([]string{"*"}, []string{"PATCH"}, nil, 600)
+	assert.ErrorContains(t, err, "unsupported method PATCH")
+}
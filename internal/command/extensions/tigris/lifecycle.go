@@ -0,0 +1,243 @@
+package tigris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func lifecycle() *cobra.Command {
+	const (
+		short = "Manage the lifecycle rules of a Tigris storage bucket"
+		long  = short + `. Rules are set through the bucket's S3 API, with the
+credentials in AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when they are set,
+and fetched from Fly.io otherwise.`
+	)
+
+	cmd := command.New("lifecycle", short, long, nil)
+	cmd.AddCommand(lifecycleShow(), lifecycleSet(), lifecycleClear())
+
+	return cmd
+}
+
+func lifecycleShow() *cobra.Command {
+	const (
+		short = "Show the lifecycle rules of a Tigris storage bucket"
+		long  = short + "\n"
+		usage = "show <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runLifecycleShow, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runLifecycleShow(ctx context.Context) error {
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	rules, err := getLifecycleRules(ctx, client, bucket)
+	if err != nil {
+		return err
+	}
+
+	rows := lo.Map(rules, func(r types.LifecycleRule, _ int) []string {
+		var expire, abort string
+		if r.Expiration != nil && r.Expiration.Days != nil {
+			expire = strconv.Itoa(int(*r.Expiration.Days))
+		}
+		if r.AbortIncompleteMultipartUpload != nil && r.AbortIncompleteMultipartUpload.DaysAfterInitiation != nil {
+			abort = strconv.Itoa(int(*r.AbortIncompleteMultipartUpload.DaysAfterInitiation))
+		}
+		return []string{lo.Ternary(rulePrefix(r) == "", "*", rulePrefix(r)), expire, abort}
+	})
+	return render.Table(iostreams.FromContext(ctx).Out, "", rows, "Prefix", "Expire Days", "Abort Incomplete Upload Days")
+}
+
+func lifecycleSet() *cobra.Command {
+	const (
+		short = "Expire objects of a Tigris storage bucket"
+		long  = short + `. Each prefix has at most one rule, setting a rule for
+a prefix replaces its previous one. Without --prefix, the rule applies to
+every object in the bucket.`
+		usage = "set <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runLifecycleSet, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.String{
+			Name:        "prefix",
+			Description: "Only apply the rule to objects whose key starts with this prefix",
+		},
+		flag.Int{
+			Name:        "expire-days",
+			Description: "Delete objects this many days after they were created",
+		},
+		flag.Int{
+			Name:        "abort-incomplete-upload-days",
+			Description: "Abort multipart uploads that are not complete this many days after they started",
+		},
+	)
+
+	return cmd
+}
+
+func runLifecycleSet(ctx context.Context) error {
+	rule, err := newLifecycleRule(flag.GetString(ctx, "prefix"), flag.GetInt(ctx, "expire-days"), flag.GetInt(ctx, "abort-incomplete-upload-days"))
+	if err != nil {
+		return err
+	}
+
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	rules, err := getLifecycleRules(ctx, client, bucket)
+	if err != nil {
+		return err
+	}
+	rules = append(removeLifecycleRules(rules, rulePrefix(rule)), rule)
+
+	if err := putLifecycleRules(ctx, client, bucket, rules); err != nil {
+		return err
+	}
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "Set the lifecycle rule of %s, it has %d rules\n", bucket, len(rules))
+	return nil
+}
+
+func lifecycleClear() *cobra.Command {
+	const (
+		short = "Remove lifecycle rules from a Tigris storage bucket"
+		long  = short + ". Without --prefix, every rule is removed.\n"
+		usage = "clear <bucket_name>"
+	)
+
+	cmd := command.New(usage, short, long, runLifecycleClear, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.String{
+			Name:        "prefix",
+			Description: "Only remove the rule of this prefix",
+		},
+	)
+
+	return cmd
+}
+
+func runLifecycleClear(ctx context.Context) error {
+	bucket := flag.FirstArg(ctx)
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	var rules []types.LifecycleRule
+	if flag.IsSpecified(ctx, "prefix") {
+		if rules, err = getLifecycleRules(ctx, client, bucket); err != nil {
+			return err
+		}
+		rules = removeLifecycleRules(rules, flag.GetString(ctx, "prefix"))
+	}
+
+	if err := putLifecycleRules(ctx, client, bucket, rules); err != nil {
+		return err
+	}
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "Removed lifecycle rules of %s, %d left\n", bucket, len(rules))
+	return nil
+}
+
+// newLifecycleRule builds the rule expiring the objects under prefix, and
+// aborting their incomplete uploads, after the given number of days.
+func newLifecycleRule(prefix string, expireDays, abortDays int) (types.LifecycleRule, error) {
+	rule := types.LifecycleRule{
+		ID:     aws.String(lo.Ternary(prefix == "", "all", prefix)),
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilterMemberPrefix{Value: prefix},
+	}
+
+	switch {
+	case expireDays < 0 || abortDays < 0:
+		return rule, errors.New("--expire-days and --abort-incomplete-upload-days must be positive")
+	case expireDays == 0 && abortDays == 0:
+		return rule, errors.New("specify --expire-days, --abort-incomplete-upload-days, or both")
+	}
+
+	if expireDays > 0 {
+		rule.Expiration = &types.LifecycleExpiration{Days: aws.Int32(int32(expireDays))}
+	}
+	if abortDays > 0 {
+		rule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(int32(abortDays))}
+	}
+	return rule, nil
+}
+
+// rulePrefix returns the key prefix a lifecycle rule applies to, either set as
+// a filter or with the legacy prefix field.
+func rulePrefix(r types.LifecycleRule) string {
+	if f, ok := r.Filter.(*types.LifecycleRuleFilterMemberPrefix); ok {
+		return f.Value
+	}
+	return aws.ToString(r.Prefix)
+}
+
+// removeLifecycleRules returns rules without those applying to prefix.
+func removeLifecycleRules(rules []types.LifecycleRule, prefix string) []types.LifecycleRule {
+	return lo.Reject(rules, func(r types.LifecycleRule, _ int) bool { return rulePrefix(r) == prefix })
+}
+
+// getLifecycleRules returns the lifecycle rules of bucket, none when it has no
+// lifecycle configuration.
+func getLifecycleRules(ctx context.Context, client *s3.Client, bucket string) ([]types.LifecycleRule, error) {
+	out, err := client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket)})
+	switch {
+	case isErrorCode(err, "NoSuchLifecycleConfiguration"):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get the lifecycle rules of %s: %w", bucket, err)
+	}
+	return out.Rules, nil
+}
+
+// putLifecycleRules replaces the lifecycle rules of bucket, deleting its
+// lifecycle configuration when rules is empty.
+func putLifecycleRules(ctx context.Context, client *s3.Client, bucket string, rules []types.LifecycleRule) error {
+	var err error
+	if len(rules) == 0 {
+		_, err = client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(bucket)})
+	} else {
+		_, err = client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+			Bucket:                 aws.String(bucket),
+			LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: rules},
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update the lifecycle rules of %s: %w", bucket, err)
+	}
+	return nil
+}
+
+// isErrorCode reports whether err is an S3 API error with the given code.
+func isErrorCode(err error, code string) bool {
+	var apiErr interface{ ErrorCode() string }
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
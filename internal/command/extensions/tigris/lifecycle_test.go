@@ -0,0 +1,43 @@
+package tigris
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLifecycleRule(t *testing.T) {
+	rule, err := newLifecycleRule("logs/", 30, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "logs/", rulePrefix(rule))
+	assert.Equal(t, types.ExpirationStatusEnabled, rule.Status)
+	assert.Equal(t, int32(30), *rule.Expiration.Days)
+	assert.Nil(t, rule.AbortIncompleteMultipartUpload)
+
+	rule, err = newLifecycleRule("", 0, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "all", *rule.ID)
+	assert.Nil(t, rule.Expiration)
+	assert.Equal(t, int32(7), *rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+
+	_, err = newLifecycleRule("", 0, 0)
+	assert.ErrorContains(t, err, "specify --expire-days")
+	_, err = newLifecycleRule("", -1, 0)
+	assert.ErrorContains(t, err, "must be positive")
+}
+
+func TestRemoveLifecycleRules(t *testing.T) {
+	logs, _ := newLifecycleRule("logs/", 30, 0)
+	all, _ := newLifecycleRule("", 0, 7)
+	// Rules created elsewhere may use the legacy prefix field
+	legacy := types.LifecycleRule{Prefix: aws.String("tmp/"), Status: types.ExpirationStatusEnabled}
+	rules := []types.LifecycleRule{logs, all, legacy}
+
+	assert.Equal(t, []types.LifecycleRule{all, legacy}, removeLifecycleRules(rules, "logs/"))
+	assert.Equal(t, []types.LifecycleRule{logs, legacy}, removeLifecycleRules(rules, ""))
+	assert.Equal(t, []types.LifecycleRule{logs, all}, removeLifecycleRules(rules, "tmp/"))
+	assert.Equal(t, rules, removeLifecycleRules(rules, "other/"))
+}
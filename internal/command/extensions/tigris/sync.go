@@ -0,0 +1,430 @@
+package tigris
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultTigrisEndpoint = "https://fly.storage.tigris.dev"
+	s3Scheme              = "s3://"
+)
+
+func syncCmd() *cobra.Command {
+	const (
+		short = "Synchronize a local directory with a Tigris storage bucket"
+		long  = short + `. Either the source or the destination is a bucket,
+written as s3://bucket/prefix. Only files whose size or checksum differ are
+transferred.
+
+The bucket's credentials are read from AWS_ACCESS_KEY_ID and
+AWS_SECRET_ACCESS_KEY when they are set, and fetched from Fly.io otherwise.
+
+Upload the public directory to the assets/ prefix of the my-bucket bucket:
+
+	fly storage sync ./public s3://my-bucket/assets --cache-control "max-age=3600"`
+		usage = "sync <source> <destination>"
+	)
+
+	cmd := command.New(usage, short, long, runSync, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(2)
+
+	flag.Add(cmd,
+		flag.Bool{
+			Name:        "delete",
+			Description: "Delete files in the destination that don't exist in the source",
+		},
+		flag.String{
+			Name:        "cache-control",
+			Description: "The Cache-Control header of uploaded objects",
+		},
+		flag.Int{
+			Name:        "concurrency",
+			Description: "The number of files transferred in parallel",
+			Default:     8,
+		},
+		flag.Bool{
+			Name:        "dry-run",
+			Description: "Show what would be transferred and deleted without doing it",
+		},
+	)
+
+	return cmd
+}
+
+// syncEntry is a file, or an object, along with its MD5 checksum when known.
+type syncEntry struct {
+	Size int64
+	MD5  string
+}
+
+// syncPlan lists the keys, relative to the synchronized prefix or directory,
+// to transfer and to delete.
+type syncPlan struct {
+	Transfer []string
+	Delete   []string
+}
+
+// planSync compares the entries of the source and the destination. Entries are
+// transferred when missing from the destination, or when their sizes or known
+// checksums differ.
+func planSync(src, dst map[string]syncEntry, del bool) syncPlan {
+	var p syncPlan
+	for key, s := range src {
+		d, ok := dst[key]
+		if !ok || s.Size != d.Size || (s.MD5 != "" && d.MD5 != "" && s.MD5 != d.MD5) {
+			p.Transfer = append(p.Transfer, key)
+		}
+	}
+	if del {
+		for key := range dst {
+			if _, ok := src[key]; !ok {
+				p.Delete = append(p.Delete, key)
+			}
+		}
+	}
+	sort.Strings(p.Transfer)
+	sort.Strings(p.Delete)
+	return p
+}
+
+// parseS3URL splits s3://bucket/prefix into its bucket and prefix. A
+// non-empty prefix always ends with a slash.
+func parseS3URL(u string) (bucket, prefix string, ok bool) {
+	if !strings.HasPrefix(u, s3Scheme) {
+		return "", "", false
+	}
+	bucket, prefix, _ = strings.Cut(strings.TrimPrefix(u, s3Scheme), "/")
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		prefix += "/"
+	}
+	return bucket, prefix, bucket != ""
+}
+
+func runSync(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		args = flag.Args(ctx)
+	)
+
+	srcBucket, srcPrefix, srcRemote := parseS3URL(args[0])
+	dstBucket, dstPrefix, dstRemote := parseS3URL(args[1])
+
+	var (
+		upload         bool
+		dir            string
+		bucket, prefix string
+	)
+	switch {
+	case srcRemote && dstRemote:
+		return errors.New("syncing two buckets is not supported, either the source or the destination must be a local directory")
+	case dstRemote:
+		upload, dir, bucket, prefix = true, args[0], dstBucket, dstPrefix
+	case srcRemote:
+		upload, dir, bucket, prefix = false, args[1], srcBucket, srcPrefix
+	default:
+		return fmt.Errorf("either the source or the destination must be a bucket, such as %smy-bucket/prefix", s3Scheme)
+	}
+
+	concurrency := flag.GetInt(ctx, "concurrency")
+	if concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	client, err := BucketClient(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	remote, err := listObjects(ctx, client, bucket, prefix)
+	if err != nil {
+		return err
+	}
+
+	local, err := listFiles(dir, !upload)
+	if err != nil {
+		return err
+	}
+
+	var p syncPlan
+	if upload {
+		p = planSync(local, remote, flag.GetBool(ctx, "delete"))
+	} else {
+		p = planSync(remote, local, flag.GetBool(ctx, "delete"))
+	}
+
+	// Object keys come from the bucket, don't let them write outside of dir
+	if !upload {
+		for _, key := range p.Transfer {
+			if _, err := localPath(dir, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	verb := lo.Ternary(upload, "upload", "download")
+	if flag.GetBool(ctx, "dry-run") {
+		for _, key := range p.Transfer {
+			fmt.Fprintf(io.Out, "(dry run) %s: %s\n", verb, key)
+		}
+		for _, key := range p.Delete {
+			fmt.Fprintf(io.Out, "(dry run) delete: %s\n", key)
+		}
+		return nil
+	}
+
+	var (
+		mu     sync.Mutex
+		report = func(format string, a ...interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Fprintf(io.Out, format, a...)
+		}
+		cacheControl = flag.GetString(ctx, "cache-control")
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for _, key := range p.Transfer {
+		key := key
+		g.Go(func() error {
+			var err error
+			if upload {
+				err = uploadFile(gctx, client, bucket, prefix+key, filepath.Join(dir, filepath.FromSlash(key)), cacheControl)
+			} else {
+				dest, _ := localPath(dir, key)
+				err = downloadObject(gctx, client, bucket, prefix+key, dest, remote[key].MD5)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to %s %s: %w", verb, key, err)
+			}
+			report("%s: %s\n", verb, key)
+			return nil
+		})
+	}
+	for _, key := range p.Delete {
+		key := key
+		g.Go(func() error {
+			var err error
+			if upload {
+				_, err = client.DeleteObject(gctx, &s3.DeleteObjectInput{Bucket: aws.String(bucket), Key: aws.String(prefix + key)})
+			} else {
+				err = os.Remove(filepath.Join(dir, filepath.FromSlash(key)))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to delete %s: %w", key, err)
+			}
+			report("delete: %s\n", key)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "%d files transferred, %d deleted, %d up to date\n",
+		len(p.Transfer), len(p.Delete), len(lo.Ternary(upload, local, remote))-len(p.Transfer))
+	return nil
+}
+
+// localPath returns the path in dir of the object key, relative to the synced
+// prefix, refusing keys that would land outside of dir.
+func localPath(dir, key string) (string, error) {
+	name := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(key, "/")))
+	if name == "." || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to download %s outside of %s", key, dir)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// BucketClient returns an S3 client for bucket, authenticated with the AWS_*
+// environment variables when set, or with the bucket's own credentials.
+func BucketClient(ctx context.Context, bucket string) (*s3.Client, error) {
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":     os.Getenv("AWS_ACCESS_KEY_ID"),
+		"AWS_SECRET_ACCESS_KEY": os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		"AWS_ENDPOINT_URL_S3":   os.Getenv("AWS_ENDPOINT_URL_S3"),
+		"AWS_REGION":            os.Getenv("AWS_REGION"),
+	}
+
+	if env["AWS_ACCESS_KEY_ID"] == "" || env["AWS_SECRET_ACCESS_KEY"] == "" {
+		response, err := gql.GetAddOn(ctx, fly.ClientFromContext(ctx).GenqClient, bucket)
+		if err != nil {
+			return nil, err
+		}
+		environment, _ := response.AddOn.Environment.(map[string]interface{})
+		for key := range env {
+			env[key], _ = environment[key].(string)
+		}
+		if env["AWS_ACCESS_KEY_ID"] == "" || env["AWS_SECRET_ACCESS_KEY"] == "" {
+			return nil, fmt.Errorf("no credentials found for %s, set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", bucket)
+		}
+	}
+
+	return s3.New(s3.Options{
+		Region:       lo.Ternary(env["AWS_REGION"] != "", env["AWS_REGION"], "auto"),
+		BaseEndpoint: aws.String(lo.Ternary(env["AWS_ENDPOINT_URL_S3"] != "", env["AWS_ENDPOINT_URL_S3"], defaultTigrisEndpoint)),
+		Credentials:  credentials.NewStaticCredentialsProvider(env["AWS_ACCESS_KEY_ID"], env["AWS_SECRET_ACCESS_KEY"], ""),
+	}), nil
+}
+
+// listObjects returns the objects under prefix, keyed relative to it. The
+// checksum of objects uploaded in multiple parts isn't known.
+func listObjects(ctx context.Context, client *s3.Client, bucket, prefix string) (map[string]syncEntry, error) {
+	objects := make(map[string]syncEntry)
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s%s/%s: %w", s3Scheme, bucket, prefix, err)
+		}
+		for _, obj := range page.Contents {
+			key := strings.TrimPrefix(aws.ToString(obj.Key), prefix)
+			if key == "" || strings.HasSuffix(key, "/") {
+				continue
+			}
+			etag := strings.Trim(aws.ToString(obj.ETag), `"`)
+			objects[key] = syncEntry{
+				Size: aws.ToInt64(obj.Size),
+				MD5:  lo.Ternary(strings.Contains(etag, "-"), "", etag),
+			}
+		}
+	}
+	return objects, nil
+}
+
+// listFiles returns the regular files in dir, keyed by their slash-separated
+// relative path. A missing dir is empty when allowMissing is set.
+func listFiles(dir string, allowMissing bool) (map[string]syncEntry, error) {
+	files := make(map[string]syncEntry)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sum, err := fileMD5(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = syncEntry{Size: info.Size(), MD5: hex.EncodeToString(sum)}
+		return nil
+	})
+	if allowMissing && errors.Is(err, fs.ErrNotExist) {
+		return files, nil
+	}
+	return files, err
+}
+
+func fileMD5(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+func uploadFile(ctx context.Context, client *s3.Client, bucket, key, p, cacheControl string) error {
+	sum, err := fileMD5(p)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	input := &s3.PutObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		Body:       f,
+		ContentMD5: aws.String(base64.StdEncoding.EncodeToString(sum)),
+	}
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	if cacheControl != "" {
+		input.CacheControl = aws.String(cacheControl)
+	}
+
+	_, err = client.PutObject(ctx, input)
+	return err
+}
+
+// downloadObject writes the object to p through a temporary file, verifying
+// its checksum when known.
+func downloadObject(ctx context.Context, client *s3.Client, bucket, key, p, expectedMD5 string) error {
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".fly-sync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := md5.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), out.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); expectedMD5 != "" && sum != expectedMD5 {
+		return fmt.Errorf("checksum mismatch, expected %s but got %s", expectedMD5, sum)
+	}
+	return os.Rename(tmp.Name(), p)
+}
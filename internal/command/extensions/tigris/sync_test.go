@@ -0,0 +1,73 @@
+package tigris
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseS3URL(t *testing.T) {
+	cases := []struct {
+		url, bucket, prefix string
+		ok                  bool
+	}{
+		{"s3://my-bucket", "my-bucket", "", true},
+		{"s3://my-bucket/", "my-bucket", "", true},
+		{"s3://my-bucket/assets", "my-bucket", "assets/", true},
+		{"s3://my-bucket//assets/img/", "my-bucket", "assets/img/", true},
+		{"s3://", "", "", false},
+		{"./public", "", "", false},
+		{"https://my-bucket/assets", "", "", false},
+	}
+	for _, c := range cases {
+		bucket, prefix, ok := parseS3URL(c.url)
+		assert.Equal(t, c.ok, ok, c.url)
+		if c.ok {
+			assert.Equal(t, c.bucket, bucket, c.url)
+			assert.Equal(t, c.prefix, prefix, c.url)
+		}
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	src := map[string]syncEntry{
+		"same.txt":       {Size: 3, MD5: "aaa"},
+		"changed.txt":    {Size: 3, MD5: "bbb"},
+		"resized.txt":    {Size: 4},
+		"multipart.bin":  {Size: 10},
+		"new/nested.txt": {Size: 1, MD5: "ccc"},
+	}
+	dst := map[string]syncEntry{
+		"same.txt":      {Size: 3, MD5: "aaa"},
+		"changed.txt":   {Size: 3, MD5: "zzz"},
+		"resized.txt":   {Size: 3},
+		"multipart.bin": {Size: 10, MD5: "ddd"},
+		"stale.txt":     {Size: 1},
+	}
+
+	p := planSync(src, dst, false)
+	assert.Equal(t, []string{"changed.txt", "new/nested.txt", "resized.txt"}, p.Transfer)
+	assert.Empty(t, p.Delete)
+
+	p = planSync(src, dst, true)
+	assert.Equal(t, []string{"stale.txt"}, p.Delete)
+}
+
+func TestLocalPath(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := localPath(dir, "assets/app.js")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "assets", "app.js"), p)
+
+	p, err = localPath(dir, "/rooted.txt")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "rooted.txt"), p)
+
+	for _, key := range []string{"../escape", "a/../../escape", "..", ""} {
+		_, err := localPath(dir, key)
+		assert.ErrorContains(t, err, "refusing to download", key)
+	}
+}
@@ -14,7 +14,7 @@ func New() (cmd *cobra.Command) {
 
 	cmd = command.New("storage", short, long, nil)
 	cmd.Aliases = []string{"tigris"}
-	cmd.AddCommand(create(), update(), list(), dashboard(), destroy(), status())
+	cmd.AddCommand(create(), update(), list(), dashboard(), destroy(), status(), lifecycle(), cors(), syncCmd())
 
 	return cmd
 }
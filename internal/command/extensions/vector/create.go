@@ -2,7 +2,9 @@ package vector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/gql"
@@ -34,6 +36,18 @@ func create() (cmd *cobra.Command) {
 			Shorthand:   "n",
 			Description: "The name of your cluster",
 		},
+		flag.String{
+			Name:        "similarity-function",
+			Description: "The metric comparing vectors, one of: euclidean, cosine, dot_product",
+		},
+		flag.String{
+			Name:        "embedding-model",
+			Description: "The model embedding data upserted as text, such as bge_small_en_v1_5, or none to provide your own embeddings",
+		},
+		flag.Int{
+			Name:        "dimensions",
+			Description: "The number of dimensions of the vectors, when providing your own embeddings",
+		},
 	)
 	return cmd
 }
@@ -64,7 +78,7 @@ func selectEmbeddingModel(ctx context.Context, defaultValue string) (function *E
 	var index int
 	if err = prompt.Select(ctx, &index, "Select an embedding model:", defaultValue, options...); err == nil {
 		if index != 0 {
-			function = &embeddingModels[index]
+			function = &embeddingModels[index-1]
 		}
 	}
 
@@ -85,30 +99,50 @@ func runCreate(ctx context.Context) (err error) {
 		params.Organization = org
 	}
 
-	function, err := selectSimilarityFunction(ctx, "")
-
-	if err != nil {
+	var function *SimilarityFunction
+	if identifier := flag.GetString(ctx, "similarity-function"); identifier != "" {
+		if function = findSimilarityFunction(identifier); function == nil {
+			return fmt.Errorf("unknown similarity function %s, expected one of: euclidean, cosine, dot_product", identifier)
+		}
+	} else if function, err = selectSimilarityFunction(ctx, ""); err != nil {
 		return err
 	}
 
-	model, err := selectEmbeddingModel(ctx, "")
-
-	if err != nil {
-		return err
+	var model *EmbeddingModel
+	if identifier := flag.GetString(ctx, "embedding-model"); identifier != "" {
+		if !strings.EqualFold(identifier, "none") {
+			if model = findEmbeddingModel(identifier); model == nil {
+				return fmt.Errorf("unknown embedding model %s", identifier)
+			}
+		}
+	} else if !flag.IsSpecified(ctx, "dimensions") {
+		if model, err = selectEmbeddingModel(ctx, ""); err != nil {
+			return err
+		}
 	}
 
-	var defaultDimensionCount int = 128
-
 	var options = gql.AddOnOptions{
 		"similarity_function": function.Identifier,
-		"dimension_count":     &defaultDimensionCount,
 	}
 
-	if model != nil {
+	dimensions := flag.GetInt(ctx, "dimensions")
+	switch {
+	case model != nil && flag.IsSpecified(ctx, "dimensions") && dimensions != model.Dimensions:
+		return fmt.Errorf("%s embeds %d dimensions, not %d", model.Name, model.Dimensions, dimensions)
+	case model != nil:
 		options["embedding_model"] = model.Identifier
 		options["dimension_count"] = model.Dimensions
-	} else {
-		prompt.Int(ctx, options["dimension_count"].(*int), "How many dimensions?", defaultDimensionCount, false)
+	case flag.IsSpecified(ctx, "dimensions"):
+		if dimensions < 1 {
+			return errors.New("--dimensions must be positive")
+		}
+		options["dimension_count"] = dimensions
+	default:
+		dimensions = 128
+		if err := prompt.Int(ctx, &dimensions, "How many dimensions?", dimensions, false); err != nil && !prompt.IsNonInteractive(err) {
+			return err
+		}
+		options["dimension_count"] = dimensions
 	}
 
 	params.Options = options
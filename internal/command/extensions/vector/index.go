@@ -0,0 +1,112 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func index() (cmd *cobra.Command) {
+	const (
+		short = "Inspect Upstash Vector indexes"
+		long  = short + "\n"
+	)
+
+	cmd = command.New("index", short, long, nil)
+	cmd.AddCommand(indexList())
+
+	return cmd
+}
+
+func indexList() (cmd *cobra.Command) {
+	const (
+		long  = `List your Upstash Vector indexes along with their configuration`
+		short = long
+		usage = "list"
+	)
+
+	cmd = command.New(usage, short, long, runIndexList, command.RequireSession)
+	cmd.Aliases = []string{"ls"}
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+// indexConfig is the configuration of an index, as chosen when creating it.
+type indexConfig struct {
+	Name               string
+	Organization       string
+	Region             string
+	SimilarityFunction string
+	Dimensions         int
+	EmbeddingModel     string
+}
+
+func runIndexList(ctx context.Context) (err error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+	response, err := gql.ListAddOns(ctx, client, gql.AddOnTypeUpstashVector)
+	if err != nil {
+		return err
+	}
+
+	indexes := indexConfigs(response.AddOns.Nodes, flag.GetOrg(ctx))
+
+	out := iostreams.FromContext(ctx).Out
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, indexes)
+	}
+
+	var rows [][]string
+	for _, index := range indexes {
+		model := "None"
+		if m := findEmbeddingModel(index.EmbeddingModel); m != nil {
+			model = m.Name
+		}
+		rows = append(rows, []string{
+			index.Name,
+			index.Organization,
+			index.Region,
+			strings.ToLower(index.SimilarityFunction),
+			fmt.Sprint(index.Dimensions),
+			model,
+		})
+	}
+
+	return render.Table(out, "", rows, "Name", "Org", "Region", "Similarity", "Dimensions", "Embedding Model")
+}
+
+// indexConfigs reads the configuration of each index from its options,
+// keeping only the indexes of org unless it's empty.
+func indexConfigs(extensions []gql.ListAddOnsAddOnsAddOnConnectionNodesAddOn, org string) []indexConfig {
+	var indexes []indexConfig
+	for _, extension := range extensions {
+		if org != "" && extension.Organization.Slug != org {
+			continue
+		}
+
+		options, _ := extension.Options.(map[string]interface{})
+		config := indexConfig{
+			Name:         extension.Name,
+			Organization: extension.Organization.Slug,
+			Region:       extension.PrimaryRegion,
+		}
+		config.SimilarityFunction, _ = options["similarity_function"].(string)
+		config.EmbeddingModel, _ = options["embedding_model"].(string)
+		if dimensions, ok := options["dimension_count"].(float64); ok {
+			config.Dimensions = int(dimensions)
+		}
+		indexes = append(indexes, config)
+	}
+	return indexes
+}
@@ -0,0 +1,33 @@
+package vector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestIndexConfigs(t *testing.T) {
+	var extensions []gql.ListAddOnsAddOnsAddOnConnectionNodesAddOn
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "search", "primaryRegion": "iad", "organization": {"slug": "acme"},
+		 "options": {"similarity_function": "COSINE", "dimension_count": 1536, "embedding_model": "BGE_SMALL_EN_V1_5"}},
+		{"name": "legacy", "primaryRegion": "ams", "organization": {"slug": "acme"}, "options": null},
+		{"name": "other", "primaryRegion": "syd", "organization": {"slug": "personal"},
+		 "options": {"similarity_function": "EUCLIDEAN", "dimension_count": "256"}}
+	]`), &extensions))
+
+	assert.Equal(t, []indexConfig{
+		{Name: "search", Organization: "acme", Region: "iad", SimilarityFunction: "COSINE", Dimensions: 1536, EmbeddingModel: "BGE_SMALL_EN_V1_5"},
+		{Name: "legacy", Organization: "acme", Region: "ams"},
+	}, indexConfigs(extensions, "acme"))
+
+	// Options of unexpected types are left out instead of failing the listing
+	all := indexConfigs(extensions, "")
+	require.Len(t, all, 3)
+	assert.Equal(t, indexConfig{Name: "other", Organization: "personal", Region: "syd", SimilarityFunction: "EUCLIDEAN"}, all[2])
+
+	assert.Empty(t, indexConfigs(extensions, "nobody"))
+}
@@ -0,0 +1,142 @@
+package vector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func queryStats() *cobra.Command {
+	const (
+		short = "Show the usage of an Upstash Vector index"
+		long  = short + ": the number of vectors stored and pending indexing, and the index size\n"
+
+		usage = "query-stats [name]"
+	)
+
+	cmd := command.New(usage, short, long, runQueryStats,
+		command.RequireSession, command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		extensions_core.SharedFlags,
+	)
+
+	return cmd
+}
+
+// indexInfo is the response of the info endpoint of the Upstash Vector REST API.
+type indexInfo struct {
+	VectorCount        int64  `json:"vectorCount"`
+	PendingVectorCount int64  `json:"pendingVectorCount"`
+	IndexSize          int64  `json:"indexSize"`
+	Dimension          int    `json:"dimension"`
+	SimilarityFunction string `json:"similarityFunction"`
+	Namespaces         map[string]struct {
+		VectorCount        int64 `json:"vectorCount"`
+		PendingVectorCount int64 `json:"pendingVectorCount"`
+	} `json:"namespaces"`
+}
+
+func runQueryStats(ctx context.Context) (err error) {
+	io := iostreams.FromContext(ctx)
+
+	extension, _, err := extensions_core.Discover(ctx, gql.AddOnTypeUpstashVector)
+	if err != nil {
+		return err
+	}
+
+	response, err := gql.GetAddOn(ctx, fly.ClientFromContext(ctx).GenqClient, extension.Name)
+	if err != nil {
+		return err
+	}
+	environment, _ := response.AddOn.Environment.(map[string]interface{})
+	url, _ := environment["UPSTASH_VECTOR_REST_URL"].(string)
+	token, _ := environment["UPSTASH_VECTOR_REST_TOKEN"].(string)
+	if url == "" || token == "" {
+		return fmt.Errorf("the REST credentials of %s are not available yet", extension.Name)
+	}
+
+	info, err := fetchIndexInfo(ctx, url, token)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the usage of %s: %w", extension.Name, err)
+	}
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(io.Out, info)
+	}
+
+	obj := [][]string{{
+		extension.Name,
+		humanize.Comma(info.VectorCount),
+		humanize.Comma(info.PendingVectorCount),
+		humanize.Bytes(uint64(info.IndexSize)),
+		fmt.Sprint(info.Dimension),
+		strings.ToLower(info.SimilarityFunction),
+	}}
+	if err := render.VerticalTable(io.Out, "Usage", obj, "Name", "Vectors", "Pending Vectors", "Index Size", "Dimensions", "Similarity"); err != nil {
+		return err
+	}
+
+	if len(info.Namespaces) > 1 {
+		names := lo.Keys(info.Namespaces)
+		sort.Strings(names)
+
+		var rows [][]string
+		for _, name := range names {
+			ns := info.Namespaces[name]
+			rows = append(rows, []string{
+				lo.Ternary(name == "", "(default)", name),
+				humanize.Comma(ns.VectorCount),
+				humanize.Comma(ns.PendingVectorCount),
+			})
+		}
+		return render.Table(io.Out, "Namespaces", rows, "Namespace", "Vectors", "Pending Vectors")
+	}
+	return nil
+}
+
+func fetchIndexInfo(ctx context.Context, url, token string) (*indexInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/info", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	var body struct {
+		Result indexInfo `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return &body.Result, nil
+}
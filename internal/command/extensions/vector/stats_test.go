@@ -0,0 +1,39 @@
+package vector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchIndexInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/info", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"vectorCount": 1200, "pendingVectorCount": 3, "indexSize": 4096, "dimension": 384,
+			"similarityFunction": "COSINE", "namespaces": {"": {"vectorCount": 1000}, "docs": {"vectorCount": 200, "pendingVectorCount": 3}}}}`)
+	}))
+	defer srv.Close()
+
+	// A trailing slash on the REST URL doesn't double up in the path
+	info, err := fetchIndexInfo(context.Background(), srv.URL+"/", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), info.VectorCount)
+	assert.Equal(t, int64(3), info.PendingVectorCount)
+	assert.Equal(t, int64(4096), info.IndexSize)
+	assert.Equal(t, 384, info.Dimension)
+	assert.Equal(t, "COSINE", info.SimilarityFunction)
+	require.Len(t, info.Namespaces, 2)
+	assert.Equal(t, int64(3), info.Namespaces["docs"].PendingVectorCount)
+
+	_, err = fetchIndexInfo(context.Background(), srv.URL, "wrong")
+	assert.EqualError(t, err, "unexpected response: 401 Unauthorized")
+}
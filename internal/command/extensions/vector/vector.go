@@ -1,6 +1,8 @@
 package vector
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
@@ -69,6 +71,28 @@ var embeddingModels = []EmbeddingModel{
 	},
 }
 
+// findEmbeddingModel returns the model with the given identifier, ignoring
+// case, or nil.
+func findEmbeddingModel(identifier string) *EmbeddingModel {
+	for i, model := range embeddingModels {
+		if strings.EqualFold(model.Identifier, identifier) {
+			return &embeddingModels[i]
+		}
+	}
+	return nil
+}
+
+// findSimilarityFunction returns the function with the given identifier,
+// ignoring case, or nil.
+func findSimilarityFunction(identifier string) *SimilarityFunction {
+	for i, function := range similarityFunctions {
+		if strings.EqualFold(function.Identifier, identifier) {
+			return &similarityFunctions[i]
+		}
+	}
+	return nil
+}
+
 func New() (cmd *cobra.Command) {
 	const (
 		short = "Provision and manage Upstash Vector index"
@@ -76,7 +100,7 @@ func New() (cmd *cobra.Command) {
 	)
 
 	cmd = command.New("vector", short, long, nil)
-	cmd.AddCommand(create(), list(), dashboard(), destroy(), status())
+	cmd.AddCommand(create(), list(), dashboard(), destroy(), status(), index(), queryStats())
 
 	return cmd
 }
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// hints maps the paths of key commands to functions returning the next steps
+// to suggest once they succeed. An empty hint is not shown.
+var hints = map[string]func(context.Context) string{
+	"fly auth login":  afterLogin,
+	"fly auth signup": afterLogin,
+	"fly init":        afterLogin,
+	"fly launch": func(ctx context.Context) string {
+		if flag.GetBool(ctx, "no-deploy") {
+			return "Run `fly deploy` when you're ready to deploy your app."
+		}
+		return "Run `fly status` to check on your app, `fly logs` to follow its logs and `fly apps open` to visit it."
+	},
+	"fly deploy": func(context.Context) string {
+		return "Run `fly logs` to follow your app's logs, or `fly scale count` to run more machines."
+	},
+	"fly apps create": func(context.Context) string {
+		return "Run `fly deploy` in a directory with a fly.toml to deploy to your new app."
+	},
+	"fly postgres create": func(context.Context) string {
+		return "Run `fly postgres attach` to make the database available to an app."
+	},
+	"fly volumes create": func(context.Context) string {
+		return "Add a [mounts] section to your fly.toml and run `fly deploy` to use the volume."
+	},
+	"fly certs add": func(context.Context) string {
+		return "Run `fly certs check` to follow the validation of the certificate."
+	},
+}
+
+func afterLogin(context.Context) string {
+	if _, err := os.Stat(appconfig.DefaultConfigFileName); err == nil {
+		return "Run `fly deploy` to deploy the app configured in this directory."
+	}
+	return "Run `fly launch` in your project's directory to create and deploy an app."
+}
+
+// showHint prints the next steps for the command of ctx, unless the user
+// opted out of hints or the output is meant for machines.
+func showHint(ctx context.Context) {
+	var (
+		cfg = config.FromContext(ctx)
+		io  = iostreams.FromContext(ctx)
+	)
+
+	if !cfg.Hints || cfg.JSONOutput || io.IsQuiet() || !io.IsInteractive() {
+		return
+	}
+
+	if hint := hintFor(ctx, FromContext(ctx).CommandPath()); hint != "" {
+		writeHint(io, hint)
+	}
+}
+
+func hintFor(ctx context.Context, path string) string {
+	if fn, ok := hints[path]; ok {
+		return fn(ctx)
+	}
+	return ""
+}
+
+func writeHint(io *iostreams.IOStreams, hint string) {
+	colorize := io.ColorScheme()
+	fmt.Fprintf(io.ErrOut, "\n%s %s\n", colorize.Bold("Next:"), hint)
+	fmt.Fprintln(io.ErrOut, colorize.Gray("Turn these hints off with `fly settings hints disable`."))
+}
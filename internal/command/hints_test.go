@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestHintFor(t *testing.T) {
+	fs := pflag.NewFlagSet("launch", pflag.ContinueOnError)
+	fs.Bool("no-deploy", false, "")
+	ctx := flag.NewContext(context.Background(), fs)
+
+	assert.Contains(t, hintFor(ctx, "fly launch"), "fly status")
+
+	_ = fs.Set("no-deploy", "true")
+	assert.Contains(t, hintFor(ctx, "fly launch"), "fly deploy")
+
+	assert.Empty(t, hintFor(ctx, "fly apps list"))
+}
+
+func TestAfterLogin(t *testing.T) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	assert.Contains(t, afterLogin(context.Background()), "fly launch")
+
+	assert.NoError(t, os.WriteFile("fly.toml", nil, 0o600))
+	assert.Contains(t, afterLogin(context.Background()), "fly deploy")
+}
+
+func TestWriteHint(t *testing.T) {
+	io, _, out, errOut := iostreams.Test()
+
+	writeHint(io, "Run `fly deploy`.")
+
+	assert.Empty(t, out.String())
+	assert.Contains(t, errOut.String(), "Next: Run `fly deploy`.")
+	assert.Contains(t, errOut.String(), "fly settings hints disable")
+}
@@ -0,0 +1,277 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const (
+	policyNotify = "notify"
+	policyApply  = "apply"
+)
+
+// managedRepositories are the images Fly.io publishes new versions of.
+var managedRepositories = []string{"flyio/postgres", "flyio/redis"}
+
+func isManagedImage(m *fly.Machine) bool {
+	for _, repo := range managedRepositories {
+		if strings.HasPrefix(m.ImageRef.Repository, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingUpdate is a machine running an older version of its image.
+type pendingUpdate struct {
+	Machine *fly.Machine
+	Latest  *fly.ImageVersion
+}
+
+// runAutoUpdate checks the machines of app for new versions of their managed
+// images and, by policy, reports them or applies them within the maintenance
+// window. With --watch it keeps checking until interrupted.
+func runAutoUpdate(ctx context.Context, app *fly.AppCompact) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		policy   = flag.GetString(ctx, "policy")
+		watch    = flag.GetBool(ctx, "watch")
+		interval = flag.GetDuration(ctx, "interval")
+	)
+
+	if policy != policyNotify && policy != policyApply {
+		return fmt.Errorf("invalid policy %q, use %s or %s", policy, policyNotify, policyApply)
+	}
+	if flag.GetString(ctx, "image") != "" {
+		return fmt.Errorf("--image can't be used with --auto, which only moves managed images to their latest version")
+	}
+	if interval < time.Minute {
+		return fmt.Errorf("--interval must be at least a minute")
+	}
+
+	w, err := parseWindow(flag.GetString(ctx, "window"))
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := checkForUpdates(ctx, app, policy, w); err != nil {
+			return err
+		}
+		if !watch {
+			return nil
+		}
+
+		// Don't sleep through a window shorter than the interval.
+		wait := interval
+		if policy == policyApply && w != nil {
+			if until := time.Until(w.next(time.Now())); until > 0 && until < wait {
+				wait = until
+			}
+		}
+
+		fmt.Fprintf(io.ErrOut, "Checking again at %s\n", time.Now().Add(wait).UTC().Format(time.RFC3339))
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func checkForUpdates(ctx context.Context, app *fly.AppCompact, policy string, w *window) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+	)
+
+	updates, err := pendingUpdates(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(updates) == 0 {
+		fmt.Fprintln(io.Out, "Managed images are up to date")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(updates))
+	for _, u := range updates {
+		rows = append(rows, []string{
+			u.Machine.ID,
+			u.Machine.ImageRefWithVersion(),
+			fmt.Sprintf("%s:%s (%s)", u.Latest.Repository, u.Latest.Tag, u.Latest.Version),
+		})
+	}
+	if err := render.Table(io.Out, "Available image updates", rows, "Machine", "Current", "Latest"); err != nil {
+		return err
+	}
+
+	if policy == policyNotify {
+		fmt.Fprintf(io.Out, "Run %s to apply them\n", colorize.Bold("fly image update -a "+app.Name))
+		return nil
+	}
+
+	now := time.Now()
+	if w != nil && !w.contains(now) {
+		fmt.Fprintf(io.Out, "Updates will be applied in the maintenance window, opening at %s\n",
+			w.next(now).Format(time.RFC3339))
+		return nil
+	}
+
+	if err := flag.SetString(ctx, "yes", "true"); err != nil {
+		return err
+	}
+	if app.IsPostgresApp() {
+		return updatePostgresOnMachines(ctx, app)
+	}
+	return updateImageForMachines(ctx, app)
+}
+
+// pendingUpdates returns the machines running managed images which have a
+// newer version.
+func pendingUpdates(ctx context.Context) ([]pendingUpdate, error) {
+	client := fly.ClientFromContext(ctx)
+
+	machines, err := flaps.FromContext(ctx).ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving machines: %w", err)
+	}
+
+	// Machines of a cluster share their image, look each up once.
+	latest := map[string]*fly.ImageVersion{}
+
+	var updates []pendingUpdate
+	for _, m := range machines {
+		if !isManagedImage(m) {
+			continue
+		}
+
+		ref := fmt.Sprintf("%s:%s", m.ImageRef.Repository, m.ImageRef.Tag)
+		img, ok := latest[ref]
+		if !ok {
+			img, err = client.GetLatestImageDetails(ctx, ref)
+			if err != nil && !strings.Contains(err.Error(), "Unknown repository") {
+				return nil, err
+			}
+			latest[ref] = img
+		}
+
+		if img != nil && img.Digest != "" && img.Digest != m.ImageRef.Digest {
+			updates = append(updates, pendingUpdate{Machine: m, Latest: img})
+		}
+	}
+	return updates, nil
+}
+
+// window is a weekly maintenance window, in UTC.
+type window struct {
+	days       [7]bool
+	start, end time.Duration
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseWindow parses a window such as "sat,sun 02:00-04:00", whose days are
+// optional. An empty window is nil, and always open.
+func parseWindow(s string) (*window, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	w := &window{}
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		for i := range w.days {
+			w.days[i] = true
+		}
+	case 2:
+		for _, day := range strings.Split(fields[0], ",") {
+			d, ok := weekdays[strings.ToLower(day)]
+			if !ok {
+				return nil, fmt.Errorf("invalid window %q: unknown day %q", s, day)
+			}
+			w.days[d] = true
+		}
+	default:
+		return nil, fmt.Errorf("invalid window %q, expected days and hours such as \"sat,sun 02:00-04:00\"", s)
+	}
+
+	from, to, ok := strings.Cut(fields[len(fields)-1], "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid window %q: expected hours such as 02:00-04:00", s)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(from); err != nil {
+		return nil, fmt.Errorf("invalid window %q: %w", s, err)
+	}
+	if w.end, err = parseTimeOfDay(to); err != nil {
+		return nil, fmt.Errorf("invalid window %q: %w", s, err)
+	}
+	if w.start == w.end {
+		return nil, fmt.Errorf("invalid window %q: it opens and closes at the same time", s)
+	}
+	return w, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// contains reports whether the window is open at t. Windows closing past
+// midnight belong to the day they open on.
+func (w *window) contains(t time.Time) bool {
+	t = t.UTC()
+	day := midnight(t)
+	tod := t.Sub(day)
+
+	if w.start < w.end {
+		return w.days[t.Weekday()] && tod >= w.start && tod < w.end
+	}
+	if tod >= w.start {
+		return w.days[t.Weekday()]
+	}
+	return tod < w.end && w.days[day.AddDate(0, 0, -1).Weekday()]
+}
+
+// next returns when the window is next open from t, t itself when it is.
+func (w *window) next(t time.Time) time.Time {
+	t = t.UTC()
+	if w.contains(t) {
+		return t
+	}
+	day := midnight(t)
+	for i := 0; i <= 7; i++ {
+		d := day.AddDate(0, 0, i)
+		if at := d.Add(w.start); at.After(t) && w.days[d.Weekday()] {
+			return at
+		}
+	}
+	return t
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,86 @@
+package image
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// at returns a time in the week of Saturday June 1st 2024, UTC.
+func at(day int, hhmm string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", "2024-06-01 "+hhmm)
+	if err != nil {
+		panic(err)
+	}
+	return t.AddDate(0, 0, day)
+}
+
+func TestParseWindow(t *testing.T) {
+	w, err := parseWindow("")
+	require.NoError(t, err)
+	assert.Nil(t, w, "an empty window is always open")
+
+	w, err = parseWindow(" Sat,sun 02:00-04:30 ")
+	require.NoError(t, err)
+	assert.Equal(t, [7]bool{true, false, false, false, false, false, true}, w.days)
+	assert.Equal(t, 2*time.Hour, w.start)
+	assert.Equal(t, 4*time.Hour+30*time.Minute, w.end)
+
+	w, err = parseWindow("23:00-01:00")
+	require.NoError(t, err)
+	assert.Equal(t, [7]bool{true, true, true, true, true, true, true}, w.days, "days default to every day")
+
+	for _, s := range []string{
+		"sat",
+		"sat,sun 02:00",
+		"sat,funday 02:00-04:00",
+		"sat 02:00-02:00",
+		"sat 25:00-26:00",
+		"sat 2am-4am",
+		"sat sun 02:00-04:00",
+	} {
+		_, err := parseWindow(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	w, err := parseWindow("sat 02:00-04:00")
+	require.NoError(t, err)
+
+	assert.True(t, w.contains(at(0, "02:00")))
+	assert.True(t, w.contains(at(0, "03:59")))
+	assert.False(t, w.contains(at(0, "04:00")), "windows close at their end")
+	assert.False(t, w.contains(at(0, "01:59")))
+	assert.False(t, w.contains(at(1, "03:00")), "sunday")
+
+	local := at(0, "03:00").In(time.FixedZone("CEST", 2*60*60))
+	assert.True(t, w.contains(local), "windows are in UTC")
+
+	// Windows closing past midnight belong to the day they open on.
+	w, err = parseWindow("sat 23:00-01:00")
+	require.NoError(t, err)
+
+	assert.True(t, w.contains(at(0, "23:30")))
+	assert.True(t, w.contains(at(1, "00:30")), "sunday morning, in saturday's window")
+	assert.False(t, w.contains(at(0, "00:30")), "saturday morning, in friday's window")
+	assert.False(t, w.contains(at(1, "23:30")))
+}
+
+func TestWindowNext(t *testing.T) {
+	w, err := parseWindow("sat,sun 02:00-04:00")
+	require.NoError(t, err)
+
+	now := at(0, "03:00")
+	assert.Equal(t, now, w.next(now), "an open window is open now")
+	assert.Equal(t, at(0, "02:00"), w.next(at(0, "01:00")))
+	assert.Equal(t, at(1, "02:00"), w.next(at(0, "04:00")))
+	assert.Equal(t, at(7, "02:00"), w.next(at(1, "05:00")), "the next saturday")
+
+	w, err = parseWindow("wed 23:00-01:00")
+	require.NoError(t, err)
+	assert.Equal(t, at(4, "23:00"), w.next(at(0, "12:00")))
+	assert.Equal(t, at(5, "00:30"), w.next(at(5, "00:30")), "thursday morning, in wednesday's window")
+}
@@ -0,0 +1,112 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/build/imgsrc"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/deploy"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newExport() *cobra.Command {
+	const (
+		short = "Build the image of the app and export it without deploying"
+		long  = `Build the image of the app exactly as deploy would, and export it to
+--output instead of pushing it, to archive what would have been deployed or
+load it elsewhere. Outputs are TYPE:PATH: oci:PATH writes an OCI image layout
+to the directory PATH, or to a tarball when PATH ends with .tar;
+docker-archive:PATH writes a tarball "docker load" takes; tar:PATH writes a
+tarball of the filesystem of the image.
+
+Exporting needs BuildKit, and oci outputs need a Docker daemon with the
+containerd image store. Without it, docker-archive outputs are saved from the
+image store of the daemon after the build, like docker save.
+`
+		usage = "export"
+	)
+
+	cmd := command.New(usage, short, long, runExport,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.RemoteOnly(false),
+		flag.LocalOnly(),
+		flag.DockerHost(),
+		flag.Wireguard(),
+		flag.HttpFailover(),
+		flag.Dockerfile(),
+		flag.Ignorefile(),
+		flag.ImageLabel(),
+		flag.BuildArg(),
+		flag.BuildSecret(),
+		flag.BuildTarget(),
+		flag.NoCache(),
+		flag.CacheFrom(),
+		flag.CacheTo(),
+		flag.Reproducible(),
+		flag.SSH(),
+		flag.String{
+			Name:        "output",
+			Description: "Where to export the image, such as oci:./out or docker-archive:app.tar",
+		},
+	)
+	_ = cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func runExport(ctx context.Context) (err error) {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	output, err := imgsrc.ParseExportOutput(flag.GetString(ctx, "output"))
+	if err != nil {
+		return err
+	}
+
+	cfg := appconfig.ConfigFromContext(ctx)
+	if cfg == nil {
+		if cfg, err = appconfig.FromRemoteApp(ctx, appName); err != nil {
+			return err
+		}
+	}
+	if appName != "" {
+		cfg.AppName = appName
+	}
+
+	img, err := deploy.ExportImage(ctx, cfg, output)
+	if err != nil {
+		return err
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, map[string]string{
+			"id":     img.ID,
+			"tag":    img.Tag,
+			"output": output.String(),
+		})
+	}
+
+	fmt.Fprintf(io.Out, "Exported %s to %s\n", img.Tag, output)
+	if img.ID != "" {
+		fmt.Fprintf(io.Out, "Image: %s\n", img.ID)
+	}
+	return nil
+}
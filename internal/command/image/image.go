@@ -23,6 +23,9 @@ func New() *cobra.Command {
 	cmd.AddCommand(
 		newShow(),
 		newUpdate(),
+		newScan(),
+		newExport(),
+		NewPush(),
 	)
 
 	return cmd
@@ -0,0 +1,99 @@
+package image
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/deploy"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// NewPush returns image push, which fly build is an alias of.
+func NewPush() *cobra.Command {
+	const (
+		short = "Build the image of the app and push it without deploying"
+		long  = `Build the image of the app exactly as deploy would, or take the local
+image of --image, push it to the registry of the app, and print its
+reference pinned to the digest the registry reports, without deploying it.
+Process groups with builds of their own get their images pushed too, one
+reference per line after the one of the app. With --json, the tag, digest,
+pinned reference, ID and size of each image, and how it was built with the
+timings of the steps of the build, are printed instead, for scripts which
+deploy the image later with "fly deploy --image".
+
+Only the references go to stdout: build progress goes to stderr. fly build
+is an alias of this command.
+`
+		usage = "push"
+	)
+
+	cmd := command.New(usage, short, long, runPush,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.Image(),
+		flag.RemoteOnly(false),
+		flag.LocalOnly(),
+		flag.DockerHost(),
+		flag.Wireguard(),
+		flag.HttpFailover(),
+		flag.Dockerfile(),
+		flag.Ignorefile(),
+		flag.ImageLabel(),
+		flag.BuildArg(),
+		flag.BuildSecret(),
+		flag.BuildTarget(),
+		flag.NoCache(),
+		flag.CacheFrom(),
+		flag.CacheTo(),
+		flag.Reproducible(),
+		flag.SBOM(),
+		flag.SSH(),
+		flag.Nixpacks(),
+		flag.StringArray{
+			Name:        "label",
+			Description: "Add custom metadata to an image via docker labels",
+		},
+		flag.Int{
+			Name:        "build-concurrency",
+			Description: "Number of images to build at once for apps with process groups which have builds of their own",
+			Default:     1,
+		},
+	)
+
+	return cmd
+}
+
+func runPush(ctx context.Context) (err error) {
+	appName := appconfig.NameFromContext(ctx)
+
+	cfg := appconfig.ConfigFromContext(ctx)
+	if cfg == nil {
+		if cfg, err = appconfig.FromRemoteApp(ctx, appName); err != nil {
+			return err
+		}
+	}
+	if appName != "" {
+		cfg.AppName = appName
+	}
+
+	// Keep stdout for the references; builds report progress on both.
+	io := iostreams.FromContext(ctx)
+	img, groupImages, err := deploy.PushImages(iostreams.NewContext(ctx, io.WithOutOnErrOut()), cfg)
+	if err != nil {
+		return err
+	}
+	return deploy.PrintPushedImages(io.Out, config.FromContext(ctx).JSONOutput, img, groupImages)
+}
@@ -0,0 +1,253 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/cli/safeexec"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newScan() *cobra.Command {
+	const (
+		short = "Scan an image for vulnerabilities"
+		long  = `Scan the image the machines of an app run, or the one of --image, for
+known vulnerabilities of its OS packages and language dependencies. Scans use
+Trivy (https://trivy.dev), which has to be installed.
+
+With --fail-on, the command fails when it finds a vulnerability of at least
+that severity, to gate deploys on it.
+`
+		usage = "scan [app]"
+	)
+
+	cmd := command.New(usage, short, long, runScan,
+		command.RequireSession,
+		command.LoadAppNameIfPresent,
+	)
+
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.String{
+			Name:        "image",
+			Shorthand:   "i",
+			Description: "Image to scan instead of the one of the app",
+		},
+		flag.String{
+			Name:        "severity",
+			Description: "Minimum severity to report: low, medium, high or critical",
+			Default:     "low",
+		},
+		flag.String{
+			Name:        "fail-on",
+			Description: "Fail when a vulnerability of at least this severity is found: low, medium, high or critical",
+		},
+	)
+
+	return cmd
+}
+
+// severities are the severities of vulnerabilities, from the least severe.
+var severities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+func severityRank(s string) int {
+	return lo.IndexOf(severities, strings.ToUpper(s))
+}
+
+func parseSeverity(s string) (int, error) {
+	if rank := severityRank(s); rank > 0 {
+		return rank, nil
+	}
+	return 0, fmt.Errorf("invalid severity %q, expected low, medium, high or critical", s)
+}
+
+// vulnerability is a vulnerability found by Trivy.
+type vulnerability struct {
+	ID               string `json:"id"`
+	Package          string `json:"package"`
+	InstalledVersion string `json:"installed_version"`
+	FixedVersion     string `json:"fixed_version,omitempty"`
+	Severity         string `json:"severity"`
+	Title            string `json:"title,omitempty"`
+	Target           string `json:"target"`
+}
+
+// trivyReport is the part of the JSON report of trivy image used here.
+type trivyReport struct {
+	Results []struct {
+		Target          string `json:"Target"`
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func runScan(ctx context.Context) error {
+	var (
+		io  = iostreams.FromContext(ctx)
+		cfg = config.FromContext(ctx)
+	)
+
+	minRank, err := parseSeverity(flag.GetString(ctx, "severity"))
+	if err != nil {
+		return err
+	}
+	failRank := -1
+	if s := flag.GetString(ctx, "fail-on"); s != "" {
+		if failRank, err = parseSeverity(s); err != nil {
+			return err
+		}
+	}
+
+	ref := flag.GetString(ctx, "image")
+	if ref == "" {
+		if ref, err = appImage(ctx); err != nil {
+			return err
+		}
+	}
+
+	trivy, err := safeexec.LookPath("trivy")
+	if err != nil {
+		return errors.New("scanning images needs trivy, see https://trivy.dev for how to install it")
+	}
+
+	if !cfg.JSONOutput {
+		fmt.Fprintf(io.ErrOut, "Scanning %s\n", ref)
+	}
+
+	vulns, err := scanImage(ctx, trivy, ref)
+	if err != nil {
+		return err
+	}
+	vulns = lo.Filter(vulns, func(v vulnerability, _ int) bool {
+		return severityRank(v.Severity) >= minRank
+	})
+
+	if cfg.JSONOutput {
+		err = render.JSON(io.Out, vulns)
+	} else if len(vulns) == 0 {
+		fmt.Fprintln(io.Out, "No vulnerabilities found")
+	} else {
+		rows := make([][]string, 0, len(vulns))
+		for _, v := range vulns {
+			rows = append(rows, []string{v.Severity, v.ID, v.Package, v.InstalledVersion, v.FixedVersion, v.Title})
+		}
+		err = render.Table(io.Out, "", rows, "Severity", "ID", "Package", "Installed", "Fixed", "Title")
+	}
+	if err != nil {
+		return err
+	}
+
+	if failRank >= 0 {
+		failing := lo.CountBy(vulns, func(v vulnerability) bool { return severityRank(v.Severity) >= failRank })
+		if failing > 0 {
+			return fmt.Errorf("found %d vulnerabilities of %s severity or higher", failing, strings.ToLower(severities[failRank]))
+		}
+	}
+	return nil
+}
+
+// appImage returns the image most machines of the app of the first argument,
+// or of the current app, run.
+func appImage(ctx context.Context) (string, error) {
+	appName := flag.FirstArg(ctx)
+	if appName == "" {
+		appName = appconfig.NameFromContext(ctx)
+	}
+	if appName == "" {
+		return "", errors.New("no app to scan, pass its name or use --image")
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err != nil {
+		return "", err
+	}
+	machines, _, err := flapsClient.ListFlyAppsMachines(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed listing machines of %s: %w", appName, err)
+	}
+	if len(machines) == 0 {
+		return "", fmt.Errorf("app %s has no machines, use --image to scan an image", appName)
+	}
+
+	counts := lo.CountValuesBy(machines, func(m *fly.Machine) string { return m.FullImageRef() })
+	refs := lo.Keys(counts)
+	sort.Slice(refs, func(i, j int) bool {
+		if counts[refs[i]] != counts[refs[j]] {
+			return counts[refs[i]] > counts[refs[j]]
+		}
+		return refs[i] < refs[j]
+	})
+	return refs[0], nil
+}
+
+// scanImage scans the image ref with the trivy binary, returning its
+// vulnerabilities from the most severe.
+func scanImage(ctx context.Context, trivy, ref string) ([]vulnerability, error) {
+	cmd := exec.CommandContext(ctx, trivy, "image", "--quiet", "--format", "json", "--scanners", "vuln", ref)
+	cmd.Env = os.Environ()
+	if strings.HasPrefix(ref, "registry.fly.io/") {
+		cmd.Env = append(cmd.Env, "TRIVY_USERNAME=x", "TRIVY_PASSWORD="+config.Tokens(ctx).Docker())
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed scanning %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var report trivyReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		return nil, fmt.Errorf("failed parsing the scan of %s: %w", ref, err)
+	}
+
+	var vulns []vulnerability
+	for _, r := range report.Results {
+		for _, v := range r.Vulnerabilities {
+			vulns = append(vulns, vulnerability{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         v.Severity,
+				Title:            v.Title,
+				Target:           r.Target,
+			})
+		}
+	}
+	sort.SliceStable(vulns, func(i, j int) bool {
+		if a, b := severityRank(vulns[i].Severity), severityRank(vulns[j].Severity); a != b {
+			return a > b
+		}
+		return vulns[i].ID < vulns[j].ID
+	})
+	return vulns, nil
+}
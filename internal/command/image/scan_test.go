@@ -0,0 +1,108 @@
+package image
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/tokens"
+
+	"github.com/superfly/flyctl/internal/config"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for s, want := range map[string]int{"low": 1, "Medium": 2, "HIGH": 3, "critical": 4} {
+		rank, err := parseSeverity(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, rank, s)
+	}
+
+	for _, s := range []string{"", "unknown", "severe"} {
+		_, err := parseSeverity(s)
+		assert.Error(t, err, s)
+	}
+}
+
+const trivyOutput = `{
+  "Results": [
+    {
+      "Target": "debian 12",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2", "PkgName": "libc", "InstalledVersion": "2.36", "Severity": "LOW"},
+        {"VulnerabilityID": "CVE-3", "PkgName": "openssl", "InstalledVersion": "3.0.1", "FixedVersion": "3.0.2", "Severity": "CRITICAL", "Title": "bad"}
+      ]
+    },
+    {
+      "Target": "app/package-lock.json",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-1", "PkgName": "lodash", "InstalledVersion": "4.17.0", "Severity": "CRITICAL"}
+      ]
+    },
+    {"Target": "app/Gemfile.lock"}
+  ]
+}`
+
+// fakeTrivy writes a trivy script printing output, which records its
+// arguments and registry credentials in the file it returns.
+func fakeTrivy(t *testing.T, output string, exitCode int) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake trivy is a shell script")
+	}
+
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "output.json"), []byte(output), 0o644))
+
+	script := filepath.Join(dir, "trivy")
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+echo "$@" "user=$TRIVY_USERNAME" "password=$TRIVY_PASSWORD" > `+calls+`
+cat `+filepath.Join(dir, "output.json")+`
+echo "scan failed" >&2
+exit `+strconv.Itoa(exitCode)+`
+`), 0o755))
+	return script, calls
+}
+
+func TestScanImage(t *testing.T) {
+	t.Setenv("TRIVY_USERNAME", "")
+	t.Setenv("TRIVY_PASSWORD", "")
+	ctx := config.NewContext(context.Background(), &config.Config{Tokens: tokens.Parse("fo1_token")})
+
+	trivy, calls := fakeTrivy(t, trivyOutput, 0)
+	vulns, err := scanImage(ctx, trivy, "registry.fly.io/my-app:deployment-1")
+	require.NoError(t, err)
+	assert.Equal(t, []vulnerability{
+		{ID: "CVE-1", Package: "lodash", InstalledVersion: "4.17.0", Severity: "CRITICAL", Target: "app/package-lock.json"},
+		{ID: "CVE-3", Package: "openssl", InstalledVersion: "3.0.1", FixedVersion: "3.0.2", Severity: "CRITICAL", Title: "bad", Target: "debian 12"},
+		{ID: "CVE-2", Package: "libc", InstalledVersion: "2.36", Severity: "LOW", Target: "debian 12"},
+	}, vulns)
+
+	call, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, "image --quiet --format json --scanners vuln registry.fly.io/my-app:deployment-1 user=x password=fo1_token\n", string(call))
+
+	// Only the Fly registry gets the Fly token.
+	_, err = scanImage(ctx, trivy, "docker.io/library/nginx:latest")
+	require.NoError(t, err)
+	call, err = os.ReadFile(calls)
+	require.NoError(t, err)
+	assert.Equal(t, "image --quiet --format json --scanners vuln docker.io/library/nginx:latest user= password=\n", string(call))
+}
+
+func TestScanImageErrors(t *testing.T) {
+	ctx := config.NewContext(context.Background(), &config.Config{Tokens: tokens.Parse("fo1_token")})
+
+	trivy, _ := fakeTrivy(t, trivyOutput, 1)
+	_, err := scanImage(ctx, trivy, "nginx")
+	assert.ErrorContains(t, err, "failed scanning nginx")
+	assert.ErrorContains(t, err, "scan failed")
+
+	trivy, _ = fakeTrivy(t, "not json", 0)
+	_, err = scanImage(ctx, trivy, "nginx")
+	assert.ErrorContains(t, err, "failed parsing the scan of nginx")
+}
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -16,7 +17,13 @@ import (
 func newUpdate() *cobra.Command {
 	const (
 		long = `Update the app's image to the latest available version.
-The update will perform a rolling restart against each Machine, which may result in a brief service disruption.`
+The update will perform a rolling restart against each Machine, which may result in a brief service disruption.
+
+With --auto, only the managed images Fly.io publishes (Postgres and Redis) are
+checked for new versions. The notify policy reports them, while the apply
+policy updates the Machines, within the maintenance window given by --window
+when there is one, such as "sat,sun 02:00-04:00" in UTC. --watch keeps checking
+every --interval until interrupted.`
 		short = "Updates the app's image to the latest available version."
 		usage = "update"
 	)
@@ -41,6 +48,28 @@ The update will perform a rolling restart against each Machine, which may result
 			Description: "Skip waiting for health checks inbetween VM updates. (Machines only)",
 			Default:     false,
 		},
+		flag.Bool{
+			Name:        "auto",
+			Description: "Check managed images for new versions and handle them by --policy",
+		},
+		flag.String{
+			Name:        "policy",
+			Description: "What --auto does with new versions: notify or apply",
+			Default:     policyNotify,
+		},
+		flag.String{
+			Name:        "window",
+			Description: "Maintenance window --auto applies updates in, in UTC, such as \"sat,sun 02:00-04:00\"",
+		},
+		flag.Bool{
+			Name:        "watch",
+			Description: "Keep checking for new versions with --auto",
+		},
+		flag.Duration{
+			Name:        "interval",
+			Description: "How often --watch checks for new versions",
+			Default:     time.Hour,
+		},
 	)
 
 	return cmd
@@ -62,6 +91,10 @@ func runUpdate(ctx context.Context) error {
 		return err
 	}
 
+	if flag.GetBool(ctx, "auto") {
+		return runAutoUpdate(ctx, app)
+	}
+
 	if app.IsPostgresApp() {
 		return updatePostgresOnMachines(ctx, app)
 	}
@@ -2,7 +2,10 @@ package ips
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/appconfig"
@@ -33,6 +36,7 @@ func newAllocatev4() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.Region(),
+		regionsFlag(),
 	)
 	return cmd
 }
@@ -52,6 +56,7 @@ func newAllocatev6() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.Region(),
+		regionsFlag(),
 		flag.Bool{
 			Name:        "private",
 			Description: "Allocate a private IPv6 address",
@@ -66,26 +71,43 @@ func newAllocatev6() *cobra.Command {
 	return cmd
 }
 
+func regionsFlag() flag.StringSlice {
+	return flag.StringSlice{
+		Name:        "regions",
+		Description: "Allocate one regional IP address for each of these regions, announced only from that region, instead of a global Anycast address",
+	}
+}
+
 func runAllocateIPAddressV4(ctx context.Context) error {
 	addrType := "v4"
 	if flag.GetBool(ctx, "shared") {
 		addrType = "shared_v4"
-	} else if !flag.GetBool(ctx, "yes") {
-		msg := `Looks like you're accessing a paid feature. Dedicated IPv4 addresses now cost $2/mo.
+	}
+	return runAllocateIPAddress(ctx, addrType, nil, "")
+}
+
+// confirmDedicatedIPv4 asks whether to allocate count paid dedicated IPv4
+// addresses, unless --yes is set.
+func confirmDedicatedIPv4(ctx context.Context, count int) (bool, error) {
+	if flag.GetYes(ctx) {
+		return true, nil
+	}
+
+	msg := `Looks like you're accessing a paid feature. Dedicated IPv4 addresses now cost $2/mo.
 Are you ok with this? Alternatively, you could allocate a shared IPv4 address with the --shared flag.`
+	if count > 1 {
+		msg = fmt.Sprintf(`Looks like you're accessing a paid feature. Dedicated IPv4 addresses now cost $2/mo,
+and --regions allocates %d of them, one for each region, so $%d/mo. Are you ok with this?`, count, 2*count)
+	}
 
-		switch confirmed, err := prompt.Confirm(ctx, msg); {
-		case err == nil:
-			if !confirmed {
-				return nil
-			}
-		case prompt.IsNonInteractive(err):
-			return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
-		default:
-			return err
-		}
+	switch confirmed, err := prompt.Confirm(ctx, msg); {
+	case err == nil:
+		return confirmed, nil
+	case prompt.IsNonInteractive(err):
+		return false, prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+	default:
+		return false, err
 	}
-	return runAllocateIPAddress(ctx, addrType, nil, "")
 }
 
 func runAllocateIPAddressV6(ctx context.Context) (err error) {
@@ -114,6 +136,20 @@ func runAllocateIPAddress(ctx context.Context, addrType string, org *fly.Organiz
 
 	appName := appconfig.NameFromContext(ctx)
 
+	regions, err := allocationRegions(ctx, addrType)
+	if err != nil {
+		return err
+	}
+
+	if addrType == "v4" {
+		switch confirmed, err := confirmDedicatedIPv4(ctx, len(regions)); {
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
 	if addrType == "shared_v4" {
 		ip, err := client.AllocateSharedIPAddress(ctx, appName)
 		if err != nil {
@@ -125,14 +161,52 @@ func runAllocateIPAddress(ctx context.Context, addrType string, org *fly.Organiz
 		return nil
 	}
 
-	region := flag.GetRegion(ctx)
-
-	ipAddress, err := client.AllocateIPAddress(ctx, appName, addrType, region, org, network)
-	if err != nil {
-		return err
+	ipAddresses := make([]fly.IPAddress, 0, len(regions))
+	for _, region := range regions {
+		ipAddress, err := client.AllocateIPAddress(ctx, appName, addrType, region, org, network)
+		if err != nil {
+			if len(ipAddresses) > 0 {
+				renderListTable(ctx, ipAddresses)
+			}
+			return err
+		}
+		ipAddresses = append(ipAddresses, *ipAddress)
 	}
 
-	ipAddresses := []fly.IPAddress{*ipAddress}
 	renderListTable(ctx, ipAddresses)
 	return nil
 }
+
+// allocationRegions returns the regions to allocate an address of addrType
+// in: the regions of --regions, or the region of --region, which is empty
+// for a global address.
+func allocationRegions(ctx context.Context, addrType string) ([]string, error) {
+	regions := flag.GetStringSlice(ctx, "regions")
+	if len(regions) == 0 {
+		return []string{flag.GetRegion(ctx)}, nil
+	}
+
+	switch {
+	case flag.IsSpecified(ctx, "region"):
+		return nil, errors.New("--region and --regions are mutually exclusive")
+	case addrType == "shared_v4":
+		return nil, errors.New("shared IPv4 addresses are always global, --regions needs a dedicated address")
+	case addrType == "private_v6":
+		return nil, errors.New("private IPv6 addresses aren't announced from edge regions, --regions only applies to public addresses")
+	}
+
+	platformRegions, _, err := fly.ClientFromContext(ctx).PlatformRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving regions: %w", err)
+	}
+	known := make(map[string]bool, len(platformRegions))
+	for _, r := range platformRegions {
+		known[r.Code] = true
+	}
+	for _, region := range regions {
+		if !known[region] {
+			return nil, fmt.Errorf("unknown region %s, run `fly platform regions` to list them", region)
+		}
+	}
+	return lo.Uniq(regions), nil
+}
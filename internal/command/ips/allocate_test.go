@@ -0,0 +1,31 @@
+package ips
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestConfirmDedicatedIPv4(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+
+	fs := pflag.NewFlagSet("allocate-v4", pflag.ContinueOnError)
+	fs.Bool("yes", false, "")
+	ctx := flag.NewContext(iostreams.NewContext(context.Background(), ios), fs)
+
+	// Regional addresses are allocated without asking only with --yes.
+	confirmed, err := confirmDedicatedIPv4(ctx, 3)
+	assert.False(t, confirmed)
+	assert.True(t, prompt.IsNonInteractive(err))
+
+	require.NoError(t, fs.Set("yes", "true"))
+	confirmed, err = confirmDedicatedIPv4(ctx, 3)
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+}
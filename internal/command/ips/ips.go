@@ -20,6 +20,7 @@ func New() *cobra.Command {
 		newAllocatev6(),
 		newPrivate(),
 		newRelease(),
+		newRegions(),
 	)
 	return cmd
 }
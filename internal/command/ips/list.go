@@ -10,6 +10,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -41,7 +42,9 @@ func runIPAddressesList(ctx context.Context) error {
 	out := iostreams.FromContext(ctx).Out
 
 	appName := appconfig.NameFromContext(ctx)
-	ipAddresses, err := client.GetIPAddresses(ctx, appName)
+	ipAddresses, err := readcache.Get(ctx, "ips list:"+appName, func(ctx context.Context) ([]fly.IPAddress, error) {
+		return client.GetIPAddresses(ctx, appName)
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,111 @@
+package ips
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newRegions() *cobra.Command {
+	const (
+		long = `Lists the scope of each public IP address of the application, and the
+edge regions expected to announce it from that scope: regional addresses from
+their region, and global Anycast addresses from every edge region of the
+platform. The platform doesn't report which edges currently announce an
+address, so the regions are inferred from its scope, not observed.`
+		short = `List the regions expected to announce IP addresses`
+	)
+
+	cmd := command.New("regions", short, long, runIPAddressesRegions,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+// announcedIPAddress is a public IP address with the regions expected to
+// announce it, inferred from its scope.
+type announcedIPAddress struct {
+	Address         string   `json:"address"`
+	Type            string   `json:"type"`
+	Scope           string   `json:"scope"`
+	ExpectedRegions []string `json:"expected_regions"`
+}
+
+func runIPAddressesRegions(ctx context.Context) error {
+	var (
+		client  = fly.ClientFromContext(ctx)
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	ipAddresses, err := client.GetIPAddresses(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	platformRegions, _, err := client.PlatformRegions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving regions: %w", err)
+	}
+	edgeRegions := make([]string, 0, len(platformRegions))
+	for _, r := range platformRegions {
+		edgeRegions = append(edgeRegions, r.Code)
+	}
+	sort.Strings(edgeRegions)
+
+	announced := announcedIPAddresses(ipAddresses, edgeRegions)
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, announced)
+	}
+
+	rows := make([][]string, 0, len(announced))
+	for _, a := range announced {
+		regions := strings.Join(a.ExpectedRegions, ", ")
+		if a.Scope == "global" {
+			regions = fmt.Sprintf("all %d edge regions", len(a.ExpectedRegions))
+		}
+		rows = append(rows, []string{a.Address, a.Type, a.Scope, regions})
+	}
+	if err := render.Table(out, "", rows, "IP", "Type", "Scope", "Expected Regions"); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Expected regions are inferred from the scope of each address, not observed announcements.")
+	return nil
+}
+
+// announcedIPAddresses returns the public addresses of ipAddresses with the
+// regions expected to announce them, of edgeRegions for global ones.
+func announcedIPAddresses(ipAddresses []fly.IPAddress, edgeRegions []string) []announcedIPAddress {
+	announced := make([]announcedIPAddress, 0, len(ipAddresses))
+	for _, ip := range ipAddresses {
+		if ip.Type == "private_v6" {
+			continue
+		}
+
+		a := announcedIPAddress{Address: ip.Address, Type: ip.Type, Scope: "global", ExpectedRegions: edgeRegions}
+		if ip.Region != "" && ip.Region != "global" {
+			a.Scope = "regional"
+			a.ExpectedRegions = []string{ip.Region}
+		}
+		announced = append(announced, a)
+	}
+	return announced
+}
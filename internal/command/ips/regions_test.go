@@ -0,0 +1,24 @@
+package ips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestAnnouncedIPAddresses(t *testing.T) {
+	edgeRegions := []string{"ams", "iad", "syd"}
+	ipAddresses := []fly.IPAddress{
+		{Address: "2a09:8280:1::1", Type: "v6", Region: "global"},
+		{Address: "137.66.1.1", Type: "v4", Region: "ams"},
+		{Address: "66.241.124.1", Type: "shared_v4"},
+		{Address: "fdaa:0:1::2", Type: "private_v6", Region: "global"},
+	}
+
+	assert.Equal(t, []announcedIPAddress{
+		{Address: "2a09:8280:1::1", Type: "v6", Scope: "global", ExpectedRegions: edgeRegions},
+		{Address: "137.66.1.1", Type: "v4", Scope: "regional", ExpectedRegions: []string{"ams"}},
+		{Address: "66.241.124.1", Type: "shared_v4", Scope: "global", ExpectedRegions: edgeRegions},
+	}, announcedIPAddresses(ipAddresses, edgeRegions))
+}
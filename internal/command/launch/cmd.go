@@ -8,14 +8,17 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"time"
 
+	"github.com/kballard/go-shellquote"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/command/deploy"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/launchtemplate"
 	"github.com/superfly/flyctl/internal/metrics"
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/tracing"
@@ -72,7 +75,15 @@ func New() (cmd *cobra.Command) {
 		},
 		flag.String{
 			Name:        "from",
-			Description: "A github repo URL to use as a template for the new app",
+			Description: "A git repo URL to launch the app from, cloned into a temporary directory unless the current one is empty. A branch or tag can be selected with a #ref suffix",
+		},
+		flag.String{
+			Name:        "ref",
+			Description: "The branch or tag of the --from repo to launch",
+		},
+		flag.String{
+			Name:        "template",
+			Description: "A launch template image to start the app from, e.g. ghcr.io/org/template:v1. See 'fly templates search'",
 		},
 		flag.Bool{
 			Name:        "manifest",
@@ -80,9 +91,37 @@ func New() (cmd *cobra.Command) {
 			Hidden:      true,
 		},
 		flag.String{
-			Name:        "from-manifest",
-			Description: "Path to a manifest file for Launch ('-' reads from stdin)",
-			Hidden:      true,
+			Name:        "plan",
+			Description: "Path to a launch plan exported with --export-plan to replay ('-' reads from stdin). --name, --org and --region override its values",
+			Aliases:     []string{"from-manifest"},
+		},
+		flag.String{
+			Name:        "export-plan",
+			Description: "Write the launch plan, including every decision made while launching, to this path",
+		},
+		flag.String{
+			Name:        "output",
+			Description: "Print the resources launch would create as infrastructure-as-code instead of launching, one of: terraform, pulumi",
+		},
+		flag.String{
+			Name:        "db",
+			Description: "The database to provision and attach: " + strings.Join(databaseProviders, ", ") + ". Defaults to the one the app source needs",
+		},
+		flag.StringSlice{
+			Name:        "attach",
+			Description: "Attach an existing resource instead of creating one, as kind=name where kind is postgres, redis or tigris. Can be specified multiple times",
+		},
+		flag.Bool{
+			Name:        "with-cost",
+			Description: "Include the estimated monthly cost of the app's machines in the launch plan",
+		},
+		flag.Bool{
+			Name:        "dry-run",
+			Description: "Write the files the launch plan generates, such as fly.toml and the Dockerfile, without creating the app or any of its resources",
+		},
+		flag.Bool{
+			Name:        "workspace",
+			Description: "Launch an app for each project found in the subdirectories of --path, e.g. apps/api and apps/web, sharing the org and region, and list them in " + WorkspaceManifestFileName,
 		},
 		// legacy launch flags (deprecated)
 		flag.Bool{
@@ -98,11 +137,13 @@ func New() (cmd *cobra.Command) {
 		},
 	)
 
+	cmd.AddCommand(newRegenerate())
+
 	return
 }
 
 func getManifestArgument(ctx context.Context) (*LaunchManifest, error) {
-	path := flag.GetString(ctx, "from-manifest")
+	path := flag.GetString(ctx, "plan")
 	if path == "" {
 		return nil, nil
 	}
@@ -126,38 +167,211 @@ func getManifestArgument(ctx context.Context) (*LaunchManifest, error) {
 	if err != nil {
 		return nil, err
 	}
+	if manifest.Plan == nil {
+		return nil, fmt.Errorf("%s is not a launch plan", path)
+	}
+
+	// Allow reusing a plan as a template for other apps
+	if name := flag.GetString(ctx, "name"); name != "" {
+		manifest.Plan.AppName = name
+	}
+	if org := flag.GetOrg(ctx); org != "" {
+		manifest.Plan.OrgSlug = org
+	}
+	if region := flag.GetRegion(ctx); region != "" {
+		manifest.Plan.RegionCode = region
+	}
 	return &manifest, nil
 }
 
-func setupFromTemplate(ctx context.Context) (context.Context, error) {
-	from := flag.GetString(ctx, "from")
-	if from == "" {
-		return ctx, nil
+// exportManifest writes manifest to the --export-plan path, if any.
+func exportManifest(ctx context.Context, manifest *LaunchManifest) error {
+	path := flag.GetString(ctx, "export-plan")
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to export launch plan: %w", err)
+	}
+
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "Wrote launch plan to %s, replay it with 'fly launch --plan %s'\n", path, path)
+	return nil
+}
+
+// parseGitSource splits a --from value into the repository URL and the
+// branch or tag to check out, which can be given as a #ref suffix.
+func parseGitSource(from, ref string) (string, string) {
+	if i := strings.LastIndex(from, "#"); i >= 0 {
+		if ref == "" {
+			ref = from[i+1:]
+		}
+		from = from[:i]
+	}
+	return from, ref
+}
+
+// setupFromTemplate fetches the --from repository or the --template image and
+// makes it the working directory. Empty working directories are used as is,
+// otherwise the source goes into a temporary directory. The returned function
+// cleans the temporary directory up once the launch has deployed successfully.
+func setupFromTemplate(ctx context.Context) (context.Context, func(deployed bool), error) {
+	noop := func(bool) {}
+	io := iostreams.FromContext(ctx)
+
+	from, ref := parseGitSource(flag.GetString(ctx, "from"), flag.GetString(ctx, "ref"))
+	templateRef := flag.GetString(ctx, "template")
+	switch {
+	case from != "" && templateRef != "":
+		return ctx, noop, errors.New("--from and --template can't be used together")
+	case from == "" && ref != "":
+		return ctx, noop, errors.New("--ref requires --from")
+	case from == "" && templateRef == "":
+		return ctx, noop, nil
 	}
 
 	entries, err := os.ReadDir(".")
 	if err != nil {
-		return ctx, fmt.Errorf("failed to read directory: %w", err)
+		return ctx, noop, fmt.Errorf("failed to read directory: %w", err)
 	}
+
+	dir, cleanup := ".", noop
 	if len(entries) > 0 {
-		return ctx, errors.New("directory not empty, refusing to clone from git")
+		if dir, err = os.MkdirTemp("", "fly-launch-"); err != nil {
+			return ctx, noop, fmt.Errorf("failed to create a directory to clone into: %w", err)
+		}
+		cleanup = func(deployed bool) {
+			if !deployed {
+				fmt.Fprintf(io.ErrOut, "The source of the app was kept in %s\n", dir)
+				return
+			}
+			os.RemoveAll(dir)
+		}
 	}
 
-	fmt.Printf("Launching from git repo %s\n", from)
+	if templateRef != "" {
+		fmt.Fprintf(io.Out, "Launching from template %s\n", templateRef)
+		tmpl, err := launchtemplate.Fetch(ctx, templateRef, dir)
+		if err != nil {
+			cleanup(true)
+			return ctx, noop, err
+		}
+		fmt.Fprintf(io.Out, "Using template %s\n", tmpl.Pinned)
+		if tmpl.Manifest.Description != "" {
+			fmt.Fprintln(io.Out, tmpl.Manifest.Description)
+		}
+		if err := confirmTemplateHooks(ctx, tmpl); err != nil {
+			cleanup(true)
+			return ctx, noop, err
+		}
+		ctx = launchtemplate.NewContext(ctx, tmpl)
+	} else if err := cloneGitSource(ctx, from, ref, dir); err != nil {
+		cleanup(true)
+		return ctx, noop, err
+	}
 
-	cmd := exec.Command("git", "clone", from, ".")
+	if dir != "." {
+		if err := os.Chdir(dir); err != nil {
+			cleanup(true)
+			return ctx, noop, err
+		}
+	}
+
+	ctx, err = command.LoadAppConfigIfPresent(ctx)
+	return ctx, cleanup, err
+}
+
+func cloneGitSource(ctx context.Context, from, ref, dir string) error {
+	io := iostreams.FromContext(ctx)
+
+	if ref != "" {
+		fmt.Fprintf(io.Out, "Launching from git repo %s at %s\n", from, ref)
+	} else {
+		fmt.Fprintf(io.Out, "Launching from git repo %s\n", from)
+	}
+
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	cmd := exec.Command("git", append(args, from, dir)...)
 	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd.Stdout = io.Out
+	cmd.Stderr = io.ErrOut
 	if err := cmd.Run(); err != nil {
-		return ctx, err
+		return fmt.Errorf("failed to clone %s: %w", from, err)
 	}
+	return nil
+}
 
-	ctx, err = command.LoadAppConfigIfPresent(ctx)
-	return ctx, err
+// confirmTemplateHooks lists the post-launch hooks of tmpl, which run commands
+// from a remote image on this machine, and asks whether to run them. Declined
+// hooks are dropped from tmpl. Non-interactive launches require --yes.
+func confirmTemplateHooks(ctx context.Context, tmpl *launchtemplate.Template) error {
+	hooks := tmpl.Manifest.PostLaunch
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.Out, "Template %s runs these commands once the app is launched:\n", tmpl.Pinned)
+	for _, hook := range hooks {
+		fmt.Fprintf(io.Out, "  %s\n", shellquote.Join(hook.Command...))
+	}
+
+	if flag.GetYes(ctx) {
+		return nil
+	}
+	switch confirmed, err := prompt.Confirm(ctx, "Run these commands?"); {
+	case err == nil:
+		if !confirmed {
+			fmt.Fprintln(io.Out, "Skipping the post-launch commands of the template")
+			tmpl.Manifest.PostLaunch = nil
+		}
+		return nil
+	case prompt.IsNonInteractive(err):
+		return prompt.NonInteractiveError("--yes flag must be specified to run the post-launch commands of a template when not running interactively")
+	default:
+		return err
+	}
 }
 
-func run(ctx context.Context) (err error) {
+// runTemplateHooks runs the post-launch hooks of the launched template, which
+// confirmTemplateHooks already had approved.
+func runTemplateHooks(ctx context.Context) error {
+	tmpl := launchtemplate.FromContext(ctx)
+	if tmpl == nil {
+		return nil
+	}
+
+	io := iostreams.FromContext(ctx)
+	for _, hook := range tmpl.Manifest.PostLaunch {
+		fmt.Fprintln(io.Out, lo.Ternary(hook.Description != "", hook.Description, "Running "+shellquote.Join(hook.Command...)))
+
+		cmd := exec.CommandContext(ctx, hook.Command[0], hook.Command[1:]...)
+		cmd.Stdin = io.In
+		cmd.Stdout = io.Out
+		cmd.Stderr = io.ErrOut
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("post-launch hook %s of template %s failed: %w", shellquote.Join(hook.Command...), tmpl.Pinned, err)
+		}
+	}
+	return nil
+}
+
+func run(ctx context.Context) error {
+	if flag.GetBool(ctx, "workspace") {
+		return runWorkspace(ctx)
+	}
+	return launchApp(ctx)
+}
+
+// launchApp launches the single app at --path.
+func launchApp(ctx context.Context) (err error) {
 	io := iostreams.FromContext(ctx)
 
 	tp, err := tracing.InitTraceProviderWithoutApp(ctx)
@@ -206,10 +420,13 @@ func run(ctx context.Context) (err error) {
 	}
 
 	// "--from" arg handling
-	ctx, err = setupFromTemplate(ctx)
+	ctx, cleanupTemplate, err := setupFromTemplate(ctx)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		cleanupTemplate(err == nil && !flag.GetBool(ctx, "no-deploy") && !flag.GetBool(ctx, "manifest"))
+	}()
 
 	incompleteLaunchManifest := false
 	canEnterUi := !flag.GetBool(ctx, "manifest")
@@ -261,44 +478,46 @@ func run(ctx context.Context) (err error) {
 		return err
 	}
 
-	summary, err := state.PlanSummary(ctx)
-	if err != nil {
-		return err
+	if format := flag.GetString(ctx, "output"); format != "" {
+		return state.ExportIaC(ctx, format)
 	}
 
-	family := ""
-	if state.sourceInfo != nil {
-		family = state.sourceInfo.Family
+	if err := state.printPlan(ctx); err != nil {
+		return err
 	}
 
-	fmt.Fprintf(
-		io.Out,
-		"We're about to launch your %s on Fly.io. Here's what you're getting:\n\n%s\n",
-		familyToAppType(family),
-		summary,
-	)
+	if flag.GetBool(ctx, "dry-run") {
+		return state.DryRun(ctx)
+	}
 
-	editInUi := false
-	if !flag.GetBool(ctx, "yes") {
-		message := lo.Ternary(
-			incompleteLaunchManifest,
-			"Would you like to continue in the web UI?",
-			"Do you want to tweak these settings before proceeding?",
-		)
-		editInUi, err = prompt.Confirm(ctx, message)
-		if err != nil && !errors.Is(err, prompt.ErrNonInteractive) {
+	if incompleteLaunchManifest {
+		editInUi := false
+		if !flag.GetBool(ctx, "yes") {
+			editInUi, err = prompt.Confirm(ctx, "Would you like to continue in the web UI?")
+			if err != nil && !errors.Is(err, prompt.ErrNonInteractive) {
+				return err
+			}
+		}
+		if !editInUi {
+			// UI was required to reconcile launch issues, but user denied. Abort.
+			return errors.New("launch can not continue with errors present")
+		}
+		if err := state.EditInWebUi(ctx); err != nil {
 			return err
 		}
-	}
-
-	if editInUi {
-		err = state.EditInWebUi(ctx)
+	} else if !flag.GetBool(ctx, "yes") {
+		proceed, err := state.reviewPlan(ctx)
 		if err != nil {
 			return err
 		}
-	} else if incompleteLaunchManifest {
-		// UI was required to reconcile launch issues, but user denied. Abort.
-		return errors.New("launch can not continue with errors present")
+		if !proceed {
+			fmt.Fprintln(io.ErrOut, "Launch cancelled, nothing was created.")
+			return nil
+		}
+	}
+
+	if err := exportManifest(ctx, &state.LaunchManifest); err != nil {
+		return err
 	}
 
 	err = state.Launch(ctx)
@@ -306,7 +525,7 @@ func run(ctx context.Context) (err error) {
 		return err
 	}
 
-	return nil
+	return runTemplateHooks(ctx)
 }
 
 // familyToAppType returns a string that describes the app type based on the source info
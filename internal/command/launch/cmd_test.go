@@ -0,0 +1,40 @@
+package launch
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/launchtemplate"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestConfirmTemplateHooks(t *testing.T) {
+	newCtx := func(yes bool) (context.Context, *bytes.Buffer, *launchtemplate.Template) {
+		fs := pflag.NewFlagSet("launch", pflag.ContinueOnError)
+		fs.Bool("yes", yes, "")
+		ios, _, out, _ := iostreams.Test()
+		ctx := flag.NewContext(iostreams.NewContext(context.Background(), ios), fs)
+		return ctx, out, &launchtemplate.Template{
+			Pinned: "ghcr.io/acme/template@sha256:abc",
+			Manifest: launchtemplate.Manifest{PostLaunch: []launchtemplate.Hook{
+				{Command: []string{"bin/rails", "db:seed"}},
+			}},
+		}
+	}
+
+	ctx, out, tmpl := newCtx(true)
+	require.NoError(t, confirmTemplateHooks(ctx, tmpl))
+	assert.Len(t, tmpl.Manifest.PostLaunch, 1)
+	assert.Contains(t, out.String(), "bin/rails db:seed")
+
+	// Hooks from a remote image never run unattended without --yes
+	ctx, _, tmpl = newCtx(false)
+	err := confirmTemplateHooks(ctx, tmpl)
+	assert.True(t, prompt.IsNonInteractive(err), "expected a non-interactive error, got %v", err)
+}
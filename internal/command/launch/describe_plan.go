@@ -28,6 +28,9 @@ func describePostgresPlan(launchPlan *plan.LaunchPlan) (string, error) {
 }
 
 func describeFlyPostgresPlan(p *plan.FlyPostgresPlan) (string, error) {
+	if p.Attach {
+		return fmt.Sprintf("(Fly Postgres) attach existing cluster %s", p.AppName), nil
+	}
 
 	nodePlural := lo.Ternary(p.Nodes == 1, "", "s")
 	nodesStr := fmt.Sprintf("(Fly Postgres) %d Node%s", p.Nodes, nodePlural)
@@ -49,6 +52,14 @@ func describeSupabasePostgresPlan(p *plan.SupabasePostgresPlan, launchPlan *plan
 	return fmt.Sprintf("(Supabase) %s in %s", p.GetDbName(launchPlan), p.GetRegion(launchPlan)), nil
 }
 
+func describeMySQLPlan(launchPlan *plan.LaunchPlan) string {
+	switch provider := launchPlan.MySQL.Provider().(type) {
+	case *plan.PlanetScaleMySQLPlan:
+		return fmt.Sprintf("(PlanetScale) %s in %s", provider.GetDbName(launchPlan), provider.GetRegion(launchPlan))
+	}
+	return descriptionNone
+}
+
 func describeRedisPlan(ctx context.Context, p plan.RedisPlan, org *fly.Organization) (string, error) {
 
 	switch provider := p.Provider().(type) {
@@ -59,6 +70,9 @@ func describeRedisPlan(ctx context.Context, p plan.RedisPlan, org *fly.Organizat
 }
 
 func describeUpstashRedisPlan(ctx context.Context, p *plan.UpstashRedisPlan, org *fly.Organization) (string, error) {
+	if p.AttachTo != "" {
+		return fmt.Sprintf("attach existing database %s", p.AttachTo), nil
+	}
 
 	plan, err := redis.DeterminePlan(ctx, org)
 	if err != nil {
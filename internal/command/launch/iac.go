@@ -0,0 +1,389 @@
+package launch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/scanner"
+)
+
+// iacFormats are the infrastructure-as-code formats launch can output.
+var iacFormats = []string{"terraform", "pulumi"}
+
+// iacValue is a resource attribute value: a string, an int, a bool, an
+// iacRef, a []iacValue or an iacBlock.
+type iacValue any
+
+// iacRef refers to an attribute of another resource.
+type iacRef struct {
+	Resource *iacResource
+	Attr     string
+}
+
+type iacAttr struct {
+	Key   string
+	Value iacValue
+}
+
+// iacBlock is an ordered set of attributes.
+type iacBlock []iacAttr
+
+// iacResource is a resource of the Fly.io Terraform provider. Pulumi uses the
+// same resources through its bridge of that provider.
+type iacResource struct {
+	Kind  string
+	Name  string
+	Attrs iacBlock
+}
+
+// iacNote describes something launch would create that has no matching
+// provider resource.
+type iacNote string
+
+// buildIaCResources returns the resources matching what launching the plan
+// creates. The machines run the image given through the "image" variable.
+func (state *launchState) buildIaCResources() ([]*iacResource, []iacNote, error) {
+	cfg := state.appConfig
+	p := state.Plan
+
+	app := &iacResource{Kind: "app", Name: "app", Attrs: iacBlock{
+		{"name", p.AppName},
+		{"org", p.OrgSlug},
+	}}
+	appRef := iacRef{app, "name"}
+	resources := []*iacResource{app}
+
+	var notes []iacNote
+
+	if cfg.HTTPService != nil || len(cfg.Services) > 0 {
+		for _, ipType := range []string{"v4", "v6"} {
+			resources = append(resources, &iacResource{Kind: "ip", Name: "ip_" + ipType, Attrs: iacBlock{
+				{"app", appRef},
+				{"type", ipType},
+			}})
+		}
+	}
+
+	groups := cfg.ProcessNames()
+	slices.Sort(groups)
+	for _, group := range groups {
+		mConfig, err := cfg.ToMachineConfig(group, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i := 0; i < state.machineCount(mConfig); i++ {
+			name := fmt.Sprintf("%s_%d", iacIdentifier(group), i)
+			attrs := iacBlock{
+				{"app", appRef},
+				{"region", p.RegionCode},
+				{"name", fmt.Sprintf("%s-%s-%d", p.AppName, group, i)},
+				{"image", iacRef{nil, "image"}},
+			}
+
+			guest := mConfig.Guest
+			if guest == nil {
+				guest = fly.MachinePresets[fly.DefaultVMSize]
+			}
+			attrs = append(attrs,
+				iacAttr{"cputype", guest.CPUKind},
+				iacAttr{"cpus", guest.CPUs},
+				iacAttr{"memorymb", guest.MemoryMB},
+			)
+
+			if len(mConfig.Init.Cmd) > 0 {
+				attrs = append(attrs, iacAttr{"cmd", lo.Map(mConfig.Init.Cmd, func(s string, _ int) iacValue { return s })})
+			}
+
+			if len(mConfig.Env) > 0 {
+				keys := lo.Keys(mConfig.Env)
+				slices.Sort(keys)
+				env := lo.Map(keys, func(k string, _ int) iacAttr { return iacAttr{k, mConfig.Env[k]} })
+				attrs = append(attrs, iacAttr{"env", iacBlock(env)})
+			}
+
+			if len(mConfig.Mounts) > 0 {
+				var mounts []iacValue
+				for _, m := range mConfig.Mounts {
+					size, err := iacVolumeSize(cfg, m.Name)
+					if err != nil {
+						return nil, nil, err
+					}
+					volume := &iacResource{Kind: "volume", Name: fmt.Sprintf("%s_%d", iacIdentifier(m.Name), i), Attrs: iacBlock{
+						{"app", appRef},
+						{"name", m.Name},
+						{"region", p.RegionCode},
+						{"size", size},
+					}}
+					resources = append(resources, volume)
+					mounts = append(mounts, iacBlock{
+						{"path", m.Path},
+						{"volume", iacRef{volume, "id"}},
+					})
+				}
+				attrs = append(attrs, iacAttr{"mounts", mounts})
+			}
+
+			if len(mConfig.Services) > 0 {
+				attrs = append(attrs, iacAttr{"services", lo.Map(mConfig.Services, func(s fly.MachineService, _ int) iacValue {
+					return iacBlock{
+						{"protocol", s.Protocol},
+						{"internal_port", s.InternalPort},
+						{"ports", lo.Map(s.Ports, func(port fly.MachinePort, _ int) iacValue {
+							return iacBlock{
+								{"port", lo.FromPtr(port.Port)},
+								{"handlers", lo.Map(port.Handlers, func(h string, _ int) iacValue { return h })},
+								{"force_https", port.ForceHTTPS},
+							}
+						})},
+					}
+				})})
+			}
+
+			resources = append(resources, &iacResource{Kind: "machine", Name: name, Attrs: attrs})
+		}
+	}
+
+	if pg := p.Postgres.FlyPostgres; pg != nil {
+		notes = append(notes, iacNote(fmt.Sprintf("Postgres: create with 'fly postgres create --name %s --region %s' and attach it with 'fly postgres attach %s --app %s'",
+			pg.AppName, p.RegionCode, pg.AppName, p.AppName)))
+	}
+	if p.Postgres.SupabasePostgres != nil {
+		notes = append(notes, iacNote("Supabase Postgres: create with 'fly ext supabase create'"))
+	}
+	if p.MySQL.PlanetScale != nil {
+		notes = append(notes, iacNote("PlanetScale MySQL: provisioned through the PlanetScale extension, e.g. with 'fly launch --db planetscale'"))
+	}
+	if p.Redis.UpstashRedis != nil {
+		notes = append(notes, iacNote("Upstash Redis: create with 'fly redis create' and set REDIS_URL as a secret"))
+	}
+	if p.ObjectStorage.TigrisObjectStorage != nil {
+		notes = append(notes, iacNote("Tigris object storage: create with 'fly storage create'"))
+	}
+	if p.Sentry {
+		notes = append(notes, iacNote("Sentry: create with 'fly ext sentry create'"))
+	}
+	if state.sourceInfo != nil && len(state.sourceInfo.Secrets) > 0 {
+		keys := lo.Map(state.sourceInfo.Secrets, func(s scanner.Secret, _ int) string { return s.Key })
+		notes = append(notes, iacNote(fmt.Sprintf("Secrets: set %s with 'fly secrets set'", strings.Join(keys, ", "))))
+	}
+
+	return resources, notes, nil
+}
+
+// iacVolumeSize returns the size in GB of the volumes of the named mount,
+// which launch creates with 1GB unless initial_size is set.
+func iacVolumeSize(cfg *appconfig.Config, name string) (int, error) {
+	for _, m := range cfg.Mounts {
+		if m.Source == name && m.InitialSize != "" {
+			return helpers.ParseSize(m.InitialSize, units.FromHumanSize, units.GB)
+		}
+	}
+	return 1, nil
+}
+
+// iacIdentifier turns name into a valid resource identifier.
+func iacIdentifier(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// ExportIaC writes the resources launching the plan would create in the given
+// infrastructure-as-code format, without creating anything.
+func (state *launchState) ExportIaC(ctx context.Context, format string) error {
+	io := iostreams.FromContext(ctx)
+
+	if err := state.updateComputeFromDeprecatedGuestFields(ctx); err != nil {
+		return err
+	}
+	state.updateConfig(ctx)
+
+	resources, notes, err := state.buildIaCResources()
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "terraform":
+		renderTerraform(io.Out, resources, notes)
+	case "pulumi":
+		renderPulumi(io.Out, state.Plan.AppName, resources, notes)
+	default:
+		return fmt.Errorf("unsupported --output format %q, use one of: %s", format, strings.Join(iacFormats, ", "))
+	}
+	return nil
+}
+
+func renderTerraform(w io.Writer, resources []*iacResource, notes []iacNote) {
+	fmt.Fprint(w, `terraform {
+  required_providers {
+    fly = {
+      source = "fly-apps/fly"
+    }
+  }
+}
+
+variable "image" {
+  description = "The image to run, e.g. pushed with 'fly deploy --build-only --push'"
+  type        = string
+}
+`)
+
+	for _, r := range resources {
+		fmt.Fprintf(w, "\nresource \"fly_%s\" %q {\n", r.Kind, r.Name)
+		writeHCLBlock(w, r.Attrs, 1)
+		fmt.Fprintln(w, "}")
+	}
+
+	if len(notes) > 0 {
+		fmt.Fprintln(w, "\n# Not managed by the provider, set these up with flyctl:")
+		for _, n := range notes {
+			fmt.Fprintf(w, "# - %s\n", n)
+		}
+	}
+}
+
+func writeHCLBlock(w io.Writer, block iacBlock, depth int) {
+	indent := strings.Repeat("  ", depth)
+	width := 0
+	for _, a := range block {
+		width = max(width, len(a.Key))
+	}
+	for _, a := range block {
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, hclKey(a.Key), formatHCLValue(a.Value, depth))
+	}
+}
+
+// hclKey quotes keys that aren't valid identifiers, like some env vars.
+func hclKey(key string) string {
+	if iacIdentifier(key) == key {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+func formatHCLValue(v iacValue, depth int) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case iacRef:
+		if v.Resource == nil {
+			return "var." + v.Attr
+		}
+		return fmt.Sprintf("fly_%s.%s.%s", v.Resource.Kind, v.Resource.Name, v.Attr)
+	case []iacValue:
+		items := lo.Map(v, func(item iacValue, _ int) string { return formatHCLValue(item, depth+1) })
+		if _, nested := lo.Find(v, func(item iacValue) bool { _, ok := item.(iacBlock); return ok }); !nested {
+			return "[" + strings.Join(items, ", ") + "]"
+		}
+		indent := strings.Repeat("  ", depth+1)
+		return "[\n" + indent + strings.Join(items, ",\n"+indent) + "\n" + strings.Repeat("  ", depth) + "]"
+	case iacBlock:
+		sb := &strings.Builder{}
+		sb.WriteString("{\n")
+		writeHCLBlock(sb, v, depth+1)
+		sb.WriteString(strings.Repeat("  ", depth) + "}")
+		return sb.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func renderPulumi(w io.Writer, appName string, resources []*iacResource, notes []iacNote) {
+	fmt.Fprintf(w, `name: %s
+runtime: yaml
+description: Resources for the Fly.io app %s, using the bridged fly-apps/fly provider
+config:
+  image:
+    type: string
+`, appName, appName)
+
+	if len(notes) > 0 {
+		fmt.Fprintln(w, "# Not managed by the provider, set these up with flyctl:")
+		for _, n := range notes {
+			fmt.Fprintf(w, "# - %s\n", n)
+		}
+	}
+
+	fmt.Fprintln(w, "resources:")
+	for _, r := range resources {
+		fmt.Fprintf(w, "  %s:\n    type: fly:%s\n    properties:\n", r.Name, pulumiType(r.Kind))
+		writeYAMLBlock(w, r.Attrs, 3)
+	}
+}
+
+func pulumiType(kind string) string {
+	if kind == "ip" {
+		return "Ip"
+	}
+	return strings.ToUpper(kind[:1]) + kind[1:]
+}
+
+func writeYAMLBlock(w io.Writer, block iacBlock, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, a := range block {
+		switch v := a.Value.(type) {
+		case iacBlock:
+			fmt.Fprintf(w, "%s%s:\n", indent, yamlKey(a.Key))
+			writeYAMLBlock(w, v, depth+1)
+		case []iacValue:
+			fmt.Fprintf(w, "%s%s:\n", indent, yamlKey(a.Key))
+			writeYAMLList(w, v, depth+1)
+		default:
+			fmt.Fprintf(w, "%s%s: %s\n", indent, yamlKey(a.Key), formatYAMLScalar(v))
+		}
+	}
+}
+
+func writeYAMLList(w io.Writer, list []iacValue, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, item := range list {
+		switch v := item.(type) {
+		case iacBlock:
+			// The first attribute goes on the dash line
+			sb := &strings.Builder{}
+			writeYAMLBlock(sb, v, depth+1)
+			fmt.Fprintf(w, "%s- %s", indent, strings.TrimPrefix(sb.String(), indent+"  "))
+		case []iacValue:
+			fmt.Fprintf(w, "%s-\n", indent)
+			writeYAMLList(w, v, depth+1)
+		default:
+			fmt.Fprintf(w, "%s- %s\n", indent, formatYAMLScalar(v))
+		}
+	}
+}
+
+func yamlKey(key string) string {
+	if iacIdentifier(key) == key {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+func formatYAMLScalar(v iacValue) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case iacRef:
+		if v.Resource == nil {
+			return "${" + v.Attr + "}"
+		}
+		return fmt.Sprintf("${%s.%s}", v.Resource.Name, v.Attr)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,100 @@
+package launch
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command/launch/plan"
+	"github.com/superfly/flyctl/scanner"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the launch tests")
+
+const iacTestConfig = `
+app = "iac-app"
+primary_region = "ams"
+
+[env]
+  PORT = "8080"
+  "LOG.LEVEL" = "debug"
+
+[processes]
+  web = "bin/server"
+  worker = "bin/worker --queue default"
+
+[[mounts]]
+  source = "data"
+  destination = "/data"
+  initial_size = "3gb"
+  processes = ["worker"]
+
+[http_service]
+  internal_port = 8080
+  force_https = true
+  processes = ["web"]
+
+[[vm]]
+  size = "shared-cpu-2x"
+  memory = "1gb"
+`
+
+func iacTestState(t *testing.T) *launchState {
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte(iacTestConfig), 0o644))
+	cfg, err := appconfig.LoadConfig(path)
+	require.NoError(t, err)
+
+	return &launchState{
+		LaunchManifest: LaunchManifest{Plan: &plan.LaunchPlan{
+			AppName:          "iac-app",
+			OrgSlug:          "personal",
+			RegionCode:       "ams",
+			HighAvailability: true,
+			Compute:          cfg.Compute,
+			Postgres:         plan.PostgresPlan{FlyPostgres: &plan.FlyPostgresPlan{AppName: "iac-app-db"}},
+		}},
+		planBuildCache: planBuildCache{
+			appConfig:  cfg,
+			sourceInfo: &scanner.SourceInfo{Secrets: []scanner.Secret{{Key: "SECRET_KEY_BASE"}}},
+		},
+	}
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestRenderTerraform(t *testing.T) {
+	resources, notes, err := iacTestState(t).buildIaCResources()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	renderTerraform(&out, resources, notes)
+	assertGolden(t, "iac.tf.golden", out.Bytes())
+}
+
+func TestRenderPulumi(t *testing.T) {
+	resources, notes, err := iacTestState(t).buildIaCResources()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	renderPulumi(&out, "iac-app", resources, notes)
+	assertGolden(t, "iac_pulumi.yaml.golden", out.Bytes())
+}
+
+func TestIaCIdentifier(t *testing.T) {
+	assert.Equal(t, "web", iacIdentifier("web"))
+	assert.Equal(t, "my_worker_1", iacIdentifier("my-worker.1"))
+}
@@ -3,6 +3,8 @@ package launch
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/samber/lo"
@@ -10,6 +12,7 @@ import (
 	"github.com/superfly/flyctl/flypg"
 	"github.com/superfly/flyctl/gql"
 	extensions_core "github.com/superfly/flyctl/internal/command/extensions/core"
+	"github.com/superfly/flyctl/internal/command/launch/plan"
 	"github.com/superfly/flyctl/internal/command/postgres"
 	"github.com/superfly/flyctl/internal/command/redis"
 	"github.com/superfly/flyctl/iostreams"
@@ -26,14 +29,21 @@ func (state *launchState) createDatabases(ctx context.Context) error {
 		}
 	}
 
-	if state.Plan.Postgres.SupabasePostgres != nil {
-		err := state.createSupabasePostgres(ctx)
+	if p := state.Plan.Postgres.SupabasePostgres; p != nil {
+		err := state.provisionDatabaseExtension(ctx, "supabase", p.GetDbName(state.Plan), p.GetRegion(state.Plan))
 		if err != nil {
 			// TODO(Ali): Make error printing here better.
 			fmt.Fprintf(iostreams.FromContext(ctx).ErrOut, "Error provisioning Supabase Postgres database: %s\n", err)
 		}
 	}
 
+	if p := state.Plan.MySQL.PlanetScale; p != nil {
+		err := state.provisionDatabaseExtension(ctx, "planetscale", p.GetDbName(state.Plan), p.GetRegion(state.Plan))
+		if err != nil {
+			fmt.Fprintf(iostreams.FromContext(ctx).ErrOut, "Error provisioning PlanetScale MySQL database: %s\n", err)
+		}
+	}
+
 	if state.Plan.Redis.UpstashRedis != nil {
 		err := state.createUpstashRedis(ctx)
 		if err != nil {
@@ -64,6 +74,60 @@ func (state *launchState) createDatabases(ctx context.Context) error {
 	return nil
 }
 
+// databaseProviders are the values accepted by --db.
+var databaseProviders = []string{"fly-postgres", "supabase", "planetscale", "none"}
+
+// planDatabase replaces the databases of lp with the one of the given
+// provider, as chosen with --db.
+func planDatabase(lp *plan.LaunchPlan, source *launchPlanSource, provider string) error {
+	const flagSource = "specified on the command line"
+
+	lp.Postgres = plan.PostgresPlan{}
+	lp.MySQL = plan.MySQLPlan{}
+	source.postgresSource = flagSource
+	source.mysqlSource = flagSource
+
+	switch provider {
+	case "fly-postgres":
+		lp.Postgres = plan.DefaultPostgres(lp)
+	case "supabase":
+		lp.Postgres.SupabasePostgres = &plan.SupabasePostgresPlan{}
+	case "planetscale":
+		lp.MySQL.PlanetScale = &plan.PlanetScaleMySQLPlan{}
+	case "none":
+	default:
+		return fmt.Errorf("invalid --db %q, must be one of: %s", provider, strings.Join(databaseProviders, ", "))
+	}
+	return nil
+}
+
+// planAttachments makes lp attach the existing resources given with --attach,
+// as kind=name pairs, instead of creating new ones.
+func planAttachments(lp *plan.LaunchPlan, source *launchPlanSource, attachments []string) error {
+	const attachSource = "attached from the command line"
+
+	for _, a := range attachments {
+		kind, name, ok := strings.Cut(a, "=")
+		if !ok || kind == "" || name == "" {
+			return fmt.Errorf("invalid --attach %q, expected kind=name, e.g. postgres=my-db", a)
+		}
+
+		switch kind {
+		case "postgres":
+			lp.Postgres = plan.PostgresPlan{FlyPostgres: &plan.FlyPostgresPlan{AppName: name, Attach: true}}
+			source.postgresSource = attachSource
+		case "redis":
+			lp.Redis = plan.RedisPlan{UpstashRedis: &plan.UpstashRedisPlan{AttachTo: name}}
+			source.redisSource = attachSource
+		case "tigris":
+			lp.ObjectStorage = plan.ObjectStoragePlan{TigrisObjectStorage: &plan.TigrisObjectStoragePlan{Name: name, Attach: true}}
+		default:
+			return fmt.Errorf("invalid --attach kind %q, must be one of: postgres, redis, tigris", kind)
+		}
+	}
+	return nil
+}
+
 func (state *launchState) createFlyPostgres(ctx context.Context) error {
 	var (
 		pgPlan    = state.Plan.Postgres.FlyPostgres
@@ -85,6 +149,10 @@ func (state *launchState) createFlyPostgres(ctx context.Context) error {
 		}
 	}
 
+	if pgPlan.Attach && !attachToExisting {
+		return fmt.Errorf("Postgres cluster %s to attach was not found", pgPlan.AppName)
+	}
+
 	if attachToExisting {
 		// If we try to attach to a PG cluster with the usual username
 		// format, we'll get an error (since that username already exists)
@@ -156,9 +224,9 @@ func (state *launchState) createFlyPostgres(ctx context.Context) error {
 	return nil
 }
 
-func (state *launchState) createSupabasePostgres(ctx context.Context) error {
-	postgresPlan := state.Plan.Postgres.SupabasePostgres
-
+// provisionDatabaseExtension provisions a database through the extension
+// provider, which sets its connection secrets on the app.
+func (state *launchState) provisionDatabaseExtension(ctx context.Context, provider, name, region string) error {
 	org, err := state.Org(ctx)
 	if err != nil {
 		return err
@@ -167,22 +235,21 @@ func (state *launchState) createSupabasePostgres(ctx context.Context) error {
 	params := extensions_core.ExtensionParams{
 		AppName:        state.Plan.AppName,
 		Organization:   org,
-		Provider:       "supabase",
-		OverrideName:   fly.Pointer(postgresPlan.GetDbName(state.Plan)),
-		OverrideRegion: postgresPlan.GetRegion(state.Plan),
+		Provider:       provider,
+		OverrideName:   fly.Pointer(name),
+		OverrideRegion: region,
 	}
 
 	_, err = extensions_core.ProvisionExtension(ctx, params)
-
-	if err != nil {
-		return err
-	}
-
 	return err
 }
 
 func (state *launchState) createUpstashRedis(ctx context.Context) error {
 	redisPlan := state.Plan.Redis.UpstashRedis
+	if redisPlan.AttachTo != "" {
+		return state.attachUpstashRedis(ctx, redisPlan.AttachTo)
+	}
+
 	dbName := fmt.Sprintf("%s-redis", state.Plan.AppName)
 	org, err := state.Org(ctx)
 	if err != nil {
@@ -216,9 +283,55 @@ func (state *launchState) createUpstashRedis(ctx context.Context) error {
 	return redis.AttachDatabase(ctx, db, state.Plan.AppName)
 }
 
+func (state *launchState) attachUpstashRedis(ctx context.Context, name string) error {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	resp, err := gql.GetAddOn(ctx, client, name)
+	if err != nil {
+		return fmt.Errorf("Redis database %s to attach was not found: %w", name, err)
+	}
+	return redis.AttachDatabase(ctx, &gql.AddOn{Name: resp.AddOn.Name, PublicUrl: resp.AddOn.PublicUrl}, state.Plan.AppName)
+}
+
+// attachExtension sets the secrets of an existing extension on the app.
+func (state *launchState) attachExtension(ctx context.Context, name string) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+	)
+
+	resp, err := gql.GetAddOn(ctx, client.GenqClient, name)
+	if err != nil {
+		return fmt.Errorf("%s to attach was not found: %w", name, err)
+	}
+
+	environment, _ := resp.AddOn.Environment.(map[string]interface{})
+	secrets := make(map[string]string, len(environment))
+	for key, value := range environment {
+		if s, ok := value.(string); ok {
+			secrets[key] = s
+		}
+	}
+	if len(secrets) == 0 {
+		return fmt.Errorf("%s has no secrets to attach", name)
+	}
+
+	if _, err := client.SetSecrets(ctx, state.Plan.AppName, secrets); err != nil {
+		return err
+	}
+
+	keys := lo.Keys(secrets)
+	slices.Sort(keys)
+	fmt.Fprintf(io.Out, "%s %s is attached to %s with the secrets %s\n", resp.AddOn.AddOnProvider.DisplayName, name, state.Plan.AppName, strings.Join(keys, ", "))
+	return nil
+}
+
 func (state *launchState) createTigrisObjectStorage(ctx context.Context) error {
 
 	tigrisPlan := state.Plan.ObjectStorage.TigrisObjectStorage
+	if tigrisPlan.Attach {
+		return state.attachExtension(ctx, tigrisPlan.Name)
+	}
 
 	org, err := state.Org(ctx)
 	if err != nil {
@@ -0,0 +1,77 @@
+package launch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/command/launch/plan"
+)
+
+func TestPlanDatabase(t *testing.T) {
+	scanned := func() (*plan.LaunchPlan, *launchPlanSource) {
+		lp := &plan.LaunchPlan{
+			AppName:  "my-app",
+			Postgres: plan.PostgresPlan{SupabasePostgres: &plan.SupabasePostgresPlan{}},
+			MySQL:    plan.MySQLPlan{PlanetScale: &plan.PlanetScaleMySQLPlan{}},
+		}
+		return lp, &launchPlanSource{postgresSource: "scanned", mysqlSource: "scanned"}
+	}
+
+	t.Run("fly-postgres", func(t *testing.T) {
+		lp, source := scanned()
+		require.NoError(t, planDatabase(lp, source, "fly-postgres"))
+		assert.Equal(t, plan.DefaultPostgres(lp), lp.Postgres)
+		assert.Equal(t, plan.MySQLPlan{}, lp.MySQL)
+		assert.Equal(t, "specified on the command line", source.postgresSource)
+		assert.Equal(t, "specified on the command line", source.mysqlSource)
+	})
+
+	t.Run("replaces the scanned database", func(t *testing.T) {
+		lp, source := scanned()
+		require.NoError(t, planDatabase(lp, source, "supabase"))
+		assert.NotNil(t, lp.Postgres.SupabasePostgres)
+		assert.Nil(t, lp.Postgres.FlyPostgres)
+		assert.Equal(t, plan.MySQLPlan{}, lp.MySQL)
+
+		lp, source = scanned()
+		require.NoError(t, planDatabase(lp, source, "planetscale"))
+		assert.Equal(t, plan.PostgresPlan{}, lp.Postgres)
+		assert.NotNil(t, lp.MySQL.PlanetScale)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		lp, source := scanned()
+		require.NoError(t, planDatabase(lp, source, "none"))
+		assert.Equal(t, plan.PostgresPlan{}, lp.Postgres)
+		assert.Equal(t, plan.MySQLPlan{}, lp.MySQL)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		lp, source := scanned()
+		assert.ErrorContains(t, planDatabase(lp, source, "mongodb"), `invalid --db "mongodb"`)
+		assert.ErrorContains(t, planDatabase(lp, source, "neon"), `invalid --db "neon"`)
+	})
+}
+
+func TestPlanAttachments(t *testing.T) {
+	lp := &plan.LaunchPlan{
+		AppName:  "my-app",
+		Postgres: plan.DefaultPostgres(&plan.LaunchPlan{AppName: "my-app"}),
+		Redis:    plan.RedisPlan{UpstashRedis: &plan.UpstashRedisPlan{}},
+	}
+	source := &launchPlanSource{}
+
+	err := planAttachments(lp, source, []string{"postgres=shared-db", "redis=cache", "tigris=assets"})
+	require.NoError(t, err)
+	assert.Equal(t, plan.PostgresPlan{FlyPostgres: &plan.FlyPostgresPlan{AppName: "shared-db", Attach: true}}, lp.Postgres)
+	assert.Equal(t, plan.RedisPlan{UpstashRedis: &plan.UpstashRedisPlan{AttachTo: "cache"}}, lp.Redis)
+	assert.Equal(t, plan.ObjectStoragePlan{TigrisObjectStorage: &plan.TigrisObjectStoragePlan{Name: "assets", Attach: true}}, lp.ObjectStorage)
+	assert.Equal(t, "attached from the command line", source.postgresSource)
+	assert.Equal(t, "attached from the command line", source.redisSource)
+
+	for _, a := range []string{"postgres", "postgres=", "=db"} {
+		assert.ErrorContains(t, planAttachments(lp, source, []string{a}), "expected kind=name", a)
+	}
+	assert.ErrorContains(t, planAttachments(lp, source, []string{"mysql=db"}), `invalid --attach kind "mysql"`)
+}
@@ -0,0 +1,34 @@
+package plan
+
+type MySQLPlan struct {
+	PlanetScale *PlanetScaleMySQLPlan `json:"planetscale"`
+}
+
+func (p *MySQLPlan) Provider() any {
+	if p == nil {
+		return nil
+	}
+	if p.PlanetScale != nil {
+		return p.PlanetScale
+	}
+	return nil
+}
+
+type PlanetScaleMySQLPlan struct {
+	DbName string `json:"db_name"`
+	Region string `json:"region"`
+}
+
+func (p *PlanetScaleMySQLPlan) GetDbName(plan *LaunchPlan) string {
+	if p.DbName == "" {
+		return plan.AppName + "-db"
+	}
+	return p.DbName
+}
+
+func (p *PlanetScaleMySQLPlan) GetRegion(plan *LaunchPlan) string {
+	if p.Region == "" {
+		return plan.RegionCode
+	}
+	return p.Region
+}
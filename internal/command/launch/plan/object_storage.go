@@ -25,4 +25,6 @@ type TigrisObjectStoragePlan struct {
 	Public            bool   `json:"public"`
 	Accelerate        bool   `json:"accelerate"`
 	WebsiteDomainName string `json:"website_domain_name"`
+	// Attach to the existing bucket Name instead of creating one
+	Attach bool `json:"attach,omitempty"`
 }
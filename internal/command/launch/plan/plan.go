@@ -30,6 +30,7 @@ type LaunchPlan struct {
 	HttpServicePortSetByScanner bool `json:"http_service_port_set_by_scanner,omitempty"`
 
 	Postgres      PostgresPlan      `json:"postgres"`
+	MySQL         MySQLPlan         `json:"mysql"`
 	Redis         RedisPlan         `json:"redis"`
 	Sentry        bool              `json:"sentry"`
 	ObjectStorage ObjectStoragePlan `json:"object_storage"`
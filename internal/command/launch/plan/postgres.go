@@ -46,6 +46,8 @@ type FlyPostgresPlan struct {
 	Nodes      int    `json:"nodes"`
 	DiskSizeGB int    `json:"disk_size_gb"`
 	AutoStop   bool   `json:"auto_stop"`
+	// Attach to the existing cluster AppName instead of creating one
+	Attach bool `json:"attach,omitempty"`
 }
 
 func (p *FlyPostgresPlan) Guest() *fly.MachineGuest {
@@ -25,4 +25,6 @@ func DefaultRedis(plan *LaunchPlan) RedisPlan {
 type UpstashRedisPlan struct {
 	Eviction     bool     `json:"eviction"`
 	ReadReplicas []string `json:"read_replicas"`
+	// Name of the existing database to attach instead of creating one
+	AttachTo string `json:"attach_to,omitempty"`
 }
@@ -186,6 +186,7 @@ func buildManifest(ctx context.Context, canEnterUi bool) (*LaunchManifest, *plan
 		orgSource:      orgExplanation,
 		computeSource:  computeExplanation,
 		postgresSource: "not requested",
+		mysqlSource:    "not requested",
 		redisSource:    "not requested",
 		sentrySource:   "not requested",
 	}
@@ -222,6 +223,15 @@ func buildManifest(ctx context.Context, canEnterUi bool) (*LaunchManifest, *plan
 		}
 	}
 
+	if db := flag.GetString(ctx, "db"); db != "" {
+		if err := planDatabase(lp, planSource, db); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := planAttachments(lp, planSource, flag.GetStringSlice(ctx, "attach")); err != nil {
+		return nil, nil, err
+	}
+
 	if len(recoverableInUiErrors) != 0 {
 
 		var allErrors string
@@ -0,0 +1,315 @@
+package launch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/scanner"
+)
+
+func newRegenerate() *cobra.Command {
+	const (
+		short = "Re-run the launch scanner on an existing app"
+		long  = `Scan the source code of an existing app again and show how the files and
+fly.toml launch would generate today differ from the ones in place, for
+instance after a framework upgrade. Each change is applied only once confirmed,
+or all of them with --yes.
+
+Generator commands run by some scanners, like dockerfile-rails, are not run;
+run them directly to regenerate the files they manage. fly.toml is compared
+as it is on disk against the format launch writes, so when its settings change
+the diff also offers to drop comments and reformat the file; each of those
+hunks can be declined like any other.`
+	)
+	cmd := command.New("regenerate", short, long, runRegenerate,
+		command.LoadAppConfigIfPresent,
+	)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.AppConfig(),
+		flag.Yes(),
+		flag.Bool{
+			Name:        "dry-run",
+			Description: "Only show the changes, without applying them",
+		},
+	)
+	return cmd
+}
+
+// regeneratedFile is a file as it exists and as launch would generate it now.
+type regeneratedFile struct {
+	Path    string
+	Current []string
+	Next    []string
+}
+
+func runRegenerate(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	workingDir := state.WorkingDirectory(ctx)
+
+	appConfig := appconfig.ConfigFromContext(ctx)
+	if appConfig == nil {
+		return errors.New("no fly.toml found, use 'fly launch' to launch a new app")
+	}
+
+	fmt.Fprintln(io.Out, "Scanning source code")
+	srcInfo, err := scanner.Scan(workingDir, &scanner.ScannerConfig{
+		ExistingPort: appConfig.InternalPort(),
+		Mode:         "launch",
+		Colorize:     io.ColorScheme(),
+	})
+	if err != nil {
+		return err
+	}
+	if srcInfo == nil {
+		return errors.New("could not detect the framework of the app, nothing to regenerate")
+	}
+	fmt.Fprintf(io.Out, "Detected %s\n", srcInfo.Family)
+
+	files, err := regenerateFiles(ctx, workingDir, appConfig, srcInfo)
+	if err != nil {
+		return err
+	}
+	files = lo.Filter(files, func(f *regeneratedFile, _ int) bool {
+		return strings.Join(f.Current, "") != strings.Join(f.Next, "")
+	})
+	if len(files) == 0 {
+		fmt.Fprintln(io.Out, "Everything is up to date")
+		return nil
+	}
+
+	dryRun := flag.GetBool(ctx, "dry-run")
+	for _, f := range files {
+		if err := applyRegeneratedFile(ctx, workingDir, f, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// regenerateFiles collects the scanner's files, with any Dockerfile appendix,
+// and the fly.toml launch would now generate from appConfig.
+func regenerateFiles(ctx context.Context, workingDir string, appConfig *appconfig.Config, srcInfo *scanner.SourceInfo) ([]*regeneratedFile, error) {
+	var files []*regeneratedFile
+	for _, f := range srcInfo.Files {
+		current, err := os.ReadFile(filepath.Join(workingDir, f.Path))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		files = append(files, &regeneratedFile{
+			Path:    f.Path,
+			Current: splitLines(string(current)),
+			Next:    splitLines(string(f.Contents)),
+		})
+	}
+
+	if len(srcInfo.DockerfileAppendix) > 0 {
+		dockerfile, found := lo.Find(files, func(f *regeneratedFile) bool { return f.Path == "Dockerfile" })
+		if !found {
+			current, err := os.ReadFile(filepath.Join(workingDir, "Dockerfile"))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			lines := splitLines(string(current))
+			dockerfile = &regeneratedFile{Path: "Dockerfile", Current: lines, Next: lines}
+			files = append(files, dockerfile)
+		}
+		if !strings.Contains(strings.Join(dockerfile.Current, ""), "# Appended by flyctl") {
+			appendix := "\n# Appended by flyctl\n" + strings.Join(srcInfo.DockerfileAppendix, "\n") + "\n"
+			dockerfile.Next = append(dockerfile.Next, splitLines(appendix)...)
+		}
+	}
+
+	// Compare against the file as written so the diff shows what would
+	// actually change, comments and formatting included
+	currentRaw, err := os.ReadFile(appConfig.ConfigFilePath())
+	if err != nil {
+		return nil, err
+	}
+	current := splitLines(string(currentRaw))
+	reserialized, err := marshalAppConfig(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the scanner results to a copy of the config like launch does,
+	// leaving the Dockerfile appendix to the Dockerfile above
+	nextConfig, err := appconfig.LoadConfig(appConfig.ConfigFilePath())
+	if err != nil {
+		return nil, err
+	}
+	if srcInfo.Port > 0 && nextConfig.HTTPService != nil {
+		nextConfig.HTTPService.InternalPort = srcInfo.Port
+	}
+	scanned := *srcInfo
+	scanned.DockerfileAppendix = nil
+	regenerated := &launchState{workingDir: workingDir}
+	regenerated.appConfig = nextConfig
+	regenerated.sourceInfo = &scanned
+	if err := regenerated.scannerSetAppconfig(ctx); err != nil {
+		return nil, err
+	}
+	next, err := marshalAppConfig(nextConfig)
+	if err != nil {
+		return nil, err
+	}
+	// Rewriting fly.toml only to reformat it isn't a change worth offering
+	if strings.Join(reserialized, "") == strings.Join(next, "") {
+		next = current
+	}
+
+	rel, err := filepath.Rel(workingDir, appConfig.ConfigFilePath())
+	if err != nil {
+		rel = appConfig.ConfigFilePath()
+	}
+	files = append(files, &regeneratedFile{Path: rel, Current: current, Next: next})
+
+	return files, nil
+}
+
+func marshalAppConfig(cfg *appconfig.Config) ([]string, error) {
+	var b bytes.Buffer
+	if _, err := cfg.WriteTo(&b); err != nil {
+		return nil, err
+	}
+	return splitLines(b.String()), nil
+}
+
+// splitLines splits s into lines, keeping their line endings.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// applyRegeneratedFile shows each change to f and writes the ones confirmed.
+func applyRegeneratedFile(ctx context.Context, workingDir string, f *regeneratedFile, dryRun bool) error {
+	io := iostreams.FromContext(ctx)
+	colorize := io.ColorScheme()
+
+	ops, hunks := splitHunks(f)
+
+	fmt.Fprintf(io.Out, "\n%s\n", colorize.Bold(f.Path))
+	selected := make(map[[2]int]bool)
+	for _, hunk := range hunks {
+		fmt.Fprint(io.Out, formatHunk(f, hunk, colorize))
+		if dryRun {
+			continue
+		}
+
+		apply := flag.GetYes(ctx)
+		if !apply {
+			var err error
+			switch apply, err = prompt.Confirm(ctx, "Apply this change?"); {
+			case prompt.IsNonInteractive(err):
+				return prompt.NonInteractiveError("--yes or --dry-run must be specified when not running interactively")
+			case err != nil:
+				return err
+			}
+		}
+		if apply {
+			selectHunk(selected, hunk)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+
+	content := applyHunks(f, ops, selected)
+	path := filepath.Join(workingDir, f.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	perms := fs.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perms = info.Mode().Perm()
+	} else if strings.HasPrefix(content, "#!") {
+		perms = 0o755
+	}
+	if err := os.WriteFile(path, []byte(content), perms); err != nil {
+		return err
+	}
+	fmt.Fprintf(io.Out, "Updated %s\n", f.Path)
+	return nil
+}
+
+// splitHunks returns the changes between the current and the next version of
+// f, and the same changes grouped into hunks with three lines of context.
+func splitHunks(f *regeneratedFile) ([]difflib.OpCode, [][]difflib.OpCode) {
+	matcher := difflib.NewMatcher(f.Current, f.Next)
+	// GetGroupedOpCodes trims the context of the cached opcodes in place
+	ops := slices.Clone(matcher.GetOpCodes())
+	return ops, matcher.GetGroupedOpCodes(3)
+}
+
+// selectHunk marks the changes of hunk for applyHunks.
+func selectHunk(selected map[[2]int]bool, hunk []difflib.OpCode) {
+	for _, op := range hunk {
+		if op.Tag != 'e' {
+			selected[[2]int{op.I1, op.J1}] = true
+		}
+	}
+}
+
+// applyHunks returns the current file with the selected changes, keyed by
+// their start in both versions, taken from the next one.
+func applyHunks(f *regeneratedFile, ops []difflib.OpCode, selected map[[2]int]bool) string {
+	var b strings.Builder
+	for _, op := range ops {
+		if op.Tag != 'e' && selected[[2]int{op.I1, op.J1}] {
+			b.WriteString(strings.Join(f.Next[op.J1:op.J2], ""))
+		} else {
+			b.WriteString(strings.Join(f.Current[op.I1:op.I2], ""))
+		}
+	}
+	return b.String()
+}
+
+func formatHunk(f *regeneratedFile, hunk []difflib.OpCode, colorize *iostreams.ColorScheme) string {
+	var b strings.Builder
+	first, last := hunk[0], hunk[len(hunk)-1]
+	fmt.Fprintf(&b, "%s\n", colorize.Cyan(fmt.Sprintf("@@ -%d,%d +%d,%d @@", first.I1+1, last.I2-first.I1, first.J1+1, last.J2-first.J1)))
+
+	line := func(prefix, s string, color func(string) string) {
+		b.WriteString(color(prefix + strings.TrimSuffix(s, "\n")))
+		b.WriteByte('\n')
+	}
+	for _, op := range hunk {
+		if op.Tag == 'e' {
+			for _, s := range f.Current[op.I1:op.I2] {
+				line(" ", s, func(s string) string { return s })
+			}
+			continue
+		}
+		if op.Tag == 'r' || op.Tag == 'd' {
+			for _, s := range f.Current[op.I1:op.I2] {
+				line("-", s, colorize.Red)
+			}
+		}
+		if op.Tag == 'r' || op.Tag == 'i' {
+			for _, s := range f.Next[op.J1:op.J2] {
+				line("+", s, colorize.Green)
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package launch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitLines(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"empty", "", []string{}},
+		{"no trailing newline", "a\nb", []string{"a\n", "b"}},
+		{"trailing newline", "a\nb\n", []string{"a\n", "b\n"}},
+		{"blank lines", "a\n\nb\n", []string{"a\n", "\n", "b\n"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, splitLines(tc.in))
+		})
+	}
+}
+
+func TestSplitAndApplyHunks(t *testing.T) {
+	// Two changes far enough apart to land in separate hunks
+	current := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	next := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+
+	cases := []struct {
+		name     string
+		current  string
+		next     string
+		hunks    int
+		selected []int
+		want     string
+	}{
+		{"unchanged", current, current, 0, nil, current},
+		{"nothing selected", current, next, 2, nil, current},
+		{"first hunk", current, next, 2, []int{0}, "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"},
+		{"second hunk", current, next, 2, []int{1}, "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"},
+		{"both hunks", current, next, 2, []int{0, 1}, next},
+		{"close changes share a hunk", "a\nb\nc\n", "A\nb\nC\n", 1, []int{0}, "A\nb\nC\n"},
+		{"new file", "", "a\nb\n", 1, []int{0}, "a\nb\n"},
+		{"unchanged lines around a change", "1\n2\n3\n4\n5\n6\n7\n8\n9\n", "1\n2\n3\n4\nfive\n6\n7\n8\n9\n", 1, []int{0}, "1\n2\n3\n4\nfive\n6\n7\n8\n9\n"},
+		{"deleted lines", "a\nb\nc\n", "a\n", 1, []int{0}, "a\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &regeneratedFile{Path: "file", Current: splitLines(tc.current), Next: splitLines(tc.next)}
+
+			ops, hunks := splitHunks(f)
+			require.Len(t, hunks, tc.hunks)
+
+			selected := make(map[[2]int]bool)
+			for _, i := range tc.selected {
+				selectHunk(selected, hunks[i])
+			}
+			got := applyHunks(f, ops, selected)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, strings.Join(f.Current, "") == got, len(selected) == 0)
+		})
+	}
+}
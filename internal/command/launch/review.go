@@ -0,0 +1,257 @@
+package launch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/command/launch/plan"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/pricing"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// machineCount returns the number of machines launch creates for a process
+// group: a spare one for high availability, except for groups with volumes.
+func (state *launchState) machineCount(mConfig *fly.MachineConfig) int {
+	if state.Plan.HighAvailability && len(mConfig.Mounts) == 0 {
+		return 2
+	}
+	return 1
+}
+
+// plannedGuests returns the guest of every machine launching the plan creates.
+// Plans without compute sections size every machine with their guest fields.
+func (state *launchState) plannedGuests() ([]*fly.MachineGuest, error) {
+	var guests []*fly.MachineGuest
+	for _, group := range state.appConfig.ProcessNames() {
+		mConfig, err := state.appConfig.ToMachineConfig(group, nil)
+		if err != nil {
+			return nil, err
+		}
+		guest := mConfig.Guest
+		switch {
+		case len(state.Plan.Compute) == 0:
+			guest = state.Plan.Guest()
+		case guest == nil:
+			guest = fly.MachinePresets[fly.DefaultVMSize]
+		}
+		guests = append(guests, lo.Times(state.machineCount(mConfig), func(int) *fly.MachineGuest { return guest })...)
+	}
+	return guests, nil
+}
+
+// planFiles lists the files launch writes, relative to the working directory.
+func (state *launchState) planFiles() []string {
+	var files []string
+	describe := func(path, action string) {
+		if helpers.FileExists(filepath.Join(state.workingDir, path)) {
+			action = lo.Ternary(action == "", "overwritten", action)
+		} else {
+			action = "created"
+		}
+		files = append(files, fmt.Sprintf("%s (%s)", path, action))
+	}
+
+	if state.sourceInfo != nil {
+		for _, f := range state.sourceInfo.Files {
+			describe(f.Path, "")
+		}
+		if len(state.sourceInfo.DockerfileAppendix) > 0 {
+			describe("Dockerfile", "appended to")
+		}
+	}
+
+	configPath := state.configPath
+	if rel, err := filepath.Rel(state.workingDir, configPath); err == nil {
+		configPath = rel
+	}
+	describe(configPath, "")
+	return files
+}
+
+// planDetails describes the files launch writes and, with --with-cost, the
+// estimated monthly cost of the app's machines.
+func (state *launchState) planDetails(ctx context.Context) (string, error) {
+	io := iostreams.FromContext(ctx)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, "Files:")
+	for _, f := range state.planFiles() {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+
+	if !flag.GetBool(ctx, "with-cost") {
+		return b.String(), nil
+	}
+
+	guests, err := state.plannedGuests()
+	if err != nil {
+		return "", err
+	}
+	prices, err := pricing.Fetch(ctx)
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "Warning: %v\n", err)
+	} else if monthly, ok := prices.MachinesMonthly(guests); ok {
+		fmt.Fprintf(&b, "\nEstimated monthly cost: %s for %d machines running full time, databases and extensions are billed separately\n",
+			pricing.Format(monthly), len(guests))
+	}
+
+	return b.String(), nil
+}
+
+// printPlan shows the plan along with the files it generates and its cost.
+func (state *launchState) printPlan(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	summary, err := state.PlanSummary(ctx)
+	if err != nil {
+		return err
+	}
+	details, err := state.planDetails(ctx)
+	if err != nil {
+		return err
+	}
+
+	family := ""
+	if state.sourceInfo != nil {
+		family = state.sourceInfo.Family
+	}
+
+	fmt.Fprintf(
+		io.Out,
+		"We're about to launch your %s on Fly.io. Here's what you're getting:\n\n%s\n%s\n",
+		familyToAppType(family),
+		summary,
+		details,
+	)
+	return nil
+}
+
+const (
+	reviewWebUI = iota
+	reviewJSON
+	reviewCancel
+)
+
+// reviewPlan asks whether to tweak the plan before launching, as launch always
+// has, and then whether to do so in the web UI or by editing the plan as JSON.
+// Nothing is provisioned unless it returns true. Launching proceeds when not
+// running interactively.
+func (state *launchState) reviewPlan(ctx context.Context) (bool, error) {
+	for {
+		tweak, err := prompt.Confirm(ctx, "Do you want to tweak these settings before proceeding?")
+		switch {
+		case prompt.IsNonInteractive(err):
+			return true, nil
+		case err != nil:
+			return false, err
+		case !tweak:
+			return true, nil
+		}
+
+		choice := reviewWebUI
+		if err := prompt.Select(ctx, &choice, "How do you want to tweak them?", "",
+			"In the web UI",
+			"By editing the plan as JSON",
+			"Cancel the launch",
+		); err != nil {
+			return false, err
+		}
+
+		switch choice {
+		case reviewWebUI:
+			return true, state.EditInWebUi(ctx)
+		case reviewCancel:
+			return false, nil
+		}
+
+		if err := state.editPlanJSON(ctx); err != nil {
+			fmt.Fprintf(iostreams.FromContext(ctx).ErrOut, "The plan was not changed: %v\n", err)
+			continue
+		}
+		if err := state.printPlan(ctx); err != nil {
+			return false, err
+		}
+	}
+}
+
+// editPlanJSON opens the plan in the user's editor and replaces it with the
+// edited version.
+func (state *launchState) editPlanJSON(ctx context.Context) error {
+	data, err := json.MarshalIndent(state.Plan, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	var edited string
+	if err := prompt.Editor(ctx, &edited, "Edit the launch plan", string(data), ".json"); err != nil {
+		return err
+	}
+	return state.applyPlanJSON([]byte(edited))
+}
+
+// applyPlanJSON replaces the plan with the one in data, recording which of
+// its app name, org and region changed.
+func (state *launchState) applyPlanJSON(data []byte) error {
+	p := &plan.LaunchPlan{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return fmt.Errorf("invalid launch plan: %w", err)
+	}
+	if p.AppName == "" || p.OrgSlug == "" || p.RegionCode == "" {
+		return errors.New("invalid launch plan: name, org and region are required")
+	}
+
+	const editedSource = "edited as JSON"
+	if p.AppName != state.Plan.AppName {
+		state.PlanSource.appNameSource = editedSource
+	}
+	if p.OrgSlug != state.Plan.OrgSlug {
+		state.PlanSource.orgSource = editedSource
+	}
+	if p.RegionCode != state.Plan.RegionCode {
+		state.PlanSource.regionSource = editedSource
+	}
+	state.Plan = p
+	return nil
+}
+
+// DryRun writes the files launching the plan generates, without provisioning
+// anything. Generator commands of the scanner aren't run.
+func (state *launchState) DryRun(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	if err := state.updateComputeFromDeprecatedGuestFields(ctx); err != nil {
+		return err
+	}
+	state.updateConfig(ctx)
+
+	if err := state.scannerCreateFiles(ctx); err != nil {
+		return err
+	}
+	if err := state.scannerSetAppconfig(ctx); err != nil {
+		return err
+	}
+	if err := state.createDockerIgnore(ctx); err != nil {
+		return err
+	}
+	if n := flag.GetInt(ctx, "internal-port"); n > 0 {
+		state.appConfig.SetInternalPort(n)
+	}
+
+	state.appConfig.SetConfigFilePath(state.configPath)
+	if err := state.appConfig.WriteToDisk(ctx, state.configPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(io.Out, "Dry run: the files above were written, no app or database was created.")
+	fmt.Fprintln(io.Out, "Run 'fly launch --copy-config' to launch the app with them.")
+	return nil
+}
@@ -0,0 +1,122 @@
+package launch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command/launch/plan"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/scanner"
+)
+
+func reviewTestState(t *testing.T) *launchState {
+	dir := t.TempDir()
+	cfg := appconfig.NewConfig()
+	cfg.Processes = map[string]string{"web": "bin/web", "worker": "bin/worker"}
+	cfg.Mounts = []appconfig.Mount{{Source: "data", Destination: "/data", Processes: []string{"worker"}}}
+
+	return &launchState{
+		workingDir: dir,
+		configPath: filepath.Join(dir, appconfig.DefaultConfigFileName),
+		LaunchManifest: LaunchManifest{
+			Plan: &plan.LaunchPlan{
+				AppName:          "my-app",
+				OrgSlug:          "personal",
+				RegionCode:       "ams",
+				HighAvailability: true,
+				CPUKind:          "shared",
+				CPUs:             1,
+				MemoryMB:         512,
+			},
+			PlanSource: &launchPlanSource{},
+		},
+		planBuildCache: planBuildCache{
+			appConfig: cfg,
+			sourceInfo: &scanner.SourceInfo{
+				Files: []scanner.SourceFile{{Path: "Dockerfile", Contents: []byte("FROM scratch\n")}},
+			},
+		},
+	}
+}
+
+func TestPlannedGuests(t *testing.T) {
+	state := reviewTestState(t)
+
+	// Without compute sections every machine uses the plan's guest; groups
+	// with volumes get no spare machine
+	guests, err := state.plannedGuests()
+	require.NoError(t, err)
+	want := &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 512}
+	assert.Equal(t, []*fly.MachineGuest{want, want, want}, guests)
+
+	state.Plan.HighAvailability = false
+	state.Plan.Compute = []*appconfig.Compute{{MachineGuest: &fly.MachineGuest{CPUKind: "performance", CPUs: 2, MemoryMB: 4096}, Processes: []string{"web"}}}
+	state.appConfig.Compute = state.Plan.Compute
+	guests, err = state.plannedGuests()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*fly.MachineGuest{
+		{CPUKind: "performance", CPUs: 2, MemoryMB: 4096},
+		fly.MachinePresets[fly.DefaultVMSize],
+	}, guests)
+}
+
+func TestPlanFiles(t *testing.T) {
+	state := reviewTestState(t)
+	assert.Equal(t, []string{"Dockerfile (created)", "fly.toml (created)"}, state.planFiles())
+
+	require.NoError(t, os.WriteFile(filepath.Join(state.workingDir, "Dockerfile"), nil, 0o644))
+	state.sourceInfo.DockerfileAppendix = []string{"RUN true"}
+	assert.Equal(t, []string{"Dockerfile (overwritten)", "Dockerfile (appended to)", "fly.toml (created)"}, state.planFiles())
+}
+
+func TestApplyPlanJSON(t *testing.T) {
+	state := reviewTestState(t)
+
+	err := state.applyPlanJSON([]byte(`{"name": "renamed", "org": "personal", "region": "ams"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", state.Plan.AppName)
+	assert.Equal(t, "edited as JSON", state.PlanSource.appNameSource)
+	assert.Empty(t, state.PlanSource.orgSource)
+	assert.Empty(t, state.PlanSource.regionSource)
+
+	assert.ErrorContains(t, state.applyPlanJSON([]byte(`{"name": "renamed"}`)), "name, org and region are required")
+	assert.ErrorContains(t, state.applyPlanJSON([]byte(`{`)), "invalid launch plan")
+	assert.Equal(t, "renamed", state.Plan.AppName)
+}
+
+func TestDryRun(t *testing.T) {
+	state := reviewTestState(t)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(state.workingDir))
+	defer os.Chdir(wd)
+
+	fs := pflag.NewFlagSet("launch", pflag.ContinueOnError)
+	fs.Int("internal-port", 0, "")
+	ios, _, out, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = logger.NewContext(ctx, logger.New(os.Stderr, logger.Info, false))
+	ctx = flag.NewContext(ctx, fs)
+
+	require.NoError(t, state.DryRun(ctx))
+	assert.Contains(t, out.String(), "no app or database was created")
+
+	data, err := os.ReadFile(filepath.Join(state.workingDir, "Dockerfile"))
+	require.NoError(t, err)
+	assert.Equal(t, "FROM scratch\n", string(data))
+
+	cfg, err := appconfig.LoadConfig(state.configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", cfg.AppName)
+	assert.Equal(t, "ams", cfg.PrimaryRegion)
+}
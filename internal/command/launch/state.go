@@ -24,6 +24,7 @@ type launchPlanSource struct {
 	orgSource      string
 	computeSource  string
 	postgresSource string
+	mysqlSource    string
 	redisSource    string
 	sentrySource   string
 }
@@ -131,6 +132,11 @@ func (state *launchState) PlanSummary(ctx context.Context) (string, error) {
 		{"Sentry", strconv.FormatBool(state.Plan.Sentry), state.PlanSource.sentrySource},
 	}
 
+	// Only mention the less common databases when requested
+	if state.Plan.MySQL.Provider() != nil {
+		rows = append(rows, []string{"MySQL", describeMySQLPlan(state.Plan), state.PlanSource.mysqlSource})
+	}
+
 	for _, row := range rows {
 		// TODO: This is a hack. It'd be nice to not require a special sentinel value for the description,
 		//       but it works OK for now. I'd special-case on value=="" instead, but that isn't *necessarily*
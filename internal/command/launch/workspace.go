@@ -0,0 +1,227 @@
+package launch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// WorkspaceManifestFileName is written to the root of a workspace launched
+// with --workspace.
+const WorkspaceManifestFileName = "fly.workspace.toml"
+
+// WorkspaceManifest lists the apps of a workspace, in launch order, so they
+// can be deployed together.
+type WorkspaceManifest struct {
+	Org           string         `toml:"org"`
+	PrimaryRegion string         `toml:"primary_region"`
+	Apps          []WorkspaceApp `toml:"apps"`
+}
+
+type WorkspaceApp struct {
+	Name string `toml:"name"`
+	// Path is relative to the workspace root
+	Path string `toml:"path"`
+}
+
+// workspaceMarkers are files that make a directory a launchable project.
+var workspaceMarkers = []string{
+	"Dockerfile", appconfig.DefaultConfigFileName,
+	"package.json", "go.mod", "Gemfile", "mix.exs", "requirements.txt", "pyproject.toml",
+	"Cargo.toml", "pom.xml", "build.gradle", "build.gradle.kts", "composer.json", "deno.json",
+}
+
+// workspaceContainers are directories monorepos commonly keep projects in.
+var workspaceContainers = []string{"apps", "services", "packages"}
+
+func isWorkspaceProject(dir string) bool {
+	for _, marker := range workspaceMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	return len(matches) > 0
+}
+
+// detectWorkspaceProjects returns the directories below root that look like
+// launchable projects, relative to root. Only direct subdirectories and those
+// of apps/, services/ and packages/ are considered.
+func detectWorkspaceProjects(root string) ([]string, error) {
+	var projects []string
+
+	var scan func(rel string) error
+	scan = func(rel string) error {
+		entries, err := os.ReadDir(filepath.Join(root, rel))
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if !e.IsDir() || strings.HasPrefix(name, ".") || name == "node_modules" || name == "vendor" {
+				continue
+			}
+			path := filepath.Join(rel, name)
+			switch {
+			case isWorkspaceProject(filepath.Join(root, path)):
+				projects = append(projects, path)
+			case rel == "." && slices.Contains(workspaceContainers, name):
+				if err := scan(path); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := scan("."); err != nil {
+		return nil, err
+	}
+	slices.Sort(projects)
+	return projects, nil
+}
+
+// workspaceAppName names the app of a project after the workspace and the
+// project directory, e.g. shop-api for apps/api in the shop repository.
+func workspaceAppName(root, project string) string {
+	return sanitizeAppName(filepath.Base(root) + "-" + filepath.Base(project))
+}
+
+// runWorkspace launches every project of the workspace at --path in turn,
+// with the same org and region, and writes the workspace manifest.
+func runWorkspace(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	for _, name := range []string{"name", "from", "template", "plan", "manifest", "export-plan", "output"} {
+		if flag.IsSpecified(ctx, name) {
+			return fmt.Errorf("--%s can't be used with --workspace", name)
+		}
+	}
+
+	root, err := filepath.Abs(flag.GetString(ctx, "path"))
+	if err != nil {
+		return err
+	}
+
+	projects, err := detectWorkspaceProjects(root)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no launchable projects found in %s", root)
+	}
+
+	fmt.Fprintf(io.Out, "Found %d projects in %s:\n", len(projects), root)
+	for _, p := range projects {
+		fmt.Fprintf(io.Out, "  %s\n", p)
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Launch an app for each of the %d projects?", len(projects)); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	// Settle the org and region once so every app shares them
+	org, _, err := determineOrg(ctx)
+	if err != nil {
+		return err
+	}
+	region, _, err := determineRegion(ctx, appconfig.NewConfig(), org.PaidPlan)
+	if err != nil {
+		return err
+	}
+	return launchWorkspace(ctx, root, projects, org.Slug, region.Code, launchApp)
+}
+
+// launchWorkspace launches each of projects below root with launch, in the
+// given org and region, and writes the workspace manifest listing the apps
+// launched, even when one of them fails.
+func launchWorkspace(ctx context.Context, root string, projects []string, org, region string, launch func(context.Context) error) error {
+	io := iostreams.FromContext(ctx)
+	fs := flag.FromContext(ctx)
+
+	// Every project is launched as a single app from its own directory
+	if err := errors.Join(fs.Set(flagnames.Org, org), fs.Set(flagnames.Region, region), fs.Set("path", "."), fs.Set("workspace", "false")); err != nil {
+		return err
+	}
+
+	manifest := &WorkspaceManifest{Org: org, PrimaryRegion: region}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+
+	for _, project := range projects {
+		dir := filepath.Join(root, project)
+		fmt.Fprintf(io.Out, "\nLaunching %s\n", project)
+
+		if err := os.Chdir(dir); err != nil {
+			return err
+		}
+		projectCtx := state.WithWorkingDirectory(appconfig.WithConfig(ctx, nil), dir)
+		if projectCtx, err = command.LoadAppConfigIfPresent(projectCtx); err != nil {
+			return err
+		}
+
+		name := ""
+		if cfg := appconfig.ConfigFromContext(projectCtx); cfg == nil || cfg.AppName == "" {
+			name = workspaceAppName(root, project)
+		}
+		if err := fs.Set("name", name); err != nil {
+			return err
+		}
+
+		err = launch(projectCtx)
+		if cfg, loadErr := appconfig.LoadConfig(filepath.Join(dir, appconfig.DefaultConfigFileName)); loadErr == nil && cfg.AppName != "" {
+			manifest.Apps = append(manifest.Apps, WorkspaceApp{Name: cfg.AppName, Path: project})
+		}
+		if err != nil {
+			if writeErr := writeWorkspaceManifest(ctx, root, manifest); writeErr != nil {
+				fmt.Fprintf(io.ErrOut, "Warning: %v\n", writeErr)
+			}
+			return fmt.Errorf("failed to launch %s: %w", project, err)
+		}
+	}
+
+	return writeWorkspaceManifest(ctx, root, manifest)
+}
+
+func writeWorkspaceManifest(ctx context.Context, root string, manifest *WorkspaceManifest) error {
+	if len(manifest.Apps) == 0 {
+		return nil
+	}
+
+	data, err := toml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, WorkspaceManifestFileName)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write workspace manifest: %w", err)
+	}
+
+	fmt.Fprintf(iostreams.FromContext(ctx).Out, "\nWrote %s listing %d apps\n", path, len(manifest.Apps))
+	return nil
+}
@@ -0,0 +1,109 @@
+package launch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func writeTestFiles(t *testing.T, root string, files ...string) {
+	for _, f := range files {
+		path := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+	}
+}
+
+func TestDetectWorkspaceProjects(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root,
+		"web/package.json",
+		"apps/api/go.mod",
+		"apps/billing/Billing.csproj",
+		"services/worker/Dockerfile",
+		"packages/ui/README.md",
+		"docs/index.md",
+		"node_modules/left-pad/package.json",
+		".github/Dockerfile",
+		"tools/nested/deeper/go.mod",
+	)
+
+	projects, err := detectWorkspaceProjects(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps/api", "apps/billing", "services/worker", "web"}, projects)
+}
+
+func TestWorkspaceAppName(t *testing.T) {
+	assert.Equal(t, "shop-api", workspaceAppName("/src/shop", "apps/api"))
+	assert.Equal(t, "my-shop-web-ui", workspaceAppName("/src/My_Shop", "web_ui"))
+}
+
+func TestLaunchWorkspace(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, "api/go.mod", "web/package.json")
+	// Projects with an app name in their config keep it
+	require.NoError(t, os.WriteFile(filepath.Join(root, "web", appconfig.DefaultConfigFileName), []byte("app = \"existing-web\"\n"), 0o644))
+
+	fs := pflag.NewFlagSet("launch", pflag.ContinueOnError)
+	fs.String("org", "", "")
+	fs.String("region", "", "")
+	fs.String("path", "", "")
+	fs.String("name", "", "")
+	fs.Bool("workspace", false, "")
+	require.NoError(t, fs.Set("workspace", "true"))
+
+	ios, _, _, _ := iostreams.Test()
+	ctx := iostreams.NewContext(context.Background(), ios)
+	ctx = logger.NewContext(ctx, logger.New(os.Stderr, logger.Info, false))
+	ctx = flag.NewContext(ctx, fs)
+
+	var launched []string
+	launch := func(ctx context.Context) error {
+		// Each project is launched as a single app, not as a workspace again
+		assert.False(t, flag.GetBool(ctx, "workspace"))
+		assert.Equal(t, "personal", flag.GetOrg(ctx))
+		assert.Equal(t, "ams", flag.GetRegion(ctx))
+
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+		name := flag.GetString(ctx, "name")
+		launched = append(launched, filepath.Base(wd)+":"+name)
+		if name != "" {
+			return os.WriteFile(appconfig.DefaultConfigFileName, []byte("app = \""+name+"\"\n"), 0o644)
+		}
+		return nil
+	}
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	err = launchWorkspace(ctx, root, []string{"api", "web"}, "personal", "ams", launch)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"api:" + workspaceAppName(root, "api"), "web:"}, launched)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, cwd)
+
+	data, err := os.ReadFile(filepath.Join(root, WorkspaceManifestFileName))
+	require.NoError(t, err)
+	var manifest WorkspaceManifest
+	require.NoError(t, toml.Unmarshal(data, &manifest))
+	assert.Equal(t, WorkspaceManifest{
+		Org:           "personal",
+		PrimaryRegion: "ams",
+		Apps: []WorkspaceApp{
+			{Name: workspaceAppName(root, "api"), Path: "api"},
+			{Name: "existing-web", Path: "web"},
+		},
+	}, manifest)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/watch"
 	"github.com/superfly/flyctl/iostreams"
@@ -36,6 +37,7 @@ If the original Machine has a volume, then a new empty volume will be created an
 	)
 
 	cmd.Args = cobra.RangeArgs(0, 1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteMachines))
 
 	flag.Add(
 		cmd,
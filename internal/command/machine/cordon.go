@@ -8,6 +8,7 @@ import (
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -32,6 +33,7 @@ func newMachineCordon() *cobra.Command {
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 	return cmd
 }
 
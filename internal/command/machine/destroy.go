@@ -11,6 +11,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/iostreams"
@@ -50,6 +51,7 @@ This command requires a machine to be in a stopped state unless the force flag i
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 
 	return cmd
 }
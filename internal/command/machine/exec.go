@@ -10,6 +10,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -40,6 +41,7 @@ func newMachineExec() *cobra.Command {
 	)
 
 	cmd.Args = cobra.RangeArgs(1, 2)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteMachines))
 
 	return cmd
 }
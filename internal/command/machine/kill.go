@@ -8,6 +8,7 @@ import (
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/iostreams"
 )
 
@@ -25,6 +26,7 @@ func newKill() *cobra.Command {
 	)
 
 	cmd.Args = cobra.RangeArgs(0, 1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteMachines))
 
 	flag.Add(
 		cmd,
@@ -12,6 +12,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -50,6 +51,7 @@ func newLeaseView() *cobra.Command {
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 
 	flag.Add(
 		cmd,
@@ -75,6 +77,7 @@ func newLeaseClear() *cobra.Command {
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 
 	flag.Add(
 		cmd,
@@ -3,6 +3,8 @@ package machine
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
@@ -12,7 +14,9 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -20,7 +24,13 @@ import (
 func newList() *cobra.Command {
 	const (
 		short = "List Fly machines"
-		long  = short + "\n"
+		long  = short + `
+
+--status and --region are applied by the Machines API, so only matching
+machines are transferred, which speeds up listing apps with many machines.
+
+With the global --quiet flag, only the machine IDs are listed.
+`
 
 		usage = "list"
 	)
@@ -38,10 +48,14 @@ func newList() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
-		flag.Bool{
-			Name:        "quiet",
-			Shorthand:   "q",
-			Description: "Only list machine ids",
+		flag.StringSlice{
+			Name:        "status",
+			Description: "Only list machines in the given states, such as started or stopped. Can be specified multiple times",
+		},
+		flag.String{
+			Name:        flagnames.Region,
+			Shorthand:   "r",
+			Description: "Only list machines in the given region",
 		},
 	)
 
@@ -63,7 +77,15 @@ func runMachineList(ctx context.Context) (err error) {
 		return fmt.Errorf("list of machines could not be retrieved: %w", err)
 	}
 
-	machines, err := flapsClient.List(ctx, "")
+	query := listQuery(flag.GetStringSlice(ctx, "status"), flag.GetRegion(ctx))
+	key := "machine list:" + appName
+	if query != "" {
+		key += "?" + query
+	}
+
+	machines, err := readcache.Get(ctx, key, func(ctx context.Context) ([]*fly.Machine, error) {
+		return flapsClient.List(ctx, query)
+	})
 	if err != nil {
 		return fmt.Errorf("machines could not be retrieved")
 	}
@@ -149,3 +171,16 @@ func runMachineList(ctx context.Context) (err error) {
 	}
 	return nil
 }
+
+// listQuery returns the query string filtering machines by state and region
+// server side.
+func listQuery(states []string, region string) string {
+	q := url.Values{}
+	if len(states) > 0 {
+		q.Set("state", strings.Join(states, ","))
+	}
+	if region != "" {
+		q.Set("region", region)
+	}
+	return q.Encode()
+}
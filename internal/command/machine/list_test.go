@@ -0,0 +1,13 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListQuery(t *testing.T) {
+	assert.Equal(t, "", listQuery(nil, ""))
+	assert.Equal(t, "state=started", listQuery([]string{"started"}, ""))
+	assert.Equal(t, "region=ord&state=started%2Cstopped", listQuery([]string{"started", "stopped"}, "ord"))
+}
@@ -24,11 +24,6 @@ func newProxy() *cobra.Command {
 
 	flag.Add(cmd,
 		flag.Org(),
-		flag.Bool{
-			Name:        "quiet",
-			Shorthand:   "q",
-			Description: "Don't print progress indicators for WireGuard",
-		},
 	)
 
 	return cmd
@@ -10,6 +10,7 @@ import (
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	mach "github.com/superfly/flyctl/internal/machine"
 )
 
@@ -27,6 +28,7 @@ func newRestart() *cobra.Command {
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 
 	flag.Add(
 		cmd,
@@ -52,6 +54,11 @@ func newRestart() *cobra.Command {
 			Description: "Restarts app without waiting for health checks. ( Machines only )",
 			Default:     false,
 		},
+		flag.Int{
+			Name:        "max-unavailable",
+			Description: "Number of machines restarted at a time",
+			Default:     1,
+		},
 	)
 
 	return cmd
@@ -83,11 +90,9 @@ func runMachineRestart(ctx context.Context) error {
 		return err
 	}
 
-	// Restart each machine
-	for _, machine := range machines {
-		if err := mach.Restart(ctx, machine, input, machine.LeaseNonce); err != nil {
-			return fmt.Errorf("failed to restart machine %s: %w", machine.ID, err)
-		}
+	// Restart the machines, --max-unavailable at a time
+	if err := mach.RestartBatches(ctx, machines, input, flag.GetInt(ctx, "max-unavailable")); err != nil {
+		return fmt.Errorf("failed to restart machines: %w", err)
 	}
 
 	return nil
@@ -61,6 +61,7 @@ var sharedFlags = flag.Set{
 		Description: "Only perform builds locally using the local docker daemon",
 		Hidden:      true,
 	},
+	flag.DockerHost(),
 	flag.Bool{
 		Name:        "build-nixpacks",
 		Description: "Build your image with nixpacks",
@@ -69,26 +70,24 @@ var sharedFlags = flag.Set{
 		Name:        "dockerfile",
 		Description: "The path to a Dockerfile. Defaults to the Dockerfile in the working directory.",
 	},
-	flag.StringArray{
-		Name:        "build-arg",
-		Description: "Set of build time variables in the form of NAME=VALUE pairs. Can be specified multiple times.",
-		Hidden:      true,
-	},
-	flag.String{
-		Name:        "image-label",
-		Description: "Image label to use when tagging and pushing to the fly registry. Defaults to \"deployment-{timestamp}\".",
-		Hidden:      true,
-	},
-	flag.String{
-		Name:        "build-target",
-		Description: "Set the target build stage to build if the Dockerfile has more than one stage",
-		Hidden:      true,
-	},
+	flag.Ignorefile(),
+	flag.BuildArg(),
+	flag.BuildSecret(),
+	flag.ImageLabel(),
+	flag.BuildTarget(),
 	flag.Bool{
 		Name:        "no-build-cache",
 		Description: "Do not use the cache when building the image",
-		Hidden:      true,
 	},
+	flag.StringArray{
+		Name:        "label",
+		Description: "Add custom metadata to an image via docker labels",
+	},
+	flag.CacheFrom(),
+	flag.CacheTo(),
+	flag.SSH(),
+	flag.SBOM(),
+	flag.Reproducible(),
 	flag.StringArray{
 		Name:        "kernel-arg",
 		Description: "A list of kernel arguments to provide to the init. Can be specified multiple times.",
@@ -11,6 +11,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -29,6 +30,7 @@ func newStart() *cobra.Command {
 	)
 
 	cmd.Args = cobra.ArbitraryArgs
+	cmd.ValidArgsFunction = completion.Adapt(completion.CompleteMachines)
 
 	flag.Add(
 		cmd,
@@ -9,7 +9,9 @@ import (
 	"github.com/alecthomas/chroma/quick"
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -28,17 +30,29 @@ func newStatus() *cobra.Command {
 	)
 
 	cmd.Args = cobra.RangeArgs(0, 1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteMachines))
 
 	flag.Add(
 		cmd,
 		flag.App(),
 		flag.AppConfig(),
 		selectFlag,
+		flag.JSONOutput(),
 		flag.Bool{
 			Name:        "display-config",
 			Description: "Display the machine config as JSON",
 			Shorthand:   "d",
 		},
+		flag.Bool{
+			Name:        "watch",
+			Description: "Keep running and stream state transitions, check changes and new events of the machine",
+			Shorthand:   "w",
+		},
+		flag.Duration{
+			Name:        "interval",
+			Description: "How often to poll the machine in watch mode",
+			Default:     2 * time.Second,
+		},
 	)
 
 	return cmd
@@ -63,6 +77,14 @@ func runMachineStatus(ctx context.Context) (err error) {
 		return err
 	}
 
+	if flag.GetBool(ctx, "watch") {
+		return watchMachineStatus(ctx, machine)
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, machine)
+	}
+
 	fmt.Fprintf(io.Out, "Machine ID: %s\n", machine.ID)
 	fmt.Fprintf(io.Out, "Instance ID: %s\n", machine.InstanceID)
 	fmt.Fprintf(io.Out, "State: %s\n\n", machine.State)
@@ -0,0 +1,171 @@
+package machine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// machineChange is a change of a watched machine, printed as a line or, with
+// --json, as a JSON object per line.
+type machineChange struct {
+	// Type is state, check or event.
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Machine string    `json:"machine"`
+	Name    string    `json:"name,omitempty"`
+	From    string    `json:"from,omitempty"`
+	To      string    `json:"to,omitempty"`
+	Output  string    `json:"output,omitempty"`
+	// ExitCode and OOMKilled are set for exit events.
+	ExitCode  *int `json:"exit_code,omitempty"`
+	OOMKilled bool `json:"oom_killed,omitempty"`
+}
+
+func (c machineChange) String() string {
+	ts := c.Time.Local().Format(time.TimeOnly)
+	switch c.Type {
+	case "state":
+		return fmt.Sprintf("%s  state   %s -> %s", ts, c.From, c.To)
+	case "check":
+		line := fmt.Sprintf("%s  check   %s: %s", ts, c.Name, c.To)
+		if c.From != "" {
+			line = fmt.Sprintf("%s  check   %s: %s -> %s", ts, c.Name, c.From, c.To)
+		}
+		if c.Output != "" {
+			line += fmt.Sprintf(" (%s)", c.Output)
+		}
+		return line
+	default:
+		line := fmt.Sprintf("%s  event   %s %s", ts, c.Name, c.To)
+		if c.ExitCode != nil {
+			line += fmt.Sprintf(" exit_code=%d oom_killed=%t", *c.ExitCode, c.OOMKilled)
+		}
+		return line
+	}
+}
+
+// watchMachineStatus polls the machine until interrupted and prints what
+// changed between polls.
+func watchMachineStatus(ctx context.Context, machine *fly.Machine) error {
+	var (
+		io          = iostreams.FromContext(ctx)
+		flapsClient = flaps.FromContext(ctx)
+		jsonOutput  = config.FromContext(ctx).JSONOutput
+		interval    = flag.GetDuration(ctx, "interval")
+	)
+
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	enc := json.NewEncoder(io.Out)
+	emit := func(changes []machineChange) {
+		for _, c := range changes {
+			if jsonOutput {
+				_ = enc.Encode(c)
+			} else {
+				fmt.Fprintln(io.Out, c)
+			}
+		}
+	}
+
+	if !jsonOutput {
+		fmt.Fprintf(io.ErrOut, "Watching machine %s (%s), press Ctrl+C to stop\n", machine.ID, machine.State)
+	}
+	emit(machineChanges(nil, machine))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := flapsClient.Get(ctx, machine.ID)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil
+		case err != nil:
+			// A single failed poll shouldn't end the watch of a flaky machine.
+			terminal.Debugf("failed polling machine %s: %v", machine.ID, err)
+			continue
+		}
+
+		emit(machineChanges(machine, current))
+		machine = current
+	}
+}
+
+// machineChanges returns the state transitions, check changes and new events
+// between two polls of a machine. With no previous poll, the current checks
+// and the most recent event are returned, so the watch starts with where the
+// machine stands.
+func machineChanges(prev, cur *fly.Machine) []machineChange {
+	var (
+		changes []machineChange
+		now     = time.Now().UTC()
+	)
+
+	prevChecks := map[string]string{}
+	var lastEvent int64
+	if prev != nil {
+		if prev.State != cur.State {
+			changes = append(changes, machineChange{Type: "state", Time: now, Machine: cur.ID, From: prev.State, To: cur.State})
+		}
+		for _, check := range prev.Checks {
+			prevChecks[check.Name] = string(check.Status)
+		}
+		for _, event := range prev.Events {
+			lastEvent = max(lastEvent, event.Timestamp)
+		}
+	} else if len(cur.Events) > 0 {
+		// Events are listed newest first; start just before the most
+		// recent one.
+		lastEvent = cur.Events[0].Timestamp - 1
+	}
+
+	for _, check := range cur.Checks {
+		from, seen := prevChecks[check.Name]
+		if seen && from == string(check.Status) {
+			continue
+		}
+		c := machineChange{Type: "check", Time: now, Machine: cur.ID, Name: check.Name, From: from, To: string(check.Status)}
+		if check.Status != fly.Passing {
+			c.Output = check.Output
+		}
+		changes = append(changes, c)
+	}
+
+	// Print new events oldest first.
+	for i := len(cur.Events) - 1; i >= 0; i-- {
+		event := cur.Events[i]
+		if event.Timestamp <= lastEvent {
+			continue
+		}
+		c := machineChange{Type: "event", Time: event.Time().UTC(), Machine: cur.ID, Name: event.Type, To: event.Status}
+		if event.Request != nil {
+			if code, err := event.Request.GetExitCode(); err == nil {
+				c.ExitCode = &code
+			}
+			if event.Request.ExitEvent != nil {
+				c.OOMKilled = event.Request.ExitEvent.OOMKilled
+			}
+		}
+		changes = append(changes, c)
+	}
+
+	return changes
+}
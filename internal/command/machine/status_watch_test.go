@@ -0,0 +1,94 @@
+package machine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestMachineChanges(t *testing.T) {
+	exit := &fly.MachineEvent{Type: "exit", Status: "stopped", Timestamp: 3000, Request: &fly.MachineRequest{
+		ExitEvent: &fly.MachineExitEvent{ExitCode: 137, OOMKilled: true},
+	}}
+	start := &fly.MachineEvent{Type: "start", Status: "started", Timestamp: 2000}
+	launch := &fly.MachineEvent{Type: "launch", Status: "created", Timestamp: 1000}
+
+	prev := &fly.Machine{
+		ID:     "m1",
+		State:  "started",
+		Checks: []*fly.MachineCheckStatus{{Name: "http", Status: fly.Passing}, {Name: "tcp", Status: fly.Passing}},
+		Events: []*fly.MachineEvent{start, launch},
+	}
+	cur := &fly.Machine{
+		ID:     "m1",
+		State:  "stopped",
+		Checks: []*fly.MachineCheckStatus{{Name: "http", Status: fly.Critical, Output: "connection refused"}, {Name: "tcp", Status: fly.Passing}},
+		Events: []*fly.MachineEvent{exit, start, launch},
+	}
+
+	exitCode := 137
+	cases := []struct {
+		name      string
+		prev, cur *fly.Machine
+		want      []machineChange
+	}{
+		{
+			name: "first poll shows the checks and the latest event",
+			cur:  prev,
+			want: []machineChange{
+				{Type: "check", Machine: "m1", Name: "http", To: "passing"},
+				{Type: "check", Machine: "m1", Name: "tcp", To: "passing"},
+				{Type: "event", Machine: "m1", Name: "start", To: "started"},
+			},
+		},
+		{
+			name: "unchanged",
+			prev: prev,
+			cur:  prev,
+		},
+		{
+			name: "state, failing check and new event",
+			prev: prev,
+			cur:  cur,
+			want: []machineChange{
+				{Type: "state", Machine: "m1", From: "started", To: "stopped"},
+				{Type: "check", Machine: "m1", Name: "http", From: "passing", To: "critical", Output: "connection refused"},
+				{Type: "event", Machine: "m1", Name: "exit", To: "stopped", ExitCode: &exitCode, OOMKilled: true},
+			},
+		},
+		{
+			name: "new check",
+			prev: &fly.Machine{ID: "m1", State: "started"},
+			cur:  &fly.Machine{ID: "m1", State: "started", Checks: []*fly.MachineCheckStatus{{Name: "http", Status: fly.Warning, Output: "slow"}}},
+			want: []machineChange{
+				{Type: "check", Machine: "m1", Name: "http", To: "warning", Output: "slow"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := machineChanges(tc.prev, tc.cur)
+			for i := range got {
+				assert.False(t, got[i].Time.IsZero())
+				got[i].Time = time.Time{}
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMachineChangeString(t *testing.T) {
+	ts := time.Date(2024, 3, 10, 12, 30, 5, 0, time.Local)
+	exitCode := 1
+
+	assert.Equal(t, "12:30:05  state   started -> stopped",
+		machineChange{Type: "state", Time: ts, From: "started", To: "stopped"}.String())
+	assert.Equal(t, "12:30:05  check   http: passing",
+		machineChange{Type: "check", Time: ts, Name: "http", To: "passing"}.String())
+	assert.Equal(t, "12:30:05  check   http: passing -> critical (connection refused)",
+		machineChange{Type: "check", Time: ts, Name: "http", From: "passing", To: "critical", Output: "connection refused"}.String())
+	assert.Equal(t, "12:30:05  event   exit stopped exit_code=1 oom_killed=false",
+		machineChange{Type: "event", Time: ts, Name: "exit", To: "stopped", ExitCode: &exitCode}.String())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flyerr"
 	mach "github.com/superfly/flyctl/internal/machine"
 	"github.com/superfly/flyctl/internal/watch"
@@ -64,6 +65,7 @@ func newUpdate() *cobra.Command {
 	)
 
 	cmd.Args = cobra.RangeArgs(0, 1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteMachines))
 
 	return cmd
 }
@@ -12,15 +12,15 @@ import (
 
 func New() (cmd *cobra.Command) {
 	const (
-		short = "Commands that handle sending any metrics to flyctl-metrics"
+		short = "Query the metrics of your apps"
 		long  = short + "\n"
 		usage = "metrics <command>"
 	)
 
 	cmd = command.New(usage, short, long, nil)
-	cmd.Hidden = true
 
 	cmd.AddCommand(
+		newQuery(),
 		newSend(),
 	)
 
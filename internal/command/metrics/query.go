@@ -0,0 +1,228 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/orgs"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prometheus"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// maxPoints bounds the number of points per series of the default step.
+const maxPoints = 120
+
+func newQuery() (cmd *cobra.Command) {
+	const (
+		short = "Run a PromQL query against the metrics of an organization"
+		long  = short + `. The query runs against the managed Prometheus of the
+organization of the app, or of the organization given with --org.
+
+  fly metrics query 'sum(rate(fly_instance_net_sent_bytes[5m]))' --since 1h
+`
+		usage = "query <promql>"
+	)
+
+	cmd = command.New(usage, short, long, runQuery, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.ExactArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Org(),
+		flag.JSONOutput(),
+		flag.Duration{
+			Name:        "since",
+			Description: "How far back to query",
+			Default:     time.Hour,
+		},
+		flag.Duration{
+			Name:        "step",
+			Description: "The resolution of the query, defaults to a step giving about 120 points",
+		},
+		flag.String{
+			Name:        "output",
+			Description: "The output format: table, sparkline or json",
+			Default:     "table",
+		},
+	)
+	return
+}
+
+func runQuery(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	format := flag.GetString(ctx, "output")
+	if config.FromContext(ctx).JSONOutput {
+		format = "json"
+	}
+	if format != "table" && format != "sparkline" && format != "json" {
+		return fmt.Errorf("invalid output format %s, expected table, sparkline or json", format)
+	}
+
+	since := flag.GetDuration(ctx, "since")
+	if since <= 0 {
+		return fmt.Errorf("--since must be positive")
+	}
+	step := flag.GetDuration(ctx, "step")
+	if step <= 0 {
+		step = defaultStep(since)
+	}
+
+	orgSlug, err := queryOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	end := time.Now()
+	series, err := prometheus.NewClient(ctx, orgSlug).QueryRange(ctx, flag.FirstArg(ctx), end.Add(-since), end, step)
+	if err != nil {
+		return err
+	}
+	sort.Slice(series, func(i, j int) bool { return seriesLabel(series[i].Metric) < seriesLabel(series[j].Metric) })
+
+	switch format {
+	case "json":
+		return render.JSON(out, series)
+	case "sparkline":
+		return renderSparklines(out, series)
+	default:
+		return renderSummary(out, series)
+	}
+}
+
+// queryOrg returns the organization given with --org, or the app's.
+func queryOrg(ctx context.Context) (string, error) {
+	if slug := flag.GetOrg(ctx); slug != "" {
+		return slug, nil
+	}
+	if appName := appconfig.NameFromContext(ctx); appName != "" {
+		app, err := fly.ClientFromContext(ctx).GetAppCompact(ctx, appName)
+		if err != nil {
+			return "", err
+		}
+		return app.Organization.Slug, nil
+	}
+	org, err := orgs.OrgFromFlagOrSelect(ctx)
+	if err != nil {
+		return "", err
+	}
+	return org.Slug, nil
+}
+
+// defaultStep returns a step giving about maxPoints points over since, and at
+// least 15 seconds, the scrape interval of Fly.io metrics.
+func defaultStep(since time.Duration) time.Duration {
+	step := (since / maxPoints).Round(time.Second)
+	return max(step, 15*time.Second)
+}
+
+// seriesLabel formats the labels of a series as {key="value", ...}.
+func seriesLabel(metric map[string]string) string {
+	name := metric["__name__"]
+	keys := make([]string, 0, len(metric))
+	for k := range metric {
+		if k != "__name__" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		if name == "" {
+			return "{}"
+		}
+		return name
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, metric[k]))
+	}
+	return name + "{" + strings.Join(pairs, ", ") + "}"
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+func renderSummary(out io.Writer, series []prometheus.Series) error {
+	rows := make([][]string, 0, len(series))
+	for _, s := range series {
+		if len(s.Points) == 0 {
+			continue
+		}
+		lo, hi, sum := math.Inf(1), math.Inf(-1), 0.0
+		for _, p := range s.Points {
+			lo, hi, sum = math.Min(lo, p.Value), math.Max(hi, p.Value), sum+p.Value
+		}
+		rows = append(rows, []string{
+			seriesLabel(s.Metric),
+			formatValue(s.Points[len(s.Points)-1].Value),
+			formatValue(lo),
+			formatValue(sum / float64(len(s.Points))),
+			formatValue(hi),
+		})
+	}
+	return render.Table(out, "", rows, "Series", "Last", "Min", "Avg", "Max")
+}
+
+func renderSparklines(out io.Writer, series []prometheus.Series) error {
+	for _, s := range series {
+		values := make([]float64, 0, len(s.Points))
+		for _, p := range s.Points {
+			values = append(values, p.Value)
+		}
+		last := ""
+		if len(values) > 0 {
+			last = formatValue(values[len(values)-1])
+		}
+		if _, err := fmt.Fprintf(out, "%s\n  %s %s\n", seriesLabel(s.Metric), sparkline(values), last); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws values scaled between their minimum and maximum.
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			// Gaps, such as a rate over a counter reset, are left blank
+			b.WriteRune(' ')
+			continue
+		}
+		i := 0
+		if high > low {
+			i = int((v - low) / (high - low) * float64(len(sparks)-1))
+		}
+		b.WriteRune(sparks[max(0, min(i, len(sparks)-1))])
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/prometheus"
+)
+
+func TestDefaultStep(t *testing.T) {
+	assert.Equal(t, 30*time.Second, defaultStep(time.Hour))
+	assert.Equal(t, 15*time.Second, defaultStep(time.Minute))
+	assert.Equal(t, 12*time.Minute, defaultStep(24*time.Hour))
+}
+
+func TestSeriesLabel(t *testing.T) {
+	assert.Equal(t, "{}", seriesLabel(nil))
+	assert.Equal(t, "fly_instance_up", seriesLabel(map[string]string{"__name__": "fly_instance_up"}))
+	assert.Equal(t, `fly_instance_up{app="web", region="ord"}`, seriesLabel(map[string]string{
+		"__name__": "fly_instance_up",
+		"region":   "ord",
+		"app":      "web",
+	}))
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", sparkline(nil))
+	assert.Equal(t, "▁▁▁", sparkline([]float64{2, 2, 2}))
+	assert.Equal(t, "▁▄█", sparkline([]float64{0, 5, 10}))
+	assert.Equal(t, "▁ █ ", sparkline([]float64{0, math.NaN(), 10, math.Inf(1)}))
+	assert.Equal(t, "  ", sparkline([]float64{math.Inf(-1), math.NaN()}))
+}
+
+func TestRenderSummary(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderSummary(&out, []prometheus.Series{
+		{Metric: map[string]string{"app": "web"}, Points: []prometheus.Point{{Value: 1}, {Value: 3}, {Value: 2}}},
+		{Metric: map[string]string{"app": "empty"}},
+	}))
+	assert.Contains(t, out.String(), `{app="web"}`)
+	assert.NotContains(t, out.String(), "empty")
+}
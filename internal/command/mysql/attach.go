@@ -0,0 +1,149 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newAttach() *cobra.Command {
+	const (
+		short = "Attach a MySQL cluster to an app"
+		long  = short + `. Creates a database and a user for the app on the
+cluster, and sets the secret holding the URL to connect with.
+`
+		usage = "attach <MYSQL APP NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runAttach,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+		flag.String{
+			Name:        "database-name",
+			Description: "The name of the database to use, defaults to the app name",
+		},
+		flag.String{
+			Name:        "database-user",
+			Description: "The name of the user to create, defaults to the app name",
+		},
+		flag.String{
+			Name:        "variable-name",
+			Description: "The name of the secret that holds the connection URL",
+			Default:     "DATABASE_URL",
+		},
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runAttach(ctx context.Context) error {
+	var (
+		client       = fly.ClientFromContext(ctx)
+		io           = iostreams.FromContext(ctx)
+		appName      = appconfig.NameFromContext(ctx)
+		mysqlAppName = flag.FirstArg(ctx)
+		dbName       = flag.GetString(ctx, "database-name")
+		dbUser       = flag.GetString(ctx, "database-user")
+		varName      = flag.GetString(ctx, "variable-name")
+	)
+
+	dbName, dbUser, err := attachNames(appName, dbName, dbUser)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := client.GetAppSecrets(ctx, appName)
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		if secret.Name == varName {
+			return fmt.Errorf("consumer app %q already contains a secret named %s", appName, varName)
+		}
+	}
+
+	ctx, _, primary, _, err := loadCluster(ctx, mysqlAppName)
+	if err != nil {
+		return err
+	}
+
+	mysqlClient := newClient(ctx)
+
+	exists, err := mysqlClient.UserExists(ctx, primary, dbUser)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("database user %q already exists, pick another one with --database-user", dbUser)
+	}
+
+	exists, err = mysqlClient.DatabaseExists(ctx, primary, dbName)
+	if err != nil {
+		return err
+	}
+	if exists && !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("Database %q already exists. Continue with the attachment process?", dbName)
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
+	password, err := helpers.RandString(15)
+	if err != nil {
+		return err
+	}
+
+	if err := mysqlClient.CreateDatabase(ctx, primary, dbName); err != nil {
+		return fmt.Errorf("failed to create database %s: %w", dbName, err)
+	}
+	if err := mysqlClient.CreateUser(ctx, primary, dbUser, password, dbName); err != nil {
+		return fmt.Errorf("failed to create user %s: %w", dbUser, err)
+	}
+
+	connectionString := flymysql.ConnectionString(mysqlAppName, dbUser, password, dbName)
+	if _, err := client.SetSecrets(ctx, appName, map[string]string{varName: connectionString}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "\nMySQL cluster %s is now attached to %s\n", mysqlAppName, appName)
+	fmt.Fprintf(io.Out, "The following secret was added to %s:\n  %s=%s\n", appName, varName, connectionString)
+
+	return nil
+}
+
+// attachNames returns the names of the database and user to create for
+// appName, defaulting to the name of the app.
+func attachNames(appName, dbName, dbUser string) (string, string, error) {
+	if dbName == "" {
+		dbName = flymysql.Identifier(appName)
+	}
+	if dbUser == "" {
+		dbUser = flymysql.Identifier(appName)
+	}
+	for _, name := range []string{dbName, dbUser} {
+		if err := flymysql.ValidateIdentifier(name); err != nil {
+			return "", "", err
+		}
+	}
+	return dbName, dbUser, nil
+}
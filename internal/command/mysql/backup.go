@@ -0,0 +1,370 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/extensions/tigris"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newBackup() *cobra.Command {
+	const (
+		short = "Manage the backups of a MySQL cluster"
+		long  = short + `. Backups are gzipped logical dumps of every database,
+kept in a Tigris bucket, off the volumes of the cluster. Create the bucket with
+'fly storage create'.
+`
+	)
+
+	cmd := command.New("backup", short, long, nil)
+
+	cmd.Aliases = []string{"backups"}
+
+	cmd.AddCommand(
+		newBackupCreate(),
+		newBackupList(),
+		newBackupRestore(),
+		newBackupDelete(),
+	)
+
+	return cmd
+}
+
+var bucketFlag = flag.String{
+	Name:        "bucket",
+	Description: "The Tigris bucket holding the backups",
+}
+
+// backupBucket returns the bucket named by --bucket and a client for it.
+func backupBucket(ctx context.Context) (string, *s3.Client, error) {
+	bucket := flag.GetString(ctx, "bucket")
+	if bucket == "" {
+		return "", nil, fmt.Errorf("the bucket holding the backups must be given with --bucket")
+	}
+
+	client, err := tigris.BucketClient(ctx, bucket)
+	if err != nil {
+		return "", nil, err
+	}
+	return bucket, client, nil
+}
+
+func newBackupCreate() *cobra.Command {
+	const (
+		short = "Create a backup of a MySQL cluster"
+		long  = short + "\n"
+
+		usage = "create"
+	)
+
+	cmd := command.New(usage, short, long, runBackupCreate,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		bucketFlag,
+		flag.String{
+			Name:        "name",
+			Shorthand:   "n",
+			Description: "The name of the backup, defaults to the current time",
+		},
+	)
+
+	return cmd
+}
+
+func runBackupCreate(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = flag.GetString(ctx, "name")
+	)
+
+	if name == "" {
+		name = flymysql.NewBackupName(time.Now())
+	}
+	if err := flymysql.ValidateBackupName(name); err != nil {
+		return err
+	}
+
+	bucket, s3Client, err := backupBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, _, primary, _, err := loadCluster(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	// The dump is buffered to a file, as uploads need to know their size.
+	file, err := os.CreateTemp("", "mysql-backup-*"+flymysql.BackupExtension)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	fmt.Fprintf(io.ErrOut, "Backing up machine %s...\n", primary.ID)
+
+	if err := newClient(ctx).CreateBackup(ctx, primary, file); err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return err
+	}
+
+	key := flymysql.BackupKey(appName, name)
+	fmt.Fprintf(io.ErrOut, "Uploading to %s/%s...\n", bucket, key)
+
+	if _, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   file,
+	}); err != nil {
+		return fmt.Errorf("failed to upload backup: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Backup %s created\n", name)
+	return nil
+}
+
+func newBackupList() *cobra.Command {
+	const (
+		short = "List the backups of a MySQL cluster"
+		long  = short + "\n"
+
+		usage = "list"
+	)
+
+	cmd := command.New(usage, short, long, runBackupList,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		bucketFlag,
+		flag.JSONOutput(),
+	)
+
+	cmd.Aliases = []string{"ls"}
+
+	return cmd
+}
+
+func runBackupList(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		cfg     = config.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	backups, err := listBackups(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, backups)
+	}
+
+	if len(backups) == 0 {
+		fmt.Fprintln(io.Out, "No backups found")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(backups))
+	for _, b := range backups {
+		rows = append(rows, []string{
+			b.Name,
+			humanize.IBytes(uint64(b.Size)),
+			format.RelativeTime(b.CreatedAt),
+		})
+	}
+
+	return render.Table(io.Out, "", rows, "Name", "Size", "Created")
+}
+
+func listBackups(ctx context.Context, appName string) ([]flymysql.Backup, error) {
+	bucket, s3Client, err := backupBucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []flymysql.BackupObject
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(flymysql.BackupPrefix(appName)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			objects = append(objects, flymysql.BackupObject{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+
+	return flymysql.BackupsFromObjects(objects), nil
+}
+
+func newBackupRestore() *cobra.Command {
+	const (
+		short = "Restore a backup into a MySQL cluster"
+		long  = short + `. Databases in the backup are replaced, other databases
+are left untouched.
+`
+		usage = "restore <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runBackupRestore,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		bucketFlag,
+		flag.Yes(),
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runBackupRestore(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = flag.FirstArg(ctx)
+	)
+
+	if !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("Restoring %s replaces the data of the databases it holds on %s. Continue?", name, appName)
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
+	if err := flymysql.ValidateBackupName(name); err != nil {
+		return err
+	}
+
+	bucket, s3Client, err := backupBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, _, primary, _, err := loadCluster(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	obj, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(flymysql.BackupKey(appName, name)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to download backup %s: %w", name, err)
+	}
+	defer obj.Body.Close()
+
+	fmt.Fprintf(io.ErrOut, "Restoring %s on machine %s...\n", name, primary.ID)
+
+	if err := newClient(ctx).RestoreBackup(ctx, primary, obj.Body); err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Backup %s restored\n", name)
+	return nil
+}
+
+func newBackupDelete() *cobra.Command {
+	const (
+		short = "Delete a backup of a MySQL cluster"
+		long  = short + "\n"
+
+		usage = "delete <NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runBackupDelete,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		bucketFlag,
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.Aliases = []string{"rm"}
+
+	return cmd
+}
+
+func runBackupDelete(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = flag.FirstArg(ctx)
+	)
+
+	if err := flymysql.ValidateBackupName(name); err != nil {
+		return err
+	}
+
+	bucket, s3Client, err := backupBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	input := &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(flymysql.BackupKey(appName, name)),
+	}
+	if _, err := s3Client.HeadObject(ctx, input); err != nil {
+		return fmt.Errorf("backup %s not found: %w", name, err)
+	}
+
+	if _, err := s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: input.Bucket,
+		Key:    input.Key,
+	}); err != nil {
+		return fmt.Errorf("failed to delete backup: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Backup %s deleted\n", name)
+	return nil
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"os"
+
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/mattn/go-colorable"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/ssh"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+func newConnect() *cobra.Command {
+	const (
+		short = "Connect to the MySQL console of the primary"
+		long  = short + "\n"
+
+		usage = "connect"
+	)
+
+	cmd := command.New(usage, short, long, runConnect,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.String{
+			Name:        "database",
+			Shorthand:   "d",
+			Description: "The name of the database you would like to connect to",
+		},
+	)
+
+	return cmd
+}
+
+func runConnect(ctx context.Context) error {
+	var (
+		appName  = appconfig.NameFromContext(ctx)
+		database = flag.GetString(ctx, "database")
+	)
+
+	if database != "" {
+		if err := flymysql.ValidateIdentifier(database); err != nil {
+			return err
+		}
+	}
+
+	ctx, app, primary, _, err := loadCluster(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	return ssh.SSHConnect(&ssh.SSHParams{
+		Ctx:      ctx,
+		Org:      app.Organization,
+		Dialer:   agent.DialerFromContext(ctx),
+		App:      app.Name,
+		Username: ssh.DefaultSshUsername,
+		Cmd:      flymysql.ConsoleCommand(primary, database),
+		Stdin:    os.Stdin,
+		Stdout:   ioutils.NewWriteCloserWrapper(colorable.NewColorableStdout(), func() error { return nil }),
+		Stderr:   ioutils.NewWriteCloserWrapper(colorable.NewColorableStderr(), func() error { return nil }),
+	}, primary.PrivateIP)
+}
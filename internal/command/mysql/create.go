@@ -0,0 +1,161 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/postgres"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+)
+
+// minMemoryMB is the memory of the smallest machines offered, below which
+// MySQL struggles.
+const minMemoryMB = 1024
+
+func newCreate() *cobra.Command {
+	const (
+		short = "Create a new MySQL or MariaDB cluster"
+		long  = short + `. MariaDB clusters with an initial cluster size above 1
+get replicas, which 'fly mysql failover' can promote.
+`
+	)
+
+	cmd := command.New("create", short, long, runCreate,
+		command.RequireSession,
+	)
+
+	flag.Add(
+		cmd,
+		flag.Region(),
+		flag.Org(),
+		flag.Detach(),
+		flag.String{
+			Name:        "name",
+			Shorthand:   "n",
+			Description: "The name of your MySQL app",
+		},
+		flag.String{
+			Name:        "flavor",
+			Description: "The database to run, mysql or mariadb",
+			Default:     flymysql.FlavorMySQL,
+		},
+		flag.String{
+			Name:        "version",
+			Description: "The version of the database, the tag of its official image",
+		},
+		flag.String{
+			Name:        "vm-size",
+			Description: "the size of the VM",
+		},
+		flag.Int{
+			Name:        "initial-cluster-size",
+			Description: "Initial cluster size, the primary and its replicas. Replicas require --flavor mariadb",
+			Default:     1,
+		},
+		flag.Int{
+			Name:        "volume-size",
+			Description: "The volume size in GB",
+			Default:     10,
+		},
+		flag.String{
+			Name:        "snapshot-id",
+			Description: "Creates the primary's volume with the contents of the snapshot",
+		},
+		flag.String{
+			Name:        "image-ref",
+			Description: "Specify a non-default image, compatible with the official one of the flavor",
+		},
+	)
+
+	return cmd
+}
+
+func runCreate(ctx context.Context) (err error) {
+	var (
+		appName = flag.GetString(ctx, "name")
+		flavor  = flag.GetString(ctx, "flavor")
+		client  = fly.ClientFromContext(ctx)
+	)
+
+	if err := flymysql.ValidateFlavor(flavor); err != nil {
+		return err
+	}
+
+	size := flag.GetInt(ctx, "initial-cluster-size")
+	if size < 1 {
+		return fmt.Errorf("the initial cluster size must be at least 1")
+	}
+	if size > 1 && !flymysql.SupportsReplicas(flavor) {
+		return fmt.Errorf("replicas require --flavor %s", flymysql.FlavorMariaDB)
+	}
+
+	// pre-fetch platform regions for later use
+	prompt.PlatformRegions(ctx)
+
+	if appName == "" {
+		if appName, err = prompt.SelectAppName(ctx); err != nil {
+			return
+		}
+	}
+
+	org, err := prompt.Org(ctx)
+	if err != nil {
+		return
+	}
+
+	region, err := prompt.Region(ctx, !org.PaidPlan, prompt.RegionParams{
+		Message: "",
+	})
+	if err != nil {
+		return
+	}
+
+	vmSize, err := resolveVMSize(ctx, flag.GetString(ctx, "vm-size"))
+	if err != nil {
+		return
+	}
+
+	input := &flymysql.CreateClusterInput{
+		AppName:            appName,
+		Organization:       org,
+		Region:             region.Code,
+		Flavor:             flavor,
+		ImageRef:           flag.GetString(ctx, "image-ref"),
+		InitialClusterSize: size,
+		VolumeSize:         fly.IntPointer(flag.GetInt(ctx, "volume-size")),
+		VMSize:             vmSize,
+	}
+
+	if version := flag.GetString(ctx, "version"); version != "" && input.ImageRef == "" {
+		input.ImageRef = fmt.Sprintf("%s:%s", flavor, version)
+	}
+
+	if snapshot := flag.GetString(ctx, "snapshot-id"); snapshot != "" {
+		input.SnapshotID = &snapshot
+	}
+
+	return flymysql.NewLauncher(client).LaunchMachinesMySQL(ctx, input, flag.GetDetach(ctx))
+}
+
+func resolveVMSize(ctx context.Context, targetSize string) (*fly.VMSize, error) {
+	sizes := lo.Filter(postgres.MachineVMSizes(), func(size fly.VMSize, _ int) bool {
+		return size.MemoryMB >= minMemoryMB
+	})
+
+	if targetSize != "" {
+		for _, size := range sizes {
+			if size.Name == targetSize {
+				return &size, nil
+			}
+		}
+		return nil, fmt.Errorf("VM size %s is invalid or has less than %dMB of memory", targetSize, minMemoryMB)
+	}
+
+	return prompt.SelectVMSize(ctx, sizes)
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newDetach() *cobra.Command {
+	const (
+		short = "Detach a MySQL cluster from an app"
+		long  = short + `. Drops the user of the app and unsets its secret. The
+database remains unless --drop-database is given.
+`
+		usage = "detach <MYSQL APP NAME>"
+	)
+
+	cmd := command.New(usage, short, long, runDetach,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+		flag.String{
+			Name:        "database-name",
+			Description: "The name of the database the app uses, defaults to the app name",
+		},
+		flag.String{
+			Name:        "database-user",
+			Description: "The name of the user of the app, defaults to the app name",
+		},
+		flag.String{
+			Name:        "variable-name",
+			Description: "The name of the secret that holds the connection URL",
+			Default:     "DATABASE_URL",
+		},
+		flag.Bool{
+			Name:        "drop-database",
+			Description: "Drop the database of the app, with all its data",
+		},
+	)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runDetach(ctx context.Context) error {
+	var (
+		client       = fly.ClientFromContext(ctx)
+		io           = iostreams.FromContext(ctx)
+		appName      = appconfig.NameFromContext(ctx)
+		mysqlAppName = flag.FirstArg(ctx)
+		dbName       = flag.GetString(ctx, "database-name")
+		dbUser       = flag.GetString(ctx, "database-user")
+		varName      = flag.GetString(ctx, "variable-name")
+	)
+
+	if dbName == "" {
+		dbName = flymysql.Identifier(appName)
+	}
+	if dbUser == "" {
+		dbUser = flymysql.Identifier(appName)
+	}
+	for _, name := range []string{dbName, dbUser} {
+		if err := flymysql.ValidateIdentifier(name); err != nil {
+			return err
+		}
+	}
+
+	dropDatabase := flag.GetBool(ctx, "drop-database")
+	if dropDatabase && !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("Database %q and all its data will be dropped. Continue?", dbName)
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err != nil:
+			return err
+		case !confirmed:
+			return nil
+		}
+	}
+
+	ctx, _, primary, _, err := loadCluster(ctx, mysqlAppName)
+	if err != nil {
+		return err
+	}
+
+	mysqlClient := newClient(ctx)
+
+	if err := mysqlClient.DropUser(ctx, primary, dbUser); err != nil {
+		return fmt.Errorf("failed to drop user %s: %w", dbUser, err)
+	}
+
+	if dropDatabase {
+		if err := mysqlClient.DropDatabase(ctx, primary, dbName); err != nil {
+			return fmt.Errorf("failed to drop database %s: %w", dbName, err)
+		}
+		fmt.Fprintf(io.Out, "Database %q was dropped\n", dbName)
+	}
+
+	if _, err := client.UnsetSecrets(ctx, appName, []string{varName}); err != nil {
+		// This will error if secret doesn't exist, so just send to stdout.
+		fmt.Fprintln(io.Out, err.Error())
+	} else {
+		fmt.Fprintf(io.Out, "Secret %q was scheduled to be removed from app %s\n", varName, appName)
+	}
+
+	fmt.Fprintf(io.Out, "MySQL cluster %s is now detached from %s\n", mysqlAppName, appName)
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// catchUpTimeout is how many seconds the new primary has to apply the
+// transactions of the old one.
+const catchUpTimeout = 60
+
+func newFailover() *cobra.Command {
+	const (
+		short = "Promote a replica of a MariaDB cluster to primary"
+		long  = short + `. The primary is made read only and the replica waits
+to catch up with it before being promoted; the old primary and the other
+replicas then replicate from the new primary. With --force, the primary is
+assumed to be lost and the replica is promoted as is.
+`
+		usage = "failover"
+	)
+
+	cmd := command.New(usage, short, long, runFailover,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.String{
+			Name:        "machine",
+			Description: "The ID of the replica to promote, defaults to the one with the least lag",
+		},
+		flag.Bool{
+			Name:        "force",
+			Description: "Promote without waiting for the replica to catch up, when the primary is unreachable",
+		},
+	)
+
+	return cmd
+}
+
+func runFailover(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		force   = flag.GetBool(ctx, "force")
+	)
+
+	ctx, _, primary, replicas, err := loadCluster(ctx, appName)
+	if err != nil {
+		return err
+	}
+	if len(replicas) == 0 {
+		return fmt.Errorf("app %s has no replicas to fail over to", appName)
+	}
+
+	client := newClient(ctx)
+
+	target, err := pickReplica(ctx, client, replicas, flag.GetString(ctx, "machine"))
+	if err != nil {
+		return err
+	}
+
+	machines, releaseLeases, err := mach.AcquireLeases(ctx, append([]*fly.Machine{primary}, replicas...))
+	defer releaseLeases()
+	if err != nil {
+		return err
+	}
+	primary, replicas = machines[0], machines[1:]
+	for _, m := range replicas {
+		if m.ID == target.ID {
+			target = m
+		}
+	}
+
+	if !force {
+		fmt.Fprintf(io.ErrOut, "Fencing primary %s\n", primary.ID)
+		pos, err := client.Fence(ctx, primary)
+		if err != nil {
+			return fmt.Errorf("failed to fence the primary, use --force if it's lost: %w", err)
+		}
+
+		fmt.Fprintf(io.ErrOut, "Waiting for %s to catch up\n", target.ID)
+		if err := client.WaitForPosition(ctx, target, pos, catchUpTimeout); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(io.ErrOut, "Promoting %s\n", target.ID)
+	if err := client.Promote(ctx, target); err != nil {
+		return fmt.Errorf("failed to promote %s: %w", target.ID, err)
+	}
+	if err := updateRole(ctx, target, flymysql.RolePrimary); err != nil {
+		return err
+	}
+
+	primaryHost := flymysql.Hostname(appName, target)
+	for _, m := range followers(primary, replicas, target, force) {
+		fmt.Fprintf(io.ErrOut, "Making %s a replica of %s\n", m.ID, target.ID)
+		if err := updateRole(ctx, m, flymysql.RoleReplica); err != nil {
+			return err
+		}
+		if err := client.ReplicateFrom(ctx, m, primaryHost); err != nil {
+			return fmt.Errorf("failed to make %s a replica: %w", m.ID, err)
+		}
+	}
+
+	if force {
+		fmt.Fprintf(io.Out, "Machine %s is the new primary. Destroy the old primary %s, or rejoin it once reachable with another failover\n", target.ID, primary.ID)
+	} else {
+		fmt.Fprintf(io.Out, "Machine %s is the new primary\n", target.ID)
+	}
+
+	return nil
+}
+
+// pickReplica returns the replica with the given id or, when id is empty, the
+// one that is the least behind the primary.
+func pickReplica(ctx context.Context, client *flymysql.Client, replicas []*fly.Machine, id string) (*fly.Machine, error) {
+	if id != "" {
+		for _, m := range replicas {
+			if m.ID == id {
+				return m, nil
+			}
+		}
+		return nil, fmt.Errorf("machine %s is not a replica", id)
+	}
+
+	var (
+		best    *fly.Machine
+		bestLag int
+	)
+	for _, m := range replicas {
+		lag, err := client.ReplicationLag(ctx, m)
+		if err != nil || lag < 0 {
+			continue
+		}
+		if best == nil || lag < bestLag {
+			best, bestLag = m, lag
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no replica is replicating, pick one with --machine")
+	}
+	return best, nil
+}
+
+// followers returns the machines to replicate from the new primary target:
+// the other replicas and, unless it is assumed lost, the old primary.
+func followers(primary *fly.Machine, replicas []*fly.Machine, target *fly.Machine, force bool) []*fly.Machine {
+	followers := make([]*fly.Machine, 0, len(replicas))
+	if !force {
+		followers = append(followers, primary)
+	}
+	for _, m := range replicas {
+		if m.ID != target.ID {
+			followers = append(followers, m)
+		}
+	}
+	return followers
+}
+
+// updateRole updates the configuration of m for role, restarting it.
+func updateRole(ctx context.Context, m *fly.Machine, role string) error {
+	conf := mach.CloneConfig(m.Config)
+	flymysql.SetRole(conf, role)
+
+	return mach.Update(ctx, m, &fly.LaunchMachineInput{
+		Region: m.Region,
+		Config: conf,
+	})
+}
@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// listConcurrency is how many apps are inspected at once.
+const listConcurrency = 8
+
+func newList() *cobra.Command {
+	const (
+		short = "List MySQL and MariaDB clusters"
+		long  = short + "\n"
+
+		usage = "list"
+	)
+
+	cmd := command.New(usage, short, long, runList,
+		command.RequireSession,
+	)
+
+	flag.Add(cmd, flag.JSONOutput(), flag.Org())
+	cmd.Aliases = []string{"ls"}
+	return cmd
+}
+
+type cluster struct {
+	Name     string `json:"name"`
+	Owner    string `json:"owner"`
+	Flavor   string `json:"flavor"`
+	Status   string `json:"status"`
+	Machines int    `json:"machines"`
+}
+
+func runList(ctx context.Context) error {
+	var (
+		client = fly.ClientFromContext(ctx)
+		io     = iostreams.FromContext(ctx)
+		cfg    = config.FromContext(ctx)
+		org    = flag.GetOrg(ctx)
+	)
+
+	apps, err := client.GetApps(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	// MySQL apps are only told apart by the metadata of their machines.
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		sem      = make(chan struct{}, listConcurrency)
+		clusters []cluster
+	)
+	for _, app := range apps {
+		if org != "" && app.Organization.Slug != org {
+			continue
+		}
+
+		app := app
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: app.Name})
+			if err != nil {
+				return
+			}
+			machines, err := flapsClient.List(ctx, "")
+			if err != nil || len(machines) == 0 || !flymysql.IsManaged(machines[0]) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			clusters = append(clusters, cluster{
+				Name:     app.Name,
+				Owner:    app.Organization.Slug,
+				Flavor:   flymysql.Flavor(machines[0]),
+				Status:   app.Status,
+				Machines: len(machines),
+			})
+		}()
+	}
+	wg.Wait()
+
+	if len(clusters) == 0 {
+		fmt.Fprintln(io.Out, "No MySQL clusters found")
+		return nil
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, clusters)
+	}
+
+	rows := make([][]string, 0, len(clusters))
+	for _, c := range clusters {
+		rows = append(rows, []string{
+			c.Name,
+			c.Owner,
+			c.Flavor,
+			c.Status,
+			fmt.Sprint(c.Machines),
+		})
+	}
+
+	return render.Table(io.Out, "", rows, "Name", "Owner", "Flavor", "Status", "Machines")
+}
@@ -0,0 +1,80 @@
+// Package mysql implements the mysql command chain, which provisions and
+// operates MySQL and MariaDB clusters on machines.
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/command/apps"
+	mach "github.com/superfly/flyctl/internal/machine"
+)
+
+func New() *cobra.Command {
+	const (
+		short = `Manage MySQL and MariaDB clusters.`
+
+		long = short + `
+Clusters run the official MySQL or MariaDB images on machines with volumes.
+MariaDB clusters can have replicas to fail over to.
+`
+	)
+
+	cmd := command.New("mysql", short, long, nil)
+
+	cmd.Aliases = []string{"mariadb"}
+
+	cmd.AddCommand(
+		newCreate(),
+		newList(),
+		newStatus(),
+		newAttach(),
+		newDetach(),
+		newConnect(),
+		newBackup(),
+		newFailover(),
+	)
+
+	return cmd
+}
+
+// loadCluster returns ctx set up to operate on the MySQL app appName, along
+// with the app and its primary and replica machines.
+func loadCluster(ctx context.Context, appName string) (context.Context, *fly.AppCompact, *fly.Machine, []*fly.Machine, error) {
+	client := fly.ClientFromContext(ctx)
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed retrieving app %s: %w", appName, err)
+	}
+
+	ctx, err = apps.BuildContext(ctx, app)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	machines, err := mach.ListActive(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("machines could not be retrieved %w", err)
+	}
+
+	if len(machines) == 0 || !flymysql.IsManaged(machines[0]) {
+		return nil, nil, nil, nil, fmt.Errorf("app %s is not a MySQL app", appName)
+	}
+
+	primary, replicas, err := flymysql.Primary(machines)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("app %s: %w", appName, err)
+	}
+
+	return ctx, app, primary, replicas, nil
+}
+
+func newClient(ctx context.Context) *flymysql.Client {
+	return flymysql.NewClient(flaps.FromContext(ctx))
+}
@@ -0,0 +1,92 @@
+package mysql
+
+import (
+	"context"
+	"encoding/base64"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/flymysql"
+)
+
+var sqlRegexp = regexp.MustCompile(`^sh -c 'echo (\S+) \| base64 -d`)
+
+// fakeReplicas answers the replication status queries of replicas with the
+// given lags, where a negative lag is a replica that isn't replicating.
+type fakeReplicas map[string]int
+
+func (f fakeReplicas) Exec(ctx context.Context, machineID string, in *fly.MachineExecRequest) (*fly.MachineExecResponse, error) {
+	lag := f[machineID]
+	if m := sqlRegexp.FindStringSubmatch(in.Cmd); m != nil {
+		sql, err := base64.StdEncoding.DecodeString(m[1])
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(string(sql), "SLAVE_RUNNING") && lag >= 0 {
+			return &fly.MachineExecResponse{StdOut: "ON\n"}, nil
+		}
+		return &fly.MachineExecResponse{StdOut: "OFF\n"}, nil
+	}
+	if strings.Contains(in.Cmd, "SHOW REPLICA STATUS") {
+		return &fly.MachineExecResponse{StdOut: strconv.Itoa(lag) + "\n"}, nil
+	}
+	return &fly.MachineExecResponse{}, nil
+}
+
+func TestPickReplica(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		replicas = []*fly.Machine{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+		client   = flymysql.NewClient(fakeReplicas{"a": 12, "b": -1, "c": 3})
+	)
+
+	m, err := pickReplica(ctx, client, replicas, "")
+	require.NoError(t, err)
+	assert.Equal(t, "c", m.ID, "the replica with the least lag")
+
+	m, err = pickReplica(ctx, client, replicas, "b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", m.ID, "the given replica, even when not replicating")
+
+	_, err = pickReplica(ctx, client, replicas, "z")
+	assert.ErrorContains(t, err, "is not a replica")
+
+	_, err = pickReplica(ctx, flymysql.NewClient(fakeReplicas{"a": -1, "b": -1, "c": -1}), replicas, "")
+	assert.ErrorContains(t, err, "--machine")
+}
+
+func TestFollowers(t *testing.T) {
+	var (
+		primary  = &fly.Machine{ID: "p"}
+		replicas = []*fly.Machine{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+		ids      = func(machines []*fly.Machine) (ids []string) {
+			for _, m := range machines {
+				ids = append(ids, m.ID)
+			}
+			return ids
+		}
+	)
+
+	assert.Equal(t, []string{"p", "a", "c"}, ids(followers(primary, replicas, replicas[1], false)))
+	assert.Equal(t, []string{"a", "c"}, ids(followers(primary, replicas, replicas[1], true)), "a lost primary doesn't follow")
+}
+
+func TestAttachNames(t *testing.T) {
+	db, user, err := attachNames("my-app", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "my_app", db)
+	assert.Equal(t, "my_app", user)
+
+	db, user, err = attachNames("my-app", "shop", "shopper")
+	require.NoError(t, err)
+	assert.Equal(t, "shop", db)
+	assert.Equal(t, "shopper", user)
+
+	_, _, err = attachNames("my-app", "shop;drop", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/flymysql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newStatus() *cobra.Command {
+	const (
+		short = "Show the machines of a cluster, their roles and replication lag"
+		long  = short + "\n"
+
+		usage = "status"
+	)
+
+	cmd := command.New(usage, short, long, runStatus,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+
+	return cmd
+}
+
+type member struct {
+	ID     string `json:"id"`
+	Role   string `json:"role"`
+	Region string `json:"region"`
+	State  string `json:"state"`
+	// Lag is how many seconds a replica is behind, -1 when it isn't
+	// replicating.
+	Lag *int `json:"lag,omitempty"`
+}
+
+func runStatus(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		cfg     = config.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	ctx, _, primary, replicas, err := loadCluster(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	client := newClient(ctx)
+
+	members := []member{{
+		ID:     primary.ID,
+		Role:   flymysql.RolePrimary,
+		Region: primary.Region,
+		State:  primary.State,
+	}}
+	for _, m := range replicas {
+		lag, err := client.ReplicationLag(ctx, m)
+		if err != nil {
+			return fmt.Errorf("failed to get the replication lag of machine %s: %w", m.ID, err)
+		}
+		members = append(members, member{
+			ID:     m.ID,
+			Role:   flymysql.RoleReplica,
+			Region: m.Region,
+			State:  m.State,
+			Lag:    &lag,
+		})
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, members)
+	}
+
+	rows := make([][]string, 0, len(members))
+	for _, m := range members {
+		lag := ""
+		switch {
+		case m.Lag == nil:
+		case *m.Lag < 0:
+			lag = "not replicating"
+		default:
+			lag = fmt.Sprintf("%ds", *m.Lag)
+		}
+		rows = append(rows, []string{m.ID, m.Role, m.Region, m.State, lag})
+	}
+
+	return render.Table(io.Out, appName, rows, "ID", "Role", "Region", "State", "Lag")
+}
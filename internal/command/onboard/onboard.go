@@ -0,0 +1,218 @@
+// Package onboard implements the init command, which sets flyctl up for new
+// users.
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// helloWorldImage is the image deployed to try things out.
+const helloWorldImage = "flyio/hello-fly:latest"
+
+func New() *cobra.Command {
+	const (
+		short = "Set up flyctl for first use"
+		long  = `Walk through setting up flyctl: log in, pick the organization and region
+new apps go to by default, choose whether to show hints about next steps,
+and optionally deploy a hello-world app to try things out.
+
+The defaults are saved to the flyctl config file and are used whenever a
+command needs an organization or region and none is given. Run fly init
+again to change them.
+`
+	)
+
+	cmd := command.New("init", short, long, run,
+		command.RequireSession,
+	)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.Region(),
+		flag.Bool{
+			Name:        "hello-world",
+			Description: "Deploy a hello-world app once set up, without prompting",
+		},
+		flag.Bool{
+			Name:        "no-hints",
+			Description: "Turn off hints about next steps, without prompting",
+		},
+	)
+
+	return cmd
+}
+
+func run(ctx context.Context) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		client   = fly.ClientFromContext(ctx)
+		colorize = io.ColorScheme()
+		path     = state.ConfigFile(ctx)
+	)
+
+	user, err := client.GetCurrentUser(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving current user: %w", err)
+	}
+	fmt.Fprintf(io.Out, "%s Logged in as %s\n", colorize.SuccessIcon(), colorize.Bold(user.Email))
+
+	org, err := selectOrg(ctx)
+	if err != nil {
+		return err
+	}
+
+	region, err := selectRegion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := config.SetDefaults(path, org.Slug, region.Code); err != nil {
+		return fmt.Errorf("failed persisting %s and %s in %s: %w\n",
+			config.DefaultOrgFileKey, config.DefaultRegionFileKey, path, err)
+	}
+	fmt.Fprintf(io.Out, "%s New apps will go to the %s organization, in %s (%s)\n",
+		colorize.SuccessIcon(), colorize.Bold(org.Slug), region.Name, colorize.Bold(region.Code))
+
+	hints, err := confirmHints(ctx)
+	if err != nil {
+		return err
+	}
+	if err := config.SetHints(path, hints); err != nil {
+		return fmt.Errorf("failed persisting %s in %s: %w\n", config.HintsFileKey, path, err)
+	}
+
+	deploy := flag.GetBool(ctx, "hello-world")
+	if !deploy && io.IsInteractive() {
+		if deploy, err = prompt.Confirm(ctx, "Would you like to deploy a hello-world app to try things out?"); err != nil {
+			return err
+		}
+	}
+	if deploy {
+		if err := deployHelloWorld(ctx, org, region); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(io.Out, "\n%s You're all set!\n", colorize.SuccessIcon())
+
+	return nil
+}
+
+// selectOrg returns the organization given via --org, or prompts for one
+// even when a default organization was configured before.
+func selectOrg(ctx context.Context) (*fly.Organization, error) {
+	if flag.IsSpecified(ctx, flagnames.Org) {
+		return prompt.Org(ctx)
+	}
+
+	orgs, err := fly.ClientFromContext(ctx).GetOrganizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(orgs) {
+	case 0:
+		return nil, fmt.Errorf("you aren't a member of any organization")
+	case 1:
+		return &orgs[0], nil
+	}
+
+	org, err := prompt.SelectOrg(ctx, orgs)
+	if prompt.IsNonInteractive(err) {
+		return nil, prompt.NonInteractiveError("org must be specified with --org when not running interactively")
+	}
+	return org, err
+}
+
+// selectRegion returns the region given via --region, or prompts for one
+// suggesting the current default.
+func selectRegion(ctx context.Context) (*fly.Region, error) {
+	if flag.IsSpecified(ctx, flagnames.Region) {
+		return prompt.Region(ctx, false, prompt.RegionParams{})
+	}
+
+	info, err := prompt.PlatformRegions(ctx).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	def := config.FromContext(ctx).Region
+	if def == "" && info.DefaultRegion != nil {
+		def = info.DefaultRegion.Code
+	}
+
+	region, err := prompt.SelectRegion(ctx, "Select the default region for new apps:", nil, info.Regions, def)
+	if prompt.IsNonInteractive(err) {
+		return nil, prompt.NonInteractiveError("region must be specified with --region when not running interactively")
+	}
+	return region, err
+}
+
+func confirmHints(ctx context.Context) (bool, error) {
+	switch {
+	case flag.GetBool(ctx, "no-hints"):
+		return false, nil
+	case !iostreams.FromContext(ctx).IsInteractive():
+		return config.FromContext(ctx).Hints, nil
+	default:
+		return prompt.Confirm(ctx, "Would you like hints about next steps after commands such as fly launch?")
+	}
+}
+
+// deployHelloWorld launches the hello-world image from a scratch directory,
+// by running fly launch attached to the terminal.
+func deployHelloWorld(ctx context.Context, org *fly.Organization, region *fly.Region) error {
+	io := iostreams.FromContext(ctx)
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find flyctl: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "hello-fly")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{
+		"launch",
+		"--image", helloWorldImage,
+		"--internal-port", "8080",
+		"--org", org.Slug,
+		"--region", region.Code,
+		"--generate-name",
+		"--ha=false",
+		"--now",
+	}
+	fmt.Fprintf(io.ErrOut, "\n$ fly %s\n", strings.Join(args, " "))
+
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Dir = dir
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = io.In, io.Out, io.ErrOut
+	cmd.Env = append(os.Environ(), "FLY_NO_HINTS=1")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed deploying the hello-world app: %w", err)
+	}
+
+	fmt.Fprintln(io.Out, "\nRun `fly apps list` to find the app, and `fly apps destroy` to remove it once you're done.")
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package orgs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/iostreams"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+)
+
+func newApps() *cobra.Command {
+	const (
+		long  = `Commands for managing the apps of an organization.`
+		short = "Manage the apps of an organization"
+	)
+
+	cmd := command.New("apps", short, long, nil)
+
+	cmd.AddCommand(
+		newAppsPrune(),
+	)
+
+	return cmd
+}
+
+func newAppsPrune() *cobra.Command {
+	const (
+		long = `Find the abandoned apps of an organization and destroy them.
+
+Apps are reported when they haven't been deployed for --days days and have
+no running machines. Whether their last release failed is shown too. When
+running interactively, pick the apps to destroy from the report, or pass
+--yes to pick all of them; the apps are listed again and destroying them
+is always confirmed. Otherwise, only the report is printed.
+`
+		short = "Find and destroy abandoned apps"
+	)
+
+	cmd := command.New("prune", short, long, runAppsPrune,
+		command.RequireSession,
+	)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.Org(),
+		flag.JSONOutput(),
+		flag.Yes(),
+		flag.Int{
+			Name:        "days",
+			Description: "Report apps which haven't been deployed for this many days",
+			Default:     90,
+		},
+	)
+
+	return cmd
+}
+
+// abandonedApp is an app of the organization reported by apps prune.
+type abandonedApp struct {
+	Name            string    `json:"name"`
+	Status          string    `json:"status"`
+	LastDeploy      time.Time `json:"last_deploy,omitempty"`
+	RunningMachines int       `json:"running_machines"`
+	Reasons         []string  `json:"reasons"`
+}
+
+func runAppsPrune(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+		days   = flag.GetInt(ctx, "days")
+	)
+
+	if days <= 0 {
+		return fmt.Errorf("--days must be positive")
+	}
+
+	org, err := orgFromFlagOrSelect(ctx)
+	if err != nil {
+		return err
+	}
+
+	apps, err := listOrgApps(ctx, client, org.Slug)
+	if err != nil {
+		return err
+	}
+
+	abandoned := findAbandonedApps(apps, time.Now(), time.Duration(days)*24*time.Hour)
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, abandoned)
+	}
+
+	if len(abandoned) == 0 {
+		fmt.Fprintf(io.Out, "No abandoned apps in %s\n", org.Slug)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(abandoned))
+	for _, app := range abandoned {
+		lastDeploy := "never"
+		if !app.LastDeploy.IsZero() {
+			lastDeploy = format.RelativeTime(app.LastDeploy)
+		}
+		rows = append(rows, []string{
+			app.Name,
+			app.Status,
+			lastDeploy,
+			fmt.Sprint(app.RunningMachines),
+			strings.Join(app.Reasons, ", "),
+		})
+	}
+	if err := render.Table(io.Out, "Abandoned apps of "+org.Slug, rows, "Name", "Status", "Last Deploy", "Running", "Reasons"); err != nil {
+		return err
+	}
+
+	selected, err := selectAppsToDestroy(ctx, abandoned)
+	if err != nil || len(selected) == 0 {
+		return err
+	}
+
+	for _, app := range selected {
+		if err := client.DeleteApp(ctx, app.Name); err != nil {
+			return fmt.Errorf("failed destroying app %s: %w", app.Name, err)
+		}
+		fmt.Fprintf(io.Out, "Destroyed app %s\n", app.Name)
+	}
+
+	return nil
+}
+
+func orgFromFlagOrSelect(ctx context.Context) (*fly.Organization, error) {
+	if slug := flag.GetOrg(ctx); slug != "" {
+		return fly.ClientFromContext(ctx).GetOrganizationBySlug(ctx, slug)
+	}
+	return prompt.Org(ctx)
+}
+
+// selectAppsToDestroy returns the abandoned apps the user picks, or all of
+// them with --yes, once the user confirms destroying them.
+func selectAppsToDestroy(ctx context.Context, abandoned []abandonedApp) ([]abandonedApp, error) {
+	io := iostreams.FromContext(ctx)
+
+	if !io.IsInteractive() {
+		fmt.Fprintln(io.ErrOut, "Run interactively to pick the apps to destroy and confirm destroying them.")
+		return nil, nil
+	}
+
+	selected := abandoned
+	if !flag.GetYes(ctx) {
+		names := make([]string, 0, len(abandoned))
+		for _, app := range abandoned {
+			names = append(names, app.Name)
+		}
+
+		var indices []int
+		if err := prompt.MultiSelect(ctx, &indices, "Select the apps to destroy:", nil, names...); err != nil {
+			return nil, err
+		}
+		if len(indices) == 0 {
+			return nil, nil
+		}
+
+		selected = make([]abandonedApp, 0, len(indices))
+		for _, i := range indices {
+			selected = append(selected, abandoned[i])
+		}
+	}
+
+	fmt.Fprintln(io.ErrOut, "Apps to destroy:")
+	for _, app := range selected {
+		fmt.Fprintf(io.ErrOut, "  %s\n", app.Name)
+	}
+	fmt.Fprintln(io.ErrOut, io.ColorScheme().Red("Destroying apps is not reversible."))
+	confirmed, err := prompt.Confirmf(ctx, "Destroy %d apps?", len(selected))
+	if err != nil || !confirmed {
+		return nil, err
+	}
+	return selected, nil
+}
+
+type orgApp = gql.OrgAppsForPruneOrganizationAppsAppConnectionNodesApp
+
+func listOrgApps(ctx context.Context, client *fly.Client, slug string) ([]orgApp, error) {
+	_ = `# @genqlient
+	query OrgAppsForPrune(
+		$slug: String!,
+		# @genqlient(omitempty: true)
+		$after: String,
+	) {
+		organization(slug: $slug) {
+			apps(first: 100, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					name
+					status
+					# @genqlient(pointer: true)
+					currentRelease {
+						status
+						createdAt
+					}
+					machines(first: 100) {
+						nodes {
+							state
+						}
+					}
+				}
+			}
+		}
+	}
+	`
+
+	var (
+		apps  []orgApp
+		after string
+	)
+	for {
+		resp, err := gql.OrgAppsForPrune(ctx, client.GenqClient, slug, after)
+		if err != nil {
+			return nil, fmt.Errorf("failed listing apps of %s: %w", slug, err)
+		}
+		apps = append(apps, resp.Organization.Apps.Nodes...)
+
+		page := resp.Organization.Apps.PageInfo
+		if !page.HasNextPage {
+			return apps, nil
+		}
+		after = page.EndCursor
+	}
+}
+
+// findAbandonedApps returns the apps which weren't deployed for maxAge and
+// have no running machines. Either alone isn't enough: apps can run for
+// months without a deploy, and apps whose machines autostop have none running
+// most of the time.
+func findAbandonedApps(apps []orgApp, now time.Time, maxAge time.Duration) []abandonedApp {
+	var abandoned []abandonedApp
+	for _, app := range apps {
+		a := abandonedApp{Name: app.Name, Status: app.Status}
+		for _, m := range app.Machines.Nodes {
+			if m.State == fly.MachineStateStarted {
+				a.RunningMachines++
+			}
+		}
+
+		release := app.CurrentRelease
+		if release != nil {
+			a.LastDeploy = release.CreatedAt
+		}
+
+		if release != nil && now.Sub(release.CreatedAt) <= maxAge {
+			continue
+		}
+		if a.RunningMachines > 0 {
+			continue
+		}
+
+		a.Reasons = []string{fmt.Sprintf("no deploy in %d days", int(maxAge.Hours()/24)), "no running machines"}
+		if (release != nil && release.Status == "failed") || app.Status == "dead" {
+			a.Reasons = append(a.Reasons, "failed")
+		}
+		abandoned = append(abandoned, a)
+	}
+
+	sort.Slice(abandoned, func(i, j int) bool { return abandoned[i].Name < abandoned[j].Name })
+	return abandoned
+}
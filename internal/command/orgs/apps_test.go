@@ -0,0 +1,109 @@
+package orgs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+type orgAppMachine = gql.OrgAppsForPruneOrganizationAppsAppConnectionNodesAppMachinesMachineConnectionNodesMachine
+
+func testOrgApp(name, status string, deployed time.Time, releaseStatus string, states ...string) orgApp {
+	app := orgApp{Name: name, Status: status}
+	if !deployed.IsZero() {
+		app.CurrentRelease = &gql.OrgAppsForPruneOrganizationAppsAppConnectionNodesAppCurrentRelease{
+			Status:    releaseStatus,
+			CreatedAt: deployed,
+		}
+	}
+	for _, state := range states {
+		app.Machines.Nodes = append(app.Machines.Nodes, orgAppMachine{State: state})
+	}
+	return app
+}
+
+func TestFindAbandonedApps(t *testing.T) {
+	var (
+		now    = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+		maxAge = 90 * 24 * time.Hour
+		recent = now.Add(-24 * time.Hour)
+		old    = now.Add(-100 * 24 * time.Hour)
+	)
+
+	testcases := []struct {
+		name    string
+		app     orgApp
+		reasons []string
+	}{
+		{
+			name: "recent deploy without running machines",
+			app:  testOrgApp("autostopped", "suspended", recent, "complete", fly.MachineStateStopped),
+		},
+		{
+			name: "old deploy with running machines",
+			app:  testOrgApp("stable", "deployed", old, "complete", fly.MachineStateStarted, fly.MachineStateStopped),
+		},
+		{
+			name: "recent failed deploy",
+			app:  testOrgApp("broken", "deployed", recent, "failed", fly.MachineStateStopped),
+		},
+		{
+			name:    "old deploy without running machines",
+			app:     testOrgApp("old", "suspended", old, "complete", fly.MachineStateStopped),
+			reasons: []string{"no deploy in 90 days", "no running machines"},
+		},
+		{
+			name:    "never deployed",
+			app:     testOrgApp("empty", "pending", time.Time{}, ""),
+			reasons: []string{"no deploy in 90 days", "no running machines"},
+		},
+		{
+			name:    "old failed deploy",
+			app:     testOrgApp("dead", "deployed", old, "failed", fly.MachineStateStopped),
+			reasons: []string{"no deploy in 90 days", "no running machines", "failed"},
+		},
+		{
+			name:    "dead app",
+			app:     testOrgApp("dead", "dead", old, "complete"),
+			reasons: []string{"no deploy in 90 days", "no running machines", "failed"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			abandoned := findAbandonedApps([]orgApp{tc.app}, now, maxAge)
+			if tc.reasons == nil {
+				assert.Empty(t, abandoned)
+				return
+			}
+			require.Len(t, abandoned, 1)
+			assert.Equal(t, tc.app.Name, abandoned[0].Name)
+			assert.Equal(t, tc.reasons, abandoned[0].Reasons)
+		})
+	}
+}
+
+func TestSelectAppsToDestroyNonInteractive(t *testing.T) {
+	abandoned := []abandonedApp{{Name: "a"}, {Name: "b"}}
+
+	for _, yes := range []bool{false, true} {
+		fs := pflag.NewFlagSet("prune", pflag.ContinueOnError)
+		fs.Bool("yes", yes, "")
+		ios, _, _, errOut := iostreams.Test()
+		ctx := flag.NewContext(iostreams.NewContext(context.Background(), ios), fs)
+
+		// Nothing is destroyed without a confirmation, even with --yes.
+		selected, err := selectAppsToDestroy(ctx, abandoned)
+		require.NoError(t, err)
+		assert.Empty(t, selected)
+		assert.Contains(t, errOut.String(), "Run interactively")
+	}
+}
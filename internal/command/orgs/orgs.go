@@ -37,6 +37,8 @@ Organization admins can also invite or remove users from Organizations.
 		newRemove(),
 		newCreate(),
 		newDelete(),
+		newUsage(),
+		newApps(),
 	)
 
 	return orgs
@@ -0,0 +1,331 @@
+package orgs
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newUsage() *cobra.Command {
+	const (
+		long = `Shows the usage of an organization over a month, broken down by
+app: compute time, requests and outbound data from the app's usage samples,
+the size of its volumes and its extensions with their monthly price. Use
+--json or --csv to feed it to other tools.
+
+The platform only keeps recent usage samples, and the report says which part
+of the month they cover. Volumes and extensions are those the apps have now,
+not those they had over the month.
+`
+		short = "Show the usage of an organization per app"
+		usage = "usage [slug]"
+	)
+
+	cmd := command.New(usage, short, long, runUsage,
+		command.RequireSession,
+	)
+
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(cmd,
+		flag.JSONOutput(),
+		flag.String{
+			Name:        "month",
+			Description: "Month to report, as YYYY-MM, defaults to the current month",
+		},
+		flag.Bool{
+			Name:        "csv",
+			Description: "Output CSV",
+		},
+	)
+	return cmd
+}
+
+// usagePageSize is how many apps or extensions are fetched per request.
+const usagePageSize = 100
+
+func fetchUsageApps(ctx context.Context, client graphql.Client, slug string) ([]gql.OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp, error) {
+	_ = `# @genqlient
+	query OrganizationUsageApps($slug: String!, $first: Int!, $after: String) {
+		organization(slug: $slug) {
+			apps(first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					name
+					usage {
+						ts
+						interval
+						requestsCount
+						totalAppExecS
+						totalDataOutGB
+					}
+					volumes {
+						nodes {
+							sizeGb
+						}
+					}
+				}
+			}
+		}
+	}
+	`
+
+	var (
+		apps  []gql.OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp
+		after string
+	)
+	for {
+		resp, err := gql.OrganizationUsageApps(ctx, client, slug, usagePageSize, after)
+		if err != nil {
+			return nil, err
+		}
+		page := resp.Organization.Apps
+		apps = append(apps, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return apps, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+func fetchUsageAddOns(ctx context.Context, client graphql.Client, slug string) ([]gql.OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn, error) {
+	_ = `# @genqlient
+	query OrganizationUsageAddOns($slug: String!, $first: Int!, $after: String) {
+		organization(slug: $slug) {
+			addOns(first: $first, after: $after) {
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+				nodes {
+					name
+					addOnProvider {
+						name
+					}
+					addOnPlan {
+						displayName
+						pricePerMonth
+					}
+					app {
+						name
+					}
+				}
+			}
+		}
+	}
+	`
+
+	var (
+		addOns []gql.OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn
+		after  string
+	)
+	for {
+		resp, err := gql.OrganizationUsageAddOns(ctx, client, slug, usagePageSize, after)
+		if err != nil {
+			return nil, err
+		}
+		page := resp.Organization.AddOns
+		addOns = append(addOns, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return addOns, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+// coverage is the span of the usage samples counted in a report.
+type coverage struct {
+	first, last time.Time
+}
+
+// summarizeUsage returns the usage of apps between from and to, sorted by
+// app, and the span the usage samples counted cover.
+func summarizeUsage(apps []gql.OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp, addOns []gql.OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn, from, to time.Time) ([]appUsage, coverage) {
+	var covered coverage
+	usages := map[string]*appUsage{}
+	for _, app := range apps {
+		u := &appUsage{App: app.Name, Extensions: []string{}}
+		for _, s := range app.Usage {
+			if s.Ts.Before(from) || !s.Ts.Before(to) {
+				continue
+			}
+			u.ComputeSeconds += s.TotalAppExecS
+			u.Requests += s.RequestsCount
+			u.DataOutGB += s.TotalDataOutGB
+
+			end := s.Ts
+			if d, err := time.ParseDuration(s.Interval); err == nil {
+				end = end.Add(d)
+			}
+			if covered.first.IsZero() || s.Ts.Before(covered.first) {
+				covered.first = s.Ts
+			}
+			if end.After(covered.last) {
+				covered.last = end
+			}
+		}
+		for _, v := range app.Volumes.Nodes {
+			u.VolumesGB += v.SizeGb
+		}
+		usages[app.Name] = u
+	}
+
+	for _, ext := range addOns {
+		u, ok := usages[ext.App.Name]
+		if ext.App.Name == "" || !ok {
+			continue
+		}
+		name := ext.AddOnProvider.Name + "/" + ext.Name
+		if ext.AddOnPlan.DisplayName != "" {
+			name += " (" + ext.AddOnPlan.DisplayName + ")"
+		}
+		u.Extensions = append(u.Extensions, name)
+		u.ExtensionsCents += ext.AddOnPlan.PricePerMonth
+	}
+
+	report := make([]appUsage, 0, len(usages))
+	for _, u := range usages {
+		report = append(report, *u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].App < report[j].App
+	})
+	return report, covered
+}
+
+// appUsage is the usage of an app over a month.
+type appUsage struct {
+	App            string   `json:"app"`
+	ComputeSeconds int      `json:"compute_seconds"`
+	Requests       int      `json:"requests"`
+	DataOutGB      float64  `json:"data_out_gb"`
+	VolumesGB      int      `json:"volumes_gb"`
+	Extensions     []string `json:"extensions"`
+	// ExtensionsCents is the monthly price of the extensions, in cents.
+	ExtensionsCents int `json:"extensions_cents"`
+}
+
+func runUsage(ctx context.Context) error {
+	var (
+		io     = iostreams.FromContext(ctx)
+		client = fly.ClientFromContext(ctx)
+	)
+
+	if flag.GetBool(ctx, "csv") && flag.GetBool(ctx, "json") {
+		return fmt.Errorf("--csv and --json can't be used together")
+	}
+
+	from, to, err := parseMonth(flag.GetString(ctx, "month"), time.Now())
+	if err != nil {
+		return err
+	}
+
+	org, err := OrgFromEnvVarOrFirstArgOrSelect(ctx)
+	if err != nil {
+		return err
+	}
+
+	apps, err := fetchUsageApps(ctx, client.GenqClient, org.Slug)
+	if err != nil {
+		return fmt.Errorf("failed retrieving usage of %s: %w", org.Slug, err)
+	}
+	addOns, err := fetchUsageAddOns(ctx, client.GenqClient, org.Slug)
+	if err != nil {
+		return fmt.Errorf("failed retrieving extensions of %s: %w", org.Slug, err)
+	}
+
+	report, covered := summarizeUsage(apps, addOns, from, to)
+	if covered.first.IsZero() {
+		return fmt.Errorf("no usage samples for %s, only recent usage is kept", from.Format("January 2006"))
+	}
+	if covered.first.After(from) {
+		fmt.Fprintf(io.ErrOut, "Usage samples only go back to %s, earlier usage isn't counted\n", covered.first.Format(time.DateTime))
+	}
+
+	switch {
+	case config.FromContext(ctx).JSONOutput:
+		return render.JSON(io.Out, report)
+	case flag.GetBool(ctx, "csv"):
+		return writeUsageCSV(io.Out, report)
+	}
+
+	var total appUsage
+	rows := make([][]string, 0, len(report)+1)
+	for _, u := range report {
+		rows = append(rows, usageRow(u))
+		total.ComputeSeconds += u.ComputeSeconds
+		total.Requests += u.Requests
+		total.DataOutGB += u.DataOutGB
+		total.VolumesGB += u.VolumesGB
+		total.ExtensionsCents += u.ExtensionsCents
+	}
+	total.App = "Total"
+	rows = append(rows, usageRow(total))
+
+	title := fmt.Sprintf("Usage of %s in %s", org.Slug, from.Format("January 2006"))
+	return render.Table(io.Out, title, rows, "App", "Compute Hours", "Requests", "Data Out (GB)", "Volumes (GB)", "Extensions", "Extensions / Month")
+}
+
+func usageRow(u appUsage) []string {
+	return []string{
+		u.App,
+		fmt.Sprintf("%.1f", float64(u.ComputeSeconds)/3600),
+		strconv.Itoa(u.Requests),
+		fmt.Sprintf("%.2f", u.DataOutGB),
+		strconv.Itoa(u.VolumesGB),
+		strings.Join(u.Extensions, ", "),
+		fmt.Sprintf("$%.2f", float64(u.ExtensionsCents)/100),
+	}
+}
+
+func writeUsageCSV(out interface{ Write([]byte) (int, error) }, report []appUsage) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"app", "compute_seconds", "requests", "data_out_gb", "volumes_gb", "extensions", "extensions_cents"}); err != nil {
+		return err
+	}
+	for _, u := range report {
+		if err := w.Write([]string{
+			u.App,
+			strconv.Itoa(u.ComputeSeconds),
+			strconv.Itoa(u.Requests),
+			strconv.FormatFloat(u.DataOutGB, 'f', -1, 64),
+			strconv.Itoa(u.VolumesGB),
+			strings.Join(u.Extensions, ";"),
+			strconv.Itoa(u.ExtensionsCents),
+		}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// parseMonth returns the bounds of month, given as YYYY-MM, or of the month of
+// now when it's empty.
+func parseMonth(month string, now time.Time) (from, to time.Time, err error) {
+	if month == "" {
+		now = now.UTC()
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	} else if from, err = time.Parse("2006-01", month); err != nil {
+		return from, to, fmt.Errorf("invalid month %q, expected YYYY-MM", month)
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
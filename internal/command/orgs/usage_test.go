@@ -0,0 +1,101 @@
+package orgs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+func TestParseMonth(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	from, to, err := parseMonth("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), to)
+
+	from, to, err = parseMonth("2023-12", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), to)
+
+	_, _, err = parseMonth("March", now)
+	assert.Error(t, err)
+}
+
+func TestSummarizeUsage(t *testing.T) {
+	var (
+		apps   []gql.OrganizationUsageAppsOrganizationAppsAppConnectionNodesApp
+		addOns []gql.OrganizationUsageAddOnsOrganizationAddOnsAddOnConnectionNodesAddOn
+	)
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "web", "usage": [
+			{"ts": "2024-02-29T00:00:00Z", "interval": "24h", "requestsCount": 1000, "totalAppExecS": 1000, "totalDataOutGB": 1},
+			{"ts": "2024-03-02T00:00:00Z", "interval": "24h", "requestsCount": 10, "totalAppExecS": 3600, "totalDataOutGB": 0.5},
+			{"ts": "2024-03-03T00:00:00Z", "interval": "24h", "requestsCount": 20, "totalAppExecS": 3600, "totalDataOutGB": 0.25}
+		], "volumes": {"nodes": [{"sizeGb": 1}, {"sizeGb": 3}]}},
+		{"name": "db", "usage": [], "volumes": {"nodes": []}}
+	]`), &apps))
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"name": "cache", "addOnProvider": {"name": "upstash_redis"}, "addOnPlan": {"displayName": "Pay as you go", "pricePerMonth": 1000}, "app": {"name": "web"}},
+		{"name": "orphan", "addOnProvider": {"name": "tigris"}, "addOnPlan": {"pricePerMonth": 500}, "app": {"name": ""}}
+	]`), &addOns))
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	report, covered := summarizeUsage(apps, addOns, from, from.AddDate(0, 1, 0))
+
+	assert.Equal(t, []appUsage{
+		{App: "db", Extensions: []string{}},
+		{
+			App:             "web",
+			ComputeSeconds:  7200,
+			Requests:        30,
+			DataOutGB:       0.75,
+			VolumesGB:       4,
+			Extensions:      []string{"upstash_redis/cache (Pay as you go)"},
+			ExtensionsCents: 1000,
+		},
+	}, report)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), covered.first, "samples of the month don't start on its first day")
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), covered.last)
+}
+
+func TestFetchUsageApps(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				Slug  string `json:"slug"`
+				First int    `json:"first"`
+				After string `json:"after"`
+			} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "acme", req.Variables.Slug)
+		assert.Equal(t, usagePageSize, req.Variables.First)
+
+		page := 1
+		if req.Variables.After != "" {
+			page = 2
+		}
+		fmt.Fprintf(w, `{"data":{"organization":{"apps":{"pageInfo":{"hasNextPage":%t,"endCursor":"c%d"},"nodes":[{"name":"app-%d"}]}}}}`,
+			page == 1, page, page)
+	}))
+	defer srv.Close()
+
+	client := fly.NewClientFromOptions(fly.ClientOptions{BaseURL: srv.URL})
+
+	apps, err := fetchUsageApps(context.Background(), client.GenqClient, "acme")
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+	assert.Equal(t, "app-1", apps[0].Name)
+	assert.Equal(t, "app-2", apps[1].Name)
+}
@@ -0,0 +1,367 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/azazeal/pause"
+	"github.com/dustin/go-humanize"
+	"github.com/inancgumus/screen"
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// products are the managed services incidents may be specific to, keyed by
+// the word identifying them in incident reports.
+var products = []string{"postgres", "redis", "tigris", "kafka", "sentry", "supabase", "vector", "enveloop", "kubernetes", "litefs", "consul"}
+
+func NewIncidents() (cmd *cobra.Command) {
+	const (
+		short = "Show platform incidents affecting your apps"
+		long  = short + `. Unresolved incidents and maintenances of the Fly.io
+status page are shown when they affect the regions your apps run in or the
+managed services they use, or when they aren't specific to any region or
+service.
+
+With --exit-code, the command exits with status 2 when incidents or ongoing
+maintenances affect the apps, so deployments can be gated in CI.
+`
+	)
+
+	cmd = command.New("incidents", short, long, runIncidents, command.RequireSession, command.LoadAppNameIfPresent)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Org(),
+		flag.JSONOutput(),
+		flag.Bool{
+			Name:        "all",
+			Description: "Show all incidents and maintenances, whether or not they affect your apps",
+		},
+		flag.Bool{
+			Name:        "exit-code",
+			Description: "Exit with status 2 when incidents or ongoing maintenances affect the apps",
+		},
+		flag.Bool{
+			Name:        "watch",
+			Description: "Refresh the incidents continuously",
+		},
+		flag.Duration{
+			Name:        "interval",
+			Description: "The refresh interval of --watch",
+			Default:     time.Minute,
+		},
+	)
+	return
+}
+
+// statusIncident is an incident or a scheduled maintenance of the status page.
+type statusIncident struct {
+	Name           string    `json:"name"`
+	Status         string    `json:"status"`
+	Impact         string    `json:"impact"`
+	Shortlink      string    `json:"shortlink"`
+	CreatedAt      time.Time `json:"created_at"`
+	ScheduledFor   time.Time `json:"scheduled_for"`
+	ScheduledUntil time.Time `json:"scheduled_until"`
+	Components     []struct {
+		Name string `json:"name"`
+	} `json:"components"`
+	IncidentUpdates []struct {
+		Body string `json:"body"`
+	} `json:"incident_updates"`
+}
+
+// text is what the incident's relevance is decided on: its name, affected
+// components and latest update.
+func (i statusIncident) text() string {
+	parts := []string{i.Name}
+	for _, c := range i.Components {
+		parts = append(parts, c.Name)
+	}
+	if len(i.IncidentUpdates) > 0 {
+		parts = append(parts, i.IncidentUpdates[0].Body)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// incidentScope is the set of regions and managed services used by the apps.
+type incidentScope struct {
+	Regions  map[string]bool
+	Products map[string]bool
+}
+
+// affected returns the regions and services of the scope text mentions, and
+// whether the incident described by text affects the scope. Incidents that
+// mention no region and no service affect everyone.
+func (s incidentScope) affected(text string, regions []fly.Region) (matches []string, ok bool) {
+	var mentionedRegion, mentionedProduct, inRegion, inProduct bool
+
+	for _, r := range regions {
+		city, _, _ := strings.Cut(r.Name, ",")
+		codeRe := regexp.MustCompile(`\b` + regexp.QuoteMeta(strings.ToUpper(r.Code)) + `\b`)
+		if !codeRe.MatchString(text) && !(city != "" && strings.Contains(strings.ToLower(text), strings.ToLower(city))) {
+			continue
+		}
+		mentionedRegion = true
+		if s.Regions[r.Code] {
+			inRegion = true
+			matches = append(matches, r.Code)
+		}
+	}
+
+	lower := strings.ToLower(text)
+	for _, p := range products {
+		if !strings.Contains(lower, p) {
+			continue
+		}
+		mentionedProduct = true
+		if s.Products[p] {
+			inProduct = true
+			matches = append(matches, p)
+		}
+	}
+
+	ok = (!mentionedRegion || inRegion) && (!mentionedProduct || inProduct)
+	return matches, ok
+}
+
+func runIncidents(ctx context.Context) (err error) {
+	streams := iostreams.FromContext(ctx)
+
+	if !flag.GetBool(ctx, "watch") {
+		affecting, err := showIncidents(ctx, streams.Out)
+		if err != nil {
+			return err
+		}
+		if affecting > 0 && flag.GetBool(ctx, "exit-code") {
+			return flyerr.ExitCodeError{Code: flyerr.ExitCondition}
+		}
+		return nil
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return errors.New("--watch and --json are not supported together")
+	}
+	if !streams.IsInteractive() {
+		return errors.New("--watch is not supported for non-interactive sessions")
+	}
+	interval := flag.GetDuration(ctx, "interval")
+	if interval < 10*time.Second {
+		return errors.New("--interval must be at least 10s")
+	}
+
+	var buf bytes.Buffer
+	for err == nil {
+		buf.Reset()
+		if _, err = showIncidents(ctx, &buf); err != nil {
+			break
+		}
+
+		screen.Clear()
+		screen.MoveTopLeft()
+		fmt.Fprintf(streams.Out, "Incidents at: %s\n\n", streams.ColorScheme().Bold(time.Now().UTC().Format("15:04:05")))
+		io.Copy(streams.Out, &buf)
+
+		pause.For(ctx, interval)
+	}
+
+	// Interrupted with Ctrl-C
+	if errors.Is(ctx.Err(), context.Canceled) {
+		err = nil
+	}
+	return
+}
+
+// showIncidents writes the incidents affecting the apps to out, and returns
+// how many unresolved incidents and ongoing maintenances affect them.
+func showIncidents(ctx context.Context, out io.Writer) (int, error) {
+	all := flag.GetBool(ctx, "all")
+
+	var scope incidentScope
+	var regions []fly.Region
+	if !all {
+		var err error
+		if scope, err = loadIncidentScope(ctx); err != nil {
+			return 0, err
+		}
+		if regions, _, err = fly.ClientFromContext(ctx).PlatformRegions(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	var incidents struct {
+		Incidents []statusIncident `json:"incidents"`
+	}
+	if err := fetchStatus(ctx, "api/v2/incidents/unresolved.json", &incidents); err != nil {
+		return 0, err
+	}
+	var active, upcoming struct {
+		ScheduledMaintenances []statusIncident `json:"scheduled_maintenances"`
+	}
+	if err := fetchStatus(ctx, "api/v2/scheduled-maintenances/active.json", &active); err != nil {
+		return 0, err
+	}
+	if err := fetchStatus(ctx, "api/v2/scheduled-maintenances/upcoming.json", &upcoming); err != nil {
+		return 0, err
+	}
+
+	type relevant struct {
+		statusIncident
+		Kind     string   `json:"kind"`
+		Affected []string `json:"affected"`
+	}
+	var list []relevant
+	affecting := 0
+	add := func(kind string, incidents []statusIncident) {
+		for _, i := range incidents {
+			matches, ok := scope.affected(i.text(), regions)
+			if !all && !ok {
+				continue
+			}
+			if kind != "upcoming maintenance" {
+				affecting++
+			}
+			list = append(list, relevant{statusIncident: i, Kind: kind, Affected: matches})
+		}
+	}
+	add("incident", incidents.Incidents)
+	add("maintenance", active.ScheduledMaintenances)
+	add("upcoming maintenance", upcoming.ScheduledMaintenances)
+
+	if config.FromContext(ctx).JSONOutput {
+		return affecting, render.JSON(out, list)
+	}
+
+	if len(list) == 0 {
+		fmt.Fprintln(out, "No incidents or maintenances affect your apps")
+		return 0, nil
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, i := range list {
+		when := humanize.Time(i.CreatedAt)
+		if !i.ScheduledFor.IsZero() {
+			when = fmt.Sprintf("%s - %s", i.ScheduledFor.Local().Format("Jan 2 15:04"), i.ScheduledUntil.Local().Format("Jan 2 15:04"))
+		}
+		affected := strings.Join(i.Affected, ", ")
+		if affected == "" {
+			affected = "all"
+		}
+		rows = append(rows, []string{i.Kind, i.Name, i.Status, when, affected, i.Shortlink})
+	}
+	return affecting, render.Table(out, "", rows, "Kind", "Name", "Status", "When", "Affects", "Link")
+}
+
+// loadIncidentScope returns the regions and managed services used by the app,
+// the apps of the organization given with --org, or all the user's apps.
+func loadIncidentScope(ctx context.Context) (incidentScope, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	_ = `# @genqlient
+	fragment IncidentScopeApp on App {
+		role {
+			name
+		}
+		regions {
+			code
+		}
+		addOns {
+			nodes {
+				addOnProvider {
+					name
+				}
+			}
+		}
+	}
+
+	query GetIncidentScopeApp($appName: String!) {
+		app(name: $appName) {
+			...IncidentScopeApp
+		}
+	}
+
+	query GetIncidentScopeOrgApps($slug: String!) {
+		organization(slug: $slug) {
+			apps {
+				nodes {
+					...IncidentScopeApp
+				}
+			}
+		}
+	}
+
+	query GetIncidentScopeApps {
+		apps {
+			nodes {
+				...IncidentScopeApp
+			}
+		}
+	}
+	`
+
+	var apps []gql.IncidentScopeApp
+	switch appName, org := appconfig.NameFromContext(ctx), flag.GetOrg(ctx); {
+	case org != "":
+		resp, err := gql.GetIncidentScopeOrgApps(ctx, client, org)
+		if err != nil {
+			return incidentScope{}, err
+		}
+		for _, a := range resp.Organization.Apps.Nodes {
+			apps = append(apps, a.IncidentScopeApp)
+		}
+	case appName != "":
+		resp, err := gql.GetIncidentScopeApp(ctx, client, appName)
+		if err != nil {
+			return incidentScope{}, err
+		}
+		apps = append(apps, resp.App.IncidentScopeApp)
+	default:
+		resp, err := gql.GetIncidentScopeApps(ctx, client)
+		if err != nil {
+			return incidentScope{}, err
+		}
+		for _, a := range resp.Apps.Nodes {
+			apps = append(apps, a.IncidentScopeApp)
+		}
+	}
+
+	scope := incidentScope{Regions: map[string]bool{}, Products: map[string]bool{}}
+	for _, app := range apps {
+		for _, r := range app.Regions {
+			scope.Regions[r.Code] = true
+		}
+		names := []string{}
+		if app.Role != nil {
+			names = append(names, app.Role.GetName())
+		}
+		for _, addOn := range app.AddOns.Nodes {
+			names = append(names, addOn.AddOnProvider.Name)
+		}
+		for _, name := range names {
+			for _, p := range products {
+				if strings.Contains(strings.ToLower(name), p) {
+					scope.Products[p] = true
+				}
+			}
+		}
+	}
+	return scope, nil
+}
@@ -0,0 +1,40 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestIncidentScopeAffected(t *testing.T) {
+	regions := []fly.Region{
+		{Code: "ord", Name: "Chicago, Illinois (US)"},
+		{Code: "ams", Name: "Amsterdam, Netherlands"},
+	}
+	scope := incidentScope{
+		Regions:  map[string]bool{"ord": true},
+		Products: map[string]bool{"postgres": true},
+	}
+
+	cases := []struct {
+		text    string
+		ok      bool
+		matches []string
+	}{
+		{"Elevated API error rates", true, nil},
+		{"Network issues in ORD", true, []string{"ord"}},
+		{"Degraded performance in Chicago", true, []string{"ord"}},
+		{"Host failure in AMS", false, nil},
+		{"Upstash Redis unavailable", false, nil},
+		{"Postgres backups delayed in AMS", false, []string{"postgres"}},
+		{"Postgres backups delayed in ORD", true, []string{"ord", "postgres"}},
+		// Region codes only match as words
+		{"Reordering of deployments", true, nil},
+	}
+	for _, c := range cases {
+		matches, ok := scope.affected(c.text, regions)
+		assert.Equal(t, c.ok, ok, c.text)
+		assert.Equal(t, c.matches, matches, c.text)
+	}
+}
@@ -0,0 +1,316 @@
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/agent"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command/apps"
+	"github.com/superfly/flyctl/internal/command/ping"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const (
+	// latencyConcurrency is how many regions are probed at once.
+	latencyConcurrency = 4
+
+	probeTimeout = 5 * time.Second
+
+	// timestampLen is the length of the binary timestamps echo requests carry.
+	timestampLen = 15
+)
+
+// latency is the round trip time to a region or a machine. RTT is zero when
+// the target didn't answer.
+type latency struct {
+	Target string        `json:"target"`
+	Region string        `json:"region"`
+	Name   string        `json:"name,omitempty"`
+	RTT    time.Duration `json:"rtt"`
+}
+
+func runRegionsLatency(ctx context.Context, regions []fly.Region) error {
+	var (
+		io       = iostreams.FromContext(ctx)
+		colorize = io.ColorScheme()
+		client   = fly.ClientFromContext(ctx)
+		appName  = appconfig.NameFromContext(ctx)
+		samples  = flag.GetInt(ctx, "samples")
+	)
+
+	if samples < 1 {
+		samples = 1
+	}
+	if appName == "" {
+		return fmt.Errorf("--latency requires an app to measure the latency to, pass one with --app")
+	}
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("failed fetching app: %w", err)
+	}
+
+	ctx, err = apps.BuildContext(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	appMachines, err := flaps.FromContext(ctx).ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed retrieving machines: %w", err)
+	}
+
+	regions = appRegions(regions, appMachines)
+	fmt.Fprintf(io.ErrOut, "Measuring the latency to %s in %d regions...\n", app.Name, len(regions))
+	results := regionLatencies(ctx, "https://"+app.Hostname+"/", regions, samples)
+
+	var machines []latency
+	if flag.GetBool(ctx, "machines") {
+		if machines, err = machineLatencies(ctx, app, appMachines, samples); err != nil {
+			return err
+		}
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, append(results, machines...))
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, l := range results {
+		rows = append(rows, []string{l.Region, l.Name, formatRTT(l.RTT)})
+	}
+	if err := render.Table(io.Out, "Regions", rows, "Code", "Name", "RTT"); err != nil {
+		return err
+	}
+
+	if len(machines) > 0 {
+		rows = rows[:0]
+		for _, l := range machines {
+			rows = append(rows, []string{l.Target, l.Region, formatRTT(l.RTT)})
+		}
+		if err := render.Table(io.Out, "Machines", rows, "Machine", "Region", "RTT"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(io.Out, "Only the regions %s runs machines in are measured, scale it to others to compare them.\n", app.Name)
+	if len(results) > 0 && results[0].RTT > 0 {
+		fmt.Fprintf(io.Out, "The closest region is %s (%s), a good primary_region for users near you.\n",
+			colorize.Bold(results[0].Region), results[0].Name)
+	}
+
+	return nil
+}
+
+// appRegions returns the regions machines run in.
+func appRegions(regions []fly.Region, machines []*fly.Machine) []fly.Region {
+	var found []fly.Region
+	for _, region := range regions {
+		for _, m := range machines {
+			if m.Region == region.Code {
+				found = append(found, region)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// regionLatencies returns the fastest of samples round trips to url, served
+// from each of regions, sorted.
+func regionLatencies(ctx context.Context, url string, regions []fly.Region, samples int) []latency {
+	var (
+		client  = &http.Client{Timeout: probeTimeout}
+		results = make([]latency, len(regions))
+		sem     = make(chan struct{}, latencyConcurrency)
+		wg      sync.WaitGroup
+	)
+
+	for i, region := range regions {
+		i, region := i, region
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var rtts []time.Duration
+			// The first request also sets up the connection, and isn't counted.
+			for n := 0; n <= samples; n++ {
+				rtt, err := probeRegion(ctx, client, url, region.Code)
+				if err != nil {
+					break
+				}
+				if n > 0 {
+					rtts = append(rtts, rtt)
+				}
+			}
+
+			results[i] = latency{
+				Target: region.Code,
+				Region: region.Code,
+				Name:   region.Name,
+				RTT:    fastest(rtts),
+			}
+		}()
+	}
+	wg.Wait()
+
+	sortLatencies(results)
+	return results
+}
+
+// probeRegion measures a request to url, routed to its machines in region by
+// the fly-prefer-region header.
+func probeRegion(ctx context.Context, client *http.Client, url, region string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("fly-prefer-region", region)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	rtt := time.Since(start)
+
+	// Requests land elsewhere when the machines in region are busy or down.
+	if served := resp.Header.Get("fly-region"); served != "" && !strings.EqualFold(served, region) {
+		return 0, fmt.Errorf("served from %s", served)
+	}
+	return rtt, nil
+}
+
+// machineLatencies pings the machines of app over the private network.
+func machineLatencies(ctx context.Context, app *fly.AppCompact, machines []*fly.Machine, samples int) ([]latency, error) {
+	client := fly.ClientFromContext(ctx)
+
+	aClient, err := agent.Establish(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	pinger, err := aClient.Pinger(ctx, app.Organization.Slug, "")
+	if err != nil {
+		return nil, err
+	}
+	defer pinger.Close()
+
+	var (
+		mu    sync.Mutex
+		rtts  = map[string][]time.Duration{}
+		done  = make(chan struct{})
+		reads sync.WaitGroup
+	)
+
+	reads.Add(1)
+	go func() {
+		defer reads.Done()
+		buf := make([]byte, 1500)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			pinger.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+			n, addr, err := pinger.ReadFrom(buf)
+			if err != nil {
+				continue
+			}
+			msg, err := icmp.ParseMessage(58, buf[:n])
+			if err != nil {
+				continue
+			}
+			echo, ok := msg.Body.(*icmp.Echo)
+			if !ok || len(echo.Data) < timestampLen {
+				continue
+			}
+			var at time.Time
+			if err := at.UnmarshalBinary(echo.Data[:timestampLen]); err != nil {
+				continue
+			}
+
+			ip := net.ParseIP(addr.String()).String()
+			mu.Lock()
+			rtts[ip] = append(rtts[ip], time.Since(at))
+			mu.Unlock()
+		}
+	}()
+
+	for seq := 0; seq < samples; seq++ {
+		for _, m := range machines {
+			if _, err := pinger.WriteTo(ping.EchoRequest(0, seq, time.Now(), 0), &net.IPAddr{IP: net.ParseIP(m.PrivateIP)}); err != nil {
+				close(done)
+				return nil, err
+			}
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	time.Sleep(time.Second)
+	close(done)
+	reads.Wait()
+
+	results := make([]latency, 0, len(machines))
+	for _, m := range machines {
+		results = append(results, latency{
+			Target: m.ID,
+			Region: m.Region,
+			RTT:    fastest(rtts[net.ParseIP(m.PrivateIP).String()]),
+		})
+	}
+
+	sortLatencies(results)
+	return results, nil
+}
+
+// fastest returns the shortest of rtts, or zero when there are none.
+func fastest(rtts []time.Duration) (min time.Duration) {
+	for i, rtt := range rtts {
+		if i == 0 || rtt < min {
+			min = rtt
+		}
+	}
+	return
+}
+
+// sortLatencies sorts results by RTT, with the targets which didn't answer
+// last.
+func sortLatencies(results []latency) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i].RTT, results[j].RTT
+		switch {
+		case a == 0:
+			return false
+		case b == 0:
+			return true
+		default:
+			return a < b
+		}
+	})
+}
+
+func formatRTT(rtt time.Duration) string {
+	if rtt == 0 {
+		return "unreachable"
+	}
+	return rtt.Round(100 * time.Microsecond).String()
+}
@@ -0,0 +1,56 @@
+package platform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestFastest(t *testing.T) {
+	assert.Equal(t, time.Duration(0), fastest(nil))
+	assert.Equal(t, 12*time.Millisecond, fastest([]time.Duration{30 * time.Millisecond, 12 * time.Millisecond, 15 * time.Millisecond}))
+}
+
+func TestSortLatencies(t *testing.T) {
+	results := []latency{
+		{Region: "syd", RTT: 0},
+		{Region: "ams", RTT: 90 * time.Millisecond},
+		{Region: "jnb", RTT: 0},
+		{Region: "ord", RTT: 20 * time.Millisecond},
+	}
+	sortLatencies(results)
+
+	var regions []string
+	for _, r := range results {
+		regions = append(regions, r.Region)
+	}
+	assert.Equal(t, []string{"ord", "ams", "syd", "jnb"}, regions)
+}
+
+func TestAppRegions(t *testing.T) {
+	regions := []fly.Region{{Code: "ams"}, {Code: "iad"}, {Code: "ord"}}
+	machines := []*fly.Machine{{Region: "ord"}, {Region: "ams"}, {Region: "ord"}}
+
+	assert.Equal(t, []fly.Region{{Code: "ams"}, {Code: "ord"}}, appRegions(regions, machines))
+}
+
+func TestProbeRegion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only ams has machines that answer
+		w.Header().Set("fly-region", "ams")
+	}))
+	defer srv.Close()
+
+	rtt, err := probeRegion(context.Background(), srv.Client(), srv.URL, "ams")
+	require.NoError(t, err)
+	assert.Greater(t, rtt, time.Duration(0))
+
+	_, err = probeRegion(context.Background(), srv.Client(), srv.URL, "ord")
+	assert.ErrorContains(t, err, "served from ams")
+}
@@ -23,7 +23,10 @@ var gpuRegions = []string{"iad", "sjc", "syd", "ams"}
 
 func newRegions() (cmd *cobra.Command) {
 	const (
-		long = `View a list of regions where Fly has edges and/or datacenters
+		long = `View a list of regions where Fly has edges and/or datacenters.
+With --latency, measure the round trip time from your location to your app in
+each region it runs machines in, through the closest edge, and with --machines
+to its machines over the private network, to help choose a primary region.
 `
 		short = "List regions"
 	)
@@ -33,7 +36,24 @@ func newRegions() (cmd *cobra.Command) {
 	)
 
 	cmd.Args = cobra.NoArgs
-	flag.Add(cmd, flag.JSONOutput())
+	flag.Add(cmd,
+		flag.JSONOutput(),
+		flag.App(),
+		flag.AppConfig(),
+		flag.Bool{
+			Name:        "latency",
+			Description: "Measure the round trip time to the app in each of its regions, sorted from the closest",
+		},
+		flag.Bool{
+			Name:        "machines",
+			Description: "With --latency, also ping the machines of the app over the private network",
+		},
+		flag.Int{
+			Name:        "samples",
+			Description: "With --latency, how many round trips to measure, the fastest is kept",
+			Default:     3,
+		},
+	)
 	return
 }
 
@@ -48,6 +68,10 @@ func runRegions(ctx context.Context) error {
 		return regions[i].Name < regions[j].Name
 	})
 
+	if flag.GetBool(ctx, "latency") {
+		return runRegionsLatency(ctx, regions)
+	}
+
 	out := iostreams.FromContext(ctx).Out
 	if config.FromContext(ctx).JSONOutput {
 		return render.JSON(out, regions)
@@ -57,24 +57,9 @@ func runStatus(ctx context.Context) error {
 	}
 
 	if cfg.JSONOutput {
-		httpClient, err := fly.NewHTTPClient(logger.MaybeFromContext(ctx), httptracing.NewTransport(http.DefaultTransport))
-		if err != nil {
-			return err
-		}
-		res, err := httpClient.Get(StatusURL + getStatusEndpoint)
-		if err != nil {
-			return err
-		}
-		defer res.Body.Close() //skipcq: GO-S2307
-
-		if res.StatusCode != 200 {
-			err = fly.ErrorFromResp(res)
-			return fmt.Errorf("failed to retrieve status: %w", err)
-		}
-
 		var result = map[string]any{}
-		if err = json.NewDecoder(res.Body).Decode(&result); err != nil {
-			return nil
+		if err := fetchStatus(ctx, getStatusEndpoint, &result); err != nil {
+			return err
 		}
 		out := iostreams.FromContext(ctx).Out
 		return render.JSON(out, result)
@@ -89,3 +74,28 @@ func runStatus(ctx context.Context) error {
 
 	return nil
 }
+
+// fetchStatus decodes the response of an endpoint of the status page API
+// into v.
+func fetchStatus(ctx context.Context, endpoint string, v any) error {
+	httpClient, err := fly.NewHTTPClient(logger.MaybeFromContext(ctx), httptracing.NewTransport(http.DefaultTransport))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, StatusURL+endpoint, nil)
+	if err != nil {
+		return err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() //skipcq: GO-S2307
+
+	if res.StatusCode != 200 {
+		err = fly.ErrorFromResp(res)
+		return fmt.Errorf("failed to retrieve status: %w", err)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/docker/docker/pkg/ioutils"
+	"github.com/kballard/go-shellquote"
 	"github.com/mattn/go-colorable"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
@@ -16,14 +20,22 @@ import (
 	"github.com/superfly/flyctl/internal/command/apps"
 	"github.com/superfly/flyctl/internal/command/ssh"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flyerr"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 func newConnect() *cobra.Command {
 	const (
 		short = "Connect to the Postgres console"
-		long  = short + "\n"
+		long  = short + `
 
-		usage = "connect"
+Arguments after -- are passed to psql, such as -- -A -t for unaligned
+output without headers. With --command or --file, the SQL runs without a
+console and stops at the first error, and connect exits with the exit code
+of psql, so migrations and checks can run from CI.
+`
+
+		usage = "connect [-- PSQL_ARGS...]"
 	)
 
 	cmd := command.New(usage, short, long, runConnect,
@@ -51,6 +63,14 @@ func newConnect() *cobra.Command {
 			Shorthand:   "p",
 			Description: "The postgres user password",
 		},
+		flag.String{
+			Name:        "command",
+			Description: "SQL to run instead of opening a console",
+		},
+		flag.String{
+			Name:        "file",
+			Description: "Path of a SQL script to run instead of opening a console, or - for stdin",
+		},
 	)
 
 	return cmd
@@ -88,8 +108,15 @@ func runMachineConnect(ctx context.Context, app *fly.AppCompact) error {
 		database = flag.GetString(ctx, "database")
 		user     = flag.GetString(ctx, "user")
 		password = flag.GetString(ctx, "password")
+		sql      = flag.GetString(ctx, "command")
+		file     = flag.GetString(ctx, "file")
+		args     = flag.Args(ctx)
 	)
 
+	if sql != "" && file != "" {
+		return errors.New("--command and --file can't be combined")
+	}
+
 	flapsClient := flaps.FromContext(ctx)
 
 	machines, err := flapsClient.ListActive(ctx)
@@ -105,7 +132,8 @@ func runMachineConnect(ctx context.Context, app *fly.AppCompact) error {
 	if err != nil {
 		return err
 	}
-	return ssh.SSHConnect(&ssh.SSHParams{
+
+	params := &ssh.SSHParams{
 		Ctx:      ctx,
 		Org:      app.Organization,
 		Dialer:   agent.DialerFromContext(ctx),
@@ -115,5 +143,71 @@ func runMachineConnect(ctx context.Context, app *fly.AppCompact) error {
 		Stdin:    os.Stdin,
 		Stdout:   ioutils.NewWriteCloserWrapper(colorable.NewColorableStdout(), func() error { return nil }),
 		Stderr:   ioutils.NewWriteCloserWrapper(colorable.NewColorableStderr(), func() error { return nil }),
-	}, leader.PrivateIP)
+	}
+
+	script := sql != "" || file != ""
+	switch {
+	case sql != "":
+		params.Stdin = strings.NewReader(sql)
+	case file != "" && file != "-":
+		f, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed opening SQL script: %w", err)
+		}
+		defer f.Close()
+		params.Stdin = f
+	}
+
+	if script || len(args) > 0 {
+		if password != "" && !script {
+			// The password would go through the terminal, which echoes it.
+			return errors.New("--password only applies with --command or --file, psql asks for the password otherwise")
+		}
+		if password != "" {
+			params.Stdin = io.MultiReader(strings.NewReader(password+"\n"), params.Stdin)
+		}
+		params.Cmd = psqlCommand(database, user, password != "", script, args)
+	}
+	if script {
+		params.NoPTY = true
+		params.DisableSpinner = true
+		params.Stdout = ioutils.NewWriteCloserWrapper(os.Stdout, func() error { return nil })
+		params.Stderr = ioutils.NewWriteCloserWrapper(os.Stderr, func() error { return nil })
+	}
+
+	return psqlExitError(ssh.SSHConnect(params, leader.PrivateIP))
+}
+
+// psqlCommand returns the psql invocation run on the leader when connect is
+// given psql arguments or SQL to run. Scripts are read from stdin and stop
+// at the first error, so psql exits non-zero when any statement fails.
+//
+// The password never appears in the command: with passwordOnStdin, it's the
+// first line of stdin, read by the shell before psql starts, and otherwise
+// the password of the postgres user is taken from the environment of the
+// machine, like the image's connect helper does.
+func psqlCommand(database, user string, passwordOnStdin, script bool, args []string) string {
+	var env string
+	switch {
+	case passwordOnStdin:
+		env = "IFS= read -r PGPASSWORD && export PGPASSWORD && "
+	case user == "postgres":
+		env = `PGPASSWORD="$OPERATOR_PASSWORD" `
+	}
+
+	psqlArgs := []string{"-h", "localhost", "-p", "5432", "-U", user, "-d", database}
+	if script {
+		psqlArgs = append(psqlArgs, "-v", "ON_ERROR_STOP=1", "-f", "-")
+	}
+	psqlArgs = append(psqlArgs, args...)
+	return shellquote.Join(append([]string{"sh", "-c", env + `exec psql "$@"`, "psql"}, psqlArgs...)...)
+}
+
+// psqlExitError makes connect exit with the exit code of psql.
+func psqlExitError(err error) error {
+	var exitErr *gossh.ExitError
+	if errors.As(err, &exitErr) {
+		return flyerr.ExitCodeError{Code: exitErr.ExitStatus()}
+	}
+	return err
 }
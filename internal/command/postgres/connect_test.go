@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPsqlCommand(t *testing.T) {
+	assert.Equal(t,
+		`sh -c 'PGPASSWORD="$OPERATOR_PASSWORD" exec psql "$@"' psql -h localhost -p 5432 -U postgres -d postgres -A -t`,
+		psqlCommand("postgres", "postgres", false, false, []string{"-A", "-t"}),
+	)
+	assert.Equal(t,
+		`sh -c 'IFS= read -r PGPASSWORD && export PGPASSWORD && exec psql "$@"' psql -h localhost -p 5432 -U app -d app_db -v ON_ERROR_STOP=1 -f - --echo-errors`,
+		psqlCommand("app_db", "app", true, true, []string{"--echo-errors"}),
+	)
+	assert.Equal(t,
+		`sh -c 'exec psql "$@"' psql -h localhost -p 5432 -U app -d app_db`,
+		psqlCommand("app_db", "app", false, false, nil),
+	)
+
+	// Arguments reach psql as they were given.
+	words, err := shellquote.Split(psqlCommand("postgres", "postgres", false, true, []string{"-c", "select 'it''s'"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-c", "select 'it''s'"}, words[len(words)-2:])
+}
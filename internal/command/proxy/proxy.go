@@ -39,11 +39,6 @@ connects to the first Machine address returned by an internal DNS query on the a
 			Default:     false,
 			Description: "Prompt to select from available Machines from the current application",
 		},
-		flag.Bool{
-			Name:        "quiet",
-			Shorthand:   "q",
-			Description: "Don't print progress indicators for WireGuard",
-		},
 		flag.String{
 			Name:        flagnames.BindAddr,
 			Shorthand:   "b",
@@ -0,0 +1,344 @@
+package redis
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/iostreams"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+const (
+	backupExtension = ".jsonl.gz"
+	backupBatchSize = 500
+)
+
+// backupEntry is a key of a backup: its value serialized with DUMP, and its
+// remaining time to live in milliseconds, 0 when it doesn't expire.
+type backupEntry struct {
+	Key   string `json:"key"`
+	TTL   int64  `json:"ttl_ms"`
+	Value []byte `json:"value"`
+}
+
+func newBackup() (cmd *cobra.Command) {
+	const (
+		long = `Back up the keys of an Upstash Redis database to local files, and
+restore them. Backups are stored in the flyctl configuration directory unless
+an output path is given.`
+
+		short = `Back up and restore Upstash Redis databases`
+	)
+
+	cmd = command.New("backup", short, long, nil)
+	cmd.AddCommand(newBackupCreate(), newBackupList(), newBackupRestore())
+	return cmd
+}
+
+func newBackupCreate() (cmd *cobra.Command) {
+	const (
+		long = `Back up every key of an Upstash Redis database`
+
+		short = long
+		usage = "create <name>"
+	)
+
+	cmd = command.New(usage, short, long, runBackupCreate, command.RequireSession)
+
+	flag.Add(cmd,
+		flag.String{
+			Name:        "output",
+			Shorthand:   "o",
+			Description: "Write the backup to this path instead of the configuration directory",
+		},
+	)
+	cmd.Args = cobra.ExactArgs(1)
+	return cmd
+}
+
+func newBackupList() (cmd *cobra.Command) {
+	const (
+		long = `List the backups of an Upstash Redis database stored in the configuration directory`
+
+		short = long
+		usage = "list <name>"
+	)
+
+	cmd = command.New(usage, short, long, runBackupList)
+	cmd.Aliases = []string{"ls"}
+
+	flag.Add(cmd, flag.JSONOutput())
+	cmd.Args = cobra.ExactArgs(1)
+	return cmd
+}
+
+func newBackupRestore() (cmd *cobra.Command) {
+	const (
+		long = `Restore a backup into an Upstash Redis database. Keys of the backup
+replace existing keys with the same name, other keys are left untouched. The
+backup is either a path, or the name of a backup listed by 'backup list'.`
+
+		short = `Restore a backup into an Upstash Redis database`
+		usage = "restore <name> <backup>"
+	)
+
+	cmd = command.New(usage, short, long, runBackupRestore, command.RequireSession)
+
+	flag.Add(cmd, flag.Yes())
+	cmd.Args = cobra.ExactArgs(2)
+	return cmd
+}
+
+func backupDir(ctx context.Context, name string) string {
+	return filepath.Join(state.ConfigDirectory(ctx), "redis-backups", name)
+}
+
+func runBackupCreate(ctx context.Context) (err error) {
+	var (
+		io   = iostreams.FromContext(ctx)
+		name = flag.FirstArg(ctx)
+	)
+
+	path := flag.GetString(ctx, "output")
+	if path == "" {
+		dir := backupDir(ctx, name)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+		path = filepath.Join(dir, time.Now().UTC().Format("20060102T150405Z")+backupExtension)
+	}
+
+	client, err := newClient(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	io.StartProgressIndicatorMsg(fmt.Sprintf("Backing up %s", name))
+	count, err := writeBackup(ctx, client, f)
+	io.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", name, err)
+	}
+
+	fmt.Fprintf(io.Out, "Backed up %d keys of %s to %s\n", count, name, path)
+	return nil
+}
+
+// writeBackup dumps every key of the database to w.
+func writeBackup(ctx context.Context, client *goredis.Client, w io.Writer) (int, error) {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+
+	count := 0
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, "*", backupBatchSize).Result()
+		if err != nil {
+			return count, err
+		}
+
+		pipe := client.Pipeline()
+		dumps := make([]*goredis.StringCmd, len(keys))
+		ttls := make([]*goredis.DurationCmd, len(keys))
+		for i, key := range keys {
+			dumps[i] = pipe.Dump(ctx, key)
+			ttls[i] = pipe.PTTL(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, goredis.Nil) {
+			return count, err
+		}
+
+		for i, key := range keys {
+			value, err := dumps[i].Result()
+			if errors.Is(err, goredis.Nil) {
+				// The key expired or was deleted since it was scanned
+				continue
+			} else if err != nil {
+				return count, err
+			}
+			entry := backupEntry{Key: key, Value: []byte(value)}
+			if ttl := ttls[i].Val(); ttl > 0 {
+				entry.TTL = ttl.Milliseconds()
+			}
+			if err := enc.Encode(entry); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	return count, gz.Close()
+}
+
+func runBackupList(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	entries, err := os.ReadDir(backupDir(ctx, flag.FirstArg(ctx)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	type backup struct {
+		Name    string
+		Path    string
+		Size    int64
+		Created time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".gz" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		backups = append(backups, backup{
+			Name:    entry.Name(),
+			Path:    filepath.Join(backupDir(ctx, flag.FirstArg(ctx)), entry.Name()),
+			Size:    info.Size(),
+			Created: info.ModTime(),
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Created.After(backups[j].Created) })
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, backups)
+	}
+
+	rows := make([][]string, 0, len(backups))
+	for _, b := range backups {
+		rows = append(rows, []string{b.Name, humanize.Time(b.Created), humanize.Bytes(uint64(b.Size))})
+	}
+	return render.Table(out, "", rows, "Name", "Created", "Size")
+}
+
+func runBackupRestore(ctx context.Context) (err error) {
+	var (
+		io     = iostreams.FromContext(ctx)
+		args   = flag.Args(ctx)
+		name   = args[0]
+		backup = args[1]
+	)
+
+	path := backup
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		path = filepath.Join(backupDir(ctx, name), backup)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if !flag.GetYes(ctx) {
+		msg := fmt.Sprintf("Restoring %s replaces the keys of %s that exist in the backup. Continue?", backup, name)
+		switch confirmed, err := prompt.Confirm(ctx, msg); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	client, err := newClient(ctx, name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	io.StartProgressIndicatorMsg(fmt.Sprintf("Restoring %s", name))
+	count, err := restoreBackup(ctx, client, f)
+	io.StopProgressIndicator()
+	if err != nil {
+		return fmt.Errorf("failed to restore %s after %d keys: %w", backup, count, err)
+	}
+
+	fmt.Fprintf(io.Out, "Restored %d keys to %s\n", count, name)
+	return nil
+}
+
+// restoreBackup restores the keys of the backup r, replacing existing ones.
+func restoreBackup(ctx context.Context, client *goredis.Client, r io.Reader) (int, error) {
+	return readBackup(r, backupBatchSize, func(entries []backupEntry) error {
+		pipe := client.Pipeline()
+		for _, entry := range entries {
+			pipe.RestoreReplace(ctx, entry.Key, time.Duration(entry.TTL)*time.Millisecond, string(entry.Value))
+		}
+		_, err := pipe.Exec(ctx)
+		return err
+	})
+}
+
+// readBackup decodes the backup r and passes its entries to fn in batches of
+// at most size entries. It returns the number of entries fn accepted.
+func readBackup(r io.Reader, size int, fn func([]backupEntry) error) (int, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("invalid backup: %w", err)
+	}
+	defer gz.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(gz))
+	count := 0
+	for {
+		batch := make([]backupEntry, 0, size)
+		for len(batch) < size {
+			var entry backupEntry
+			if err := dec.Decode(&entry); err == io.EOF {
+				break
+			} else if err != nil {
+				return count, fmt.Errorf("invalid backup: %w", err)
+			}
+			batch = append(batch, entry)
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		if err := fn(batch); err != nil {
+			return count, err
+		}
+		count += len(batch)
+	}
+}
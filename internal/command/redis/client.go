@@ -0,0 +1,32 @@
+package redis
+
+import (
+	"context"
+	"net"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// newClient connects to the database name through the WireGuard tunnel of its
+// organization.
+func newClient(ctx context.Context, name string) (*goredis.Client, error) {
+	params, database, err := redisProxyParams(ctx, name, "")
+	if err != nil {
+		return nil, err
+	}
+
+	addr := net.JoinHostPort(params.RemoteHost, params.Ports[1])
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     addr,
+		Password: database.Password,
+		Dialer: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return params.Dialer.DialContext(ctx, network, addr)
+		},
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	"github.com/superfly/flyctl/iostreams"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+)
+
+func newConfig() (cmd *cobra.Command) {
+	const (
+		long = `Manage the configuration of an Upstash Redis database`
+
+		short = long
+	)
+
+	cmd = command.New("config", short, long, nil)
+	cmd.AddCommand(newConfigSet())
+	return cmd
+}
+
+func newConfigSet() (cmd *cobra.Command) {
+	const (
+		long = `Set a configuration parameter of an Upstash Redis database. The
+supported parameter is eviction, on or off: when on, keys are evicted once the
+database reaches its maximum size instead of rejecting writes.`
+
+		short = `Set a configuration parameter of an Upstash Redis database`
+		usage = "set <name> <parameter> <value>"
+	)
+
+	cmd = command.New(usage, short, long, runConfigSet, command.RequireSession)
+	cmd.Args = cobra.ExactArgs(3)
+	return cmd
+}
+
+func runConfigSet(ctx context.Context) (err error) {
+	var (
+		out    = iostreams.FromContext(ctx).Out
+		client = fly.ClientFromContext(ctx).GenqClient
+		args   = flag.Args(ctx)
+	)
+
+	name := args[0]
+	eviction, err := parseEviction(args[1], args[2])
+	if err != nil {
+		return
+	}
+
+	response, err := gql.GetAddOn(ctx, client, name)
+	if err != nil {
+		return
+	}
+	addOn := response.AddOn
+
+	options, _ := addOn.Options.(map[string]interface{})
+	if options == nil {
+		options = make(map[string]interface{})
+	}
+	options["eviction"] = eviction
+
+	readRegions := addOn.ReadRegions
+	if readRegions == nil {
+		readRegions = []string{}
+	}
+
+	_, err = gql.UpdateAddOn(ctx, client, addOn.Id, addOn.AddOnPlan.Id, readRegions, options)
+	if err != nil {
+		return
+	}
+
+	status := "disabled"
+	if eviction {
+		status = "enabled"
+	}
+	fmt.Fprintf(out, "Eviction is now %s for %s.\n", status, addOn.Name)
+	return
+}
+
+// parseEviction parses the value of the eviction parameter, the only one
+// Upstash Redis databases expose.
+func parseEviction(parameter, value string) (bool, error) {
+	if strings.ToLower(parameter) != "eviction" {
+		return false, fmt.Errorf("unsupported parameter %s, only eviction can be set", parameter)
+	}
+
+	switch strings.ToLower(value) {
+	case "on", "true", "enabled":
+		return true, nil
+	case "off", "false", "disabled":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid eviction value %s, expected on or off", value)
+	}
+}
@@ -2,9 +2,13 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os/exec"
+	"strconv"
 
+	"github.com/google/shlex"
 	"github.com/spf13/cobra"
 
 	"github.com/superfly/flyctl/iostreams"
@@ -16,10 +20,14 @@ import (
 
 func newConnect() (cmd *cobra.Command) {
 	const (
-		long = `Connect to a Redis database using redis-cli`
-
-		short = long
-		usage = "connect"
+		long = `Connect to a Redis database using redis-cli. Without a database name, one is
+prompted for. Use --command to run a single command, or --eval to run a Lua
+script, and exit, for scripted access. Keys and arguments of the script follow
+--, separated by a comma as redis-cli expects, as in
+'fly redis connect my-db --eval script.lua -- key1 key2 , arg1'.`
+
+		short = `Connect to a Redis database using redis-cli`
+		usage = "connect [name]"
 	)
 
 	cmd = command.New(usage, short, long, runConnect, command.RequireSession)
@@ -27,25 +35,91 @@ func newConnect() (cmd *cobra.Command) {
 	flag.Add(cmd,
 		flag.Org(),
 		flag.Region(),
+		flag.Int{
+			Name:        "db",
+			Description: "The number of the database to select",
+		},
+		flag.Bool{
+			Name:        "tls",
+			Description: "Connect with TLS, validating the certificate against the database's hostname",
+		},
+		flag.String{
+			Name:        "sni",
+			Description: "The server name to send when connecting with TLS, instead of the database's hostname",
+		},
+		flag.Bool{
+			Name:        "insecure",
+			Description: "Don't verify the certificate of the database when connecting with TLS",
+		},
+		flag.String{
+			Name:        "command",
+			Shorthand:   "c",
+			Description: `Run this command, such as "GET key", print its reply and exit`,
+		},
+		flag.String{
+			Name:        "eval",
+			Description: "Run this Lua script, print its reply and exit",
+		},
 	)
+	cmd.Args = cobra.MaximumNArgs(1)
 
 	return cmd
 }
 
+// cliOptions are the options of redis-cli, as set by the flags of connect.
+type cliOptions struct {
+	db       int
+	tls      bool
+	sni      string
+	insecure bool
+	command  string
+	eval     string
+	evalArgs []string
+}
+
 func runConnect(ctx context.Context) (err error) {
 	io := iostreams.FromContext(ctx)
 
 	localProxyPort := "16379"
 
-	params, password, err := getRedisProxyParams(ctx, localProxyPort)
+	opts := cliOptions{
+		db:       flag.GetInt(ctx, "db"),
+		tls:      flag.GetBool(ctx, "tls"),
+		sni:      flag.GetString(ctx, "sni"),
+		insecure: flag.GetBool(ctx, "insecure"),
+		command:  flag.GetString(ctx, "command"),
+		eval:     flag.GetString(ctx, "eval"),
+		evalArgs: flag.ExtraArgsFromContext(ctx),
+	}
+
+	name := flag.FirstArg(ctx)
+	if name == "" {
+		if name, err = selectDatabase(ctx); err != nil {
+			return err
+		}
+	}
+
+	params, database, err := redisProxyParams(ctx, name, localProxyPort)
+	if err != nil {
+		return err
+	}
+
+	// the certificate is for the public hostname, not the private IP the
+	// proxy dials
+	if opts.tls && opts.sni == "" {
+		if u, err := url.Parse(database.PublicUrl); err == nil {
+			opts.sni = u.Hostname()
+		}
+	}
+
+	args, err := cliArgs(localProxyPort, opts)
 	if err != nil {
 		return err
 	}
 
 	redisCliPath, err := exec.LookPath("redis-cli")
 	if err != nil {
-		fmt.Fprintf(io.Out, "Could not find redis-cli in your $PATH. Install it or point your redis-cli at: %s", "someurl")
-		return
+		return errors.New("could not find redis-cli in your $PATH, install it or run 'fly redis proxy' and point your client at the proxy")
 	}
 
 	err = proxy.Start(ctx, params)
@@ -53,14 +127,53 @@ func runConnect(ctx context.Context) (err error) {
 		return err
 	}
 
-	cmd := exec.CommandContext(ctx, redisCliPath, "-p", localProxyPort)
-	cmd.Env = append(cmd.Env, fmt.Sprintf("REDISCLI_AUTH=%s", password))
+	cmd := exec.CommandContext(ctx, redisCliPath, args...)
+	cmd.Env = append(cmd.Env, fmt.Sprintf("REDISCLI_AUTH=%s", database.Password))
 	cmd.Stdout = io.Out
 	cmd.Stderr = io.ErrOut
-	cmd.Stdin = io.In
+	if opts.command == "" && opts.eval == "" {
+		cmd.Stdin = io.In
+	}
+
+	return cmd.Run()
+}
+
+// cliArgs returns the arguments to run redis-cli with against the proxy
+// listening on port.
+func cliArgs(port string, opts cliOptions) ([]string, error) {
+	args := []string{"-p", port}
 
-	cmd.Start()
-	cmd.Wait()
+	if opts.db != 0 {
+		args = append(args, "-n", strconv.Itoa(opts.db))
+	}
+
+	if opts.tls {
+		args = append(args, "--tls")
+		if opts.sni != "" {
+			args = append(args, "--sni", opts.sni)
+		}
+		if opts.insecure {
+			args = append(args, "--insecure")
+		}
+	} else if opts.sni != "" || opts.insecure {
+		return nil, errors.New("--sni and --insecure require --tls")
+	}
+
+	switch {
+	case opts.command != "" && opts.eval != "":
+		return nil, errors.New("--command and --eval can't be used together")
+	case opts.command != "":
+		words, err := shlex.Split(opts.command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid command: %w", err)
+		}
+		args = append(args, words...)
+	case opts.eval != "":
+		args = append(args, "--eval", opts.eval)
+		args = append(args, opts.evalArgs...)
+	case len(opts.evalArgs) > 0:
+		return nil, errors.New("arguments after -- are only used with --eval")
+	}
 
-	return
+	return args, nil
 }
@@ -35,17 +35,23 @@ func newProxy() (cmd *cobra.Command) {
 
 func runProxy(ctx context.Context) (err error) {
 	localProxyPort := "16379"
-	params, password, err := getRedisProxyParams(ctx, localProxyPort)
+	name, err := selectDatabase(ctx)
 	if err != nil {
 		return err
 	}
 
-	terminal.Infof("Proxying redis to port \"%s\" with password \"%s\"", localProxyPort, password)
+	params, database, err := redisProxyParams(ctx, name, localProxyPort)
+	if err != nil {
+		return err
+	}
+
+	terminal.Infof("Proxying redis to port \"%s\" with password \"%s\"", localProxyPort, database.Password)
 
 	return proxy.Connect(ctx, params)
 }
 
-func getRedisProxyParams(ctx context.Context, localProxyPort string) (*proxy.ConnectParams, string, error) {
+// selectDatabase prompts for one of the Upstash Redis databases of the user.
+func selectDatabase(ctx context.Context) (string, error) {
 	client := fly.ClientFromContext(ctx)
 
 	var index int
@@ -53,7 +59,7 @@ func getRedisProxyParams(ctx context.Context, localProxyPort string) (*proxy.Con
 
 	result, err := gql.ListAddOns(ctx, client.GenqClient, "upstash_redis")
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 
 	databases := result.AddOns.Nodes
@@ -64,24 +70,32 @@ func getRedisProxyParams(ctx context.Context, localProxyPort string) (*proxy.Con
 
 	err = prompt.Select(ctx, &index, "Select a database to connect to", "", options...)
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
 
-	response, err := gql.GetAddOn(ctx, client.GenqClient, databases[index].Name)
+	return databases[index].Name, nil
+}
+
+// redisProxyParams returns the parameters to proxy localProxyPort to the
+// database name, along with the database.
+func redisProxyParams(ctx context.Context, name, localProxyPort string) (*proxy.ConnectParams, *gql.GetAddOnAddOn, error) {
+	client := fly.ClientFromContext(ctx)
+
+	response, err := gql.GetAddOn(ctx, client.GenqClient, name)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	database := response.AddOn
+	database := &response.AddOn
 
 	agentclient, err := agent.Establish(ctx, client)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	dialer, err := agentclient.ConnectToTunnel(ctx, database.Organization.Slug, "", false)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	return &proxy.ConnectParams{
@@ -89,5 +103,5 @@ func getRedisProxyParams(ctx context.Context, localProxyPort string) (*proxy.Con
 		OrganizationSlug: database.Organization.Slug,
 		Dialer:           dialer,
 		RemoteHost:       database.PrivateIp,
-	}, database.Password, nil
+	}, database, nil
 }
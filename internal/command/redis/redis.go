@@ -30,6 +30,9 @@ func New() (cmd *cobra.Command) {
 		newDashboard(),
 		newReset(),
 		newProxy(),
+		newBackup(),
+		newConfig(),
+		newSlowlog(),
 	)
 
 	return cmd
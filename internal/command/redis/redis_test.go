@@ -0,0 +1,102 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseEviction(t *testing.T) {
+	eviction, err := parseEviction("eviction", "on")
+	require.NoError(t, err)
+	assert.True(t, eviction)
+
+	eviction, err = parseEviction("Eviction", "OFF")
+	require.NoError(t, err)
+	assert.False(t, eviction)
+
+	_, err = parseEviction("maxmemory-policy", "allkeys-lru")
+	assert.ErrorContains(t, err, "only eviction can be set")
+
+	_, err = parseEviction("eviction", "maybe")
+	assert.ErrorContains(t, err, "expected on or off")
+}
+
+func Test_slowlogRows(t *testing.T) {
+	rows := slowlogRows([]goredis.SlowLog{{
+		ID:         7,
+		Time:       time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		Duration:   1500 * time.Microsecond,
+		Args:       []string{"KEYS", "*"},
+		ClientAddr: "[fdaa::3]:4242",
+	}})
+	assert.Equal(t, [][]string{{"7", "2024-05-01 12:30:00", "1.5ms", "KEYS *", "[fdaa::3]:4242"}}, rows)
+}
+
+func Test_readBackup(t *testing.T) {
+	entries := []backupEntry{
+		{Key: "a", Value: []byte("\x00\x01a")},
+		{Key: "b", TTL: 60000, Value: []byte("\x00\x01b")},
+		{Key: "c", Value: []byte("\x00\x01c")},
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+	for _, entry := range entries {
+		require.NoError(t, enc.Encode(entry))
+	}
+	require.NoError(t, gz.Close())
+
+	var batches [][]backupEntry
+	count, err := readBackup(bytes.NewReader(buf.Bytes()), 2, func(batch []backupEntry) error {
+		batches = append(batches, batch)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, [][]backupEntry{entries[:2], entries[2:]}, batches)
+
+	_, err = readBackup(bytes.NewReader([]byte("not a backup")), 2, func([]backupEntry) error { return nil })
+	assert.ErrorContains(t, err, "invalid backup")
+}
+
+func Test_cliArgs(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts cliOptions
+		want []string
+	}{
+		{"plain", cliOptions{}, []string{"-p", "16379"}},
+		{"db", cliOptions{db: 2}, []string{"-p", "16379", "-n", "2"}},
+		{"tls", cliOptions{tls: true, sni: "fly-redis.upstash.io", insecure: true}, []string{"-p", "16379", "--tls", "--sni", "fly-redis.upstash.io", "--insecure"}},
+		{"command", cliOptions{command: `SET key "a value"`}, []string{"-p", "16379", "SET", "key", "a value"}},
+		{"eval", cliOptions{eval: "script.lua", evalArgs: []string{"key", ",", "arg"}}, []string{"-p", "16379", "--eval", "script.lua", "key", ",", "arg"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := cliArgs("16379", tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, args)
+		})
+	}
+
+	for _, tc := range []struct {
+		opts cliOptions
+		err  string
+	}{
+		{cliOptions{sni: "host"}, "--sni and --insecure require --tls"},
+		{cliOptions{insecure: true}, "--sni and --insecure require --tls"},
+		{cliOptions{command: "GET a", eval: "script.lua"}, "--command and --eval can't be used together"},
+		{cliOptions{evalArgs: []string{"key"}}, "arguments after -- are only used with --eval"},
+		{cliOptions{command: `GET "unterminated`}, "invalid command"},
+	} {
+		_, err := cliArgs("16379", tc.opts)
+		assert.ErrorContains(t, err, tc.err)
+	}
+}
@@ -0,0 +1,72 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newSlowlog() (cmd *cobra.Command) {
+	const (
+		long = `Show the slowest recent commands run against an Upstash Redis database`
+
+		short = long
+		usage = "slowlog <name>"
+	)
+
+	cmd = command.New(usage, short, long, runSlowlog, command.RequireSession)
+
+	flag.Add(cmd,
+		flag.Int{
+			Name:        "limit",
+			Description: "The number of entries to show",
+			Default:     10,
+		},
+		flag.JSONOutput(),
+	)
+	cmd.Args = cobra.ExactArgs(1)
+	return cmd
+}
+
+func runSlowlog(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	client, err := newClient(ctx, flag.FirstArg(ctx))
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	entries, err := client.SlowLogGet(ctx, int64(flag.GetInt(ctx, "limit"))).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read the slow log: %w", err)
+	}
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(out, entries)
+	}
+
+	return render.Table(out, "", slowlogRows(entries), "ID", "Time", "Duration", "Command", "Client")
+}
+
+func slowlogRows(entries []goredis.SlowLog) [][]string {
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			fmt.Sprint(entry.ID),
+			entry.Time.Format("2006-01-02 15:04:05"),
+			entry.Duration.String(),
+			strings.Join(entry.Args, " "),
+			entry.ClientAddr,
+		})
+	}
+	return rows
+}
@@ -0,0 +1,48 @@
+package root
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flyerr"
+)
+
+// newExitCodes returns the exit-codes help topic, shown by fly help
+// exit-codes.
+func newExitCodes() *cobra.Command {
+	const short = "Exit codes of flyctl commands"
+
+	long := fmt.Sprintf(`flyctl exits with a code telling the kind of failure apart, so scripts can
+branch on it instead of parsing error messages:
+
+  %3d  Success
+  %3d  Failure of no more specific kind
+  %3d  A condition the command was asked to check for was met, such as
+       incidents affecting your apps with fly incidents --exit-code
+  %3d  Authentication failure: not logged in, or the token was rejected
+  %3d  Not found: the app, machine or other resource doesn't exist
+  %3d  Validation error: invalid arguments, flags or configuration
+  %3d  Health checks of machines didn't pass in time
+  %3d  Lease conflict: a machine is being changed by another operation
+  %3d  The command timed out
+  %3d  The command was interrupted
+`,
+		flyerr.ExitOK,
+		flyerr.ExitFailure,
+		flyerr.ExitCondition,
+		flyerr.ExitAuth,
+		flyerr.ExitNotFound,
+		flyerr.ExitValidation,
+		flyerr.ExitHealthCheckTimeout,
+		flyerr.ExitLeaseConflict,
+		flyerr.ExitTimeout,
+		flyerr.ExitCanceled,
+	)
+
+	cmd := command.New("exit-codes", short, long, nil)
+	// The help template wraps text, which would mangle the table.
+	cmd.SetHelpTemplate("{{.Long}}")
+	return cmd
+}
@@ -12,14 +12,18 @@ import (
 	"github.com/superfly/flyctl/flyctl"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/command/agent"
+	"github.com/superfly/flyctl/internal/command/alias"
 	"github.com/superfly/flyctl/internal/command/apps"
 	"github.com/superfly/flyctl/internal/command/auth"
+	"github.com/superfly/flyctl/internal/command/build"
+	"github.com/superfly/flyctl/internal/command/builders"
 	"github.com/superfly/flyctl/internal/command/certificates"
 	"github.com/superfly/flyctl/internal/command/checks"
 	"github.com/superfly/flyctl/internal/command/config"
 	"github.com/superfly/flyctl/internal/command/console"
 	"github.com/superfly/flyctl/internal/command/consul"
 	"github.com/superfly/flyctl/internal/command/create"
+	"github.com/superfly/flyctl/internal/command/cron"
 	"github.com/superfly/flyctl/internal/command/curl"
 	"github.com/superfly/flyctl/internal/command/dashboard"
 	"github.com/superfly/flyctl/internal/command/deploy"
@@ -29,6 +33,7 @@ import (
 	"github.com/superfly/flyctl/internal/command/docs"
 	"github.com/superfly/flyctl/internal/command/doctor"
 	"github.com/superfly/flyctl/internal/command/domains"
+	"github.com/superfly/flyctl/internal/command/events"
 	"github.com/superfly/flyctl/internal/command/extensions"
 	"github.com/superfly/flyctl/internal/command/history"
 	"github.com/superfly/flyctl/internal/command/image"
@@ -41,6 +46,8 @@ import (
 	"github.com/superfly/flyctl/internal/command/machine"
 	"github.com/superfly/flyctl/internal/command/metrics"
 	"github.com/superfly/flyctl/internal/command/move"
+	"github.com/superfly/flyctl/internal/command/mysql"
+	"github.com/superfly/flyctl/internal/command/onboard"
 	"github.com/superfly/flyctl/internal/command/open"
 	"github.com/superfly/flyctl/internal/command/orgs"
 	"github.com/superfly/flyctl/internal/command/ping"
@@ -52,14 +59,19 @@ import (
 	"github.com/superfly/flyctl/internal/command/releases"
 	"github.com/superfly/flyctl/internal/command/resume"
 	"github.com/superfly/flyctl/internal/command/scale"
+	"github.com/superfly/flyctl/internal/command/schema"
 	"github.com/superfly/flyctl/internal/command/secrets"
 	"github.com/superfly/flyctl/internal/command/services"
 	"github.com/superfly/flyctl/internal/command/settings"
+	"github.com/superfly/flyctl/internal/command/slo"
 	"github.com/superfly/flyctl/internal/command/ssh"
 	"github.com/superfly/flyctl/internal/command/status"
 	"github.com/superfly/flyctl/internal/command/storage"
 	"github.com/superfly/flyctl/internal/command/suspend"
+	"github.com/superfly/flyctl/internal/command/templates"
 	"github.com/superfly/flyctl/internal/command/tokens"
+	"github.com/superfly/flyctl/internal/command/trace"
+	"github.com/superfly/flyctl/internal/command/ui"
 	"github.com/superfly/flyctl/internal/command/version"
 	"github.com/superfly/flyctl/internal/command/volumes"
 	"github.com/superfly/flyctl/internal/command/wireguard"
@@ -83,38 +95,61 @@ func New() *cobra.Command {
 	_ = fs.StringP(flagnames.AccessToken, "t", "", "Fly API Access Token")
 	_ = fs.BoolP(flagnames.Verbose, "", false, "Verbose output")
 	_ = fs.BoolP(flagnames.Debug, "", false, "Print additional logs and traces")
+	_ = fs.String(flagnames.LogLevel, "", "Log level of the output, one of debug, info, warn or error. Defaults to $LOG_LEVEL or info")
+	_ = fs.String(flagnames.LogFile, "", "Path to a file to append debug logs and redacted HTTP traces to, for support tickets (see fly doctor export)")
+	_ = fs.BoolP(flagnames.JSONOutput, "j", false, "JSON output, for commands that support it (see fly schema)")
+	_ = fs.BoolP(flagnames.Quiet, "q", false, "Only print results, warnings and errors. Implies --progress quiet")
+	_ = fs.Bool(flagnames.NoColor, false, "Disable colored output. Also honors $NO_COLOR")
+	_ = fs.String(flagnames.Progress, "", "How to report progress: auto, plain, json (one event per line on stderr) or quiet. Defaults to $FLY_PROGRESS or auto")
+	_ = fs.BoolP(flagnames.Offline, "", false, "Show the last known state of read commands without querying the API")
+	_ = fs.Bool(flagnames.NoReadCache, false, "Query the API even when read commands have a cached result fresher than FLY_CACHE_TTL")
+	_ = fs.Int(flagnames.MaxRetries, 3, "Maximum number of times rate limited or failed API requests are retried. Zero disables retries")
+	_ = fs.Duration(flagnames.RequestTimeout, 0, "Maximum time a single API request may take, such as 30s. Unlimited when zero")
 
 	flyctl.InitConfig()
 
 	root.AddCommand(
 		group(apps.New(), "deploy"),
 		group(machine.New(), "deploy"),
+		group(cron.New(), "deploy"),
 		version.New(),
 		group(orgs.New(), "acl"),
 		group(auth.New(), "acl"),
 		group(platform.New(), "more_help"),
+		group(platform.NewIncidents(), "upkeep"),
 		group(docs.New(), "more_help"),
 		group(releases.New(), "upkeep"),
 		group(deploy.New(), "deploy"),
+		group(build.New(), "deploy"),
 		group(history.New(), "upkeep"),
 		group(status.New(), "deploy"),
 		group(logs.New(), "upkeep"),
+		group(events.New(), "upkeep"),
+		group(trace.New(), "upkeep"),
+		group(ui.New(), "upkeep"),
+		schema.New(),
+		newExitCodes(),
 		group(doctor.New(), "more_help"),
 		group(dig.New(), "upkeep"),
 		group(volumes.New(), "configuring"),
 		group(lfsc.New(), "dbs_and_extensions"),
 		agent.New(),
 		group(image.New(), "configuring"),
+		group(builders.New(), "configuring"),
 		group(ping.New(), "upkeep"),
 		group(proxy.New(), "upkeep"),
 		group(postgres.New(), "dbs_and_extensions"),
+		group(mysql.New(), "dbs_and_extensions"),
 		group(ips.New(), "configuring"),
 		group(secrets.New(), "configuring"),
 		group(ssh.New(), "upkeep"),
 		group(ssh.NewSFTP(), "upkeep"),
 		group(redis.New(), "dbs_and_extensions"),
 		group(checks.New(), "upkeep"),
+		group(slo.New(), "upkeep"),
 		group(launch.New(), "deploy"),
+		group(onboard.New(), "deploy"),
+		group(templates.New(), "deploy"),
 		group(info.New(), "upkeep"),
 		jobs.New(),
 		group(services.New(), "upkeep"),
@@ -128,6 +163,7 @@ func New() *cobra.Command {
 		group(wireguard.New(), "upkeep"),
 		group(console.New(), "upkeep"),
 		settings.New(),
+		alias.New(),
 		group(storage.New(), "dbs_and_extensions"),
 		metrics.New(),
 		curl.New(),       // TODO: deprecate
@@ -0,0 +1,42 @@
+package root
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/superfly/flyctl/internal/flag/flagnames"
+)
+
+// TestPersistentFlagCollisions makes sure no command declares a flag whose
+// name or shorthand is taken by a global flag, as the local flag would
+// silently change what the global one means for that command.
+func TestPersistentFlagCollisions(t *testing.T) {
+	// Flags commands redeclare with the meaning of the global flag
+	redeclared := map[string]bool{
+		flagnames.JSONOutput: true,
+		flagnames.Verbose:    true,
+	}
+
+	root := New()
+	persistent := root.PersistentFlags()
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		cmd.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
+			if persistent.Lookup(f.Name) != nil && !redeclared[f.Name] {
+				t.Errorf("%s: --%s is a global flag", cmd.CommandPath(), f.Name)
+			}
+			if f.Shorthand == "" {
+				return
+			}
+			if p := persistent.ShorthandLookup(f.Shorthand); p != nil && p.Name != f.Name {
+				t.Errorf("%s: -%s of --%s is the shorthand of the global --%s", cmd.CommandPath(), f.Shorthand, f.Name, p.Name)
+			}
+		})
+		for _, c := range cmd.Commands() {
+			walk(c)
+		}
+	}
+	walk(root)
+}
@@ -24,6 +24,11 @@ func newScaleCount() *cobra.Command {
 		short = "Change an app's VM count to the given value"
 		long  = `Change an app's VM count to the given value.
 
+Use --min and --max to bound the number of VMs in each region. The --min VMs
+of a region are kept always running, while the rest can be autostopped and
+autostarted by the proxy. Per-region bounds can also be set with
+[[region_scaling]] sections in fly.toml.
+
 For pricing, see https://fly.io/docs/about/pricing/`
 	)
 	cmd := command.New("count [count]", short, long, runScaleCount,
@@ -36,7 +41,8 @@ For pricing, see https://fly.io/docs/about/pricing/`
 		flag.AppConfig(),
 		flag.Yes(),
 		flag.ProcessGroup("The process group to scale"),
-		flag.Int{Name: "max-per-region", Description: "Max number of VMs per region", Default: -1},
+		flag.Int{Name: "max-per-region", Aliases: []string{"max"}, Description: "Max number of VMs per region", Default: -1},
+		flag.Int{Name: "min", Description: "Min number of VMs per region to keep always running, they are never autostopped", Default: -1},
 		flag.String{Name: "region", Shorthand: "r", Description: "Comma separated list of regions to act on. Defaults to all regions where there is at least one machine running for the app", CompletionFn: completion.CompleteRegions},
 		flag.Bool{Name: "with-new-volumes", Description: "New machines each get a new volumes even if there are unattached volumes available"},
 		flag.String{Name: "from-snapshot", Description: "New volumes are restored from snapshot, use 'last' for most recent snapshot. The default is an empty volume"},
@@ -88,8 +94,12 @@ func runScaleCount(ctx context.Context) error {
 	}
 
 	maxPerRegion := flag.GetInt(ctx, "max-per-region")
+	minPerRegion := flag.GetInt(ctx, "min")
+	if maxPerRegion >= 0 && minPerRegion > maxPerRegion {
+		return fmt.Errorf("--min (%d) can't be greater than --max (%d)", minPerRegion, maxPerRegion)
+	}
 
-	return runMachinesScaleCount(ctx, appName, appConfig, groups, maxPerRegion)
+	return runMachinesScaleCount(ctx, appName, appConfig, groups, maxPerRegion, minPerRegion)
 }
 
 func parseGroupCounts(args []string, defaultGroupName string) (map[string]int, error) {
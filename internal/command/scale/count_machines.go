@@ -20,7 +20,7 @@ import (
 
 const maxConcurrentActions = 5
 
-func runMachinesScaleCount(ctx context.Context, appName string, appConfig *appconfig.Config, expectedGroupCounts map[string]int, maxPerRegion int) error {
+func runMachinesScaleCount(ctx context.Context, appName string, appConfig *appconfig.Config, expectedGroupCounts map[string]int, maxPerRegion, minPerRegion int) error {
 	io := iostreams.FromContext(ctx)
 	flapsClient := flaps.FromContext(ctx)
 	ctx = appconfig.WithConfig(ctx, appConfig)
@@ -65,14 +65,16 @@ func runMachinesScaleCount(ctx context.Context, appName string, appConfig *appco
 	defaults := newDefaults(appConfig, latestCompleteRelease, machines, volumes,
 		flag.GetString(ctx, "from-snapshot"), flag.GetBool(ctx, "with-new-volumes"), defaultGuest)
 
-	actions, err := computeActions(machines, expectedGroupCounts, regions, maxPerRegion, defaults)
+	bounds := groupRegionBounds(appConfig, expectedGroupCounts, regions, maxPerRegion, minPerRegion)
+
+	actions, err := computeActions(machines, expectedGroupCounts, regions, bounds, defaults)
 	if err != nil {
 		return err
 	}
 
 	if len(actions) == 0 {
 		fmt.Fprintf(io.Out, "App already scaled to desired state. No need for changes\n")
-		return nil
+		return applyRegionFloors(ctx, appConfig, bounds, nil)
 	}
 
 	fmt.Fprintf(io.Out, "App '%s' is going to be scaled according to this plan:\n", appName)
@@ -157,7 +159,11 @@ func runMachinesScaleCount(ctx context.Context, appName string, appConfig *appco
 		}
 	}
 
-	return updatePool.Wait()
+	if err := updatePool.Wait(); err != nil {
+		return err
+	}
+
+	return applyRegionFloors(ctx, appConfig, bounds, machines)
 }
 
 func launchMachine(ctx context.Context, action *planItem, idx int) (*fly.Machine, error) {
@@ -240,7 +246,7 @@ func (pi *planItem) MachineSize() string {
 	return ""
 }
 
-func computeActions(machines []*fly.Machine, expectedGroupCounts map[string]int, regions []string, maxPerRegion int, defaults *defaultValues) ([]*planItem, error) {
+func computeActions(machines []*fly.Machine, expectedGroupCounts map[string]int, regions []string, bounds map[string]map[string]regionBounds, defaults *defaultValues) ([]*planItem, error) {
 	actions := make([]*planItem, 0)
 	seenGroups := make(map[string]bool)
 	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string {
@@ -263,9 +269,9 @@ func computeActions(machines []*fly.Machine, expectedGroupCounts map[string]int,
 			return k, len(v)
 		})
 
-		regionDiffs, err := convergeGroupCounts(expected, currentPerRegionCount, regions, maxPerRegion)
+		regionDiffs, err := convergeRegionCounts(expected, currentPerRegionCount, regions, bounds[groupName])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("can't scale group '%s': %w", groupName, err)
 		}
 
 		mConfig := groupMachines[0].Config
@@ -296,9 +302,9 @@ func computeActions(machines []*fly.Machine, expectedGroupCounts map[string]int,
 			return nil, err
 		}
 
-		regionDiffs, err := convergeGroupCounts(expected, nil, regions, maxPerRegion)
+		regionDiffs, err := convergeRegionCounts(expected, nil, regions, bounds[groupName])
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("can't scale group '%s': %w", groupName, err)
 		}
 
 		for region, delta := range regionDiffs {
@@ -316,29 +322,103 @@ func computeActions(machines []*fly.Machine, expectedGroupCounts map[string]int,
 	return actions, nil
 }
 
-var MaxPerRegionError = errors.New("the number of regions by the maximum machines per region is fewer than the expected total")
+var (
+	MaxPerRegionError = errors.New("the number of regions by the maximum machines per region is fewer than the expected total")
+	MinPerRegionError = errors.New("the minimum machines per region add up to more than the expected total")
+)
+
+// regionBounds limits the number of machines of a process group in a region.
+type regionBounds struct {
+	// Min is the number of machines always kept running, negative if unset.
+	Min int
+	// Max is the maximum number of machines, negative if unbounded.
+	Max int
+}
+
+// groupRegionBounds resolves the bounds of each region for every group being
+// scaled. The --min and --max flags apply to all regions and take precedence
+// over the [[region_scaling]] sections of the app config.
+func groupRegionBounds(appConfig *appconfig.Config, groups map[string]int, regions []string, maxPerRegion, minPerRegion int) map[string]map[string]regionBounds {
+	bounds := make(map[string]map[string]regionBounds)
+
+	for groupName := range groups {
+		configured := appConfig.RegionScalingForGroup(groupName)
+		bounds[groupName] = make(map[string]regionBounds)
+
+		for _, region := range regions {
+			b := regionBounds{Min: minPerRegion, Max: maxPerRegion}
+			if rs, ok := configured[region]; ok {
+				if b.Min < 0 && rs.MinMachines != nil {
+					b.Min = *rs.MinMachines
+				}
+				if b.Max < 0 && rs.MaxMachines != nil {
+					b.Max = *rs.MaxMachines
+				}
+			}
+			bounds[groupName][region] = b
+		}
+	}
+
+	return bounds
+}
 
 func convergeGroupCounts(expectedTotal int, current map[string]int, regions []string, maxPerRegion int) (map[string]int, error) {
+	if len(regions) == 0 {
+		regions = lo.Keys(current)
+	}
+
+	bounds := make(map[string]regionBounds, len(regions))
+	for _, region := range regions {
+		bounds[region] = regionBounds{Min: -1, Max: maxPerRegion}
+	}
+
+	return convergeRegionCounts(expectedTotal, current, regions, bounds)
+}
+
+// convergeRegionCounts computes how many machines to add or remove in each
+// region to reach expectedTotal while keeping every region within its bounds.
+// Regions missing from bounds are unbounded.
+func convergeRegionCounts(expectedTotal int, current map[string]int, regions []string, bounds map[string]regionBounds) (map[string]int, error) {
 	diffs := make(map[string]int)
 
 	if len(regions) == 0 {
 		regions = lo.Keys(current)
 	}
 
-	if maxPerRegion >= 0 {
-		if len(regions)*maxPerRegion < expectedTotal {
-			return nil, MaxPerRegionError
+	boundsFor := func(region string) regionBounds {
+		b, ok := bounds[region]
+		if !ok {
+			return regionBounds{Min: -1, Max: -1}
+		}
+		return b
+	}
+
+	minTotal, maxTotal, capped := 0, 0, true
+	for _, region := range regions {
+		b := boundsFor(region)
+		minTotal += max(b.Min, 0)
+		if b.Max < 0 {
+			capped = false
+		} else {
+			maxTotal += b.Max
 		}
 
-		// Compute the diff to any region with more machines than the maximum allowed
-		for _, region := range regions {
-			c := current[region]
-			if c > maxPerRegion {
-				diffs[region] = maxPerRegion - c
-			}
+		// Compute the diff to any region out of its bounds
+		switch c := current[region]; {
+		case b.Max >= 0 && c > b.Max:
+			diffs[region] = b.Max - c
+		case c < b.Min:
+			diffs[region] = b.Min - c
 		}
 	}
 
+	if capped && maxTotal < expectedTotal {
+		return nil, MaxPerRegionError
+	}
+	if minTotal > expectedTotal {
+		return nil, MinPerRegionError
+	}
+
 	diff := expectedTotal
 	for _, region := range regions {
 		diff -= (current[region] + diffs[region])
@@ -347,7 +427,7 @@ func convergeGroupCounts(expectedTotal int, current map[string]int, regions []st
 	idx := 0
 	for diff > 0 {
 		region := regions[idx%(len(regions))]
-		if maxPerRegion < 0 || current[region]+diffs[region] < maxPerRegion {
+		if b := boundsFor(region); b.Max < 0 || current[region]+diffs[region] < b.Max {
 			diffs[region]++
 			diff--
 		}
@@ -359,7 +439,7 @@ func convergeGroupCounts(expectedTotal int, current map[string]int, regions []st
 	idx = -1
 	for diff < 0 {
 		region := regions[-idx%(len(regions))]
-		if current[region]+diffs[region] > 0 {
+		if current[region]+diffs[region] > max(boundsFor(region).Min, 0) {
 			diffs[region]--
 			diff++
 		}
@@ -137,3 +137,58 @@ func Test_convergeGroupCounts_maxPerRegion(t *testing.T) {
 		})
 	}
 }
+
+func Test_convergeRegionCounts(t *testing.T) {
+	_, err := convergeRegionCounts(1, nil, []string{"fra", "ams"}, map[string]regionBounds{
+		"fra": {Min: 1, Max: -1},
+		"ams": {Min: 1, Max: -1},
+	})
+	assert.Equal(t, MinPerRegionError, err)
+
+	_, err = convergeRegionCounts(10, nil, []string{"fra", "ams"}, map[string]regionBounds{
+		"fra": {Min: -1, Max: 5},
+		"ams": {Min: -1, Max: 2},
+	})
+	assert.Equal(t, MaxPerRegionError, err)
+
+	testcases := []struct {
+		name          string
+		want          map[string]int
+		expectedTotal int
+		current       map[string]int
+		regions       []string
+		bounds        map[string]regionBounds
+	}{
+		{
+			name:          "Fill the minimum of each region first",
+			want:          map[string]int{"fra": 1, "ams": 2},
+			current:       map[string]int{"ams": 1},
+			expectedTotal: 4,
+			regions:       []string{"fra", "ams"},
+			bounds:        map[string]regionBounds{"ams": {Min: 3, Max: -1}},
+		},
+		{
+			name:          "Respect each region maximum",
+			want:          map[string]int{"fra": 5, "ams": 1},
+			expectedTotal: 6,
+			regions:       []string{"fra", "ams"},
+			bounds:        map[string]regionBounds{"fra": {Min: 1, Max: 5}, "ams": {Min: -1, Max: 1}},
+		},
+		{
+			name:          "Don't remove machines below the minimum",
+			want:          map[string]int{"fra": -2},
+			current:       map[string]int{"fra": 3, "ams": 2},
+			expectedTotal: 3,
+			regions:       []string{"fra", "ams"},
+			bounds:        map[string]regionBounds{"ams": {Min: 2, Max: -1}},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := convergeRegionCounts(tc.expectedTotal, tc.current, tc.regions, tc.bounds)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/pricing"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// costEstimate is the estimated monthly cost of the machines of a process
+// group before and after a change.
+type costEstimate struct {
+	Group          string
+	Machines       int
+	CurrentSizes   []string
+	Sizes          []string
+	CurrentMonthly *float64 `json:",omitempty"`
+	Monthly        *float64 `json:",omitempty"`
+}
+
+// estimateGuestChanges estimates the cost of moving each of machines to the
+// guest guests holds for it, by process group.
+func estimateGuestChanges(machines []*fly.Machine, guests map[string]*fly.MachineGuest, prices *pricing.Prices) []*costEstimate {
+	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string { return m.ProcessGroup() })
+	groupNames := lo.Keys(machineGroups)
+	slices.Sort(groupNames)
+
+	return lo.Map(groupNames, func(group string, _ int) *costEstimate {
+		current := lo.Map(machineGroups[group], func(m *fly.Machine, _ int) *fly.MachineGuest { return m.Config.Guest })
+		next := lo.Map(machineGroups[group], func(m *fly.Machine, _ int) *fly.MachineGuest { return guests[m.ID] })
+
+		e := &costEstimate{
+			Group:        group,
+			Machines:     len(current),
+			CurrentSizes: pricing.FormatSizes(current),
+			Sizes:        pricing.FormatSizes(next),
+		}
+		if price, ok := prices.MachinesMonthly(current); ok {
+			e.CurrentMonthly = &price
+		}
+		if price, ok := prices.MachinesMonthly(next); ok {
+			e.Monthly = &price
+		}
+		return e
+	})
+}
+
+func renderCostEstimate(w io.Writer, estimates []*costEstimate) error {
+	var (
+		total float64
+		known = true
+	)
+
+	rows := lo.Map(estimates, func(e *costEstimate, _ int) []string {
+		current, next, delta := "unknown", "unknown", "unknown"
+		if e.CurrentMonthly != nil {
+			current = pricing.Format(*e.CurrentMonthly)
+		}
+		if e.Monthly != nil {
+			next = pricing.Format(*e.Monthly)
+		}
+		if e.CurrentMonthly != nil && e.Monthly != nil {
+			delta = pricing.FormatDelta(*e.Monthly - *e.CurrentMonthly)
+			total += *e.Monthly - *e.CurrentMonthly
+		} else {
+			known = false
+		}
+		return []string{
+			e.Group,
+			fmt.Sprint(e.Machines),
+			strings.Join(e.CurrentSizes, ", "),
+			strings.Join(e.Sizes, ", "),
+			current,
+			next,
+			delta,
+		}
+	})
+
+	if err := render.Table(w, "", rows, "Group", "Machines", "Current", "New", "Current Cost", "New Cost", "Monthly Change"); err != nil {
+		return err
+	}
+	if known {
+		fmt.Fprintf(w, "Estimated monthly cost change: %s\n", pricing.FormatDelta(total))
+	}
+	return nil
+}
+
+// estimateScaleVM prints the estimated cost change of scaling the machines of
+// groups to sizeName and memoryMB, without changing them.
+func estimateScaleVM(ctx context.Context, appName string, groups []string, sizeName string, memoryMB int) error {
+	io := iostreams.FromContext(ctx)
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppName: appName,
+	})
+	if err != nil {
+		return err
+	}
+	ctx = flaps.NewContext(ctx, flapsClient)
+
+	if sizeName != "" {
+		if err := validateSizeName(sizeName); err != nil {
+			return err
+		}
+	}
+
+	machines, guests, err := resolveScaleVMTargets(ctx, appName, groups, sizeName, memoryMB)
+	if err != nil {
+		return err
+	}
+
+	prices, err := pricing.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Estimated monthly cost of scaling app %s, no machines were changed:\n\n", appName)
+	return renderCostEstimate(io.Out, estimateGuestChanges(machines, guests, prices))
+}
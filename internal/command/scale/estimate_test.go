@@ -0,0 +1,42 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/pricing"
+)
+
+func Test_estimateGuestChanges(t *testing.T) {
+	metadata := map[string]string{fly.MachineConfigMetadataKeyFlyProcessGroup: "app"}
+	machines := []*fly.Machine{
+		{ID: "a", Config: &fly.MachineConfig{Guest: &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}, Metadata: metadata}},
+		{ID: "b", Config: &fly.MachineConfig{Guest: &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 512}, Metadata: metadata}},
+	}
+	guests := map[string]*fly.MachineGuest{
+		"a": {CPUKind: "shared", CPUs: 2, MemoryMB: 512},
+		"b": {CPUKind: "shared", CPUs: 2, MemoryMB: 512},
+	}
+	prices := pricing.New(map[string]float64{"shared-cpu-1x": 2})
+
+	got := estimateGuestChanges(machines, guests, prices)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "app", got[0].Group)
+	assert.Equal(t, []string{"shared-cpu-1x/256MB", "shared-cpu-1x/512MB"}, got[0].CurrentSizes)
+	assert.Equal(t, []string{"shared-cpu-2x/512MB"}, got[0].Sizes)
+	assert.InDelta(t, 5.25, *got[0].CurrentMonthly, 0.001)
+	// No price is known for shared-cpu-2x
+	assert.Nil(t, got[0].Monthly)
+}
+
+func Test_estimateGuestChangesWithoutGuest(t *testing.T) {
+	machines := []*fly.Machine{{ID: "a", Config: &fly.MachineConfig{}}}
+	guests := map[string]*fly.MachineGuest{"a": {CPUKind: "shared", CPUs: 1, MemoryMB: 256}}
+
+	got := estimateGuestChanges(machines, guests, pricing.New(map[string]float64{"shared-cpu-1x": 2}))
+	assert.Len(t, got, 1)
+	assert.Empty(t, got[0].CurrentSizes)
+	assert.Equal(t, []string{"shared-cpu-1x/256MB"}, got[0].Sizes)
+	assert.Nil(t, got[0].CurrentMonthly)
+}
@@ -0,0 +1,231 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/internal/pricing"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newScaleGPU() *cobra.Command {
+	const (
+		short = "Change the GPUs attached to an app's machines"
+		long  = `Change the GPU kind and number of GPUs of each machine in a process group.
+
+Machines without GPUs are moved to the CPU and memory of the matching GPU
+preset. The GPU kind must be available in every region the group runs in, use
+'fly platform regions' to list regions with GPUs.
+
+For pricing, see https://fly.io/docs/about/pricing/#gpus-and-fly-machines`
+	)
+	cmd := command.New("gpu", short, long, runScaleGPU,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+		flag.ProcessGroup("The process group to scale"),
+		flag.String{Name: "kind", Description: "The GPU kind, e.g. a100-40gb, a100-80gb, l40s or a10"},
+		flag.Int{Name: "count", Description: "Number of GPUs per machine", Default: 1},
+	)
+	return cmd
+}
+
+func runScaleGPU(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+
+	kind, err := flag.ParseGPUKind(flag.GetString(ctx, "kind"))
+	if err != nil {
+		return fmt.Errorf("--kind %w", err)
+	}
+	count := flag.GetInt(ctx, "count")
+	if count < 1 {
+		return fmt.Errorf("--count must be greater than zero, got: %d", count)
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppName: appName,
+	})
+	if err != nil {
+		return err
+	}
+	ctx = flaps.NewContext(ctx, flapsClient)
+
+	group := flag.GetProcessGroup(ctx)
+	if group == "" {
+		appConfig, err := appconfig.FromRemoteApp(ctx, appName)
+		if err != nil {
+			return err
+		}
+		if len(appConfig.Processes) > 1 {
+			return fmt.Errorf("scaling an app with multiple process groups requires specifying a group with '--process-group <name>'\n * this app has the following process groups: %v", appConfig.FormatProcessNames())
+		}
+		group = appConfig.DefaultProcessName()
+	}
+
+	machines, err := listMachinesWithGroup(ctx, group)
+	if err != nil {
+		return err
+	}
+	if len(machines) == 0 {
+		return fmt.Errorf("No active machines in process group '%s', check `fly status` output", group)
+	}
+
+	if err := checkGPURegions(kind, machines); err != nil {
+		return err
+	}
+
+	// Validate every resulting guest before touching any machine
+	guests := make(map[string]*fly.MachineGuest, len(machines))
+	unique := make(map[string]*fly.MachineGuest)
+	for _, m := range machines {
+		guest := gpuGuest(m.Config.Guest, kind, count)
+		guests[m.ID] = guest
+		unique[fmt.Sprintf("%+v", *guest)] = guest
+	}
+	for _, guest := range unique {
+		if err := preflightGuest(ctx, appName, guest); err != nil {
+			return err
+		}
+	}
+
+	prices, err := pricing.Fetch(ctx)
+	if err != nil {
+		fmt.Fprintf(io.ErrOut, "Warning: %v\n", err)
+	}
+
+	var (
+		rows          [][]string
+		before, after float64
+		priced        = true
+	)
+	for _, m := range machines {
+		guest := guests[m.ID]
+		current, okCurrent := prices.MachineMonthly(m.Config.Guest)
+		next, okNext := prices.MachineMonthly(guest)
+		priced = priced && okCurrent && okNext
+		before += current
+		after += next
+
+		rows = append(rows, []string{
+			m.ID,
+			m.Region,
+			formatGuestGPUs(m.Config.Guest),
+			formatGuestGPUs(guest),
+			lo.Ternary(okNext, pricing.Format(next), "unknown"),
+		})
+	}
+
+	fmt.Fprintf(io.Out, "Machines of group '%s' are going to be scaled to %d %s GPUs each:\n\n", group, count, kind)
+	render.Table(io.Out, "", rows, "Machine", "Region", "Current", "New", "Monthly Cost")
+	if priced {
+		fmt.Fprintf(io.Out, "Estimated monthly cost change: %s\n", pricing.FormatDelta(after-before))
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Scale %d machines?", len(machines)); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	machines, releaseFunc, err := mach.AcquireLeases(ctx, machines)
+	defer releaseFunc()
+	if err != nil {
+		return err
+	}
+
+	for i, m := range machines {
+		m.Config.Guest = guests[m.ID]
+		input := &fly.LaunchMachineInput{
+			Name:   m.Name,
+			Region: m.Region,
+			Config: m.Config,
+		}
+		if err := mach.Update(ctx, m, input); err != nil {
+			if i > 0 {
+				fmt.Fprintf(io.ErrOut, "Updated %d of %d machines before failing, rerun the command to scale the remaining ones\n", i, len(machines))
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkGPURegions fails if any of machines runs in a region without GPUs of
+// the given kind.
+func checkGPURegions(kind string, machines []*fly.Machine) error {
+	unavailable := lo.Uniq(lo.FilterMap(machines, func(m *fly.Machine, _ int) (string, bool) {
+		return m.Region, !mach.GPUAvailableIn(kind, m.Region)
+	}))
+	if len(unavailable) == 0 {
+		return nil
+	}
+	slices.Sort(unavailable)
+
+	return fmt.Errorf("%s GPUs are not available in %s\n * they are available in %s, move the machines with 'fly scale count --region' first",
+		kind, strings.Join(unavailable, ", "), strings.Join(mach.GPURegions(kind), ", "))
+}
+
+// gpuGuest returns a copy of current with count GPUs of the given kind. Guests
+// without GPUs get the CPUs and memory of the kind's preset, keeping their
+// memory if it's larger.
+func gpuGuest(current *fly.MachineGuest, kind string, count int) *fly.MachineGuest {
+	guest := helpers.Clone(current)
+	if guest == nil {
+		guest = &fly.MachineGuest{}
+	}
+
+	if guest.GPUKind == "" {
+		for _, preset := range fly.MachinePresets {
+			if preset.GPUKind != kind {
+				continue
+			}
+			guest.CPUKind = preset.CPUKind
+			guest.CPUs = preset.CPUs
+			guest.MemoryMB = max(guest.MemoryMB, preset.MemoryMB)
+			break
+		}
+	}
+
+	guest.GPUKind = kind
+	guest.GPUs = count
+	return guest
+}
+
+func formatGuestGPUs(guest *fly.MachineGuest) string {
+	switch {
+	case guest == nil:
+		return "unknown"
+	case guest.GPUKind == "":
+		return fmt.Sprintf("%s (no GPUs)", guest.ToSize())
+	default:
+		return fmt.Sprintf("%dx %s", guest.GPUs, guest.GPUKind)
+	}
+}
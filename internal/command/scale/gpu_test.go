@@ -0,0 +1,32 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func Test_gpuGuest(t *testing.T) {
+	// Machines without GPUs get the CPUs of the GPU preset
+	got := gpuGuest(&fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}, "a100-pcie-40gb", 2)
+	assert.Equal(t, &fly.MachineGuest{CPUKind: "performance", CPUs: 8, MemoryMB: 32768, GPUKind: "a100-pcie-40gb", GPUs: 2}, got)
+
+	// Machines with GPUs keep their CPUs and memory
+	got = gpuGuest(&fly.MachineGuest{CPUKind: "performance", CPUs: 16, MemoryMB: 65536, GPUKind: "a10", GPUs: 1}, "l40s", 1)
+	assert.Equal(t, &fly.MachineGuest{CPUKind: "performance", CPUs: 16, MemoryMB: 65536, GPUKind: "l40s", GPUs: 1}, got)
+}
+
+func Test_checkGPURegions(t *testing.T) {
+	machines := []*fly.Machine{{Region: "iad"}, {Region: "ams"}}
+	assert.NoError(t, checkGPURegions("a100-sxm4-80gb", machines))
+
+	err := checkGPURegions("l40s", append(machines, &fly.Machine{Region: "iad"}))
+	assert.ErrorContains(t, err, "l40s GPUs are not available in ams, iad")
+}
+
+func Test_formatGuestGPUs(t *testing.T) {
+	assert.Equal(t, "unknown", formatGuestGPUs(nil))
+	assert.Equal(t, "shared-cpu-1x (no GPUs)", formatGuestGPUs(&fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}))
+	assert.Equal(t, "2x l40s", formatGuestGPUs(&fly.MachineGuest{CPUKind: "performance", CPUs: 8, GPUKind: "l40s", GPUs: 2}))
+}
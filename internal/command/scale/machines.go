@@ -7,12 +7,16 @@ import (
 	"github.com/samber/lo"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/iostreams"
 )
 
-func v2ScaleVM(ctx context.Context, appName, group, sizeName string, memoryMB int) (*fly.VMSize, error) {
+func v2ScaleVM(ctx context.Context, appName string, groups []string, sizeName string, memoryMB int) (*fly.VMSize, error) {
+	io := iostreams.FromContext(ctx)
+
 	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
 		AppName: appName,
 	})
@@ -22,27 +26,27 @@ func v2ScaleVM(ctx context.Context, appName, group, sizeName string, memoryMB in
 	ctx = flaps.NewContext(ctx, flapsClient)
 
 	// Quickly validate sizeName before any network call
-	if err := (&fly.MachineGuest{}).SetSize(sizeName); err != nil && sizeName != "" {
-		return nil, err
-	}
-
-	if group == "" {
-		appConfig, err := appconfig.FromRemoteApp(ctx, appName)
-		if err != nil {
+	if sizeName != "" {
+		if err := validateSizeName(sizeName); err != nil {
 			return nil, err
 		}
-		if len(appConfig.Processes) > 1 {
-			return nil, fmt.Errorf("scaling an app with multiple process groups requires specifying a group with '--process-group <name>'\n * this app has the following process groups: %v", appConfig.FormatProcessNames())
-		}
-		group = appConfig.DefaultProcessName()
 	}
 
-	machines, err := listMachinesWithGroup(ctx, group)
+	machines, guests, err := resolveScaleVMTargets(ctx, appName, groups, sizeName, memoryMB)
 	if err != nil {
 		return nil, err
 	}
-	if len(machines) == 0 {
-		return nil, fmt.Errorf("No active machines in process group '%s', check `fly status` output", group)
+
+	// Validate the resulting guest of every machine up front so we don't
+	// leave the app half scaled because one of them can't take the new size.
+	unique := make(map[string]*fly.MachineGuest, len(guests))
+	for _, guest := range guests {
+		unique[fmt.Sprintf("%+v", *guest)] = guest
+	}
+	for _, guest := range unique {
+		if err := preflightGuest(ctx, appName, guest); err != nil {
+			return nil, err
+		}
 	}
 
 	machines, releaseFunc, err := mach.AcquireLeases(ctx, machines)
@@ -51,13 +55,8 @@ func v2ScaleVM(ctx context.Context, appName, group, sizeName string, memoryMB in
 		return nil, err
 	}
 
-	for _, machine := range machines {
-		if sizeName != "" {
-			machine.Config.Guest.SetSize(sizeName)
-		}
-		if memoryMB > 0 {
-			machine.Config.Guest.MemoryMB = memoryMB
-		}
+	for i, machine := range machines {
+		machine.Config.Guest = guests[machine.ID]
 
 		input := &fly.LaunchMachineInput{
 			Name:   machine.Name,
@@ -65,6 +64,9 @@ func v2ScaleVM(ctx context.Context, appName, group, sizeName string, memoryMB in
 			Config: machine.Config,
 		}
 		if err := mach.Update(ctx, machine, input); err != nil {
+			if i > 0 {
+				fmt.Fprintf(io.ErrOut, "Updated %d of %d machines before failing, rerun the command to scale the remaining ones\n", i, len(machines))
+			}
 			return nil, err
 		}
 	}
@@ -79,6 +81,48 @@ func v2ScaleVM(ctx context.Context, appName, group, sizeName string, memoryMB in
 	return size, nil
 }
 
+// resolveScaleVMTargets lists the machines of the given process groups, or of
+// the only group of the app, along with the guest each of them gets once
+// scaled, keyed by machine ID.
+func resolveScaleVMTargets(ctx context.Context, appName string, groups []string, sizeName string, memoryMB int) ([]*fly.Machine, map[string]*fly.MachineGuest, error) {
+	if len(groups) == 0 {
+		appConfig, err := appconfig.FromRemoteApp(ctx, appName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(appConfig.Processes) > 1 {
+			return nil, nil, fmt.Errorf("scaling an app with multiple process groups requires specifying a group with '--process-group <name>'\n * this app has the following process groups: %v", appConfig.FormatProcessNames())
+		}
+		groups = []string{appConfig.DefaultProcessName()}
+	}
+
+	var machines []*fly.Machine
+	for _, group := range groups {
+		groupMachines, err := listMachinesWithGroup(ctx, group)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(groupMachines) == 0 {
+			return nil, nil, fmt.Errorf("No active machines in process group '%s', check `fly status` output", group)
+		}
+		machines = append(machines, groupMachines...)
+	}
+
+	guests := make(map[string]*fly.MachineGuest, len(machines))
+	for _, machine := range machines {
+		guest := helpers.Clone(machine.Config.Guest)
+		if sizeName != "" {
+			guest.SetSize(sizeName)
+		}
+		if memoryMB > 0 {
+			guest.MemoryMB = memoryMB
+		}
+		guests[machine.ID] = guest
+	}
+
+	return machines, guests, nil
+}
+
 func listMachinesWithGroup(ctx context.Context, group string) ([]*fly.Machine, error) {
 	machines, err := mach.ListActive(ctx)
 	if err != nil {
@@ -23,7 +23,7 @@ func newScaleMemory() *cobra.Command {
 	flag.Add(cmd,
 		flag.App(),
 		flag.AppConfig(),
-		flag.ProcessGroup("The process group to apply the VM size to"),
+		flag.ProcessGroup("Comma separated list of process groups to apply the memory size to"),
 	)
 	return cmd
 }
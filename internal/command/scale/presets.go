@@ -0,0 +1,122 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+	mach "github.com/superfly/flyctl/internal/machine"
+)
+
+// presetFits reports whether memoryMB is a valid amount of memory for preset.
+func presetFits(preset *fly.MachineGuest, memoryMB int) bool {
+	guest := *preset
+	guest.MemoryMB = memoryMB
+	return mach.ValidateGuest(&guest) == nil
+}
+
+// nearestPreset returns the name of the smallest guest preset that can
+// accommodate the memory of guest, preferring presets of the same CPU kind.
+// GPU presets are only considered for guests that already have a GPU.
+func nearestPreset(guest *fly.MachineGuest) string {
+	candidates := lo.PickBy(fly.MachinePresets, func(_ string, p *fly.MachineGuest) bool {
+		return (p.GPUKind != "") == (guest.GPUKind != "")
+	})
+
+	best, bestScore := "", math.MaxInt
+	for name, p := range candidates {
+		score := p.CPUs
+		if p.CPUKind != guest.CPUKind {
+			score += 1000
+		}
+		if !presetFits(p, guest.MemoryMB) {
+			// Doesn't fit, rank by how far off the preset's default memory is
+			score += 100000 + int(math.Abs(float64(p.MemoryMB-guest.MemoryMB)))
+		}
+		if score < bestScore || (score == bestScore && name < best) {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+var presetCPUsRegexp = regexp.MustCompile(`^(shared-cpu|performance)-(\d+)x$`)
+
+// validateSizeName checks that sizeName is a known guest preset. For unknown
+// names that look like a preset, it suggests the closest existing one.
+func validateSizeName(sizeName string) error {
+	if _, ok := fly.MachinePresets[sizeName]; ok {
+		return nil
+	}
+
+	err := (&fly.MachineGuest{}).SetSize(sizeName)
+
+	m := presetCPUsRegexp.FindStringSubmatch(sizeName)
+	if m == nil {
+		return err
+	}
+	cpus, _ := strconv.Atoi(m[2])
+
+	suggestion := ""
+	for name, p := range fly.MachinePresets {
+		if !strings.HasPrefix(name, m[1]) || p.GPUKind != "" || p.CPUs < cpus {
+			continue
+		}
+		if suggestion == "" || p.CPUs < fly.MachinePresets[suggestion].CPUs {
+			suggestion = name
+		}
+	}
+	if suggestion == "" {
+		return err
+	}
+	return fmt.Errorf("%w\n * the nearest valid size is '%s'", err, suggestion)
+}
+
+// preflightGuest checks that guest is valid and that the organization owning
+// appName is allowed to run it.
+func preflightGuest(ctx context.Context, appName string, guest *fly.MachineGuest) error {
+	if err := mach.ValidateGuest(guest); err != nil {
+		if preset := nearestPreset(guest); preset != "" {
+			return fmt.Errorf("%w\n * %s\n * the nearest preset with %dMiB of memory is '%s', try 'fly scale vm %s --vm-memory %d'",
+				err, err.(mach.InvalidConfigErr).Suggestion(), guest.MemoryMB, preset, preset, guest.MemoryMB)
+		}
+		return err
+	}
+
+	_ = `# @genqlient
+	query GetAppOrganizationBilling($appName: String!) {
+		app(name: $appName) {
+			organization {
+				slug
+				billingStatus
+				trust
+			}
+		}
+	}
+	`
+
+	client := fly.ClientFromContext(ctx).GenqClient
+	resp, err := gql.GetAppOrganizationBilling(ctx, client, appName)
+	if err != nil {
+		return fmt.Errorf("failed checking organization limits: %w", err)
+	}
+	org := resp.App.Organization
+
+	switch {
+	case org.Trust == gql.OrganizationTrustBanned:
+		return fmt.Errorf("organization %s is not allowed to run machines", org.Slug)
+	case org.BillingStatus == gql.BillingStatusSourceRequired && guest.CPUKind != "shared":
+		return fmt.Errorf("organization %s needs a payment method to use %s machines, add one at https://fly.io/dashboard/%s/billing",
+			org.Slug, guest.ToSize(), org.Slug)
+	case org.Trust == gql.OrganizationTrustRestricted && guest.GPUKind != "":
+		return fmt.Errorf("organization %s is not allowed to use GPU machines yet, contact billing@fly.io", org.Slug)
+	}
+
+	return nil
+}
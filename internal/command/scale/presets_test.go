@@ -0,0 +1,32 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func Test_nearestPreset(t *testing.T) {
+	testcases := []struct {
+		guest fly.MachineGuest
+		want  string
+	}{
+		{fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 4096}, "shared-cpu-2x"},
+		{fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 512}, "shared-cpu-1x"},
+		{fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 32768}, "performance-4x"},
+		{fly.MachineGuest{CPUKind: "performance", CPUs: 1, MemoryMB: 16384}, "performance-2x"},
+		{fly.MachineGuest{CPUKind: "performance", CPUs: 8, MemoryMB: 1024, GPUKind: "a10", GPUs: 1}, "a10"},
+	}
+
+	for _, tc := range testcases {
+		assert.Equal(t, tc.want, nearestPreset(&tc.guest), tc.guest.String())
+	}
+}
+
+func Test_validateSizeName(t *testing.T) {
+	assert.NoError(t, validateSizeName("shared-cpu-1x"))
+	assert.ErrorContains(t, validateSizeName("shared-cpu-3x"), "'shared-cpu-4x'")
+	assert.ErrorContains(t, validateSizeName("performance-32x"), "invalid machine size")
+	assert.Error(t, validateSizeName("huge"))
+}
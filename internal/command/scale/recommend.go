@@ -0,0 +1,344 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/pricing"
+	"github.com/superfly/flyctl/internal/prometheus"
+	"github.com/superfly/flyctl/internal/prompt"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const (
+	// targetCPUUtilization is the share of its CPUs a machine should use at
+	// its 95th percentile load.
+	targetCPUUtilization = 0.7
+	// memoryHeadroom is applied on top of the 95th percentile memory usage.
+	memoryHeadroom = 1.3
+)
+
+func newScaleRecommend() *cobra.Command {
+	const (
+		short = "Recommend VM sizes based on recent usage"
+		long  = `Analyze the CPU and memory usage of each process group over the last days
+and recommend a VM size and count for it, along with the estimated change in
+monthly cost.
+
+Recommendations target running at most 70% of the CPUs and keeping 30% of
+memory free at the 95th percentile of usage. Use --apply to scale the app
+according to the recommendations.`
+	)
+	cmd := command.New("recommend", short, long, runScaleRecommend,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+		flag.JSONOutput(),
+		flag.ProcessGroup("Only recommend sizes for this process group"),
+		flag.Int{Name: "days", Description: "Number of days of metrics to analyze", Default: 7},
+		flag.Bool{Name: "apply", Description: "Scale the app according to the recommendations"},
+	)
+	return cmd
+}
+
+// machineUsage is the 95th percentile resource usage of a single machine.
+type machineUsage struct {
+	CPUs     float64
+	MemoryMB float64
+}
+
+type recommendation struct {
+	Group            string
+	Machines         int
+	CurrentSize      string
+	CurrentMemoryMB  int
+	CPUUsageP95      float64
+	MemoryUsageP95MB float64
+	Size             string
+	MemoryMB         int
+	Count            int
+	MonthlyDelta     *float64 `json:",omitempty"`
+	Note             string   `json:",omitempty"`
+
+	guest *fly.MachineGuest
+}
+
+func (r *recommendation) changed() bool {
+	return r.guest != nil && (r.Size != r.CurrentSize || r.MemoryMB != r.CurrentMemoryMB || r.Count != r.Machines)
+}
+
+func runScaleRecommend(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+	apiClient := fly.ClientFromContext(ctx)
+
+	days := flag.GetInt(ctx, "days")
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	app, err := apiClient.GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppCompact: app,
+		AppName:    appName,
+	})
+	if err != nil {
+		return err
+	}
+	ctx = flaps.NewContext(ctx, flapsClient)
+
+	machines, _, err := flapsClient.ListFlyAppsMachines(ctx)
+	if err != nil {
+		return err
+	}
+	if group := flag.GetProcessGroup(ctx); group != "" {
+		machines = lo.Filter(machines, func(m *fly.Machine, _ int) bool { return m.ProcessGroup() == group })
+	}
+	if len(machines) == 0 {
+		return fmt.Errorf("app %s has no machines to analyze", appName)
+	}
+
+	usage, err := fetchMachineUsage(ctx, app.Organization.Slug, appName, days)
+	if err != nil {
+		return err
+	}
+
+	prices, err := pricing.Fetch(ctx)
+	if err != nil {
+		// Recommendations are still useful without the cost estimate
+		fmt.Fprintf(io.ErrOut, "Warning: %v\n", err)
+	}
+
+	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string { return m.ProcessGroup() })
+	groupNames := lo.Keys(machineGroups)
+	slices.Sort(groupNames)
+
+	recs := lo.Map(groupNames, func(group string, _ int) *recommendation {
+		return recommendGroup(group, machineGroups[group], usage, prices)
+	})
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, recs)
+	}
+
+	rows := lo.Map(recs, func(r *recommendation, _ int) []string {
+		if r.guest == nil {
+			current := lo.Ternary(r.CurrentSize == "", "unknown", fmt.Sprintf("%s/%dMB", r.CurrentSize, r.CurrentMemoryMB))
+			return []string{r.Group, fmt.Sprint(r.Machines), current, "-", "-", r.Note, "", ""}
+		}
+		delta := "unknown"
+		if r.MonthlyDelta != nil {
+			delta = pricing.FormatDelta(*r.MonthlyDelta)
+		}
+		recommended := fmt.Sprintf("%s/%dMB", r.Size, r.MemoryMB)
+		if !r.changed() {
+			recommended = "keep"
+			delta = pricing.FormatDelta(0)
+		}
+		return []string{
+			r.Group,
+			fmt.Sprint(r.Machines),
+			fmt.Sprintf("%s/%dMB", r.CurrentSize, r.CurrentMemoryMB),
+			fmt.Sprintf("%.2f", r.CPUUsageP95),
+			fmt.Sprintf("%.0f MB", r.MemoryUsageP95MB),
+			recommended,
+			fmt.Sprint(r.Count),
+			delta,
+		}
+	})
+
+	fmt.Fprintf(io.Out, "Recommendations for app %s based on the last %d days of usage\n\n", appName, days)
+	render.Table(io.Out, "", rows, "Group", "Machines", "Current", "CPU p95", "Memory p95", "Recommended", "Count", "Monthly Cost")
+
+	toApply := lo.Filter(recs, func(r *recommendation, _ int) bool { return r.changed() })
+	if !flag.GetBool(ctx, "apply") || len(toApply) == 0 {
+		return nil
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Apply recommendations to %d process groups?", len(toApply)); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("--yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	return applyRecommendations(ctx, appName, toApply)
+}
+
+func applyRecommendations(ctx context.Context, appName string, recs []*recommendation) error {
+	io := iostreams.FromContext(ctx)
+
+	counts := make(map[string]int)
+	for _, r := range recs {
+		if r.Size != r.CurrentSize || r.MemoryMB != r.CurrentMemoryMB {
+			fmt.Fprintf(io.Out, "Scaling group '%s' to %s with %dMB of memory\n", r.Group, r.Size, r.MemoryMB)
+			if _, err := v2ScaleVM(ctx, appName, []string{r.Group}, r.Size, r.MemoryMB); err != nil {
+				return err
+			}
+		}
+		if r.Count != r.Machines {
+			counts[r.Group] = r.Count
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	appConfig, err := appconfig.FromRemoteApp(ctx, appName)
+	if err != nil {
+		return err
+	}
+	return runMachinesScaleCount(ctx, appName, appConfig, counts, -1, -1)
+}
+
+// fetchMachineUsage returns the 95th percentile CPU and memory usage over the
+// last days of every machine of the app, keyed by machine ID.
+func fetchMachineUsage(ctx context.Context, orgSlug, appName string, days int) (map[string]machineUsage, error) {
+	client := prometheus.NewClient(ctx, orgSlug)
+
+	// fly_instance_cpu counts centiseconds spent per CPU and mode
+	cpuQuery := fmt.Sprintf(
+		`quantile_over_time(0.95, (sum by (instance) (rate(fly_instance_cpu{app=%q,mode!="idle"}[5m])) / 100)[%dd:5m])`,
+		appName, days)
+	memQuery := fmt.Sprintf(
+		`quantile_over_time(0.95, (sum by (instance) (fly_instance_memory_mem_total{app=%q} - fly_instance_memory_mem_available{app=%q}))[%dd:5m])`,
+		appName, appName, days)
+
+	cpu, err := client.Query(ctx, cpuQuery, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	mem, err := client.Query(ctx, memQuery, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]machineUsage)
+	for _, s := range cpu {
+		u := usage[s.Metric["instance"]]
+		u.CPUs = s.Value
+		usage[s.Metric["instance"]] = u
+	}
+	for _, s := range mem {
+		u := usage[s.Metric["instance"]]
+		u.MemoryMB = s.Value / (1024 * 1024)
+		usage[s.Metric["instance"]] = u
+	}
+	return usage, nil
+}
+
+// recommendGroup sizes a process group so that its busiest machine stays within
+// the target CPU utilization and memory headroom.
+func recommendGroup(group string, machines []*fly.Machine, usage map[string]machineUsage, prices *pricing.Prices) *recommendation {
+	r := &recommendation{
+		Group:    group,
+		Machines: len(machines),
+		Count:    len(machines),
+	}
+
+	m, ok := lo.Find(machines, func(m *fly.Machine) bool { return m.Config != nil && m.Config.Guest != nil })
+	if !ok {
+		r.Note = "machine size unknown"
+		return r
+	}
+	current := m.Config.Guest
+	r.CurrentSize = current.ToSize()
+	r.CurrentMemoryMB = current.MemoryMB
+
+	if current.GPUKind != "" {
+		r.Note = "GPU machines are not analyzed"
+		return r
+	}
+
+	samples := lo.FilterMap(machines, func(m *fly.Machine, _ int) (machineUsage, bool) {
+		u, ok := usage[m.ID]
+		return u, ok
+	})
+	if len(samples) == 0 {
+		r.Note = "no metrics available"
+		return r
+	}
+
+	for _, u := range samples {
+		r.CPUUsageP95 = math.Max(r.CPUUsageP95, u.CPUs)
+		r.MemoryUsageP95MB = math.Max(r.MemoryUsageP95MB, u.MemoryMB)
+	}
+
+	// Presets of the same CPU kind, smallest first
+	presets := lo.Filter(lo.Values(fly.MachinePresets), func(p *fly.MachineGuest, _ int) bool {
+		return p.GPUKind == "" && p.CPUKind == current.CPUKind
+	})
+	slices.SortFunc(presets, func(a, b *fly.MachineGuest) int { return a.CPUs - b.CPUs })
+
+	neededCPUs := r.CPUUsageP95 / targetCPUUtilization
+	increment := 256
+	if current.CPUKind == "performance" {
+		increment = 1024
+	}
+	neededMemory := int(math.Ceil(r.MemoryUsageP95MB*memoryHeadroom/float64(increment))) * increment
+
+	// Pick the smallest preset that fits both CPU and memory needs. When even
+	// the largest one can't keep up with CPU demand, add machines instead.
+	var guest *fly.MachineGuest
+	for _, p := range presets {
+		if float64(p.CPUs) >= neededCPUs && presetFits(p, max(neededMemory, p.MemoryMB)) {
+			guest = helpers.Clone(p)
+			break
+		}
+	}
+	if guest == nil {
+		guest = helpers.Clone(presets[len(presets)-1])
+		if neededCPUs > float64(guest.CPUs) {
+			r.Count = int(math.Ceil(float64(len(machines)) * neededCPUs / float64(guest.CPUs)))
+		}
+	}
+	guest.MemoryMB = max(guest.MemoryMB, neededMemory)
+	if !presetFits(guest, guest.MemoryMB) {
+		guest.MemoryMB = guest.CPUs * lo.Ternary(guest.CPUKind == "performance", fly.MAX_MEMORY_MB_PER_CPU, fly.MAX_MEMORY_MB_PER_SHARED_CPU)
+	}
+
+	r.guest = guest
+	r.Size = guest.ToSize()
+	r.MemoryMB = guest.MemoryMB
+
+	if prices != nil {
+		before, okBefore := prices.MachineMonthly(current)
+		after, okAfter := prices.MachineMonthly(guest)
+		if okBefore && okAfter {
+			delta := after*float64(r.Count) - before*float64(r.Machines)
+			r.MonthlyDelta = &delta
+		}
+	}
+
+	return r
+}
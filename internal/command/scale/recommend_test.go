@@ -0,0 +1,75 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/pricing"
+)
+
+func Test_recommendGroup(t *testing.T) {
+	machinesWith := func(guest fly.MachineGuest, ids ...string) []*fly.Machine {
+		var machines []*fly.Machine
+		for _, id := range ids {
+			g := guest
+			machines = append(machines, &fly.Machine{ID: id, Config: &fly.MachineConfig{Guest: &g}})
+		}
+		return machines
+	}
+	prices := pricing.New(map[string]float64{
+		"shared-cpu-1x":  1.94,
+		"shared-cpu-2x":  3.89,
+		"performance-1x": 31.00,
+		"performance-2x": 62.00,
+	})
+
+	// Oversized: performance-2x barely used
+	r := recommendGroup("app",
+		machinesWith(fly.MachineGuest{CPUKind: "performance", CPUs: 2, MemoryMB: 4096}, "m1", "m2"),
+		map[string]machineUsage{"m1": {CPUs: 0.2, MemoryMB: 900}, "m2": {CPUs: 0.3, MemoryMB: 1200}},
+		prices,
+	)
+	assert.Equal(t, "performance-1x", r.Size)
+	assert.Equal(t, 2048, r.MemoryMB)
+	assert.Equal(t, 2, r.Count)
+	assert.True(t, r.changed())
+	if assert.NotNil(t, r.MonthlyDelta) {
+		assert.InDelta(t, -62.0, *r.MonthlyDelta, 0.01)
+	}
+
+	// Memory bound: shared-cpu-1x running out of memory
+	r = recommendGroup("app",
+		machinesWith(fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}, "m1"),
+		map[string]machineUsage{"m1": {CPUs: 0.1, MemoryMB: 240}},
+		prices,
+	)
+	assert.Equal(t, "shared-cpu-1x", r.Size)
+	assert.Equal(t, 512, r.MemoryMB)
+	if assert.NotNil(t, r.MonthlyDelta) {
+		assert.InDelta(t, 1.25, *r.MonthlyDelta, 0.01)
+	}
+
+	// CPU saturated beyond the largest preset: add machines
+	r = recommendGroup("app",
+		machinesWith(fly.MachineGuest{CPUKind: "shared", CPUs: 8, MemoryMB: 2048}, "m1"),
+		map[string]machineUsage{"m1": {CPUs: 8, MemoryMB: 1000}},
+		prices,
+	)
+	assert.Equal(t, "shared-cpu-8x", r.Size)
+	assert.Equal(t, 2, r.Count)
+	assert.Nil(t, r.MonthlyDelta)
+
+	// No metrics
+	r = recommendGroup("app",
+		machinesWith(fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}, "m1"),
+		nil, prices,
+	)
+	assert.False(t, r.changed())
+	assert.Equal(t, "no metrics available", r.Note)
+
+	// No guest to size from
+	r = recommendGroup("app", []*fly.Machine{{ID: "m1"}, {ID: "m2", Config: &fly.MachineConfig{}}}, nil, prices)
+	assert.False(t, r.changed())
+	assert.Equal(t, "machine size unknown", r.Note)
+}
@@ -0,0 +1,123 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	mach "github.com/superfly/flyctl/internal/machine"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func isRegionFloor(m *fly.Machine) bool {
+	return m.Config != nil && m.Config.Metadata[appconfig.MachineConfigMetadataKeyRegionFloor] == "true"
+}
+
+// pickRegionFloors returns the machines of a single group and region that
+// should be kept always running. Machines that already are part of the floor
+// are preferred so scaling doesn't shuffle them around.
+func pickRegionFloors(machines []*fly.Machine, count int) []*fly.Machine {
+	sorted := slices.Clone(machines)
+	slices.SortStableFunc(sorted, func(a, b *fly.Machine) int {
+		switch {
+		case isRegionFloor(a) != isRegionFloor(b):
+			return lo.Ternary(isRegionFloor(a), -1, 1)
+		case (a.State == fly.MachineStateStarted) != (b.State == fly.MachineStateStarted):
+			return lo.Ternary(a.State == fly.MachineStateStarted, -1, 1)
+		default:
+			return strings.Compare(a.ID, b.ID)
+		}
+	})
+	return sorted[:min(len(sorted), count)]
+}
+
+// applyRegionFloors marks the machines that keep the minimum of each region,
+// which disables their autostop, and unmarks the ones no longer needed.
+// Leases already held on leased machines are reused.
+func applyRegionFloors(ctx context.Context, appConfig *appconfig.Config, bounds map[string]map[string]regionBounds, leased []*fly.Machine) error {
+	io := iostreams.FromContext(ctx)
+	flapsClient := flaps.FromContext(ctx)
+
+	hasFloors := lo.SomeBy(lo.Values(bounds), func(regions map[string]regionBounds) bool {
+		return lo.SomeBy(lo.Values(regions), func(b regionBounds) bool { return b.Min >= 0 })
+	})
+	if !hasFloors {
+		return nil
+	}
+
+	machines, _, err := flapsClient.ListFlyAppsMachines(ctx)
+	if err != nil {
+		return err
+	}
+	nonces := lo.SliceToMap(leased, func(m *fly.Machine) (string, string) { return m.ID, m.LeaseNonce })
+
+	type change struct {
+		machine *fly.Machine
+		floor   bool
+	}
+	var changes []change
+
+	machineGroups := lo.GroupBy(machines, func(m *fly.Machine) string { return m.ProcessGroup() })
+	for groupName, regions := range bounds {
+		perRegion := lo.GroupBy(machineGroups[groupName], func(m *fly.Machine) string { return m.Region })
+
+		for region, b := range regions {
+			if b.Min < 0 {
+				continue
+			}
+			floors := pickRegionFloors(perRegion[region], b.Min)
+			for _, m := range perRegion[region] {
+				if floor := slices.Contains(floors, m); floor != isRegionFloor(m) {
+					changes = append(changes, change{machine: m, floor: floor})
+				}
+			}
+			if len(floors) > 0 {
+				fmt.Fprintf(io.Out, "Keeping %d machines of group '%s' always running in region '%s'\n", len(floors), groupName, region)
+			}
+		}
+	}
+
+	for _, c := range changes {
+		m := c.machine
+		if nonce := nonces[m.ID]; nonce != "" {
+			m.LeaseNonce = nonce
+		} else {
+			var releaseFunc func()
+			m, releaseFunc, err = mach.AcquireLease(ctx, m)
+			defer releaseFunc()
+			if err != nil {
+				return err
+			}
+		}
+
+		mConfig := helpers.Clone(m.Config)
+		if c.floor {
+			mConfig.Metadata = lo.Assign(mConfig.Metadata)
+			mConfig.Metadata[appconfig.MachineConfigMetadataKeyRegionFloor] = "true"
+		} else {
+			delete(mConfig.Metadata, appconfig.MachineConfigMetadataKeyRegionFloor)
+		}
+		mConfig, err = appConfig.ToMachineConfig(m.ProcessGroup(), mConfig)
+		if err != nil {
+			return err
+		}
+
+		err = mach.Update(ctx, m, &fly.LaunchMachineInput{
+			Region: m.Region,
+			Config: mConfig,
+			// Machines leaving the floor can stay stopped
+			SkipLaunch: !c.floor && m.State == fly.MachineStateStopped,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -17,6 +17,9 @@ func New() *cobra.Command {
 		newScaleMemory(),
 		newScaleShow(),
 		newScaleCount(),
+		newScaleSchedule(),
+		newScaleRecommend(),
+		newScaleGPU(),
 	)
 	return cmd
 }
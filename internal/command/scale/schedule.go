@@ -0,0 +1,309 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/cron"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newScaleSchedule() *cobra.Command {
+	const (
+		short = "Manage scheduled scaling"
+		long  = `Manage scheduled scaling of process groups.
+
+Each schedule entry sets a process group to a machine count whenever its cron
+expression fires. Pair a scale-up entry with a scale-down entry to follow a
+predictable traffic pattern:
+
+  fly scale schedule add --group web --cron '0 8 * * mon-fri' --count 10
+  fly scale schedule add --group web --cron '0 20 * * mon-fri' --count 2
+
+Schedules are stored in the flyctl configuration directory of the machine
+you add them from; they are not uploaded to Fly.io and nothing applies them
+on its own. Run 'fly scale schedule run' periodically, from cron, CI or a
+scheduled machine with access to the same configuration directory, to
+converge each group to the count of its most recently fired entry. No
+scaling happens between runs, or at all if nothing runs it.`
+	)
+	cmd := command.New("schedule", short, long, nil)
+	cmd.AddCommand(
+		newScaleScheduleAdd(),
+		newScaleScheduleList(),
+		newScaleScheduleRemove(),
+		newScaleScheduleRun(),
+	)
+	return cmd
+}
+
+func newScaleScheduleAdd() *cobra.Command {
+	const (
+		short = "Add a scheduled scaling entry"
+		long  = `Add an entry that scales a process group to the given count every time
+the cron expression fires. Cron expressions use the standard 5-field format
+(minute hour day-of-month month day-of-week) and are evaluated in UTC.`
+	)
+	cmd := command.New("add", short, long, runScaleScheduleAdd,
+		command.RequireAppName,
+	)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.ProcessGroup("The process group to scale"),
+		flag.String{Name: "cron", Description: "Cron expression describing when to scale, e.g. '0 8 * * *'"},
+		flag.Int{Name: "count", Description: "Number of machines to scale the group to", Default: -1},
+	)
+	return cmd
+}
+
+func runScaleScheduleAdd(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+
+	group := flag.GetProcessGroup(ctx)
+	if group == "" {
+		group = fly.MachineProcessGroupApp
+	}
+
+	spec := flag.GetString(ctx, "cron")
+	if spec == "" {
+		return fmt.Errorf("--cron is required")
+	}
+	schedule, err := cron.Parse(spec)
+	if err != nil {
+		return err
+	}
+
+	count := flag.GetInt(ctx, "count")
+	if count < 0 {
+		return fmt.Errorf("--count is required and must be zero or greater")
+	}
+
+	id, err := helpers.RandString(8)
+	if err != nil {
+		return err
+	}
+
+	entry := scaleSchedule{
+		ID:        id,
+		Group:     group,
+		Cron:      spec,
+		Count:     count,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = updateSchedules(ctx, func(schedules map[string][]scaleSchedule) error {
+		schedules[appName] = append(schedules[appName], entry)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed saving scale schedules: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Added schedule %s: scale group '%s' to %d machines at '%s' (next run %s)\n",
+		entry.ID, entry.Group, entry.Count, entry.Cron, schedule.Next(time.Now().UTC()).Format(time.RFC3339))
+	return nil
+}
+
+func newScaleScheduleList() *cobra.Command {
+	const (
+		short = "List scheduled scaling entries"
+		long  = `List the scheduled scaling entries of an app`
+	)
+	cmd := command.New("list", short, long, runScaleScheduleList,
+		command.RequireAppName,
+	)
+	cmd.Aliases = []string{"ls"}
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+func runScaleScheduleList(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+
+	schedules, err := loadSchedules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed loading scale schedules: %w", err)
+	}
+	entries := schedules[appName]
+	slices.SortStableFunc(entries, func(a, b scaleSchedule) int {
+		if a.Group < b.Group {
+			return -1
+		} else if a.Group > b.Group {
+			return 1
+		}
+		return 0
+	})
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(io.Out, "No scale schedules for app %s\n", appName)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	active, err := activeSchedules(entries, now)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		next := "never"
+		if at, err := e.nextFire(now); err == nil && !at.IsZero() {
+			next = at.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{
+			e.ID,
+			e.Group,
+			e.Cron,
+			fmt.Sprintf("%d", e.Count),
+			next,
+			lo.Ternary(active[e.Group].ID == e.ID, "yes", ""),
+		})
+	}
+
+	return render.Table(io.Out, "", rows, "ID", "Group", "Cron", "Count", "Next Run", "Active")
+}
+
+func newScaleScheduleRemove() *cobra.Command {
+	const (
+		short = "Remove scheduled scaling entries"
+		long  = `Remove one or more scheduled scaling entries by ID`
+	)
+	cmd := command.New("remove <id> [<id>...]", short, long, runScaleScheduleRemove,
+		command.RequireAppName,
+	)
+	cmd.Aliases = []string{"rm"}
+	cmd.Args = cobra.MinimumNArgs(1)
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+	)
+	return cmd
+}
+
+func runScaleScheduleRemove(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+	ids := flag.Args(ctx)
+
+	err := updateSchedules(ctx, func(schedules map[string][]scaleSchedule) error {
+		entries := schedules[appName]
+		for _, id := range ids {
+			if !lo.ContainsBy(entries, func(e scaleSchedule) bool { return e.ID == id }) {
+				return fmt.Errorf("app %s has no scale schedule with ID %s", appName, id)
+			}
+		}
+
+		entries = lo.Reject(entries, func(e scaleSchedule, _ int) bool {
+			return slices.Contains(ids, e.ID)
+		})
+		if len(entries) == 0 {
+			delete(schedules, appName)
+		} else {
+			schedules[appName] = entries
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		fmt.Fprintf(io.Out, "Removed schedule %s\n", id)
+	}
+	return nil
+}
+
+func newScaleScheduleRun() *cobra.Command {
+	const (
+		short = "Apply the scheduled scaling entries currently in effect"
+		long  = `Scale each scheduled process group to the count of its most recently
+fired schedule entry. The command is idempotent: groups already at the
+scheduled count are left untouched, so it is safe to run as often as needed.`
+	)
+	cmd := command.New("run", short, long, runScaleScheduleRun,
+		command.RequireSession,
+		command.RequireAppName,
+	)
+	cmd.Args = cobra.NoArgs
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.Yes(),
+	)
+	return cmd
+}
+
+func runScaleScheduleRun(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	appName := appconfig.NameFromContext(ctx)
+
+	schedules, err := loadSchedules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed loading scale schedules: %w", err)
+	}
+
+	active, err := activeSchedules(schedules[appName], time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(active) == 0 {
+		fmt.Fprintf(io.Out, "No scale schedules in effect for app %s\n", appName)
+		return nil
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppName: appName,
+	})
+	if err != nil {
+		return err
+	}
+	ctx = flaps.NewContext(ctx, flapsClient)
+
+	appConfig, err := appconfig.FromRemoteApp(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	processNames := appConfig.ProcessNames()
+	groups := make(map[string]int, len(active))
+	for group, entry := range active {
+		if !slices.Contains(processNames, group) {
+			fmt.Fprintf(io.ErrOut, "Skipping schedule %s: app has no process group '%s'\n", entry.ID, group)
+			continue
+		}
+		fmt.Fprintf(io.Out, "Schedule %s ('%s') sets group '%s' to %d machines\n", entry.ID, entry.Cron, group, entry.Count)
+		groups[group] = entry.Count
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	return runMachinesScaleCount(ctx, appName, appConfig, groups, -1, -1)
+}
@@ -0,0 +1,145 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/superfly/flyctl/internal/cron"
+	"github.com/superfly/flyctl/internal/filemu"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+// scheduleFileName is the name of the file, relative to the config directory,
+// scale schedules are persisted to.
+const scheduleFileName = "scale_schedules.yml"
+
+// scaleSchedule is a single scheduled scaling entry. It sets the process group
+// Group to Count machines every time Cron fires.
+type scaleSchedule struct {
+	ID        string    `yaml:"id" json:"id"`
+	Group     string    `yaml:"group" json:"group"`
+	Cron      string    `yaml:"cron" json:"cron"`
+	Count     int       `yaml:"count" json:"count"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// lastFired returns the most recent time at or before now the schedule fired.
+func (s scaleSchedule) lastFired(now time.Time) (time.Time, error) {
+	c, err := cron.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.Prev(now), nil
+}
+
+// nextFire returns the next time after now the schedule fires.
+func (s scaleSchedule) nextFire(now time.Time) (time.Time, error) {
+	c, err := cron.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return c.Next(now), nil
+}
+
+// activeSchedules returns, for each process group, the schedule entry that is
+// currently in effect: the one that fired most recently.
+func activeSchedules(schedules []scaleSchedule, now time.Time) (map[string]scaleSchedule, error) {
+	active := make(map[string]scaleSchedule)
+	fired := make(map[string]time.Time)
+
+	for _, s := range schedules {
+		at, err := s.lastFired(now)
+		if err != nil {
+			return nil, err
+		}
+		if at.IsZero() {
+			continue
+		}
+		if prev, ok := fired[s.Group]; !ok || at.After(prev) {
+			active[s.Group] = s
+			fired[s.Group] = at
+		}
+	}
+
+	return active, nil
+}
+
+func schedulePath(ctx context.Context) string {
+	return filepath.Join(state.ConfigDirectory(ctx), scheduleFileName)
+}
+
+func scheduleLockPath(ctx context.Context) string {
+	return filepath.Join(state.ConfigDirectory(ctx), "flyctl.scale_schedules.lock")
+}
+
+// loadSchedules returns the schedules persisted for all apps, keyed by app
+// name.
+func loadSchedules(ctx context.Context) (schedules map[string][]scaleSchedule, err error) {
+	var unlock filemu.UnlockFunc
+	if unlock, err = filemu.RLock(ctx, scheduleLockPath(ctx)); err != nil {
+		return
+	}
+	defer func() {
+		if e := unlock(); err == nil {
+			err = e
+		}
+	}()
+
+	return readSchedules(ctx)
+}
+
+// updateSchedules loads the schedules of all apps, applies fn to them and
+// persists the result. The file stays locked from the read to the write so
+// concurrent updates can't drop each other's changes. Nothing is written when
+// fn fails.
+func updateSchedules(ctx context.Context, fn func(map[string][]scaleSchedule) error) (err error) {
+	var unlock filemu.UnlockFunc
+	if unlock, err = filemu.Lock(ctx, scheduleLockPath(ctx)); err != nil {
+		return
+	}
+	defer func() {
+		if e := unlock(); err == nil {
+			err = e
+		}
+	}()
+
+	schedules, err := readSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(schedules); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(schedules)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(schedulePath(ctx), data, 0o600)
+}
+
+func readSchedules(ctx context.Context) (map[string][]scaleSchedule, error) {
+	schedules := make(map[string][]scaleSchedule)
+
+	data, err := os.ReadFile(schedulePath(ctx))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return schedules, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err = yaml.Unmarshal(data, &schedules); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
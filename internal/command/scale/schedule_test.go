@@ -0,0 +1,78 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func Test_activeSchedules(t *testing.T) {
+	schedules := []scaleSchedule{
+		{ID: "up", Group: "web", Cron: "0 8 * * *", Count: 10},
+		{ID: "down", Group: "web", Cron: "0 20 * * *", Count: 2},
+		{ID: "worker", Group: "worker", Cron: "30 12 * * *", Count: 1},
+	}
+
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+
+	active, err := activeSchedules(schedules, at("2024-03-10T09:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, "up", active["web"].ID)
+	assert.Equal(t, "worker", active["worker"].ID)
+
+	active, err = activeSchedules(schedules, at("2024-03-10T21:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, "down", active["web"].ID)
+
+	active, err = activeSchedules(schedules, at("2024-03-10T07:59:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, "down", active["web"].ID)
+
+	_, err = activeSchedules([]scaleSchedule{{Group: "web", Cron: "bogus"}}, time.Now())
+	assert.Error(t, err)
+}
+
+func Test_updateSchedules(t *testing.T) {
+	ctx := state.WithConfigDirectory(context.Background(), t.TempDir())
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := updateSchedules(ctx, func(schedules map[string][]scaleSchedule) error {
+				schedules["app"] = append(schedules["app"], scaleSchedule{ID: fmt.Sprint(i)})
+				return nil
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	schedules, err := loadSchedules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, schedules["app"], n)
+
+	err = updateSchedules(ctx, func(schedules map[string][]scaleSchedule) error {
+		delete(schedules, "app")
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	schedules, err = loadSchedules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, schedules["app"], n)
+}
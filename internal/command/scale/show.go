@@ -9,7 +9,10 @@ import (
 func newScaleShow() *cobra.Command {
 	const (
 		short = "Show current resources"
-		long  = `Show current VM size and counts`
+		long  = `Show current VM size and counts.
+
+With --history, show how the size and count of each process group changed
+over time, derived from the lifecycle of the app's machines.`
 	)
 	cmd := command.New("show", short, long, runMachinesScaleShow,
 		command.RequireSession,
@@ -20,6 +23,7 @@ func newScaleShow() *cobra.Command {
 		flag.App(),
 		flag.AppConfig(),
 		flag.JSONOutput(),
+		flag.Bool{Name: "history", Description: "Show the history of count and size changes"},
 	)
 	return cmd
 }
@@ -0,0 +1,157 @@
+package scale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// scaleHistoryEntry is a single change to the size or count of a process
+// group in a region.
+type scaleHistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Group   string    `json:"group"`
+	Region  string    `json:"region"`
+	Action  string    `json:"action"`
+	Delta   int       `json:"delta"`
+	Count   int       `json:"count"`
+	Size    string    `json:"size,omitempty"`
+	Source  string    `json:"source,omitempty"`
+	Machine string    `json:"machine"`
+}
+
+// buildScaleHistory derives the scaling history of an app from the lifecycle
+// of its machines, including destroyed ones. Count is the number of machines
+// in the group after the change.
+func buildScaleHistory(machines []*fly.Machine) []scaleHistoryEntry {
+	var entries []scaleHistoryEntry
+
+	for _, m := range machines {
+		if m.IsFlyAppsReleaseCommand() || m.IsFlyAppsConsole() {
+			continue
+		}
+
+		size := ""
+		if m.Config != nil && m.Config.Guest != nil {
+			size = m.Config.Guest.ToSize()
+		}
+
+		base := scaleHistoryEntry{
+			Group:   m.ProcessGroup(),
+			Region:  m.Region,
+			Machine: m.ID,
+			Size:    size,
+		}
+
+		launched, destroyed := false, false
+		lastUpdate := -1
+
+		for _, e := range m.Events {
+			entry := base
+			entry.Time = e.Time().UTC()
+			entry.Source = e.Source
+
+			switch e.Type {
+			case "launch":
+				launched = true
+				entry.Action, entry.Delta = "create", 1
+			case "destroy":
+				if destroyed {
+					continue
+				}
+				destroyed = true
+				entry.Action, entry.Delta = "destroy", -1
+			case "update":
+				// Only the most recent update is known to have produced the
+				// machine's current size.
+				entry.Action = "update"
+				entry.Size = ""
+				if lastUpdate < 0 || entry.Time.After(entries[lastUpdate].Time) {
+					lastUpdate = len(entries)
+				}
+			default:
+				continue
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if lastUpdate >= 0 {
+			entries[lastUpdate].Size = size
+		}
+
+		// Machines only keep their most recent events around, fall back to
+		// timestamps for lifecycle changes that have scrolled out.
+		if !launched {
+			if t, err := time.Parse(time.RFC3339, m.CreatedAt); err == nil {
+				entry := base
+				entry.Time, entry.Action, entry.Delta = t.UTC(), "create", 1
+				entries = append(entries, entry)
+			}
+		}
+		if !destroyed && !m.IsActive() {
+			if t, err := time.Parse(time.RFC3339, m.UpdatedAt); err == nil {
+				entry := base
+				entry.Time, entry.Action, entry.Delta = t.UTC(), "destroy", -1
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b scaleHistoryEntry) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	counts := make(map[string]int)
+	for i := range entries {
+		counts[entries[i].Group] += entries[i].Delta
+		entries[i].Count = counts[entries[i].Group]
+	}
+
+	return entries
+}
+
+func runMachinesScaleHistory(ctx context.Context, appName string) error {
+	io := iostreams.FromContext(ctx)
+	flapsClient := flaps.FromContext(ctx)
+
+	machines, err := flapsClient.List(ctx, "include_deleted=true")
+	if err != nil {
+		return err
+	}
+
+	entries := buildScaleHistory(machines)
+
+	if flag.GetBool(ctx, "json") {
+		return render.JSON(io.Out, entries)
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		change := e.Action
+		if e.Delta != 0 {
+			change = fmt.Sprintf("%+d", e.Delta)
+		}
+		rows = append(rows, []string{
+			format.RelativeTime(e.Time),
+			e.Group,
+			e.Region,
+			change,
+			fmt.Sprintf("%d", e.Count),
+			e.Size,
+			e.Source,
+			e.Machine,
+		})
+	}
+
+	fmt.Fprintf(io.Out, "Scaling history for app: %s\n\n", appName)
+	return render.Table(io.Out, "", rows, "When", "Group", "Region", "Change", "Group Count", "Size", "Source", "Machine")
+}
@@ -0,0 +1,62 @@
+package scale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func Test_buildScaleHistory(t *testing.T) {
+	ms := func(s string) int64 {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return ts.UnixMilli()
+	}
+	guest := &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}
+
+	machines := []*fly.Machine{
+		{
+			ID: "m1", Region: "ord", State: fly.MachineStateStarted,
+			Config: &fly.MachineConfig{Guest: guest},
+			Events: []*fly.MachineEvent{
+				{Type: "launch", Source: "user", Timestamp: ms("2024-01-01T10:00:00Z")},
+				{Type: "update", Source: "user", Timestamp: ms("2024-01-03T10:00:00Z")},
+				{Type: "update", Source: "user", Timestamp: ms("2024-01-04T10:00:00Z")},
+			},
+		},
+		{
+			ID: "m2", Region: "ams", State: fly.MachineStateDestroyed,
+			Config:    &fly.MachineConfig{Guest: guest},
+			CreatedAt: "2024-01-02T10:00:00Z",
+			UpdatedAt: "2024-01-05T10:00:00Z",
+		},
+	}
+
+	entries := buildScaleHistory(machines)
+	assert.Len(t, entries, 5)
+
+	assert.Equal(t, []string{"create", "create", "update", "update", "destroy"},
+		[]string{entries[0].Action, entries[1].Action, entries[2].Action, entries[3].Action, entries[4].Action})
+	assert.Equal(t, []int{1, 2, 2, 2, 1},
+		[]int{entries[0].Count, entries[1].Count, entries[2].Count, entries[3].Count, entries[4].Count})
+
+	// only the latest update is known to have produced the current size
+	assert.Equal(t, "", entries[2].Size)
+	assert.Equal(t, "shared-cpu-1x", entries[3].Size)
+	assert.Equal(t, "ams", entries[4].Region)
+}
+
+func Test_breakdownRegions(t *testing.T) {
+	guest := &fly.MachineGuest{CPUKind: "shared", CPUs: 1, MemoryMB: 256}
+	regions := breakdownRegions([]*fly.Machine{
+		{Region: "ord", State: fly.MachineStateStarted, Config: &fly.MachineConfig{Guest: guest}},
+		{Region: "ord", State: fly.MachineStateStopped, Config: &fly.MachineConfig{Guest: guest}},
+		{Region: "ams", State: fly.MachineStateStarted, Config: &fly.MachineConfig{Guest: guest}},
+	})
+
+	assert.Equal(t, []regionData{
+		{Region: "ams", Count: 1, Started: 1, Sizes: map[string]int{"shared-cpu-1x": 1}},
+		{Region: "ord", Count: 2, Started: 1, Stopped: 1, Sizes: map[string]int{"shared-cpu-1x": 2}},
+	}, regions)
+}
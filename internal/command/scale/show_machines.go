@@ -29,6 +29,10 @@ func runMachinesScaleShow(ctx context.Context) error {
 	}
 	ctx = flaps.NewContext(ctx, flapsClient)
 
+	if flag.GetBool(ctx, "history") {
+		return runMachinesScaleHistory(ctx, appName)
+	}
+
 	machines, _, err := flapsClient.ListFlyAppsMachines(ctx)
 	if err != nil {
 		return err
@@ -54,12 +58,13 @@ func runMachinesScaleShow(ctx context.Context) error {
 
 	if flag.GetBool(ctx, "json") {
 		type groupData struct {
-			Process string
-			Count   int
-			CPUKind string
-			CPUs    int
-			Memory  int
-			Regions map[string]int
+			Process         string
+			Count           int
+			CPUKind         string
+			CPUs            int
+			Memory          int
+			Regions         map[string]int
+			RegionBreakdown []regionData
 		}
 		groups := lo.FilterMap(groupNames, func(name string, _ int) (res groupData, ok bool) {
 			machines := machineGroups[name]
@@ -76,6 +81,7 @@ func runMachinesScaleShow(ctx context.Context) error {
 				Regions: lo.CountValues(lo.Map(machines, func(m *fly.Machine, _ int) string {
 					return m.Region
 				})),
+				RegionBreakdown: breakdownRegions(machines),
 			}, true
 		})
 
@@ -122,3 +128,37 @@ func formatRegions(machines []*fly.Machine) string {
 	slices.Sort(regions)
 	return strings.Join(regions, ",")
 }
+
+type regionData struct {
+	Region  string
+	Count   int
+	Started int
+	Stopped int
+	Sizes   map[string]int
+}
+
+// breakdownRegions summarizes machines per region, sorted by region name.
+func breakdownRegions(machines []*fly.Machine) []regionData {
+	byRegion := lo.GroupBy(machines, func(m *fly.Machine) string {
+		return m.Region
+	})
+
+	regions := lo.Keys(byRegion)
+	slices.Sort(regions)
+
+	return lo.Map(regions, func(region string, _ int) regionData {
+		machines := byRegion[region]
+		return regionData{
+			Region:  region,
+			Count:   len(machines),
+			Started: lo.CountBy(machines, func(m *fly.Machine) bool { return m.State == fly.MachineStateStarted }),
+			Stopped: lo.CountBy(machines, func(m *fly.Machine) bool { return m.State == fly.MachineStateStopped }),
+			Sizes: lo.CountValues(lo.FilterMap(machines, func(m *fly.Machine, _ int) (string, bool) {
+				if m.Config == nil || m.Config.Guest == nil {
+					return "", false
+				}
+				return m.Config.Guest.ToSize(), true
+			})),
+		}
+	})
+}
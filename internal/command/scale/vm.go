@@ -3,6 +3,7 @@ package scale
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
@@ -19,9 +20,16 @@ func newScaleVm() *cobra.Command {
 
 For a full list of supported sizes use the command 'flyctl platform vm-sizes'
 
+The new size is validated for every targeted machine before any of them is
+updated. Several process groups can be scaled at once with
+--process-group=web,worker
+
 Memory size can be set with --memory=number-of-MB
 e.g. flyctl scale vm shared-cpu-1x --memory=2048
 
+Use --estimate to see how the monthly cost of the app would change without
+scaling it.
+
 For pricing, see https://fly.io/docs/about/pricing/`
 	)
 	cmd := command.New("vm [size]", short, long, runScaleVM,
@@ -38,7 +46,11 @@ For pricing, see https://fly.io/docs/about/pricing/`
 			Default:     0,
 			Aliases:     []string{"memory"},
 		},
-		flag.ProcessGroup("The process group to apply the VM size to"),
+		flag.ProcessGroup("Comma separated list of process groups to apply the VM size to"),
+		flag.Bool{
+			Name:        "estimate",
+			Description: "Show the estimated monthly cost change without scaling",
+		},
 	)
 	return cmd
 }
@@ -47,6 +59,15 @@ func runScaleVM(ctx context.Context) error {
 	sizeName := flag.FirstArg(ctx)
 	memoryMB := flag.GetInt(ctx, "vm-memory")
 	group := flag.GetProcessGroup(ctx)
+
+	if flag.GetBool(ctx, "estimate") {
+		var groups []string
+		if group != "" {
+			groups = strings.Split(group, ",")
+		}
+		return estimateScaleVM(ctx, appconfig.NameFromContext(ctx), groups, sizeName, memoryMB)
+	}
+
 	return scaleVertically(ctx, group, sizeName, memoryMB)
 }
 
@@ -54,7 +75,12 @@ func scaleVertically(ctx context.Context, group, sizeName string, memoryMB int)
 	io := iostreams.FromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 
-	size, err := v2ScaleVM(ctx, appName, group, sizeName, memoryMB)
+	var groups []string
+	if group != "" {
+		groups = strings.Split(group, ",")
+	}
+
+	size, err := v2ScaleVM(ctx, appName, groups, sizeName, memoryMB)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// describe returns the JSON Schema of the JSON encoding of values of type t.
+func describe(t reflect.Type) map[string]any {
+	return (&describer{seen: map[reflect.Type]bool{}}).describe(t)
+}
+
+type describer struct {
+	// seen holds the struct types being described, to stop at recursive
+	// types instead of looping forever.
+	seen map[reflect.Type]bool
+}
+
+func (d *describer) describe(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(marshalerType), reflect.PointerTo(t).Implements(marshalerType):
+		// Custom encodings can't be described by reflection.
+		return map[string]any{}
+	case t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": d.describe(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": d.describe(t.Elem())}
+	case reflect.Struct:
+		return d.describeStruct(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+func (d *describer) describeStruct(t reflect.Type) map[string]any {
+	if d.seen[t] {
+		return map[string]any{"type": "object"}
+	}
+	d.seen[t] = true
+	defer delete(d.seen, t)
+
+	properties := map[string]any{}
+	d.addFields(t, properties)
+
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// addFields adds the properties of the fields of struct type t, including
+// those promoted from untagged embedded structs, the way encoding/json does.
+func (d *describer) addFields(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				d.addFields(ft, properties)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := properties[name]; ok {
+			// Fields of the outer struct take precedence over promoted ones.
+			continue
+		}
+		properties[name] = d.describe(f.Type)
+	}
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type node struct {
+	Name     string `json:"name"`
+	Hidden   string `json:"-"`
+	Size     int64  `json:"size,omitempty"`
+	Ratio    float64
+	Tags     []string `json:"tags"`
+	Labels   map[string]string
+	Created  time.Time `json:"created_at"`
+	Children []*node   `json:"children"`
+	embedded
+	private bool
+}
+
+type embedded struct {
+	Region string `json:"region"`
+}
+
+func TestDescribe(t *testing.T) {
+	doc := describe(reflect.TypeOf([]*node{}))
+
+	assert.Equal(t, "array", doc["type"])
+	items := doc["items"].(map[string]any)
+	assert.Equal(t, "object", items["type"])
+
+	properties := items["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"name":       map[string]any{"type": "string"},
+		"size":       map[string]any{"type": "integer"},
+		"Ratio":      map[string]any{"type": "number"},
+		"tags":       map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		"Labels":     map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+		"created_at": map[string]any{"type": "string", "format": "date-time"},
+		"children":   map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+		"region":     map[string]any{"type": "string"},
+	}, properties)
+}
+
+func TestDocuments(t *testing.T) {
+	for _, s := range schemas {
+		doc := s.document()
+		assert.Equal(t, "fly "+s.Command+" --json", doc["title"])
+		assert.Equal(t, s.Version, doc["version"])
+		assert.Contains(t, []any{"array", "object"}, doc["type"], s.Command)
+	}
+
+	_, ok := find("nope")
+	assert.False(t, ok)
+}
@@ -0,0 +1,55 @@
+// Package schema implements the schema command.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() (cmd *cobra.Command) {
+	const (
+		short = "Show the schema of the JSON output of commands"
+		long  = `Show the JSON Schema of the --json output of a command, or list the
+commands whose JSON output is covered by a schema when no command is given.
+
+The fields of these outputs are stable: fields may be added, but removing,
+renaming or retyping one bumps the version of the schema.
+
+  fly schema machine list
+`
+	)
+
+	cmd = command.New("schema [command]", short, long, run)
+	cmd.Args = cobra.ArbitraryArgs
+
+	return
+}
+
+func run(ctx context.Context) error {
+	out := iostreams.FromContext(ctx).Out
+
+	args := flag.Args(ctx)
+	if len(args) == 0 {
+		rows := make([][]string, 0, len(schemas))
+		for _, s := range schemas {
+			rows = append(rows, []string{"fly " + s.Command, strconv.Itoa(s.Version)})
+		}
+		return render.Table(out, "", rows, "Command", "Version")
+	}
+
+	name := strings.TrimPrefix(strings.Join(args, " "), "fly ")
+	s, ok := find(name)
+	if !ok {
+		return fmt.Errorf("no JSON output schema for fly %s, see fly schema for the commands that have one", name)
+	}
+	return render.JSON(out, s.document())
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"reflect"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/command/status"
+)
+
+// outputSchema is the committed shape of the --json output of a command.
+//
+// Fields may be added to an output within a version. Removing, renaming or
+// retyping a field requires bumping its version.
+type outputSchema struct {
+	// Command is the path of the command, without the leading fly.
+	Command string
+	Version int
+	// Value is a value of the type the command encodes.
+	Value any
+}
+
+var schemas = []outputSchema{
+	{Command: "apps list", Version: 1, Value: []fly.App{}},
+	{Command: "certs list", Version: 1, Value: []fly.AppCertificateCompact{}},
+	{Command: "certs show", Version: 1, Value: fly.AppCertificate{}},
+	{Command: "ips list", Version: 1, Value: []fly.IPAddress{}},
+	{Command: "machine list", Version: 1, Value: []fly.Machine{}},
+	{Command: "machine status", Version: 1, Value: fly.Machine{}},
+	{Command: "releases", Version: 1, Value: []fly.Release{}},
+	{Command: "secrets list", Version: 1, Value: []fly.Secret{}},
+	{Command: "status", Version: 1, Value: status.AppStatus{}},
+	{Command: "volumes list", Version: 1, Value: []fly.Volume{}},
+	{Command: "volumes show", Version: 1, Value: fly.Volume{}},
+}
+
+// Commands returns the paths of the commands whose JSON output has a schema,
+// without the leading fly.
+func Commands() []string {
+	commands := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		commands = append(commands, s.Command)
+	}
+	return commands
+}
+
+func find(command string) (outputSchema, bool) {
+	for _, s := range schemas {
+		if s.Command == command {
+			return s, true
+		}
+	}
+	return outputSchema{}, false
+}
+
+// document returns the JSON Schema document of s.
+func (s outputSchema) document() map[string]any {
+	doc := describe(reflect.TypeOf(s.Value))
+	doc["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	doc["title"] = "fly " + s.Command + " --json"
+	doc["version"] = s.Version
+	return doc
+}
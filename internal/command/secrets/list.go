@@ -10,6 +10,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -40,7 +41,9 @@ func runList(ctx context.Context) (err error) {
 	client := fly.ClientFromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
 	out := iostreams.FromContext(ctx).Out
-	secrets, err := client.GetAppSecrets(ctx, appName)
+	secrets, err := readcache.Get(ctx, "secrets list:"+appName, func(ctx context.Context) ([]fly.Secret, error) {
+		return client.GetAppSecrets(ctx, appName)
+	})
 	cfg := config.FromContext(ctx)
 
 	if err != nil {
@@ -2,8 +2,13 @@ package secrets
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
@@ -16,8 +21,15 @@ import (
 
 func newSet() (cmd *cobra.Command) {
 	const (
-		long  = `Set one or more encrypted secrets for an application`
-		short = long
+		short = `Set one or more encrypted secrets for an application`
+		long  = short + `
+
+A value of - is read from standard input. With --from-stdin or --from-file,
+the value of the single secret NAME is read as is, without shell quoting
+hazards. Values read from standard input or files must be text, unless
+--encoding base64 stores them base64 encoded, for private keys and other
+binary values the application decodes.
+`
 		usage = "set [flags] NAME=VALUE NAME=VALUE ..."
 	)
 
@@ -25,6 +37,19 @@ func newSet() (cmd *cobra.Command) {
 
 	flag.Add(cmd,
 		sharedFlags,
+		flag.Bool{
+			Name:        "from-stdin",
+			Description: "Read the value of the single secret NAME from standard input",
+		},
+		flag.String{
+			Name:        "from-file",
+			Description: "Read the value of the single secret NAME from this file",
+		},
+		flag.String{
+			Name:        "encoding",
+			Description: "Encoding of the values read from standard input or files: raw, or base64 for binary values",
+			Default:     "raw",
+		},
 	)
 
 	cmd.Args = cobra.MinimumNArgs(1)
@@ -32,17 +57,33 @@ func newSet() (cmd *cobra.Command) {
 	return cmd
 }
 
+// maxSecretSize is the largest value read from standard input or a file.
+const maxSecretSize = 64 * 1024
+
 func runSet(ctx context.Context) (err error) {
 	client := fly.ClientFromContext(ctx)
 	appName := appconfig.NameFromContext(ctx)
+
+	encoding := flag.GetString(ctx, "encoding")
+	if encoding != "raw" && encoding != "base64" {
+		return fmt.Errorf("unsupported encoding %q, expected raw or base64", encoding)
+	}
+
 	app, err := client.GetAppCompact(ctx, appName)
 	if err != nil {
 		return err
 	}
 
-	secrets, err := cmdutil.ParseKVStringsToMap(flag.Args(ctx))
-	if err != nil {
-		return fmt.Errorf("could not parse secrets: %w", err)
+	var secrets map[string]string
+	if fromFile := flag.GetString(ctx, "from-file"); fromFile != "" || flag.GetBool(ctx, "from-stdin") {
+		if secrets, err = secretFromStdinOrFile(ctx, fromFile, encoding); err != nil {
+			return err
+		}
+	} else {
+		secrets, err = cmdutil.ParseKVStringsToMap(flag.Args(ctx))
+		if err != nil {
+			return fmt.Errorf("could not parse secrets: %w", err)
+		}
 	}
 
 	for k, v := range secrets {
@@ -50,7 +91,12 @@ func runSet(ctx context.Context) (err error) {
 			if !helpers.HasPipedStdin() {
 				return fmt.Errorf("secret `%s` expects standard input but none provided", k)
 			}
-			inval, err := helpers.ReadStdin(64 * 1024)
+			var inval string
+			if encoding == "raw" {
+				inval, err = helpers.ReadStdin(maxSecretSize)
+			} else {
+				inval, err = readSecretValue(os.Stdin, encoding)
+			}
 			if err != nil {
 				return fmt.Errorf("error reading stdin for '%s': %s", k, err)
 			}
@@ -65,6 +111,58 @@ func runSet(ctx context.Context) (err error) {
 	return SetSecretsAndDeploy(ctx, app, secrets, flag.GetBool(ctx, "stage"), flag.GetBool(ctx, "detach"))
 }
 
+// secretFromStdinOrFile returns the single secret named by the arguments,
+// with its value read from standard input, or from the file fromFile.
+func secretFromStdinOrFile(ctx context.Context, fromFile, encoding string) (map[string]string, error) {
+	if fromFile != "" && flag.GetBool(ctx, "from-stdin") {
+		return nil, errors.New("--from-stdin and --from-file are mutually exclusive")
+	}
+
+	args := flag.Args(ctx)
+	if len(args) != 1 || strings.Contains(args[0], "=") {
+		return nil, errors.New("--from-stdin and --from-file set a single secret, give its NAME only")
+	}
+	name := args[0]
+
+	var r io.Reader = os.Stdin
+	if fromFile != "" {
+		f, err := os.Open(fromFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading value of '%s': %w", name, err)
+		}
+		defer f.Close()
+		r = f
+	} else if !helpers.HasPipedStdin() {
+		return nil, fmt.Errorf("secret `%s` expects standard input but none provided", name)
+	}
+
+	value, err := readSecretValue(r, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("error reading value of '%s': %w", name, err)
+	}
+	return map[string]string{name: value}, nil
+}
+
+// readSecretValue reads a secret value as is. Raw values must be text,
+// base64 encodes any bytes.
+func readSecretValue(r io.Reader, encoding string) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSecretSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxSecretSize {
+		return "", fmt.Errorf("input exceeded max length of %d bytes", maxSecretSize)
+	}
+
+	if encoding == "base64" {
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+	if !utf8.Valid(data) {
+		return "", errors.New("value isn't text, set it with --encoding base64")
+	}
+	return string(data), nil
+}
+
 func SetSecretsAndDeploy(ctx context.Context, app *fly.AppCompact, secrets map[string]string, stage bool, detach bool) error {
 	client := fly.ClientFromContext(ctx)
 	if _, err := client.SetSecrets(ctx, app.Name, secrets); err != nil {
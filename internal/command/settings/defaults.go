@@ -0,0 +1,148 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newDefaults() *cobra.Command {
+	const (
+		short = "Manage default flags of commands for an app"
+		long  = `Default flags are applied to a command whenever it runs against the app,
+unless the flag is given explicitly:
+
+  fly settings defaults set deploy '--strategy bluegreen --ha=false' -a myapp
+
+These defaults are personal. Defaults shared by everyone deploying the app go
+in the [cli] section of its fly.toml, and the personal ones take precedence.
+`
+	)
+
+	cmd := command.New("defaults", short, long, nil)
+
+	set := command.New("set <command> <flags>", "Set the default flags of a command", "", runDefaultsSet,
+		command.RequireAppName,
+	)
+	set.Args = cobra.ExactArgs(2)
+	flag.Add(set, flag.App(), flag.AppConfig())
+
+	list := command.New("list", "List the default flags of commands", "", runDefaultsList,
+		command.RequireAppName,
+	)
+	list.Aliases = []string{"ls"}
+	list.Args = cobra.NoArgs
+	flag.Add(list, flag.App(), flag.AppConfig(), flag.JSONOutput())
+
+	unset := command.New("unset <command>", "Remove the default flags of a command", "", runDefaultsUnset,
+		command.RequireAppName,
+	)
+	unset.Args = cobra.ExactArgs(1)
+	flag.Add(unset, flag.App(), flag.AppConfig())
+
+	cmd.AddCommand(set, list, unset)
+
+	return cmd
+}
+
+func runDefaultsSet(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = defaultsCommand(ctx, flag.FirstArg(ctx))
+		flags   = strings.TrimSpace(flag.Args(ctx)[1])
+	)
+
+	if flags == "" {
+		return fmt.Errorf("no flags given, use fly settings defaults unset to remove defaults")
+	}
+
+	if err := setDefaults(ctx, appName, name, flags); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "fly %s now defaults to %s for %s\n", name, flags, appName)
+	return nil
+}
+
+func runDefaultsUnset(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+		name    = defaultsCommand(ctx, flag.FirstArg(ctx))
+	)
+
+	if err := setDefaults(ctx, appName, name, ""); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Removed the default flags of fly %s for %s\n", name, appName)
+	return nil
+}
+
+func runDefaultsList(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	defaults, err := config.ReadAppDefaults(state.ConfigFile(ctx))
+	if err != nil {
+		return err
+	}
+
+	flags := defaults[appName]
+	if flags == nil {
+		flags = map[string]string{}
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, flags)
+	}
+
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		rows = append(rows, []string{name, flags[name]})
+	}
+	return render.Table(io.Out, "", rows, "Command", "Flags")
+}
+
+func setDefaults(ctx context.Context, appName, name, flags string) error {
+	path := state.ConfigFile(ctx)
+
+	if err := config.SetAppDefaults(path, appName, name, flags); err != nil {
+		return fmt.Errorf("failed persisting %s in %s: %w\n",
+			config.AppDefaultsFileKey, path, err)
+	}
+	return nil
+}
+
+// defaultsCommand normalizes name into the path of a command, such as
+// "machine run", without the leading fly.
+func defaultsCommand(ctx context.Context, name string) string {
+	fields := strings.Fields(name)
+	if len(fields) > 0 && (fields[0] == "fly" || fields[0] == "flyctl") {
+		fields = fields[1:]
+	}
+
+	if cmd, _, err := command.FromContext(ctx).Root().Find(fields); err == nil && cmd.HasParent() {
+		return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newHints() *cobra.Command {
+	const long = `Control the hints about next steps shown after key commands, such as
+fly launch or fly deploy, succeed. Hints are never shown with --json or
+--quiet, or when not running in a terminal. Setting FLY_NO_HINTS also
+turns them off.
+`
+
+	hintsRoot := command.New("hints", "Control hints about next steps", long, runHintsStatus)
+
+	optIn := command.New("enable", "Enable hints about next steps", "", func(ctx context.Context) error {
+		return setHintsEnabled(ctx, true)
+	})
+	optOut := command.New("disable", "Disable hints about next steps", "", func(ctx context.Context) error {
+		return setHintsEnabled(ctx, false)
+	})
+
+	hintsRoot.AddCommand(optIn)
+	hintsRoot.AddCommand(optOut)
+
+	return hintsRoot
+}
+
+func printHintsEnabled(ctx context.Context, enabled bool) {
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.Out, "Hints: %s\n", lo.Ternary(enabled, "enabled", "disabled"))
+}
+
+func runHintsStatus(ctx context.Context) error {
+	var (
+		cfg = config.FromContext(ctx)
+		io  = iostreams.FromContext(ctx)
+	)
+
+	printHintsEnabled(ctx, cfg.Hints)
+
+	fmt.Fprintf(io.Out, "\nThis can be controlled with 'fly settings hints <enable/disable>'\n")
+
+	return nil
+}
+
+func setHintsEnabled(ctx context.Context, enabled bool) error {
+	path := state.ConfigFile(ctx)
+
+	if err := config.SetHints(path, enabled); err != nil {
+		return fmt.Errorf("failed persisting %s in %s: %w\n",
+			config.HintsFileKey, path, err)
+	}
+
+	printHintsEnabled(ctx, enabled)
+
+	return nil
+}
@@ -11,6 +11,9 @@ func New() *cobra.Command {
 	cmd.AddCommand(
 		newAnalytics(),
 		newAutoUpdate(),
+		newDefaults(),
+		newHints(),
+		newTelemetry(),
 	)
 
 	return cmd
@@ -0,0 +1,132 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/metrics"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newTelemetry() *cobra.Command {
+	const long = `Show which categories of anonymized metrics flyctl sends, and opt out of
+them individually. 'fly settings analytics disable' opts out of all of them.
+
+Metrics flyctl sent, failed to send or withheld because their category was
+opted out of are recorded locally; 'fly settings telemetry show' prints them.
+`
+
+	telemetryRoot := command.New("telemetry", "Inspect and control the metrics flyctl sends", long, runTelemetryStatus)
+
+	show := command.New("show", "Show the metrics flyctl sent recently", "", runTelemetryShow)
+	show.Args = cobra.NoArgs
+	flag.Add(show,
+		flag.JSONOutput(),
+		flag.Int{
+			Name:        "limit",
+			Description: "Maximum number of metrics to show, most recent last",
+			Default:     50,
+		},
+		flag.String{
+			Name:        "category",
+			Description: "Only show metrics of the given category",
+		},
+	)
+
+	optIn := command.New("enable <category>", "Send the metrics of a category", "", func(ctx context.Context) error {
+		return setTelemetryCategory(ctx, flag.FirstArg(ctx), false)
+	})
+	optIn.Args = cobra.ExactArgs(1)
+	optOut := command.New("disable <category>", "Stop sending the metrics of a category", "", func(ctx context.Context) error {
+		return setTelemetryCategory(ctx, flag.FirstArg(ctx), true)
+	})
+	optOut.Args = cobra.ExactArgs(1)
+
+	telemetryRoot.AddCommand(show, optIn, optOut)
+
+	return telemetryRoot
+}
+
+func runTelemetryStatus(ctx context.Context) error {
+	var (
+		cfg = config.FromContext(ctx)
+		io  = iostreams.FromContext(ctx)
+	)
+
+	printAnalyticsEnabled(ctx, cfg.SendMetrics)
+	fmt.Fprintln(io.Out)
+
+	rows := make([][]string, 0, len(metrics.Categories))
+	for _, c := range metrics.Categories {
+		enabled := cfg.SendMetrics && !slices.Contains(cfg.TelemetryOptOuts, c.Name)
+		rows = append(rows, []string{c.Name, lo.Ternary(enabled, "enabled", "disabled"), c.Description})
+	}
+	_ = render.Table(io.Out, "", rows, "Category", "Status", "Description")
+
+	fmt.Fprintf(io.Out, "This can be controlled with 'fly settings telemetry <enable/disable> <category>'\n")
+
+	return nil
+}
+
+func runTelemetryShow(ctx context.Context) error {
+	var (
+		cfg      = config.FromContext(ctx)
+		io       = iostreams.FromContext(ctx)
+		category = flag.GetString(ctx, "category")
+		limit    = flag.GetInt(ctx, "limit")
+	)
+
+	entries, err := metrics.ReadJournal(metrics.JournalPath(ctx))
+	if err != nil {
+		return fmt.Errorf("failed reading %s: %w", metrics.JournalPath(ctx), err)
+	}
+
+	if category != "" {
+		entries = lo.Filter(entries, func(e metrics.JournalEntry, _ int) bool { return e.Category == category })
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, lo.Ternary(entries == nil, []metrics.JournalEntry{}, entries))
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(io.Out, "No metrics were recorded yet.")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, []string{e.Time.Local().Format("2006-01-02 15:04:05"), e.Category, e.Metric, e.Status, string(e.Payload)})
+	}
+
+	return render.Table(io.Out, "", rows, "Time", "Category", "Metric", "Status", "Payload")
+}
+
+func setTelemetryCategory(ctx context.Context, category string, optOut bool) error {
+	if !metrics.IsCategory(category) {
+		names := lo.Map(metrics.Categories, func(c metrics.Category, _ int) string { return c.Name })
+		return fmt.Errorf("unknown category %q, must be one of %v or other", category, names)
+	}
+
+	path := state.ConfigFile(ctx)
+	if err := config.SetTelemetryOptOut(path, category, optOut); err != nil {
+		return fmt.Errorf("failed persisting %s in %s: %w\n",
+			config.TelemetryOptOutsFileKey, path, err)
+	}
+
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintf(io.Out, "Metrics of category %s: %s\n", category, lo.Ternary(optOut, "disabled", "enabled"))
+
+	return nil
+}
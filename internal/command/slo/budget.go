@@ -0,0 +1,72 @@
+package slo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Burn rates from which an SLO is reported as burning its budget too fast:
+// at 14.4 a 30 day budget is exhausted in about 2 days, at 6 in 5 days.
+const (
+	fastBurnRate = 14.4
+	slowBurnRate = 6
+)
+
+// metrics maps the SLO metrics to the PromQL queries counting bad and total
+// events of an app over a range.
+var metrics = map[string]struct {
+	Description string
+	Bad, Total  string
+}{
+	"http_5xx_ratio": {
+		Description: "HTTP responses with a 5xx status",
+		Bad:         `sum(increase(fly_app_http_responses_count{app=%q,status=~"5.."}[%s]))`,
+		Total:       `sum(increase(fly_app_http_responses_count{app=%q}[%s]))`,
+	},
+}
+
+// promDuration formats d as a PromQL range, in whole minutes.
+func promDuration(d time.Duration) string {
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// budget is the state of the error budget of an SLO.
+type budget struct {
+	// ErrorRatio is the ratio of bad events over the window.
+	ErrorRatio float64 `json:"error_ratio"`
+	// Allowed is the ratio of bad events the target allows.
+	Allowed float64 `json:"allowed"`
+	// Remaining is the fraction of the budget left, negative when exceeded.
+	Remaining float64 `json:"remaining"`
+	// BurnRate1h and BurnRate6h are how many times faster than allowed the
+	// budget was consumed over the last hour and 6 hours.
+	BurnRate1h float64 `json:"burn_rate_1h"`
+	BurnRate6h float64 `json:"burn_rate_6h"`
+}
+
+// computeBudget derives the budget of an SLO with target percent from the
+// error ratios over its window, the last hour and the last 6 hours.
+func computeBudget(target, window, lastHour, last6Hours float64) budget {
+	b := budget{ErrorRatio: window, Allowed: 1 - target/100}
+	if b.Allowed <= 0 {
+		return b
+	}
+	b.Remaining = 1 - window/b.Allowed
+	b.BurnRate1h = lastHour / b.Allowed
+	b.BurnRate6h = last6Hours / b.Allowed
+	return b
+}
+
+// state summarizes the budget for humans.
+func (b budget) state() string {
+	switch {
+	case b.Remaining < 0:
+		return "exhausted"
+	case b.BurnRate1h >= fastBurnRate:
+		return "fast burn"
+	case b.BurnRate6h >= slowBurnRate:
+		return "slow burn"
+	default:
+		return "ok"
+	}
+}
@@ -0,0 +1,26 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeBudget(t *testing.T) {
+	b := computeBudget(99.9, 0.0005, 0.0001, 0.0002)
+	assert.InDelta(t, 0.001, b.Allowed, 1e-9)
+	assert.InDelta(t, 0.5, b.Remaining, 1e-9)
+	assert.InDelta(t, 0.1, b.BurnRate1h, 1e-9)
+	assert.InDelta(t, 0.2, b.BurnRate6h, 1e-9)
+	assert.Equal(t, "ok", b.state())
+
+	assert.Equal(t, "fast burn", computeBudget(99.9, 0.0005, 0.02, 0.001).state())
+	assert.Equal(t, "slow burn", computeBudget(99.9, 0.0005, 0.001, 0.007).state())
+	assert.Equal(t, "exhausted", computeBudget(99.9, 0.002, 0, 0).state())
+}
+
+func TestPromDuration(t *testing.T) {
+	assert.Equal(t, "43200m", promDuration(30*24*time.Hour))
+	assert.Equal(t, "60m", promDuration(time.Hour))
+}
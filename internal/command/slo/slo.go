@@ -0,0 +1,315 @@
+// Package slo implements the slo command chain.
+package slo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/prometheus"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() *cobra.Command {
+	const (
+		short = "Track service level objectives of an app"
+		long  = short + `. An SLO sets the percentage of good events, such as
+HTTP responses without a 5xx status, an app must achieve over a window. The
+error budget is the share of bad events the target allows; its burn rate is how
+many times faster than allowed the budget is being consumed.
+
+SLOs are stored as [[slo]] sections of fly.toml, so they are versioned with
+the app and shared with everyone deploying it.
+`
+	)
+
+	cmd := command.New("slo", short, long, nil)
+	cmd.AddCommand(newSet(), newStatus(), newDelete())
+	return cmd
+}
+
+// defaultWindow is the window of SLOs that don't set one.
+const defaultWindow = 30 * 24 * time.Hour
+
+func metricFlag() flag.String {
+	names := maps.Keys(metrics)
+	sort.Strings(names)
+	return flag.String{
+		Name:        "metric",
+		Description: "The metric of the SLO: " + strings.Join(names, ", "),
+		Default:     "http_5xx_ratio",
+	}
+}
+
+func newSet() *cobra.Command {
+	const (
+		short = "Set an SLO of an app"
+		long  = short + ", replacing the SLO of the same metric\n"
+	)
+
+	cmd := command.New("set", short, long, runSet, command.RequireAppName)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		metricFlag(),
+		flag.Float64{
+			Name:        "target",
+			Description: "The percentage of good events to achieve, such as 99.9",
+		},
+		flag.Duration{
+			Name:        "window",
+			Description: "The window the target applies to",
+			Default:     defaultWindow,
+		},
+	)
+	return cmd
+}
+
+func runSet(ctx context.Context) error {
+	var (
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+		metric  = flag.GetString(ctx, "metric")
+		target  = flag.GetFloat64(ctx, "target")
+		window  = flag.GetDuration(ctx, "window")
+	)
+
+	if _, ok := metrics[metric]; !ok {
+		return fmt.Errorf("unknown metric %s", metric)
+	}
+	if target <= 0 || target >= 100 {
+		return errors.New("--target must be a percentage between 0 and 100, such as 99.9")
+	}
+	if window < 24*time.Hour {
+		return errors.New("--window must be at least 24h")
+	}
+
+	cfg, err := localConfig(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	slos := lo.Reject(cfg.SLOs, func(o appconfig.SLO, _ int) bool { return o.Metric == metric })
+	slo := appconfig.SLO{Metric: metric, Target: target}
+	if window != defaultWindow {
+		slo.Window = &fly.Duration{Duration: window}
+	}
+	cfg.SLOs = append(slos, slo)
+
+	if err := cfg.WriteToDisk(ctx, cfg.ConfigFilePath()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%s of %s must stay below %g%% of events over %s\n", metric, appName, 100-target, window)
+	return nil
+}
+
+func newStatus() *cobra.Command {
+	const (
+		short = "Show the error budgets of the SLOs of an app"
+		long  = short + "\n"
+	)
+
+	cmd := command.New("status", short, long, runStatus, command.RequireSession, command.RequireAppName)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+	)
+	return cmd
+}
+
+func runStatus(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	slos, err := appObjectives(ctx, appName)
+	if err != nil {
+		return err
+	}
+	if len(slos) == 0 {
+		return fmt.Errorf("%s has no SLOs, set one with 'fly slo set'", appName)
+	}
+
+	app, err := fly.ClientFromContext(ctx).GetAppCompact(ctx, appName)
+	if err != nil {
+		return err
+	}
+	client := prometheus.NewClient(ctx, app.Organization.Slug)
+
+	type status struct {
+		appconfig.SLO
+		Window time.Duration `json:"window"`
+		budget
+		State string `json:"state"`
+	}
+	statuses := make([]status, 0, len(slos))
+	for _, o := range slos {
+		var ratios [3]float64
+		window := objectiveWindow(o)
+		for i, window := range []time.Duration{window, time.Hour, 6 * time.Hour} {
+			if ratios[i], err = errorRatio(ctx, client, appName, o.Metric, window); err != nil {
+				return err
+			}
+		}
+		b := computeBudget(o.Target, ratios[0], ratios[1], ratios[2])
+		statuses = append(statuses, status{SLO: o, Window: window, budget: b, State: b.state()})
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, statuses)
+	}
+
+	colorize := io.ColorScheme()
+	rows := make([][]string, 0, len(statuses))
+	for _, s := range statuses {
+		state := s.State
+		switch state {
+		case "ok":
+			state = colorize.Green(state)
+		case "slow burn":
+			state = colorize.Yellow(state)
+		default:
+			state = colorize.Red(state)
+		}
+		rows = append(rows, []string{
+			s.Metric,
+			fmt.Sprintf("%g%% over %s", s.Target, s.Window),
+			fmt.Sprintf("%.3f%%", 100*(1-s.ErrorRatio)),
+			fmt.Sprintf("%.1f%%", 100*s.Remaining),
+			fmt.Sprintf("%.2fx", s.BurnRate1h),
+			fmt.Sprintf("%.2fx", s.BurnRate6h),
+			state,
+		})
+	}
+	return render.Table(io.Out, "SLOs of "+appName, rows, "Metric", "Target", "Achieved", "Budget Left", "Burn 1h", "Burn 6h", "State")
+}
+
+// errorRatio returns the ratio of bad events of metric over window, 0 when
+// there were no events.
+func errorRatio(ctx context.Context, client *prometheus.Client, appName, metric string, window time.Duration) (float64, error) {
+	m := metrics[metric]
+	r := promDuration(window)
+
+	bad, err := scalar(ctx, client, fmt.Sprintf(m.Bad, appName, r))
+	if err != nil {
+		return 0, err
+	}
+	total, err := scalar(ctx, client, fmt.Sprintf(m.Total, appName, r))
+	if err != nil || total == 0 {
+		return 0, err
+	}
+	return bad / total, nil
+}
+
+// scalar returns the value of a query returning at most one sample, 0 when it
+// returns none.
+func scalar(ctx context.Context, client *prometheus.Client, query string) (float64, error) {
+	samples, err := client.Query(ctx, query, time.Time{})
+	if err != nil || len(samples) == 0 {
+		return 0, err
+	}
+	return samples[0].Value, nil
+}
+
+func newDelete() *cobra.Command {
+	const (
+		short = "Delete an SLO of an app"
+		long  = short + "\n"
+	)
+
+	cmd := command.New("delete", short, long, runDelete, command.RequireAppName)
+	cmd.Aliases = []string{"rm"}
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		metricFlag(),
+	)
+	return cmd
+}
+
+func runDelete(ctx context.Context) error {
+	var (
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+		metric  = flag.GetString(ctx, "metric")
+	)
+
+	cfg, err := localConfig(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	slos := lo.Reject(cfg.SLOs, func(o appconfig.SLO, _ int) bool { return o.Metric == metric })
+	if len(slos) == len(cfg.SLOs) {
+		return fmt.Errorf("%s has no %s SLO", appName, metric)
+	}
+	cfg.SLOs = slos
+
+	if err := cfg.WriteToDisk(ctx, cfg.ConfigFilePath()); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Deleted the %s SLO of %s\n", metric, appName)
+	return nil
+}
+
+// localConfig returns the fly.toml of appName, which SLOs are written to.
+func localConfig(ctx context.Context, appName string) (*appconfig.Config, error) {
+	cfg := appconfig.ConfigFromContext(ctx)
+	if cfg == nil || cfg.ConfigFilePath() == "" {
+		return nil, errors.New("SLOs are stored in fly.toml, run this command in the app's directory or pass --config")
+	}
+	if cfg.AppName != "" && cfg.AppName != appName {
+		return nil, fmt.Errorf("%s is the config of %s, not %s", cfg.ConfigFilePath(), cfg.AppName, appName)
+	}
+	return cfg, nil
+}
+
+// appObjectives returns the SLOs of the local fly.toml of appName, or else of
+// its deployed config.
+func appObjectives(ctx context.Context, appName string) ([]appconfig.SLO, error) {
+	if cfg := appconfig.ConfigFromContext(ctx); cfg != nil && cfg.AppName == appName {
+		return cfg.SLOs, nil
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: appName})
+	if err != nil {
+		return nil, fmt.Errorf("could not create flaps client: %w", err)
+	}
+	cfg, err := appconfig.FromRemoteApp(flaps.NewContext(ctx, flapsClient), appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the config of %s: %w", appName, err)
+	}
+	return cfg.SLOs, nil
+}
+
+// objectiveWindow returns the window of o, 30 days when unset.
+func objectiveWindow(o appconfig.SLO) time.Duration {
+	if o.Window == nil || o.Window.Duration == 0 {
+		return defaultWindow
+	}
+	return o.Window.Duration
+}
@@ -0,0 +1,62 @@
+package slo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestSetAndDeleteWriteFlyToml(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"my-app\"\nprimary_region = \"iad\"\n"), 0o644))
+
+	newCtx := func(metric string, target float64, window time.Duration) context.Context {
+		cfg, err := appconfig.LoadConfig(path)
+		require.NoError(t, err)
+
+		fs := pflag.NewFlagSet("slo", pflag.ContinueOnError)
+		fs.String("metric", metric, "")
+		fs.Float64("target", target, "")
+		fs.Duration("window", window, "")
+		ios, _, _, _ := iostreams.Test()
+		ctx := flag.NewContext(iostreams.NewContext(context.Background(), ios), fs)
+		return appconfig.WithName(appconfig.WithConfig(ctx, cfg), "my-app")
+	}
+
+	require.NoError(t, runSet(newCtx("http_5xx_ratio", 99.5, defaultWindow)))
+	require.NoError(t, runSet(newCtx("http_5xx_ratio", 99.9, 7*24*time.Hour)))
+
+	cfg, err := appconfig.LoadConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.SLOs, 1)
+	assert.Equal(t, 99.9, cfg.SLOs[0].Target)
+	assert.Equal(t, 7*24*time.Hour, objectiveWindow(cfg.SLOs[0]))
+	assert.Equal(t, "iad", cfg.PrimaryRegion)
+
+	require.NoError(t, runDelete(newCtx("http_5xx_ratio", 0, 0)))
+	cfg, err = appconfig.LoadConfig(path)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.SLOs)
+
+	assert.ErrorContains(t, runDelete(newCtx("http_5xx_ratio", 0, 0)), "my-app has no http_5xx_ratio SLO")
+}
+
+func TestLocalConfig(t *testing.T) {
+	_, err := localConfig(context.Background(), "my-app")
+	assert.ErrorContains(t, err, "SLOs are stored in fly.toml")
+
+	cfg := appconfig.NewConfig()
+	cfg.AppName = "other-app"
+	cfg.SetConfigFilePath("fly.toml")
+	_, err = localConfig(appconfig.WithConfig(context.Background(), cfg), "my-app")
+	assert.ErrorContains(t, err, "fly.toml is the config of other-app, not my-app")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/sentry"
@@ -39,9 +40,10 @@ func stdArgsSSH(cmd *cobra.Command) {
 			Description: "command to run on SSH session",
 		},
 		flag.String{
-			Name:        "machine",
-			Default:     "",
-			Description: "Run the console in the existing machine with the specified ID",
+			Name:         "machine",
+			Default:      "",
+			Description:  "Run the console in the existing machine with the specified ID",
+			CompletionFn: completion.CompleteMachines,
 		},
 		flag.Bool{
 			Name:        "select",
@@ -50,11 +52,6 @@ func stdArgsSSH(cmd *cobra.Command) {
 			Description: "select available instances",
 		},
 		flag.Region(),
-		flag.Bool{
-			Name:        "quiet",
-			Shorthand:   "q",
-			Description: "Don't print progress indicators for WireGuard",
-		},
 		flag.String{
 			Name:        "address",
 			Shorthand:   "A",
@@ -29,6 +29,9 @@ type SSHParams struct {
 	Stdout         io.WriteCloser
 	Stderr         io.WriteCloser
 	DisableSpinner bool
+	// NoPTY runs Cmd without a pseudo-terminal, so its output isn't mangled
+	// and Stdin can be a file or a pipe.
+	NoPTY bool
 }
 
 func RunSSHCommand(ctx context.Context, app *fly.AppCompact, dialer agent.Dialer, addr string, cmd string, username string) ([]byte, error) {
@@ -111,7 +114,7 @@ func SSHConnect(p *SSHParams, addr string) error {
 		Stdin:    p.Stdin,
 		Stdout:   p.Stdout,
 		Stderr:   p.Stderr,
-		AllocPTY: true,
+		AllocPTY: !p.NoPTY,
 		TermEnv:  "xterm",
 	}
 
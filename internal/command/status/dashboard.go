@@ -0,0 +1,386 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/inancgumus/screen"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"golang.org/x/term"
+
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const (
+	dashboardEvents = 5
+	dashboardLogs   = 10
+)
+
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyRestart
+	keyLogs
+	keyYes
+	keyNo
+	keyQuit
+)
+
+// parseKeys translates the bytes read from a terminal in raw mode to keys.
+func parseKeys(b []byte) (keys []key) {
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case 0x1b:
+			if i+2 < len(b) && b[i+1] == '[' {
+				switch b[i+2] {
+				case 'A':
+					keys = append(keys, keyUp)
+				case 'B':
+					keys = append(keys, keyDown)
+				}
+				i += 2
+			} else {
+				keys = append(keys, keyNo)
+			}
+		case 'k':
+			keys = append(keys, keyUp)
+		case 'j':
+			keys = append(keys, keyDown)
+		case 'r':
+			keys = append(keys, keyRestart)
+		case 'l':
+			keys = append(keys, keyLogs)
+		case 'y':
+			keys = append(keys, keyYes)
+		case 'n':
+			keys = append(keys, keyNo)
+		case 'q', 0x03:
+			keys = append(keys, keyQuit)
+		}
+	}
+	return keys
+}
+
+type action int
+
+const (
+	actionNone action = iota
+	actionRedraw
+	actionRefresh
+	actionRestart
+	actionQuit
+)
+
+// dashboard is the state of the status --watch view.
+type dashboard struct {
+	app      *fly.AppCompact
+	machines []*fly.Machine
+	release  *fly.Release
+	logs     []fly.LogEntry
+
+	selected       string
+	showLogs       bool
+	pendingRestart string
+	message        string
+	updatedAt      time.Time
+}
+
+func (d *dashboard) selectedIndex() int {
+	for i, m := range d.machines {
+		if m.ID == d.selected {
+			return i
+		}
+	}
+	return 0
+}
+
+func (d *dashboard) selectedMachine() *fly.Machine {
+	if len(d.machines) == 0 {
+		return nil
+	}
+	return d.machines[d.selectedIndex()]
+}
+
+// setMachines replaces the machines shown, keeping the selected machine when
+// it still exists.
+func (d *dashboard) setMachines(machines []*fly.Machine) {
+	sort.Slice(machines, func(i, j int) bool {
+		if a, b := getProcessgroup(machines[i]), getProcessgroup(machines[j]); a != b {
+			return a < b
+		}
+		return machines[i].ID < machines[j].ID
+	})
+	d.machines = machines
+	if m := d.selectedMachine(); m != nil {
+		d.selected = m.ID
+	} else {
+		d.selected = ""
+	}
+}
+
+// handle applies k to the dashboard and returns what the caller must do next.
+func (d *dashboard) handle(k key) action {
+	if d.pendingRestart != "" {
+		switch k {
+		case keyYes:
+			return actionRestart
+		case keyQuit:
+			return actionQuit
+		default:
+			d.pendingRestart = ""
+			d.message = "Restart cancelled"
+			return actionRedraw
+		}
+	}
+
+	switch k {
+	case keyUp, keyDown:
+		if len(d.machines) == 0 {
+			return actionNone
+		}
+		i := d.selectedIndex()
+		if k == keyUp && i > 0 {
+			i--
+		} else if k == keyDown && i < len(d.machines)-1 {
+			i++
+		}
+		d.selected = d.machines[i].ID
+		if d.showLogs {
+			return actionRefresh
+		}
+		return actionRedraw
+	case keyRestart:
+		if m := d.selectedMachine(); m != nil {
+			d.pendingRestart = m.ID
+			d.message = fmt.Sprintf("Restart machine %s? (y/n)", m.ID)
+			return actionRedraw
+		}
+	case keyLogs:
+		d.showLogs = !d.showLogs
+		return actionRefresh
+	case keyQuit:
+		return actionQuit
+	}
+	return actionNone
+}
+
+// render writes the dashboard to out.
+func (d *dashboard) render(out io.Writer, colorize *iostreams.ColorScheme) error {
+	fmt.Fprintf(out, "%s at: %s\n\n", colorize.Bold(d.app.Name), colorize.Bold(d.updatedAt.UTC().Format("15:04:05")))
+
+	if r := d.release; r != nil {
+		fmt.Fprintf(out, "Release v%d %s, %s", r.Version, r.Status, humanize.Time(r.CreatedAt))
+		if r.User.Email != "" {
+			fmt.Fprintf(out, " by %s", r.User.Email)
+		}
+		if r.Description != "" {
+			fmt.Fprintf(out, ": %s", r.Description)
+		}
+		fmt.Fprintln(out)
+	}
+
+	selected := d.selectedMachine()
+	rows := make([][]string, 0, len(d.machines))
+	for _, m := range d.machines {
+		marker := " "
+		if m == selected {
+			marker = ">"
+		}
+		rows = append(rows, []string{
+			marker,
+			getProcessgroup(m),
+			m.ID,
+			getReleaseVersion(m),
+			m.Region,
+			m.State,
+			render.MachineHealthChecksSummary(m),
+			m.UpdatedAt,
+		})
+	}
+	if err := render.Table(out, "Machines", rows, "", "Process", "ID", "Version", "Region", "State", "Checks", "Last Updated"); err != nil {
+		return err
+	}
+
+	if selected != nil {
+		if len(selected.Checks) > 0 {
+			rows = rows[:0]
+			for _, c := range selected.Checks {
+				rows = append(rows, []string{c.Name, string(c.Status), firstLine(c.Output, 80)})
+			}
+			if err := render.Table(out, "Checks of "+selected.ID, rows, "Name", "Status", "Output"); err != nil {
+				return err
+			}
+		}
+
+		events := selected.Events
+		if len(events) > dashboardEvents {
+			events = events[:dashboardEvents]
+		}
+		if len(events) > 0 {
+			rows = rows[:0]
+			for _, e := range events {
+				rows = append(rows, []string{humanize.Time(e.Time()), e.Type, e.Status, e.Source})
+			}
+			if err := render.Table(out, "Events of "+selected.ID, rows, "Time", "Type", "Status", "Source"); err != nil {
+				return err
+			}
+		}
+
+		if d.showLogs {
+			fmt.Fprintf(out, "Logs of %s\n", selected.ID)
+			if len(d.logs) == 0 {
+				fmt.Fprintln(out, "  no recent logs")
+			}
+			for _, entry := range d.logs {
+				fmt.Fprintf(out, "  %s %s\n", entry.Timestamp, firstLine(entry.Message, 120))
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	fmt.Fprintln(out, colorize.Gray("↑/↓ select  r restart  l logs  q quit"))
+	if d.message != "" {
+		fmt.Fprintln(out, colorize.Yellow(d.message))
+	}
+	return nil
+}
+
+func firstLine(s string, width int) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	if len(s) > width {
+		s = s[:width-3] + "..."
+	}
+	return s
+}
+
+func runDashboard(ctx context.Context, appName string, rate time.Duration) error {
+	var (
+		streams = iostreams.FromContext(ctx)
+		client  = fly.ClientFromContext(ctx)
+	)
+
+	app, err := client.GetAppCompact(ctx, appName)
+	if err != nil {
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+
+	flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+		AppCompact: app,
+		AppName:    app.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	keys := make(chan key)
+	go func() {
+		buf := make([]byte, 16)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, k := range parseKeys(buf[:n]) {
+				select {
+				case keys <- k:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	d := &dashboard{app: app}
+
+	refresh := func() {
+		machines, err := flapsClient.ListActive(ctx)
+		if err != nil {
+			d.message = fmt.Sprintf("failed to list machines: %v", err)
+			return
+		}
+		d.setMachines(machines)
+
+		if release, err := client.GetAppCurrentReleaseMachines(ctx, app.Name); err == nil {
+			d.release = release
+		}
+
+		d.logs = nil
+		if m := d.selectedMachine(); d.showLogs && m != nil {
+			logs, _, err := client.GetAppLogs(ctx, app.Name, "", "", m.ID)
+			if err != nil {
+				d.message = fmt.Sprintf("failed to fetch logs: %v", err)
+			} else if len(logs) > dashboardLogs {
+				logs = logs[len(logs)-dashboardLogs:]
+			}
+			d.logs = logs
+		}
+		d.updatedAt = time.Now()
+	}
+
+	var buf bytes.Buffer
+	draw := func() {
+		buf.Reset()
+		if err := d.render(&buf, streams.ColorScheme()); err != nil {
+			fmt.Fprintln(&buf, err)
+		}
+		screen.Clear()
+		screen.MoveTopLeft()
+		// Raw mode disables the translation of newlines to carriage returns
+		streams.Out.Write(bytes.ReplaceAll(buf.Bytes(), []byte("\n"), []byte("\r\n")))
+	}
+
+	ticker := time.NewTicker(rate)
+	defer ticker.Stop()
+
+	refresh()
+	draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			refresh()
+		case k := <-keys:
+			switch d.handle(k) {
+			case actionNone:
+				continue
+			case actionRefresh:
+				refresh()
+			case actionRestart:
+				id := d.pendingRestart
+				d.pendingRestart = ""
+				d.message = fmt.Sprintf("Restarting machine %s...", id)
+				draw()
+				if err := flapsClient.Restart(ctx, fly.RestartMachineInput{ID: id}, ""); err != nil {
+					d.message = fmt.Sprintf("failed to restart machine %s: %v", id, err)
+				} else {
+					d.message = fmt.Sprintf("Restarted machine %s", id)
+				}
+				refresh()
+			case actionQuit:
+				return nil
+			}
+		}
+		draw()
+	}
+}
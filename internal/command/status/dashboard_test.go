@@ -0,0 +1,80 @@
+package status
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []key{keyUp, keyDown, keyUp, keyDown}, parseKeys([]byte("\x1b[A\x1b[Bkj")))
+	assert.Equal(t, []key{keyRestart, keyYes, keyLogs, keyQuit, keyQuit}, parseKeys([]byte("rylq\x03")))
+	assert.Equal(t, []key{keyNo}, parseKeys([]byte("\x1b")))
+	assert.Empty(t, parseKeys([]byte("x")))
+}
+
+func dashboardMachines(ids ...string) []*fly.Machine {
+	machines := make([]*fly.Machine, 0, len(ids))
+	for _, id := range ids {
+		machines = append(machines, &fly.Machine{ID: id, Region: "ord", State: "started", Config: &fly.MachineConfig{}})
+	}
+	return machines
+}
+
+func TestDashboardNavigation(t *testing.T) {
+	d := &dashboard{app: &fly.AppCompact{Name: "app"}}
+	d.setMachines(dashboardMachines("c", "a", "b"))
+	assert.Equal(t, "a", d.selected)
+
+	assert.Equal(t, actionNone, (&dashboard{}).handle(keyDown))
+	assert.Equal(t, actionRedraw, d.handle(keyUp))
+	assert.Equal(t, "a", d.selected)
+	d.handle(keyDown)
+	d.handle(keyDown)
+	d.handle(keyDown)
+	assert.Equal(t, "c", d.selected)
+
+	// The selection survives refreshes, and falls back to the first machine
+	d.setMachines(dashboardMachines("b", "c"))
+	assert.Equal(t, "c", d.selected)
+	d.setMachines(dashboardMachines("a", "b"))
+	assert.Equal(t, "a", d.selected)
+
+	assert.Equal(t, actionRefresh, d.handle(keyLogs))
+	assert.True(t, d.showLogs)
+	assert.Equal(t, actionRefresh, d.handle(keyDown))
+	assert.Equal(t, actionQuit, d.handle(keyQuit))
+}
+
+func TestDashboardRestartConfirmation(t *testing.T) {
+	d := &dashboard{app: &fly.AppCompact{Name: "app"}}
+	d.setMachines(dashboardMachines("a", "b"))
+
+	assert.Equal(t, actionRedraw, d.handle(keyRestart))
+	assert.Equal(t, "a", d.pendingRestart)
+	assert.Equal(t, actionRedraw, d.handle(keyNo))
+	assert.Empty(t, d.pendingRestart)
+
+	d.handle(keyDown)
+	d.handle(keyRestart)
+	assert.Equal(t, actionRestart, d.handle(keyYes))
+	assert.Equal(t, "b", d.pendingRestart)
+}
+
+func TestDashboardRender(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	d := &dashboard{app: &fly.AppCompact{Name: "app"}, release: &fly.Release{Version: 3, Status: "complete"}}
+	d.setMachines(dashboardMachines("a", "b"))
+	d.showLogs = true
+	d.logs = []fly.LogEntry{{Timestamp: "2024-01-01T00:00:00Z", Message: "listening\non 8080"}}
+
+	var out bytes.Buffer
+	require.NoError(t, d.render(&out, ios.ColorScheme()))
+	assert.Contains(t, out.String(), "Release v3 complete")
+	assert.Contains(t, out.String(), "Logs of a")
+	assert.Contains(t, out.String(), "listening\n")
+}
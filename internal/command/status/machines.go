@@ -255,21 +255,35 @@ func renderMachineJSONStatus(ctx context.Context, app *fly.AppCompact, machines
 		}
 	}
 
-	status := map[string]any{
-		"ID":              app.ID,
-		"Name":            app.Name,
-		"Deployed":        app.Deployed,
-		"Status":          app.Status,
-		"Hostname":        app.Hostname,
-		"Version":         version,
-		"AppURL":          app.AppURL,
-		"Organization":    app.Organization,
-		"PlatformVersion": app.PlatformVersion,
-		"Machines":        machinesToShow,
+	status := AppStatus{
+		ID:              app.ID,
+		Name:            app.Name,
+		Deployed:        app.Deployed,
+		Status:          app.Status,
+		Hostname:        app.Hostname,
+		Version:         version,
+		AppURL:          app.AppURL,
+		Organization:    app.Organization,
+		PlatformVersion: app.PlatformVersion,
+		Machines:        machinesToShow,
 	}
 	return render.JSON(out, status)
 }
 
+// AppStatus is the JSON output of the status command.
+type AppStatus struct {
+	ID              string
+	Name            string
+	Deployed        bool
+	Status          string
+	Hostname        string
+	Version         int
+	AppURL          string
+	Organization    *fly.OrganizationBasic
+	PlatformVersion string
+	Machines        []*fly.Machine
+}
+
 func renderPGStatus(ctx context.Context, app *fly.AppCompact, machines []*fly.Machine, out io.Writer) (err error) {
 	var (
 		io       = iostreams.FromContext(ctx)
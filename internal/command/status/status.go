@@ -2,16 +2,12 @@
 package status
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
-	"strings"
 	"time"
 
-	"github.com/azazeal/pause"
-	"github.com/inancgumus/screen"
 	"github.com/spf13/cobra"
 
 	fly "github.com/superfly/fly-go"
@@ -28,6 +24,10 @@ func New() (cmd *cobra.Command) {
 		long = `Show the application's current status including application
 details, tasks, most recent deployment details and in which regions it is
 currently allocated.
+
+With --watch, the status is refreshed continuously. Select a machine with the
+arrow keys to see its health checks and recent events, press r to restart it,
+l to show its logs, and q to quit.
 `
 		short = "Show app status"
 	)
@@ -53,7 +53,7 @@ currently allocated.
 		},
 		flag.Bool{
 			Name:        "watch",
-			Description: "Refresh details",
+			Description: "Continuously refresh the status, with keyboard navigation",
 		},
 		flag.Int{
 			Name:        "rate",
@@ -103,7 +103,6 @@ func runWatch(ctx context.Context) (err error) {
 
 		return
 	}
-	colorize := streams.ColorScheme()
 
 	sleep := flag.GetInt(ctx, "rate")
 	if sleep < 1 || sleep > 3600 {
@@ -112,29 +111,7 @@ func runWatch(ctx context.Context) (err error) {
 		return
 	}
 
-	appName := appconfig.NameFromContext(ctx)
-
-	var buf bytes.Buffer
-
-	for err == nil {
-		buf.Reset()
-
-		if err = once(ctx, &buf); err != nil {
-			break
-		}
-
-		header := fmt.Sprintf("%s %s %s\n\n", colorize.Bold(appName), "at:", colorize.Bold(time.Now().UTC().Format("15:04:05")))
-
-		screen.Clear()
-		screen.MoveTopLeft()
-
-		io.Copy(streams.Out, io.MultiReader(
-			strings.NewReader(header),
-			&buf,
-		))
-
-		pause.For(ctx, time.Duration(sleep)*time.Second)
-	}
+	err = runDashboard(ctx, appconfig.NameFromContext(ctx), time.Duration(sleep)*time.Second)
 
 	// Interrupted with Ctrl-C
 	if errors.Is(ctx.Err(), context.Canceled) {
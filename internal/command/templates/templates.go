@@ -0,0 +1,64 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/launchtemplate"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() *cobra.Command {
+	const (
+		short = "Find templates to launch apps from"
+		long  = `Launch templates are container images holding the files a new app starts
+from, like fly.toml and a Dockerfile, along with hooks run after launching.
+Launch one with 'fly launch --template <image>'.`
+	)
+	cmd := command.New("templates", short, long, nil)
+	cmd.AddCommand(newSearch())
+	return cmd
+}
+
+func newSearch() *cobra.Command {
+	const (
+		short = "Search community launch templates"
+		long  = `Search the GitHub repositories tagged with the '` + launchtemplate.SearchTopic + `' topic,
+whose templates are published on the GitHub container registry.`
+		usage = "search [query]"
+	)
+	cmd := command.New(usage, short, long, runSearch)
+	cmd.Args = cobra.ArbitraryArgs
+	flag.Add(cmd, flag.JSONOutput())
+	return cmd
+}
+
+func runSearch(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	results, err := launchtemplate.Search(ctx, strings.Join(flag.Args(ctx), " "))
+	if err != nil {
+		return err
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(io.Out, results)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintln(io.ErrOut, "No templates found")
+		return nil
+	}
+
+	rows := lo.Map(results, func(r launchtemplate.SearchResult, _ int) []string {
+		return []string{r.Ref, r.Description, fmt.Sprint(r.Stars)}
+	})
+	return render.Table(io.Out, "", rows, "Template", "Description", "Stars")
+}
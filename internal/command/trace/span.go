@@ -0,0 +1,115 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/superfly/flyctl/logs"
+)
+
+// Trace is what is known about a single request.
+type Trace struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Time      time.Time         `json:"time"`
+	Status    int               `json:"status"`
+	Size      int64             `json:"size"`
+	RequestID string            `json:"request_id"`
+	Timings   Timings           `json:"timings"`
+	Edge      map[string]string `json:"edge,omitempty"`
+	Logs      []logs.LogEntry   `json:"logs"`
+}
+
+// Timings are the client side durations of the phases of a request. DNS,
+// Connect and TLS are zero when a connection was reused.
+type Timings struct {
+	DNS       time.Duration `json:"dns"`
+	Connect   time.Duration `json:"connect"`
+	TLS       time.Duration `json:"tls"`
+	FirstByte time.Duration `json:"first_byte"`
+	Total     time.Duration `json:"total"`
+}
+
+type phase struct {
+	name     string
+	duration time.Duration
+}
+
+func (t Timings) phases() []phase {
+	return []phase{
+		{"DNS lookup", t.DNS},
+		{"TCP connect", t.Connect},
+		{"TLS handshake", t.TLS},
+		{"Time to first byte", t.FirstByte},
+		{"Total", t.Total},
+	}
+}
+
+// debugFields names the fields of the flyio-debug header fly-proxy responds
+// with.
+var debugFields = map[string]string{
+	"n":    "edge node",
+	"nr":   "edge region",
+	"ra":   "client address",
+	"rf":   "routing",
+	"sr":   "serving region",
+	"sdc":  "serving datacenter",
+	"sid":  "serving machine",
+	"st":   "serving state",
+	"nrtt": "edge round trip (ms)",
+	"bn":   "backend node",
+	"mhn":  "machine host node",
+	"mrtt": "machine round trip (ms)",
+}
+
+// parseDebug decodes the flyio-debug header, naming the fields it knows
+// about. Null fields are left out.
+func parseDebug(header string) map[string]string {
+	if header == "" {
+		return nil
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(header), &raw); err != nil {
+		return map[string]string{"raw": header}
+	}
+
+	fields := make(map[string]string, len(raw))
+	for k, v := range raw {
+		if v == nil {
+			continue
+		}
+		if name, ok := debugFields[k]; ok {
+			k = name
+		}
+		fields[k] = fmt.Sprint(v)
+	}
+	return fields
+}
+
+// matchesRequest reports whether entry was logged while handling the request
+// with the given ID, either by fly-proxy or by the app.
+func matchesRequest(entry logs.LogEntry, requestID string) bool {
+	return entry.Meta.HTTP.Request.ID == requestID || strings.Contains(entry.Message, requestID)
+}
+
+// sortLogs orders entries chronologically, keeping the order of entries
+// logged at the same time.
+func sortLogs(entries []logs.LogEntry) []logs.LogEntry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp < entries[j].Timestamp
+	})
+	return entries
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/superfly/flyctl/logs"
+)
+
+func TestParseDebug(t *testing.T) {
+	fields := parseDebug(`{"n":"edge-nac-iad1-5a0c","nr":"iad","sr":"ord","sid":"148e21","st":0,"mhn":null}`)
+
+	assert.Equal(t, map[string]string{
+		"edge node":       "edge-nac-iad1-5a0c",
+		"edge region":     "iad",
+		"serving region":  "ord",
+		"serving machine": "148e21",
+		"serving state":   "0",
+	}, fields)
+
+	assert.Nil(t, parseDebug(""))
+	assert.Equal(t, map[string]string{"raw": "garbage"}, parseDebug("garbage"))
+}
+
+func TestMatchesRequest(t *testing.T) {
+	var proxy logs.LogEntry
+	proxy.Meta.HTTP.Request.ID = "01HX"
+	assert.True(t, matchesRequest(proxy, "01HX"))
+	assert.False(t, matchesRequest(proxy, "01HY"))
+
+	app := logs.LogEntry{Message: "handled request_id=01HX in 12ms"}
+	assert.True(t, matchesRequest(app, "01HX"))
+	assert.False(t, matchesRequest(logs.LogEntry{Message: "booting"}, "01HX"))
+}
+
+func TestSortLogs(t *testing.T) {
+	entries := sortLogs([]logs.LogEntry{
+		{Timestamp: "2024-05-01T12:00:02Z", Message: "c"},
+		{Timestamp: "2024-05-01T12:00:01Z", Message: "a"},
+		{Timestamp: "2024-05-01T12:00:01Z", Message: "b"},
+	})
+
+	var messages []string
+	for _, e := range entries {
+		messages = append(messages, e.Message)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, messages)
+}
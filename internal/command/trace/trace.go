@@ -0,0 +1,321 @@
+// Package trace implements the trace command.
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"time"
+
+	"github.com/azazeal/pause"
+	"github.com/spf13/cobra"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/appconfig"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+	"github.com/superfly/flyctl/logs"
+)
+
+// debugHeader asks fly-proxy to report how it handled a request in the
+// response header of the same name.
+const debugHeader = "flyio-debug"
+
+func New() (cmd *cobra.Command) {
+	const (
+		short = "Trace a request through the Fly.io edge to your app"
+		long  = `Send a request to the app through fly-proxy with debugging enabled, then
+correlate the proxy and machine logs of that request with its client side
+timings in a single trace view, to debug slow or failing edge requests.
+
+  fly trace /api/health --method POST --header "Content-Type: application/json"
+`
+	)
+
+	cmd = command.New("trace [path]", short, long, run,
+		command.RequireSession,
+		command.RequireAppName,
+		command.LoadAppConfigIfPresent,
+	)
+	cmd.Args = cobra.MaximumNArgs(1)
+
+	flag.Add(cmd,
+		flag.App(),
+		flag.AppConfig(),
+		flag.JSONOutput(),
+		flag.String{
+			Name:        "method",
+			Shorthand:   "X",
+			Description: "The HTTP method of the request",
+			Default:     http.MethodGet,
+		},
+		flag.StringSlice{
+			Name:        "header",
+			Shorthand:   "H",
+			Description: "Headers of the request, as 'Name: value'",
+		},
+		flag.String{
+			Name:        "data",
+			Shorthand:   "d",
+			Description: "The body of the request",
+		},
+		flag.Duration{
+			Name:        "wait",
+			Description: "How long to wait for the logs of the request",
+			Default:     10 * time.Second,
+		},
+	)
+	return
+}
+
+func run(ctx context.Context) error {
+	var (
+		out     = iostreams.FromContext(ctx).Out
+		appName = appconfig.NameFromContext(ctx)
+	)
+
+	target, err := targetURL(ctx, appName)
+	if err != nil {
+		return err
+	}
+
+	req, err := newRequest(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	t, err := send(req)
+	if err != nil {
+		return err
+	}
+
+	if t.RequestID != "" {
+		t.Logs, err = collectLogs(ctx, appName, t.RequestID, flag.GetDuration(ctx, "wait"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.FromContext(ctx).JSONOutput {
+		return render.JSON(out, t)
+	}
+	return renderTrace(ctx, t)
+}
+
+// targetURL returns the public URL of the app, resolving the path argument
+// against it.
+func targetURL(ctx context.Context, appName string) (string, error) {
+	appConfig := appconfig.ConfigFromContext(ctx)
+	if appConfig == nil {
+		flapsClient, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{
+			AppName: appName,
+		})
+		if err != nil {
+			return "", fmt.Errorf("could not create flaps client: %w", err)
+		}
+		ctx = flaps.NewContext(ctx, flapsClient)
+
+		if appConfig, err = appconfig.FromRemoteApp(ctx, appName); err != nil {
+			return "", errors.New("The app config could not be found")
+		}
+	}
+
+	appURL := appConfig.URL()
+	if appURL == nil {
+		return "", errors.New("The app doesn't expose a public http service")
+	}
+
+	if path := flag.FirstArg(ctx); path != "" {
+		u, err := appURL.Parse(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse relative URI '%s': %w", path, err)
+		}
+		appURL = u
+	}
+	return appURL.String(), nil
+}
+
+func newRequest(ctx context.Context, target string) (*http.Request, error) {
+	var body io.Reader
+	if data := flag.GetString(ctx, "data"); data != "" {
+		body = strings.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(flag.GetString(ctx, "method")), target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range flag.GetStringSlice(ctx, "header") {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected 'Name: value'", h)
+		}
+		req.Header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	req.Header.Set(debugHeader, "doit")
+	req.Header.Set("User-Agent", "flyctl/trace")
+
+	return req, nil
+}
+
+// send issues req, timing each phase of it.
+func send(req *http.Request) (*Trace, error) {
+	var (
+		t                                             = &Trace{Method: req.Method, URL: req.URL.String()}
+		start, dnsStart, connectStart, tlsStart, sent time.Time
+	)
+
+	ct := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { dnsStart = time.Now() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { t.Timings.DNS = time.Since(dnsStart) },
+		ConnectStart:      func(string, string) { connectStart = time.Now() },
+		ConnectDone:       func(string, string, error) { t.Timings.Connect = time.Since(connectStart) },
+		TLSHandshakeStart: func() { tlsStart = time.Now() },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.Timings.TLS = time.Since(tlsStart) },
+		WroteRequest:      func(httptrace.WroteRequestInfo) { sent = time.Now() },
+		GotFirstResponseByte: func() {
+			t.Timings.FirstByte = time.Since(sent)
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), ct))
+
+	client := &http.Client{
+		Timeout: time.Minute,
+		// Trace the request that was asked for, not the ones it redirects to.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	start = time.Now()
+	t.Time = start
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	size, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response: %w", err)
+	}
+	t.Timings.Total = time.Since(start)
+
+	t.Status = resp.StatusCode
+	t.Size = size
+	t.RequestID = resp.Header.Get("fly-request-id")
+	t.Edge = parseDebug(resp.Header.Get(debugHeader))
+
+	return t, nil
+}
+
+// collectLogs polls the logs of the app until wait elapses, keeping the ones
+// of the request.
+func collectLogs(ctx context.Context, appName, requestID string, wait time.Duration) ([]logs.LogEntry, error) {
+	var (
+		client    = fly.ClientFromContext(ctx)
+		deadline  = time.Now().Add(wait)
+		nextToken string
+		entries   []logs.LogEntry
+	)
+
+	for {
+		batch, token, err := client.GetAppLogs(ctx, appName, nextToken, "", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching logs: %w", err)
+		}
+		if token != "" {
+			nextToken = token
+		}
+
+		for _, e := range batch {
+			entry := logs.LogEntry{
+				Instance:  e.Instance,
+				Level:     e.Level,
+				Message:   e.Message,
+				Region:    e.Region,
+				Timestamp: e.Timestamp,
+				Meta:      e.Meta,
+			}
+			if matchesRequest(entry, requestID) {
+				entries = append(entries, entry)
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return sortLogs(entries), nil
+		}
+		pause.For(ctx, time.Second)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func renderTrace(ctx context.Context, t *Trace) error {
+	var (
+		io  = iostreams.FromContext(ctx)
+		out = io.Out
+		cs  = io.ColorScheme()
+	)
+
+	status := fmt.Sprint(t.Status)
+	switch {
+	case t.Status >= 500:
+		status = cs.Red(status)
+	case t.Status >= 400:
+		status = cs.Yellow(status)
+	default:
+		status = cs.Green(status)
+	}
+
+	fmt.Fprintf(out, "%s %s %s (%d bytes)\n", cs.Bold(t.Method), t.URL, status, t.Size)
+	if t.RequestID != "" {
+		fmt.Fprintf(out, "Request ID: %s\n", t.RequestID)
+	}
+	fmt.Fprintln(out)
+
+	rows := [][]string{}
+	for _, phase := range t.Timings.phases() {
+		rows = append(rows, []string{phase.name, phase.duration.Round(time.Millisecond).String()})
+	}
+	if err := render.Table(out, "Client timings", rows, "Phase", "Duration"); err != nil {
+		return err
+	}
+
+	if len(t.Edge) > 0 {
+		rows := make([][]string, 0, len(t.Edge))
+		for _, k := range sortedKeys(t.Edge) {
+			rows = append(rows, []string{k, t.Edge[k]})
+		}
+		if err := render.Table(out, "Edge", rows, "Field", "Value"); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case t.RequestID == "":
+		fmt.Fprintln(out, "The response has no fly-request-id header, so its logs can't be correlated. Is the request served through fly-proxy?")
+	case len(t.Logs) == 0:
+		fmt.Fprintln(out, "No logs of this request were found. Logs may take a few more seconds to arrive, try a longer --wait.")
+	default:
+		fmt.Fprintln(out, "Logs")
+		for _, entry := range t.Logs {
+			if err := render.LogEntry(out, entry, render.RemoveNewlines()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
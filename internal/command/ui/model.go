@@ -0,0 +1,323 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyEnter
+	keyBack
+	keyTab
+	keyRestart
+	keyScale
+	keyLogs
+	keySSH
+	keyRefresh
+	keyYes
+	keyNo
+	keyQuit
+)
+
+// parseKeys translates the bytes read from a terminal in raw mode to keys.
+func parseKeys(b []byte) (keys []key) {
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case 0x1b:
+			if i+2 < len(b) && b[i+1] == '[' {
+				switch b[i+2] {
+				case 'A':
+					keys = append(keys, keyUp)
+				case 'B':
+					keys = append(keys, keyDown)
+				case 'C':
+					keys = append(keys, keyEnter)
+				case 'D':
+					keys = append(keys, keyBack)
+				}
+				i += 2
+			} else {
+				keys = append(keys, keyBack)
+			}
+		case 'k':
+			keys = append(keys, keyUp)
+		case 'j':
+			keys = append(keys, keyDown)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x7f, 'h':
+			keys = append(keys, keyBack)
+		case '\t':
+			keys = append(keys, keyTab)
+		case 'r':
+			keys = append(keys, keyRestart)
+		case 's':
+			keys = append(keys, keyScale)
+		case 'l':
+			keys = append(keys, keyLogs)
+		case 'x':
+			keys = append(keys, keySSH)
+		case 'R':
+			keys = append(keys, keyRefresh)
+		case 'y':
+			keys = append(keys, keyYes)
+		case 'n':
+			keys = append(keys, keyNo)
+		case 'q', 0x03:
+			keys = append(keys, keyQuit)
+		}
+	}
+	return keys
+}
+
+// level is how deep the user has browsed.
+type level int
+
+const (
+	levelOrgs level = iota
+	levelApps
+	levelApp
+)
+
+// tab is the resources of an app being browsed.
+type tab int
+
+const (
+	tabMachines tab = iota
+	tabVolumes
+	tabReleases
+	tabCount
+)
+
+func (t tab) String() string {
+	return [...]string{"Machines", "Volumes", "Releases"}[t]
+}
+
+// item is a row of a list. Group is the process group of machines.
+type item struct {
+	ID      string
+	Group   string
+	Columns []string
+}
+
+// list is a selectable list of items. offset is the first item shown when
+// the list doesn't fit the terminal.
+type list struct {
+	headers []string
+	items   []item
+	cursor  int
+	offset  int
+}
+
+func (l *list) selected() *item {
+	if len(l.items) == 0 {
+		return nil
+	}
+	return &l.items[l.cursor]
+}
+
+func (l *list) move(delta int) {
+	l.cursor = max(0, min(len(l.items)-1, l.cursor+delta))
+}
+
+// window scrolls the list so the cursor is within size items from offset,
+// and returns the range of items to show.
+func (l *list) window(size int) (start, end int) {
+	if size <= 0 || len(l.items) <= size {
+		l.offset = 0
+		return 0, len(l.items)
+	}
+	switch {
+	case l.cursor < l.offset:
+		l.offset = l.cursor
+	case l.cursor >= l.offset+size:
+		l.offset = l.cursor - size + 1
+	}
+	l.offset = min(l.offset, len(l.items)-size)
+	return l.offset, l.offset + size
+}
+
+// set replaces the items, keeping the selected item when it still exists.
+func (l *list) set(headers []string, items []item) {
+	var id string
+	if s := l.selected(); s != nil {
+		id = s.ID
+	}
+	l.headers, l.items, l.cursor = headers, items, 0
+	for i, it := range items {
+		if it.ID == id {
+			l.cursor = i
+		}
+	}
+}
+
+type actionKind int
+
+const (
+	actionNone actionKind = iota
+	actionRedraw
+	// actionLoad asks the caller to load the items of the current level.
+	actionLoad
+	// actionRun asks the caller to run a flyctl command with the terminal.
+	actionRun
+	// actionScale asks the caller to prompt for a machine count, then run a
+	// flyctl command to scale to it.
+	actionScale
+	actionQuit
+)
+
+type action struct {
+	kind actionKind
+	args []string
+}
+
+// model is the state of the fly ui view. height is the number of lines of
+// the terminal, 0 when unknown.
+type model struct {
+	level  level
+	tab    tab
+	height int
+
+	org string
+	app string
+
+	orgs list
+	apps list
+	tabs [tabCount]list
+
+	// confirm is the command waiting for confirmation, and prompt the
+	// question asked.
+	confirm []string
+	prompt  string
+	message string
+}
+
+func (m *model) current() *list {
+	switch m.level {
+	case levelOrgs:
+		return &m.orgs
+	case levelApps:
+		return &m.apps
+	default:
+		return &m.tabs[m.tab]
+	}
+}
+
+// handle applies k to the model and returns what the caller must do next.
+func (m *model) handle(k key) action {
+	if m.confirm != nil {
+		args := m.confirm
+		m.confirm, m.prompt = nil, ""
+		switch k {
+		case keyYes:
+			return action{kind: actionRun, args: args}
+		case keyQuit:
+			return action{kind: actionQuit}
+		default:
+			m.message = "Cancelled"
+			return action{kind: actionRedraw}
+		}
+	}
+
+	m.message = ""
+	l := m.current()
+
+	switch k {
+	case keyUp:
+		l.move(-1)
+		return action{kind: actionRedraw}
+	case keyDown:
+		l.move(1)
+		return action{kind: actionRedraw}
+	case keyEnter:
+		s := l.selected()
+		if s == nil {
+			return action{kind: actionNone}
+		}
+		switch m.level {
+		case levelOrgs:
+			m.level, m.org = levelApps, s.ID
+			m.apps = list{}
+			return action{kind: actionLoad}
+		case levelApps:
+			m.level, m.app, m.tab = levelApp, s.ID, tabMachines
+			m.tabs = [tabCount]list{}
+			return action{kind: actionLoad}
+		}
+	case keyBack:
+		if m.level > levelOrgs {
+			m.level--
+			if len(m.current().items) == 0 {
+				// Browsing may have started past this level, with --org.
+				return action{kind: actionLoad}
+			}
+			return action{kind: actionRedraw}
+		}
+	case keyTab:
+		if m.level == levelApp {
+			m.tab = (m.tab + 1) % tabCount
+			return action{kind: actionLoad}
+		}
+	case keyRefresh:
+		return action{kind: actionLoad}
+	case keyLogs:
+		switch {
+		case m.level == levelApps && l.selected() != nil:
+			return action{kind: actionRun, args: []string{"logs", "--app", l.selected().ID}}
+		case m.level == levelApp && m.tab == tabMachines && l.selected() != nil:
+			return action{kind: actionRun, args: []string{"logs", "--app", m.app, "--instance", l.selected().ID}}
+		case m.level == levelApp:
+			return action{kind: actionRun, args: []string{"logs", "--app", m.app}}
+		}
+	case keySSH:
+		if m.level == levelApp && m.tab == tabMachines && l.selected() != nil {
+			return action{kind: actionRun, args: []string{"ssh", "console", "--app", m.app, "--machine", l.selected().ID}}
+		}
+	case keyRestart:
+		if m.level == levelApp && m.tab == tabMachines && l.selected() != nil {
+			id := l.selected().ID
+			m.confirm = []string{"machine", "restart", id, "--app", m.app}
+			m.prompt = fmt.Sprintf("Restart machine %s? (y/n)", id)
+			return action{kind: actionRedraw}
+		}
+	case keyScale:
+		if m.level == levelApp && m.tab == tabMachines && l.selected() != nil {
+			return action{kind: actionScale, args: []string{"scale", "count", "--app", m.app, "--process-group", l.selected().Group}}
+		}
+	case keyQuit:
+		return action{kind: actionQuit}
+	}
+	return action{kind: actionNone}
+}
+
+// breadcrumb tells where the user is.
+func (m *model) breadcrumb() string {
+	parts := []string{"orgs"}
+	if m.level >= levelApps {
+		parts = append(parts, m.org)
+	}
+	if m.level >= levelApp {
+		parts = append(parts, m.app)
+	}
+	return strings.Join(parts, " › ")
+}
+
+// help lists the keys bound at the current level.
+func (m *model) help() string {
+	switch m.level {
+	case levelOrgs:
+		return "↑/↓ select  enter open  R refresh  q quit"
+	case levelApps:
+		return "↑/↓ select  enter open  ← back  l logs  R refresh  q quit"
+	default:
+		if m.tab == tabMachines {
+			return "↑/↓ select  tab next  ← back  r restart  s scale  l logs  x ssh  R refresh  q quit"
+		}
+		return "↑/↓ select  tab next  ← back  l logs  R refresh  q quit"
+	}
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t,
+		[]key{keyUp, keyDown, keyEnter, keyBack, keyEnter, keyTab, keyRestart, keyScale, keyLogs, keySSH, keyRefresh, keyBack, keyQuit},
+		parseKeys([]byte("\x1b[A\x1b[B\x1b[C\x1b[D\r\trslxR\x1bq")),
+	)
+}
+
+func testModel() *model {
+	m := &model{}
+	m.orgs.set([]string{"Slug"}, []item{{ID: "personal"}, {ID: "acme"}})
+	return m
+}
+
+func TestNavigation(t *testing.T) {
+	m := testModel()
+
+	assert.Equal(t, actionRedraw, m.handle(keyDown).kind)
+	assert.Equal(t, actionLoad, m.handle(keyEnter).kind)
+	assert.Equal(t, levelApps, m.level)
+	assert.Equal(t, "acme", m.org)
+
+	m.apps.set([]string{"Name"}, []item{{ID: "web"}})
+	assert.Equal(t, actionLoad, m.handle(keyEnter).kind)
+	assert.Equal(t, levelApp, m.level)
+	assert.Equal(t, "web", m.app)
+	assert.Equal(t, "orgs › acme › web", m.breadcrumb())
+
+	assert.Equal(t, actionLoad, m.handle(keyTab).kind)
+	assert.Equal(t, tabVolumes, m.tab)
+	m.handle(keyTab)
+	m.handle(keyTab)
+	assert.Equal(t, tabMachines, m.tab)
+
+	assert.Equal(t, actionRedraw, m.handle(keyBack).kind)
+	assert.Equal(t, levelApps, m.level)
+	m.handle(keyBack)
+	assert.Equal(t, actionNone, m.handle(keyBack).kind)
+	assert.Equal(t, levelOrgs, m.level)
+	// The cursor is kept when going back.
+	assert.Equal(t, "acme", m.orgs.selected().ID)
+}
+
+func TestMachineActions(t *testing.T) {
+	m := &model{level: levelApp, app: "web"}
+	m.tabs[tabMachines].set([]string{"ID"}, []item{{ID: "148e", Group: "app"}, {ID: "3d8d", Group: "worker"}})
+
+	a := m.handle(keyLogs)
+	assert.Equal(t, action{kind: actionRun, args: []string{"logs", "--app", "web", "--instance", "148e"}}, a)
+
+	m.handle(keyDown)
+	a = m.handle(keyScale)
+	assert.Equal(t, action{kind: actionScale, args: []string{"scale", "count", "--app", "web", "--process-group", "worker"}}, a)
+
+	// Restarting asks for confirmation first.
+	assert.Equal(t, actionRedraw, m.handle(keyRestart).kind)
+	assert.Equal(t, "Restart machine 3d8d? (y/n)", m.prompt)
+	assert.Equal(t, action{kind: actionRun, args: []string{"machine", "restart", "3d8d", "--app", "web"}}, m.handle(keyYes))
+
+	m.handle(keyRestart)
+	assert.Equal(t, actionRedraw, m.handle(keyNo).kind)
+	assert.Equal(t, "Cancelled", m.message)
+
+	// Machine actions don't apply to other tabs.
+	m.tab = tabReleases
+	assert.Equal(t, actionNone, m.handle(keyRestart).kind)
+	assert.Equal(t, action{kind: actionRun, args: []string{"logs", "--app", "web"}}, m.handle(keyLogs))
+}
+
+func TestListKeepsSelection(t *testing.T) {
+	var l list
+	l.set(nil, []item{{ID: "a"}, {ID: "b"}, {ID: "c"}})
+	l.move(2)
+	l.set(nil, []item{{ID: "c"}, {ID: "d"}})
+	assert.Equal(t, "c", l.selected().ID)
+
+	l.move(10)
+	assert.Equal(t, "d", l.selected().ID)
+	l.set(nil, nil)
+	assert.Nil(t, l.selected())
+}
+
+func TestRender(t *testing.T) {
+	m := &model{level: levelApp, org: "acme", app: "web", tab: tabVolumes}
+	m.tabs[tabVolumes].set([]string{"ID", "Name"}, []item{{ID: "vol_1", Columns: []string{"vol_1", "data"}}})
+
+	var out strings.Builder
+	require.NoError(t, m.render(&out, iostreams.System().ColorScheme()))
+	assert.Contains(t, out.String(), "orgs › acme › web")
+	assert.Contains(t, out.String(), "[Volumes]")
+	assert.Contains(t, out.String(), "vol_1")
+	assert.NotContains(t, out.String(), "r restart")
+}
+
+func TestListWindow(t *testing.T) {
+	var l list
+	items := make([]item, 10)
+	for i := range items {
+		items[i] = item{ID: fmt.Sprint(i)}
+	}
+	l.set(nil, items)
+
+	start, end := l.window(0)
+	assert.Equal(t, [2]int{0, 10}, [2]int{start, end})
+
+	start, end = l.window(4)
+	assert.Equal(t, [2]int{0, 4}, [2]int{start, end})
+
+	// Moving past the bottom scrolls down to keep the cursor visible
+	l.move(5)
+	start, end = l.window(4)
+	assert.Equal(t, [2]int{2, 6}, [2]int{start, end})
+
+	// and moving above the top scrolls back up
+	l.move(-4)
+	start, end = l.window(4)
+	assert.Equal(t, [2]int{1, 5}, [2]int{start, end})
+
+	// A taller terminal shows more items, never past the end
+	l.move(10)
+	start, end = l.window(6)
+	assert.Equal(t, [2]int{4, 10}, [2]int{start, end})
+}
+
+func TestRenderFitsHeight(t *testing.T) {
+	m := &model{level: levelApp, org: "acme", app: "web", height: 15}
+	items := make([]item, 40)
+	for i := range items {
+		id := fmt.Sprintf("m%02d", i)
+		items[i] = item{ID: id, Columns: []string{id}}
+	}
+	m.tabs[tabMachines].set([]string{"ID"}, items)
+	m.tabs[tabMachines].move(20)
+	m.message = "Restarted"
+
+	var out strings.Builder
+	require.NoError(t, m.render(&out, iostreams.System().ColorScheme()))
+	assert.Less(t, strings.Count(out.String(), "\n"), m.height)
+	assert.Contains(t, out.String(), ">\tm20")
+	assert.Contains(t, out.String(), "of 40")
+	assert.NotContains(t, out.String(), "m00")
+}
@@ -0,0 +1,396 @@
+// Package ui implements the ui command.
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/inancgumus/screen"
+	"github.com/spf13/cobra"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"golang.org/x/term"
+
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func New() (cmd *cobra.Command) {
+	const (
+		short = "Browse orgs, apps and their resources in a full-screen view"
+		long  = `Browse organizations, their apps, and the machines, volumes and releases
+of apps in a full-screen view, acting on them with single keys:
+
+  enter/→  open          ←/esc  back        tab  next resource
+  r        restart       s      scale       l    logs
+  x        ssh console   R      refresh     q    quit
+
+Logs, SSH consoles and scaling run the corresponding fly commands, and come
+back to the view when they exit.
+`
+	)
+
+	cmd = command.New("ui", short, long, run, command.RequireSession)
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.Org(),
+	)
+	return
+}
+
+func run(ctx context.Context) error {
+	streams := iostreams.FromContext(ctx)
+	if !streams.IsInteractive() {
+		return errors.New("fly ui requires an interactive terminal")
+	}
+
+	u := &ui{
+		client:  fly.ClientFromContext(ctx),
+		streams: streams,
+	}
+	if org := flag.GetOrg(ctx); org != "" {
+		u.model.level, u.model.org = levelApps, org
+	}
+	return u.run(ctx)
+}
+
+type ui struct {
+	model   model
+	client  *fly.Client
+	streams *iostreams.IOStreams
+
+	// flapsApp and flapsClient cache the client of the app being browsed.
+	flapsApp    string
+	flapsClient *flaps.Client
+}
+
+// Escape sequences switching to the alternate screen of the terminal and
+// back, so the view doesn't scroll the shell's output away.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+)
+
+// chromeLines is the number of lines of the view besides list items: the
+// breadcrumb, tabs, table header, scroll position, help and message, and
+// the last line left empty so that the view doesn't scroll.
+const chromeLines = 9
+
+func (u *ui) run(ctx context.Context) error {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() { term.Restore(fd, state) }()
+
+	fmt.Fprint(u.streams.Out, enterAltScreen)
+	defer fmt.Fprint(u.streams.Out, exitAltScreen)
+
+	// Interrupting a command run from the view, such as to stop following
+	// logs, also cancels ctx, which must not quit the view: it only quits
+	// with q, as the terminal is in raw mode otherwise.
+	ctx = context.WithoutCancel(ctx)
+
+	u.load(ctx)
+	u.draw()
+
+	// Keys are read in this loop rather than in the background, so that
+	// commands run from the view get all the input while they run.
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range parseKeys(buf[:n]) {
+			switch a := u.model.handle(k); a.kind {
+			case actionNone:
+				continue
+			case actionLoad:
+				u.load(ctx)
+			case actionRun, actionScale:
+				term.Restore(fd, state)
+
+				args := a.args
+				if a.kind == actionScale {
+					args = u.promptCount(args)
+				}
+				if args != nil {
+					u.runCommand(ctx, args)
+				}
+
+				if state, err = term.MakeRaw(fd); err != nil {
+					return err
+				}
+				u.load(ctx)
+			case actionQuit:
+				return nil
+			}
+			u.draw()
+		}
+	}
+}
+
+// promptCount asks how many machines to scale to, returning args completed
+// with the count, or nil when the user gave none.
+func (u *ui) promptCount(args []string) []string {
+	screen.Clear()
+	screen.MoveTopLeft()
+	fmt.Fprintf(u.streams.Out, "Scale %s to how many machines? ", u.model.current().selected().Group)
+
+	line, _ := bufio.NewReader(u.streams.In).ReadString('\n')
+	n, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil || n < 0 {
+		u.model.message = "Scaling cancelled"
+		return nil
+	}
+	return append([]string{args[0], args[1], strconv.Itoa(n)}, args[2:]...)
+}
+
+// runCommand runs flyctl with args, attached to the terminal. It runs on the
+// main screen, so its output stays in the terminal's scrollback.
+func (u *ui) runCommand(ctx context.Context, args []string) {
+	fmt.Fprint(u.streams.Out, exitAltScreen)
+	defer fmt.Fprint(u.streams.Out, enterAltScreen)
+	fmt.Fprintf(u.streams.Out, "$ fly %s\n", strings.Join(args, " "))
+
+	exe, err := os.Executable()
+	if err != nil {
+		u.model.message = fmt.Sprintf("failed to find flyctl: %v", err)
+		return
+	}
+
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		u.model.message = fmt.Sprintf("fly %s: %v", strings.Join(args, " "), err)
+		fmt.Fprint(u.streams.Out, "\nPress enter to go back")
+		bufio.NewReader(u.streams.In).ReadString('\n')
+	}
+}
+
+// load fetches the items of the current level.
+func (u *ui) load(ctx context.Context) {
+	m := &u.model
+	var err error
+	switch m.level {
+	case levelOrgs:
+		err = u.loadOrgs(ctx)
+	case levelApps:
+		err = u.loadApps(ctx)
+	default:
+		err = u.loadTab(ctx)
+	}
+	if err != nil {
+		m.message = err.Error()
+	}
+}
+
+func (u *ui) loadOrgs(ctx context.Context) error {
+	orgs, err := u.client.GetOrganizations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list organizations: %w", err)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Slug < orgs[j].Slug })
+
+	items := make([]item, 0, len(orgs))
+	for _, org := range orgs {
+		items = append(items, item{ID: org.Slug, Columns: []string{org.Slug, org.Name, org.Type}})
+	}
+	u.model.orgs.set([]string{"Slug", "Name", "Type"}, items)
+	return nil
+}
+
+func (u *ui) loadApps(ctx context.Context) error {
+	org, err := u.client.GetOrganizationBySlug(ctx, u.model.org)
+	if err != nil {
+		return fmt.Errorf("failed to get organization %s: %w", u.model.org, err)
+	}
+	apps, err := u.client.GetAppsForOrganization(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+
+	items := make([]item, 0, len(apps))
+	for _, app := range apps {
+		deployed := ""
+		if app.Deployed && app.CurrentRelease != nil {
+			deployed = humanize.Time(app.CurrentRelease.CreatedAt)
+		}
+		items = append(items, item{ID: app.Name, Columns: []string{app.Name, app.Status, deployed}})
+	}
+	u.model.apps.set([]string{"Name", "Status", "Last Deploy"}, items)
+	return nil
+}
+
+func (u *ui) loadTab(ctx context.Context) error {
+	m := &u.model
+
+	if m.tab == tabReleases {
+		releases, err := u.client.GetAppReleasesMachines(ctx, m.app, "", 25)
+		if err != nil {
+			return fmt.Errorf("failed to list releases: %w", err)
+		}
+		sort.Slice(releases, func(i, j int) bool { return releases[i].Version > releases[j].Version })
+
+		items := make([]item, 0, len(releases))
+		for _, r := range releases {
+			items = append(items, item{
+				ID:      strconv.Itoa(r.Version),
+				Columns: []string{fmt.Sprintf("v%d", r.Version), r.Status, r.Description, r.User.Email, humanize.Time(r.CreatedAt)},
+			})
+		}
+		m.tabs[tabReleases].set([]string{"Version", "Status", "Description", "User", "Date"}, items)
+		return nil
+	}
+
+	if u.flapsClient == nil || u.flapsApp != m.app {
+		client, err := flapsutil.NewClientWithOptions(ctx, flaps.NewClientOpts{AppName: m.app})
+		if err != nil {
+			return fmt.Errorf("could not create flaps client: %w", err)
+		}
+		u.flapsApp, u.flapsClient = m.app, client
+	}
+
+	if m.tab == tabVolumes {
+		volumes, err := u.flapsClient.GetVolumes(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list volumes: %w", err)
+		}
+
+		items := make([]item, 0, len(volumes))
+		for _, v := range volumes {
+			attached := ""
+			if v.AttachedMachine != nil {
+				attached = *v.AttachedMachine
+			}
+			items = append(items, item{
+				ID:      v.ID,
+				Columns: []string{v.ID, v.Name, v.State, fmt.Sprintf("%dGB", v.SizeGb), v.Region, attached},
+			})
+		}
+		m.tabs[tabVolumes].set([]string{"ID", "Name", "State", "Size", "Region", "Attached To"}, items)
+		return nil
+	}
+
+	machines, err := u.flapsClient.List(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list machines: %w", err)
+	}
+	m.tabs[tabMachines].set(machineItems(machines))
+	return nil
+}
+
+func machineItems(machines []*fly.Machine) ([]string, []item) {
+	sort.Slice(machines, func(i, j int) bool {
+		if a, b := machines[i].ProcessGroup(), machines[j].ProcessGroup(); a != b {
+			return a < b
+		}
+		return machines[i].ID < machines[j].ID
+	})
+
+	items := make([]item, 0, len(machines))
+	for _, machine := range machines {
+		group := machine.ProcessGroup()
+		items = append(items, item{
+			ID:    machine.ID,
+			Group: group,
+			Columns: []string{
+				machine.ID,
+				group,
+				machine.Name,
+				machine.State,
+				machine.Region,
+				render.MachineHealthChecksSummary(machine),
+				machine.UpdatedAt,
+			},
+		})
+	}
+	return []string{"ID", "Process", "Name", "State", "Region", "Checks", "Last Updated"}, items
+}
+
+// render writes the view of m to out.
+func (m *model) render(out io.Writer, colorize *iostreams.ColorScheme) error {
+	fmt.Fprintln(out, colorize.Bold(m.breadcrumb()))
+	fmt.Fprintln(out)
+
+	if m.level == levelApp {
+		var tabs []string
+		for t := tab(0); t < tabCount; t++ {
+			if t == m.tab {
+				tabs = append(tabs, colorize.Bold("["+t.String()+"]"))
+			} else {
+				tabs = append(tabs, " "+t.String()+" ")
+			}
+		}
+		fmt.Fprintln(out, strings.Join(tabs, " "))
+	}
+
+	l := m.current()
+	if len(l.items) == 0 {
+		fmt.Fprintln(out, "  nothing here")
+		fmt.Fprintln(out)
+	} else {
+		size := 0
+		if m.height > 0 {
+			size = max(1, m.height-chromeLines)
+		}
+		start, end := l.window(size)
+
+		rows := make([][]string, 0, end-start)
+		for i := start; i < end; i++ {
+			marker := " "
+			if i == l.cursor {
+				marker = ">"
+			}
+			rows = append(rows, append([]string{marker}, l.items[i].Columns...))
+		}
+		if err := render.Table(out, "", rows, append([]string{""}, l.headers...)...); err != nil {
+			return err
+		}
+		if end-start < len(l.items) {
+			fmt.Fprintln(out, colorize.Gray(fmt.Sprintf("  %d-%d of %d", start+1, end, len(l.items))))
+		}
+	}
+
+	fmt.Fprintln(out, colorize.Gray(m.help()))
+	switch {
+	case m.prompt != "":
+		fmt.Fprintln(out, colorize.Yellow(m.prompt))
+	case m.message != "":
+		fmt.Fprintln(out, colorize.Yellow(m.message))
+	}
+	return nil
+}
+
+func (u *ui) draw() {
+	if _, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+		u.model.height = height
+	}
+
+	var buf bytes.Buffer
+	if err := u.model.render(&buf, u.streams.ColorScheme()); err != nil {
+		fmt.Fprintln(&buf, err)
+	}
+	screen.Clear()
+	screen.MoveTopLeft()
+	// Raw mode disables the translation of newlines to carriage returns
+	u.streams.Out.Write(bytes.ReplaceAll(buf.Bytes(), []byte("\n"), []byte("\r\n")))
+}
@@ -0,0 +1,114 @@
+package version
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/cache"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/render"
+	"github.com/superfly/flyctl/internal/update"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newList() *cobra.Command {
+	const (
+		short = "Lists the latest releases of flyctl"
+
+		long = `Lists the latest releases of flyctl, with the size of the download for
+this platform. Prereleases are listed when following the pre or nightly
+channels. Use --notes to read what changed in each release.`
+	)
+
+	cmd := command.New("list", short, long, runList)
+
+	cmd.Args = cobra.NoArgs
+
+	flag.Add(cmd,
+		flag.JSONOutput(),
+		flag.Bool{
+			Name:        "notes",
+			Description: "Show the release notes of each release",
+		},
+		flag.Int{
+			Name:        "limit",
+			Description: "The number of releases to list, or 0 to list all of them",
+			Default:     10,
+		},
+	)
+
+	return cmd
+}
+
+func runList(ctx context.Context) error {
+	var (
+		io      = iostreams.FromContext(ctx)
+		cfg     = config.FromContext(ctx)
+		channel = cache.FromContext(ctx).Channel()
+		current = buildinfo.Version().String()
+	)
+
+	var prerelease bool
+	switch update.NormalizeChannel(channel) {
+	case "pre", "prerelease", "nightly":
+		prerelease = true
+	}
+
+	releases, err := update.ListReleases(ctx, prerelease, flag.GetInt(ctx, "limit"))
+	if err != nil {
+		return fmt.Errorf("failed listing releases: %w", err)
+	}
+
+	if !flag.GetBool(ctx, "notes") {
+		for i := range releases {
+			releases[i].Notes = ""
+		}
+	}
+
+	if cfg.JSONOutput {
+		return render.JSON(io.Out, releases)
+	}
+
+	if flag.GetBool(ctx, "notes") {
+		for _, r := range releases {
+			fmt.Fprintf(io.Out, "%s\n\n", io.ColorScheme().Bold(releaseTitle(r, current)))
+			if r.Notes == "" {
+				r.Notes = "No release notes."
+			}
+			fmt.Fprintf(io.Out, "%s\n\n", r.Notes)
+		}
+		return nil
+	}
+
+	rows := make([][]string, 0, len(releases))
+	for _, r := range releases {
+		size := "-"
+		if asset := r.AssetFor(runtime.GOOS, runtime.GOARCH); asset != nil {
+			size = humanize.IBytes(uint64(asset.Size))
+		}
+
+		installed := ""
+		if r.Version == current {
+			installed = "*"
+		}
+
+		rows = append(rows, []string{r.Version, humanize.Time(r.PublishedAt), size, installed})
+	}
+
+	return render.Table(io.Out, "", rows, "Version", "Published", "Size", "Installed")
+}
+
+func releaseTitle(r update.ReleaseInfo, current string) string {
+	title := fmt.Sprintf("v%s (%s)", r.Version, r.PublishedAt.Format("2006-01-02"))
+	if r.Version == current {
+		title += " - installed"
+	}
+	return title
+}
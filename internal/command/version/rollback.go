@@ -0,0 +1,42 @@
+package version
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/update"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newRollback() *cobra.Command {
+	const (
+		short = "Switches back to the flyctl binary the last upgrade replaced"
+
+		long = `Switches back to the flyctl binary the last upgrade replaced, which is
+kept on disk. The current binary is kept in turn, so rolling back twice
+undoes the rollback. Automatic updates can be disabled meanwhile with
+'fly settings autoupdate disable', or pinned with 'fly version use'.`
+	)
+
+	cmd := command.New("rollback", short, long, runRollback)
+
+	cmd.Args = cobra.NoArgs
+
+	return cmd
+}
+
+func runRollback(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+
+	previous, err := update.Rollback()
+	if err != nil {
+		return fmt.Errorf("failed rolling back: %w", err)
+	}
+
+	fmt.Fprintf(io.Out, "Rolled back flyctl v%s -> v%s\n", buildinfo.Version(), previous)
+	return nil
+}
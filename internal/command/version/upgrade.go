@@ -17,6 +17,8 @@ import (
 	"github.com/superfly/flyctl/internal/buildinfo"
 	"github.com/superfly/flyctl/internal/cache"
 	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/update"
 	"github.com/superfly/flyctl/internal/version"
 	"github.com/superfly/flyctl/iostreams"
@@ -27,18 +29,60 @@ func newUpgrade() *cobra.Command {
 		short = "Checks for available updates and automatically upgrades"
 
 		long = `Checks for an update and if one is available, runs the appropriate
-command to upgrade the application.`
+command to upgrade the application.
+
+Updates come from the stable channel unless another is chosen with --channel,
+which is remembered for later updates. When flyctl is pinned with
+'fly version use', only versions matching the pin are installed.`
 	)
 
 	cmd := command.New("upgrade", short, long, runUpgrade)
 
 	cmd.Aliases = []string{"update"}
 
+	flag.Add(cmd,
+		flag.String{
+			Name:        "channel",
+			Description: "Switch to a release channel: " + strings.Join(update.Channels, ", "),
+		},
+	)
+
 	return cmd
 }
 
 func runUpgrade(ctx context.Context) error {
-	release, err := update.LatestRelease(ctx, cache.FromContext(ctx).Channel())
+	var (
+		io      = iostreams.FromContext(ctx)
+		cache   = cache.FromContext(ctx)
+		pin     = config.FromContext(ctx).UpdatePin
+		channel = flag.GetString(ctx, "channel")
+	)
+
+	if channel != "" {
+		if err := update.ValidateChannel(channel); err != nil {
+			return err
+		}
+		cache.SetChannel(channel)
+		fmt.Fprintf(io.ErrOut, "Switched to the %s release channel\n", channel)
+	}
+
+	var (
+		release *update.Release
+		err     error
+	)
+	if pin != "" {
+		var p update.Pin
+		if p, err = update.ParsePin(pin); err != nil {
+			return fmt.Errorf("invalid version pin in your config file: %w", err)
+		}
+		if channel != "" {
+			fmt.Fprintf(io.ErrOut, "flyctl is pinned to %s, run 'fly version use latest' to follow the channel\n", pin)
+		}
+		release, err = update.LatestReleaseMatching(ctx, p)
+	} else {
+		release, err = update.LatestRelease(ctx, cache.Channel())
+	}
+
 	switch {
 	case err != nil:
 		return fmt.Errorf("failed determining latest release: %w", err)
@@ -59,8 +103,6 @@ func runUpgrade(ctx context.Context) error {
 		return fmt.Errorf("error parsing version: %q, %w", release.Version, err)
 	}
 
-	io := iostreams.FromContext(ctx)
-
 	if !latest.Newer(buildinfo.Version()) {
 		fmt.Fprintf(io.Out, "Already running latest flyctl v%s\n", buildinfo.Version().String())
 		return nil
@@ -72,7 +114,13 @@ func runUpgrade(ctx context.Context) error {
 
 	homebrew := update.IsUnderHomebrew()
 
-	if err = update.UpgradeInPlace(ctx, io, release.Prerelease, false); err != nil {
+	// pinned and nightly releases aren't what the installers default to
+	if pin != "" || cache.Channel() == "nightly" {
+		err = update.InstallVersion(ctx, io, release.Version, false)
+	} else {
+		err = update.UpgradeInPlace(ctx, io, release.Prerelease, false)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,87 @@
+package version
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/command"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/internal/update"
+	"github.com/superfly/flyctl/internal/version"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+func newUse() *cobra.Command {
+	const (
+		short = "Pins flyctl to a version and installs it"
+
+		long = `Installs the newest release of flyctl matching the given version, such as
+0.2.x or 0.2.25, and pins updates to it, including automatic ones. Use
+'latest' to remove the pin.`
+	)
+
+	cmd := command.New("use <version>", short, long, runUse)
+
+	cmd.Args = cobra.ExactArgs(1)
+
+	return cmd
+}
+
+func runUse(ctx context.Context) error {
+	var (
+		io   = iostreams.FromContext(ctx)
+		arg  = flag.FirstArg(ctx)
+		path = state.ConfigFile(ctx)
+	)
+
+	if arg == "latest" || arg == "stable" {
+		if err := config.SetUpdatePin(path, ""); err != nil {
+			return fmt.Errorf("failed persisting %s in %s: %w", config.UpdatePinFileKey, path, err)
+		}
+		fmt.Fprintln(io.Out, "flyctl is no longer pinned, run 'fly version upgrade' to update to the latest release")
+		return nil
+	}
+
+	pin, err := update.ParsePin(arg)
+	if err != nil {
+		return err
+	}
+
+	if !update.CanUpdateThisInstallation() || update.IsUnderHomebrew() {
+		return errors.New("cannot install specific versions of this installation.\nthe environment variable FLYCTL_INSTALL must be set to the installation directory")
+	}
+
+	release, err := update.LatestReleaseMatching(ctx, pin)
+	if err != nil {
+		return fmt.Errorf("failed determining the release to install: %w", err)
+	}
+
+	target, err := version.Parse(release.Version)
+	if err != nil {
+		return fmt.Errorf("error parsing version: %q, %w", release.Version, err)
+	}
+
+	if err := config.SetUpdatePin(path, pin.String()); err != nil {
+		return fmt.Errorf("failed persisting %s in %s: %w", config.UpdatePinFileKey, path, err)
+	}
+
+	current := buildinfo.Version()
+	if target.Equal(current) {
+		fmt.Fprintf(io.Out, "Pinned flyctl to %s, already running v%s\n", pin, current)
+		return nil
+	}
+
+	if err := update.InstallVersion(ctx, io, target.String(), false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Pinned flyctl to %s, switched v%s -> v%s\n", pin, current, target)
+	fmt.Fprintln(io.Out, "Run 'fly version rollback' to switch back")
+	return nil
+}
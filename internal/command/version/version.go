@@ -40,6 +40,9 @@ number and build date.`
 	version.AddCommand(
 		newSaveInstall(),
 		newUpgrade(),
+		newUse(),
+		newRollback(),
+		newList(),
 	)
 
 	flag.Add(version, flag.JSONOutput())
@@ -13,6 +13,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
@@ -33,6 +34,7 @@ func newExtend() *cobra.Command {
 	)
 
 	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteVolumes))
 
 	flag.Add(cmd,
 		flag.App(),
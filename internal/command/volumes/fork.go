@@ -11,6 +11,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
@@ -31,6 +32,7 @@ func newFork() *cobra.Command {
 	)
 
 	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteVolumes))
 
 	flag.Add(cmd,
 		flag.App(),
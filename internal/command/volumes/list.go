@@ -12,6 +12,7 @@ import (
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
 	"github.com/superfly/flyctl/internal/flapsutil"
+	"github.com/superfly/flyctl/internal/readcache"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -49,7 +50,9 @@ func runList(ctx context.Context) error {
 
 	appName := appconfig.NameFromContext(ctx)
 
-	app, err := apiClient.GetAppBasic(ctx, appName)
+	app, err := readcache.Get(ctx, "app:"+appName, func(ctx context.Context) (*fly.AppBasic, error) {
+		return apiClient.GetAppBasic(ctx, appName)
+	})
 	if err != nil {
 		return err
 	}
@@ -61,12 +64,13 @@ func runList(ctx context.Context) error {
 		return err
 	}
 
-	var volumes []fly.Volume
-	if flag.GetBool(ctx, "all") {
-		volumes, err = flapsClient.GetAllVolumes(ctx)
-	} else {
-		volumes, err = flapsClient.GetVolumes(ctx)
-	}
+	all := flag.GetBool(ctx, "all")
+	volumes, err := readcache.Get(ctx, fmt.Sprintf("volumes list:%s:%t", appName, all), func(ctx context.Context) ([]fly.Volume, error) {
+		if all {
+			return flapsClient.GetAllVolumes(ctx)
+		}
+		return flapsClient.GetVolumes(ctx)
+	})
 	if err != nil {
 		return fmt.Errorf("failed retrieving volumes: %w", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
@@ -28,6 +29,7 @@ func newShow() (cmd *cobra.Command) {
 		command.LoadAppNameIfPresent,
 	)
 	cmd.Args = cobra.MaximumNArgs(1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteVolumes))
 
 	flag.Add(cmd,
 		flag.JSONOutput(),
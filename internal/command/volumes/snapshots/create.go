@@ -10,6 +10,7 @@ import (
 	"github.com/superfly/flyctl/internal/appconfig"
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 )
 
@@ -22,6 +23,7 @@ func newCreate() *cobra.Command {
 
 	cmd := command.New(usage, short, long, create, command.RequireSession)
 	cmd.Args = cobra.ExactArgs(1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteVolumes))
 
 	flag.Add(cmd, flag.JSONOutput())
 	return cmd
@@ -15,6 +15,7 @@ import (
 	"github.com/superfly/flyctl/internal/command"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/flag/completion"
 	"github.com/superfly/flyctl/internal/flapsutil"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/iostreams"
@@ -35,6 +36,7 @@ func newList() *cobra.Command {
 	cmd.Aliases = []string{"ls"}
 
 	cmd.Args = cobra.ExactArgs(1)
+	cmd.ValidArgsFunction = completion.Adapt(completion.FirstArg(completion.CompleteVolumes))
 
 	flag.Add(cmd, flag.JSONOutput())
 	return cmd
@@ -18,6 +18,8 @@ func New() *cobra.Command {
 		newWireguardList(),
 		newWireguardCreate(),
 		newWireguardRemove(),
+		newWireguardRotate(),
+		newWireguardPrune(),
 		newWireguardReset(),
 		newWireguardWebsockets(),
 		newWireguardToken(),
@@ -48,6 +50,12 @@ func newWireguardCreate() *cobra.Command {
 	cmd := command.New("create [org] [region] [name] [file]", short, long, runWireguardCreate,
 		command.RequireSession,
 	)
+	flag.Add(cmd,
+		flag.Duration{
+			Name:        "ttl",
+			Description: "Record that the peer expires once this much time has passed, such as 168h, for fly wireguard prune. Only this machine knows about the expiry",
+		},
+	)
 	cmd.Args = cobra.MaximumNArgs(4)
 	return cmd
 }
@@ -64,6 +72,43 @@ func newWireguardRemove() *cobra.Command {
 	return cmd
 }
 
+func newWireguardRotate() *cobra.Command {
+	const (
+		short = "Rotate the keys of a WireGuard peer connection"
+		long  = `Replace a WireGuard peer connection of an organization with a new one
+with fresh keys in the same region, and write its configuration. The new peer
+is named after the previous one, which is removed once the new one is created.`
+	)
+	cmd := command.New("rotate [org] [name] [file]", short, long, runWireguardRotate,
+		command.RequireSession,
+	)
+	flag.Add(cmd,
+		flag.Duration{
+			Name:        "ttl",
+			Description: "Record that the new peer expires once this much time has passed, such as 168h. Keeps the expiry of the peer by default",
+		},
+	)
+	cmd.Args = cobra.MaximumNArgs(3)
+	return cmd
+}
+
+func newWireguardPrune() *cobra.Command {
+	const (
+		short = "Remove expired WireGuard peer connections"
+		long  = `Remove the WireGuard peer connections of an organization created from this
+machine with a TTL which has passed. Expiries are recorded in the flyctl config
+of the machine which created the peers, the platform doesn't know about them.`
+	)
+	cmd := command.New("prune [org]", short, long, runWireguardPrune,
+		command.RequireSession,
+	)
+	flag.Add(cmd,
+		flag.Yes(),
+	)
+	cmd.Args = cobra.MaximumNArgs(1)
+	return cmd
+}
+
 func newWireguardReset() *cobra.Command {
 	const (
 		short = "Reset WireGuard peer connection for an organization"
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
@@ -13,6 +15,8 @@ import (
 	"github.com/superfly/flyctl/flyctl"
 	"github.com/superfly/flyctl/internal/config"
 	"github.com/superfly/flyctl/internal/flag"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/prompt"
 	"github.com/superfly/flyctl/internal/render"
 	"github.com/superfly/flyctl/internal/state"
 	"github.com/superfly/flyctl/internal/wireguard"
@@ -34,6 +38,11 @@ func runWireguardList(ctx context.Context) error {
 		return err
 	}
 
+	expiries, err := wireguard.PeerExpiries(ctx, org)
+	if err != nil {
+		return err
+	}
+
 	if config.FromContext(ctx).JSONOutput {
 		render.JSON(io.Out, peers)
 		return nil
@@ -45,14 +54,32 @@ func runWireguardList(ctx context.Context) error {
 		"Name",
 		"Region",
 		"Peer IP",
+		"Expires",
 	})
 
+	now := time.Now()
+	var expired int
 	for _, peer := range peers {
-		table.Append([]string{peer.Name, peer.Region, peer.Peerip})
+		expires := ""
+		if expiry, ok := expiries[peer.Name]; ok {
+			expires = format.RelativeTime(expiry)
+			if !now.Before(expiry) {
+				expires = "expired " + expires
+				expired++
+			}
+		}
+		table.Append([]string{peer.Name, peer.Region, peer.Peerip, expires})
 	}
 
 	table.Render()
 
+	if len(expiries) > 0 {
+		fmt.Fprintln(io.Out, "Expiries are the TTLs of peers created from this machine, recorded in its flyctl config only.")
+	}
+	if expired > 0 {
+		fmt.Fprintf(io.Out, "Run fly wireguard prune %s to remove the %d expired peer(s).\n", org.Slug, expired)
+	}
+
 	return nil
 }
 
@@ -151,6 +178,13 @@ func runWireguardCreate(ctx context.Context) error {
 
 	data := &state.Peer
 
+	if ttl := flag.GetDuration(ctx, "ttl"); ttl > 0 {
+		if err := wireguard.SetPeerExpiry(ctx, org, state.Name, ttl); err != nil {
+			return err
+		}
+		fmt.Fprintf(io.Out, "Recorded in this machine's flyctl config that WireGuard peer %s expires in %s; run fly wireguard prune from this machine after that to remove it\n", state.Name, ttl)
+	}
+
 	fmt.Fprintf(io.Out, `
 !!!! WARNING: Output includes private key. Private keys cannot be recovered !!!!
 !!!! after creating the peer; if you lose the key, you'll need to remove    !!!!
@@ -206,3 +240,168 @@ func runWireguardRemove(ctx context.Context) error {
 
 	return wireguard.PruneInvalidPeers(ctx, apiClient)
 }
+
+func runWireguardRotate(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	apiClient := fly.ClientFromContext(ctx)
+
+	org, err := orgByArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := flag.Args(ctx)
+	var name string
+	if len(args) >= 2 {
+		name = args[1]
+	} else {
+		name, err = selectWireGuardPeer(ctx, apiClient, org.Slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	peers, err := apiClient.GetWireGuardPeers(ctx, org.Slug)
+	if err != nil {
+		return err
+	}
+	var peer *fly.WireGuardPeer
+	for _, p := range peers {
+		if p.Name == name {
+			peer = p
+			break
+		}
+	}
+	if peer == nil {
+		return fmt.Errorf("organization %s has no WireGuard peer %s", org.Slug, name)
+	}
+
+	expiries, err := wireguard.PeerExpiries(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, "Rotating the keys of WireGuard peer \"%s\" for organization %s\n", name, org.Slug)
+
+	// Peers can't be rekeyed in place and their names are unique, so the
+	// replacement gets a new name. The peer is only removed once its
+	// replacement exists and its configuration is written, so that a failure
+	// doesn't leave the user without a working peer.
+	state, err := wireguard.Create(apiClient, org, peer.Region, rotatedPeerName(name, time.Now()), "")
+	if err != nil {
+		return fmt.Errorf("failed creating the replacement of peer %s, which is left as it is: %w", name, err)
+	}
+
+	switch ttl := flag.GetDuration(ctx, "ttl"); {
+	case ttl > 0:
+		err = wireguard.SetPeerExpiry(ctx, org, state.Name, ttl)
+	default:
+		err = wireguard.SetPeerExpiryAt(ctx, org, state.Name, expiries[name])
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(io.Out, `
+!!!! WARNING: Output includes private key. Private keys cannot be recovered !!!!
+!!!! after creating the peer; if you lose the key, you'll need to rotate    !!!!
+!!!! the peer again.                                                        !!!!
+`)
+
+	w, shouldClose, err := resolveOutputWriter(ctx, 2, "Filename to store the new WireGuard configuration in, or 'stdout': ")
+	if err != nil {
+		return fmt.Errorf("created peer %s but kept peer %s: %w", state.Name, name, err)
+	}
+	if shouldClose {
+		defer w.Close() // skipcq: GO-S2307
+	}
+
+	generateWgConf(&state.Peer, state.LocalPrivate, w)
+
+	if shouldClose {
+		filename := w.(*os.File).Name()
+		fmt.Fprintf(io.Out, "Wrote WireGuard configuration to %s; replace the previous one in your WireGuard client\n", filename)
+	}
+
+	fmt.Fprintf(io.Out, "Removing WireGuard peer \"%s\"\n", name)
+	if err := apiClient.RemoveWireGuardPeer(ctx, org, name); err != nil {
+		return fmt.Errorf("created peer %s but failed removing peer %s, remove it with fly wireguard remove %s %s: %w", state.Name, name, org.Slug, name, err)
+	}
+	if err := wireguard.ForgetPeerExpiries(ctx, org, []string{name}); err != nil {
+		return err
+	}
+
+	return wireguard.PruneInvalidPeers(ctx, apiClient)
+}
+
+// rotatedPeerSuffix matches the suffix rotatedPeerName gives peers.
+var rotatedPeerSuffix = regexp.MustCompile(`-rotated-\d{14}$`)
+
+// rotatedPeerName returns the name of the replacement of the peer name
+// rotated at now.
+func rotatedPeerName(name string, now time.Time) string {
+	return rotatedPeerSuffix.ReplaceAllString(name, "") + "-rotated-" + now.UTC().Format("20060102150405")
+}
+
+func runWireguardPrune(ctx context.Context) error {
+	io := iostreams.FromContext(ctx)
+	apiClient := fly.ClientFromContext(ctx)
+
+	org, err := orgByArg(ctx)
+	if err != nil {
+		return err
+	}
+
+	expiries, err := wireguard.PeerExpiries(ctx, org)
+	if err != nil {
+		return err
+	}
+	if len(expiries) == 0 {
+		fmt.Fprintf(io.Out, "No WireGuard peer of %s was created with a TTL from this machine.\n", org.Slug)
+		return nil
+	}
+
+	peers, err := apiClient.GetWireGuardPeers(ctx, org.Slug)
+	if err != nil {
+		return err
+	}
+
+	expired, gone := wireguard.ExpiredPeers(expiries, peers, time.Now())
+	if err := wireguard.ForgetPeerExpiries(ctx, org, gone); err != nil {
+		return err
+	}
+	if len(expired) == 0 {
+		fmt.Fprintf(io.Out, "No WireGuard peer of %s has expired.\n", org.Slug)
+		return nil
+	}
+
+	fmt.Fprintf(io.Out, "Expired WireGuard peers of %s:\n", org.Slug)
+	for _, name := range expired {
+		fmt.Fprintf(io.Out, "  %s (expired %s)\n", name, format.RelativeTime(expiries[name]))
+	}
+
+	if !flag.GetYes(ctx) {
+		switch confirmed, err := prompt.Confirmf(ctx, "Remove %d WireGuard peer(s)?", len(expired)); {
+		case err == nil:
+			if !confirmed {
+				return nil
+			}
+		case prompt.IsNonInteractive(err):
+			return prompt.NonInteractiveError("yes flag must be specified when not running interactively")
+		default:
+			return err
+		}
+	}
+
+	for _, name := range expired {
+		if err := apiClient.RemoveWireGuardPeer(ctx, org, name); err != nil {
+			return fmt.Errorf("failed removing WireGuard peer %s: %w", name, err)
+		}
+		if err := wireguard.ForgetPeerExpiries(ctx, org, []string{name}); err != nil {
+			return err
+		}
+		fmt.Fprintf(io.Out, "Removed WireGuard peer %s\n", name)
+	}
+
+	return wireguard.PruneInvalidPeers(ctx, apiClient)
+}
@@ -0,0 +1,17 @@
+package wireguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatedPeerName(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 45, 0, time.FixedZone("CEST", 2*60*60))
+
+	assert.Equal(t, "laptop-rotated-20240501103045", rotatedPeerName("laptop", now))
+	// Rotating a rotated peer replaces the suffix instead of stacking them.
+	assert.Equal(t, "laptop-rotated-20240501103045", rotatedPeerName("laptop-rotated-20240101000000", now))
+	assert.Equal(t, "laptop-rotated-2024-rotated-20240501103045", rotatedPeerName("laptop-rotated-2024", now))
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"io/fs"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/spf13/pflag"
 
@@ -28,8 +30,17 @@ const (
 	SendMetricsEnvKey          = "FLY_SEND_METRICS"
 	SendMetricsFileKey         = "send_metrics"
 	AutoUpdateFileKey          = "auto_update"
+	UpdatePinFileKey           = "update_pin"
 	WireGuardStateFileKey      = "wire_guard_state"
 	WireGuardWebsocketsFileKey = "wire_guard_websockets"
+	WireGuardExpiriesFileKey   = "wire_guard_expiries"
+	AliasesFileKey             = "aliases"
+	AppDefaultsFileKey         = "app_defaults"
+	RemoteBuildersFileKey      = "remote_builders"
+	DefaultOrgFileKey          = "default_org"
+	DefaultRegionFileKey       = "default_region"
+	HintsFileKey               = "hints"
+	TelemetryOptOutsFileKey    = "telemetry_opt_outs"
 	APITokenEnvKey             = "FLY_API_TOKEN"
 	orgEnvKey                  = "FLY_ORG"
 	registryHostEnvKey         = "FLY_REGISTRY_HOST"
@@ -39,11 +50,21 @@ const (
 	jsonOutputEnvKey           = "FLY_JSON"
 	logGQLEnvKey               = "FLY_LOG_GQL_ERRORS"
 	localOnlyEnvKey            = "FLY_LOCAL_ONLY"
+	offlineEnvKey              = "FLY_OFFLINE"
+	noReadCacheEnvKey          = "FLY_NO_READ_CACHE"
+	cacheTTLEnvKey             = "FLY_CACHE_TTL"
+	maxRetriesEnvKey           = "FLY_MAX_RETRIES"
+	requestTimeoutEnvKey       = "FLY_REQUEST_TIMEOUT"
+	quietEnvKey                = "FLY_QUIET"
+	noColorEnvKey              = "NO_COLOR"
+	progressEnvKey             = "FLY_PROGRESS"
+	noHintsEnvKey              = "FLY_NO_HINTS"
 
 	defaultAPIBaseURL     = "https://api.fly.io"
 	defaultFlapsBaseURL   = "https://api.machines.dev"
 	defaultRegistryHost   = "registry.fly.io"
 	defaultMetricsBaseURL = "https://flyctl-metrics.fly.dev"
+	defaultMaxRetries     = 3
 )
 
 // Config wraps the functionality of the configuration file.
@@ -79,6 +100,10 @@ type Config struct {
 	// AutoUpdate denotes whether the user wants to automatically update flyctl.
 	AutoUpdate bool
 
+	// UpdatePin denotes the versions flyctl may update to, such as 0.2.x.
+	// Empty when not pinned.
+	UpdatePin string
+
 	// Organization denotes the organizational slug the user has selected.
 	Organization string
 
@@ -88,6 +113,45 @@ type Config struct {
 	// LocalOnly denotes whether the user wants only local operations.
 	LocalOnly bool
 
+	// Offline denotes whether the user wants read commands to show the last
+	// state they fetched instead of querying the API.
+	Offline bool
+
+	// NoReadCache denotes whether the user wants read commands to skip cached
+	// results, even when they're fresher than CacheTTL.
+	NoReadCache bool
+
+	// CacheTTL denotes for how long read commands may show cached results
+	// instead of querying the API. Zero disables the cache.
+	CacheTTL time.Duration
+
+	// MaxRetries denotes how many times API requests which were rate limited
+	// or failed with a server error are retried.
+	MaxRetries int
+
+	// RequestTimeout denotes how long a single API request may take. Zero
+	// means no limit.
+	RequestTimeout time.Duration
+
+	// Quiet denotes whether the user wants informational output and progress
+	// suppressed.
+	Quiet bool
+
+	// NoColor denotes whether the user wants output without ANSI colors.
+	NoColor bool
+
+	// Progress denotes how the user wants progress reported: auto, plain,
+	// json or quiet.
+	Progress string
+
+	// Hints denotes whether the user wants to be shown hints about next steps
+	// after commands succeed.
+	Hints bool
+
+	// TelemetryOptOuts denotes the metrics categories the user doesn't want
+	// sent, even when SendMetrics is set.
+	TelemetryOptOuts []string
+
 	// Tokens is the user's authentication token(s). They are used differently
 	// depending on where they need to be sent.
 	Tokens *tokens.Tokens
@@ -102,6 +166,8 @@ func Load(ctx context.Context, path string) (*Config, error) {
 		FlapsBaseURL:   defaultFlapsBaseURL,
 		RegistryHost:   defaultRegistryHost,
 		MetricsBaseURL: defaultMetricsBaseURL,
+		MaxRetries:     defaultMaxRetries,
+		Hints:          true,
 		Tokens:         new(tokens.Tokens),
 	}
 
@@ -114,7 +180,7 @@ func Load(ctx context.Context, path string) (*Config, error) {
 	cfg.applyEnv()
 
 	// Finally, apply command line options, overriding any previous setting
-	cfg.applyFlags(flagctx.FromContext(ctx))
+	cfg.ApplyFlags(flagctx.FromContext(ctx))
 
 	return cfg, nil
 }
@@ -135,6 +201,21 @@ func (cfg *Config) applyEnv() {
 	cfg.JSONOutput = env.IsTruthy(jsonOutputEnvKey) || cfg.JSONOutput
 	cfg.LogGQLErrors = env.IsTruthy(logGQLEnvKey) || cfg.LogGQLErrors
 	cfg.LocalOnly = env.IsTruthy(localOnlyEnvKey) || cfg.LocalOnly
+	cfg.Offline = env.IsTruthy(offlineEnvKey) || cfg.Offline
+	cfg.NoReadCache = env.IsTruthy(noReadCacheEnvKey) || cfg.NoReadCache
+	if ttl, err := time.ParseDuration(env.First(cacheTTLEnvKey)); err == nil {
+		cfg.CacheTTL = ttl
+	}
+	if n, err := strconv.Atoi(env.First(maxRetriesEnvKey)); err == nil && n >= 0 {
+		cfg.MaxRetries = n
+	}
+	if timeout, err := time.ParseDuration(env.First(requestTimeoutEnvKey)); err == nil {
+		cfg.RequestTimeout = timeout
+	}
+	cfg.Quiet = env.IsTruthy(quietEnvKey) || cfg.Quiet
+	cfg.NoColor = env.First(noColorEnvKey) != "" || cfg.NoColor
+	cfg.Progress = env.FirstOrDefault(cfg.Progress, progressEnvKey)
+	cfg.Hints = cfg.Hints && !env.IsTruthy(noHintsEnvKey)
 
 	cfg.Organization = env.FirstOrDefault(cfg.Organization,
 		orgEnvKey, organizationEnvKey)
@@ -153,41 +234,74 @@ func (cfg *Config) applyFile(path string) (err error) {
 	defer cfg.mu.Unlock()
 
 	var w struct {
-		AccessToken  string `yaml:"access_token"`
-		MetricsToken string `yaml:"metrics_token"`
-		SendMetrics  bool   `yaml:"send_metrics"`
-		AutoUpdate   bool   `yaml:"auto_update"`
+		AccessToken   string `yaml:"access_token"`
+		MetricsToken  string `yaml:"metrics_token"`
+		SendMetrics   bool   `yaml:"send_metrics"`
+		AutoUpdate    bool   `yaml:"auto_update"`
+		UpdatePin     string `yaml:"update_pin"`
+		DefaultOrg    string `yaml:"default_org"`
+		DefaultRegion string `yaml:"default_region"`
+		Hints         bool   `yaml:"hints"`
+
+		TelemetryOptOuts []string `yaml:"telemetry_opt_outs"`
 	}
 	w.SendMetrics = true
 	w.AutoUpdate = true
+	w.Hints = true
 
 	if err = unmarshal(path, &w); err == nil {
 		cfg.Tokens = tokens.ParseFromFile(w.AccessToken, path)
 		cfg.MetricsToken = w.MetricsToken
 		cfg.SendMetrics = w.SendMetrics
 		cfg.AutoUpdate = w.AutoUpdate
+		cfg.UpdatePin = w.UpdatePin
+		cfg.Organization = w.DefaultOrg
+		cfg.Region = w.DefaultRegion
+		cfg.Hints = w.Hints
+		cfg.TelemetryOptOuts = w.TelemetryOptOuts
 	}
 
 	return
 }
 
-// applyFlags sets the properties of cfg which may be set via command line flags
+// ApplyFlags sets the properties of cfg which may be set via command line flags
 // to the values the flags of the given FlagSet may contain.
-func (cfg *Config) applyFlags(fs *pflag.FlagSet) {
+func (cfg *Config) ApplyFlags(fs *pflag.FlagSet) {
 	cfg.mu.Lock()
 	defer cfg.mu.Unlock()
 
 	applyStringFlags(fs, map[string]*string{
-		flagnames.Org:    &cfg.Organization,
-		flagnames.Region: &cfg.Region,
+		flagnames.Org:      &cfg.Organization,
+		flagnames.Region:   &cfg.Region,
+		flagnames.Progress: &cfg.Progress,
 	})
 
 	applyBoolFlags(fs, map[string]*bool{
-		flagnames.Verbose:    &cfg.VerboseOutput,
-		flagnames.JSONOutput: &cfg.JSONOutput,
-		flagnames.LocalOnly:  &cfg.LocalOnly,
+		flagnames.Verbose:     &cfg.VerboseOutput,
+		flagnames.JSONOutput:  &cfg.JSONOutput,
+		flagnames.LocalOnly:   &cfg.LocalOnly,
+		flagnames.Offline:     &cfg.Offline,
+		flagnames.NoReadCache: &cfg.NoReadCache,
+		flagnames.Quiet:       &cfg.Quiet,
+		flagnames.NoColor:     &cfg.NoColor,
 	})
 
+	if fs.Changed(flagnames.MaxRetries) {
+		if v, err := fs.GetInt(flagnames.MaxRetries); err != nil {
+			panic(err)
+		} else {
+			cfg.MaxRetries = max(v, 0)
+		}
+	}
+
+	if fs.Changed(flagnames.RequestTimeout) {
+		if v, err := fs.GetDuration(flagnames.RequestTimeout); err != nil {
+			panic(err)
+		} else {
+			cfg.RequestTimeout = v
+		}
+	}
+
 	if fs.Changed(flagnames.AccessToken) {
 		if v, err := fs.GetString(flagnames.AccessToken); err != nil {
 			panic(err)
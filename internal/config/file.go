@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/superfly/flyctl/wg"
 	"gopkg.in/yaml.v3"
@@ -57,6 +59,14 @@ func SetAutoUpdate(path string, autoUpdate bool) error {
 	})
 }
 
+// SetUpdatePin sets the versions flyctl may update to at the configuration
+// file found at path. An empty pin removes it.
+func SetUpdatePin(path, pin string) error {
+	return set(path, map[string]interface{}{
+		UpdatePinFileKey: pin,
+	})
+}
+
 func SetWireGuardState(path string, state wg.States) error {
 	return set(path, map[string]interface{}{
 		WireGuardStateFileKey: state,
@@ -69,6 +79,201 @@ func SetWireGuardWebsocketsEnabled(path string, enabled bool) error {
 	})
 }
 
+// ReadWireGuardExpiries returns when the WireGuard peers created with a TTL
+// expire, keyed by organization slug, then by peer name.
+func ReadWireGuardExpiries(path string) (map[string]map[string]time.Time, error) {
+	var s struct {
+		Expiries map[string]map[string]time.Time `yaml:"wire_guard_expiries"`
+	}
+	if err := unmarshal(path, &s); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return s.Expiries, nil
+}
+
+// SetWireGuardExpiry sets when the WireGuard peer name of org expires at the
+// configuration file found at path. A zero expiry removes it.
+func SetWireGuardExpiry(path, org, name string, expiry time.Time) error {
+	expiries, err := ReadWireGuardExpiries(path)
+	if err != nil {
+		return err
+	}
+	if expiries == nil {
+		expiries = map[string]map[string]time.Time{}
+	}
+
+	if expiry.IsZero() {
+		delete(expiries[org], name)
+		if len(expiries[org]) == 0 {
+			delete(expiries, org)
+		}
+	} else {
+		if expiries[org] == nil {
+			expiries[org] = map[string]time.Time{}
+		}
+		expiries[org][name] = expiry.UTC()
+	}
+
+	return set(path, map[string]interface{}{
+		WireGuardExpiriesFileKey: expiries,
+	})
+}
+
+// ReadAliases returns the command aliases of the configuration file found at
+// path, keyed by name.
+func ReadAliases(path string) (map[string]string, error) {
+	var s struct {
+		Aliases map[string]string `yaml:"aliases"`
+	}
+	if err := unmarshal(path, &s); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return s.Aliases, nil
+}
+
+// SetAlias sets the command alias name to expand to expansion at the
+// configuration file found at path. An empty expansion removes the alias.
+func SetAlias(path, name, expansion string) error {
+	aliases, err := ReadAliases(path)
+	if err != nil {
+		return err
+	}
+	if aliases == nil {
+		aliases = map[string]string{}
+	}
+
+	if expansion == "" {
+		delete(aliases, name)
+	} else {
+		aliases[name] = expansion
+	}
+
+	return set(path, map[string]interface{}{
+		AliasesFileKey: aliases,
+	})
+}
+
+// ReadAppDefaults returns the default flags of commands of the configuration
+// file found at path, keyed by app name, then by command path.
+func ReadAppDefaults(path string) (map[string]map[string]string, error) {
+	var s struct {
+		AppDefaults map[string]map[string]string `yaml:"app_defaults"`
+	}
+	if err := unmarshal(path, &s); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return s.AppDefaults, nil
+}
+
+// SetAppDefaults sets the default flags of command for app at the
+// configuration file found at path. Empty flags remove the defaults.
+func SetAppDefaults(path, app, command, flags string) error {
+	defaults, err := ReadAppDefaults(path)
+	if err != nil {
+		return err
+	}
+	if defaults == nil {
+		defaults = map[string]map[string]string{}
+	}
+
+	if flags == "" {
+		delete(defaults[app], command)
+		if len(defaults[app]) == 0 {
+			delete(defaults, app)
+		}
+	} else {
+		if defaults[app] == nil {
+			defaults[app] = map[string]string{}
+		}
+		defaults[app][command] = flags
+	}
+
+	return set(path, map[string]interface{}{
+		AppDefaultsFileKey: defaults,
+	})
+}
+
+// RemoteBuilder is the remote builder the builds of the apps of an
+// organization are pinned to.
+type RemoteBuilder struct {
+	// App is the builder app, empty for the one flyctl provisions.
+	App string `yaml:"app,omitempty"`
+	// Size is the machine size of the builder, such as performance-4x.
+	Size string `yaml:"size,omitempty"`
+}
+
+// ReadRemoteBuilders returns the remote builders of the configuration file
+// found at path, keyed by organization slug.
+func ReadRemoteBuilders(path string) (map[string]RemoteBuilder, error) {
+	var s struct {
+		RemoteBuilders map[string]RemoteBuilder `yaml:"remote_builders"`
+	}
+	if err := unmarshal(path, &s); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return s.RemoteBuilders, nil
+}
+
+// SetRemoteBuilder pins the builds of the apps of org to builder at the
+// configuration file found at path. An empty builder unpins them.
+func SetRemoteBuilder(path, org string, builder RemoteBuilder) error {
+	builders, err := ReadRemoteBuilders(path)
+	if err != nil {
+		return err
+	}
+	if builders == nil {
+		builders = map[string]RemoteBuilder{}
+	}
+
+	if builder == (RemoteBuilder{}) {
+		delete(builders, org)
+	} else {
+		builders[org] = builder
+	}
+
+	return set(path, map[string]interface{}{
+		RemoteBuildersFileKey: builders,
+	})
+}
+
+// SetDefaults sets the organization and region commands use when none is
+// given at the configuration file found at path. Empty values unset them.
+func SetDefaults(path, org, region string) error {
+	return set(path, map[string]interface{}{
+		DefaultOrgFileKey:    org,
+		DefaultRegionFileKey: region,
+	})
+}
+
+// SetHints sets whether hints about next steps are shown at the
+// configuration file found at path.
+func SetHints(path string, enabled bool) error {
+	return set(path, map[string]interface{}{
+		HintsFileKey: enabled,
+	})
+}
+
+// SetTelemetryOptOut sets whether the metrics category is opted out of at
+// the configuration file found at path.
+func SetTelemetryOptOut(path, category string, optOut bool) error {
+	var s struct {
+		TelemetryOptOuts []string `yaml:"telemetry_opt_outs"`
+	}
+	if err := unmarshal(path, &s); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	optOuts := slices.DeleteFunc(s.TelemetryOptOuts, func(c string) bool { return c == category })
+	if optOut {
+		optOuts = append(optOuts, category)
+	}
+	slices.Sort(optOuts)
+
+	return set(path, map[string]interface{}{
+		TelemetryOptOutsFileKey: optOuts,
+	})
+}
+
 // Clear clears the access token, metrics token, and wireguard-related keys of the configuration
 // file found at path.
 func Clear(path string) (err error) {
@@ -0,0 +1,101 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, spec := range []string{
+		"* * * * *",
+		"0 8 * * *",
+		"*/15 9-17 * * mon-fri",
+		"0 0 1,15 jan,jul *",
+		"5/10 * * * 7",
+		"@daily",
+	} {
+		_, err := Parse(spec)
+		assert.NoError(t, err, spec)
+	}
+
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * funday",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNextPrev(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+
+	testcases := []struct {
+		spec string
+		from time.Time
+		next time.Time
+		prev time.Time
+	}{
+		{
+			spec: "0 8 * * *",
+			from: at("2024-03-10T09:30:00Z"),
+			next: at("2024-03-11T08:00:00Z"),
+			prev: at("2024-03-10T08:00:00Z"),
+		},
+		{
+			spec: "0 20 * * mon-fri",
+			from: at("2024-03-09T12:00:00Z"), // Saturday
+			next: at("2024-03-11T20:00:00Z"),
+			prev: at("2024-03-08T20:00:00Z"),
+		},
+		{
+			spec: "*/15 * * * *",
+			from: at("2024-03-10T09:30:00Z"),
+			next: at("2024-03-10T09:45:00Z"),
+			prev: at("2024-03-10T09:30:00Z"),
+		},
+		{
+			// Both day fields restricted: either matches
+			spec: "0 0 1 * sun",
+			from: at("2024-03-02T12:00:00Z"), // Saturday
+			next: at("2024-03-03T00:00:00Z"),
+			prev: at("2024-03-01T00:00:00Z"),
+		},
+		{
+			spec: "0 0 29 feb *",
+			from: at("2024-03-01T00:00:00Z"),
+			next: at("2028-02-29T00:00:00Z"),
+			prev: at("2024-02-29T00:00:00Z"),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.next, s.Next(tc.from))
+			assert.Equal(t, tc.prev, s.Prev(tc.from))
+		})
+	}
+}
+
+func TestNever(t *testing.T) {
+	s, err := Parse("0 0 31 feb *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+	assert.True(t, s.Prev(time.Now()).IsZero())
+}
@@ -0,0 +1,18 @@
+package cron
+
+import fly "github.com/superfly/fly-go"
+
+const (
+	// MetadataKeyJob holds the name of the job a machine runs for 'fly cron'.
+	MetadataKeyJob = "fly_cron_job"
+	// MetadataKeySchedule holds the schedule of the job a machine runs.
+	MetadataKeySchedule = "fly_cron_schedule"
+)
+
+// JobName returns the name of the job m runs, if any.
+func JobName(m *fly.Machine) string {
+	if m.Config == nil {
+		return ""
+	}
+	return m.Config.Metadata[MetadataKeyJob]
+}
@@ -0,0 +1,89 @@
+package completion
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/env"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+// cacheTTL is how long completion candidates are reused for. It's short, so
+// that resources created or destroyed a moment ago show up soon, while
+// pressing tab repeatedly doesn't hit the API every time.
+const cacheTTL = 30 * time.Second
+
+// noCacheEnvKey disables the completion cache when set.
+const noCacheEnvKey = "FLY_NO_COMPLETION_CACHE"
+
+type cacheEntry struct {
+	Time       time.Time `json:"time"`
+	Candidates []string  `json:"candidates"`
+}
+
+// cached returns the candidates cached under key, calling fetch and caching
+// its result when there are none fresher than cacheTTL. Candidates are cached
+// per token, so switching users or tokens scoped to other organizations never
+// completes resources the current token can't see.
+func cached(ctx context.Context, key string, fetch func() ([]string, error)) ([]string, error) {
+	if env.IsTruthy(noCacheEnvKey) {
+		return fetch()
+	}
+
+	path := cachePath(state.ConfigDirectory(ctx), cacheScope(config.Tokens(ctx).GraphQL()), key)
+	if candidates, ok := readCache(path, time.Now()); ok {
+		return candidates, nil
+	}
+
+	candidates, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	// A failure to cache only makes the next completion slower.
+	_ = writeCache(path, cacheEntry{Time: time.Now(), Candidates: candidates})
+	return candidates, nil
+}
+
+// cacheScope identifies token without storing it.
+func cacheScope(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+func cachePath(configDir, scope, key string) string {
+	return filepath.Join(configDir, "completions", scope, url.PathEscape(key)+".json")
+}
+
+func readCache(path string, now time.Time) ([]string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if now.Sub(entry.Time) > cacheTTL {
+		return nil, false
+	}
+	return entry.Candidates, true
+}
+
+func writeCache(path string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
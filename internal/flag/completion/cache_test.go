@@ -0,0 +1,81 @@
+package completion
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/tokens"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func TestCached(t *testing.T) {
+	t.Setenv(noCacheEnvKey, "")
+
+	dir := t.TempDir()
+	withToken := func(token string) context.Context {
+		ctx := state.WithConfigDirectory(context.Background(), dir)
+		return config.NewContext(ctx, &config.Config{Tokens: tokens.Parse(token)})
+	}
+	ctx := withToken("FlyV1 fm2_first")
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"a\tfirst", "b\tsecond"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		candidates, err := cached(ctx, "machines:my-app", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a\tfirst", "b\tsecond"}, candidates)
+	}
+	assert.Equal(t, 1, calls)
+
+	// Another token doesn't see the candidates of the first one
+	_, err := cached(withToken("FlyV1 fm2_second"), "machines:my-app", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = cached(ctx, "machines:other-app", func() ([]string, error) {
+		return nil, errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+}
+
+func TestReadCacheExpires(t *testing.T) {
+	path := cachePath(t.TempDir(), cacheScope("token"), "apps:")
+	now := time.Now()
+	require.NoError(t, writeCache(path, cacheEntry{Time: now, Candidates: []string{"app"}}))
+
+	candidates, ok := readCache(path, now.Add(cacheTTL-time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"app"}, candidates)
+
+	_, ok = readCache(path, now.Add(cacheTTL+time.Second))
+	assert.False(t, ok)
+}
+
+func TestFilterPrefix(t *testing.T) {
+	candidates := []string{"148e\tweb, started", "3d8d\tworker, stopped", "14ab\tweb, started"}
+
+	assert.Equal(t, []string{"148e\tweb, started", "14ab\tweb, started"}, filterPrefix(candidates, "14", nil))
+	assert.Equal(t, []string{"14ab\tweb, started"}, filterPrefix(candidates, "14", []string{"148e"}))
+	// Descriptions are not matched.
+	assert.Empty(t, filterPrefix(candidates, "web", nil))
+}
+
+func TestAppNameFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fly.toml")
+	require.NoError(t, os.WriteFile(path, []byte("app = \"my-app\"\nprimary_region = \"ord\"\n"), 0o600))
+
+	assert.Equal(t, "my-app", appNameFromConfig(path))
+	assert.Empty(t, appNameFromConfig(filepath.Join(t.TempDir(), "missing.toml")))
+}
@@ -3,12 +3,19 @@ package completion
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
+	"github.com/pelletier/go-toml/v2"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/buildinfo"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/env"
 	"github.com/superfly/flyctl/internal/flag/flagnames"
 )
 
@@ -18,45 +25,48 @@ func CompleteApps(
 	args []string,
 	partial string,
 ) ([]string, error) {
-	var (
-		client = fly.ClientFromContext(ctx)
-
-		apps []fly.App
-		err  error
-	)
-
-	orgFiltered := false
+	client := fly.ClientFromContext(ctx)
 
 	// We can't use `flag.*` here because of import cycles. *sigh*
-	orgFlag := cmd.Flag(flagnames.Org)
-	if orgFlag != nil && orgFlag.Changed {
-		var org *fly.Organization
-		org, err = client.GetOrganizationBySlug(ctx, orgFlag.Value.String())
+	orgSlug := ""
+	if orgFlag := cmd.Flag(flagnames.Org); orgFlag != nil && orgFlag.Changed {
+		orgSlug = orgFlag.Value.String()
+	}
+
+	candidates, err := cached(ctx, "apps:"+orgSlug, func() ([]string, error) {
+		var (
+			apps []fly.App
+			err  error
+		)
+		if orgSlug != "" {
+			var org *fly.Organization
+			org, err = client.GetOrganizationBySlug(ctx, orgSlug)
+			if err != nil {
+				return nil, err
+			}
+			apps, err = client.GetAppsForOrganization(ctx, org.ID)
+		} else {
+			apps, err = client.GetApps(ctx, nil)
+		}
 		if err != nil {
 			return nil, err
 		}
-		apps, err = client.GetAppsForOrganization(ctx, org.ID)
-		orgFiltered = true
-	} else {
-		apps, err = client.GetApps(ctx, nil)
-	}
-	if err != nil {
-		return nil, err
-	}
 
-	ret := lo.FilterMap(apps, func(app fly.App, _ int) (string, bool) {
-		if strings.HasPrefix(app.Name, partial) {
+		ret := lo.Map(apps, func(app fly.App, _ int) string {
 			var info []string
-			if !orgFiltered {
+			if orgSlug == "" {
 				info = append(info, app.Organization.Name)
 			}
 			info = append(info, app.Status)
-			return fmt.Sprintf("%s\t%s", app.Name, strings.Join(info, ", ")), true
-		}
-		return "", false
+			return fmt.Sprintf("%s\t%s", app.Name, strings.Join(info, ", "))
+		})
+		slices.Sort(ret)
+		return ret, nil
 	})
-	slices.Sort(ret)
-	return ret, nil
+	if err != nil {
+		return nil, err
+	}
+	return filterPrefix(candidates, partial, args), nil
 }
 
 func CompleteOrgs(
@@ -67,56 +77,233 @@ func CompleteOrgs(
 ) ([]string, error) {
 	client := fly.ClientFromContext(ctx)
 
-	format := func(org fly.Organization) string {
-		return fmt.Sprintf("%s\t%s", org.Slug, org.Name)
+	candidates, err := cached(ctx, "orgs", func() ([]string, error) {
+		orgs, err := client.GetOrganizations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ret := lo.Map(orgs, func(org fly.Organization, _ int) string {
+			return fmt.Sprintf("%s\t%s", org.Slug, org.Name)
+		})
+		slices.Sort(ret)
+		return ret, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return filterPrefix(candidates, partial, args), nil
+}
 
-	orgs, err := client.GetOrganizations(ctx)
+func CompleteRegions(
+	ctx context.Context,
+	cmd *cobra.Command,
+	args []string,
+	partial string,
+) ([]string, error) {
+	client := fly.ClientFromContext(ctx)
+
+	candidates, err := cached(ctx, "regions", func() ([]string, error) {
+		format := func(region fly.Region) string {
+			return fmt.Sprintf("%s\t%s", region.Code, region.Name)
+		}
+
+		// TODO(ali): Do we need to worry about which ones are marked as "gateway"?
+		regions, reqRegion, err := client.PlatformRegions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		regionNames := lo.Map(regions, func(region fly.Region, _ int) string {
+			return format(region)
+		})
+		slices.Sort(regionNames)
+		// If the region we're closest to is in the list, put it at the top
+		if reqRegion != nil {
+			idx := slices.Index(regionNames, format(*reqRegion))
+			if idx >= 0 {
+				regionNames = append([]string{regionNames[idx]}, append(regionNames[:idx], regionNames[idx+1:]...)...)
+			}
+		}
+		return regionNames, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	names := []string{}
-	for _, org := range orgs {
-		names = append(names, format(org))
+	return filterPrefix(candidates, partial, nil), nil
+}
+
+// CompleteMachines completes the IDs of the machines of the app, leaving out
+// the ones already given as arguments.
+func CompleteMachines(
+	ctx context.Context,
+	cmd *cobra.Command,
+	args []string,
+	partial string,
+) ([]string, error) {
+	appName := appNameOf(cmd)
+	if appName == "" {
+		return nil, nil
 	}
-	ret := lo.Filter(names, func(name string, _ int) bool {
-		return strings.HasPrefix(name, partial)
+
+	candidates, err := cached(ctx, "machines:"+appName, func() ([]string, error) {
+		flapsClient, err := newFlapsClient(ctx, appName)
+		if err != nil {
+			return nil, err
+		}
+		machines, err := flapsClient.List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		ret := lo.Map(machines, func(m *fly.Machine, _ int) string {
+			return fmt.Sprintf("%s\t%s, %s, %s", m.ID, m.Name, m.State, m.Region)
+		})
+		slices.Sort(ret)
+		return ret, nil
 	})
-	slices.Sort(ret)
-	return ret, nil
+	if err != nil {
+		return nil, err
+	}
+	return filterPrefix(candidates, partial, args), nil
 }
 
-func CompleteRegions(
+// CompleteVolumes completes the IDs of the volumes of the app, leaving out the
+// ones already given as arguments.
+func CompleteVolumes(
 	ctx context.Context,
 	cmd *cobra.Command,
 	args []string,
 	partial string,
 ) ([]string, error) {
-	client := fly.ClientFromContext(ctx)
+	appName := appNameOf(cmd)
+	if appName == "" {
+		return nil, nil
+	}
+
+	candidates, err := cached(ctx, "volumes:"+appName, func() ([]string, error) {
+		flapsClient, err := newFlapsClient(ctx, appName)
+		if err != nil {
+			return nil, err
+		}
+		volumes, err := flapsClient.GetVolumes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ret := lo.Map(volumes, func(v fly.Volume, _ int) string {
+			attached := "unattached"
+			if v.AttachedMachine != nil {
+				attached = "attached to " + *v.AttachedMachine
+			}
+			return fmt.Sprintf("%s\t%s, %dGB, %s, %s", v.ID, v.Name, v.SizeGb, v.Region, attached)
+		})
+		slices.Sort(ret)
+		return ret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterPrefix(candidates, partial, args), nil
+}
 
-	format := func(org fly.Region) string {
-		return fmt.Sprintf("%s\t%s", org.Code, org.Name)
+// CompleteImages completes the images of the recent releases of the app, most
+// recent first.
+func CompleteImages(
+	ctx context.Context,
+	cmd *cobra.Command,
+	args []string,
+	partial string,
+) ([]string, error) {
+	appName := appNameOf(cmd)
+	if appName == "" {
+		return nil, nil
 	}
 
-	// TODO(ali): Do we need to worry about which ones are marked as "gateway"?
-	regions, reqRegion, err := client.PlatformRegions(ctx)
+	client := fly.ClientFromContext(ctx)
+	candidates, err := cached(ctx, "images:"+appName, func() ([]string, error) {
+		releases, err := client.GetAppReleasesMachines(ctx, appName, "", 25)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(releases, func(a, b fly.Release) int {
+			return b.Version - a.Version
+		})
+
+		var ret []string
+		seen := map[string]bool{}
+		for _, release := range releases {
+			if release.ImageRef == "" || seen[release.ImageRef] {
+				continue
+			}
+			seen[release.ImageRef] = true
+			ret = append(ret, fmt.Sprintf("%s\tv%d", release.ImageRef, release.Version))
+		}
+		return ret, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	regionNames := lo.FilterMap(regions, func(region fly.Region, _ int) (string, bool) {
-		if strings.HasPrefix(region.Code, partial) {
-			return format(region), true
+	return filterPrefix(candidates, partial, nil), nil
+}
+
+// filterPrefix returns the candidates, formatted as value and description
+// separated by a tab, whose value starts with partial and isn't one of args.
+func filterPrefix(candidates []string, partial string, args []string) []string {
+	return lo.Filter(candidates, func(candidate string, _ int) bool {
+		value, _, _ := strings.Cut(candidate, "\t")
+		return strings.HasPrefix(value, partial) && !slices.Contains(args, value)
+	})
+}
+
+// appNameOf returns the name of the app a command applies to, from the app
+// flag, the FLY_APP environment variable or the app config file, the way
+// commands resolve it.
+func appNameOf(cmd *cobra.Command) string {
+	// We can't use `flag.*` or appconfig here because of import cycles.
+	if appFlag := cmd.Flag(flagnames.App); appFlag != nil && appFlag.Changed {
+		return appFlag.Value.String()
+	}
+	if name := env.First("FLY_APP"); name != "" {
+		return name
+	}
+
+	path := "fly.toml"
+	if configFlag := cmd.Flag(flagnames.AppConfigFilePath); configFlag != nil && configFlag.Value.String() != "" {
+		path = configFlag.Value.String()
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			path = filepath.Join(path, "fly.toml")
 		}
-		return "", false
+	}
+	return appNameFromConfig(path)
+}
+
+// appNameFromConfig reads the app name of the app config file at path.
+func appNameFromConfig(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		App string `toml:"app"`
+	}
+	// Only the app name matters, so errors in other parts are fine.
+	_ = toml.Unmarshal(data, &cfg)
+	return cfg.App
+}
+
+func newFlapsClient(ctx context.Context, appName string) (*flaps.Client, error) {
+	return flaps.NewWithOptions(ctx, flaps.NewClientOpts{
+		AppName:   appName,
+		Tokens:    config.Tokens(ctx),
+		UserAgent: buildinfo.UserAgent(),
 	})
-	slices.Sort(regionNames)
-	// If the region we're closest to is in the list, put it at the top
-	if reqRegion != nil && strings.HasPrefix(reqRegion.Code, partial) {
-		idx := slices.Index(regionNames, format(*reqRegion))
-		// Should always be true because of the check above, but just to be safe...
-		if idx >= 0 {
-			regionNames = append([]string{regionNames[idx]}, append(regionNames[:idx], regionNames[idx+1:]...)...)
+}
+
+// FirstArg restricts fn to completing the first argument of a command.
+func FirstArg(
+	fn func(ctx context.Context, cmd *cobra.Command, args []string, partial string) ([]string, error),
+) func(ctx context.Context, cmd *cobra.Command, args []string, partial string) ([]string, error) {
+	return func(ctx context.Context, cmd *cobra.Command, args []string, partial string) ([]string, error) {
+		if len(args) > 0 {
+			return nil, nil
 		}
+		return fn(ctx, cmd, args, partial)
 	}
-	return regionNames, nil
 }
@@ -355,9 +355,10 @@ func AppConfig() String {
 // Image returns a Docker image config string flag.
 func Image() String {
 	return String{
-		Name:        flagnames.Image,
-		Shorthand:   "i",
-		Description: "The Docker image to deploy",
+		Name:         flagnames.Image,
+		Shorthand:    "i",
+		Description:  "The Docker image to deploy",
+		CompletionFn: completion.CompleteImages,
 	}
 }
 
@@ -445,6 +446,20 @@ func GetLocalOnly(ctx context.Context) bool {
 	return GetBool(ctx, localOnlyName)
 }
 
+const dockerHostName = "docker-host"
+
+// DockerHost returns a string flag for the address of the local Docker daemon
+func DockerHost() String {
+	return String{
+		Name:        dockerHostName,
+		Description: "Address of the local Docker daemon to build with, such as unix:///path/to/docker.sock or npipe:////./pipe/docker_engine. Defaults to DOCKER_HOST, then the current docker context.",
+	}
+}
+
+func GetDockerHost(ctx context.Context) string {
+	return GetString(ctx, dockerHostName)
+}
+
 const detachName = "detach"
 
 // Detach returns a boolean flag for detaching during deployment
@@ -519,7 +534,42 @@ func NoCache() Bool {
 func BuildSecret() StringArray {
 	return StringArray{
 		Name:        "build-secret",
-		Description: "Set of build secrets of NAME=VALUE pairs. Can be specified multiple times. See https://docs.docker.com/engine/reference/commandline/buildx_build/#secret",
+		Description: "Set of build secrets of NAME=VALUE pairs. Can be specified multiple times. Use id=NAME,src=path/to/file to read the secret from a file, or id=NAME,env=VARIABLE to read it from an environment variable. See https://docs.docker.com/engine/reference/commandline/buildx_build/#secret",
+	}
+}
+
+func SSH() StringArray {
+	return StringArray{
+		Name:        "ssh",
+		Description: "SSH agent socket or keys to expose to RUN --mount=type=ssh steps of the build, as default|<id>[=<socket>|<key>[,<key>]]. Can be specified multiple times",
+	}
+}
+
+func SBOM() Bool {
+	return Bool{
+		Name:        "sbom",
+		Description: "Generate an SPDX SBOM of the image with BuildKit and attach it to the image as an attestation. Needs a Docker daemon using the containerd image store",
+	}
+}
+
+func Reproducible() Bool {
+	return Bool{
+		Name:        "reproducible",
+		Description: "Build reproducibly with BuildKit, clamping timestamps to $SOURCE_DATE_EPOCH or the time of the last git commit, so the same source builds the same image",
+	}
+}
+
+func CacheFrom() StringArray {
+	return StringArray{
+		Name:        "cache-from",
+		Description: "External cache to import when building with BuildKit, such as type=registry,ref=registry.fly.io/my-app:cache. A bare image reference is a registry cache. Can be specified multiple times.",
+	}
+}
+
+func CacheTo() StringArray {
+	return StringArray{
+		Name:        "cache-to",
+		Description: "External cache to export to when building with BuildKit, such as type=registry,ref=registry.fly.io/my-app:cache,mode=max. A bare image reference is a registry cache. Can be specified multiple times.",
 	}
 }
 
@@ -537,6 +587,20 @@ func BuildTarget() String {
 	}
 }
 
+func BakeTarget() String {
+	return String{
+		Name:        "bake-target",
+		Description: "Build the image from this target of a docker buildx bake file",
+	}
+}
+
+func BakeFile() String {
+	return String{
+		Name:        "bake-file",
+		Description: "Path to the bake file of --bake-target, whose default target is built without one. Defaults to the docker-bake.json or docker-bake.hcl in the working directory.",
+	}
+}
+
 func Nixpacks() Bool {
 	return Bool{
 		Name:        "nixpacks",
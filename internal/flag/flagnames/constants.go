@@ -13,6 +13,33 @@ const (
 	// LocalOnly denotes the name of the local-only flag.
 	LocalOnly = "local-only"
 
+	// Offline denotes the name of the offline flag.
+	Offline = "offline"
+
+	// NoReadCache denotes the name of the no-read-cache flag.
+	NoReadCache = "no-read-cache"
+
+	// MaxRetries denotes the name of the max retries flag.
+	MaxRetries = "max-retries"
+
+	// RequestTimeout denotes the name of the request timeout flag.
+	RequestTimeout = "request-timeout"
+
+	// Quiet denotes the name of the quiet flag.
+	Quiet = "quiet"
+
+	// NoColor denotes the name of the no-color flag.
+	NoColor = "no-color"
+
+	// Progress denotes the name of the progress flag.
+	Progress = "progress"
+
+	// LogLevel denotes the name of the log level flag.
+	LogLevel = "log-level"
+
+	// LogFile denotes the name of the log file flag.
+	LogFile = "log-file"
+
 	// Debug denotes the name of the debug flag.
 	Debug = "debug"
 
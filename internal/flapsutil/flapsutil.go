@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/internal/buildinfo"
 	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/httpretry"
+	"github.com/superfly/flyctl/internal/httptracing"
 	"github.com/superfly/flyctl/internal/logger"
 	"github.com/superfly/flyctl/internal/metrics"
 )
@@ -47,6 +50,10 @@ func NewClientWithOptions(ctx context.Context, opts flaps.NewClientOpts) (*flaps
 		opts.UserAgent = buildinfo.UserAgent()
 	}
 
+	if opts.Transport == nil {
+		opts.Transport = httpretry.NewFromContext(ctx, httptracing.NewTransport(http.DefaultTransport))
+	}
+
 	if opts.Tokens == nil {
 		opts.Tokens = config.Tokens(ctx)
 	}
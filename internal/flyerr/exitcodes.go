@@ -0,0 +1,134 @@
+package flyerr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Exit codes the CLI exits with, so scripts can tell failures apart without
+// parsing error messages. They're documented in fly help exit-codes.
+const (
+	ExitOK = 0
+	// ExitFailure is the exit code of failures of no more specific kind.
+	ExitFailure = 1
+	// ExitCondition is the exit code of commands reporting a condition they
+	// were asked to check for, such as fly incidents --exit-code.
+	ExitCondition = 2
+	// ExitAuth is the exit code of missing or rejected credentials.
+	ExitAuth = 3
+	// ExitNotFound is the exit code of missing apps, machines and other
+	// resources.
+	ExitNotFound = 4
+	// ExitValidation is the exit code of invalid arguments, flags and
+	// configuration.
+	ExitValidation = 5
+	// ExitHealthCheckTimeout is the exit code of machines whose health checks
+	// didn't pass in time.
+	ExitHealthCheckTimeout = 6
+	// ExitLeaseConflict is the exit code of machines leased by another
+	// operation.
+	ExitLeaseConflict = 7
+	// ExitTimeout is the exit code of commands that ran out of time.
+	ExitTimeout = 126
+	// ExitCanceled is the exit code of commands that were interrupted.
+	ExitCanceled = 127
+)
+
+var (
+	// ErrHealthCheckTimeout is wrapped by errors of health checks that didn't
+	// pass in time.
+	ErrHealthCheckTimeout = errors.New("timeout reached waiting for health checks to pass")
+
+	// ErrLeaseConflict is wrapped by errors of leases held by another
+	// operation.
+	ErrLeaseConflict = errors.New("machine is leased by another operation")
+)
+
+// ValidationError marks Err as caused by invalid input.
+type ValidationError struct {
+	Err error
+}
+
+func (e ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code the CLI should exit with when failing with
+// err.
+func ExitCode(err error) int {
+	var (
+		exitErr       ExitCodeError
+		validationErr ValidationError
+		status        = statusCode(err)
+		code          = graphQLErrorCode(err)
+	)
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	case errors.Is(err, context.Canceled), IsCancelledError(err):
+		return ExitCanceled
+	case errors.Is(err, ErrHealthCheckTimeout):
+		return ExitHealthCheckTimeout
+	case errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	case errors.Is(err, ErrLeaseConflict):
+		return ExitLeaseConflict
+	case errors.Is(err, fly.ErrNoAuthToken), status == http.StatusUnauthorized, status == http.StatusForbidden,
+		code == "UNAUTHORIZED", code == "UNAUTHENTICATED":
+		return ExitAuth
+	case status == http.StatusNotFound, code == "NOT_FOUND":
+		return ExitNotFound
+	case errors.As(err, &validationErr):
+		return ExitValidation
+	default:
+		return ExitFailure
+	}
+}
+
+// statusCode returns the HTTP status code of API errors, or zero.
+func statusCode(err error) int {
+	var (
+		apiErr   *fly.ApiError
+		flapsErr *flaps.FlapsError
+	)
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.Status
+	case errors.As(err, &flapsErr):
+		return flapsErr.ResponseStatusCode
+	default:
+		return 0
+	}
+}
+
+// graphQLErrorCode returns the code of GraphQL errors, or an empty string.
+func graphQLErrorCode(err error) string {
+	var (
+		gqlErr  *graphql.GraphQLError
+		gqlList gqlerror.List
+	)
+	switch {
+	case errors.As(err, &gqlErr):
+		return gqlErr.Extensions.Code
+	case errors.As(err, &gqlList):
+		for _, e := range gqlList {
+			if code, ok := e.Extensions["code"].(string); ok {
+				return code
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package flyerr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestExitCode(t *testing.T) {
+	notFound := gqlerror.List{{Message: "Could not find App", Extensions: map[string]any{"code": "NOT_FOUND"}}}
+
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, ExitOK},
+		{errors.New("boom"), ExitFailure},
+		{ExitCodeError{Code: ExitCondition}, ExitCondition},
+		{context.Canceled, ExitCanceled},
+		{fmt.Errorf("deploying: %w", context.DeadlineExceeded), ExitTimeout},
+		{fly.ErrNoAuthToken, ExitAuth},
+		{fmt.Errorf("listing apps: %w", &fly.ApiError{Status: http.StatusUnauthorized}), ExitAuth},
+		{&flaps.FlapsError{ResponseStatusCode: http.StatusForbidden}, ExitAuth},
+		{fmt.Errorf("getting app: %w", notFound), ExitNotFound},
+		{fmt.Errorf("getting machine: %w", flaps.FlapsErrorNotFound), ExitNotFound},
+		{ValidationError{Err: errors.New("unknown flag: --nope")}, ExitValidation},
+		{fmt.Errorf("%w for machine 148e: %w", ErrHealthCheckTimeout, context.DeadlineExceeded), ExitHealthCheckTimeout},
+		{fmt.Errorf("error acquiring leases on all machines: %w", ErrLeaseConflict), ExitLeaseConflict},
+		// Conflicts other than leases, such as a taken app name, are plain failures
+		{&flaps.FlapsError{ResponseStatusCode: http.StatusConflict}, ExitFailure},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.code, ExitCode(c.err), "%v", c.err)
+	}
+}
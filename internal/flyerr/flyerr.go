@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/logrusorgru/aurora"
+	"github.com/superfly/flyctl/iostreams"
 )
 
 type GenericErr struct {
@@ -84,7 +84,7 @@ func PrintCLIOutput(err error) {
 	}
 
 	fmt.Println()
-	fmt.Println(aurora.Red("Error"), err)
+	fmt.Println(iostreams.Aurora().Red("Error"), err)
 
 	description := GetErrorDescription(err)
 	suggestion := GetErrorSuggestion(err)
@@ -127,3 +127,21 @@ func IsCancelledError(err error) bool {
 
 	return false
 }
+
+// ExitCodeError makes the CLI exit with Code instead of the generic failure
+// exit code. Err, when set, is reported like any other error.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e ExitCodeError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,327 @@
+// Package httpretry implements an http.RoundTripper which retries requests
+// the API rate limited or failed to serve, and bounds how long each may take.
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+const (
+	minDelay = 250 * time.Millisecond
+	maxDelay = 30 * time.Second
+)
+
+// Transport retries requests answered with 429 Too Many Requests, whatever
+// their method, and idempotent requests answered with a server error or
+// which failed with a network error, with jittered exponential backoff.
+// Retry-After headers are honored.
+//
+// The HTTP clients of fly-go wrap their transport in one retrying 502 and 503
+// responses and temporary errors. So that Transport alone decides what is
+// retried, and how often, the outcomes it gives up on are returned in a form
+// that one leaves alone: errors don't report themselves as temporary, and 502
+// and 503 responses become a *StatusError.
+type Transport struct {
+	// Inner is the transport requests are sent with.
+	Inner http.RoundTripper
+
+	// MaxRetries is how many times a request may be retried.
+	MaxRetries int
+
+	// Timeout bounds each attempt of a request, including reading its
+	// response body. Zero means no limit.
+	Timeout time.Duration
+
+	// Logf, when set, reports retries and the rate limit headers of
+	// responses.
+	Logf func(format string, v ...any)
+
+	// sleep waits for d or until ctx is done; overridden in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New returns a Transport wrapping inner.
+func New(inner http.RoundTripper, maxRetries int, timeout time.Duration) *Transport {
+	return &Transport{
+		Inner:      inner,
+		MaxRetries: maxRetries,
+		Timeout:    timeout,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req, attempt)
+		if resp != nil {
+			t.logRateLimit(req, resp)
+		}
+
+		if attempt >= t.MaxRetries || !retryable(req, resp, err) {
+			return final(resp, err)
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			// the body was consumed and can't be replayed
+			return final(resp, err)
+		}
+
+		delay := backoff(attempt)
+		if d, ok := retryAfter(resp); ok {
+			delay = d
+		}
+
+		if resp != nil {
+			t.logf("%s %s: %s, retrying in %s (%d/%d)", req.Method, req.URL.Redacted(), resp.Status, delay.Round(time.Millisecond), attempt+1, t.MaxRetries)
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		} else {
+			t.logf("%s %s: %v, retrying in %s (%d/%d)", req.Method, req.URL.Redacted(), err, delay.Round(time.Millisecond), attempt+1, t.MaxRetries)
+		}
+
+		if err := t.wait(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) roundTrip(req *http.Request, attempt int) (*http.Response, error) {
+	inner := t.Inner
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+
+	if t.Timeout <= 0 {
+		return inner.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	resp, err := inner.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			err = &TimeoutError{After: t.Timeout, Err: err}
+		}
+		return nil, err
+	}
+
+	// the deadline applies until the body has been read
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+func (t *Transport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *Transport) logf(format string, v ...any) {
+	if t.Logf != nil {
+		t.Logf(format, v...)
+	}
+}
+
+// logRateLimit reports the rate limit headers of resp, if there are any.
+func (t *Transport) logRateLimit(req *http.Request, resp *http.Response) {
+	if t.Logf == nil {
+		return
+	}
+
+	var headers []string
+	for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"} {
+		if v := resp.Header.Get(name); v != "" {
+			headers = append(headers, strings.ToLower(name)+"="+v)
+		}
+	}
+	if len(headers) > 0 {
+		t.Logf("%s %s: %s", req.Method, req.URL.Redacted(), strings.Join(headers, " "))
+	}
+}
+
+// TimeoutError is returned when an attempt of a request exceeded the
+// Transport's Timeout.
+type TimeoutError struct {
+	After time.Duration
+	Err   error
+}
+
+func (e *TimeoutError) Error() string {
+	return "request timed out after " + e.After.String()
+}
+
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+func (*TimeoutError) Timeout() bool { return true }
+
+// StatusError is returned in place of a 502 Bad Gateway or 503 Service
+// Unavailable response Transport won't retry.
+type StatusError struct {
+	Status     string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return "server responded with " + e.Status
+}
+
+// finalError wraps an error Transport won't retry. It hides the Temporary
+// method of the error it wraps.
+type finalError struct {
+	err error
+}
+
+func (e *finalError) Error() string { return e.err.Error() }
+
+func (e *finalError) Unwrap() error { return e.err }
+
+func (e *finalError) Timeout() bool {
+	var netErr net.Error
+	return errors.As(e.err, &netErr) && netErr.Timeout()
+}
+
+// final returns the outcome of a request Transport gave up on.
+func final(resp *http.Response, err error) (*http.Response, error) {
+	if err != nil {
+		return nil, &finalError{err: err}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+		return nil, &StatusError{
+			Status:     resp.Status,
+			StatusCode: resp.StatusCode,
+		}
+	default:
+		return resp, nil
+	}
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// retryable reports whether the outcome of req is worth retrying. Rate
+// limited requests weren't served, so they're retried whatever their method;
+// server and network errors only for idempotent methods, since the request
+// may have been served.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) && idempotent(req.Method)
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusTooManyRequests:
+		return true
+	case code >= 500:
+		return idempotent(req.Method)
+	default:
+		return false
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the jittered delay before retry number attempt+1.
+func backoff(attempt int) time.Duration {
+	d := minDelay << min(attempt, 10)
+	if d > maxDelay {
+		d = maxDelay
+	}
+	// full jitter over the upper half keeps clients from retrying in lockstep
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header of resp, in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	var d time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(v); err == nil {
+		d = time.Until(at)
+	} else {
+		return 0, false
+	}
+
+	return min(max(d, 0), maxDelay), true
+}
+
+// NewFromContext returns a Transport wrapping inner, or http.DefaultTransport
+// when nil, configured by the --max-retries and --request-timeout flags. In
+// verbose mode retries and rate limit headers are printed to stderr; they're
+// otherwise logged at debug level.
+func NewFromContext(ctx context.Context, inner http.RoundTripper) *Transport {
+	cfg := config.FromContext(ctx)
+
+	t := New(inner, cfg.MaxRetries, cfg.RequestTimeout)
+	if cfg.VerboseOutput {
+		if io := iostreams.FromContext(ctx); io != nil {
+			t.Logf = func(format string, v ...any) {
+				fmt.Fprintf(io.ErrOut, format+"\n", v...)
+			}
+		}
+	} else if l := logger.MaybeFromContext(ctx); l != nil {
+		t.Logf = l.Debugf
+	}
+
+	return t
+}
@@ -0,0 +1,180 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func newTestTransport(maxRetries int, delays *[]time.Duration) *Transport {
+	t := New(http.DefaultTransport, maxRetries, 0)
+	t.sleep = func(_ context.Context, d time.Duration) error {
+		*delays = append(*delays, d)
+		return nil
+	}
+	return t
+}
+
+func TestRetriesRateLimitedRequests(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"query":"{}"}`, string(body))
+
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	client := &http.Client{Transport: newTestTransport(3, &delays)}
+
+	resp, err := client.Post(srv.URL, "application/json", strings.NewReader(`{"query":"{}"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.EqualValues(t, 3, calls.Load())
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, delays)
+}
+
+func TestGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	client := &http.Client{Transport: newTestTransport(2, &delays)}
+
+	_, err := client.Get(srv.URL)
+
+	var statusErr *StatusError
+	require.True(t, errors.As(err, &statusErr), "%v", err)
+	assert.Equal(t, http.StatusServiceUnavailable, statusErr.StatusCode)
+	assert.EqualValues(t, 3, calls.Load())
+	require.Len(t, delays, 2)
+	assert.GreaterOrEqual(t, delays[1], delays[0]/2)
+}
+
+func TestNoRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	client, err := fly.NewHTTPClient(nil, newTestTransport(0, &delays))
+	require.NoError(t, err)
+
+	_, err = client.Get(srv.URL)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, calls.Load(), "the retrying transport of fly-go retried")
+	assert.Empty(t, delays)
+}
+
+func TestDoesNotRetryNonIdempotentServerErrors(t *testing.T) {
+	var calls, status atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer srv.Close()
+
+	var delays []time.Duration
+	client := &http.Client{Transport: newTestTransport(3, &delays)}
+
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("x"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.EqualValues(t, 1, calls.Load())
+
+	status.Store(http.StatusServiceUnavailable)
+	_, err = client.Post(srv.URL, "text/plain", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.EqualValues(t, 2, calls.Load())
+	status.Store(http.StatusInternalServerError)
+
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.EqualValues(t, 6, calls.Load())
+}
+
+func TestRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	tr := New(http.DefaultTransport, 0, 50*time.Millisecond)
+	_, err := (&http.Client{Transport: tr}).Get(srv.URL)
+
+	var timeoutErr *TimeoutError
+	require.True(t, errors.As(err, &timeoutErr), "%v", err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLogsRateLimitHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Header().Set("X-RateLimit-Limit", "100")
+	}))
+	defer srv.Close()
+
+	var lines []string
+	tr := New(http.DefaultTransport, 0, 0)
+	tr.Logf = func(format string, v ...any) {
+		lines = append(lines, fmt.Sprintf(format, v...))
+	}
+
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, lines, 1)
+	assert.True(t, strings.HasSuffix(lines[0], ": x-ratelimit-limit=100 x-ratelimit-remaining=41"), lines[0])
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{v}}}
+	}
+
+	d, ok := retryAfter(header("7"))
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	d, ok = retryAfter(header(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)))
+	assert.True(t, ok)
+	assert.Equal(t, maxDelay, d)
+
+	_, ok = retryAfter(header("soon"))
+	assert.False(t, ok)
+
+	_, ok = retryAfter(nil)
+	assert.False(t, ok)
+}
@@ -42,6 +42,10 @@ func Finish() {
 }
 
 func NewTransport(transport http.RoundTripper) http.RoundTripper {
+	if tracing() {
+		transport = &traceTransport{inner: transport}
+	}
+
 	if har == nil {
 		return transport
 	}
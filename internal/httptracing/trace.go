@@ -0,0 +1,163 @@
+package httptracing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTracedBody bounds how much of each request and response body is traced.
+const maxTracedBody = 64 << 10
+
+var (
+	traceMu  sync.Mutex
+	traceOut io.Writer
+)
+
+// SetTraceOutput makes transports returned by NewTransport write redacted
+// traces of every request and response to w. A nil w disables tracing.
+func SetTraceOutput(w io.Writer) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	traceOut = w
+}
+
+func tracing() bool {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	return traceOut != nil
+}
+
+func writeTrace(s string) {
+	traceMu.Lock()
+	defer traceMu.Unlock()
+
+	if traceOut != nil {
+		_, _ = io.WriteString(traceOut, s)
+	}
+}
+
+type traceTransport struct {
+	inner http.RoundTripper
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--> %s %s\n", req.Method, req.URL.Redacted())
+	writeHeaders(&b, req.Header)
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxTracedBody))
+			body.Close()
+			writeBody(&b, data)
+		}
+	}
+	writeTrace(b.String())
+
+	resp, err := t.inner.RoundTrip(req)
+	if err != nil {
+		writeTrace(fmt.Sprintf("<-- %s %s: %v (%s)\n\n", req.Method, req.URL.Redacted(), err, time.Since(start).Round(time.Millisecond)))
+		return resp, err
+	}
+
+	b.Reset()
+	fmt.Fprintf(&b, "<-- %s %s %s (%s)\n", resp.Status, req.Method, req.URL.Redacted(), time.Since(start).Round(time.Millisecond))
+	writeHeaders(&b, resp.Header)
+
+	// the body is traced once read, so streamed responses aren't held up
+	resp.Body = &tracedBody{ReadCloser: resp.Body, header: b.String()}
+	return resp, nil
+}
+
+// tracedBody captures the start of a response body and traces it on Close.
+type tracedBody struct {
+	io.ReadCloser
+	header string
+	buf    bytes.Buffer
+	once   sync.Once
+}
+
+func (b *tracedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := maxTracedBody - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *tracedBody) Close() error {
+	b.once.Do(func() {
+		var s strings.Builder
+		s.WriteString(b.header)
+		writeBody(&s, b.buf.Bytes())
+		writeTrace(s.String())
+	})
+	return b.ReadCloser.Close()
+}
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Fly-Prometheus-Auth": true,
+	"X-Api-Key":           true,
+}
+
+func writeHeaders(w io.Writer, header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range header[name] {
+			if sensitiveHeaders[name] || sensitiveKey.MatchString(name) {
+				v = redacted
+			}
+			fmt.Fprintf(w, "%s: %s\n", name, v)
+		}
+	}
+}
+
+func writeBody(w io.Writer, data []byte) {
+	if len(data) > 0 {
+		fmt.Fprintf(w, "\n%s\n", Redact(data))
+	}
+	fmt.Fprintln(w)
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	sensitiveKey = regexp.MustCompile(`(?i)token|secret|passw|private|credential|signature|macaroon|api[-_]?key`)
+
+	// jsonString matches a JSON object key and its string value.
+	jsonString = regexp.MustCompile(`"([^"\\]*)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// Redact replaces the string values of JSON keys which look like they hold
+// credentials, and the values of secrets, with a placeholder.
+func Redact(data []byte) []byte {
+	secrets := bytes.Contains(bytes.ToLower(data), []byte("secret"))
+
+	return jsonString.ReplaceAllFunc(data, func(m []byte) []byte {
+		sub := jsonString.FindSubmatch(m)
+		key := string(sub[1])
+		if sensitiveKey.MatchString(key) || (secrets && strings.EqualFold(key, "value")) {
+			return []byte(`"` + key + `"` + string(sub[2]) + `"` + redacted + `"`)
+		}
+		return m
+	})
+}
@@ -0,0 +1,63 @@
+package httptracing
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	cases := map[string]string{
+		`{"access_token":"fo1_abc","name":"x"}`: `{"access_token":"[REDACTED]","name":"x"}`,
+		`{"Password" : "hunter2"}`:              `{"Password" : "[REDACTED]"}`,
+		`{"query":"mutation setSecrets","variables":{"input":{"secrets":[{"key":"DB","value":"p\"w"}]}}}`: `{"query":"mutation setSecrets","variables":{"input":{"secrets":[{"key":"DB","value":"[REDACTED]"}]}}}`,
+		`{"key":"region","value":"ord"}`: `{"key":"region","value":"ord"}`,
+	}
+
+	for in, exp := range cases {
+		assert.Equal(t, exp, string(Redact([]byte(in))), in)
+	}
+}
+
+func TestTraceTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = io.WriteString(w, `{"token":"xyz","ok":true}`)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	SetTraceOutput(&out)
+	defer SetTraceOutput(nil)
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/graphql", strings.NewReader(`{"password":"hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "FlyV1 fm2_secret")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, `{"token":"xyz","ok":true}`, string(body))
+
+	trace := out.String()
+	assert.Contains(t, trace, "--> POST "+srv.URL+"/graphql")
+	assert.Contains(t, trace, "Authorization: [REDACTED]")
+	assert.Contains(t, trace, `{"password":"[REDACTED]"}`)
+	assert.Contains(t, trace, "<-- 200 OK POST")
+	assert.Contains(t, trace, "Set-Cookie: [REDACTED]")
+	assert.Contains(t, trace, `{"token":"[REDACTED]","ok":true}`)
+	assert.NotContains(t, trace, "fm2_secret")
+	assert.NotContains(t, trace, "hunter2")
+	assert.NotContains(t, trace, "xyz")
+}
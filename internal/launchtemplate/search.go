@@ -0,0 +1,68 @@
+package launchtemplate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SearchTopic is the GitHub topic of template repositories. Their templates
+// are expected on the GitHub container registry under the same name.
+const SearchTopic = "fly-launch-template"
+
+var searchURL = "https://api.github.com/search/repositories"
+
+type SearchResult struct {
+	Ref         string
+	Description string
+	Stars       int
+	URL         string
+}
+
+// Search finds template repositories matching query, most starred first.
+func Search(ctx context.Context, query string) ([]SearchResult, error) {
+	q := strings.TrimSpace(query + " topic:" + SearchTopic)
+	u := fmt.Sprintf("%s?q=%s&sort=stars&order=desc", searchURL, url.QueryEscape(q))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search templates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to search templates: %s", resp.Status)
+	}
+
+	var body struct {
+		Items []struct {
+			FullName        string `json:"full_name"`
+			Description     string `json:"description"`
+			StargazersCount int    `json:"stargazers_count"`
+			HTMLURL         string `json:"html_url"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode template search results: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(body.Items))
+	for _, item := range body.Items {
+		results = append(results, SearchResult{
+			Ref:         "ghcr.io/" + strings.ToLower(item.FullName),
+			Description: item.Description,
+			Stars:       item.StargazersCount,
+			URL:         item.HTMLURL,
+		})
+	}
+	return results, nil
+}
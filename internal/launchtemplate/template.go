@@ -0,0 +1,212 @@
+// Package launchtemplate fetches launch templates, which are OCI images whose
+// files seed a new app: typically a fly.toml, a Dockerfile and a
+// fly-template.toml manifest describing the template and its hooks.
+package launchtemplate
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ManifestFileName is the template manifest, removed once the template is
+// extracted.
+const ManifestFileName = "fly-template.toml"
+
+// Hook is a command run in the app directory.
+type Hook struct {
+	Description string   `toml:"description"`
+	Command     []string `toml:"command"`
+}
+
+type Manifest struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	// PostLaunch hooks run once the app is launched, in order
+	PostLaunch []Hook `toml:"post_launch"`
+}
+
+type Template struct {
+	// Ref is the reference the template was fetched with
+	Ref string
+	// Pinned references the exact version fetched, by digest
+	Pinned   string
+	Manifest Manifest
+}
+
+// Fetch pulls the template image at ref, ghcr.io/org/template:v1 for instance,
+// and extracts its files into dir.
+func Fetch(ctx context.Context, ref, dir string) (*Template, error) {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template reference %s: %w", ref, err)
+	}
+
+	img, err := remote.Image(r, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch template %s: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := extractLayers(img, dir); err != nil {
+		return nil, fmt.Errorf("failed to extract template %s: %w", ref, err)
+	}
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Template{
+		Ref:      ref,
+		Pinned:   r.Context().Digest(digest.String()).String(),
+		Manifest: *manifest,
+	}, nil
+}
+
+// MaxSize is the largest compressed size of the layers of a template. Templates
+// are expected to be built FROM scratch with just the files of the app, not on
+// top of a base image.
+const MaxSize = 50 * 1024 * 1024
+
+// extractLayers writes the files of each layer of img into dir, in order, so
+// later layers overwrite earlier ones. Unlike flattening the image, nothing
+// but the template's own layers is read.
+func extractLayers(img v1.Image, dir string) error {
+	layers, err := img.Layers()
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	for _, l := range layers {
+		size, err := l.Size()
+		if err != nil {
+			return err
+		}
+		total += size
+	}
+	if total > MaxSize {
+		return fmt.Errorf("template is %d MB, larger than the %d MB allowed; build templates FROM scratch with only the files of the app", total>>20, MaxSize>>20)
+	}
+
+	for _, l := range layers {
+		rc, err := l.Uncompressed()
+		if err != nil {
+			return err
+		}
+		err = extractTar(rc, dir)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readManifest reads and removes the template manifest in dir. Templates
+// without one get an empty manifest.
+func readManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFileName)
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return &Manifest{}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := toml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+	for _, hook := range manifest.PostLaunch {
+		if len(hook.Command) == 0 {
+			return nil, fmt.Errorf("invalid %s: post_launch hooks require a command", ManifestFileName)
+		}
+	}
+	return manifest, os.Remove(path)
+}
+
+// extractTar writes the regular files and directories of the tar stream r
+// into dir, refusing entries that would land outside of it.
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.Clean(filepath.FromSlash(strings.TrimPrefix(hdr.Name, "/")))
+		if name == "." {
+			continue
+		}
+		if name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("refusing to extract %s outside of the app directory", hdr.Name)
+		}
+		path := filepath.Join(dir, name)
+
+		// Whiteouts delete files of earlier layers
+		if base := filepath.Base(name); strings.HasPrefix(base, ".wh.") {
+			if base != ".wh..wh..opq" {
+				if err := os.RemoveAll(filepath.Join(filepath.Dir(path), strings.TrimPrefix(base, ".wh."))); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type contextKey struct{}
+
+// NewContext derives a context that carries the template being launched.
+func NewContext(ctx context.Context, t *Template) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the template ctx carries, if any.
+func FromContext(ctx context.Context) *Template {
+	t, _ := ctx.Value(contextKey{}).(*Template)
+	return t
+}
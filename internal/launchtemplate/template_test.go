@@ -0,0 +1,112 @@
+package launchtemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tarball(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, contents := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestExtractTar(t *testing.T) {
+	dir := t.TempDir()
+	err := extractTar(tarball(t, map[string]string{
+		"fly.toml":        "app = 'x'\n",
+		"/app/Dockerfile": "FROM scratch\n",
+	}), dir)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "app", "Dockerfile"))
+	require.NoError(t, err)
+	assert.Equal(t, "FROM scratch\n", string(data))
+	assert.FileExists(t, filepath.Join(dir, "fly.toml"))
+
+	err = extractTar(tarball(t, map[string]string{"../escape": "x"}), dir)
+	assert.ErrorContains(t, err, "outside of the app directory")
+}
+
+func TestExtractLayers(t *testing.T) {
+	layer := func(files map[string]string) v1.Layer {
+		return static.NewLayer(tarball(t, files).Bytes(), types.DockerUncompressedLayer)
+	}
+	img, err := mutate.AppendLayers(empty.Image,
+		layer(map[string]string{"fly.toml": "app = 'old'\n", "Dockerfile": "FROM scratch\n", "tmp/cache": "x"}),
+		layer(map[string]string{"fly.toml": "app = 'new'\n", "tmp/.wh.cache": ""}),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, extractLayers(img, dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, "fly.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, "app = 'new'\n", string(data))
+	assert.FileExists(t, filepath.Join(dir, "Dockerfile"))
+	assert.NoFileExists(t, filepath.Join(dir, "tmp", "cache"))
+	assert.NoFileExists(t, filepath.Join(dir, "tmp", ".wh.cache"))
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifest, err := readManifest(dir)
+	require.NoError(t, err)
+	assert.Empty(t, manifest.PostLaunch)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(`
+name = "rails-sqlite"
+description = "Rails with SQLite on a volume"
+
+[[post_launch]]
+description = "Seeding the database"
+command = ["bin/rails", "db:seed"]
+`), 0o644))
+
+	manifest, err = readManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "rails-sqlite", manifest.Name)
+	assert.Equal(t, []Hook{{Description: "Seeding the database", Command: []string{"bin/rails", "db:seed"}}}, manifest.PostLaunch)
+	assert.NoFileExists(t, filepath.Join(dir, ManifestFileName))
+}
+
+func TestSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "rails topic:"+SearchTopic, r.URL.Query().Get("q"))
+		w.Write([]byte(`{"items":[{"full_name":"Acme/Rails-Starter","description":"Rails","stargazers_count":3,"html_url":"https://github.com/Acme/Rails-Starter"}]}`))
+	}))
+	defer srv.Close()
+
+	defer func(u string) { searchURL = u }(searchURL)
+	searchURL = srv.URL
+
+	results, err := Search(context.Background(), "rails")
+	require.NoError(t, err)
+	assert.Equal(t, []SearchResult{{
+		Ref:         "ghcr.io/acme/rails-starter",
+		Description: "Rails",
+		Stars:       3,
+		URL:         "https://github.com/Acme/Rails-Starter",
+	}}, results)
+}
@@ -56,19 +56,28 @@ func levelFromEnv() Level {
 	lit, ok := os.LookupEnv("LOG_LEVEL")
 	if !ok && buildinfo.IsDev() {
 		lit = "warn"
-	} else {
-		lit = strings.ToLower(lit)
 	}
 
-	switch lit {
-	default:
+	level, err := ParseLevel(lit)
+	if err != nil {
 		return Info
+	}
+	return level
+}
+
+// ParseLevel returns the Level named lit, such as debug or warn.
+func ParseLevel(lit string) (Level, error) {
+	switch strings.ToLower(lit) {
 	case "debug":
-		return Debug
-	case "warn":
-		return Warn
+		return Debug, nil
+	case "info", "":
+		return Info, nil
+	case "warn", "warning":
+		return Warn, nil
 	case "error":
-		return Error
+		return Error, nil
+	default:
+		return Info, fmt.Errorf("invalid log level %q, must be one of debug, info, warn or error", lit)
 	}
 }
 
@@ -143,6 +152,15 @@ func (l *Logger) AndLogToFile() *Logger {
 	}}
 }
 
+// AndLogTo returns a Logger which also writes all logs, regardless of
+// their level, to w.
+func (l *Logger) AndLogTo(w io.Writer) *Logger {
+	return &Logger{inner: &SplitLogger{
+		terminal: l.inner,
+		file:     &WriterLogger{out: w, level: Debug},
+	}}
+}
+
 // Level returns the current log level, or NoLogLevel if not applicable
 func (l *Logger) Level() Level {
 	return l.inner.Level()
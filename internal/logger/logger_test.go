@@ -0,0 +1,32 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevel(t *testing.T) {
+	for lit, exp := range map[string]Level{"debug": Debug, "INFO": Info, "": Info, "warn": Warn, "warning": Warn, "error": Error} {
+		level, err := ParseLevel(lit)
+		assert.NoError(t, err, lit)
+		assert.Equal(t, exp, level, lit)
+	}
+
+	_, err := ParseLevel("verbose")
+	assert.Error(t, err)
+}
+
+func TestAndLogTo(t *testing.T) {
+	var term, file bytes.Buffer
+
+	l := New(&term, Warn, false).AndLogTo(&file)
+	l.Debug("details")
+	l.Warn("careful")
+
+	assert.NotContains(t, term.String(), "details")
+	assert.Contains(t, term.String(), "careful")
+	assert.Contains(t, file.String(), "details")
+	assert.Contains(t, file.String(), "careful")
+}
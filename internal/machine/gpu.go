@@ -0,0 +1,35 @@
+package machine
+
+import (
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Hardcoded list of regions with GPUs, by GPU kind
+// TODO: fetch this list from the graphql endpoint once it is there
+var gpuKindRegions = map[string][]string{
+	"a100-pcie-40gb": {"ord"},
+	"a100-sxm4-80gb": {"iad", "sjc", "syd", "ams"},
+	"l40s":           {"ord"},
+	"a10":            {"ord"},
+}
+
+// GPURegions returns the regions where machines with the given GPU kind can
+// run, or every region with GPUs if kind is empty.
+func GPURegions(kind string) []string {
+	var regions []string
+	if kind == "" {
+		regions = lo.Uniq(lo.Flatten(lo.Values(gpuKindRegions)))
+	} else {
+		regions = slices.Clone(gpuKindRegions[kind])
+	}
+	slices.Sort(regions)
+	return regions
+}
+
+// GPUAvailableIn reports whether machines with the given GPU kind can run in
+// region.
+func GPUAvailableIn(kind, region string) bool {
+	return slices.Contains(gpuKindRegions[kind], region)
+}
@@ -11,6 +11,7 @@ import (
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/ctrlc"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/internal/statuslogger"
 	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/terminal"
@@ -318,7 +319,7 @@ func (lm *leasableMachine) WaitForHealthchecksToPass(ctx context.Context, timeou
 		case errors.Is(waitCtx.Err(), context.Canceled):
 			return err
 		case errors.Is(waitCtx.Err(), context.DeadlineExceeded):
-			return fmt.Errorf("timeout reached waiting for health checks to pass for machine %s: %w", lm.Machine().ID, err)
+			return fmt.Errorf("%w for machine %s: %w", flyerr.ErrHealthCheckTimeout, lm.Machine().ID, err)
 		case err != nil:
 			return fmt.Errorf("error getting machine %s from api: %w", lm.Machine().ID, err)
 		case !updateMachine.AllHealthChecks().AllPassing():
@@ -355,7 +356,7 @@ func (lm *leasableMachine) WaitForEventTypeAfterType(ctx context.Context, eventT
 		case errors.Is(waitCtx.Err(), context.Canceled):
 			return nil, err
 		case errors.Is(waitCtx.Err(), context.DeadlineExceeded):
-			return nil, fmt.Errorf("timeout reached waiting for health checks to pass for machine %s: %w", lm.Machine().ID, err)
+			return nil, fmt.Errorf("%w for machine %s: %w", flyerr.ErrHealthCheckTimeout, lm.Machine().ID, err)
 		case err != nil:
 			return nil, fmt.Errorf("error getting machine %s from api: %w", lm.Machine().ID, err)
 		}
@@ -390,10 +391,10 @@ func (lm *leasableMachine) AcquireLease(ctx context.Context, duration time.Durat
 	seconds := int(duration.Seconds())
 	lease, err := lm.flapsClient.AcquireLease(ctx, lm.machine.ID, &seconds)
 	if err != nil {
-		return err
+		return leaseError(err)
 	}
 	if lease.Status != "success" {
-		return fmt.Errorf("did not acquire lease for machine %s status: %s code: %s message: %s", lm.machine.ID, lease.Status, lease.Code, lease.Message)
+		return fmt.Errorf("did not acquire lease for machine %s status: %s code: %s message: %s: %w", lm.machine.ID, lease.Status, lease.Code, lease.Message, flyerr.ErrLeaseConflict)
 	}
 	if lease.Data == nil {
 		return fmt.Errorf("missing data from lease response for machine %s, assuming not successful", lm.machine.ID)
@@ -407,10 +408,10 @@ func (lm *leasableMachine) RefreshLease(ctx context.Context, duration time.Durat
 	seconds := int(duration.Seconds())
 	refreshedLease, err := lm.flapsClient.RefreshLease(ctx, lm.machine.ID, &seconds, lm.leaseNonce)
 	if err != nil {
-		return err
+		return leaseError(err)
 	}
 	if refreshedLease.Status != "success" {
-		return fmt.Errorf("did not acquire lease for machine %s status: %s code: %s message: %s", lm.machine.ID, refreshedLease.Status, refreshedLease.Code, refreshedLease.Message)
+		return fmt.Errorf("did not acquire lease for machine %s status: %s code: %s message: %s: %w", lm.machine.ID, refreshedLease.Status, refreshedLease.Code, refreshedLease.Message, flyerr.ErrLeaseConflict)
 	} else if refreshedLease.Data == nil {
 		return fmt.Errorf("missing data from lease response for machine %s, assuming not successful", lm.machine.ID)
 	} else if refreshedLease.Data.Nonce != lm.leaseNonce {
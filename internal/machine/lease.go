@@ -2,17 +2,30 @@ package machine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/sourcegraph/conc/pool"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flyerr"
 	"github.com/superfly/flyctl/iostreams"
 )
 
 const maxConcurrentLeases = 20
 
+// leaseError wraps the errors of flaps refusing a lease because another
+// operation holds it with flyerr.ErrLeaseConflict.
+func leaseError(err error) error {
+	var flapsErr *flaps.FlapsError
+	if errors.As(err, &flapsErr) && flapsErr.ResponseStatusCode == http.StatusConflict {
+		return fmt.Errorf("%w: %w", flyerr.ErrLeaseConflict, err)
+	}
+	return err
+}
+
 type releaseLeaseFunc func()
 
 // AcquireAllLeases works to acquire/attach a lease for each active machine.
@@ -74,7 +87,7 @@ func AcquireLease(ctx context.Context, machine *fly.Machine) (*fly.Machine, rele
 
 	lease, err := flapsClient.AcquireLease(ctx, machine.ID, fly.IntPointer(120))
 	if err != nil {
-		return nil, func() {}, fmt.Errorf("failed to obtain lease: %w", err)
+		return nil, func() {}, fmt.Errorf("failed to obtain lease: %w", leaseError(err))
 	}
 	releaseFunc := func() { releaseLease(ctx, machine) }
 
@@ -64,18 +64,19 @@ func (ms *machineSet) AcquireLeases(ctx context.Context, duration time.Duration)
 		wg.Wait()
 		close(results)
 	}()
-	hadError := false
+	var errs []error
 	for err := range results {
 		if err != nil {
-			hadError = true
+			errs = append(errs, err)
 			terminal.Warnf("failed to acquire lease: %v\n", err)
 		}
 	}
-	if hadError {
+	if len(errs) > 0 {
 		if err := ms.ReleaseLeases(ctx); err != nil {
 			terminal.Warnf("error releasing machine leases: %v\n", err)
 		}
-		return fmt.Errorf("error acquiring leases on all machines")
+		// Only leases held by other operations make this a lease conflict
+		return fmt.Errorf("error acquiring leases on all machines: %w", errors.Join(errs...))
 	}
 	return nil
 }
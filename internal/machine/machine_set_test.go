@@ -2,17 +2,26 @@ package machine
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	fly "github.com/superfly/fly-go"
+	"github.com/superfly/fly-go/flaps"
+	"github.com/superfly/flyctl/internal/flyerr"
 )
 
 var _ LeasableMachine = &mockLeasableMachine{}
 
 type mockLeasableMachine struct {
 	LeasableMachine
-	machine *fly.Machine
+	machine    *fly.Machine
+	acquireErr error
+}
+
+func (m *mockLeasableMachine) AcquireLease(context.Context, time.Duration) error {
+	return m.acquireErr
 }
 
 func (m *mockLeasableMachine) Machine() *fly.Machine {
@@ -110,3 +119,23 @@ func TestRemoveMachines(t *testing.T) {
 		})
 	}
 }
+
+func TestAcquireLeasesConflict(t *testing.T) {
+	conflict := leaseError(&flaps.FlapsError{ResponseStatusCode: http.StatusConflict})
+	require.ErrorIs(t, conflict, flyerr.ErrLeaseConflict)
+
+	ms := &machineSet{machines: []LeasableMachine{
+		&mockLeasableMachine{machine: &fly.Machine{ID: "1"}},
+		&mockLeasableMachine{machine: &fly.Machine{ID: "2"}, acquireErr: conflict},
+	}}
+	require.ErrorIs(t, ms.AcquireLeases(context.Background(), time.Minute), flyerr.ErrLeaseConflict)
+
+	// Other failures aren't reported as lease conflicts
+	unavailable := leaseError(&flaps.FlapsError{ResponseStatusCode: http.StatusServiceUnavailable})
+	ms = &machineSet{machines: []LeasableMachine{
+		&mockLeasableMachine{machine: &fly.Machine{ID: "1"}, acquireErr: unavailable},
+	}}
+	err := ms.AcquireLeases(context.Background(), time.Minute)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, flyerr.ErrLeaseConflict)
+}
@@ -5,35 +5,79 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/samber/lo"
 	fly "github.com/superfly/fly-go"
 	"github.com/superfly/fly-go/flaps"
 	"github.com/superfly/flyctl/internal/watch"
 	"github.com/superfly/flyctl/iostreams"
+	"golang.org/x/sync/errgroup"
 )
 
 func Restart(ctx context.Context, m *fly.Machine, input *fly.RestartMachineInput, nonce string) error {
+	leased := *m
+	leased.LeaseNonce = nonce
+	return RestartBatches(ctx, []*fly.Machine{&leased}, input, 1)
+}
+
+// RestartBatches restarts machines batchSize at a time. Unless input skips
+// them, the health checks of a batch have to pass before the next batch
+// restarts, so that at most batchSize machines are ever down. It stops at the
+// first batch failing to come back healthy.
+func RestartBatches(ctx context.Context, machines []*fly.Machine, input *fly.RestartMachineInput, batchSize int) error {
+	return restartBatches(ctx, machines, batchSize, func(ctx context.Context, batch []*fly.Machine) error {
+		return restartBatch(ctx, batch, input)
+	})
+}
+
+func restartBatches(ctx context.Context, machines []*fly.Machine, batchSize int, restart func(context.Context, []*fly.Machine) error) error {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	restarted := 0
+	for _, batch := range lo.Chunk(machines, batchSize) {
+		if err := restart(ctx, batch); err != nil {
+			if left := len(machines) - restarted - len(batch); left > 0 {
+				return fmt.Errorf("%w (%d machine(s) left to restart)", err, left)
+			}
+			return err
+		}
+		restarted += len(batch)
+	}
+	return nil
+}
+
+func restartBatch(ctx context.Context, batch []*fly.Machine, input *fly.RestartMachineInput) error {
 	var (
 		flapsClient = flaps.FromContext(ctx)
 		io          = iostreams.FromContext(ctx)
 		colorize    = io.ColorScheme()
 	)
 
-	fmt.Fprintf(io.Out, "Restarting machine %s\n", colorize.Bold(m.ID))
-	input.ID = m.ID
-	if err := flapsClient.Restart(ctx, *input, nonce); err != nil {
-		return fmt.Errorf("could not stop machine %s: %w", input.ID, err)
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, m := range batch {
+		m := m
+		eg.Go(func() error {
+			fmt.Fprintf(io.Out, "Restarting machine %s\n", colorize.Bold(m.ID))
+			in := *input
+			in.ID = m.ID
+			if err := flapsClient.Restart(egCtx, in, m.LeaseNonce); err != nil {
+				return fmt.Errorf("could not stop machine %s: %w", m.ID, err)
+			}
+			return WaitForStartOrStop(egCtx, &fly.Machine{ID: m.ID}, "start", time.Minute*5)
+		})
 	}
-
-	if err := WaitForStartOrStop(ctx, &fly.Machine{ID: input.ID}, "start", time.Minute*5); err != nil {
+	if err := eg.Wait(); err != nil {
 		return err
 	}
 
 	if !input.SkipHealthChecks {
-		if err := watch.MachinesChecks(ctx, []*fly.Machine{m}); err != nil {
+		if err := watch.MachinesChecks(ctx, batch); err != nil {
 			return fmt.Errorf("failed to wait for health checks to pass: %w", err)
 		}
 	}
-	fmt.Fprintf(io.Out, "Machine %s restarted successfully!\n", colorize.Bold(m.ID))
-
+	for _, m := range batch {
+		fmt.Fprintf(io.Out, "Machine %s restarted successfully!\n", colorize.Bold(m.ID))
+	}
 	return nil
 }
@@ -0,0 +1,73 @@
+package machine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestRestartBatches(t *testing.T) {
+	machines := []*fly.Machine{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}, {ID: "5"}}
+
+	testcases := []struct {
+		name      string
+		batchSize int
+		failAt    int
+		expect    [][]string
+		expectErr string
+	}{
+		{
+			name:      "one at a time",
+			batchSize: 1,
+			expect:    [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+		},
+		{
+			name:      "unset batch size",
+			batchSize: 0,
+			expect:    [][]string{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+		},
+		{
+			name:      "batches",
+			batchSize: 2,
+			expect:    [][]string{{"1", "2"}, {"3", "4"}, {"5"}},
+		},
+		{
+			name:      "stops at failing batch",
+			batchSize: 2,
+			failAt:    2,
+			expect:    [][]string{{"1", "2"}, {"3", "4"}},
+			expectErr: "unhealthy (1 machine(s) left to restart)",
+		},
+		{
+			name:      "failing last batch",
+			batchSize: 2,
+			failAt:    3,
+			expect:    [][]string{{"1", "2"}, {"3", "4"}, {"5"}},
+			expectErr: "unhealthy",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var restarted [][]string
+			err := restartBatches(context.Background(), machines, tc.batchSize, func(_ context.Context, batch []*fly.Machine) error {
+				restarted = append(restarted, lo.Map(batch, func(m *fly.Machine, _ int) string { return m.ID }))
+				if len(restarted) == tc.failAt {
+					return errors.New("unhealthy")
+				}
+				return nil
+			})
+			if tc.expectErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectErr)
+			}
+			assert.Equal(t, tc.expect, restarted)
+		})
+	}
+}
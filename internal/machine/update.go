@@ -28,53 +28,9 @@ func Update(ctx context.Context, m *fly.Machine, input *fly.LaunchMachineInput)
 	)
 
 	if input != nil && input.Config != nil && input.Config.Guest != nil {
-		var invalidConfigErr InvalidConfigErr
-		invalidConfigErr.guest = input.Config.Guest
-		// Check that there's a valid number of CPUs
-		validNumCpus, ok := cpusPerKind[input.Config.Guest.CPUKind]
-		if !ok {
-			invalidConfigErr.Reason = invalidCpuKind
-			return invalidConfigErr
-		} else if !slices.Contains(validNumCpus, input.Config.Guest.CPUs) {
-			invalidConfigErr.Reason = invalidNumCPUs
-			return invalidConfigErr
+		if err := ValidateGuest(input.Config.Guest); err != nil {
+			return err
 		}
-
-		if input.Config.Guest.CPUKind == "shared" && input.Config.Guest.MemoryMB%256 != 0 {
-			invalidConfigErr.Reason = invalidMemorySize
-			return invalidConfigErr
-		} else if input.Config.Guest.CPUKind == "performance" && input.Config.Guest.MemoryMB%1024 != 0 {
-			invalidConfigErr.Reason = invalidMemorySize
-			return invalidConfigErr
-		}
-
-		// Check memory sizes
-		var min_memory_size int
-
-		if input.Config.Guest.CPUKind == "shared" {
-			min_memory_size = fly.MIN_MEMORY_MB_PER_SHARED_CPU * input.Config.Guest.CPUs
-		} else if input.Config.Guest.CPUKind == "performance" {
-			min_memory_size = fly.MIN_MEMORY_MB_PER_CPU * input.Config.Guest.CPUs
-		}
-
-		if min_memory_size > input.Config.Guest.MemoryMB {
-			invalidConfigErr.Reason = memoryTooLow
-			return invalidConfigErr
-		}
-
-		var maxMemory int
-
-		if input.Config.Guest.CPUKind == "shared" {
-			maxMemory = input.Config.Guest.CPUs * fly.MAX_MEMORY_MB_PER_SHARED_CPU
-		} else if input.Config.Guest.CPUKind == "performance" {
-			maxMemory = input.Config.Guest.CPUs * fly.MAX_MEMORY_MB_PER_CPU
-		}
-
-		if input.Config.Guest.MemoryMB > maxMemory {
-			invalidConfigErr.Reason = memoryTooHigh
-			return invalidConfigErr
-		}
-
 	}
 
 	fmt.Fprintf(io.Out, "Updating machine %s\n", colorize.Bold(m.ID))
@@ -112,6 +68,59 @@ func Update(ctx context.Context, m *fly.Machine, input *fly.LaunchMachineInput)
 	return nil
 }
 
+// ValidateGuest checks that guest describes a valid combination of CPU kind,
+// CPU count and memory. The returned error is an InvalidConfigErr.
+func ValidateGuest(guest *fly.MachineGuest) error {
+	var invalidConfigErr InvalidConfigErr
+	invalidConfigErr.guest = guest
+	// Check that there's a valid number of CPUs
+	validNumCpus, ok := cpusPerKind[guest.CPUKind]
+	if !ok {
+		invalidConfigErr.Reason = invalidCpuKind
+		return invalidConfigErr
+	} else if !slices.Contains(validNumCpus, guest.CPUs) {
+		invalidConfigErr.Reason = invalidNumCPUs
+		return invalidConfigErr
+	}
+
+	if guest.CPUKind == "shared" && guest.MemoryMB%256 != 0 {
+		invalidConfigErr.Reason = invalidMemorySize
+		return invalidConfigErr
+	} else if guest.CPUKind == "performance" && guest.MemoryMB%1024 != 0 {
+		invalidConfigErr.Reason = invalidMemorySize
+		return invalidConfigErr
+	}
+
+	// Check memory sizes
+	var min_memory_size int
+
+	if guest.CPUKind == "shared" {
+		min_memory_size = fly.MIN_MEMORY_MB_PER_SHARED_CPU * guest.CPUs
+	} else if guest.CPUKind == "performance" {
+		min_memory_size = fly.MIN_MEMORY_MB_PER_CPU * guest.CPUs
+	}
+
+	if min_memory_size > guest.MemoryMB {
+		invalidConfigErr.Reason = memoryTooLow
+		return invalidConfigErr
+	}
+
+	var maxMemory int
+
+	if guest.CPUKind == "shared" {
+		maxMemory = guest.CPUs * fly.MAX_MEMORY_MB_PER_SHARED_CPU
+	} else if guest.CPUKind == "performance" {
+		maxMemory = guest.CPUs * fly.MAX_MEMORY_MB_PER_CPU
+	}
+
+	if guest.MemoryMB > maxMemory {
+		invalidConfigErr.Reason = memoryTooHigh
+		return invalidConfigErr
+	}
+
+	return nil
+}
+
 type invalidConfigReason string
 
 const (
@@ -29,6 +29,11 @@ func rawSend(parentCtx context.Context, metricSlug string, payload json.RawMessa
 		Payload: payload,
 	}
 
+	if isOptedOut(parentCtx, metricSlug) {
+		withheld = append(withheld, message)
+		return
+	}
+
 	queueMetric(message)
 }
 
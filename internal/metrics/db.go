@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/rehttp"
+	"github.com/samber/lo"
 	"github.com/superfly/flyctl/agent"
 	"github.com/superfly/flyctl/internal/buildinfo"
 	"github.com/superfly/flyctl/internal/config"
@@ -20,12 +21,19 @@ import (
 
 var metrics []metricsMessage = make([]metricsMessage, 0)
 
+// withheld holds the metrics which weren't queued because the user opted out
+// of their category, so they can be journaled.
+var withheld []metricsMessage
+
 func queueMetric(metric metricsMessage) {
 	metrics = append(metrics, metric)
 }
 
 // Spawns a forked `flyctl metrics send` process that sends metrics to the flyctl-metrics server
 func FlushMetrics(ctx context.Context) error {
+	record(ctx, StatusOptedOut, withheld)
+	withheld = nil
+
 	if len(metrics) == 0 {
 		// Don't bother sending an empty request if there are no metrics to flush
 		// This is important to prevent leaking requests when analytics is disabled
@@ -78,14 +86,14 @@ func FlushMetrics(ctx context.Context) error {
 }
 
 // / Spens up to 15 seconds sending all metrics collected so far to flyctl-metrics post endpoint
-func SendMetrics(ctx context.Context, json string) error {
+func SendMetrics(ctx context.Context, jsonData string) error {
 	authToken, err := GetMetricsToken(ctx)
 	if err != nil {
 		return err
 	}
 
 	cfg := config.FromContext(ctx)
-	request, err := http.NewRequest("POST", cfg.MetricsBaseURL+"/metrics_post", bytes.NewBuffer([]byte(json)))
+	request, err := http.NewRequest("POST", cfg.MetricsBaseURL+"/metrics_post", bytes.NewBuffer([]byte(jsonData)))
 	if err != nil {
 		return err
 	}
@@ -100,10 +108,15 @@ func SendMetrics(ctx context.Context, json string) error {
 		Timeout:   time.Second * 5,
 	}
 
+	var messages []metricsMessage
+	_ = json.Unmarshal([]byte(jsonData), &messages)
+
 	resp, err := client.Do(request)
 	if err != nil {
+		record(ctx, StatusFailed, messages)
 		return err
 	}
 
+	record(ctx, lo.Ternary(resp.StatusCode < 300, StatusSent, StatusFailed), messages)
 	return resp.Body.Close()
 }
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// JournalFileName denotes the name of the file, inside the config directory,
+// which records the metrics flyctl sent or withheld recently.
+const JournalFileName = "telemetry.jsonl"
+
+// journalSize is the number of entries the journal keeps.
+const journalSize = 500
+
+// Statuses of journal entries.
+const (
+	StatusSent     = "sent"
+	StatusFailed   = "failed"
+	StatusOptedOut = "opted out"
+)
+
+// Category groups related metrics, so they can be opted out of together.
+type Category struct {
+	Name        string
+	Description string
+	prefixes    []string
+}
+
+// Categories lists the categories of metrics flyctl sends.
+var Categories = []Category{
+	{
+		Name:        "usage",
+		Description: "Which commands run, on which OS, how long they take, how many API calls they make and whether they fail",
+		prefixes:    []string{"runs/", "command/"},
+	},
+	{
+		Name:        "launch",
+		Description: "Outcome of fly launch: duration, region, VM size and which databases and scanners were involved",
+		prefixes:    []string{"launch/"},
+	},
+	{
+		Name:        "deploy",
+		Description: "Success and duration of deployments, image pushes and machine updates",
+		prefixes:    []string{"deploy_", "machine_", "image_push/"},
+	},
+	{
+		Name:        "build",
+		Description: "Success and duration of remote builds, and remote builder failures",
+		prefixes:    []string{"remote_build"},
+	},
+}
+
+// CategoryOf returns the name of the category metricSlug belongs to, or
+// "other" when it belongs to none.
+func CategoryOf(metricSlug string) string {
+	for _, c := range Categories {
+		for _, prefix := range c.prefixes {
+			if strings.HasPrefix(metricSlug, prefix) {
+				return c.Name
+			}
+		}
+	}
+	return "other"
+}
+
+// IsCategory reports whether name is the name of a category.
+func IsCategory(name string) bool {
+	return name == "other" || slices.ContainsFunc(Categories, func(c Category) bool { return c.Name == name })
+}
+
+func isOptedOut(ctx context.Context, metricSlug string) bool {
+	return slices.Contains(config.FromContext(ctx).TelemetryOptOuts, CategoryOf(metricSlug))
+}
+
+// JournalEntry is a metric flyctl sent, failed to send or withheld.
+type JournalEntry struct {
+	Time     time.Time       `json:"time"`
+	Metric   string          `json:"metric"`
+	Category string          `json:"category"`
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	Status   string          `json:"status"`
+}
+
+// JournalPath returns the path of the journal for the config directory of
+// ctx.
+func JournalPath(ctx context.Context) string {
+	return filepath.Join(state.ConfigDirectory(ctx), JournalFileName)
+}
+
+// record appends an entry per message to the journal, keeping the most
+// recent journalSize ones. Failures are only logged, since the journal must
+// never get in the way of commands.
+func record(ctx context.Context, status string, messages []metricsMessage) {
+	if len(messages) == 0 {
+		return
+	}
+
+	path := JournalPath(ctx)
+	entries, err := ReadJournal(path)
+	if err != nil {
+		terminal.Debugf("Metrics: failed reading journal: %v", err)
+	}
+
+	now := time.Now().UTC()
+	for _, m := range messages {
+		entries = append(entries, JournalEntry{
+			Time:     now,
+			Metric:   m.Metric,
+			Category: CategoryOf(m.Metric),
+			Payload:  m.Payload,
+			Status:   status,
+		})
+	}
+
+	if err := writeJournal(path, entries); err != nil {
+		terminal.Debugf("Metrics: failed writing journal: %v", err)
+	}
+}
+
+// ReadJournal returns the entries of the journal at path, oldest first.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var e JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func writeJournal(path string, entries []JournalEntry) error {
+	if len(entries) > journalSize {
+		entries = entries[len(entries)-journalSize:]
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+func TestCategoryOf(t *testing.T) {
+	assert.Equal(t, "usage", CategoryOf("command/duration"))
+	assert.Equal(t, "launch", CategoryOf("launch/started"))
+	assert.Equal(t, "deploy", CategoryOf("machine_update/duration"))
+	assert.Equal(t, "build", CategoryOf("remote_builder_failure"))
+	assert.Equal(t, "other", CategoryOf("something/else"))
+
+	assert.True(t, IsCategory("deploy"))
+	assert.True(t, IsCategory("other"))
+	assert.False(t, IsCategory("nope"))
+}
+
+func TestIsOptedOut(t *testing.T) {
+	ctx := config.NewContext(context.Background(), &config.Config{TelemetryOptOuts: []string{"launch"}})
+
+	assert.True(t, isOptedOut(ctx, "launch/started"))
+	assert.False(t, isOptedOut(ctx, "command/duration"))
+}
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	ctx := state.WithConfigDirectory(context.Background(), dir)
+	path := JournalPath(ctx)
+
+	entries, err := ReadJournal(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	record(ctx, StatusSent, []metricsMessage{
+		{Metric: "command/duration", Payload: json.RawMessage(`{"seconds":1}`)},
+	})
+	record(ctx, StatusOptedOut, []metricsMessage{{Metric: "launch/started"}})
+
+	entries, err = ReadJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "command/duration", entries[0].Metric)
+	assert.Equal(t, "usage", entries[0].Category)
+	assert.Equal(t, StatusSent, entries[0].Status)
+	assert.JSONEq(t, `{"seconds":1}`, string(entries[0].Payload))
+
+	assert.Equal(t, "launch", entries[1].Category)
+	assert.Equal(t, StatusOptedOut, entries[1].Status)
+
+	_, err = os.Stat(filepath.Join(dir, JournalFileName+".tmp"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestJournalKeepsRecentEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+
+	var entries []JournalEntry
+	for i := 0; i < journalSize+10; i++ {
+		entries = append(entries, JournalEntry{Metric: fmt.Sprintf("m%d", i), Status: StatusSent})
+	}
+	require.NoError(t, writeJournal(path, entries))
+
+	read, err := ReadJournal(path)
+	require.NoError(t, err)
+	require.Len(t, read, journalSize)
+	assert.Equal(t, "m10", read[0].Metric)
+	assert.Equal(t, fmt.Sprintf("m%d", journalSize+9), read[len(read)-1].Metric)
+}
+
+func TestReadJournalSkipsCorruptLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), JournalFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"metric":"a","status":"sent"}
+not json
+{"metric":"b","status":"failed"}
+`), 0o600))
+
+	entries, err := ReadJournal(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Metric)
+	assert.Equal(t, StatusFailed, entries[1].Status)
+}
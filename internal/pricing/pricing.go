@@ -0,0 +1,128 @@
+// Package pricing implements estimating the cost of running machines.
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/gql"
+)
+
+// AdditionalMemoryPerGBMonth is the monthly price, in USD, of each GB of memory
+// a machine has on top of the memory included with its preset.
+//
+// See https://fly.io/docs/about/pricing/#compute
+const AdditionalMemoryPerGBMonth = 5.0
+
+// Prices holds the price of each machine size preset.
+type Prices struct {
+	// sizes maps preset names to their monthly price in USD and the memory
+	// included in that price.
+	sizes map[string]sizePrice
+}
+
+type sizePrice struct {
+	month    float64
+	memoryMB int
+}
+
+// Fetch retrieves the current machine prices from the API.
+func Fetch(ctx context.Context) (*Prices, error) {
+	client := fly.ClientFromContext(ctx).GenqClient
+
+	resp, err := gql.GetPlatformVMSizes(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching machine prices: %w", err)
+	}
+
+	p := &Prices{sizes: make(map[string]sizePrice, len(resp.Platform.VmSizes))}
+	for _, s := range resp.Platform.VmSizes {
+		p.sizes[s.Name] = sizePrice{month: s.PriceMonth, memoryMB: s.MemoryMb}
+	}
+	return p, nil
+}
+
+// New returns Prices for the given presets. It's mostly useful for tests.
+func New(monthly map[string]float64) *Prices {
+	p := &Prices{sizes: make(map[string]sizePrice, len(monthly))}
+	for name, price := range monthly {
+		memoryMB := 0
+		if preset, ok := fly.MachinePresets[name]; ok {
+			memoryMB = preset.MemoryMB
+		}
+		p.sizes[name] = sizePrice{month: price, memoryMB: memoryMB}
+	}
+	return p
+}
+
+// MachineMonthly returns the estimated monthly price, in USD, of a machine
+// with the given guest running full time. The boolean result is false when no
+// price is known for the guest's size.
+func (p *Prices) MachineMonthly(guest *fly.MachineGuest) (float64, bool) {
+	if p == nil || guest == nil {
+		return 0, false
+	}
+
+	size, ok := p.sizes[guest.ToSize()]
+	if !ok {
+		return 0, false
+	}
+
+	price := size.month
+	// GPU presets come with a single GPU, each additional one costs the same
+	if guest.GPUKind != "" && guest.GPUs > 1 {
+		price *= float64(guest.GPUs)
+	}
+	if extra := guest.MemoryMB - size.memoryMB; extra > 0 {
+		price += float64(extra) / 1024 * AdditionalMemoryPerGBMonth
+	}
+	return price, true
+}
+
+// MachinesMonthly returns the estimated monthly price, in USD, of running a
+// machine with each of the given guests full time. The boolean result is
+// false when the price of any of the guests isn't known.
+func (p *Prices) MachinesMonthly(guests []*fly.MachineGuest) (float64, bool) {
+	total := 0.0
+	for _, guest := range guests {
+		price, ok := p.MachineMonthly(guest)
+		if !ok {
+			return 0, false
+		}
+		total += price
+	}
+	return total, true
+}
+
+// Format formats a USD amount for display.
+func Format(usd float64) string {
+	if usd < 0 {
+		return fmt.Sprintf("-$%.2f", -usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// FormatDelta formats a USD difference for display, always including a sign.
+func FormatDelta(usd float64) string {
+	if usd < 0 {
+		return Format(usd)
+	}
+	return "+" + Format(usd)
+}
+
+// FormatSizes returns the distinct sizes of guests, including memory, sorted.
+// Nil guests are skipped.
+func FormatSizes(guests []*fly.MachineGuest) []string {
+	sizes := lo.Uniq(lo.FilterMap(guests, func(g *fly.MachineGuest, _ int) (string, bool) {
+		if g == nil {
+			return "", false
+		}
+		return fmt.Sprintf("%s/%dMB", g.ToSize(), g.MemoryMB), true
+	}))
+	slices.Sort(sizes)
+	return sizes
+}
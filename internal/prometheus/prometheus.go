@@ -0,0 +1,190 @@
+// Package prometheus implements a minimal client for the Prometheus-compatible
+// query API Fly.io exposes for each organization's metrics.
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+)
+
+// Client queries the metrics of a single organization.
+type Client struct {
+	baseURL    string
+	authHeader string
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the metrics of the organization identified
+// by orgSlug, authenticated with the credentials ctx carries.
+func NewClient(ctx context.Context, orgSlug string) *Client {
+	cfg := config.FromContext(ctx)
+
+	return &Client{
+		baseURL:    fmt.Sprintf("%s/prometheus/%s", strings.TrimSuffix(cfg.APIBaseURL, "/"), url.PathEscape(orgSlug)),
+		authHeader: fly.AuthorizationHeader(cfg.Tokens.GraphQL()),
+		httpClient: &http.Client{Timeout: time.Minute},
+	}
+}
+
+// Sample is a single value of an instant vector.
+type Sample struct {
+	Metric map[string]string `json:"metric"`
+	Time   time.Time         `json:"time"`
+	Value  float64           `json:"value"`
+}
+
+// Point is a single value of a range vector.
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// Series is a range vector.
+type Series struct {
+	Metric map[string]string `json:"metric"`
+	Points []Point           `json:"points"`
+}
+
+// Query evaluates an instant query at the given time. A zero time evaluates
+// the query at the current time.
+func (c *Client) Query(ctx context.Context, query string, at time.Time) ([]Sample, error) {
+	params := url.Values{"query": {query}}
+	if !at.IsZero() {
+		params.Set("time", formatTime(at))
+	}
+
+	var data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []json.RawMessage `json:"value"`
+		} `json:"result"`
+	}
+	if err := c.do(ctx, "query", params, &data); err != nil {
+		return nil, err
+	}
+	if data.ResultType != "vector" {
+		return nil, fmt.Errorf("unsupported result type %q, expected an instant vector", data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(data.Result))
+	for _, r := range data.Result {
+		p, err := parsePoint(r.Value)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, Sample{Metric: r.Metric, Time: p.Time, Value: p.Value})
+	}
+	return samples, nil
+}
+
+// QueryRange evaluates a query over a range of time.
+func (c *Client) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]Series, error) {
+	params := url.Values{
+		"query": {query},
+		"start": {formatTime(start)},
+		"end":   {formatTime(end)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	}
+
+	var data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string   `json:"metric"`
+			Values [][]json.RawMessage `json:"values"`
+		} `json:"result"`
+	}
+	if err := c.do(ctx, "query_range", params, &data); err != nil {
+		return nil, err
+	}
+	if data.ResultType != "matrix" {
+		return nil, fmt.Errorf("unsupported result type %q, expected a range vector", data.ResultType)
+	}
+
+	series := make([]Series, 0, len(data.Result))
+	for _, r := range data.Result {
+		s := Series{Metric: r.Metric, Points: make([]Point, 0, len(r.Values))}
+		for _, v := range r.Values {
+			p, err := parsePoint(v)
+			if err != nil {
+				return nil, err
+			}
+			s.Points = append(s.Points, p)
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+func (c *Client) do(ctx context.Context, endpoint string, params url.Values, data any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/api/v1/%s", c.baseURL, endpoint),
+		strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", c.authHeader)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed querying metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed reading metrics response: %w", err)
+	}
+
+	var envelope struct {
+		Status    string          `json:"status"`
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+		Error     string          `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("failed querying metrics: unexpected response (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("failed querying metrics: %s: %s", envelope.ErrorType, envelope.Error)
+	}
+
+	return json.Unmarshal(envelope.Data, data)
+}
+
+// parsePoint parses a [<unix time>, "<value>"] pair.
+func parsePoint(raw []json.RawMessage) (p Point, err error) {
+	if len(raw) != 2 {
+		return p, fmt.Errorf("malformed sample: expected a [time, value] pair")
+	}
+
+	var ts float64
+	if err = json.Unmarshal(raw[0], &ts); err != nil {
+		return p, fmt.Errorf("malformed sample time: %w", err)
+	}
+	var value string
+	if err = json.Unmarshal(raw[1], &value); err != nil {
+		return p, fmt.Errorf("malformed sample value: %w", err)
+	}
+
+	p.Time = time.UnixMilli(int64(ts * 1000)).UTC()
+	if p.Value, err = strconv.ParseFloat(value, 64); err != nil {
+		return p, fmt.Errorf("malformed sample value: %w", err)
+	}
+	return p, nil
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', -1, 64)
+}
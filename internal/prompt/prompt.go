@@ -110,6 +110,25 @@ func Password(ctx context.Context, dst *string, msg string, required bool) error
 	return survey.AskOne(p, dst, opts...)
 }
 
+// Editor opens the user's editor on def, a file with the given extension, and
+// stores the edited contents in dst.
+func Editor(ctx context.Context, dst *string, msg, def, ext string) error {
+	opt, err := newSurveyIO(ctx)
+	if err != nil {
+		return err
+	}
+
+	p := &survey.Editor{
+		Message:       msg,
+		Default:       def,
+		AppendDefault: true,
+		HideDefault:   true,
+		FileName:      "*" + ext,
+	}
+
+	return survey.AskOne(p, dst, opt)
+}
+
 func MultiSelect(ctx context.Context, indices *[]int, msg string, def []int, options ...string) error {
 	opt, err := newSurveyIO(ctx)
 	if err != nil {
@@ -0,0 +1,148 @@
+// Package readcache caches the results of read commands, so they can be
+// served without querying the API when they're fresh enough, when the API
+// can't be reached, or when running offline.
+package readcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/format"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// DirName denotes the name of the directory, inside the config directory,
+// results are cached in.
+const DirName = "results"
+
+type entry struct {
+	Time  time.Time       `json:"time"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Get returns the result of fetch, caching it under key. The cached result
+// is returned instead of calling fetch when it is fresher than the cache
+// TTL, when running offline, or when fetch fails to reach the API, unless
+// fetch marked its error with Partial.
+func Get[T any](ctx context.Context, key string, fetch func(context.Context) (T, error)) (T, error) {
+	var (
+		cfg  = config.FromContext(ctx)
+		path = entryPath(ctx, key)
+		v    T
+	)
+
+	cached, found := read(path)
+
+	switch {
+	case cfg.Offline:
+		if !found {
+			return v, fmt.Errorf("no result of %s was cached yet, run it once online to cache it", key)
+		}
+		warn(ctx, "Offline: showing the state as of "+format.RelativeTime(cached.Time))
+		return decode[T](cached)
+	case found && !cfg.NoReadCache && cfg.CacheTTL > 0 && time.Since(cached.Time) <= cfg.CacheTTL:
+		logger.FromContext(ctx).Debugf("using the result of %s cached %s", key, format.RelativeTime(cached.Time))
+		return decode[T](cached)
+	}
+
+	v, err := fetch(ctx)
+	if err != nil {
+		if found && isUnreachable(err) && !errors.As(err, new(partialError)) {
+			warn(ctx, fmt.Sprintf("Could not reach the API (%v), showing the state as of %s", err, format.RelativeTime(cached.Time)))
+			return decode[T](cached)
+		}
+		return v, err
+	}
+
+	if err := write(path, v); err != nil {
+		logger.FromContext(ctx).Debugf("failed caching the result of %s: %v", key, err)
+	}
+	return v, nil
+}
+
+type partialError struct {
+	error
+}
+
+func (e partialError) Unwrap() error {
+	return e.error
+}
+
+// Partial marks err as happening after fetch already showed part of its
+// result, so Get returns err instead of showing the cached result on top.
+func Partial(err error) error {
+	if err == nil {
+		return nil
+	}
+	return partialError{err}
+}
+
+// entryPath returns the path of the entry of key. Entries are kept apart per
+// token, since different tokens may see different resources.
+func entryPath(ctx context.Context, key string) string {
+	sum := sha256.Sum256([]byte(config.Tokens(ctx).GraphQL()))
+	return filepath.Join(state.ConfigDirectory(ctx), DirName, hex.EncodeToString(sum[:8]), url.PathEscape(key)+".json")
+}
+
+func read(path string) (entry, bool) {
+	var e entry
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return e, false
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return e, false
+	}
+	return e, true
+}
+
+func write(path string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry{Time: time.Now(), Value: value})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func decode[T any](e entry) (T, error) {
+	var v T
+	if err := json.Unmarshal(e.Value, &v); err != nil {
+		return v, fmt.Errorf("failed decoding cached result: %w", err)
+	}
+	return v, nil
+}
+
+// isUnreachable reports whether err is a failure to reach the API, as opposed
+// to an error the API responded with.
+func isUnreachable(err error) bool {
+	var (
+		netErr net.Error
+		urlErr *url.Error
+	)
+	return errors.As(err, &netErr) || errors.As(err, &urlErr)
+}
+
+func warn(ctx context.Context, msg string) {
+	io := iostreams.FromContext(ctx)
+	fmt.Fprintln(io.ErrOut, io.ColorScheme().Yellow(msg))
+}
@@ -0,0 +1,123 @@
+package readcache
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/superfly/fly-go/tokens"
+
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/logger"
+	"github.com/superfly/flyctl/internal/state"
+	"github.com/superfly/flyctl/iostreams"
+)
+
+type thing struct {
+	Name string
+}
+
+func newContext(t *testing.T, cfg *config.Config) (context.Context, *strings.Builder) {
+	t.Helper()
+
+	cfg.Tokens = tokens.Parse("FlyV1 fm2_test")
+
+	var errOut strings.Builder
+	ctx := context.Background()
+	ctx = state.WithConfigDirectory(ctx, t.TempDir())
+	ctx = config.NewContext(ctx, cfg)
+	ctx = logger.NewContext(ctx, logger.New(&errOut, logger.Info, false))
+	ctx = iostreams.NewContext(ctx, &iostreams.IOStreams{Out: &strings.Builder{}, ErrOut: &errOut})
+	return ctx, &errOut
+}
+
+func fetched(name string, err error) (func(context.Context) ([]thing, error), *int) {
+	calls := 0
+	return func(context.Context) ([]thing, error) {
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return []thing{{Name: name}}, nil
+	}, &calls
+}
+
+func TestGet(t *testing.T) {
+	cfg := &config.Config{}
+	ctx, errOut := newContext(t, cfg)
+
+	fetch, calls := fetched("a", nil)
+	v, err := Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []thing{{Name: "a"}}, v)
+
+	// Without a TTL, results are fetched every time.
+	_, err = Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+
+	// With a TTL, the cached result is used.
+	cfg.CacheTTL = time.Minute
+	fetch, calls = fetched("b", nil)
+	v, err = Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []thing{{Name: "a"}}, v)
+	assert.Equal(t, 0, *calls)
+
+	// Unless asked not to.
+	cfg.NoReadCache = true
+	v, err = Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []thing{{Name: "b"}}, v)
+	assert.Empty(t, errOut.String())
+}
+
+func TestGetOffline(t *testing.T) {
+	cfg := &config.Config{}
+	ctx, errOut := newContext(t, cfg)
+
+	fetch, _ := fetched("a", nil)
+	_, err := Get(ctx, "things", fetch)
+	require.NoError(t, err)
+
+	cfg.Offline = true
+	fetch, calls := fetched("b", nil)
+	v, err := Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []thing{{Name: "a"}}, v)
+	assert.Equal(t, 0, *calls)
+	assert.Contains(t, errOut.String(), "Offline: showing the state as of")
+
+	_, err = Get(ctx, "other things", fetch)
+	assert.ErrorContains(t, err, "no result of other things was cached yet")
+}
+
+func TestGetFallsBackWhenUnreachable(t *testing.T) {
+	ctx, errOut := newContext(t, &config.Config{})
+
+	fetch, _ := fetched("a", nil)
+	_, err := Get(ctx, "things", fetch)
+	require.NoError(t, err)
+
+	unreachable := &url.Error{Op: "Post", URL: "https://api.fly.io/graphql", Err: errors.New("connection refused")}
+	fetch, _ = fetched("", unreachable)
+	v, err := Get(ctx, "things", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, []thing{{Name: "a"}}, v)
+	assert.Contains(t, errOut.String(), "Could not reach the API")
+
+	// Nor when fetch already showed part of its result.
+	fetch, _ = fetched("", Partial(unreachable))
+	_, err = Get(ctx, "things", fetch)
+	assert.ErrorIs(t, err, unreachable)
+
+	// Errors the API responds with are not hidden.
+	fetch, _ = fetched("", errors.New("app not found"))
+	_, err = Get(ctx, "things", fetch)
+	assert.EqualError(t, err, "app not found")
+}
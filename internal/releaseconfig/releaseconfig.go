@@ -0,0 +1,85 @@
+// Package releaseconfig renders the machine configs of a release from the
+// app config and image the platform keeps with it, so that they can be
+// inspected after fly.toml has moved on, from any machine.
+package releaseconfig
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+)
+
+// Machine is the config of a machine still running a release.
+type Machine struct {
+	ID           string             `json:"id"`
+	ProcessGroup string             `json:"process_group"`
+	Region       string             `json:"region"`
+	Config       *fly.MachineConfig `json:"config"`
+}
+
+// Group is the machine config a release renders for a process group.
+type Group struct {
+	ProcessGroup string             `json:"process_group"`
+	Config       *fly.MachineConfig `json:"config"`
+}
+
+// Release is the machine configs of a release.
+type Release struct {
+	App       string    `json:"app"`
+	Version   int       `json:"version"`
+	ReleaseID string    `json:"release_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Image     string    `json:"image"`
+	// Groups are rendered from the release's fly.toml, without what's been
+	// changed on the machines since, such as by fly scale.
+	Groups []Group `json:"groups"`
+	// Machines are the machines which still run the release.
+	Machines []Machine `json:"machines"`
+}
+
+// Render returns the machine config of each process group of cfg, the app
+// config of the release version of image.
+func Render(cfg *appconfig.Config, version int, image string) ([]Group, error) {
+	var groups []Group
+	for _, name := range cfg.ProcessNames() {
+		mc, err := cfg.ToMachineConfig(name, nil)
+		if err != nil {
+			return nil, err
+		}
+		mc.Image = image
+		if mc.Metadata == nil {
+			mc.Metadata = map[string]string{}
+		}
+		mc.Metadata[fly.MachineConfigMetadataKeyFlyReleaseVersion] = strconv.Itoa(version)
+		groups = append(groups, Group{ProcessGroup: name, Config: mc})
+	}
+	return groups, nil
+}
+
+// RunningMachines returns the machines whose metadata says they run the
+// release version.
+func RunningMachines(version int, machines []*fly.Machine) []Machine {
+	var running []Machine
+	for _, m := range machines {
+		if m.Config == nil || m.Config.Metadata[fly.MachineConfigMetadataKeyFlyReleaseVersion] != strconv.Itoa(version) {
+			continue
+		}
+		running = append(running, Machine{
+			ID:           m.ID,
+			ProcessGroup: m.ProcessGroup(),
+			Region:       m.Region,
+			Config:       m.Config,
+		})
+	}
+
+	sort.Slice(running, func(i, j int) bool {
+		if running[i].ProcessGroup != running[j].ProcessGroup {
+			return running[i].ProcessGroup < running[j].ProcessGroup
+		}
+		return running[i].ID < running[j].ID
+	})
+	return running
+}
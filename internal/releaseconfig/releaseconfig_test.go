@@ -0,0 +1,67 @@
+package releaseconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/appconfig"
+)
+
+func machineOfRelease(id, group, version string) *fly.Machine {
+	return &fly.Machine{
+		ID:     id,
+		Region: "ord",
+		Config: &fly.MachineConfig{
+			Image: "registry.fly.io/app:" + version,
+			Metadata: map[string]string{
+				fly.MachineConfigMetadataKeyFlyProcessGroup:   group,
+				fly.MachineConfigMetadataKeyFlyReleaseVersion: version,
+				fly.MachineConfigMetadataKeyFlyReleaseId:      "rel-" + version,
+			},
+		},
+	}
+}
+
+func TestRunningMachines(t *testing.T) {
+	machines := RunningMachines(41, []*fly.Machine{
+		machineOfRelease("m3", "web", "41"),
+		machineOfRelease("m2", "app", "41"),
+		machineOfRelease("m1", "app", "40"),
+		{ID: "m0"},
+	})
+
+	require.Len(t, machines, 2)
+	assert.Equal(t, "m2", machines[0].ID)
+	assert.Equal(t, "app", machines[0].ProcessGroup)
+	assert.Equal(t, "m3", machines[1].ID)
+	assert.Equal(t, "registry.fly.io/app:41", machines[1].Config.Image)
+}
+
+func TestRender(t *testing.T) {
+	cfg, err := appconfig.FromDefinition(&fly.Definition{
+		"app": "app",
+		"processes": map[string]any{
+			"web":    "bin/web",
+			"worker": "bin/worker",
+		},
+		"env": map[string]any{"MODE": "production"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.SetMachinesPlatform())
+
+	groups, err := Render(cfg, 41, "registry.fly.io/app:deployment-41")
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	for i, name := range []string{"web", "worker"} {
+		g := groups[i]
+		assert.Equal(t, name, g.ProcessGroup)
+		assert.Equal(t, "registry.fly.io/app:deployment-41", g.Config.Image)
+		assert.Equal(t, "41", g.Config.Metadata[fly.MachineConfigMetadataKeyFlyReleaseVersion])
+		assert.Equal(t, name, g.Config.Metadata[fly.MachineConfigMetadataKeyFlyProcessGroup])
+		assert.Equal(t, "production", g.Config.Env["MODE"])
+	}
+	assert.Equal(t, []string{"bin/web"}, groups[0].Config.Init.Cmd)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/logrusorgru/aurora"
 
+	"github.com/superfly/flyctl/iostreams"
 	"github.com/superfly/flyctl/logs"
 
 	"github.com/superfly/flyctl/internal/format"
@@ -70,11 +71,11 @@ func LogEntry(w io.Writer, entry logs.LogEntry, opts ...LogOption) (err error) {
 	}
 
 	if !options.HideRegion {
-		fmt.Fprintf(w, "%s ", aurora.Green(entry.Region))
+		fmt.Fprintf(w, "%s ", iostreams.Aurora().Green(entry.Region))
 	}
 
 	var buf bytes.Buffer
-	fmt.Fprintf(&buf, "%s ", aurora.Faint(format.Time(ts)))
+	fmt.Fprintf(&buf, "%s ", iostreams.Aurora().Faint(format.Time(ts)))
 
 	if entry.Meta.Event.Provider != "" {
 		if entry.Instance != "" {
@@ -86,7 +87,7 @@ func LogEntry(w io.Writer, entry logs.LogEntry, opts ...LogOption) (err error) {
 		fmt.Fprintf(&buf, "%s", entry.Instance)
 	}
 
-	fmt.Fprintf(&buf, " %s [%s]", aurora.Green(entry.Region), aurora.Colorize(entry.Level, levelColor(entry.Level)))
+	fmt.Fprintf(&buf, " %s [%s]", iostreams.Aurora().Green(entry.Region), iostreams.Aurora().Colorize(entry.Level, levelColor(entry.Level)))
 
 	printFieldIfPresent(&buf, "error.code", entry.Meta.Error.Code)
 	hadErrorMsg := printFieldIfPresent(w, "error.message", entry.Meta.Error.Message)
@@ -109,13 +110,13 @@ func printFieldIfPresent(w io.Writer, name string, value interface{}) (present b
 	switch v := value.(type) {
 	case string:
 		if v != "" {
-			fmt.Fprintf(w, `%s"%s" `, aurora.Faint(name+"="), v)
+			fmt.Fprintf(w, `%s"%s" `, iostreams.Aurora().Faint(name+"="), v)
 
 			present = true
 		}
 	case int:
 		if v > 0 {
-			fmt.Fprintf(w, "%s%d ", aurora.Faint(name+"="), v)
+			fmt.Fprintf(w, "%s%d ", iostreams.Aurora().Faint(name+"="), v)
 
 			present = true
 		}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/logrusorgru/aurora"
 	"github.com/morikuni/aec"
 	"github.com/olekukonko/tablewriter"
 	"github.com/superfly/flyctl/iostreams"
@@ -28,9 +27,19 @@ func TitledJSON(w io.Writer, title string, v interface{}) error {
 // cols are optional.
 func Table(w io.Writer, title string, rows [][]string, cols ...string) error {
 	if title != "" {
-		fmt.Fprintln(w, aurora.Bold(title))
+		fmt.Fprintln(w, iostreams.Aurora().Bold(title))
 	}
 
+	table := newTable(w, cols...)
+	table.AppendBulk(rows)
+	table.Render()
+
+	fmt.Fprintln(w)
+
+	return nil
+}
+
+func newTable(w io.Writer, cols ...string) *tablewriter.Table {
 	table := tablewriter.NewWriter(w)
 
 	if len(cols) > 0 {
@@ -47,18 +56,53 @@ func Table(w io.Writer, title string, rows [][]string, cols ...string) error {
 	table.SetNoWhiteSpace(true)
 	table.SetTablePadding("\t")
 
-	table.AppendBulk(rows)
+	return table
+}
 
-	table.Render()
+// TableStream renders a table in batches, as their rows become available,
+// instead of all at once. Columns are aligned per batch.
+type TableStream struct {
+	w       io.Writer
+	cols    []string
+	started bool
+}
 
-	fmt.Fprintln(w)
+// NewTableStream returns a TableStream writing to w. The optional cols are
+// rendered as the header of the first batch.
+func NewTableStream(w io.Writer, cols ...string) *TableStream {
+	return &TableStream{w: w, cols: cols}
+}
 
-	return nil
+// Append renders rows. Empty batches are skipped.
+func (ts *TableStream) Append(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+
+	var table *tablewriter.Table
+	if ts.started {
+		table = newTable(ts.w)
+	} else {
+		table = newTable(ts.w, ts.cols...)
+	}
+	ts.started = true
+
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// Close terminates the table the way Table does. It reports whether any
+// rows were rendered.
+func (ts *TableStream) Close() bool {
+	if ts.started {
+		fmt.Fprintln(ts.w)
+	}
+	return ts.started
 }
 
 func VerticalTable(w io.Writer, title string, objects [][]string, cols ...string) error {
 	if title != "" {
-		fmt.Fprintln(w, aurora.Bold(title))
+		fmt.Fprintln(w, iostreams.Aurora().Bold(title))
 	}
 
 	table := tablewriter.NewWriter(w)
@@ -83,7 +127,7 @@ func VerticalTable(w io.Writer, title string, objects [][]string, cols ...string
 
 func ReusableTable(w io.Writer, title string, rows [][]string, cols ...string) (err error) {
 	if title != "" {
-		fmt.Fprintln(w, aurora.Bold(title))
+		fmt.Fprintln(w, iostreams.Aurora().Bold(title))
 	}
 
 	table := tablewriter.NewWriter(w)
@@ -11,7 +11,7 @@ func Create(ctx context.Context, numLines int, showStatusChar bool) StatusLogger
 
 	logNumbers := numLines > 1
 	io := iostreams.FromContext(ctx)
-	if io.IsInteractive() {
+	if io.IsInteractive() && io.ProgressMode() == iostreams.ProgressAuto {
 
 		sl := &interactiveLogger{
 			lines:      make([]*interactiveLine, numLines),
@@ -1,8 +1,10 @@
 package statuslogger
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/superfly/flyctl/iostreams"
 )
@@ -28,6 +30,16 @@ type noninteractiveLine struct {
 }
 
 func (line *noninteractiveLine) Log(s string) {
+	switch line.logger.io.ProgressMode() {
+	case iostreams.ProgressJSON:
+		line.logJSON(s)
+		return
+	case iostreams.ProgressQuiet:
+		if line.status != StatusFailure {
+			return
+		}
+	}
+
 	buf := ""
 	if line.logger.showStatus {
 		buf += line.status.charFor(-1) + " "
@@ -39,6 +51,27 @@ func (line *noninteractiveLine) Log(s string) {
 	fmt.Fprintln(line.logger.io.Out, buf)
 }
 
+type lineEvent struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Line    int       `json:"line"`
+	Lines   int       `json:"lines"`
+	Status  string    `json:"status,omitempty"`
+	Message string    `json:"message"`
+}
+
+// logJSON writes s to stderr as a single JSON status event.
+func (line *noninteractiveLine) logJSON(s string) {
+	_ = json.NewEncoder(line.logger.io.ErrOut).Encode(lineEvent{
+		Type:    "status",
+		Time:    time.Now().UTC(),
+		Line:    line.lineNum + 1,
+		Lines:   len(line.logger.lines),
+		Status:  line.status.String(),
+		Message: s,
+	})
+}
+
 func (line *noninteractiveLine) Logf(format string, args ...interface{}) {
 	line.Log(fmt.Sprintf(format, args...))
 }
@@ -38,6 +38,20 @@ func (status Status) charFor(frame int) string {
 	}
 }
 
+// String returns the name of the status, as used in JSON progress events.
+func (status Status) String() string {
+	switch status {
+	case StatusRunning:
+		return "running"
+	case StatusSuccess:
+		return "success"
+	case StatusFailure:
+		return "failure"
+	default:
+		return ""
+	}
+}
+
 func formatIndex(n, total int) string {
 	pad := 0
 	for i := total; i != 0; i /= 10 {
@@ -0,0 +1,145 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/superfly/flyctl/iostreams"
+)
+
+// binaryName is the name of the flyctl binary inside release archives.
+const binaryName = "flyctl"
+
+// resolveRelease returns the release install target denotes: a version,
+// "pre" for the latest prerelease or empty for the latest release.
+func resolveRelease(ctx context.Context, target string) (*Release, error) {
+	switch target {
+	case "":
+		return fetchRelease(ctx, translateChannelForRails("stable"))
+	case "pre":
+		return fetchRelease(ctx, translateChannelForRails(target))
+	default:
+		return ReleaseForVersion(ctx, target)
+	}
+}
+
+// installArchive replaces the current binary with the one of release, after
+// verifying its archive against the published checksums and signature.
+func installArchive(ctx context.Context, io *iostreams.IOStreams, release *Release, silent bool) error {
+	published, err := fetchPkgsRelease(ctx, release.Version)
+	if err != nil {
+		return err
+	}
+	sums, err := fetchChecksums(ctx, published)
+	if err != nil {
+		return err
+	}
+
+	binPath, err := GetCurrentBinaryPath()
+	if err != nil {
+		return err
+	}
+
+	binary, err := downloadBinary(ctx, io, release, sums, silent)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceBinary(binPath, binary); err != nil {
+		return err
+	}
+
+	// like the install script, link fly to flyctl
+	link := filepath.Join(filepath.Dir(binPath), "fly")
+	if _, err := os.Lstat(link); errors.Is(err, fs.ErrNotExist) {
+		return os.Symlink(binPath, link)
+	}
+	return nil
+}
+
+// downloadBinary downloads the archive of release, verifies it against sums
+// and returns the binary it contains.
+func downloadBinary(ctx context.Context, io *iostreams.IOStreams, release *Release, sums []byte, silent bool) ([]byte, error) {
+	if !silent {
+		fmt.Fprintf(io.ErrOut, "Downloading flyctl %s from %s\n", release.Version, release.DownloadURL)
+	}
+
+	archive, err := download(ctx, release.DownloadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed downloading flyctl %s: %w", release.Version, err)
+	}
+
+	if err := verifyChecksum(sums, path.Base(release.DownloadURL), archive); err != nil {
+		return nil, err
+	}
+	if !silent {
+		fmt.Fprintln(io.ErrOut, "Verified checksum"+signatureNote())
+	}
+
+	binary, err := extractBinary(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed extracting flyctl %s: %w", release.Version, err)
+	}
+	return binary, nil
+}
+
+func signatureNote() string {
+	if MinisignPublicKey == "" {
+		return ""
+	}
+	return " and signature"
+}
+
+// extractBinary returns the flyctl binary a .tar.gz release archive holds.
+func extractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("no %s binary in the archive", binaryName)
+		} else if err != nil {
+			return nil, err
+		}
+
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == binaryName {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceBinary atomically replaces the binary at path with binary.
+func replaceBinary(path string, binary []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+binaryName+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,197 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/superfly/flyctl/internal/version"
+	"github.com/superfly/flyctl/terminal"
+)
+
+// Channels lists the release channels flyctl can be subscribed to.
+var Channels = []string{"stable", "pre", "nightly"}
+
+// ValidateChannel reports an error when channel isn't one of Channels.
+func ValidateChannel(channel string) error {
+	switch NormalizeChannel(channel) {
+	case "stable", "latest", "pre", "prerelease", "nightly":
+		return nil
+	default:
+		return fmt.Errorf("invalid channel %q, must be one of %s", channel, strings.Join(Channels, ", "))
+	}
+}
+
+// Pin constrains the versions flyctl may update to. Its fields are the
+// leading components of a version; missing ones match anything.
+type Pin struct {
+	parts []int
+	raw   string
+}
+
+// ParsePin parses pins such as 0.2.x, 0.2 or 0.2.25.
+func ParsePin(s string) (Pin, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	pin := Pin{raw: s}
+	for i, part := range strings.Split(s, ".") {
+		if part == "x" || part == "*" {
+			if i == 0 {
+				return Pin{}, fmt.Errorf("invalid version %q, pin at least a major version", s)
+			}
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || i > 2 {
+			return Pin{}, fmt.Errorf("invalid version %q, expected something like 0.2.x or 0.2.25", s)
+		}
+		pin.parts = append(pin.parts, n)
+	}
+	return pin, nil
+}
+
+func (p Pin) String() string {
+	return p.raw
+}
+
+// Exact reports whether p names a single version.
+func (p Pin) Exact() bool {
+	return len(p.parts) == 3
+}
+
+// Matches reports whether v satisfies p. Prereleases only match exact pins.
+func (p Pin) Matches(v version.Version) bool {
+	fields := []int{v.Major, v.Minor, v.Patch}
+	for i, n := range p.parts {
+		if fields[i] != n {
+			return false
+		}
+	}
+	return p.Exact() || v.Channel == "" || v.Channel == "stable"
+}
+
+// ReleaseForVersion returns the release of flyctl tagged v.
+func ReleaseForVersion(ctx context.Context, v string) (*Release, error) {
+	return fetchRelease(ctx, strings.TrimPrefix(v, "v"))
+}
+
+// LatestReleaseMatching returns the newest release of flyctl satisfying pin
+// which wasn't yanked.
+func LatestReleaseMatching(ctx context.Context, pin Pin) (*Release, error) {
+	if pin.Exact() {
+		release, err := ReleaseForVersion(ctx, pin.String())
+		if err == nil && release.Yanked {
+			return nil, yankedError(release)
+		}
+		return release, err
+	}
+
+	versions, err := fetchMatchingVersions(ctx, pin)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range versions {
+		release, err := ReleaseForVersion(ctx, v.String())
+		if err != nil {
+			return nil, err
+		}
+		if release.Yanked {
+			terminal.Debugf("skipping %s: %v", v, yankedError(release))
+			continue
+		}
+		return release, nil
+	}
+
+	return nil, fmt.Errorf("no release of flyctl matches %s", pin)
+}
+
+// fetchMatchingVersions returns the versions of the published releases which
+// satisfy pin, newest first. Releases are listed newest first, so pages are
+// read until one has no match after some did, or until the last page.
+func fetchMatchingVersions(ctx context.Context, pin Pin) ([]version.Version, error) {
+	var releases []githubRelease
+	for page, found := 1, false; ; page++ {
+		batch, err := fetchGithubReleasesPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		releases = append(releases, batch...)
+
+		matched := len(matchingVersions(batch, pin)) > 0
+		if found && !matched {
+			break
+		}
+		found = found || matched
+	}
+
+	return matchingVersions(releases, pin), nil
+}
+
+// matchingVersions returns the versions of the published releases which
+// satisfy pin, newest first.
+func matchingVersions(releases []githubRelease, pin Pin) []version.Version {
+	var versions []version.Version
+	for _, r := range releases {
+		if r.Draft || r.Prerelease {
+			continue
+		}
+		v, err := version.Parse(r.TagName)
+		if err != nil || !pin.Matches(v) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Newer(versions[j])
+	})
+	return versions
+}
+
+func yankedError(release *Release) error {
+	if release.YankReason == "" {
+		return fmt.Errorf("flyctl %s was yanked", release.Version)
+	}
+	return fmt.Errorf("flyctl %s was yanked: %s", release.Version, release.YankReason)
+}
+
+func fetchRelease(ctx context.Context, channelOrVersion string) (*Release, error) {
+	updateUrl := fmt.Sprintf("https://api.fly.io/app/flyctl_releases/%s/%s/%s", runtime.GOOS, runtime.GOARCH, channelOrVersion)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", updateUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			terminal.Debugf("error closing response body: %s", err)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no release of flyctl %s was found", channelOrVersion)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return &release, err
+	}
+
+	return &release, nil
+}
@@ -0,0 +1,119 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/version"
+)
+
+func TestPin(t *testing.T) {
+	parse := func(s string) version.Version {
+		v, err := version.Parse(s)
+		require.NoError(t, err)
+		return v
+	}
+
+	pin, err := ParsePin("0.2.x")
+	require.NoError(t, err)
+	assert.False(t, pin.Exact())
+	assert.True(t, pin.Matches(parse("0.2.25")))
+	assert.True(t, pin.Matches(parse("0.2.0")))
+	assert.False(t, pin.Matches(parse("0.3.0")))
+	assert.False(t, pin.Matches(parse("0.2.26-pre-1")))
+
+	pin, err = ParsePin("v0.2")
+	require.NoError(t, err)
+	assert.Equal(t, "0.2", pin.String())
+	assert.True(t, pin.Matches(parse("0.2.3")))
+
+	pin, err = ParsePin("0.2.25")
+	require.NoError(t, err)
+	assert.True(t, pin.Exact())
+	assert.True(t, pin.Matches(parse("0.2.25")))
+	assert.False(t, pin.Matches(parse("0.2.24")))
+
+	for _, invalid := range []string{"x", "0.two", "0.2.3.4", ""} {
+		_, err := ParsePin(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestValidateChannel(t *testing.T) {
+	for _, channel := range []string{"stable", "pre", "nightly", "Nightly"} {
+		assert.NoError(t, ValidateChannel(channel))
+	}
+	assert.Error(t, ValidateChannel("beta"))
+}
+
+func TestMatchingVersions(t *testing.T) {
+	pin, err := ParsePin("0.2")
+	require.NoError(t, err)
+
+	versions := matchingVersions([]githubRelease{
+		{TagName: "v0.2.3"},
+		{TagName: "v0.3.0"},
+		{TagName: "v0.2.10"},
+		{TagName: "v0.2.11", Draft: true},
+		{TagName: "v0.2.12-pre-1", Prerelease: true},
+		{TagName: "v0.2.4"},
+	}, pin)
+
+	var tags []string
+	for _, v := range versions {
+		tags = append(tags, v.String())
+	}
+	assert.Equal(t, []string{"0.2.10", "0.2.4", "0.2.3"}, tags)
+}
+
+func TestYankedError(t *testing.T) {
+	assert.EqualError(t, yankedError(&Release{Version: "0.2.3"}), "flyctl 0.2.3 was yanked")
+	assert.EqualError(t, yankedError(&Release{Version: "0.2.3", YankReason: "broken deploys"}), "flyctl 0.2.3 was yanked: broken deploys")
+}
+
+func TestFetchMatchingVersions(t *testing.T) {
+	// one release per page, newest first
+	tags := []string{"v0.3.1", "v0.3.0", "v0.2.9", "v0.2.8", "v0.1.9", "v0.2.7"}
+	var pages []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+		pages = append(pages, page)
+
+		var releases []githubRelease
+		if page <= len(tags) {
+			releases = append(releases, githubRelease{TagName: tags[page-1]})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(releases))
+	}))
+	defer srv.Close()
+
+	defer func(url string) { githubReleasesURL = url }(githubReleasesURL)
+	githubReleasesURL = srv.URL
+
+	pin, err := ParsePin("0.2.x")
+	require.NoError(t, err)
+
+	versions, err := fetchMatchingVersions(context.Background(), pin)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, "0.2.9", versions[0].String())
+	assert.Equal(t, "0.2.8", versions[1].String())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, pages)
+
+	pages = nil
+	pin, err = ParsePin("0.4.x")
+	require.NoError(t, err)
+
+	versions, err = fetchMatchingVersions(context.Background(), pin)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7}, pages)
+}
@@ -0,0 +1,84 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/superfly/flyctl/terminal"
+)
+
+// pkgsURL is the API of pkgs.fly.io, which the release workflow uploads
+// releases to along with their checksums and signatures.
+var pkgsURL = "https://flyio-pkgs.fly.dev/api"
+
+var (
+	// errUnpublished is returned for releases pkgs.fly.io doesn't have.
+	errUnpublished = errors.New("the release wasn't published to pkgs.fly.io")
+
+	// errNoChecksums is returned when no checksums were published for a
+	// release, as is the case for releases made before they were.
+	errNoChecksums = errors.New("no checksums were published for the release")
+)
+
+// pkgsRelease is a release of flyctl as pkgs.fly.io describes it.
+type pkgsRelease struct {
+	Version      string          `json:"version"`
+	ChecksumsURL string          `json:"checksums_url"`
+	Signatures   []pkgsSignature `json:"signatures"`
+	Notes        string          `json:"notes"`
+	Assets       []Asset         `json:"assets"`
+}
+
+type pkgsSignature struct {
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
+}
+
+// signatureURL returns the URL of the signature of the checksums of r made
+// with kind, or an empty string when there's none.
+func (r *pkgsRelease) signatureURL(kind string) string {
+	for _, s := range r.Signatures {
+		if s.Kind == kind {
+			return s.URL
+		}
+	}
+	return ""
+}
+
+// fetchPkgsRelease returns the release of flyctl v from pkgs.fly.io.
+func fetchPkgsRelease(ctx context.Context, v string) (*pkgsRelease, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pkgsURL+"/releases/version/"+url.PathEscape(v), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			terminal.Debugf("error closing response body: %s", err)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errUnpublished
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed fetching flyctl %s from pkgs.fly.io: %s", v, resp.Status)
+	}
+
+	var body struct {
+		Data pkgsRelease `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return &body.Data, nil
+}
@@ -0,0 +1,214 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/superfly/flyctl/terminal"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReleaseInfo describes a published release of flyctl.
+type ReleaseInfo struct {
+	Version     string    `json:"version"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Notes       string    `json:"notes,omitempty"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Asset is the archive of a release for one platform.
+type Asset struct {
+	Name   string `json:"name"`
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+	URL    string `json:"url"`
+}
+
+// AssetFor returns the asset of r for the platform os/arch, named like Go
+// names them, or nil when there's none.
+func (r ReleaseInfo) AssetFor(os, arch string) *Asset {
+	for i, a := range r.Assets {
+		if a.OS == os && a.Arch == arch {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// ListReleases returns the limit latest published releases of flyctl, or
+// all of them when limit is zero, newest first. Prereleases are only
+// included when prerelease is set. Releases are listed from GitHub, with the
+// notes and assets uploaded to pkgs.fly.io when they were.
+func ListReleases(ctx context.Context, prerelease bool, limit int) ([]ReleaseInfo, error) {
+	var infos []ReleaseInfo
+	for page := 1; limit <= 0 || len(infos) < limit; page++ {
+		releases, err := fetchGithubReleasesPage(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if len(releases) == 0 {
+			break
+		}
+
+		for _, r := range releases {
+			if r.Draft || (r.Prerelease && !prerelease) {
+				continue
+			}
+			infos = append(infos, r.info())
+		}
+	}
+	if limit > 0 && len(infos) > limit {
+		infos = infos[:limit]
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(4)
+	for i := range infos {
+		info := &infos[i]
+		eg.Go(func() error {
+			published, err := fetchPkgsRelease(ctx, info.Version)
+			if errors.Is(err, errUnpublished) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			info.merge(published)
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// merge replaces the notes and assets of info with those published to
+// pkgs.fly.io, when there are any.
+func (info *ReleaseInfo) merge(published *pkgsRelease) {
+	if published.Notes != "" {
+		info.Notes = strings.TrimSpace(published.Notes)
+	}
+	if len(published.Assets) > 0 {
+		info.Assets = published.Assets
+	}
+}
+
+type githubRelease struct {
+	TagName     string        `json:"tag_name"`
+	Draft       bool          `json:"draft"`
+	Prerelease  bool          `json:"prerelease"`
+	Body        string        `json:"body"`
+	PublishedAt time.Time     `json:"published_at"`
+	Assets      []githubAsset `json:"assets"`
+}
+
+type githubAsset struct {
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	Digest             string `json:"digest"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+func (r githubRelease) info() ReleaseInfo {
+	info := ReleaseInfo{
+		Version:     strings.TrimPrefix(r.TagName, "v"),
+		Prerelease:  r.Prerelease,
+		PublishedAt: r.PublishedAt,
+		Notes:       strings.TrimSpace(r.Body),
+		Assets:      []Asset{},
+	}
+
+	for _, a := range r.Assets {
+		os, arch, ok := assetPlatform(a.Name)
+		if !ok {
+			continue
+		}
+		info.Assets = append(info.Assets, Asset{
+			Name:   a.Name,
+			OS:     os,
+			Arch:   arch,
+			Size:   a.Size,
+			SHA256: strings.TrimPrefix(a.Digest, "sha256:"),
+			URL:    a.BrowserDownloadURL,
+		})
+	}
+
+	return info
+}
+
+// assetPlatform returns the platform of the release archive called name,
+// such as flyctl_0.2.1_macOS_arm64.tar.gz, as Go names them.
+func assetPlatform(name string) (os, arch string, ok bool) {
+	name, ok = strings.CutSuffix(name, ".tar.gz")
+	if !ok {
+		if name, ok = strings.CutSuffix(name, ".zip"); !ok {
+			return "", "", false
+		}
+	}
+
+	// the architecture may hold an underscore, as in x86_64
+	parts := strings.SplitN(name, "_", 4)
+	if len(parts) < 4 || parts[0] != binaryName {
+		return "", "", false
+	}
+	os, arch = parts[2], parts[3]
+
+	switch os {
+	case "macOS":
+		os = "darwin"
+	case "Linux":
+		os = "linux"
+	case "Windows":
+		os = "windows"
+	}
+	if arch == "x86_64" {
+		arch = "amd64"
+	}
+
+	return os, arch, true
+}
+
+// githubReleasesURL lists the releases of flyctl on GitHub, newest first.
+var githubReleasesURL = "https://api.github.com/repos/superfly/flyctl/releases"
+
+// fetchGithubReleasesPage returns the page-th page of 100 releases, which is
+// empty past the last one.
+func fetchGithubReleasesPage(ctx context.Context, page int) ([]githubRelease, error) {
+	url := fmt.Sprintf("%s?per_page=100&page=%d", githubReleasesURL, page)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			terminal.Debugf("error closing response body: %s", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed listing releases: %s", resp.Status)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
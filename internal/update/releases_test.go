@@ -0,0 +1,102 @@
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetPlatform(t *testing.T) {
+	for name, want := range map[string][2]string{
+		"flyctl_0.2.1_macOS_arm64.tar.gz":       {"darwin", "arm64"},
+		"flyctl_0.2.1_Linux_x86_64.tar.gz":      {"linux", "amd64"},
+		"flyctl_0.2.1_Windows_x86_64.zip":       {"windows", "amd64"},
+		"flyctl_0.2.1-pre-3_Linux_arm64.tar.gz": {"linux", "arm64"},
+	} {
+		os, arch, ok := assetPlatform(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, want, [2]string{os, arch}, name)
+	}
+
+	for _, name := range []string{"checksums.txt", "flyctl_0.2.1_Linux_x86_64.from-0.2.0.delta", "other_0.2.1_Linux_x86_64.tar.gz"} {
+		_, _, ok := assetPlatform(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestGithubReleaseInfo(t *testing.T) {
+	info := githubRelease{
+		TagName: "v0.2.1",
+		Body:    "\n* Fixed things\n",
+		Assets: []githubAsset{
+			{Name: "checksums.txt", Size: 10},
+			{Name: "flyctl_0.2.1_Linux_x86_64.tar.gz", Size: 100, Digest: "sha256:abc", BrowserDownloadURL: "https://example.com/a.tar.gz"},
+		},
+	}.info()
+
+	assert.Equal(t, "0.2.1", info.Version)
+	assert.Equal(t, "* Fixed things", info.Notes)
+	assert.Len(t, info.Assets, 1)
+
+	asset := info.AssetFor("linux", "amd64")
+	if assert.NotNil(t, asset) {
+		assert.Equal(t, int64(100), asset.Size)
+		assert.Equal(t, "abc", asset.SHA256)
+	}
+	assert.Nil(t, info.AssetFor("darwin", "arm64"))
+}
+
+func TestListReleases(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		// one release per page, newest first
+		pages := [][]githubRelease{
+			{{TagName: "v0.2.2", Prerelease: true}},
+			{{TagName: "v0.2.1", Body: "github notes"}},
+			{{TagName: "v0.2.0", Body: "old notes"}},
+		}
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		require.NoError(t, err)
+
+		releases := []githubRelease{}
+		if page <= len(pages) {
+			releases = pages[page-1]
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(releases))
+	})
+	mux.HandleFunc("/api/releases/version/0.2.1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":200,"data":{"version":"0.2.1","notes":"pkgs notes","assets":[{"name":"flyctl_0.2.1_Linux_x86_64.tar.gz","os":"linux","arch":"amd64","size":100,"url":"https://pkgs.example/a.tar.gz"}]}}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	defer func(github, pkgs string) { githubReleasesURL, pkgsURL = github, pkgs }(githubReleasesURL, pkgsURL)
+	githubReleasesURL = srv.URL + "/releases"
+	pkgsURL = srv.URL + "/api"
+
+	releases, err := ListReleases(context.Background(), false, 0)
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+
+	assert.Equal(t, "0.2.1", releases[0].Version)
+	assert.Equal(t, "pkgs notes", releases[0].Notes)
+	if asset := releases[0].AssetFor("linux", "amd64"); assert.NotNil(t, asset) {
+		assert.Equal(t, "https://pkgs.example/a.tar.gz", asset.URL)
+	}
+
+	// releases pkgs.fly.io doesn't have keep what GitHub has
+	assert.Equal(t, "0.2.0", releases[1].Version)
+	assert.Equal(t, "old notes", releases[1].Notes)
+
+	releases, err = ListReleases(context.Background(), true, 1)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "0.2.2", releases[0].Version)
+}
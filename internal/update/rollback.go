@@ -0,0 +1,146 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/superfly/flyctl/helpers"
+	"github.com/superfly/flyctl/internal/buildinfo"
+)
+
+// previousDir returns the directory the binary replaced by the last upgrade
+// is kept in.
+func previousDir() (string, error) {
+	dir, err := helpers.GetConfigDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "previous"), nil
+}
+
+// PreviousVersion returns the version of the binary the last upgrade
+// replaced, which Rollback restores.
+func PreviousVersion() (string, error) {
+	dir, err := previousDir()
+	if err != nil {
+		return "", err
+	}
+	return readPreviousVersion(dir)
+}
+
+func readPreviousVersion(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "version"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", errors.New("no previous version of flyctl is kept; one is kept once flyctl upgrades itself")
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// backupCurrentBinary copies the running binary to previousDir.
+func backupCurrentBinary() error {
+	binPath, err := GetCurrentBinaryPath()
+	if err != nil {
+		return err
+	}
+
+	dir, err := previousDir()
+	if err != nil {
+		return err
+	}
+	return backupBinary(binPath, dir)
+}
+
+// backupBinary copies the binary at binPath, of the running version, to dir.
+func backupBinary(binPath, dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	if err := copyFile(binPath, filepath.Join(dir, filepath.Base(binPath))); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "version"), []byte(buildinfo.Version().String()), 0o600)
+}
+
+// Rollback replaces the current binary with the one the last upgrade
+// replaced, and keeps the current one so the rollback can be undone. It
+// returns the version it rolled back to.
+func Rollback() (string, error) {
+	if IsUnderHomebrew() {
+		return "", errors.New("homebrew installations can't be rolled back, run brew install flyctl@<version> instead")
+	}
+
+	binPath, err := GetCurrentBinaryPath()
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := previousDir()
+	if err != nil {
+		return "", err
+	}
+
+	return rollback(binPath, dir)
+}
+
+// rollback swaps the binary at binPath with the one kept in dir.
+func rollback(binPath, dir string) (string, error) {
+	previous, err := readPreviousVersion(dir)
+	if err != nil {
+		return "", err
+	}
+
+	// stage the previous binary next to the current one, so it can be
+	// renamed over it
+	staged := binPath + ".rollback"
+	if err := copyFile(filepath.Join(dir, filepath.Base(binPath)), staged); err != nil {
+		return "", fmt.Errorf("failed staging flyctl v%s: %w", previous, err)
+	}
+
+	if err := backupBinary(binPath, dir); err != nil {
+		_ = os.Remove(staged)
+		return "", fmt.Errorf("failed keeping the current binary: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		// running binaries can't be replaced on windows, but can be moved
+		_ = os.Remove(binPath + ".old")
+		if err := os.Rename(binPath, binPath+".old"); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.Rename(staged, binPath); err != nil {
+		return "", fmt.Errorf("failed replacing %s: %w", binPath, err)
+	}
+	return previous, nil
+}
+
+func copyFile(src, dst string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := out.Close(); err == nil {
+			err = e
+		}
+	}()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -0,0 +1,44 @@
+package update
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/buildinfo"
+)
+
+func TestRollback(t *testing.T) {
+	binDir, dir := t.TempDir(), t.TempDir()
+	binPath := filepath.Join(binDir, "flyctl")
+
+	_, err := rollback(binPath, dir)
+	require.ErrorContains(t, err, "no previous version of flyctl is kept")
+
+	require.NoError(t, os.WriteFile(binPath, []byte("current"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "flyctl"), []byte("previous"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "version"), []byte("0.2.1\n"), 0o600))
+
+	previous, err := rollback(binPath, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "0.2.1", previous)
+
+	data, err := os.ReadFile(binPath)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(data))
+
+	// the replaced binary is kept, so the rollback can be undone
+	data, err = os.ReadFile(filepath.Join(dir, "flyctl"))
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(data))
+
+	version, err := readPreviousVersion(dir)
+	require.NoError(t, err)
+	assert.Equal(t, buildinfo.Version().String(), version)
+
+	_, err = os.Stat(binPath + ".rollback")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
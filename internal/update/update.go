@@ -30,6 +30,8 @@ type Release struct {
 	Prerelease  bool      `yaml:"prerelease"`
 	DownloadURL string    `yaml:"download_url" json:"download_url"`
 	Timestamp   time.Time `yaml:"timestamp"`
+	Yanked      bool      `yaml:"yanked"`
+	YankReason  string    `yaml:"yank_reason" json:"yank_reason"`
 }
 
 // Check reports whether update checks should take place.
@@ -130,31 +132,7 @@ func LatestRelease(ctx context.Context, channel string) (*Release, error) {
 		return latestHomebrewRelease(ctx, channel)
 	}
 
-	updateUrl := fmt.Sprintf("https://api.fly.io/app/flyctl_releases/%s/%s/%s", runtime.GOOS, runtime.GOARCH, channel)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", updateUrl, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Accept", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		err := resp.Body.Close()
-		if err != nil {
-			terminal.Debugf("error closing response body: %s", err)
-		}
-	}()
-
-	var release Release
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return &release, err
-	}
-
-	return &release, nil
+	return fetchRelease(ctx, channel)
 }
 
 func latestHomebrewRelease(ctx context.Context, channel string) (*Release, error) {
@@ -242,27 +220,66 @@ func IsUnderHomebrew() bool {
 	return val
 }
 
-func upgradeCommand(prerelease bool) string {
+// installCommand returns the command installing target, which is either a
+// version, "pre" for the latest prerelease or empty for the latest release.
+func installCommand(target string) string {
 	if IsUnderHomebrew() {
 		return "brew upgrade flyctl"
 	}
 
 	if runtime.GOOS == "windows" {
 		cmd := "iwr https://fly.io/install.ps1 -useb | iex"
-		if prerelease {
-			cmd = "$v=\"pre\"; " + cmd
+		if target != "" {
+			cmd = fmt.Sprintf("$v=\"%s\"; ", target) + cmd
 		}
 		return cmd
 	} else {
 		cmd := "curl -L \"https://fly.io/install.sh\" | sh"
-		if prerelease {
-			cmd = cmd + " -s pre"
+		if target != "" {
+			cmd = cmd + " -s " + target
 		}
 		return cmd
 	}
 }
 
 func UpgradeInPlace(ctx context.Context, io *iostreams.IOStreams, prelease, silent bool) error {
+	target := ""
+	if prelease {
+		target = "pre"
+	}
+	return install(ctx, io, target, silent)
+}
+
+// InstallVersion replaces this installation of flyctl with version v.
+func InstallVersion(ctx context.Context, io *iostreams.IOStreams, v string, silent bool) error {
+	if IsUnderHomebrew() {
+		return errors.New("homebrew installations can only be upgraded to the latest release, run brew upgrade flyctl")
+	}
+	return install(ctx, io, strings.TrimPrefix(v, "v"), silent)
+}
+
+func install(ctx context.Context, io *iostreams.IOStreams, target string, silent bool) error {
+	if !IsUnderHomebrew() {
+		// keep the binary around, so the upgrade can be rolled back
+		if err := backupCurrentBinary(); err != nil {
+			terminal.Debugf("error backing up the current binary: %s", err)
+		}
+	}
+
+	// installations made by the install script are updated the way it
+	// would, after verifying the release against its published checksums.
+	// Releases without any fall back to the install script.
+	if !IsUnderHomebrew() && runtime.GOOS != "windows" && CanUpdateThisInstallation() {
+		release, err := resolveRelease(ctx, target)
+		if err == nil && release.DownloadURL != "" {
+			err = installArchive(ctx, io, release, silent)
+			if !errors.Is(err, errNoChecksums) && !errors.Is(err, errUnpublished) {
+				return err
+			}
+		}
+		terminal.Debugf("falling back to the install script: %v", err)
+	}
+
 	if runtime.GOOS == "windows" {
 		if err := renameCurrentBinaries(); err != nil {
 			return err
@@ -304,7 +321,7 @@ func UpgradeInPlace(ctx context.Context, io *iostreams.IOStreams, prelease, sile
 		}
 	}
 
-	command := upgradeCommand(prelease)
+	command := installCommand(target)
 	cmd := exec.Command(shellToUse, switchToUse, command)
 
 	if !silent {
@@ -496,6 +513,8 @@ func translateChannelForRails(channel string) string {
 	switch channel {
 	case "pre", "prerelease":
 		return "pre"
+	case "nightly":
+		return "nightly"
 	default:
 		return "latest"
 	}
@@ -0,0 +1,173 @@
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// checksumsFileName is the name of the file listing the SHA256 checksums of
+// the archives of a release.
+const checksumsFileName = "SHA256SUMS"
+
+// MinisignPublicKey is the minisign public key the checksums of releases are
+// verified with, in the base64 form minisign prints. Release builds set it
+// with -ldflags -X, and the release workflow fails without it. Builds without
+// it only verify the checksums of releases that publish no signature.
+var MinisignPublicKey = ""
+
+// VerificationError is returned when a downloaded release doesn't match its
+// checksums or signature.
+type VerificationError struct {
+	Name   string
+	Reason string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("failed verifying %s: %s", e.Name, e.Reason)
+}
+
+// fetchChecksums fetches the checksums published for release, verifying
+// they are signed by MinisignPublicKey. A published signature that can't be
+// verified, for lack of a key, fails verification rather than being skipped.
+func fetchChecksums(ctx context.Context, release *pkgsRelease) ([]byte, error) {
+	if release.ChecksumsURL == "" {
+		return nil, errNoChecksums
+	}
+
+	sums, err := download(ctx, release.ChecksumsURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching checksums: %w", err)
+	}
+
+	sigURL := release.signatureURL("minisign")
+	switch {
+	case MinisignPublicKey == "" && sigURL != "":
+		return nil, &VerificationError{Name: checksumsFileName, Reason: "it is signed, but this build of flyctl has no key to verify the signature; reinstall flyctl with https://fly.io/install.sh"}
+	case MinisignPublicKey != "":
+		if sigURL == "" {
+			return nil, &VerificationError{Name: checksumsFileName, Reason: "no minisign signature was published for it"}
+		}
+		sig, err := download(ctx, sigURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching checksums signature: %w", err)
+		}
+		if err := verifyMinisign(MinisignPublicKey, sums, sig); err != nil {
+			return nil, &VerificationError{Name: checksumsFileName, Reason: err.Error()}
+		}
+	}
+
+	return sums, nil
+}
+
+// verifyChecksum verifies that data, the content of the file called name,
+// matches the checksum sums lists for it.
+func verifyChecksum(sums []byte, name string, data []byte) error {
+	want, ok := parseChecksums(sums)[name]
+	if !ok {
+		return &VerificationError{Name: name, Reason: "no checksum was published for it"}
+	}
+
+	got := sha256.Sum256(data)
+	if hex.EncodeToString(got[:]) != want {
+		return &VerificationError{Name: name, Reason: "checksum mismatch"}
+	}
+	return nil
+}
+
+// parseChecksums parses a file in the format sha256sum writes into a map of
+// file names to checksums.
+func parseChecksums(data []byte) map[string]string {
+	sums := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// verifyMinisign verifies the minisign signature sig of msg against the
+// base64 encoded public key.
+func verifyMinisign(publicKey string, msg, sig []byte) error {
+	key, err := base64.StdEncoding.DecodeString(lastLine(publicKey))
+	if err != nil || len(key) != 2+8+ed25519.PublicKeySize || string(key[:2]) != "Ed" {
+		return errors.New("invalid public key")
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(sig)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("malformed signature")
+	}
+
+	s, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(s) != 2+8+ed25519.SignatureSize {
+		return errors.New("malformed signature")
+	}
+	if !bytes.Equal(s[2:10], key[2:10]) {
+		return errors.New("signed with a different key")
+	}
+
+	pub := ed25519.PublicKey(key[10:])
+	switch string(s[:2]) {
+	case "ED":
+		h := blake2b.Sum512(msg)
+		msg = h[:]
+	case "Ed":
+	default:
+		return errors.New("unsupported signature algorithm")
+	}
+	if !ed25519.Verify(pub, msg, s[10:]) {
+		return errors.New("invalid signature")
+	}
+
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return errors.New("malformed signature")
+	}
+	trusted := strings.TrimPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !ed25519.Verify(pub, append(s[10:], trusted...), global) {
+		return errors.New("invalid trusted comment signature")
+	}
+
+	return nil
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
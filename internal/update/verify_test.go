@@ -0,0 +1,149 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("flyctl")
+	sum := sha256.Sum256(data)
+	sums := []byte(fmt.Sprintf("%s  flyctl_Linux.tar.gz\n%s *flyctl_macOS.tar.gz\n", hex.EncodeToString(sum[:]), "00"))
+
+	assert.NoError(t, verifyChecksum(sums, "flyctl_Linux.tar.gz", data))
+	assert.EqualError(t, verifyChecksum(sums, "flyctl_macOS.tar.gz", data), "failed verifying flyctl_macOS.tar.gz: checksum mismatch")
+	assert.EqualError(t, verifyChecksum(sums, "flyctl_Windows.zip", data), "failed verifying flyctl_Windows.zip: no checksum was published for it")
+}
+
+// minisign signs msg the way minisign does, returning the public key and
+// signature files.
+func minisign(t *testing.T, msg []byte, prehash bool) (string, []byte) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyID := []byte("8bytesid")
+	key := append(append([]byte("Ed"), keyID...), pub...)
+
+	alg := "Ed"
+	if prehash {
+		alg = "ED"
+		h := blake2b.Sum512(msg)
+		msg = h[:]
+	}
+	sig := ed25519.Sign(priv, msg)
+	trusted := "timestamp:1700000000"
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), trusted...))
+
+	file := fmt.Sprintf("untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte(alg), keyID...), sig...)),
+		trusted,
+		base64.StdEncoding.EncodeToString(global))
+
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(key), []byte(file)
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	msg := []byte("checksums")
+
+	for _, prehash := range []bool{false, true} {
+		key, sig := minisign(t, msg, prehash)
+
+		assert.NoError(t, verifyMinisign(key, msg, sig))
+		assert.EqualError(t, verifyMinisign(key, []byte("tampered"), sig), "invalid signature")
+		assert.EqualError(t, verifyMinisign(key, msg, bytes.Replace(sig, []byte("1700000000"), []byte("1800000000"), 1)), "invalid trusted comment signature")
+
+		otherKey, _ := minisign(t, msg, prehash)
+		assert.EqualError(t, verifyMinisign(otherKey, msg, sig), "invalid signature")
+	}
+}
+
+func TestFetchChecksums(t *testing.T) {
+	sums := []byte("00  flyctl_0.2.1_Linux_x86_64.tar.gz\n")
+	key, sig := minisign(t, sums, true)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/releases/version/0.2.1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"code":200,"data":{"version":"0.2.1","checksums_url":"http://%[1]s/SHA256SUMS","signatures":[{"kind":"minisign","url":"http://%[1]s/SHA256SUMS.minisign"}]}}`, r.Host)
+	})
+	mux.HandleFunc("/api/releases/version/0.2.0", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"code":200,"data":{"version":"0.2.0","checksums_url":"","signatures":[]}}`)
+	})
+	mux.HandleFunc("/SHA256SUMS", func(w http.ResponseWriter, r *http.Request) { w.Write(sums) })
+	mux.HandleFunc("/SHA256SUMS.minisign", func(w http.ResponseWriter, r *http.Request) { w.Write(sig) })
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	defer func(url, key string) { pkgsURL, MinisignPublicKey = url, key }(pkgsURL, MinisignPublicKey)
+	pkgsURL = srv.URL + "/api"
+	MinisignPublicKey = key
+
+	ctx := context.Background()
+
+	release, err := fetchPkgsRelease(ctx, "0.2.1")
+	require.NoError(t, err)
+	got, err := fetchChecksums(ctx, release)
+	require.NoError(t, err)
+	assert.Equal(t, sums, got)
+
+	otherKey, _ := minisign(t, sums, true)
+	MinisignPublicKey = otherKey
+	_, err = fetchChecksums(ctx, release)
+	var verr *VerificationError
+	assert.ErrorAs(t, err, &verr)
+
+	// A signature that can't be checked fails verification
+	MinisignPublicKey = ""
+	_, err = fetchChecksums(ctx, release)
+	assert.ErrorAs(t, err, &verr)
+	assert.ErrorContains(t, err, "no key to verify the signature")
+
+	// Builds without a key only verify checksums of unsigned releases
+	release.Signatures = nil
+	got, err = fetchChecksums(ctx, release)
+	require.NoError(t, err)
+	assert.Equal(t, sums, got)
+
+	MinisignPublicKey = key
+	_, err = fetchChecksums(ctx, release)
+	assert.ErrorAs(t, err, &verr)
+
+	release, err = fetchPkgsRelease(ctx, "0.2.0")
+	require.NoError(t, err)
+	_, err = fetchChecksums(ctx, release)
+	assert.ErrorIs(t, err, errNoChecksums)
+
+	_, err = fetchPkgsRelease(ctx, "0.1.0")
+	assert.ErrorIs(t, err, errUnpublished)
+}
+
+func TestExtractBinary(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"LICENSE": "license", "flyctl": "binary"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	binary, err := extractBinary(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "binary", string(binary))
+}
@@ -0,0 +1,74 @@
+package wireguard
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	fly "github.com/superfly/fly-go"
+	"github.com/superfly/flyctl/internal/config"
+	"github.com/superfly/flyctl/internal/state"
+)
+
+// Peer expiries are recorded in the flyctl config file of the machine which
+// created the peers: the platform has no notion of them, so they're neither
+// enforced nor known anywhere else.
+
+// PeerExpiries returns when the peers of org created with a TTL on this
+// machine expire, keyed by peer name.
+func PeerExpiries(ctx context.Context, org *fly.Organization) (map[string]time.Time, error) {
+	expiries, err := config.ReadWireGuardExpiries(state.ConfigFile(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return expiries[org.Slug], nil
+}
+
+// SetPeerExpiry records that the peer name of org expires after ttl. A zero
+// ttl makes it permanent.
+func SetPeerExpiry(ctx context.Context, org *fly.Organization, name string, ttl time.Duration) error {
+	var expiry time.Time
+	if ttl > 0 {
+		expiry = time.Now().Add(ttl)
+	}
+	return SetPeerExpiryAt(ctx, org, name, expiry)
+}
+
+// SetPeerExpiryAt records that the peer name of org expires at expiry. A
+// zero expiry makes it permanent.
+func SetPeerExpiryAt(ctx context.Context, org *fly.Organization, name string, expiry time.Time) error {
+	return config.SetWireGuardExpiry(state.ConfigFile(ctx), org.Slug, name, expiry)
+}
+
+// ExpiredPeers returns the names of the peers whose expiry has passed at
+// now, and the names of the expiries of peers which don't exist anymore.
+func ExpiredPeers(expiries map[string]time.Time, peers []*fly.WireGuardPeer, now time.Time) (expired, gone []string) {
+	exists := make(map[string]bool, len(peers))
+	for _, peer := range peers {
+		exists[peer.Name] = true
+	}
+
+	for name, expiry := range expiries {
+		switch {
+		case !exists[name]:
+			gone = append(gone, name)
+		case !now.Before(expiry):
+			expired = append(expired, name)
+		}
+	}
+
+	sort.Strings(expired)
+	sort.Strings(gone)
+	return expired, gone
+}
+
+// ForgetPeerExpiries removes the recorded expiries of the peers names of
+// org.
+func ForgetPeerExpiries(ctx context.Context, org *fly.Organization, names []string) error {
+	for _, name := range names {
+		if err := SetPeerExpiryAt(ctx, org, name, time.Time{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package wireguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	fly "github.com/superfly/fly-go"
+)
+
+func TestExpiredPeers(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expiries := map[string]time.Time{
+		"laptop":  now.Add(-time.Hour),
+		"ci":      now,
+		"desktop": now.Add(time.Hour),
+		"old":     now.Add(-time.Hour),
+	}
+	peers := []*fly.WireGuardPeer{
+		{Name: "laptop"},
+		{Name: "ci"},
+		{Name: "desktop"},
+		{Name: "permanent"},
+	}
+
+	expired, gone := ExpiredPeers(expiries, peers, now)
+	assert.Equal(t, []string{"ci", "laptop"}, expired)
+	assert.Equal(t, []string{"old"}, gone)
+
+	expired, gone = ExpiredPeers(nil, peers, now)
+	assert.Empty(t, expired)
+	assert.Empty(t, gone)
+}
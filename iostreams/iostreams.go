@@ -35,6 +35,8 @@ type IOStreams struct {
 
 	progressIndicatorEnabled bool
 	progressIndicator        *spinner.Spinner
+	progress                 string
+	quiet                    bool
 
 	stdinTTYOverride  bool
 	stdinIsTTY        bool
@@ -128,6 +130,17 @@ func (s *IOStreams) SetStderrTTY(isTTY bool) {
 	s.stderrIsTTY = isTTY
 }
 
+// WithOutOnErrOut returns a copy of s whose Out writes to ErrOut, for
+// commands which keep stdout for what they print for scripts while the
+// steps they run report on both.
+func (s *IOStreams) WithOutOnErrOut() *IOStreams {
+	c := *s
+	c.Out = s.ErrOut
+	c.originalOut = s.ErrOut
+	c.SetStdoutTTY(s.IsStderrTTY())
+	return &c
+}
+
 func (s *IOStreams) IsStderrTTY() bool {
 	if s.stderrTTYOverride {
 		return s.stderrIsTTY
@@ -231,7 +244,8 @@ func (s *IOStreams) StartProgressIndicator() {
 }
 
 func (s *IOStreams) StartProgressIndicatorMsg(msg string) {
-	if !s.progressIndicatorEnabled {
+	s.emitProgress(msg, false)
+	if !s.progressIndicatorEnabled || s.ProgressMode() != ProgressAuto {
 		return
 	}
 	sp := spinner.New(spinner.CharSets[39], 250*time.Millisecond, spinner.WithWriter(s.ErrOut))
@@ -241,6 +255,7 @@ func (s *IOStreams) StartProgressIndicatorMsg(msg string) {
 }
 
 func (s *IOStreams) StopProgressIndicatorMsg(msg string) {
+	s.emitProgress(msg, true)
 	if s.progressIndicator == nil {
 		return
 	}
@@ -254,6 +269,7 @@ func (s *IOStreams) StopProgressIndicator() {
 }
 
 func (s *IOStreams) ChangeProgressIndicatorMsg(msg string) {
+	s.emitProgress(msg, false)
 	if s.progressIndicator == nil {
 		return
 	}
@@ -383,6 +399,7 @@ func System() *IOStreams {
 	if stdoutIsTTY && stderrIsTTY {
 		io.progressIndicatorEnabled = true
 	}
+	colorsEnabled.Store(io.colorEnabled)
 
 	// prevent duplicate isTerminal queries now that we know the answer
 	io.SetStdoutTTY(stdoutIsTTY)
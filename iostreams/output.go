@@ -0,0 +1,105 @@
+package iostreams
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+)
+
+// Progress modes accepted by --progress and FLY_PROGRESS.
+const (
+	// ProgressAuto animates progress on terminals and prints plain lines
+	// otherwise.
+	ProgressAuto = "auto"
+	// ProgressPlain never animates and prints one line per update.
+	ProgressPlain = "plain"
+	// ProgressJSON prints one JSON object per progress event to stderr.
+	ProgressJSON = "json"
+	// ProgressQuiet suppresses progress output altogether.
+	ProgressQuiet = "quiet"
+)
+
+// ProgressModes lists the supported progress modes.
+var ProgressModes = []string{ProgressAuto, ProgressPlain, ProgressJSON, ProgressQuiet}
+
+// colorsEnabled mirrors the color setting of the most recently configured
+// IOStreams so that package-level helpers such as Aurora honor NO_COLOR and
+// --no-color too.
+var colorsEnabled atomic.Bool
+
+// Aurora returns an aurora instance which only emits ANSI sequences when
+// colors are enabled.
+func Aurora() aurora.Aurora {
+	return aurora.NewAurora(colorsEnabled.Load())
+}
+
+// SetColorEnabled overrides whether color output is enabled.
+func (s *IOStreams) SetColorEnabled(enabled bool) {
+	s.colorEnabled = enabled
+	colorsEnabled.Store(enabled)
+}
+
+// SetQuiet sets whether informational output should be suppressed.
+func (s *IOStreams) SetQuiet(quiet bool) {
+	s.quiet = quiet
+}
+
+// IsQuiet reports whether informational output should be suppressed.
+func (s *IOStreams) IsQuiet() bool {
+	return s.quiet
+}
+
+// SetProgress sets the progress mode. See ProgressModes.
+func (s *IOStreams) SetProgress(mode string) error {
+	switch mode {
+	case "":
+		mode = ProgressAuto
+	case ProgressAuto, ProgressPlain, ProgressJSON, ProgressQuiet:
+	default:
+		return fmt.Errorf("invalid progress mode %q, must be one of %v", mode, ProgressModes)
+	}
+
+	s.progress = mode
+	return nil
+}
+
+// ProgressMode returns the effective progress mode. Quiet output implies
+// quiet progress, and auto resolves to plain when stderr isn't a terminal.
+func (s *IOStreams) ProgressMode() string {
+	switch {
+	case s.quiet:
+		return ProgressQuiet
+	case s.progress == "" || s.progress == ProgressAuto:
+		if s.IsStderrTTY() {
+			return ProgressAuto
+		}
+		return ProgressPlain
+	default:
+		return s.progress
+	}
+}
+
+type progressEvent struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message,omitempty"`
+	Done    bool      `json:"done,omitempty"`
+}
+
+// emitProgress writes a JSON progress event to stderr when in JSON
+// progress mode.
+func (s *IOStreams) emitProgress(msg string, done bool) {
+	if s.ProgressMode() != ProgressJSON {
+		return
+	}
+
+	_ = json.NewEncoder(s.ErrOut).Encode(progressEvent{
+		Type:    "progress",
+		Time:    time.Now().UTC(),
+		Message: msg,
+		Done:    done,
+	})
+}
@@ -0,0 +1,71 @@
+package iostreams
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressMode(t *testing.T) {
+	io, _, _, _ := Test()
+
+	io.SetStderrTTY(true)
+	assert.Equal(t, ProgressAuto, io.ProgressMode())
+
+	io.SetStderrTTY(false)
+	assert.Equal(t, ProgressPlain, io.ProgressMode())
+
+	require.NoError(t, io.SetProgress(ProgressJSON))
+	assert.Equal(t, ProgressJSON, io.ProgressMode())
+
+	io.SetQuiet(true)
+	assert.Equal(t, ProgressQuiet, io.ProgressMode())
+
+	assert.Error(t, io.SetProgress("fancy"))
+}
+
+func TestProgressIndicatorJSON(t *testing.T) {
+	io, _, _, errOut := Test()
+	require.NoError(t, io.SetProgress(ProgressJSON))
+
+	io.StartProgressIndicatorMsg("Fetching")
+	io.StopProgressIndicatorMsg("Fetched")
+
+	dec := json.NewDecoder(errOut)
+	for _, want := range []progressEvent{{Message: "Fetching"}, {Message: "Fetched", Done: true}} {
+		var got progressEvent
+		require.NoError(t, dec.Decode(&got))
+		assert.Equal(t, "progress", got.Type)
+		assert.Equal(t, want.Message, got.Message)
+		assert.Equal(t, want.Done, got.Done)
+	}
+}
+
+func TestSetColorEnabled(t *testing.T) {
+	io, _, _, _ := Test()
+
+	io.SetColorEnabled(true)
+	assert.Equal(t, "\x1b[1mhi\x1b[0m", Aurora().Bold("hi").String())
+
+	io.SetColorEnabled(false)
+	assert.False(t, io.ColorEnabled())
+	assert.Equal(t, "hi", Aurora().Bold("hi").String())
+}
+
+func TestWithOutOnErrOut(t *testing.T) {
+	ios, _, out, errOut := Test()
+	ios.SetStdoutTTY(false)
+	ios.SetStderrTTY(true)
+
+	progress := ios.WithOutOnErrOut()
+	assert.True(t, progress.IsStdoutTTY())
+	fmt.Fprintln(progress.Out, "building")
+	fmt.Fprintln(ios.Out, "registry.fly.io/app:deployment-1")
+
+	assert.Equal(t, "building\n", errOut.String())
+	assert.Equal(t, "registry.fly.io/app:deployment-1\n", out.String())
+	assert.False(t, ios.IsStdoutTTY())
+}
@@ -0,0 +1,66 @@
+package iostreams
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// prefixMu serializes the lines of every prefixed stream, so that the lines
+// of concurrent tasks don't interleave mid-line.
+var prefixMu sync.Mutex
+
+// WithPrefix returns a copy of s for a task running concurrently with
+// others, whose output lines start with prefix. Progress is plain and
+// prompts are disabled, since neither animations nor prompts can be shared
+// between tasks. JSON progress events are left unprefixed.
+func (s *IOStreams) WithPrefix(prefix string) *IOStreams {
+	c := *s
+	c.Out = &prefixWriter{w: s.Out, prefix: []byte(prefix)}
+	c.originalOut = c.Out
+	c.ErrOut = &prefixWriter{w: s.ErrOut, prefix: []byte(prefix)}
+	if s.ProgressMode() == ProgressJSON {
+		c.ErrOut = &prefixWriter{w: s.ErrOut}
+	}
+
+	if c.progress == "" || c.progress == ProgressAuto {
+		c.progress = ProgressPlain
+	}
+	c.SetStdoutTTY(false)
+	c.SetStderrTTY(false)
+	c.progressIndicatorEnabled = false
+	c.progressIndicator = nil
+	c.neverPrompt = true
+	return &c
+}
+
+// prefixWriter writes whole lines to w, each starting with prefix. A
+// trailing partial line is held until it's completed.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	prefixMu.Lock()
+	defer prefixMu.Unlock()
+
+	p.buf = append(p.buf, b...)
+	var out []byte
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		out = append(out, p.prefix...)
+		out = append(out, p.buf[:i+1]...)
+		p.buf = p.buf[i+1:]
+	}
+	if len(out) > 0 {
+		if _, err := p.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
@@ -0,0 +1,64 @@
+package iostreams
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPrefix(t *testing.T) {
+	ios, _, out, errOut := Test()
+	ios.SetStdoutTTY(true)
+	ios.SetStderrTTY(true)
+
+	web := ios.WithPrefix("[web] ")
+	assert.Equal(t, ProgressPlain, web.ProgressMode())
+	assert.False(t, web.IsStderrTTY())
+	assert.False(t, web.CanPrompt())
+
+	fmt.Fprint(web.Out, "building")
+	assert.Empty(t, out.String(), "partial lines are held")
+	fmt.Fprint(web.Out, " image\ndone\n")
+	fmt.Fprintln(web.ErrOut, "step 1")
+	assert.Equal(t, "[web] building image\n[web] done\n", out.String())
+	assert.Equal(t, "[web] step 1\n", errOut.String())
+
+	// The parent streams are left as they are.
+	assert.True(t, ios.IsStderrTTY())
+}
+
+func TestWithPrefixConcurrentLines(t *testing.T) {
+	ios, _, out, _ := Test()
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"web", "worker"} {
+		s := ios.WithPrefix("[" + name + "] ")
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				fmt.Fprint(s.Out, "line ")
+				fmt.Fprintf(s.Out, "%d\n", i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 200)
+	for _, line := range lines {
+		assert.Regexp(t, `^\[(web|worker)\] line \d+$`, line)
+	}
+}
+
+func TestWithPrefixJSONProgress(t *testing.T) {
+	ios, _, _, errOut := Test()
+	assert.NoError(t, ios.SetProgress(ProgressJSON))
+
+	s := ios.WithPrefix("[web] ")
+	fmt.Fprintln(s.ErrOut, `{"type":"progress"}`)
+	assert.Equal(t, "{\"type\":\"progress\"}\n", errOut.String())
+}
@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var bunVersionRegex = regexp.MustCompile(`(\d+(?:\.\d+){0,2})`)
+
+// configureBun handles Bun apps that configureJsFramework can't, usually
+// because Bun isn't installed locally to generate a Dockerfile.
+func configureBun(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
+	if !checksPass(sourceDir, fileExists("package.json")) {
+		return nil, nil
+	}
+	if !checksPass(sourceDir,
+		fileExists("bun.lockb", "bun.lock", "bunfig.toml"),
+		dirContains("package.json", `"(@types/bun|bun-types)"`),
+	) {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(sourceDir, "package.json"))
+	if err != nil {
+		return nil, nil
+	}
+	var pkg struct {
+		Main           string            `json:"main"`
+		Module         string            `json:"module"`
+		Scripts        map[string]string `json:"scripts"`
+		PackageManager string            `json:"packageManager"`
+		Engines        map[string]string `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, nil
+	}
+
+	var cmd []string
+	switch {
+	case pkg.Scripts["start"] != "":
+		cmd = []string{"bun", "run", "start"}
+	case pkg.Module != "":
+		cmd = []string{"bun", pkg.Module}
+	case pkg.Main != "":
+		cmd = []string{"bun", pkg.Main}
+	case absFileExists(filepath.Join(sourceDir, "index.ts")):
+		cmd = []string{"bun", "index.ts"}
+	default:
+		return nil, nil
+	}
+
+	version := "1"
+	if v, ok := strings.CutPrefix(pkg.PackageManager, "bun@"); ok && bunVersionRegex.MatchString(v) {
+		version = bunVersionRegex.FindString(v)
+	} else if m := bunVersionRegex.FindString(pkg.Engines["bun"]); m != "" {
+		version = m
+	}
+
+	lockfile := ""
+	for _, name := range []string{"bun.lockb", "bun.lock"} {
+		if absFileExists(filepath.Join(sourceDir, name)) {
+			lockfile = name + " "
+			break
+		}
+	}
+
+	quoted := make([]string, len(cmd))
+	for i, arg := range cmd {
+		quoted[i] = fmt.Sprintf("%q", arg)
+	}
+
+	s := &SourceInfo{
+		Family: "Bun",
+		Port:   3000,
+		Env: map[string]string{
+			"PORT": "3000",
+		},
+		SkipDatabase: true,
+	}
+
+	vars := map[string]interface{}{
+		"bunVersion": version,
+		"lockfile":   lockfile,
+		"cmd":        strings.Join(quoted, ", "),
+	}
+	s.Files = templatesExecute("templates/bun", vars)
+
+	return s, nil
+}
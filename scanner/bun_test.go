@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBunScanner(t *testing.T) {
+	dir := t.TempDir()
+	pkg := `{"name": "app", "packageManager": "bun@1.1.8", "scripts": {"start": "bun run server.ts"}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(pkg), 0o644))
+
+	// Plain Node apps are left to other scanners
+	si, err := configureBun(dir, &ScannerConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, si)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bun.lockb"), nil, 0o644))
+
+	si, err = configureBun(dir, &ScannerConfig{})
+	require.NoError(t, err)
+	require.NotNil(t, si)
+	assert.Equal(t, "Bun", si.Family)
+	assert.Equal(t, 3000, si.Port)
+
+	dockerfile, ok := lo.Find(si.Files, func(f SourceFile) bool { return f.Path == "Dockerfile" })
+	require.True(t, ok)
+	assert.Contains(t, string(dockerfile.Contents), "ARG BUN_VERSION=1.1.8")
+	assert.Contains(t, string(dockerfile.Contents), "COPY bun.lockb package.json ./")
+	assert.Contains(t, string(dockerfile.Contents), `CMD ["bun", "run", "start"]`)
+}
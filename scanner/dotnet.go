@@ -37,8 +37,9 @@ func configureDotnet(sourceDir string, config *ScannerConfig) (*SourceInfo, erro
 	}
 
 	s := &SourceInfo{
-		Family: ".NET",
-		Port:   8080,
+		Family:        ".NET",
+		Port:          8080,
+		HttpCheckPath: findDotnetHealthCheckPath(filepath.Dir(csprojPath)),
 	}
 
 	vars := make(map[string]interface{})
@@ -88,3 +89,21 @@ func extractDotnetTargetFramework(filePath string) (string, error) {
 
 	return "", fmt.Errorf("failed to extract .NET version")
 }
+
+var dotnetHealthCheckRegex = regexp.MustCompile(`MapHealthChecks\(\s*"([^"]+)"`)
+
+// findDotnetHealthCheckPath returns the path of the ASP.NET Core health check
+// endpoint mapped in the project, if any.
+func findDotnetHealthCheckPath(projectDir string) string {
+	filenames, _ := filepath.Glob(filepath.Join(projectDir, "*.cs"))
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			continue
+		}
+		if m := dotnetHealthCheckRegex.FindSubmatch(content); m != nil {
+			return string(m[1])
+		}
+	}
+	return ""
+}
@@ -119,10 +119,12 @@ func Scan(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
 		configureDeno,
 		configureNuxt,
 		configureNextJs,
+		configureBun,
 		configureNode,
 		configureStatic,
 		configureDotnet,
 		configureRust,
+		configureSpringBoot,
 	}
 
 	for _, scanner := range scanners {
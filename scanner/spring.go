@@ -0,0 +1,61 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/samber/lo"
+)
+
+var (
+	mavenJavaVersionRegex  = regexp.MustCompile(`<(?:java\.version|maven\.compiler\.release)>\s*(\d+)\s*</`)
+	gradleJavaVersionRegex = regexp.MustCompile(`(?:languageVersion\s*=\s*JavaLanguageVersion\.of\(|sourceCompatibility\s*=\s*(?:JavaVersion\.VERSION_|['"]))(\d+)`)
+)
+
+func configureSpringBoot(sourceDir string, config *ScannerConfig) (*SourceInfo, error) {
+	maven := checksPass(sourceDir, dirContains("pom.xml", "spring-boot"))
+	gradle := checksPass(sourceDir, dirContains("build.gradle*", "org\\.springframework\\.boot"))
+	if !maven && !gradle {
+		return nil, nil
+	}
+
+	s := &SourceInfo{
+		Family: "Spring Boot",
+		Port:   8080,
+		Env: map[string]string{
+			"SERVER_PORT": "8080",
+		},
+	}
+
+	buildFile := "pom.xml"
+	versionRegex := mavenJavaVersionRegex
+	if !maven {
+		buildFile = "build.gradle"
+		if !absFileExists(filepath.Join(sourceDir, buildFile)) {
+			buildFile = "build.gradle.kts"
+		}
+		versionRegex = gradleJavaVersionRegex
+	}
+
+	javaVersion := "17"
+	if data, err := os.ReadFile(filepath.Join(sourceDir, buildFile)); err == nil {
+		if m := versionRegex.FindSubmatch(data); m != nil {
+			javaVersion = string(m[1])
+		}
+	}
+
+	// The actuator exposes a health endpoint Fly can check
+	if checksPass(sourceDir, dirContains(buildFile, "spring-boot-starter-actuator")) {
+		s.HttpCheckPath = "/actuator/health"
+	}
+
+	vars := map[string]interface{}{
+		"javaVersion": javaVersion,
+		"maven":       maven,
+		"wrapper":     checksPass(sourceDir, fileExists(lo.Ternary(maven, "mvnw", "gradlew"))),
+	}
+	s.Files = templatesExecute("templates/spring", vars)
+
+	return s, nil
+}
@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpringBootScanner(t *testing.T) {
+	t.Run("maven with actuator", func(t *testing.T) {
+		dir := t.TempDir()
+		pom := `<project>
+  <parent><artifactId>spring-boot-starter-parent</artifactId></parent>
+  <properties><java.version>21</java.version></properties>
+  <dependencies>
+    <dependency><artifactId>spring-boot-starter-actuator</artifactId></dependency>
+  </dependencies>
+</project>`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml"), []byte(pom), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "mvnw"), []byte("#!/bin/sh"), 0o755))
+
+		si, err := configureSpringBoot(dir, &ScannerConfig{})
+		require.NoError(t, err)
+		require.NotNil(t, si)
+		assert.Equal(t, "Spring Boot", si.Family)
+		assert.Equal(t, 8080, si.Port)
+		assert.Equal(t, "/actuator/health", si.HttpCheckPath)
+
+		dockerfile, ok := lo.Find(si.Files, func(f SourceFile) bool { return f.Path == "Dockerfile" })
+		require.True(t, ok)
+		assert.Contains(t, string(dockerfile.Contents), "ARG JAVA_VERSION=21")
+		assert.Contains(t, string(dockerfile.Contents), "./mvnw -B package")
+	})
+
+	t.Run("gradle kotlin dsl", func(t *testing.T) {
+		dir := t.TempDir()
+		gradle := `plugins { id("org.springframework.boot") version "3.2.0" }
+java { toolchain { languageVersion = JavaLanguageVersion.of(17) } }`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "build.gradle.kts"), []byte(gradle), 0o644))
+
+		si, err := configureSpringBoot(dir, &ScannerConfig{})
+		require.NoError(t, err)
+		require.NotNil(t, si)
+		assert.Empty(t, si.HttpCheckPath)
+
+		dockerfile, ok := lo.Find(si.Files, func(f SourceFile) bool { return f.Path == "Dockerfile" })
+		require.True(t, ok)
+		assert.Contains(t, string(dockerfile.Contents), "ARG JAVA_VERSION=17")
+		assert.Contains(t, string(dockerfile.Contents), "gradle bootJar")
+	})
+
+	t.Run("not spring", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project></project>"), 0o644))
+
+		si, err := configureSpringBoot(dir, &ScannerConfig{})
+		require.NoError(t, err)
+		assert.Nil(t, si)
+	})
+}
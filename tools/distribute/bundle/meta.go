@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -68,6 +69,7 @@ type Asset struct {
 	OS           string `json:"os"`
 	Arch         string `json:"arch"`
 	SHA256       string `json:"sha256"`
+	Size         int64  `json:"size"`
 	ContentType  string `json:"content_type"`
 	AbsolutePath string `json:"-"`
 }
@@ -99,6 +101,11 @@ func assetsFromGoReleaserDist(distDir string) ([]Asset, error) {
 					return errors.Wrapf(err, "hashing %q", fullPath)
 				}
 
+				info, err := os.Stat(fullPath)
+				if err != nil {
+					return err
+				}
+
 				a := Asset{
 					Name:         artifact.Name,
 					Path:         artifact.Name,
@@ -106,6 +113,7 @@ func assetsFromGoReleaserDist(distDir string) ([]Asset, error) {
 					OS:           artifact.Goos,
 					Arch:         artifact.Goarch,
 					SHA256:       sha,
+					Size:         info.Size(),
 				}
 
 				if artifact.Extra.Format == "tar.gz" {
@@ -158,3 +166,14 @@ func sha256File(path string) (string, error) {
 
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
+
+// Checksums returns the SHA256SUMS file of the assets of m, in the format
+// sha256sum and goreleaser write.
+func (m Meta) Checksums() []byte {
+	lines := make([]string, 0, len(m.Assets))
+	for _, asset := range m.Assets {
+		lines = append(lines, fmt.Sprintf("%s  %s\n", asset.SHA256, asset.Name))
+	}
+	sort.Strings(lines)
+	return []byte(strings.Join(lines, ""))
+}
@@ -40,6 +40,11 @@ func (c *Client) URL(path string, params ...any) string {
 }
 
 func (c *Client) sendRequest(ctx context.Context, req *http.Request, v interface{}) error {
+	return c.send(ctx, c.HTTPClient, req, v)
+}
+
+// send is sendRequest with client.
+func (c *Client) send(ctx context.Context, client *http.Client, req *http.Request, v interface{}) error {
 	req = req.WithContext(ctx)
 
 	if len(req.Header.Values("Content-Type")) == 0 {
@@ -49,7 +54,7 @@ func (c *Client) sendRequest(ctx context.Context, req *http.Request, v interface
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
 
-	res, err := c.HTTPClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
 		return err
 	}
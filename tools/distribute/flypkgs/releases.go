@@ -3,9 +3,11 @@ package flypkgs
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
+	"slices"
 
 	"github.com/superfly/flyctl/internal/version"
 )
@@ -24,29 +26,38 @@ func (c *Client) GetReleaseByVersion(ctx context.Context, v version.Version) (*R
 	return &res, nil
 }
 
-func (c *Client) UploadRelease(ctx context.Context, v version.Version, r io.Reader) (*Release, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// UploadRelease uploads the release bundle of version v, read from r.
+// progress, when set, is called as the bundle is sent.
+func (c *Client) UploadRelease(ctx context.Context, v version.Version, r io.ReadSeeker, progress Progress) (*Release, error) {
+	return c.uploadFile(ctx, c.URL("/releases/%s", v.String()), "release.tar.gz", r, progress)
+}
 
-	part, err := writer.CreateFormFile("file", "release.tar.gz")
-	if err != nil {
-		return nil, err
-	}
-	_, err = io.Copy(part, r)
-	if err != nil {
-		return nil, err
-	}
+// UploadChecksums uploads the SHA256SUMS file listing the checksums of the
+// artifacts of the release of version v.
+func (c *Client) UploadChecksums(ctx context.Context, v version.Version, r io.ReadSeeker) (*Release, error) {
+	return c.uploadFile(ctx, c.URL("/releases/%s/checksums", v.String()), "SHA256SUMS", r, nil)
+}
 
-	err = writer.Close()
-	if err != nil {
-		return nil, err
+// UploadSignature uploads a signature of the SHA256SUMS file of the release
+// of version v. kind is the tool which made it, see SignatureKinds.
+func (c *Client) UploadSignature(ctx context.Context, v version.Version, kind string, r io.ReadSeeker) (*Release, error) {
+	if !slices.Contains(SignatureKinds, kind) {
+		return nil, fmt.Errorf("unsupported signature kind %q, must be one of %v", kind, SignatureKinds)
 	}
+	return c.uploadFile(ctx, c.URL("/releases/%s/signatures/%s", v.String(), kind), "SHA256SUMS."+kind, r, nil)
+}
 
-	req, err := http.NewRequest("POST", c.URL("/releases/%s", v.String()), body)
+// AttachReleaseNotes uploads the markdown release notes of the release of
+// version v, replacing any attached before.
+func (c *Client) AttachReleaseNotes(ctx context.Context, v version.Version, r io.ReadSeeker) (*Release, error) {
+	return c.uploadFile(ctx, c.URL("/releases/%s/notes", v.String()), "NOTES.md", r, nil)
+}
+
+func (c *Client) PublishRelease(ctx context.Context, v version.Version) (*Release, error) {
+	req, err := http.NewRequest("POST", c.URL("/releases/%s/publish", v.String()), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 
 	var out Release
@@ -57,11 +68,35 @@ func (c *Client) UploadRelease(ctx context.Context, v version.Version, r io.Read
 	return &out, nil
 }
 
-func (c *Client) PublishRelease(ctx context.Context, v version.Version) (*Release, error) {
-	req, err := http.NewRequest("POST", c.URL("/releases/%s/publish", v.String()), nil)
+// PromoteRelease moves the release of version v to channel, such as from
+// "pre" to "stable", reusing the artifacts that were already uploaded.
+func (c *Client) PromoteRelease(ctx context.Context, v version.Version, channel string) (*Release, error) {
+	return c.postJSON(ctx, c.URL("/releases/%s/promote", v.String()), map[string]string{"channel": channel})
+}
+
+// YankRelease pulls the release of version v from its channel, so that
+// flyctl stops updating to it. reason is shown to users pinned to it.
+func (c *Client) YankRelease(ctx context.Context, v version.Version, reason string) (*Release, error) {
+	return c.postJSON(ctx, c.URL("/releases/%s/yank", v.String()), map[string]string{"reason": reason})
+}
+
+// SetChannelVersion makes the release of version v the latest of channel,
+// such as to roll it back to the release before a yanked one.
+func (c *Client) SetChannelVersion(ctx context.Context, channel string, v version.Version) (*Release, error) {
+	return c.postJSON(ctx, c.URL("/channels/%s/version", channel), map[string]string{"version": v.String()})
+}
+
+func (c *Client) postJSON(ctx context.Context, url string, in any) (*Release, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json; charset=utf-8")
 
 	var out Release
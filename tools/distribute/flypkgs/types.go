@@ -20,6 +20,20 @@ type Release struct {
 	UpdatedAt      time.Time       `json:"updated_at"`
 	PublishedAt    time.Time       `json:"published_at"`
 	Assets         []Asset         `json:"assets"`
+	ChecksumsURL   string          `json:"checksums_url"`
+	Signatures     []Signature     `json:"signatures"`
+	Notes          string          `json:"notes"`
+	Yanked         bool            `json:"yanked"`
+	YankReason     string          `json:"yank_reason"`
+}
+
+// SignatureKinds lists the tools release checksums may be signed with.
+var SignatureKinds = []string{"cosign", "minisign"}
+
+// Signature is a signature of the SHA256SUMS file of a release.
+type Signature struct {
+	Kind string `json:"kind"`
+	URL  string `json:"url"`
 }
 
 type Channel struct {
@@ -39,6 +53,7 @@ type Asset struct {
 	OS          string `json:"os"`
 	Arch        string `json:"arch"`
 	ContentType string `json:"content_type"`
+	URL         string `json:"url"`
 	InsertedAt  string `json:"inserted_at"`
 	UpdatedAt   string `json:"updated_at"`
 }
@@ -0,0 +1,125 @@
+package flypkgs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/jpillora/backoff"
+)
+
+// uploadAttempts is how many times an upload is sent before giving up on it.
+const uploadAttempts = 5
+
+// uploadRetryDelay is how long the first retry of an upload waits, doubling
+// with each retry after it.
+var uploadRetryDelay = 2 * time.Second
+
+// Progress is called as an upload is sent with the number of bytes of the
+// file sent so far and its size. sent goes back to 0 when the upload is
+// retried.
+type Progress func(sent, total int64)
+
+// uploadFile uploads the file read from r as name to url, in a multipart
+// form. The file is streamed from r rather than buffered, and the upload is
+// retried from the start, reading r again, when it fails on a network error
+// or a server error: the packages API takes a file in a single request, so
+// uploads can't resume midway.
+func (c *Client) uploadFile(ctx context.Context, url, name string, r io.ReadSeeker, progress Progress) (*Release, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// The multipart envelope of the file is written once, so that each
+	// attempt only has to stream the file itself.
+	var envelope bytes.Buffer
+	writer := multipart.NewWriter(&envelope)
+	if _, err := writer.CreateFormFile("file", name); err != nil {
+		return nil, err
+	}
+	headLen := envelope.Len()
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	head, tail := envelope.Bytes()[:headLen], envelope.Bytes()[headLen:]
+
+	b := &backoff.Backoff{
+		Min:    uploadRetryDelay,
+		Max:    time.Minute,
+		Factor: 2,
+	}
+	for attempt := 1; ; attempt++ {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		body := io.MultiReader(bytes.NewReader(head), &progressReader{r: r, total: size, progress: progress}, bytes.NewReader(tail))
+		req, err := http.NewRequest("POST", url, body)
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = int64(len(head)) + size + int64(len(tail))
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Accept", "application/json; charset=utf-8")
+
+		var out Release
+		err = c.send(ctx, c.uploadClient(), req, &out)
+		switch {
+		case err == nil:
+			return &out, nil
+		case attempt == uploadAttempts || !retryableUpload(ctx, err):
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(b.Duration()):
+		}
+	}
+}
+
+// uploadClient returns the HTTP client uploads are sent with. Uploads take as
+// long as the file takes to send, so the context of the upload bounds them
+// rather than the timeout of the client.
+func (c *Client) uploadClient() *http.Client {
+	client := *c.HTTPClient
+	client.Timeout = 0
+	return &client
+}
+
+// retryableUpload reports whether an upload which failed with err is worth
+// sending again: it failed on the way, or the server failed handling it.
+func retryableUpload(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var errRes ErrorResponse
+	if errors.As(err, &errRes) {
+		return errRes.Code == http.StatusTooManyRequests || errRes.Code >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// progressReader reports the bytes read from r to progress.
+type progressReader struct {
+	r        io.Reader
+	sent     int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.sent += int64(n)
+	if p.progress != nil && n > 0 {
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,98 @@
+package flypkgs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/superfly/flyctl/internal/version"
+)
+
+func TestUploadReleaseRetries(t *testing.T) {
+	uploadRetryDelay = time.Millisecond
+
+	var (
+		attempts int
+		files    []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		assert.Equal(t, "/releases/1.2.3", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Positive(t, r.ContentLength)
+
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		files = append(files, string(data))
+
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"error": "bad gateway"}`))
+			return
+		}
+		w.Write([]byte(`{"code": 200, "data": {"version": "1.2.3", "status": "draft"}}`))
+	}))
+	defer srv.Close()
+
+	var sent []int64
+	client := NewClient(srv.URL, "token")
+	release, err := client.UploadRelease(context.Background(), v123(t), strings.NewReader("bundle"), func(n, total int64) {
+		assert.Equal(t, int64(6), total)
+		sent = append(sent, n)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "draft", release.Status)
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{"bundle", "bundle"}, files, "each attempt sends the whole file")
+	assert.Equal(t, []int64{6, 6}, sent)
+}
+
+func TestUploadReleaseDoesNotRetryClientErrors(t *testing.T) {
+	uploadRetryDelay = time.Millisecond
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"errors": ["release exists"]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "token")
+	_, err := client.UploadRelease(context.Background(), v123(t), strings.NewReader("bundle"), nil)
+	assert.True(t, IsConflictError(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestUploadReleaseGivesUp(t *testing.T) {
+	uploadRetryDelay = time.Millisecond
+
+	var attempts int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "token")
+	_, err := client.UploadRelease(context.Background(), v123(t), strings.NewReader("bundle"), nil)
+	assert.ErrorContains(t, err, "API error: 503")
+	assert.Equal(t, uploadAttempts, attempts)
+}
+
+func v123(t *testing.T) version.Version {
+	v, err := version.Parse("1.2.3")
+	require.NoError(t, err)
+	return v
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 	"github.com/superfly/flyctl/internal/version"
 	"github.com/superfly/flyctl/tools/distribute/bundle"
@@ -32,6 +35,9 @@ func main() {
 		RunE:         runUpload,
 	}
 	uploadCmd.Args = cobra.MaximumNArgs(1)
+	uploadCmd.Flags().String("cosign-signature", "checksums.txt.sig", "path, relative to the dist dir, of the cosign signature of checksums.txt. Skipped when missing")
+	uploadCmd.Flags().String("notes", "release-notes.md", "path, relative to the dist dir, of the markdown release notes. Skipped when missing")
+	uploadCmd.Flags().String("minisign-signature", "checksums.txt.minisig", "path, relative to the dist dir, of the minisign signature of checksums.txt. Skipped when missing")
 
 	publishCmd := &cobra.Command{
 		Use:          "publish <version>",
@@ -40,8 +46,27 @@ func main() {
 		RunE:         runPublish,
 	}
 	publishCmd.Args = cobra.ExactArgs(1)
+	publishCmd.Flags().String("channel", "", "promote the version to this channel, such as stable, before publishing it. The uploaded artifacts are reused")
 
-	rootCmd.AddCommand(uploadCmd, publishCmd)
+	yankCmd := &cobra.Command{
+		Use:          "yank <version>",
+		Short:        "pull a broken version from its channel so flyctl stops updating to it",
+		SilenceUsage: true,
+		RunE:         runYank,
+	}
+	yankCmd.Args = cobra.ExactArgs(1)
+	yankCmd.Flags().String("reason", "", "why the version was yanked, shown to users pinned to it")
+	yankCmd.Flags().String("rollback-to", "", "make this version the latest of the yanked version's channel")
+
+	setChannelCmd := &cobra.Command{
+		Use:          "set-channel <channel> <version>",
+		Short:        "make a published version the latest of a channel",
+		SilenceUsage: true,
+		RunE:         runSetChannel,
+	}
+	setChannelCmd.Args = cobra.ExactArgs(2)
+
+	rootCmd.AddCommand(uploadCmd, publishCmd, yankCmd, setChannelCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -115,7 +140,7 @@ func runUpload(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("Uploading release bundle")
 
-	release, err := client.UploadRelease(ctx, *meta.Release.Version, outFile)
+	release, err := client.UploadRelease(ctx, *meta.Release.Version, outFile, (&uploadProgress{}).print)
 	if err != nil {
 		return err
 	}
@@ -124,17 +149,107 @@ func runUpload(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Status: %s\n", release.Status)
 	fmt.Printf("  Channel: %s (status:%s, stable:%t)\n", release.Channel.Name, release.Channel.Status, release.Channel.Stable)
 
+	if err := uploadChecksums(cmd, client, meta, distDir); err != nil {
+		return err
+	}
+
+	return uploadNotes(cmd, client, meta, distDir)
+}
+
+// uploadProgress prints the progress of an upload every 10%, and when it
+// restarts.
+type uploadProgress struct {
+	step int64
+}
+
+func (p *uploadProgress) print(sent, total int64) {
+	if total == 0 {
+		return
+	}
+	step := sent * 10 / total
+	switch {
+	case step < p.step:
+		fmt.Println("  Upload failed, retrying")
+	case step == p.step:
+		return
+	}
+	p.step = step
+	fmt.Printf("  %s of %s (%d%%)\n", humanize.IBytes(uint64(sent)), humanize.IBytes(uint64(total)), step*10)
+}
+
+// uploadChecksums uploads goreleaser's checksums.txt, or checksums of the
+// assets when it's missing, as the release's SHA256SUMS, followed by the
+// signatures of it which were made.
+func uploadChecksums(cmd *cobra.Command, client *flypkgs.Client, meta bundle.Meta, distDir string) error {
+	ctx := cmd.Context()
+	v := *meta.Release.Version
+
+	sums, err := os.ReadFile(filepath.Join(distDir, "checksums.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		sums = meta.Checksums()
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Println("Uploading SHA256SUMS")
+	if _, err := client.UploadChecksums(ctx, v, bytes.NewReader(sums)); err != nil {
+		return err
+	}
+
+	for _, kind := range flypkgs.SignatureKinds {
+		name, _ := cmd.Flags().GetString(kind + "-signature")
+		sig, err := os.ReadFile(filepath.Join(distDir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("No %s signature at %s, skipping\n", kind, name)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		fmt.Printf("Uploading %s signature\n", kind)
+		if _, err := client.UploadSignature(ctx, v, kind, bytes.NewReader(sig)); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// uploadNotes attaches the release notes in distDir, when there are any, to
+// the release.
+func uploadNotes(cmd *cobra.Command, client *flypkgs.Client, meta bundle.Meta, distDir string) error {
+	name, _ := cmd.Flags().GetString("notes")
+	notes, err := os.ReadFile(filepath.Join(distDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("No release notes at %s, skipping\n", name)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Println("Attaching release notes")
+	_, err = client.AttachReleaseNotes(cmd.Context(), *meta.Release.Version, bytes.NewReader(notes))
+	return err
+}
+
 func runPublish(cmd *cobra.Command, args []string) error {
 	version, err := version.Parse(args[0])
 	if err != nil {
 		return err
 	}
 
+	ctx := cmd.Context()
 	client := flypkgs.NewClient(apiEndpoint, apiToken)
-	release, err := client.PublishRelease(cmd.Context(), version)
+
+	if channel, _ := cmd.Flags().GetString("channel"); channel != "" {
+		release, err := client.PromoteRelease(ctx, version, channel)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Promoted release", release.Version, "to", release.Channel.Name)
+	}
+
+	release, err := client.PublishRelease(ctx, version)
 	if err != nil {
 		return err
 	}
@@ -143,6 +258,53 @@ func runPublish(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runYank(cmd *cobra.Command, args []string) error {
+	v, err := version.Parse(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	client := flypkgs.NewClient(apiEndpoint, apiToken)
+
+	reason, _ := cmd.Flags().GetString("reason")
+	release, err := client.YankRelease(ctx, v, reason)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Yanked release", release.Version, "from", release.Channel.Name)
+
+	rollbackTo, _ := cmd.Flags().GetString("rollback-to")
+	if rollbackTo == "" {
+		return nil
+	}
+
+	previous, err := version.Parse(rollbackTo)
+	if err != nil {
+		return err
+	}
+	return setChannelVersion(cmd, client, release.Channel.Name, previous)
+}
+
+func runSetChannel(cmd *cobra.Command, args []string) error {
+	v, err := version.Parse(args[1])
+	if err != nil {
+		return err
+	}
+
+	return setChannelVersion(cmd, flypkgs.NewClient(apiEndpoint, apiToken), args[0], v)
+}
+
+func setChannelVersion(cmd *cobra.Command, client *flypkgs.Client, channel string, v version.Version) error {
+	release, err := client.SetChannelVersion(cmd.Context(), channel, v)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Channel", channel, "now points at release", release.Version)
+	return nil
+}
+
 func checkExistingRelease(ctx context.Context, client *flypkgs.Client, v version.Version) error {
 	release, err := client.GetReleaseByVersion(ctx, v)
 	if flypkgs.IsNotFoundErr(err) {